@@ -6,21 +6,47 @@ import (
 	"log"
 	"os"
 	"strconv"
+	"strings"
 
 	_ "github.com/joho/godotenv/autoload"
 
+	"github.com/edwintantawi/taskit/pkg/mailer"
+	"github.com/edwintantawi/taskit/pkg/oidc"
 	"github.com/edwintantawi/taskit/pkg/postgres"
+	"github.com/edwintantawi/taskit/pkg/security"
 )
 
 type Config struct {
-	Port                   string
-	AllowedOrigin          string
-	AccessTokenKey         string
-	RefreshTokenKey        string
-	AccessTokenExpiration  int
-	RefreshTokenExpiration int
-	AutoMigrate            bool
-	Postgres               postgres.Config
+	Port                            string
+	AllowedOrigin                   string
+	AccessTokenKey                  string
+	RefreshTokenKey                 string
+	JWTIssuer                       string
+	JWTAudience                     string
+	AccessTokenSigningKeyFile       string
+	AccessTokenVerificationKeyFiles []string
+	RefreshTokenPepper              string
+	TOTPIssuer                      string
+	Argon2                          security.Argon2Config
+	PasswordPolicy                  security.PasswordPolicyConfig
+	AccessTokenExpiration           int
+	RefreshTokenExpiration          int
+	AutoMigrate                     bool
+	AppURL                          string
+	CookieDomain                    string
+	CookieSameSite                  string
+	AdminEmail                      string
+	AdminPassword                   string
+	RevocationCacheSize             int
+	RevocationCacheTTL              int
+	SecurityEventRetention          int
+	MaxSessionsPerUser              int
+	JanitorBatchSize                int
+	ServiceAccountKeyOverlap        int
+	ServiceAccountRateLimit         int
+	Postgres                        postgres.Config
+	Mailer                          mailer.Config
+	OIDCProviders                   []oidc.Config
 }
 
 func New() Config {
@@ -30,9 +56,33 @@ func New() Config {
 	allowedOriginEnv := os.Getenv("ALLOWED_ORIGIN")
 	accessTokenKeyEnv := os.Getenv("ACCESS_TOKEN_KEY")
 	refreshTokenKeyEnv := os.Getenv("REFRESH_TOKEN_KEY")
+	jwtIssuerEnv := os.Getenv("JWT_ISSUER")
+	jwtAudienceEnv := os.Getenv("JWT_AUDIENCE")
+	accessTokenSigningKeyEnv := os.Getenv("ACCESS_TOKEN_SIGNING_KEY_FILE")
+	accessTokenVerifyKeysEnv := os.Getenv("ACCESS_TOKEN_VERIFICATION_KEY_FILES")
+	refreshTokenPepperEnv := os.Getenv("REFRESH_TOKEN_PEPPER")
+	totpIssuerEnv := os.Getenv("TOTP_ISSUER")
+	argon2MemoryEnv, _ := strconv.ParseUint(os.Getenv("ARGON2_MEMORY"), 10, 32)
+	argon2IterationsEnv, _ := strconv.ParseUint(os.Getenv("ARGON2_ITERATIONS"), 10, 32)
+	argon2ParallelismEnv, _ := strconv.ParseUint(os.Getenv("ARGON2_PARALLELISM"), 10, 8)
+	passwordMinLengthEnv, _ := strconv.Atoi(os.Getenv("PASSWORD_MIN_LENGTH"))
+	passwordMaxLengthEnv, _ := strconv.Atoi(os.Getenv("PASSWORD_MAX_LENGTH"))
+	passwordBreachedCorpusDirEnv := os.Getenv("PASSWORD_BREACHED_CORPUS_DIR")
 	accessTokenExpirationEnv, _ := strconv.Atoi(os.Getenv("ACCESS_TOKEN_EXPIRATION"))
 	refreshTokenExpirationEnv, _ := strconv.Atoi(os.Getenv("REFRESH_TOKEN_EXPIRATION"))
 	autoMigrateEnv, _ := strconv.ParseBool(os.Getenv("AUTO_MIGRATE"))
+	appURLEnv := os.Getenv("APP_URL")
+	cookieDomainEnv := os.Getenv("COOKIE_DOMAIN")
+	cookieSameSiteEnv := os.Getenv("COOKIE_SAMESITE")
+	adminEmailEnv := os.Getenv("ADMIN_EMAIL")
+	adminPasswordEnv := os.Getenv("ADMIN_PASSWORD")
+	revocationCacheSizeEnv, _ := strconv.Atoi(os.Getenv("REVOCATION_CACHE_SIZE"))
+	revocationCacheTTLEnv, _ := strconv.Atoi(os.Getenv("REVOCATION_CACHE_TTL"))
+	securityEventRetentionEnv, _ := strconv.Atoi(os.Getenv("SECURITY_EVENT_RETENTION"))
+	maxSessionsPerUserEnv, _ := strconv.Atoi(os.Getenv("MAX_SESSIONS_PER_USER"))
+	janitorBatchSizeEnv, _ := strconv.Atoi(os.Getenv("JANITOR_BATCH_SIZE"))
+	serviceAccountKeyOverlapEnv, _ := strconv.Atoi(os.Getenv("SERVICE_ACCOUNT_KEY_OVERLAP"))
+	serviceAccountRateLimitEnv, _ := strconv.Atoi(os.Getenv("SERVICE_ACCOUNT_RATE_LIMIT"))
 
 	postgresHost := os.Getenv("POSTGRES_HOST")
 	postgresPort := os.Getenv("POSTGRES_PORT")
@@ -41,13 +91,41 @@ func New() Config {
 	postgresPassword := os.Getenv("POSTGRES_PASSWORD")
 	postgresSSLModeEnv := os.Getenv("POSTGRES_SSLMODE")
 
+	smtpHostEnv := os.Getenv("SMTP_HOST")
+	smtpPortEnv := os.Getenv("SMTP_PORT")
+	smtpUsernameEnv := os.Getenv("SMTP_USERNAME")
+	smtpPasswordEnv := os.Getenv("SMTP_PASSWORD")
+	mailFromEnv := os.Getenv("MAIL_FROM")
+
+	oidcProvidersEnv := os.Getenv("OIDC_PROVIDERS")
+
 	flag.StringVar(&config.Port, "port", portEnv, "provide http server port address")
 	flag.StringVar(&config.AllowedOrigin, "allowed-origin", allowedOriginEnv, "provide allowed origin")
 	flag.StringVar(&config.AccessTokenKey, "access-token-key", accessTokenKeyEnv, "provide access token secret key for jwt")
 	flag.StringVar(&config.RefreshTokenKey, "refresh-token-key", refreshTokenKeyEnv, "provide refresh token secret key for jwt")
+	flag.StringVar(&config.JWTIssuer, "jwt-issuer", jwtIssuerEnv, "provide iss claim of issued jwt (taskit)")
+	flag.StringVar(&config.JWTAudience, "jwt-audience", jwtAudienceEnv, "provide aud claim of issued access tokens (taskit-api)")
+	flag.StringVar(&config.AccessTokenSigningKeyFile, "access-token-signing-key-file", accessTokenSigningKeyEnv, "provide PEM private key file to sign access tokens with (RSA, ECDSA or Ed25519), empty uses access token key")
+	flag.StringVar(&config.RefreshTokenPepper, "refresh-token-pepper", refreshTokenPepperEnv, "provide secret pepper to hash stored refresh tokens, at least 32 bytes")
+	flag.StringVar(&config.TOTPIssuer, "totp-issuer", totpIssuerEnv, "provide issuer name shown in authenticator apps")
+	flag.IntVar(&config.PasswordPolicy.MinLength, "password-min-length", passwordMinLengthEnv, "provide minimum password length in characters (8)")
+	flag.IntVar(&config.PasswordPolicy.MaxLength, "password-max-length", passwordMaxLengthEnv, "provide maximum password length in bytes (128)")
+	flag.StringVar(&config.PasswordPolicy.BreachedCorpusDir, "password-breached-corpus-dir", passwordBreachedCorpusDirEnv, "provide directory of sha-1 range files of breached passwords (empty disables the check)")
 	flag.IntVar(&config.AccessTokenExpiration, "access-token-expiration", accessTokenExpirationEnv, "provide access token expiration time in seconds")
 	flag.IntVar(&config.RefreshTokenExpiration, "refresh-token-expiration", refreshTokenExpirationEnv, "provide refresh token expiration time in seconds")
 	flag.BoolVar(&config.AutoMigrate, "auto-migrate", autoMigrateEnv, "should auto migrate database (true | false)")
+	flag.StringVar(&config.AppURL, "app-url", appURLEnv, "provide web app url used in email links")
+	flag.StringVar(&config.CookieDomain, "cookie-domain", cookieDomainEnv, "provide domain of the authentication cookies (empty scopes them to the api host)")
+	flag.StringVar(&config.CookieSameSite, "cookie-samesite", cookieSameSiteEnv, "provide SameSite attribute of the authentication cookies (strict | lax | none)")
+	flag.StringVar(&config.AdminEmail, "admin-email", adminEmailEnv, "provide email of the initial administrator, promoted when verified or created on startup (empty disables)")
+	flag.StringVar(&config.AdminPassword, "admin-password", adminPasswordEnv, "provide password of the initial administrator when the account does not exist yet")
+	flag.IntVar(&config.RevocationCacheSize, "revocation-cache-size", revocationCacheSizeEnv, "provide number of access tokens and users kept in the revocation cache (10000)")
+	flag.IntVar(&config.RevocationCacheTTL, "revocation-cache-ttl", revocationCacheTTLEnv, "provide seconds a revocation lookup is cached, how late other replicas honor a revocation (15)")
+	flag.IntVar(&config.SecurityEventRetention, "security-event-retention", securityEventRetentionEnv, "provide days security events are kept before they are deleted (90)")
+	flag.IntVar(&config.MaxSessionsPerUser, "max-sessions-per-user", maxSessionsPerUserEnv, "provide maximum number of sessions a user can have at once, the oldest is signed out on login (0, unlimited)")
+	flag.IntVar(&config.JanitorBatchSize, "janitor-batch-size", janitorBatchSizeEnv, "provide number of expired rows the janitor deletes at once per table (1000)")
+	flag.IntVar(&config.ServiceAccountKeyOverlap, "service-account-key-overlap", serviceAccountKeyOverlapEnv, "provide seconds a rotated service account API key keeps working (86400)")
+	flag.IntVar(&config.ServiceAccountRateLimit, "service-account-rate-limit", serviceAccountRateLimitEnv, "provide number of requests a service account can make per minute (600)")
 
 	flag.StringVar(&config.Postgres.Host, "postgres-host", postgresHost, "provide postgres host")
 	flag.StringVar(&config.Postgres.Port, "postgres-port", postgresPort, "provide postgres port")
@@ -56,8 +134,41 @@ func New() Config {
 	flag.StringVar(&config.Postgres.Password, "postgres-password", postgresPassword, "provide postgres password")
 	flag.StringVar(&config.Postgres.SSLMode, "postgres-sslmode", postgresSSLModeEnv, "provide postgres ssl mode (disable | require)")
 
+	flag.StringVar(&config.Mailer.Host, "smtp-host", smtpHostEnv, "provide smtp host (empty keeps emails in memory)")
+	flag.StringVar(&config.Mailer.Port, "smtp-port", smtpPortEnv, "provide smtp port")
+	flag.StringVar(&config.Mailer.Username, "smtp-username", smtpUsernameEnv, "provide smtp username")
+	flag.StringVar(&config.Mailer.Password, "smtp-password", smtpPasswordEnv, "provide smtp password")
+	flag.StringVar(&config.Mailer.From, "mail-from", mailFromEnv, "provide sender address of emails")
+
+	var argon2Memory, argon2Iterations, argon2Parallelism uint
+	flag.UintVar(&argon2Memory, "argon2-memory", uint(argon2MemoryEnv), "provide argon2id memory cost of password hashes in KiB (65536)")
+	flag.UintVar(&argon2Iterations, "argon2-iterations", uint(argon2IterationsEnv), "provide argon2id iterations of password hashes (3)")
+	flag.UintVar(&argon2Parallelism, "argon2-parallelism", uint(argon2ParallelismEnv), "provide argon2id parallelism of password hashes (4)")
+
+	var accessTokenVerifyKeys string
+	flag.StringVar(&accessTokenVerifyKeys, "access-token-verification-key-files", accessTokenVerifyKeysEnv, "provide comma separated PEM public key files still accepted for access tokens, such as the previous signing key")
+
+	var oidcProviders string
+	flag.StringVar(&oidcProviders, "oidc-providers", oidcProvidersEnv, "provide openid connect providers as a json array of {name, issuer, client_id, client_secret, redirect_url, scopes}")
+
 	flag.Parse()
 
+	config.Argon2 = security.Argon2Config{
+		Memory:      uint32(argon2Memory),
+		Iterations:  uint32(argon2Iterations),
+		Parallelism: uint8(argon2Parallelism),
+	}
+
+	if accessTokenVerifyKeys != "" {
+		config.AccessTokenVerificationKeyFiles = strings.Split(accessTokenVerifyKeys, ",")
+	}
+
+	if oidcProviders != "" {
+		if err := json.Unmarshal([]byte(oidcProviders), &config.OIDCProviders); err != nil {
+			log.Fatalf("Failed to parse openid connect providers: %v", err)
+		}
+	}
+
 	if os.Getenv("APP_ENV") == "dev" {
 		strCfg, _ := json.MarshalIndent(&config, "", "  ")
 		log.Println("Configuration:", string(strCfg))
@@ -1,27 +1,75 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 
 	"github.com/edwintantawi/taskit/cmd/config"
+	adminHTTPHandler "github.com/edwintantawi/taskit/internal/admin/delivery/http"
+	adminUsecase "github.com/edwintantawi/taskit/internal/admin/usecase"
 	authHTTPHandler "github.com/edwintantawi/taskit/internal/auth/delivery/http"
 	authMiddleware "github.com/edwintantawi/taskit/internal/auth/delivery/http/middleware"
 	authRepository "github.com/edwintantawi/taskit/internal/auth/repository"
 	authUsecase "github.com/edwintantawi/taskit/internal/auth/usecase"
+	checklistHTTPHandler "github.com/edwintantawi/taskit/internal/checklist/delivery/http"
+	checklistRepository "github.com/edwintantawi/taskit/internal/checklist/repository"
+	checklistUsecase "github.com/edwintantawi/taskit/internal/checklist/usecase"
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	filterHTTPHandler "github.com/edwintantawi/taskit/internal/filter/delivery/http"
+	filterRepository "github.com/edwintantawi/taskit/internal/filter/repository"
+	filterUsecase "github.com/edwintantawi/taskit/internal/filter/usecase"
+	identityRepository "github.com/edwintantawi/taskit/internal/identity/repository"
+	janitorUsecase "github.com/edwintantawi/taskit/internal/janitor/usecase"
+	loginAttemptRepository "github.com/edwintantawi/taskit/internal/loginattempt/repository"
+	loginMethodRepository "github.com/edwintantawi/taskit/internal/loginmethod/repository"
+	magicLinkRepository "github.com/edwintantawi/taskit/internal/magiclink/repository"
+	passwordResetHTTPHandler "github.com/edwintantawi/taskit/internal/passwordreset/delivery/http"
+	passwordResetRepository "github.com/edwintantawi/taskit/internal/passwordreset/repository"
+	passwordResetUsecase "github.com/edwintantawi/taskit/internal/passwordreset/usecase"
+	personalTokenHTTPHandler "github.com/edwintantawi/taskit/internal/personaltoken/delivery/http"
+	personalTokenRepository "github.com/edwintantawi/taskit/internal/personaltoken/repository"
+	personalTokenUsecase "github.com/edwintantawi/taskit/internal/personaltoken/usecase"
+	revocationRepository "github.com/edwintantawi/taskit/internal/revocation/repository"
+	revocationUsecase "github.com/edwintantawi/taskit/internal/revocation/usecase"
+	securityEventHTTPHandler "github.com/edwintantawi/taskit/internal/securityevent/delivery/http"
+	securityEventRepository "github.com/edwintantawi/taskit/internal/securityevent/repository"
+	securityEventUsecase "github.com/edwintantawi/taskit/internal/securityevent/usecase"
+	serviceAccountHTTPHandler "github.com/edwintantawi/taskit/internal/serviceaccount/delivery/http"
+	serviceAccountRepository "github.com/edwintantawi/taskit/internal/serviceaccount/repository"
+	serviceAccountUsecase "github.com/edwintantawi/taskit/internal/serviceaccount/usecase"
+	sessionHTTPHandler "github.com/edwintantawi/taskit/internal/session/delivery/http"
+	sessionUsecase "github.com/edwintantawi/taskit/internal/session/usecase"
+	statsHTTPHandler "github.com/edwintantawi/taskit/internal/stats/delivery/http"
+	statsUsecase "github.com/edwintantawi/taskit/internal/stats/usecase"
 	taskHTTPHandler "github.com/edwintantawi/taskit/internal/task/delivery/http"
 	taskRepository "github.com/edwintantawi/taskit/internal/task/repository"
 	taskUsecase "github.com/edwintantawi/taskit/internal/task/usecase"
+	templateHTTPHandler "github.com/edwintantawi/taskit/internal/template/delivery/http"
+	templateRepository "github.com/edwintantawi/taskit/internal/template/repository"
+	templateUsecase "github.com/edwintantawi/taskit/internal/template/usecase"
+	twoFactorHTTPHandler "github.com/edwintantawi/taskit/internal/twofactor/delivery/http"
+	twoFactorRepository "github.com/edwintantawi/taskit/internal/twofactor/repository"
+	twoFactorUsecase "github.com/edwintantawi/taskit/internal/twofactor/usecase"
 	userHTTPHandler "github.com/edwintantawi/taskit/internal/user/delivery/http"
 	userRepository "github.com/edwintantawi/taskit/internal/user/repository"
 	userUsecase "github.com/edwintantawi/taskit/internal/user/usecase"
+	verificationRepository "github.com/edwintantawi/taskit/internal/verification/repository"
 	"github.com/edwintantawi/taskit/pkg/httpsvr"
 	"github.com/edwintantawi/taskit/pkg/idgen"
+	"github.com/edwintantawi/taskit/pkg/mailer"
+	"github.com/edwintantawi/taskit/pkg/metrics"
+	"github.com/edwintantawi/taskit/pkg/oidc"
 	"github.com/edwintantawi/taskit/pkg/postgres"
+	"github.com/edwintantawi/taskit/pkg/ratelimit"
 	"github.com/edwintantawi/taskit/pkg/security"
 	"github.com/edwintantawi/taskit/pkg/validator"
 )
@@ -39,60 +87,278 @@ func main() {
 	}
 
 	// Create new providers.
-	hashProvider := security.NewBcrypt()
+	hashProvider := security.NewArgon2(cfg.Argon2)
+	passwordPolicy := security.NewPasswordPolicy(cfg.PasswordPolicy)
+	tokenProvider := security.NewToken()
+	totpProvider := security.NewTOTP(cfg.TOTPIssuer)
+	oidcProvider := oidc.New(cfg.OIDCProviders)
 	idProvider := idgen.NewUUID()
 	validator := validator.New()
-	jwtProvider := security.NewJWT(
-		security.JWTTokenConfig{Key: cfg.AccessTokenKey, Exp: cfg.AccessTokenExpiration},
-		security.JWTTokenConfig{Key: cfg.RefreshTokenKey, Exp: cfg.RefreshTokenExpiration},
-	)
+	metricsRegistry := metrics.New()
+	jwtProvider, err := security.NewJWT(security.JWTConfig{
+		Issuer:   cfg.JWTIssuer,
+		Audience: cfg.JWTAudience,
+		AccessToken: security.JWTTokenConfig{
+			Key:                  cfg.AccessTokenKey,
+			Exp:                  cfg.AccessTokenExpiration,
+			SigningKeyFile:       cfg.AccessTokenSigningKeyFile,
+			VerificationKeyFiles: cfg.AccessTokenVerificationKeyFiles,
+		},
+		RefreshToken: security.JWTTokenConfig{Key: cfg.RefreshTokenKey, Exp: cfg.RefreshTokenExpiration},
+	})
+	if err != nil {
+		log.Fatalf("Failed to load jwt keys: %v", err)
+	}
+	refreshTokenHasher, err := security.NewHMAC(cfg.RefreshTokenPepper)
+	if err != nil {
+		log.Fatalf("Failed to load refresh token pepper, it must be at least %d bytes: %v", security.MinPepperLength, err)
+	}
+
+	// Create new mailer, emails are kept in memory when smtp is not configured.
+	var mail domain.Mailer
+	if cfg.Mailer.Host != "" {
+		smtpMailer := mailer.NewSMTP(cfg.Mailer, cfg.AppURL)
+		mail = &smtpMailer
+	} else {
+		log.Println("SMTP is not configured, emails will not be delivered")
+		memoryMailer := mailer.NewMemory(cfg.AppURL)
+		mail = &memoryMailer
+	}
+	// Mails are sent in the background, so requests never wait on the mail server
+	// and response times never tell which emails are registered.
+	asyncMail := mailer.NewAsync(mail)
+
+	// Access token revocation, prune revocations of expired access tokens every hour.
+	revocationStore := revocationRepository.New(db)
+	revocationRepository := revocationRepository.NewCache(&revocationStore, cfg.RevocationCacheSize, time.Duration(cfg.RevocationCacheTTL)*time.Second)
+	revocationUsecase := revocationUsecase.New(&revocationRepository, time.Duration(cfg.AccessTokenExpiration)*time.Second)
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := revocationUsecase.Prune(context.Background()); err != nil {
+				log.Printf("Failed to prune access token revocations: %v", err)
+			}
+		}
+	}()
+
+	// Security event, prune events older than the retention every hour.
+	securityEventRepository := securityEventRepository.New(db, &idProvider)
+	securityEventUsecase := securityEventUsecase.New(&securityEventRepository, time.Duration(cfg.SecurityEventRetention)*24*time.Hour)
+	securityEventHTTPHandler := securityEventHTTPHandler.New(&validator, &securityEventUsecase)
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := securityEventUsecase.Prune(context.Background()); err != nil {
+				log.Printf("Failed to prune security events: %v", err)
+			}
+		}
+	}()
 
 	// User.
 	userRepository := userRepository.New(db, &idProvider)
-	userUsecase := userUsecase.New(&validator, &userRepository, &hashProvider)
+	verificationRepository := verificationRepository.New(db, &idProvider)
+	authRepository := authRepository.New(db, &idProvider, &refreshTokenHasher)
+	userUsecase := userUsecase.New(&validator, &userRepository, &verificationRepository, &authRepository, &revocationRepository, &securityEventRepository, &hashProvider, &passwordPolicy, &tokenProvider, &jwtProvider, &asyncMail)
 	userHTTPHandler := userHTTPHandler.New(&validator, &userUsecase)
 
+	// Two factor.
+	twoFactorRepository := twoFactorRepository.New(db, &idProvider)
+	twoFactorUsecase := twoFactorUsecase.New(&twoFactorRepository, &userRepository, &securityEventRepository, &hashProvider, &tokenProvider, &totpProvider)
+	twoFactorHTTPHandler := twoFactorHTTPHandler.New(&validator, &twoFactorUsecase)
+
 	// Auth.
-	authRepository := authRepository.New(db, &idProvider)
-	authUsecase := authUsecase.New(&validator, &authRepository, &userRepository, &hashProvider, &jwtProvider)
-	authHTTPHandler := authHTTPHandler.New(&validator, &authUsecase)
-	authMiddleware := authMiddleware.New(&jwtProvider)
+	loginAttemptRepository := loginAttemptRepository.New(db, &idProvider)
+	identityRepository := identityRepository.New(db, &idProvider)
+	magicLinkRepository := magicLinkRepository.New(db, &idProvider)
+	loginMethodRepository := loginMethodRepository.New(db)
+	authUsecase := authUsecase.New(&validator, &authRepository, &userRepository, &twoFactorRepository, &identityRepository, &securityEventRepository, &loginAttemptRepository, &revocationRepository, &magicLinkRepository, &loginMethodRepository, &hashProvider, &jwtProvider, &tokenProvider, &totpProvider, &oidcProvider, &asyncMail, cfg.MaxSessionsPerUser)
+	authHTTPHandler := authHTTPHandler.New(&validator, &authUsecase, &tokenProvider, authHTTPHandler.CookieConfig{
+		Domain:   cfg.CookieDomain,
+		SameSite: cfg.CookieSameSite,
+		MaxAge:   cfg.RefreshTokenExpiration,
+	})
+
+	// Personal access token.
+	personalTokenRepository := personalTokenRepository.New(db, &idProvider)
+	personalTokenUsecase := personalTokenUsecase.New(&personalTokenRepository, &tokenProvider)
+	personalTokenHTTPHandler := personalTokenHTTPHandler.New(&validator, &personalTokenUsecase)
+
+	// Service account, every service account is rate-limited separately.
+	serviceAccountRateLimiter := ratelimit.New(cfg.ServiceAccountRateLimit, time.Minute)
+	serviceAccountRepository := serviceAccountRepository.New(db, &idProvider)
+	serviceAccountUsecase := serviceAccountUsecase.New(&serviceAccountRepository, &userRepository, &securityEventRepository, &tokenProvider, &serviceAccountRateLimiter, time.Duration(cfg.ServiceAccountKeyOverlap)*time.Second)
+	serviceAccountHTTPHandler := serviceAccountHTTPHandler.New(&validator, &serviceAccountUsecase)
+	authMiddleware := authMiddleware.New(&jwtProvider, &userUsecase, &personalTokenUsecase, &serviceAccountUsecase, &revocationUsecase)
+
+	// Session.
+	sessionUsecase := sessionUsecase.New(&authRepository)
+	sessionHTTPHandler := sessionHTTPHandler.New(&validator, &sessionUsecase)
+
+	// Janitor, delete expired sessions, old failed login attempts and abandoned OpenID Connect and two-factor logins every hour.
+	janitorUsecase := janitorUsecase.New(&authRepository, &loginAttemptRepository, &identityRepository, &twoFactorRepository, &metricsRegistry, cfg.JanitorBatchSize)
+	go func() {
+		for range time.Tick(time.Hour) {
+			if _, err := janitorUsecase.Run(context.Background()); err != nil {
+				log.Printf("Failed to delete expired data: %v", err)
+			}
+		}
+	}()
+
+	// Password reset.
+	passwordResetRepository := passwordResetRepository.New(db, &idProvider)
+	passwordResetUsecase := passwordResetUsecase.New(&passwordResetRepository, &userRepository, &authRepository, &revocationRepository, &securityEventRepository, &loginAttemptRepository, &hashProvider, &passwordPolicy, &tokenProvider, &asyncMail)
+	passwordResetHTTPHandler := passwordResetHTTPHandler.New(&validator, &passwordResetUsecase)
 
 	// Task.
 	taskRepository := taskRepository.New(db, &idProvider)
-	taskUsecase := taskUsecase.New(&taskRepository)
+	checklistRepository := checklistRepository.New(db, &idProvider)
+	taskUsecase := taskUsecase.New(&taskRepository, &checklistRepository)
 	taskHTTPHandler := taskHTTPHandler.New(&validator, &taskUsecase)
 
+	// Checklist.
+	checklistUsecase := checklistUsecase.New(&checklistRepository, &taskRepository)
+	checklistHTTPHandler := checklistHTTPHandler.New(&validator, &checklistUsecase)
+
+	// Template.
+	templateRepository := templateRepository.New(db, &idProvider)
+	templateUsecase := templateUsecase.New(&validator, &templateRepository, &taskRepository)
+	templateHTTPHandler := templateHTTPHandler.New(&validator, &templateUsecase)
+
+	// Filter.
+	filterRepository := filterRepository.New(db, &idProvider)
+	filterUsecase := filterUsecase.New(&filterRepository, &taskRepository, &checklistRepository)
+	filterHTTPHandler := filterHTTPHandler.New(&validator, &filterUsecase)
+
+	// Stats.
+	statsUsecase := statsUsecase.New(&taskRepository)
+	statsHTTPHandler := statsHTTPHandler.New(&validator, &statsUsecase)
+
+	// Admin.
+	adminUsecase := adminUsecase.New(&userRepository, &authRepository, &revocationRepository, &twoFactorRepository, &loginMethodRepository, &securityEventRepository, &hashProvider, &passwordPolicy)
+	adminHTTPHandler := adminHTTPHandler.New(&validator, &adminUsecase)
+	if err := adminUsecase.Seed(context.Background(), &dto.AdminSeedIn{Email: cfg.AdminEmail, Password: cfg.AdminPassword}); errors.Is(err, domain.ErrAdminSeedUnverified) {
+		log.Printf("Skipped seeding admin, %s is registered but not verified, verify it and restart to promote it", cfg.AdminEmail)
+	} else if err != nil {
+		log.Fatalf("Failed to seed admin: %v", err)
+	}
+
 	// Create new router.
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{cfg.AllowedOrigin},
 		AllowedMethods:   []string{http.MethodOptions, http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
-		AllowedHeaders:   []string{"Content-Type", "Authorization"},
+		AllowedHeaders:   []string{"Content-Type", "Authorization", "X-Auth-Mode", "X-CSRF-Token"},
 		AllowCredentials: true,
 	}))
 
 	// public routes
 	r.Group(func(r chi.Router) {
+		r.Get("/.well-known/jwks.json", authHTTPHandler.GetJWKS)
+
 		r.Post("/api/users", userHTTPHandler.Post)
+		r.Post("/api/users/verify", userHTTPHandler.PostVerify)
 
 		r.Post("/api/authentications", authHTTPHandler.Post)
+		r.Post("/api/authentications/mfa", authHTTPHandler.PostMFA)
+		r.Get("/api/authentications/oidc/{provider}", authHTTPHandler.GetOIDC)
+		r.Post("/api/authentications/oidc/{provider}/callback", authHTTPHandler.PostOIDCCallback)
+		r.Post("/api/authentications/magic-link", authHTTPHandler.PostMagicLink)
+		r.Post("/api/authentications/magic-link/confirm", authHTTPHandler.PostMagicLinkConfirm)
+		r.Get("/api/authentications/methods", authHTTPHandler.GetLoginMethods)
 		r.Put("/api/authentications", authHTTPHandler.Put)
+
+		r.Post("/api/password-resets", passwordResetHTTPHandler.Post)
+		r.Post("/api/password-resets/confirm", passwordResetHTTPHandler.PostConfirm)
 	})
 
 	// private routes (need authentication)
 	r.Group(func(r chi.Router) {
 		r.Use(authMiddleware.Authenticate)
 
-		r.Get("/api/authentications", authHTTPHandler.Get)
-		r.Delete("/api/authentications", authHTTPHandler.Delete)
+		// session routes (personal access tokens and service accounts are not allowed)
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.RequireSession)
+
+			r.Get("/api/authentications", authHTTPHandler.Get)
+			r.Delete("/api/authentications", authHTTPHandler.Delete)
+
+			r.Get("/api/sessions", sessionHTTPHandler.Get)
+			r.Delete("/api/sessions", sessionHTTPHandler.DeleteOthers)
+			r.Delete("/api/sessions/{session_id}", sessionHTTPHandler.Delete)
+
+			r.Get("/api/tokens", personalTokenHTTPHandler.Get)
+			r.Post("/api/tokens", personalTokenHTTPHandler.Post)
+			r.Delete("/api/tokens/{token_id}", personalTokenHTTPHandler.Delete)
+
+			r.Post("/api/users/verify/resend", userHTTPHandler.PostVerifyResend)
+			r.Put("/api/users/me", userHTTPHandler.PutMe)
+			r.Put("/api/users/me/password", userHTTPHandler.PutMePassword)
+			r.Delete("/api/users/me", userHTTPHandler.DeleteMe)
+
+			r.Post("/api/users/me/2fa/setup", twoFactorHTTPHandler.PostSetup)
+			r.Post("/api/users/me/2fa", twoFactorHTTPHandler.Post)
+			r.Delete("/api/users/me/2fa", twoFactorHTTPHandler.Delete)
+
+			r.Get("/api/users/me/security-events", securityEventHTTPHandler.Get)
+		})
+
+		// admin routes (need admin role, personal access tokens and service accounts are not allowed)
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.RequireSession)
+			r.Use(authMiddleware.RequireRole(entity.RoleAdmin))
+
+			r.Get("/metrics", metricsRegistry.ServeHTTP)
+
+			r.Get("/api/admin/users", adminHTTPHandler.GetUsers)
+			r.Get("/api/admin/users/{user_id}", adminHTTPHandler.GetUserByID)
+			r.Put("/api/admin/users/{user_id}/suspension", adminHTTPHandler.PutSuspension)
+			r.Delete("/api/admin/users/{user_id}/suspension", adminHTTPHandler.DeleteSuspension)
+			r.Delete("/api/admin/users/{user_id}/sessions", adminHTTPHandler.DeleteSessions)
+			r.Delete("/api/admin/users/{user_id}/2fa", adminHTTPHandler.DeleteTwoFactor)
+			r.Get("/api/admin/login-methods", adminHTTPHandler.GetLoginMethods)
+			r.Put("/api/admin/login-methods/{method}", adminHTTPHandler.PutLoginMethod)
+			r.Get("/api/admin/security-events", adminHTTPHandler.GetSecurityEvents)
+
+			r.Post("/api/admin/service-accounts", serviceAccountHTTPHandler.Post)
+			r.Get("/api/admin/service-accounts/{user_id}/keys", serviceAccountHTTPHandler.GetKeys)
+			r.Post("/api/admin/service-accounts/{user_id}/keys", serviceAccountHTTPHandler.PostKey)
+			r.Delete("/api/admin/service-accounts/{user_id}/keys/{key_id}", serviceAccountHTTPHandler.DeleteKey)
+		})
+
+		// verified routes (need verified email, personal access tokens need the matching scope)
+		r.Group(func(r chi.Router) {
+			r.Use(authMiddleware.RequireVerified)
+
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Post("/api/tasks", taskHTTPHandler.Post)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksRead)).Get("/api/tasks", taskHTTPHandler.Get)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksRead)).Get("/api/tasks/{task_id}", taskHTTPHandler.GetByID)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Delete("/api/tasks/{task_id}", taskHTTPHandler.Delete)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Put("/api/tasks/{task_id}", taskHTTPHandler.Put)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Put("/api/tasks/{task_id}/snooze", taskHTTPHandler.PutSnooze)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Delete("/api/tasks/{task_id}/snooze", taskHTTPHandler.DeleteSnooze)
+
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Post("/api/tasks/{task_id}/checklist", checklistHTTPHandler.Post)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Put("/api/tasks/{task_id}/checklist/order", checklistHTTPHandler.PutOrder)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Put("/api/tasks/{task_id}/checklist/{item_id}/toggle", checklistHTTPHandler.PutToggle)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksWrite)).Delete("/api/tasks/{task_id}/checklist/{item_id}", checklistHTTPHandler.Delete)
+
+			r.With(authMiddleware.RequireScope(entity.ScopeTemplatesWrite)).Post("/api/templates", templateHTTPHandler.Post)
+			r.With(authMiddleware.RequireScope(entity.ScopeTemplatesRead)).Get("/api/templates", templateHTTPHandler.Get)
+			r.With(authMiddleware.RequireScope(entity.ScopeTemplatesWrite, entity.ScopeTasksRead)).Post("/api/templates/from-tasks", templateHTTPHandler.PostFromTasks)
+			r.With(authMiddleware.RequireScope(entity.ScopeTemplatesRead)).Get("/api/templates/{template_id}", templateHTTPHandler.GetByID)
+			r.With(authMiddleware.RequireScope(entity.ScopeTemplatesWrite)).Put("/api/templates/{template_id}", templateHTTPHandler.Put)
+			r.With(authMiddleware.RequireScope(entity.ScopeTemplatesWrite)).Delete("/api/templates/{template_id}", templateHTTPHandler.Delete)
+			r.With(authMiddleware.RequireScope(entity.ScopeTemplatesRead, entity.ScopeTasksWrite)).Post("/api/templates/{template_id}/instantiate", templateHTTPHandler.Instantiate)
+
+			r.With(authMiddleware.RequireScope(entity.ScopeFiltersWrite)).Post("/api/filters", filterHTTPHandler.Post)
+			r.With(authMiddleware.RequireScope(entity.ScopeFiltersRead)).Get("/api/filters", filterHTTPHandler.Get)
+			r.With(authMiddleware.RequireScope(entity.ScopeFiltersWrite)).Put("/api/filters/order", filterHTTPHandler.PutOrder)
+			r.With(authMiddleware.RequireScope(entity.ScopeFiltersRead)).Get("/api/filters/{filter_id}", filterHTTPHandler.GetByID)
+			r.With(authMiddleware.RequireScope(entity.ScopeFiltersWrite)).Put("/api/filters/{filter_id}", filterHTTPHandler.Put)
+			r.With(authMiddleware.RequireScope(entity.ScopeFiltersWrite)).Delete("/api/filters/{filter_id}", filterHTTPHandler.Delete)
+			r.With(authMiddleware.RequireScope(entity.ScopeFiltersRead, entity.ScopeTasksRead)).Get("/api/filters/{filter_id}/tasks", filterHTTPHandler.GetTasks)
 
-		r.Post("/api/tasks", taskHTTPHandler.Post)
-		r.Get("/api/tasks", taskHTTPHandler.Get)
-		r.Get("/api/tasks/{task_id}", taskHTTPHandler.GetByID)
-		r.Delete("/api/tasks/{task_id}", taskHTTPHandler.Delete)
-		r.Put("/api/tasks/{task_id}", taskHTTPHandler.Put)
+			r.With(authMiddleware.RequireScope(entity.ScopeTasksRead)).Get("/api/stats", statsHTTPHandler.Get)
+		})
 	})
 
 	// Start HTTP server.
@@ -0,0 +1,263 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+)
+
+type HTTPHandler struct {
+	validator    domain.ValidatorProvider
+	adminUsecase domain.AdminUsecase
+}
+
+// New creates a new admin handler.
+func New(validator domain.ValidatorProvider, adminUsecase domain.AdminUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, adminUsecase: adminUsecase}
+}
+
+// GET /admin/users?q=search&limit=50&offset=0 to list and search users.
+func (h *HTTPHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	query := r.URL.Query()
+	var payload dto.AdminUserGetAllIn
+	payload.Search = query.Get("q")
+
+	var err error
+	if payload.Limit, err = queryInt(query.Get("limit")); err != nil {
+		err = dto.ErrLimitInvalid
+	} else if payload.Offset, err = queryInt(query.Get("offset")); err != nil {
+		err = dto.ErrOffsetInvalid
+	} else {
+		err = h.validator.Validate(&payload)
+	}
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.adminUsecase.GetAllUsers(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// queryInt parse an optional integer query parameter, it is 0 when not provided.
+func queryInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// queryTime parse an optional RFC 3339 query parameter, it is the zero time when not provided.
+func queryTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// GET /admin/security-events?user_id=&type=&ip_address=&since=&until=&limit=50&offset=0 to search the security events of every user.
+func (h *HTTPHandler) GetSecurityEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	query := r.URL.Query()
+	var payload dto.AdminSecurityEventGetAllIn
+	payload.UserID = entity.UserID(query.Get("user_id"))
+	payload.Type = entity.SecurityEventType(query.Get("type"))
+	payload.IPAddress = query.Get("ip_address")
+
+	var err error
+	if payload.Since, err = queryTime(query.Get("since")); err != nil {
+		err = dto.ErrTimeInvalid
+	} else if payload.Until, err = queryTime(query.Get("until")); err != nil {
+		err = dto.ErrTimeInvalid
+	} else if payload.Limit, err = queryInt(query.Get("limit")); err != nil {
+		err = dto.ErrLimitInvalid
+	} else if payload.Offset, err = queryInt(query.Get("offset")); err != nil {
+		err = dto.ErrOffsetInvalid
+	} else {
+		err = h.validator.Validate(&payload)
+	}
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.adminUsecase.GetSecurityEvents(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// GET /admin/users/{user_id} to get a user with their usage counts.
+func (h *HTTPHandler) GetUserByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AdminUserGetByIDIn
+	payload.UserID = entity.UserID(chi.URLParam(r, "user_id"))
+
+	output, err := h.adminUsecase.GetUserByID(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// PUT /admin/users/{user_id}/suspension to suspend a user.
+func (h *HTTPHandler) PutSuspension(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AdminUserSuspendIn
+	payload.AdminID = entity.GetAuthContext(r.Context())
+	payload.UserID = entity.UserID(chi.URLParam(r, "user_id"))
+
+	if err := h.adminUsecase.Suspend(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully suspended user", nil))
+}
+
+// DELETE /admin/users/{user_id}/suspension to lift the suspension of a user.
+func (h *HTTPHandler) DeleteSuspension(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AdminUserUnsuspendIn
+	payload.UserID = entity.UserID(chi.URLParam(r, "user_id"))
+
+	if err := h.adminUsecase.Unsuspend(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully unsuspended user", nil))
+}
+
+// DELETE /admin/users/{user_id}/sessions to sign a user out of every session.
+func (h *HTTPHandler) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AdminUserLogoutIn
+	payload.UserID = entity.UserID(chi.URLParam(r, "user_id"))
+
+	if err := h.adminUsecase.Logout(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully signed out user", nil))
+}
+
+// DELETE /admin/users/{user_id}/2fa to reset the two-factor authentication of a user.
+func (h *HTTPHandler) DeleteTwoFactor(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AdminUserResetTwoFactorIn
+	payload.UserID = entity.UserID(chi.URLParam(r, "user_id"))
+
+	if err := h.adminUsecase.ResetTwoFactor(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully reset two-factor authentication", nil))
+}
+
+// GET /admin/login-methods to list the login methods and whether they are enabled.
+func (h *HTTPHandler) GetLoginMethods(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	output, err := h.adminUsecase.GetLoginMethods(r.Context())
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// PUT /admin/login-methods/{method} to enable or disable a login method.
+func (h *HTTPHandler) PutLoginMethod(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AdminLoginMethodUpdateIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.Method = entity.LoginMethod(chi.URLParam(r, "method"))
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	if err := h.adminUsecase.UpdateLoginMethod(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully updated login method", nil))
+}
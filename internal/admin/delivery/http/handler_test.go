@@ -0,0 +1,768 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type AdminHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestAdminHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(AdminHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	validator    *mocks.ValidatorProvider
+	adminUsecase *mocks.AdminUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *AdminHTTPHandlerTestSuite) assertResponse(rr *httptest.ResponseRecorder, isError bool, expected expected) {
+	s.Equal(expected.contentType, rr.Header().Get("Content-Type"))
+	s.Equal(expected.statusCode, rr.Code)
+
+	if isError {
+		var resBody domain.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.error, resBody.Error)
+	} else {
+		var resBody domain.SuccessResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.payload, resBody.Payload)
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestGetUsers() {
+	tests := []struct {
+		name     string
+		isError  bool
+		query    string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when limit is not a number",
+			isError: true,
+			query:   "?limit=all",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Limit must be a number between 0 and 100",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when offset is not a number",
+			isError: true,
+			query:   "?offset=next",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Offset must be zero or a positive number",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			query:   "?limit=1000",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Limit must be a number between 0 and 100",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrLimitInvalid)
+			},
+		},
+		{
+			name:    "it should response with error when admin usecase GetAllUsers return unexpected error",
+			isError: true,
+			query:   "?q=gopher",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.adminUsecase.On("GetAllUsers", mock.Anything, &dto.AdminUserGetAllIn{Search: "gopher"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			query:   "?q=gopher&limit=10&offset=20",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{
+						"id":             "user-xxxxx",
+						"name":           "Gopher",
+						"email":          "gopher@go.dev",
+						"role":           "user",
+						"email_verified": true,
+						"suspended_at":   nil,
+						"created_at":     test.TimeBeforeNow.Format(time.RFC3339Nano),
+					},
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.adminUsecase.On("GetAllUsers", mock.Anything, &dto.AdminUserGetAllIn{Search: "gopher", Limit: 10, Offset: 20}).
+					Return([]dto.AdminUserGetAllOut{
+						{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", Role: entity.RoleUser, EmailVerified: true, CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/"+t.query, nil)
+
+			d := &dependency{
+				validator:    &mocks.ValidatorProvider{},
+				adminUsecase: &mocks.AdminUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.adminUsecase)
+			handler.GetUsers(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestGetSecurityEvents() {
+	tests := []struct {
+		name     string
+		isError  bool
+		query    string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when since is not a RFC 3339 time",
+			isError: true,
+			query:   "?since=yesterday",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Since and until must be times in RFC 3339 format",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when until is not a RFC 3339 time",
+			isError: true,
+			query:   "?until=2023-01-02",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Since and until must be times in RFC 3339 format",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when limit is not a number",
+			isError: true,
+			query:   "?limit=all",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Limit must be a number between 0 and 100",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when offset is not a number",
+			isError: true,
+			query:   "?offset=next",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Offset must be zero or a positive number",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			query:   "?since=2023-01-02T00:00:00Z&until=2023-01-01T00:00:00Z",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Since must be before until",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrTimeRangeInvalid)
+			},
+		},
+		{
+			name:    "it should response with error when admin usecase GetSecurityEvents return unexpected error",
+			isError: true,
+			query:   "?user_id=user-xxxxx",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.adminUsecase.On("GetSecurityEvents", mock.Anything, &dto.AdminSecurityEventGetAllIn{UserID: "user-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			query:   "?user_id=user-xxxxx&type=login_failed&ip_address=203.0.113.7&since=2023-01-01T00:00:00Z&until=2023-01-02T00:00:00Z&limit=10&offset=20",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{
+						"id":         "security-event-xxxxx",
+						"user_id":    "user-xxxxx",
+						"type":       "login_failed",
+						"user_agent": "Mozilla/5.0",
+						"ip_address": "203.0.113.7",
+						"created_at": test.TimeBeforeNow.Format(time.RFC3339Nano),
+					},
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.adminUsecase.On("GetSecurityEvents", mock.Anything, &dto.AdminSecurityEventGetAllIn{
+					UserID:    "user-xxxxx",
+					Type:      entity.SecurityEventLoginFailed,
+					IPAddress: "203.0.113.7",
+					Since:     time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+					Until:     time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+					Limit:     10,
+					Offset:    20,
+				}).
+					Return([]dto.AdminSecurityEventGetAllOut{
+						{ID: "security-event-xxxxx", UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/"+t.query, nil)
+
+			d := &dependency{
+				validator:    &mocks.ValidatorProvider{},
+				adminUsecase: &mocks.AdminUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.adminUsecase)
+			handler.GetSecurityEvents(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestGetUserByID() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when admin usecase GetUserByID return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "User not found",
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("GetUserByID", mock.Anything, &dto.AdminUserGetByIDIn{UserID: "user-xxxxx"}).
+					Return(dto.AdminUserGetByIDOut{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: map[string]any{
+					"id":                 "user-xxxxx",
+					"name":               "Gopher",
+					"email":              "gopher@go.dev",
+					"role":               "user",
+					"email_verified":     true,
+					"two_factor_enabled": false,
+					"suspended_at":       nil,
+					"created_at":         test.TimeBeforeNow.Format(time.RFC3339Nano),
+					"usage": map[string]any{
+						"tasks":           float64(12),
+						"templates":       float64(2),
+						"filters":         float64(3),
+						"sessions":        float64(1),
+						"personal_tokens": float64(4),
+					},
+				},
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("GetUserByID", mock.Anything, &dto.AdminUserGetByIDIn{UserID: "user-xxxxx"}).
+					Return(dto.AdminUserGetByIDOut{
+						ID:            "user-xxxxx",
+						Name:          "Gopher",
+						Email:         "gopher@go.dev",
+						Role:          entity.RoleUser,
+						EmailVerified: true,
+						CreatedAt:     test.TimeBeforeNow,
+						Usage:         dto.AdminUserUsageOut{Tasks: 12, Templates: 2, Filters: 3, Sessions: 1, PersonalTokens: 4},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"user_id": "user-xxxxx"})
+
+			d := &dependency{adminUsecase: &mocks.AdminUsecase{}}
+			t.setup(d)
+
+			handler := New(nil, d.adminUsecase)
+			handler.GetUserByID(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestPutSuspension() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when admin usecase Suspend return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Can not suspend your own account",
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("Suspend", mock.Anything, &dto.AdminUserSuspendIn{AdminID: "user-aaaaa", UserID: "user-xxxxx"}).
+					Return(domain.ErrAdminSelfSuspend)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully suspended user",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("Suspend", mock.Anything, &dto.AdminUserSuspendIn{AdminID: "user-aaaaa", UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/", nil)
+			req = test.InjectAuthContext(req, entity.UserID("user-aaaaa"))
+			req = test.InjectChiRouterParams(req, map[string]string{"user_id": "user-xxxxx"})
+
+			d := &dependency{adminUsecase: &mocks.AdminUsecase{}}
+			t.setup(d)
+
+			handler := New(nil, d.adminUsecase)
+			handler.PutSuspension(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestDeleteSuspension() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when admin usecase Unsuspend return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "User not found",
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("Unsuspend", mock.Anything, &dto.AdminUserUnsuspendIn{UserID: "user-xxxxx"}).
+					Return(domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully unsuspended user",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("Unsuspend", mock.Anything, &dto.AdminUserUnsuspendIn{UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"user_id": "user-xxxxx"})
+
+			d := &dependency{adminUsecase: &mocks.AdminUsecase{}}
+			t.setup(d)
+
+			handler := New(nil, d.adminUsecase)
+			handler.DeleteSuspension(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestDeleteSessions() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when admin usecase Logout return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("Logout", mock.Anything, &dto.AdminUserLogoutIn{UserID: "user-xxxxx"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully signed out user",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("Logout", mock.Anything, &dto.AdminUserLogoutIn{UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"user_id": "user-xxxxx"})
+
+			d := &dependency{adminUsecase: &mocks.AdminUsecase{}}
+			t.setup(d)
+
+			handler := New(nil, d.adminUsecase)
+			handler.DeleteSessions(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestDeleteTwoFactor() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when admin usecase ResetTwoFactor return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("ResetTwoFactor", mock.Anything, &dto.AdminUserResetTwoFactorIn{UserID: "user-xxxxx"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully reset two-factor authentication",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("ResetTwoFactor", mock.Anything, &dto.AdminUserResetTwoFactorIn{UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"user_id": "user-xxxxx"})
+
+			d := &dependency{adminUsecase: &mocks.AdminUsecase{}}
+			t.setup(d)
+
+			handler := New(nil, d.adminUsecase)
+			handler.DeleteTwoFactor(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestGetLoginMethods() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when admin usecase GetLoginMethods return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("GetLoginMethods", mock.Anything).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{"method": "password", "enabled": true, "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
+				},
+			},
+			setup: func(d *dependency) {
+				d.adminUsecase.On("GetLoginMethods", mock.Anything).
+					Return([]dto.AdminLoginMethodGetAllOut{{Method: entity.LoginMethodPassword, Enabled: true, UpdatedAt: test.TimeBeforeNow}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+
+			d := &dependency{adminUsecase: &mocks.AdminUsecase{}}
+			t.setup(d)
+
+			handler := New(nil, d.adminUsecase)
+			handler.GetLoginMethods(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *AdminHTTPHandlerTestSuite) TestPutLoginMethod() {
+	enabled := false
+
+	type args struct {
+		requestBody string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when request body is invalid or not provided",
+			isError: true,
+			args:    args{requestBody: `{`},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			args:    args{requestBody: `{}`},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Enabled is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodPassword}).
+					Return(dto.ErrEnabledEmpty)
+			},
+		},
+		{
+			name:    "it should response with error when admin usecase UpdateLoginMethod return error",
+			isError: true,
+			args:    args{requestBody: `{"enabled":false}`},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Can not disable the last login method",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodPassword, Enabled: &enabled}).
+					Return(nil)
+				d.adminUsecase.On("UpdateLoginMethod", mock.Anything, &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodPassword, Enabled: &enabled}).
+					Return(domain.ErrLoginMethodLast)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			args:    args{requestBody: `{"enabled":false}`},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully updated login method",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodPassword, Enabled: &enabled}).
+					Return(nil)
+				d.adminUsecase.On("UpdateLoginMethod", mock.Anything, &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodPassword, Enabled: &enabled}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/", strings.NewReader(t.args.requestBody))
+			req = test.InjectChiRouterParams(req, map[string]string{"method": "password"})
+
+			d := &dependency{validator: &mocks.ValidatorProvider{}, adminUsecase: &mocks.AdminUsecase{}}
+			t.setup(d)
+
+			handler := New(d.validator, d.adminUsecase)
+			handler.PutLoginMethod(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
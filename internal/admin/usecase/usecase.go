@@ -0,0 +1,288 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Usecase struct {
+	userRepository          domain.UserRepository
+	authRepository          domain.AuthRepository
+	revocationRepository    domain.RevocationRepository
+	twoFactorRepository     domain.TwoFactorRepository
+	loginMethodRepository   domain.LoginMethodRepository
+	securityEventRepository domain.SecurityEventRepository
+	hashProvider            domain.HashProvider
+	passwordPolicy          domain.PasswordPolicyProvider
+}
+
+// New create a new admin usecase.
+func New(
+	userRepository domain.UserRepository,
+	authRepository domain.AuthRepository,
+	revocationRepository domain.RevocationRepository,
+	twoFactorRepository domain.TwoFactorRepository,
+	loginMethodRepository domain.LoginMethodRepository,
+	securityEventRepository domain.SecurityEventRepository,
+	hashProvider domain.HashProvider,
+	passwordPolicy domain.PasswordPolicyProvider,
+) Usecase {
+	return Usecase{
+		userRepository:          userRepository,
+		authRepository:          authRepository,
+		revocationRepository:    revocationRepository,
+		twoFactorRepository:     twoFactorRepository,
+		loginMethodRepository:   loginMethodRepository,
+		securityEventRepository: securityEventRepository,
+		hashProvider:            hashProvider,
+		passwordPolicy:          passwordPolicy,
+	}
+}
+
+// Seed make sure the configured email belongs to an administrator.
+// An existing user is promoted, otherwise a verified administrator is created with the password.
+// Nothing is seeded when no email is configured, and an existing user who never verified the email
+// is refused, as anyone could have registered it to be made administrator.
+func (u *Usecase) Seed(ctx context.Context, payload *dto.AdminSeedIn) error {
+	if payload.Email == "" {
+		return nil
+	}
+	if err := entity.ValidateEmail(payload.Email); err != nil {
+		return err
+	}
+
+	user, err := u.userRepository.FindByEmail(ctx, payload.Email)
+	if err == nil {
+		if user.Role == entity.RoleAdmin {
+			return nil
+		}
+		if !user.IsEmailVerified() {
+			return domain.ErrAdminSeedUnverified
+		}
+		return u.userRepository.UpdateRole(ctx, user.ID, entity.RoleAdmin)
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		return err
+	}
+
+	if payload.Password == "" {
+		return dto.ErrPasswordEmpty
+	}
+	name, _, _ := strings.Cut(payload.Email, "@")
+	user = entity.User{Name: name, Email: payload.Email}
+	if err := u.passwordPolicy.Check(payload.Password, &user); err != nil {
+		return err
+	}
+	securePassword, err := u.hashProvider.Hash(payload.Password)
+	if err != nil {
+		return err
+	}
+	user.Password = string(securePassword)
+
+	userID, err := u.userRepository.Store(ctx, &user)
+	if err != nil {
+		return err
+	}
+	if err := u.userRepository.UpdateRole(ctx, userID, entity.RoleAdmin); err != nil {
+		return err
+	}
+	return u.userRepository.MarkEmailVerified(ctx, userID)
+}
+
+// GetAllUsers list the users whose name or email contains the search, newest first.
+func (u *Usecase) GetAllUsers(ctx context.Context, payload *dto.AdminUserGetAllIn) ([]dto.AdminUserGetAllOut, error) {
+	limit := payload.Limit
+	if limit == 0 {
+		limit = dto.AdminUserDefaultLimit
+	}
+
+	users, err := u.userRepository.FindAll(ctx, payload.Search, limit, payload.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.AdminUserGetAllOut, len(users))
+	for i, user := range users {
+		output[i] = dto.AdminUserGetAllOut{
+			ID:            user.ID,
+			Name:          user.Name,
+			Email:         user.Email,
+			Role:          user.Role,
+			EmailVerified: user.IsEmailVerified(),
+			SuspendedAt:   user.SuspendedAt,
+			CreatedAt:     user.CreatedAt,
+		}
+	}
+	return output, nil
+}
+
+// GetUserByID get a user with their usage counts.
+func (u *Usecase) GetUserByID(ctx context.Context, payload *dto.AdminUserGetByIDIn) (dto.AdminUserGetByIDOut, error) {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return dto.AdminUserGetByIDOut{}, err
+	}
+
+	usage, err := u.userRepository.CountUsage(ctx, user.ID)
+	if err != nil {
+		return dto.AdminUserGetByIDOut{}, err
+	}
+
+	twoFactor, err := u.twoFactorRepository.FindByUserID(ctx, user.ID)
+	if err != nil && !errors.Is(err, domain.ErrTwoFactorNotFound) {
+		return dto.AdminUserGetByIDOut{}, err
+	}
+
+	return dto.AdminUserGetByIDOut{
+		ID:               user.ID,
+		Name:             user.Name,
+		Email:            user.Email,
+		Role:             user.Role,
+		EmailVerified:    user.IsEmailVerified(),
+		TwoFactorEnabled: twoFactor.IsEnabled(),
+		SuspendedAt:      user.SuspendedAt,
+		CreatedAt:        user.CreatedAt,
+		Usage: dto.AdminUserUsageOut{
+			Tasks:          usage.Tasks,
+			Templates:      usage.Templates,
+			Filters:        usage.Filters,
+			Sessions:       usage.Sessions,
+			PersonalTokens: usage.PersonalTokens,
+		},
+	}, nil
+}
+
+// Suspend suspend a user and sign them out of every session.
+// Administrators can not suspend themselves, so there is always one left to lift a suspension.
+func (u *Usecase) Suspend(ctx context.Context, payload *dto.AdminUserSuspendIn) error {
+	if payload.UserID == payload.AdminID {
+		return domain.ErrAdminSelfSuspend
+	}
+
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.userRepository.Suspend(ctx, user.ID); err != nil {
+		return err
+	}
+	return u.revokeAll(ctx, user.ID)
+}
+
+// Unsuspend lift the suspension of a user, they have to log in again.
+func (u *Usecase) Unsuspend(ctx context.Context, payload *dto.AdminUserUnsuspendIn) error {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	return u.userRepository.Unsuspend(ctx, user.ID)
+}
+
+// Logout sign a user out of every session, their access tokens stop working right away.
+func (u *Usecase) Logout(ctx context.Context, payload *dto.AdminUserLogoutIn) error {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	return u.revokeAll(ctx, user.ID)
+}
+
+// ResetTwoFactor remove the two-factor authentication of a user who lost their authenticator and recovery codes.
+// The reset shows up in the security events of the user, without the device of the administrator.
+func (u *Usecase) ResetTwoFactor(ctx context.Context, payload *dto.AdminUserResetTwoFactorIn) error {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.twoFactorRepository.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	return u.securityEventRepository.Store(ctx, &entity.SecurityEvent{UserID: user.ID, Type: entity.SecurityEventTwoFactorReset})
+}
+
+// GetLoginMethods get every login method and whether users can log in with it.
+func (u *Usecase) GetLoginMethods(ctx context.Context) ([]dto.AdminLoginMethodGetAllOut, error) {
+	settings, err := u.loginMethodRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.AdminLoginMethodGetAllOut, len(settings))
+	for i, s := range settings {
+		output[i] = dto.AdminLoginMethodGetAllOut{Method: s.Method, Enabled: s.Enabled, UpdatedAt: s.UpdatedAt}
+	}
+	return output, nil
+}
+
+// UpdateLoginMethod turn a login method on or off.
+// The last enabled login method can not be turned off, otherwise nobody could log in anymore.
+func (u *Usecase) UpdateLoginMethod(ctx context.Context, payload *dto.AdminLoginMethodUpdateIn) error {
+	settings, err := u.loginMethodRepository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	found, othersEnabled := false, false
+	for _, s := range settings {
+		if s.Method == payload.Method {
+			found = true
+		} else if s.Enabled {
+			othersEnabled = true
+		}
+	}
+	if !found {
+		return domain.ErrLoginMethodNotFound
+	}
+	if !*payload.Enabled && !othersEnabled {
+		return domain.ErrLoginMethodLast
+	}
+
+	return u.loginMethodRepository.Update(ctx, payload.Method, *payload.Enabled)
+}
+
+// GetSecurityEvents list the security events of every user matching the filters, newest first.
+func (u *Usecase) GetSecurityEvents(ctx context.Context, payload *dto.AdminSecurityEventGetAllIn) ([]dto.AdminSecurityEventGetAllOut, error) {
+	limit := payload.Limit
+	if limit == 0 {
+		limit = dto.SecurityEventDefaultLimit
+	}
+
+	filter := entity.SecurityEventFilter{
+		UserID:    payload.UserID,
+		Type:      payload.Type,
+		IPAddress: payload.IPAddress,
+		Since:     payload.Since,
+		Until:     payload.Until,
+	}
+	events, err := u.securityEventRepository.FindAll(ctx, filter, limit, payload.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.AdminSecurityEventGetAllOut, len(events))
+	for i, e := range events {
+		output[i] = dto.AdminSecurityEventGetAllOut{
+			ID:        e.ID,
+			UserID:    e.UserID,
+			Type:      e.Type,
+			UserAgent: e.UserAgent,
+			IPAddress: e.IPAddress,
+			Detail:    e.Detail,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+	return output, nil
+}
+
+// revokeAll delete every session of a user and revoke the access tokens issued so far.
+func (u *Usecase) revokeAll(ctx context.Context, userID entity.UserID) error {
+	if err := u.authRepository.DeleteByUserID(ctx, userID); err != nil {
+		return err
+	}
+	return u.revocationRepository.StoreWatermark(ctx, userID, time.Now())
+}
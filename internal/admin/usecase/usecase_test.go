@@ -0,0 +1,845 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type AdminUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestAdminUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(AdminUsecaseTestSuite))
+}
+
+type dependency struct {
+	userRepository          *mocks.UserRepository
+	authRepository          *mocks.AuthRepository
+	revocationRepository    *mocks.RevocationRepository
+	twoFactorRepository     *mocks.TwoFactorRepository
+	loginMethodRepository   *mocks.LoginMethodRepository
+	securityEventRepository *mocks.SecurityEventRepository
+	hashProvider            *mocks.HashProvider
+	passwordPolicy          *mocks.PasswordPolicyProvider
+}
+
+func newDependency() *dependency {
+	return &dependency{
+		userRepository:          &mocks.UserRepository{},
+		authRepository:          &mocks.AuthRepository{},
+		revocationRepository:    &mocks.RevocationRepository{},
+		twoFactorRepository:     &mocks.TwoFactorRepository{},
+		loginMethodRepository:   &mocks.LoginMethodRepository{},
+		securityEventRepository: &mocks.SecurityEventRepository{},
+		hashProvider:            &mocks.HashProvider{},
+		passwordPolicy:          &mocks.PasswordPolicyProvider{},
+	}
+}
+
+func newUsecase(d *dependency) Usecase {
+	return New(d.userRepository, d.authRepository, d.revocationRepository, d.twoFactorRepository, d.loginMethodRepository, d.securityEventRepository, d.hashProvider, d.passwordPolicy)
+}
+
+var (
+	verifiedAt  = entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}
+	suspendedAt = entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}
+)
+
+func (s *AdminUsecaseTestSuite) TestSeed() {
+	newAdmin := &entity.User{Name: "admin", Email: "admin@go.dev"}
+	storedAdmin := &entity.User{Name: "admin", Email: "admin@go.dev", Password: "hashed_password"}
+	verified := entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}
+
+	tests := []struct {
+		name     string
+		payload  *dto.AdminSeedIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error nil without seeding when email is not configured",
+			payload:  &dto.AdminSeedIn{},
+			expected: nil,
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error ErrEmailInvalid when email is invalid",
+			payload:  &dto.AdminSeedIn{Email: "admin"},
+			expected: entity.ErrEmailInvalid,
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error when user repository FindByEmail return unexpected error",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev", Password: "secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil without changes when user is already an administrator",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev", Password: "secret_password"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleAdmin}, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrAdminSeedUnverified without promoting when the existing user is not verified",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev"},
+			expected: domain.ErrAdminSeedUnverified,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleUser}, nil)
+			},
+		},
+		{
+			name:     "it should return error nil and promote the existing user when user is verified and not an administrator",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleUser, EmailVerifiedAt: verified}, nil)
+				d.userRepository.On("UpdateRole", context.Background(), entity.UserID("user-xxxxx"), entity.RoleAdmin).
+					Return(nil)
+			},
+		},
+		{
+			name:     "it should return error ErrPasswordEmpty when user does not exist and password is not configured",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev"},
+			expected: dto.ErrPasswordEmpty,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error when password policy Check return error",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev", Password: "admin"},
+			expected: entity.ErrPasswordContainsEmail,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+				d.passwordPolicy.On("Check", "admin", newAdmin).
+					Return(entity.ErrPasswordContainsEmail)
+			},
+		},
+		{
+			name:     "it should return error when hash provider Hash return unexpected error",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev", Password: "secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+				d.passwordPolicy.On("Check", "secret_password", newAdmin).
+					Return(nil)
+				d.hashProvider.On("Hash", "secret_password").
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when user repository Store return unexpected error",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev", Password: "secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+				d.passwordPolicy.On("Check", "secret_password", newAdmin).
+					Return(nil)
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("hashed_password"), nil)
+				d.userRepository.On("Store", context.Background(), storedAdmin).
+					Return(entity.UserID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when user repository UpdateRole return unexpected error",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev", Password: "secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+				d.passwordPolicy.On("Check", "secret_password", newAdmin).
+					Return(nil)
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("hashed_password"), nil)
+				d.userRepository.On("Store", context.Background(), storedAdmin).
+					Return(entity.UserID("user-xxxxx"), nil)
+				d.userRepository.On("UpdateRole", context.Background(), entity.UserID("user-xxxxx"), entity.RoleAdmin).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and create a verified administrator when user does not exist",
+			payload:  &dto.AdminSeedIn{Email: "admin@go.dev", Password: "secret_password"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByEmail", context.Background(), "admin@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+				d.passwordPolicy.On("Check", "secret_password", newAdmin).
+					Return(nil)
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("hashed_password"), nil)
+				d.userRepository.On("Store", context.Background(), storedAdmin).
+					Return(entity.UserID("user-xxxxx"), nil)
+				d.userRepository.On("UpdateRole", context.Background(), entity.UserID("user-xxxxx"), entity.RoleAdmin).
+					Return(nil)
+				d.userRepository.On("MarkEmailVerified", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Seed(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestGetAllUsers() {
+	type expected struct {
+		output []dto.AdminUserGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.AdminUserGetAllIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when user repository FindAll return unexpected error",
+			payload:  &dto.AdminUserGetAllIn{Search: "gopher", Limit: 10, Offset: 20},
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindAll", context.Background(), "gopher", 10, 20).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should list a page of the default size when limit is not given",
+			payload:  &dto.AdminUserGetAllIn{},
+			expected: expected{output: []dto.AdminUserGetAllOut{}, err: nil},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindAll", context.Background(), "", dto.AdminUserDefaultLimit, 0).
+					Return([]entity.User{}, nil)
+			},
+		},
+		{
+			name:    "it should return error nil and users when success",
+			payload: &dto.AdminUserGetAllIn{Search: "gopher", Limit: 10, Offset: 20},
+			expected: expected{
+				output: []dto.AdminUserGetAllOut{
+					{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", Role: entity.RoleUser, EmailVerified: true, SuspendedAt: suspendedAt, CreatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindAll", context.Background(), "gopher", 10, 20).
+					Return([]entity.User{
+						{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", Password: "hashed_password", Role: entity.RoleUser, EmailVerifiedAt: verifiedAt, SuspendedAt: suspendedAt, CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.GetAllUsers(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestGetUserByID() {
+	user := entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", Role: entity.RoleUser, EmailVerifiedAt: verifiedAt, CreatedAt: test.TimeBeforeNow}
+	usage := entity.UserUsage{Tasks: 12, Templates: 2, Filters: 3, Sessions: 1, PersonalTokens: 4}
+
+	type expected struct {
+		output dto.AdminUserGetByIDOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrUserNotFound when user does not exist",
+			expected: expected{output: dto.AdminUserGetByIDOut{}, err: domain.ErrUserNotFound},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error when user repository CountUsage return unexpected error",
+			expected: expected{output: dto.AdminUserGetByIDOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.userRepository.On("CountUsage", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.UserUsage{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository FindByUserID return unexpected error",
+			expected: expected{output: dto.AdminUserGetByIDOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.userRepository.On("CountUsage", context.Background(), entity.UserID("user-xxxxx")).
+					Return(usage, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and the user with usage when success",
+			expected: expected{
+				output: dto.AdminUserGetByIDOut{
+					ID:               "user-xxxxx",
+					Name:             "Gopher",
+					Email:            "gopher@go.dev",
+					Role:             entity.RoleUser,
+					EmailVerified:    true,
+					TwoFactorEnabled: true,
+					CreatedAt:        test.TimeBeforeNow,
+					Usage:            dto.AdminUserUsageOut{Tasks: 12, Templates: 2, Filters: 3, Sessions: 1, PersonalTokens: 4},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.userRepository.On("CountUsage", context.Background(), entity.UserID("user-xxxxx")).
+					Return(usage, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{UserID: "user-xxxxx", EnabledAt: verifiedAt}, nil)
+			},
+		},
+		{
+			name: "it should return error nil and two-factor disabled when user never set it up",
+			expected: expected{
+				output: dto.AdminUserGetByIDOut{
+					ID:            "user-xxxxx",
+					Name:          "Gopher",
+					Email:         "gopher@go.dev",
+					Role:          entity.RoleUser,
+					EmailVerified: true,
+					CreatedAt:     test.TimeBeforeNow,
+					Usage:         dto.AdminUserUsageOut{Tasks: 12, Templates: 2, Filters: 3, Sessions: 1, PersonalTokens: 4},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.userRepository.On("CountUsage", context.Background(), entity.UserID("user-xxxxx")).
+					Return(usage, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.GetUserByID(context.Background(), &dto.AdminUserGetByIDIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestSuspend() {
+	tests := []struct {
+		name     string
+		payload  *dto.AdminUserSuspendIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrAdminSelfSuspend when administrator suspend themselves",
+			payload:  &dto.AdminUserSuspendIn{AdminID: "user-xxxxx", UserID: "user-xxxxx"},
+			expected: domain.ErrAdminSelfSuspend,
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error ErrUserNotFound when user does not exist",
+			payload:  &dto.AdminUserSuspendIn{AdminID: "user-aaaaa", UserID: "user-xxxxx"},
+			expected: domain.ErrUserNotFound,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error when user repository Suspend return unexpected error",
+			payload:  &dto.AdminUserSuspendIn{AdminID: "user-aaaaa", UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.userRepository.On("Suspend", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when auth repository DeleteByUserID return unexpected error",
+			payload:  &dto.AdminUserSuspendIn{AdminID: "user-aaaaa", UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.userRepository.On("Suspend", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when revocation repository StoreWatermark return unexpected error",
+			payload:  &dto.AdminUserSuspendIn{AdminID: "user-aaaaa", UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.userRepository.On("Suspend", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and sign the user out when success",
+			payload:  &dto.AdminUserSuspendIn{AdminID: "user-aaaaa", UserID: "user-xxxxx"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.userRepository.On("Suspend", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Suspend(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestUnsuspend() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrUserNotFound when user does not exist",
+			expected: domain.ErrUserNotFound,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error when user repository Unsuspend return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", SuspendedAt: suspendedAt}, nil)
+				d.userRepository.On("Unsuspend", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", SuspendedAt: suspendedAt}, nil)
+				d.userRepository.On("Unsuspend", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Unsuspend(context.Background(), &dto.AdminUserUnsuspendIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestLogout() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrUserNotFound when user does not exist",
+			expected: domain.ErrUserNotFound,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error when auth repository DeleteByUserID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when revocation repository StoreWatermark return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Logout(context.Background(), &dto.AdminUserLogoutIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestResetTwoFactor() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrUserNotFound when user does not exist",
+			expected: domain.ErrUserNotFound,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository DeleteByUserID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.twoFactorRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when security event repository Store return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.twoFactorRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventTwoFactorReset}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.twoFactorRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventTwoFactorReset}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.ResetTwoFactor(context.Background(), &dto.AdminUserResetTwoFactorIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestGetLoginMethods() {
+	type expected struct {
+		output []dto.AdminLoginMethodGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when login method repository FindAll return unexpected error",
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and the login methods when success",
+			expected: expected{
+				output: []dto.AdminLoginMethodGetAllOut{
+					{Method: entity.LoginMethodMagicLink, Enabled: false, UpdatedAt: test.TimeBeforeNow},
+					{Method: entity.LoginMethodPassword, Enabled: true, UpdatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return([]entity.LoginMethodSetting{
+						{Method: entity.LoginMethodMagicLink, Enabled: false, UpdatedAt: test.TimeBeforeNow},
+						{Method: entity.LoginMethodPassword, Enabled: true, UpdatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.GetLoginMethods(context.Background())
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestUpdateLoginMethod() {
+	enabled, disabled := true, false
+	settings := []entity.LoginMethodSetting{
+		{Method: entity.LoginMethodMagicLink, Enabled: false, UpdatedAt: test.TimeBeforeNow},
+		{Method: entity.LoginMethodPassword, Enabled: true, UpdatedAt: test.TimeBeforeNow},
+	}
+
+	type args struct {
+		payload *dto.AdminLoginMethodUpdateIn
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when login method repository FindAll return unexpected error",
+			args:     args{payload: &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodMagicLink, Enabled: &enabled}},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrLoginMethodNotFound when login method does not exist",
+			args:     args{payload: &dto.AdminLoginMethodUpdateIn{Method: "carrier_pigeon", Enabled: &enabled}},
+			expected: domain.ErrLoginMethodNotFound,
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return(settings, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrLoginMethodLast when disabling the last enabled login method",
+			args:     args{payload: &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodPassword, Enabled: &disabled}},
+			expected: domain.ErrLoginMethodLast,
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return(settings, nil)
+			},
+		},
+		{
+			name:     "it should return error when login method repository Update return unexpected error",
+			args:     args{payload: &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodMagicLink, Enabled: &enabled}},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return(settings, nil)
+				d.loginMethodRepository.On("Update", context.Background(), entity.LoginMethodMagicLink, true).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully disable a login method while another one is enabled",
+			args:     args{payload: &dto.AdminLoginMethodUpdateIn{Method: entity.LoginMethodMagicLink, Enabled: &disabled}},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return(settings, nil)
+				d.loginMethodRepository.On("Update", context.Background(), entity.LoginMethodMagicLink, false).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.UpdateLoginMethod(context.Background(), t.args.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *AdminUsecaseTestSuite) TestGetSecurityEvents() {
+	type args struct {
+		payload *dto.AdminSecurityEventGetAllIn
+	}
+	type expected struct {
+		output []dto.AdminSecurityEventGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when security event repository FindAll return unexpected error",
+			args:     args{payload: &dto.AdminSecurityEventGetAllIn{}},
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.securityEventRepository.On("FindAll", context.Background(), entity.SecurityEventFilter{}, dto.SecurityEventDefaultLimit, 0).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and the matching events when success",
+			args: args{payload: &dto.AdminSecurityEventGetAllIn{
+				UserID:    "user-xxxxx",
+				Type:      entity.SecurityEventLoginFailed,
+				IPAddress: "203.0.113.7",
+				Since:     test.TimeBeforeNow,
+				Until:     test.TimeAfterNow,
+				Limit:     10,
+				Offset:    20,
+			}},
+			expected: expected{
+				output: []dto.AdminSecurityEventGetAllOut{
+					{ID: "security-event-xxxxx", UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", CreatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				filter := entity.SecurityEventFilter{UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed, IPAddress: "203.0.113.7", Since: test.TimeBeforeNow, Until: test.TimeAfterNow}
+				d.securityEventRepository.On("FindAll", context.Background(), filter, 10, 20).
+					Return([]entity.SecurityEvent{
+						{ID: "security-event-xxxxx", UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.GetSecurityEvents(context.Background(), t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+)
+
+const (
+	// authModeHeader select how a login returns the refresh token, browsers send
+	// "cookie" to keep it in an HttpOnly cookie instead of the response body.
+	authModeHeader = "X-Auth-Mode"
+	authModeCookie = "cookie"
+	// csrfHeader carry the double-submit csrf token of cookie authenticated requests.
+	csrfHeader = "X-CSRF-Token"
+
+	refreshTokenCookieName = "taskit_refresh_token"
+	refreshTokenCookiePath = "/api/authentications"
+	csrfTokenCookieName    = "taskit_csrf_token"
+
+	// magicLinkNonceCookie bind a login link to the browser it was requested from,
+	// it lives as long as the login link.
+	magicLinkNonceCookieName   = "taskit_magic_link_nonce"
+	magicLinkNonceCookiePath   = "/api/authentications/magic-link"
+	magicLinkNonceCookieMaxAge = 15 * 60
+
+	// oidcStateCookie bind an OpenID Connect login to the browser that started it,
+	// it lives as long as the state.
+	oidcStateCookieName   = "taskit_oidc_state"
+	oidcStateCookiePath   = "/api/authentications/oidc"
+	oidcStateCookieMaxAge = 10 * 60
+)
+
+// CookieConfig represent the cookies of the browser authentication mode.
+type CookieConfig struct {
+	Domain   string
+	SameSite string
+	MaxAge   int
+}
+
+// sameSite parse the SameSite attribute of the cookies, it defaults to strict.
+func (c CookieConfig) sameSite() http.SameSite {
+	switch strings.ToLower(c.SameSite) {
+	case "lax":
+		return http.SameSiteLaxMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteStrictMode
+	}
+}
+
+// useCookie report whether a login asked for the cookie mode.
+func useCookie(r *http.Request) bool {
+	return r.Header.Get(authModeHeader) == authModeCookie
+}
+
+// setAuthCookies store the refresh token in an HttpOnly cookie scoped to the refresh path,
+// together with a new csrf token the client has to echo in the csrfHeader.
+// The csrf token is returned too, since the client can not read cookies of another host.
+func (h *HTTPHandler) setAuthCookies(w http.ResponseWriter, refreshToken string) (string, error) {
+	csrfToken, err := h.tokenProvider.Generate()
+	if err != nil {
+		return "", err
+	}
+
+	http.SetCookie(w, h.newCookie(refreshTokenCookieName, refreshToken, refreshTokenCookiePath, h.cookie.MaxAge, true))
+	http.SetCookie(w, h.newCookie(csrfTokenCookieName, csrfToken, "/", h.cookie.MaxAge, false))
+	return csrfToken, nil
+}
+
+// clearAuthCookies expire the cookies of the cookie mode.
+func (h *HTTPHandler) clearAuthCookies(w http.ResponseWriter) {
+	http.SetCookie(w, h.newCookie(refreshTokenCookieName, "", refreshTokenCookiePath, -1, true))
+	http.SetCookie(w, h.newCookie(csrfTokenCookieName, "", "/", -1, false))
+}
+
+// setMagicLinkNonceCookie store the nonce of a login link bound to the browser.
+func (h *HTTPHandler) setMagicLinkNonceCookie(w http.ResponseWriter, nonce string) {
+	http.SetCookie(w, h.newCookie(magicLinkNonceCookieName, nonce, magicLinkNonceCookiePath, magicLinkNonceCookieMaxAge, true))
+}
+
+// clearMagicLinkNonceCookie expire the nonce cookie once its login link is used.
+func (h *HTTPHandler) clearMagicLinkNonceCookie(w http.ResponseWriter) {
+	http.SetCookie(w, h.newCookie(magicLinkNonceCookieName, "", magicLinkNonceCookiePath, -1, true))
+}
+
+// magicLinkNonceFromCookie get the nonce of a login link, empty when the browser has none.
+func magicLinkNonceFromCookie(r *http.Request) string {
+	cookie, err := r.Cookie(magicLinkNonceCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// setOIDCStateCookie store the state of an OpenID Connect login started by the browser.
+func (h *HTTPHandler) setOIDCStateCookie(w http.ResponseWriter, state string) {
+	http.SetCookie(w, h.newCookie(oidcStateCookieName, state, oidcStateCookiePath, oidcStateCookieMaxAge, true))
+}
+
+// clearOIDCStateCookie expire the state cookie once its login is finished.
+func (h *HTTPHandler) clearOIDCStateCookie(w http.ResponseWriter) {
+	http.SetCookie(w, h.newCookie(oidcStateCookieName, "", oidcStateCookiePath, -1, true))
+}
+
+// oidcStateFromCookie get the state of an OpenID Connect login, empty when the browser has none.
+func oidcStateFromCookie(r *http.Request) string {
+	cookie, err := r.Cookie(oidcStateCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+func (h *HTTPHandler) newCookie(name string, value string, path string, maxAge int, httpOnly bool) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     path,
+		Domain:   h.cookie.Domain,
+		MaxAge:   maxAge,
+		Secure:   true,
+		HttpOnly: httpOnly,
+		SameSite: h.cookie.sameSite(),
+	}
+}
+
+// refreshTokenFromCookie get the refresh token of a cookie authenticated request.
+// It is empty for bearer clients, otherwise the csrf header must match the csrf cookie,
+// which a cross-site request can neither read nor set.
+func refreshTokenFromCookie(r *http.Request) (string, error) {
+	refreshCookie, err := r.Cookie(refreshTokenCookieName)
+	if err != nil || refreshCookie.Value == "" {
+		return "", nil
+	}
+
+	csrfCookie, err := r.Cookie(csrfTokenCookieName)
+	if err != nil || csrfCookie.Value == "" {
+		return "", dto.ErrCSRFTokenInvalid
+	}
+	if subtle.ConstantTimeCompare([]byte(csrfCookie.Value), []byte(r.Header.Get(csrfHeader))) != 1 {
+		return "", dto.ErrCSRFTokenInvalid
+	}
+	return refreshCookie.Value, nil
+}
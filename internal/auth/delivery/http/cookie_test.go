@@ -0,0 +1,129 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+)
+
+// responseCookies get the cookies set by a response by name, it is nil when none are set.
+func responseCookies(rr *httptest.ResponseRecorder) map[string]string {
+	var cookies map[string]string
+	for _, cookie := range rr.Result().Cookies() {
+		if cookies == nil {
+			cookies = map[string]string{}
+		}
+		cookies[cookie.Name] = cookie.Value
+	}
+	return cookies
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestSetAuthCookies() {
+	tokenProvider := &mocks.TokenProvider{}
+	tokenProvider.On("Generate").Return("csrf_token", nil)
+
+	rr := httptest.NewRecorder()
+	handler := New(nil, nil, tokenProvider, CookieConfig{Domain: "taskit.dev", SameSite: "lax", MaxAge: 3600})
+	csrfToken, err := handler.setAuthCookies(rr, "yyyyy.yyyyy.yyyyy")
+
+	s.NoError(err)
+	s.Equal("csrf_token", csrfToken)
+
+	cookies := rr.Result().Cookies()
+	s.Len(cookies, 2)
+
+	s.Equal("taskit_refresh_token", cookies[0].Name)
+	s.Equal("yyyyy.yyyyy.yyyyy", cookies[0].Value)
+	s.Equal("/api/authentications", cookies[0].Path)
+	s.Equal("taskit.dev", cookies[0].Domain)
+	s.Equal(3600, cookies[0].MaxAge)
+	s.True(cookies[0].Secure)
+	s.True(cookies[0].HttpOnly)
+	s.Equal(http.SameSiteLaxMode, cookies[0].SameSite)
+
+	s.Equal("taskit_csrf_token", cookies[1].Name)
+	s.Equal("csrf_token", cookies[1].Value)
+	s.Equal("/", cookies[1].Path)
+	s.True(cookies[1].Secure)
+	s.False(cookies[1].HttpOnly)
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestCookieConfigSameSite() {
+	tests := []struct {
+		name     string
+		sameSite string
+		expected http.SameSite
+	}{
+		{name: "it should return strict mode when not provided", sameSite: "", expected: http.SameSiteStrictMode},
+		{name: "it should return strict mode when unknown", sameSite: "unknown", expected: http.SameSiteStrictMode},
+		{name: "it should return lax mode when lax", sameSite: "Lax", expected: http.SameSiteLaxMode},
+		{name: "it should return none mode when none", sameSite: "none", expected: http.SameSiteNoneMode},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			s.Equal(t.expected, CookieConfig{SameSite: t.sameSite}.sameSite())
+		})
+	}
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestRefreshTokenFromCookie() {
+	type expected struct {
+		refreshToken string
+		err          error
+	}
+	tests := []struct {
+		name      string
+		cookies   []*http.Cookie
+		csrfToken string
+		expected  expected
+	}{
+		{
+			name:     "it should return empty refresh token when refresh token cookie is not provided",
+			cookies:  []*http.Cookie{{Name: "taskit_csrf_token", Value: "csrf_token"}},
+			expected: expected{refreshToken: "", err: nil},
+		},
+		{
+			name:      "it should return error ErrCSRFTokenInvalid when csrf cookie is not provided",
+			cookies:   []*http.Cookie{{Name: "taskit_refresh_token", Value: "yyyyy.yyyyy.yyyyy"}},
+			csrfToken: "csrf_token",
+			expected:  expected{refreshToken: "", err: dto.ErrCSRFTokenInvalid},
+		},
+		{
+			name:     "it should return error ErrCSRFTokenInvalid when csrf header is not provided",
+			cookies:  []*http.Cookie{{Name: "taskit_refresh_token", Value: "yyyyy.yyyyy.yyyyy"}, {Name: "taskit_csrf_token", Value: "csrf_token"}},
+			expected: expected{refreshToken: "", err: dto.ErrCSRFTokenInvalid},
+		},
+		{
+			name:      "it should return error ErrCSRFTokenInvalid when csrf header does not match",
+			cookies:   []*http.Cookie{{Name: "taskit_refresh_token", Value: "yyyyy.yyyyy.yyyyy"}, {Name: "taskit_csrf_token", Value: "csrf_token"}},
+			csrfToken: "other_csrf_token",
+			expected:  expected{refreshToken: "", err: dto.ErrCSRFTokenInvalid},
+		},
+		{
+			name:      "it should return refresh token when csrf header match",
+			cookies:   []*http.Cookie{{Name: "taskit_refresh_token", Value: "yyyyy.yyyyy.yyyyy"}, {Name: "taskit_csrf_token", Value: "csrf_token"}},
+			csrfToken: "csrf_token",
+			expected:  expected{refreshToken: "yyyyy.yyyyy.yyyyy", err: nil},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			req := httptest.NewRequest("PUT", "/", nil)
+			if t.csrfToken != "" {
+				req.Header.Set("X-CSRF-Token", t.csrfToken)
+			}
+			for _, cookie := range t.cookies {
+				req.AddCookie(cookie)
+			}
+
+			refreshToken, err := refreshTokenFromCookie(req)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.refreshToken, refreshToken)
+		})
+	}
+}
@@ -2,25 +2,34 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/pkg/httpsvr"
 )
 
 type HTTPHandler struct {
-	validator   domain.ValidatorProvider
-	authUsecase domain.AuthUsecase
+	validator     domain.ValidatorProvider
+	authUsecase   domain.AuthUsecase
+	tokenProvider domain.TokenProvider
+	cookie        CookieConfig
 }
 
 // New creates a new auth handler
-func New(validator domain.ValidatorProvider, authUsecase domain.AuthUsecase) HTTPHandler {
-	return HTTPHandler{validator: validator, authUsecase: authUsecase}
+func New(validator domain.ValidatorProvider, authUsecase domain.AuthUsecase, tokenProvider domain.TokenProvider, cookie CookieConfig) HTTPHandler {
+	return HTTPHandler{validator: validator, authUsecase: authUsecase, tokenProvider: tokenProvider, cookie: cookie}
 }
 
 // POST /authentications to login user
+// With the X-Auth-Mode: cookie header the refresh token is set as an HttpOnly cookie.
 func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	encoder := json.NewEncoder(w)
@@ -31,6 +40,8 @@ func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
 		return
 	}
+	payload.UserAgent = r.UserAgent()
+	payload.IPAddress = httpsvr.ClientIP(r)
 	if err := h.validator.Validate(&payload); err != nil {
 		code, msg := errorx.HTTPErrorTranslator(err)
 		w.WriteHeader(code)
@@ -39,15 +50,196 @@ func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 	}
 
 	output, err := h.authUsecase.Login(r.Context(), &payload)
+	if err != nil {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	h.writeTokens(w, r, output)
+}
+
+// POST /authentications/mfa to complete login with a second factor
+func (h *HTTPHandler) PostMFA(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AuthVerifyMFAIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserAgent = r.UserAgent()
+	payload.IPAddress = httpsvr.ClientIP(r)
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.authUsecase.VerifyMFA(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	h.writeTokens(w, r, output)
+}
+
+// GET /authentications/oidc/{provider} to start login with an OpenID Connect provider
+// The state is set as an HttpOnly cookie, so only this browser can finish the login.
+func (h *HTTPHandler) GetOIDC(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AuthOIDCStartIn
+	payload.Provider = chi.URLParam(r, "provider")
+
+	output, err := h.authUsecase.StartOIDC(r.Context(), &payload)
 	if err != nil {
 		code, msg := errorx.HTTPErrorTranslator(err)
 		w.WriteHeader(code)
 		encoder.Encode(domain.NewErrorResponse(code, msg))
 		return
 	}
+	h.setOIDCStateCookie(w, output.State)
 
 	w.WriteHeader(http.StatusOK)
-	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully logged in user", output))
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// POST /authentications/oidc/{provider}/callback to finish login with an OpenID Connect provider
+func (h *HTTPHandler) PostOIDCCallback(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AuthOIDCLoginIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.Provider = chi.URLParam(r, "provider")
+	payload.BrowserState = oidcStateFromCookie(r)
+	payload.UserAgent = r.UserAgent()
+	payload.IPAddress = httpsvr.ClientIP(r)
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.authUsecase.LoginOIDC(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+	h.clearOIDCStateCookie(w)
+
+	h.writeTokens(w, r, output)
+}
+
+// POST /authentications/magic-link to mail a login link
+func (h *HTTPHandler) PostMagicLink(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AuthMagicLinkRequestIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.IPAddress = httpsvr.ClientIP(r)
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.authUsecase.RequestMagicLink(r.Context(), &payload)
+	if err != nil {
+		var throttled *domain.MagicLinkThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+		}
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	if output.Nonce != "" {
+		h.setMagicLinkNonceCookie(w, output.Nonce)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "A login link is sent if the email is registered", nil))
+}
+
+// POST /authentications/magic-link/confirm to login with a login link
+func (h *HTTPHandler) PostMagicLinkConfirm(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.AuthMagicLinkLoginIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.Nonce = magicLinkNonceFromCookie(r)
+	payload.UserAgent = r.UserAgent()
+	payload.IPAddress = httpsvr.ClientIP(r)
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.authUsecase.LoginMagicLink(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+	if payload.Nonce != "" {
+		h.clearMagicLinkNonceCookie(w)
+	}
+
+	h.writeTokens(w, r, output)
+}
+
+// GET /authentications/methods to get the login methods users can log in with
+func (h *HTTPHandler) GetLoginMethods(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	output, err := h.authUsecase.GetLoginMethods(r.Context())
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
 }
 
 // DELETE /authentications to logout from current authentication
@@ -55,12 +247,25 @@ func (h *HTTPHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	encoder := json.NewEncoder(w)
 
+	refreshToken, err := refreshTokenFromCookie(r)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
 	var payload dto.AuthLogoutIn
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+	if refreshToken != "" {
+		payload.RefreshToken = refreshToken
+	} else if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
 		return
 	}
+	payload.AccessToken = entity.GetAuthTokenContext(r.Context())
+	payload.UserAgent = r.UserAgent()
+	payload.IPAddress = httpsvr.ClientIP(r)
 	if err := h.validator.Validate(&payload); err != nil {
 		code, msg := errorx.HTTPErrorTranslator(err)
 		w.WriteHeader(code)
@@ -68,14 +273,17 @@ func (h *HTTPHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := h.authUsecase.Logout(r.Context(), &payload)
-	if err != nil {
+	if err := h.authUsecase.Logout(r.Context(), &payload); err != nil {
 		code, msg := errorx.HTTPErrorTranslator(err)
 		w.WriteHeader(code)
 		encoder.Encode(domain.NewErrorResponse(code, msg))
 		return
 	}
 
+	if refreshToken != "" {
+		h.clearAuthCookies(w)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully logout user", nil))
 }
@@ -101,16 +309,29 @@ func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
 }
 
 // PUT /authentications to refresh authentication token
+// Cookie mode clients send no body, their refresh token cookie is rotated instead.
 func (h *HTTPHandler) Put(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	encoder := json.NewEncoder(w)
 
+	refreshToken, err := refreshTokenFromCookie(r)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
 	var payload dto.AuthRefreshIn
-	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+	if refreshToken != "" {
+		payload.RefreshToken = refreshToken
+	} else if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
 		w.WriteHeader(http.StatusBadRequest)
 		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
 		return
 	}
+	payload.UserAgent = r.UserAgent()
+	payload.IPAddress = httpsvr.ClientIP(r)
 	if err := h.validator.Validate(&payload); err != nil {
 		code, msg := errorx.HTTPErrorTranslator(err)
 		w.WriteHeader(code)
@@ -126,6 +347,62 @@ func (h *HTTPHandler) Put(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if refreshToken != "" {
+		csrfToken, err := h.setAuthCookies(w, output.RefreshToken)
+		if err != nil {
+			code, msg := errorx.HTTPErrorTranslator(err)
+			w.WriteHeader(code)
+			encoder.Encode(domain.NewErrorResponse(code, msg))
+			return
+		}
+		output.RefreshToken, output.CSRFToken = "", csrfToken
+	}
+
 	w.WriteHeader(http.StatusOK)
 	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully refreshed authentication token", output))
 }
+
+// GET /.well-known/jwks.json to get the public keys access tokens can be verified with.
+// The key set is served as is, since JWT libraries expect the standard format.
+func (h *HTTPHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	output, err := h.authUsecase.GetJWKS(r.Context())
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(output)
+}
+
+// writeTokens respond with the tokens of a login, or with the mfa token when a second factor is required.
+// In the cookie mode the refresh token is moved to an HttpOnly cookie and a csrf token is returned instead.
+func (h *HTTPHandler) writeTokens(w http.ResponseWriter, r *http.Request, output dto.AuthLoginOut) {
+	encoder := json.NewEncoder(w)
+
+	if output.MFAToken != "" {
+		w.WriteHeader(http.StatusOK)
+		encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Two-factor authentication required", output))
+		return
+	}
+
+	if useCookie(r) {
+		csrfToken, err := h.setAuthCookies(w, output.RefreshToken)
+		if err != nil {
+			code, msg := errorx.HTTPErrorTranslator(err)
+			w.WriteHeader(code)
+			encoder.Encode(domain.NewErrorResponse(code, msg))
+			return
+		}
+		output.RefreshToken, output.CSRFToken = "", csrfToken
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully logged in user", output))
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -15,6 +16,7 @@ import (
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 	"github.com/edwintantawi/taskit/internal/domain/mocks"
 	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/pkg/oidc"
 	"github.com/edwintantawi/taskit/test"
 )
 
@@ -27,21 +29,25 @@ func TestAuthHTTPHandlerSuite(t *testing.T) {
 }
 
 type dependency struct {
-	req         *http.Request
-	validator   *mocks.ValidatorProvider
-	authUsecase *mocks.AuthUsecase
+	req           *http.Request
+	validator     *mocks.ValidatorProvider
+	authUsecase   *mocks.AuthUsecase
+	tokenProvider *mocks.TokenProvider
 }
 
 func (s *AuthHTTPHandlerTestSuite) TestPost() {
 	type args struct {
 		requestBody []byte
+		authMode    string
 	}
 	type expected struct {
 		contentType string
+		retryAfter  string
 		statusCode  int
 		message     string
 		error       string
 		payload     map[string]any
+		cookies     map[string]string
 	}
 	tests := []struct {
 		name     string
@@ -77,13 +83,763 @@ func (s *AuthHTTPHandlerTestSuite) TestPost() {
 				error:       errorx.InternalServerErrorMessage,
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthLoginIn{}).
+				d.validator.On("Validate", &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(test.ErrValidator)
 			},
 		},
+		{
+			name:    "it should response with error and retry after when login is throttled",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				retryAfter:  "91",
+				statusCode:  http.StatusTooManyRequests,
+				message:     http.StatusText(http.StatusTooManyRequests),
+				error:       "Too many failed login attempts, please try again later",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("Login", mock.Anything, &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{}, &domain.LoginThrottledError{RetryAfter: 90*time.Second + 200*time.Millisecond})
+			},
+		},
+		{
+			name:    "it should response with error when auth usecase Login return unexpected error",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("Login", mock.Anything, &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should response with success when success",
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully logged in user",
+				payload: map[string]any{
+					"access_token":  "xxxxx.xxxxx.xxxxx",
+					"refresh_token": "yyyyy.yyyyy.yyyyy",
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("Login", mock.Anything, &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, nil)
+			},
+		},
+		{
+			name:    "it should response with error when generate csrf token failed in cookie mode",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{}`),
+				authMode:    "cookie",
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("Login", mock.Anything, &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, nil)
+
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should response with success and set the refresh token cookie when success in cookie mode",
+			args: args{
+				requestBody: []byte(`{}`),
+				authMode:    "cookie",
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully logged in user",
+				payload: map[string]any{
+					"access_token": "xxxxx.xxxxx.xxxxx",
+					"csrf_token":   "csrf_token",
+				},
+				cookies: map[string]string{
+					"taskit_refresh_token": "yyyyy.yyyyy.yyyyy",
+					"taskit_csrf_token":    "csrf_token",
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("Login", mock.Anything, &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, nil)
+
+				d.tokenProvider.On("Generate").Return("csrf_token", nil)
+			},
+		},
+		{
+			name: "it should response with mfa token when two-factor authentication is required",
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Two-factor authentication required",
+				payload: map[string]any{
+					"mfa_token": "mfa_token",
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("Login", mock.Anything, &dto.AuthLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{MFAToken: "mfa_token"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			reqBody := bytes.NewReader(t.args.requestBody)
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", reqBody)
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+			req.Header.Set("X-Auth-Mode", t.args.authMode)
+
+			d := &dependency{
+				validator:     &mocks.ValidatorProvider{},
+				authUsecase:   &mocks.AuthUsecase{},
+				tokenProvider: &mocks.TokenProvider{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.authUsecase, d.tokenProvider, CookieConfig{MaxAge: 3600})
+			handler.Post(rr, req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.retryAfter, rr.Header().Get("Retry-After"))
+			s.Equal(t.expected.statusCode, rr.Code)
+			s.Equal(t.expected.cookies, responseCookies(rr))
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+				payloadMap := resBody.Payload.(map[string]any)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, payloadMap)
+			}
+		})
+	}
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestPostMFA() {
+	type args struct {
+		requestBody []byte
+	}
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     map[string]any
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when request body is invalid or not provided",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthVerifyMFAIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(test.ErrValidator)
+			},
+		},
+
+		{
+			name:    "it should response with error when auth usecase VerifyMFA return unexpected error",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthVerifyMFAIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("VerifyMFA", mock.Anything, &dto.AuthVerifyMFAIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should response with success when success",
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully logged in user",
+				payload: map[string]any{
+					"access_token":  "xxxxx.xxxxx.xxxxx",
+					"refresh_token": "yyyyy.yyyyy.yyyyy",
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthVerifyMFAIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("VerifyMFA", mock.Anything, &dto.AuthVerifyMFAIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			reqBody := bytes.NewReader(t.args.requestBody)
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", reqBody)
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+
+			d := &dependency{
+				validator:     &mocks.ValidatorProvider{},
+				authUsecase:   &mocks.AuthUsecase{},
+				tokenProvider: &mocks.TokenProvider{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.authUsecase, d.tokenProvider, CookieConfig{MaxAge: 3600})
+			handler.PostMFA(rr, req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+				payloadMap := resBody.Payload.(map[string]any)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, payloadMap)
+			}
+		})
+	}
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestGetOIDC() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     map[string]any
+		cookies     map[string]string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when auth usecase StartOIDC return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "Identity provider not found",
+			},
+			setup: func(d *dependency) {
+				d.authUsecase.On("StartOIDC", mock.Anything, &dto.AuthOIDCStartIn{Provider: "acme"}).
+					Return(dto.AuthOIDCStartOut{}, oidc.ErrProviderUnknown)
+			},
+		},
+		{
+			name:    "it should response with success and set the state cookie when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: map[string]any{
+					"authorization_url": "https://idp.acme.dev/authorize?state=state",
+				},
+				cookies: map[string]string{"taskit_oidc_state": "state"},
+			},
+			setup: func(d *dependency) {
+				d.authUsecase.On("StartOIDC", mock.Anything, &dto.AuthOIDCStartIn{Provider: "acme"}).
+					Return(dto.AuthOIDCStartOut{AuthorizationURL: "https://idp.acme.dev/authorize?state=state", State: "state"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"provider": "acme"})
+
+			d := &dependency{
+				req:         req,
+				authUsecase: &mocks.AuthUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.authUsecase, nil, CookieConfig{})
+			handler.GetOIDC(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+			s.Equal(t.expected.cookies, responseCookies(rr))
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+				payloadMap := resBody.Payload.(map[string]any)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, payloadMap)
+			}
+		})
+	}
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestPostOIDCCallback() {
+	type args struct {
+		requestBody []byte
+		cookies     []*http.Cookie
+	}
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     map[string]any
+		cookies     map[string]string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when request body is invalid or not provided",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthOIDCLoginIn{Provider: "acme", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(test.ErrValidator)
+			},
+		},
+
+		{
+			name:    "it should response with error and keep the state cookie when auth usecase LoginOIDC return unexpected error",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthOIDCLoginIn{Provider: "acme", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("LoginOIDC", mock.Anything, &dto.AuthOIDCLoginIn{Provider: "acme", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should response with success and clear the state cookie when success",
+			args: args{
+				requestBody: []byte(`{"code":"code","state":"state"}`),
+				cookies:     []*http.Cookie{{Name: "taskit_oidc_state", Value: "state"}},
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully logged in user",
+				payload: map[string]any{
+					"access_token":  "xxxxx.xxxxx.xxxxx",
+					"refresh_token": "yyyyy.yyyyy.yyyyy",
+				},
+				cookies: map[string]string{"taskit_oidc_state": ""},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthOIDCLoginIn{Provider: "acme", Code: "code", State: "state", BrowserState: "state", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("LoginOIDC", mock.Anything, &dto.AuthOIDCLoginIn{Provider: "acme", Code: "code", State: "state", BrowserState: "state", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			reqBody := bytes.NewReader(t.args.requestBody)
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", reqBody)
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+			for _, cookie := range t.args.cookies {
+				req.AddCookie(cookie)
+			}
+			req = test.InjectChiRouterParams(req, map[string]string{"provider": "acme"})
+
+			d := &dependency{
+				validator:     &mocks.ValidatorProvider{},
+				authUsecase:   &mocks.AuthUsecase{},
+				tokenProvider: &mocks.TokenProvider{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.authUsecase, d.tokenProvider, CookieConfig{MaxAge: 3600})
+			handler.PostOIDCCallback(rr, req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+			s.Equal(t.expected.cookies, responseCookies(rr))
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+				payloadMap := resBody.Payload.(map[string]any)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, payloadMap)
+			}
+		})
+	}
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestPostMagicLink() {
+	type args struct {
+		requestBody []byte
+	}
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		retryAfter  string
+		cookies     map[string]string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when request body is invalid or not provided",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthMagicLinkRequestIn{IPAddress: "192.0.2.1"}).
+					Return(test.ErrValidator)
+			},
+		},
+		{
+			name:    "it should response with error when auth usecase RequestMagicLink return unexpected error",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{"email":"gopher@go.dev"}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("RequestMagicLink", mock.Anything, &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthMagicLinkRequestOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with error and retry after when auth usecase RequestMagicLink return throttled error",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{"email":"gopher@go.dev"}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusTooManyRequests,
+				message:     http.StatusText(http.StatusTooManyRequests),
+				error:       "Too many login link requests, please try again later",
+				retryAfter:  "90",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("RequestMagicLink", mock.Anything, &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthMagicLinkRequestOut{}, &domain.MagicLinkThrottledError{RetryAfter: 90 * time.Second})
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			args: args{
+				requestBody: []byte(`{"email":"gopher@go.dev"}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "A login link is sent if the email is registered",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("RequestMagicLink", mock.Anything, &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthMagicLinkRequestOut{}, nil)
+			},
+		},
+		{
+			name:    "it should response with success and set the nonce cookie when login link is bound to the browser",
+			isError: false,
+			args: args{
+				requestBody: []byte(`{"email":"gopher@go.dev","bind_browser":true}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "A login link is sent if the email is registered",
+				cookies:     map[string]string{"taskit_magic_link_nonce": "nonce"},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", BindBrowser: true, IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("RequestMagicLink", mock.Anything, &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", BindBrowser: true, IPAddress: "192.0.2.1"}).
+					Return(dto.AuthMagicLinkRequestOut{Nonce: "nonce"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			reqBody := bytes.NewReader(t.args.requestBody)
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", reqBody)
+
+			d := &dependency{
+				validator:   &mocks.ValidatorProvider{},
+				authUsecase: &mocks.AuthUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.authUsecase, nil, CookieConfig{MaxAge: 3600})
+			handler.PostMagicLink(rr, req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+			s.Equal(t.expected.cookies, responseCookies(rr))
+			s.Equal(t.expected.retryAfter, rr.Header().Get("Retry-After"))
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
 
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Nil(resBody.Payload)
+			}
+		})
+	}
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestPostMagicLinkConfirm() {
+	type args struct {
+		requestBody []byte
+		cookies     []*http.Cookie
+		authMode    string
+	}
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     map[string]any
+		cookies     map[string]string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
 		{
-			name:    "it should response with error when auth usecase Login return unexpected error",
+			name:    "it should response with error when request body is invalid or not provided",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
 			isError: true,
 			args: args{
 				requestBody: []byte(`{}`),
@@ -95,17 +851,57 @@ func (s *AuthHTTPHandlerTestSuite) TestPost() {
 				error:       errorx.InternalServerErrorMessage,
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthLoginIn{}).
+				d.validator.On("Validate", &dto.AuthMagicLinkLoginIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(test.ErrValidator)
+			},
+		},
+		{
+			name:    "it should response with error and keep the nonce cookie when auth usecase LoginMagicLink return error",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{"token":"magic_token"}`),
+				cookies:     []*http.Cookie{{Name: "taskit_magic_link_nonce", Value: "nonce"}},
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Login link was requested from another browser",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthMagicLinkLoginIn{Token: "magic_token", Nonce: "nonce", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(nil)
 
-				d.authUsecase.On("Login", mock.Anything, &dto.AuthLoginIn{}).
-					Return(dto.AuthLoginOut{}, test.ErrUnexpected)
+				d.authUsecase.On("LoginMagicLink", mock.Anything, &dto.AuthMagicLinkLoginIn{Token: "magic_token", Nonce: "nonce", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{}, entity.ErrMagicLinkNonceMismatch)
 			},
 		},
 		{
-			name: "it should response with success when success",
+			name:    "it should response with mfa token when user has two-factor authentication enabled",
+			isError: false,
 			args: args{
-				requestBody: []byte(`{}`),
+				requestBody: []byte(`{"token":"magic_token"}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Two-factor authentication required",
+				payload:     map[string]any{"mfa_token": "mfa_token"},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthMagicLinkLoginIn{Token: "magic_token", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("LoginMagicLink", mock.Anything, &dto.AuthMagicLinkLoginIn{Token: "magic_token", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{MFAToken: "mfa_token"}, nil)
+			},
+		},
+		{
+			name:    "it should response with success and clear the nonce cookie when success",
+			isError: false,
+			args: args{
+				requestBody: []byte(`{"token":"magic_token"}`),
+				cookies:     []*http.Cookie{{Name: "taskit_magic_link_nonce", Value: "nonce"}},
 			},
 			expected: expected{
 				contentType: "application/json",
@@ -115,13 +911,44 @@ func (s *AuthHTTPHandlerTestSuite) TestPost() {
 					"access_token":  "xxxxx.xxxxx.xxxxx",
 					"refresh_token": "yyyyy.yyyyy.yyyyy",
 				},
+				cookies: map[string]string{"taskit_magic_link_nonce": ""},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthMagicLinkLoginIn{Token: "magic_token", Nonce: "nonce", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("LoginMagicLink", mock.Anything, &dto.AuthMagicLinkLoginIn{Token: "magic_token", Nonce: "nonce", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, nil)
+			},
+		},
+		{
+			name:    "it should response with success and set the auth cookies when success in cookie mode",
+			isError: false,
+			args: args{
+				requestBody: []byte(`{"token":"magic_token"}`),
+				authMode:    "cookie",
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully logged in user",
+				payload: map[string]any{
+					"access_token": "xxxxx.xxxxx.xxxxx",
+					"csrf_token":   "csrf_token",
+				},
+				cookies: map[string]string{
+					"taskit_refresh_token": "yyyyy.yyyyy.yyyyy",
+					"taskit_csrf_token":    "csrf_token",
+				},
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthLoginIn{}).
+				d.validator.On("Validate", &dto.AuthMagicLinkLoginIn{Token: "magic_token", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(nil)
 
-				d.authUsecase.On("Login", mock.Anything, &dto.AuthLoginIn{}).
+				d.authUsecase.On("LoginMagicLink", mock.Anything, &dto.AuthMagicLinkLoginIn{Token: "magic_token", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, nil)
+
+				d.tokenProvider.On("Generate").Return("csrf_token", nil)
 			},
 		},
 	}
@@ -131,15 +958,102 @@ func (s *AuthHTTPHandlerTestSuite) TestPost() {
 			reqBody := bytes.NewReader(t.args.requestBody)
 			rr := httptest.NewRecorder()
 			req := httptest.NewRequest("POST", "/", reqBody)
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+			req.Header.Set("X-Auth-Mode", t.args.authMode)
+			for _, cookie := range t.args.cookies {
+				req.AddCookie(cookie)
+			}
+
+			d := &dependency{
+				validator:     &mocks.ValidatorProvider{},
+				authUsecase:   &mocks.AuthUsecase{},
+				tokenProvider: &mocks.TokenProvider{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.authUsecase, d.tokenProvider, CookieConfig{MaxAge: 3600})
+			handler.PostMagicLinkConfirm(rr, req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+			s.Equal(t.expected.cookies, responseCookies(rr))
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+				payloadMap := resBody.Payload.(map[string]any)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, payloadMap)
+			}
+		})
+	}
+}
+
+func (s *AuthHTTPHandlerTestSuite) TestGetLoginMethods() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     map[string]any
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when auth usecase GetLoginMethods return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.authUsecase.On("GetLoginMethods", mock.Anything).
+					Return(dto.AuthLoginMethodsOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with the enabled login methods when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload:     map[string]any{"methods": []any{"magic_link", "password"}},
+			},
+			setup: func(d *dependency) {
+				d.authUsecase.On("GetLoginMethods", mock.Anything).
+					Return(dto.AuthLoginMethodsOut{Methods: []entity.LoginMethod{entity.LoginMethodMagicLink, entity.LoginMethodPassword}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
 
 			d := &dependency{
-				validator:   &mocks.ValidatorProvider{},
 				authUsecase: &mocks.AuthUsecase{},
 			}
 			t.setup(d)
 
-			handler := New(d.validator, d.authUsecase)
-			handler.Post(rr, req)
+			handler := New(nil, d.authUsecase, nil, CookieConfig{})
+			handler.GetLoginMethods(rr, req)
 
 			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
 			s.Equal(t.expected.statusCode, rr.Code)
@@ -167,6 +1081,8 @@ func (s *AuthHTTPHandlerTestSuite) TestPost() {
 func (s *AuthHTTPHandlerTestSuite) TestDelete() {
 	type args struct {
 		requestBody []byte
+		cookies     []*http.Cookie
+		csrfToken   string
 	}
 	type expected struct {
 		contentType string
@@ -174,6 +1090,7 @@ func (s *AuthHTTPHandlerTestSuite) TestDelete() {
 		message     string
 		error       string
 		payload     map[string]any
+		cookies     map[string]string
 	}
 	tests := []struct {
 		name     string
@@ -196,6 +1113,21 @@ func (s *AuthHTTPHandlerTestSuite) TestDelete() {
 			},
 			setup: func(d *dependency) {},
 		},
+		{
+			name:    "it should response with error when csrf cookie is missing in cookie mode",
+			isError: true,
+			args: args{
+				cookies:   []*http.Cookie{{Name: "taskit_refresh_token", Value: "yyyyy.yyyyy.yyyyy"}},
+				csrfToken: "csrf_token",
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "CSRF token is missing or invalid",
+			},
+			setup: func(d *dependency) {},
+		},
 		{
 			name:    "it should response with error when payload is not valid",
 			isError: true,
@@ -209,7 +1141,7 @@ func (s *AuthHTTPHandlerTestSuite) TestDelete() {
 				error:       errorx.InternalServerErrorMessage,
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthLogoutIn{}).
+				d.validator.On("Validate", &dto.AuthLogoutIn{IPAddress: "192.0.2.1"}).
 					Return(test.ErrValidator)
 			},
 		},
@@ -226,10 +1158,10 @@ func (s *AuthHTTPHandlerTestSuite) TestDelete() {
 				error:       errorx.InternalServerErrorMessage,
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthLogoutIn{}).
+				d.validator.On("Validate", &dto.AuthLogoutIn{IPAddress: "192.0.2.1"}).
 					Return(nil)
 
-				d.authUsecase.On("Logout", mock.Anything, &dto.AuthLogoutIn{}).
+				d.authUsecase.On("Logout", mock.Anything, &dto.AuthLogoutIn{IPAddress: "192.0.2.1"}).
 					Return(test.ErrUnexpected)
 			},
 		},
@@ -246,10 +1178,35 @@ func (s *AuthHTTPHandlerTestSuite) TestDelete() {
 				payload:     nil,
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthLogoutIn{}).
+				d.validator.On("Validate", &dto.AuthLogoutIn{IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("Logout", mock.Anything, &dto.AuthLogoutIn{IPAddress: "192.0.2.1"}).
+					Return(nil)
+			},
+		},
+		{
+			name:    "it should response with success and clear the cookies when success in cookie mode",
+			isError: false,
+			args: args{
+				cookies:   []*http.Cookie{{Name: "taskit_refresh_token", Value: "yyyyy.yyyyy.yyyyy"}, {Name: "taskit_csrf_token", Value: "csrf_token"}},
+				csrfToken: "csrf_token",
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully logout user",
+				payload:     nil,
+				cookies: map[string]string{
+					"taskit_refresh_token": "",
+					"taskit_csrf_token":    "",
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthLogoutIn{RefreshToken: "yyyyy.yyyyy.yyyyy", IPAddress: "192.0.2.1"}).
 					Return(nil)
 
-				d.authUsecase.On("Logout", mock.Anything, &dto.AuthLogoutIn{}).
+				d.authUsecase.On("Logout", mock.Anything, &dto.AuthLogoutIn{RefreshToken: "yyyyy.yyyyy.yyyyy", IPAddress: "192.0.2.1"}).
 					Return(nil)
 			},
 		},
@@ -260,18 +1217,24 @@ func (s *AuthHTTPHandlerTestSuite) TestDelete() {
 			reqBody := bytes.NewReader(t.args.requestBody)
 			rr := httptest.NewRecorder()
 			req := httptest.NewRequest("DELETE", "/", reqBody)
+			req.Header.Set("X-CSRF-Token", t.args.csrfToken)
+			for _, cookie := range t.args.cookies {
+				req.AddCookie(cookie)
+			}
 
 			d := &dependency{
-				validator:   &mocks.ValidatorProvider{},
-				authUsecase: &mocks.AuthUsecase{},
+				validator:     &mocks.ValidatorProvider{},
+				authUsecase:   &mocks.AuthUsecase{},
+				tokenProvider: &mocks.TokenProvider{},
 			}
 			t.setup(d)
 
-			handler := New(d.validator, d.authUsecase)
+			handler := New(d.validator, d.authUsecase, d.tokenProvider, CookieConfig{MaxAge: 3600})
 			handler.Delete(rr, req)
 
 			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
 			s.Equal(t.expected.statusCode, rr.Code)
+			s.Equal(t.expected.cookies, responseCookies(rr))
 
 			if t.isError {
 				var resBody domain.ErrorResponse
@@ -330,16 +1293,17 @@ func (s *AuthHTTPHandlerTestSuite) TestGet() {
 				statusCode:  http.StatusOK,
 				message:     http.StatusText(http.StatusOK),
 				payload: map[string]any{
-					"id":    "user-xxxxx",
-					"name":  "Gopher",
-					"email": "gopher@go.dev",
+					"id":             "user-xxxxx",
+					"name":           "Gopher",
+					"email":          "gopher@go.dev",
+					"email_verified": true,
 				},
 			},
 			setup: func(d *dependency) {
 				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
 
 				d.authUsecase.On("GetProfile", mock.Anything, &dto.AuthProfileIn{UserID: entity.UserID("user-xxxxx")}).
-					Return(dto.AuthProfileOut{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}, nil)
+					Return(dto.AuthProfileOut{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", EmailVerified: true}, nil)
 			},
 		},
 	}
@@ -350,13 +1314,14 @@ func (s *AuthHTTPHandlerTestSuite) TestGet() {
 			req := httptest.NewRequest("GET", "/", nil)
 
 			d := &dependency{
-				req:         req,
-				validator:   &mocks.ValidatorProvider{},
-				authUsecase: &mocks.AuthUsecase{},
+				req:           req,
+				validator:     &mocks.ValidatorProvider{},
+				authUsecase:   &mocks.AuthUsecase{},
+				tokenProvider: &mocks.TokenProvider{},
 			}
 			t.setup(d)
 
-			handler := New(d.validator, d.authUsecase)
+			handler := New(d.validator, d.authUsecase, d.tokenProvider, CookieConfig{MaxAge: 3600})
 			handler.Get(rr, d.req)
 
 			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
@@ -385,6 +1350,8 @@ func (s *AuthHTTPHandlerTestSuite) TestGet() {
 func (s *AuthHTTPHandlerTestSuite) TestPut() {
 	type args struct {
 		requestBody []byte
+		cookies     []*http.Cookie
+		csrfToken   string
 	}
 	type expected struct {
 		contentType string
@@ -392,6 +1359,7 @@ func (s *AuthHTTPHandlerTestSuite) TestPut() {
 		message     string
 		error       string
 		payload     map[string]any
+		cookies     map[string]string
 	}
 	tests := []struct {
 		name     string
@@ -414,6 +1382,21 @@ func (s *AuthHTTPHandlerTestSuite) TestPut() {
 			},
 			setup: func(d *dependency) {},
 		},
+		{
+			name:    "it should response with error when csrf token does not match in cookie mode",
+			isError: true,
+			args: args{
+				cookies:   []*http.Cookie{{Name: "taskit_refresh_token", Value: "yyyyy.yyyyy.yyyyy"}, {Name: "taskit_csrf_token", Value: "csrf_token"}},
+				csrfToken: "other_csrf_token",
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "CSRF token is missing or invalid",
+			},
+			setup: func(d *dependency) {},
+		},
 		{
 			name:    "it should response with error when payload is not valid",
 			isError: true,
@@ -427,7 +1410,7 @@ func (s *AuthHTTPHandlerTestSuite) TestPut() {
 				error:       errorx.InternalServerErrorMessage,
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthRefreshIn{}).
+				d.validator.On("Validate", &dto.AuthRefreshIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(test.ErrValidator)
 			},
 		},
@@ -444,10 +1427,10 @@ func (s *AuthHTTPHandlerTestSuite) TestPut() {
 				error:       errorx.InternalServerErrorMessage,
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthRefreshIn{}).
+				d.validator.On("Validate", &dto.AuthRefreshIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(nil)
 
-				d.authUsecase.On("Refresh", mock.Anything, &dto.AuthRefreshIn{}).
+				d.authUsecase.On("Refresh", mock.Anything, &dto.AuthRefreshIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(dto.AuthRefreshOut{}, test.ErrUnexpected)
 			},
 		},
@@ -467,13 +1450,43 @@ func (s *AuthHTTPHandlerTestSuite) TestPut() {
 				},
 			},
 			setup: func(d *dependency) {
-				d.validator.On("Validate", &dto.AuthRefreshIn{}).
+				d.validator.On("Validate", &dto.AuthRefreshIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(nil)
 
-				d.authUsecase.On("Refresh", mock.Anything, &dto.AuthRefreshIn{}).
+				d.authUsecase.On("Refresh", mock.Anything, &dto.AuthRefreshIn{UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
 					Return(dto.AuthRefreshOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, nil)
 			},
 		},
+		{
+			name:    "it should response with success and rotate the cookies when success in cookie mode",
+			isError: false,
+			args: args{
+				cookies:   []*http.Cookie{{Name: "taskit_refresh_token", Value: "yyyyy.yyyyy.yyyyy"}, {Name: "taskit_csrf_token", Value: "csrf_token"}},
+				csrfToken: "csrf_token",
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully refreshed authentication token",
+				payload: map[string]any{
+					"access_token": "xxxxx.xxxxx.xxxxx",
+					"csrf_token":   "new_csrf_token",
+				},
+				cookies: map[string]string{
+					"taskit_refresh_token": "zzzzz.zzzzz.zzzzz",
+					"taskit_csrf_token":    "new_csrf_token",
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.AuthRefreshIn{RefreshToken: "yyyyy.yyyyy.yyyyy", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.authUsecase.On("Refresh", mock.Anything, &dto.AuthRefreshIn{RefreshToken: "yyyyy.yyyyy.yyyyy", UserAgent: "Mozilla/5.0", IPAddress: "192.0.2.1"}).
+					Return(dto.AuthRefreshOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "zzzzz.zzzzz.zzzzz"}, nil)
+
+				d.tokenProvider.On("Generate").Return("new_csrf_token", nil)
+			},
+		},
 	}
 
 	for _, t := range tests {
@@ -481,18 +1494,25 @@ func (s *AuthHTTPHandlerTestSuite) TestPut() {
 			reqBody := bytes.NewReader(t.args.requestBody)
 			rr := httptest.NewRecorder()
 			req := httptest.NewRequest("PUT", "/", reqBody)
+			req.Header.Set("User-Agent", "Mozilla/5.0")
+			req.Header.Set("X-CSRF-Token", t.args.csrfToken)
+			for _, cookie := range t.args.cookies {
+				req.AddCookie(cookie)
+			}
 
 			d := &dependency{
-				validator:   &mocks.ValidatorProvider{},
-				authUsecase: &mocks.AuthUsecase{},
+				validator:     &mocks.ValidatorProvider{},
+				authUsecase:   &mocks.AuthUsecase{},
+				tokenProvider: &mocks.TokenProvider{},
 			}
 			t.setup(d)
 
-			handler := New(d.validator, d.authUsecase)
+			handler := New(d.validator, d.authUsecase, d.tokenProvider, CookieConfig{MaxAge: 3600})
 			handler.Put(rr, req)
 
 			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
 			s.Equal(t.expected.statusCode, rr.Code)
+			s.Equal(t.expected.cookies, responseCookies(rr))
 
 			if t.isError {
 				var resBody domain.ErrorResponse
@@ -512,3 +1532,84 @@ func (s *AuthHTTPHandlerTestSuite) TestPut() {
 		})
 	}
 }
+
+func (s *AuthHTTPHandlerTestSuite) TestGetJWKS() {
+	type expected struct {
+		contentType  string
+		cacheControl string
+		statusCode   int
+		error        string
+		body         map[string]any
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when auth usecase GetJWKS return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.authUsecase.On("GetJWKS", mock.Anything).
+					Return(dto.AuthJWKSOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with the bare key set when success",
+			isError: false,
+			expected: expected{
+				contentType:  "application/json",
+				cacheControl: "public, max-age=300",
+				statusCode:   http.StatusOK,
+				body: map[string]any{
+					"keys": []any{
+						map[string]any{"kty": "OKP", "kid": "key-xxxxx", "use": "sig", "alg": "EdDSA", "crv": "Ed25519", "x": "xxxxx"},
+					},
+				},
+			},
+			setup: func(d *dependency) {
+				d.authUsecase.On("GetJWKS", mock.Anything).
+					Return(dto.AuthJWKSOut{Keys: []entity.JWK{{KeyType: "OKP", KeyID: "key-xxxxx", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "xxxxx"}}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+
+			d := &dependency{
+				req:         req,
+				authUsecase: &mocks.AuthUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.authUsecase, nil, CookieConfig{})
+			handler.GetJWKS(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.cacheControl, rr.Header().Get("Cache-Control"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody map[string]any
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.body, resBody)
+			}
+		})
+	}
+}
@@ -3,24 +3,39 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+
 	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/pkg/httpsvr"
 )
 
 type Middleware struct {
-	jwtProvider domain.JWTProvider
+	jwtProvider           domain.JWTProvider
+	userUsecase           domain.UserUsecase
+	personalTokenUsecase  domain.PersonalTokenUsecase
+	serviceAccountUsecase domain.ServiceAccountUsecase
+	revocationUsecase     domain.RevocationUsecase
 }
 
 // New creates a new HTTP auth middleware.
-func New(jwtProvider domain.JWTProvider) Middleware {
-	return Middleware{jwtProvider: jwtProvider}
+func New(jwtProvider domain.JWTProvider, userUsecase domain.UserUsecase, personalTokenUsecase domain.PersonalTokenUsecase, serviceAccountUsecase domain.ServiceAccountUsecase, revocationUsecase domain.RevocationUsecase) Middleware {
+	return Middleware{jwtProvider: jwtProvider, userUsecase: userUsecase, personalTokenUsecase: personalTokenUsecase, serviceAccountUsecase: serviceAccountUsecase, revocationUsecase: revocationUsecase}
 }
 
-// Authenticate authenticates the request.
+// Authenticate authenticates the request with an access token, a personal access token or a service account API key.
+// Requests authenticated by a personal access token carry the granted scopes in the context,
+// requests authenticated by a service account are marked in the context and recorded as security events once served,
+// access tokens are checked against revocations and carry their claims in the context.
 func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -34,7 +49,53 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 		}
 
 		rawToken := strings.TrimPrefix(bearerToken, "Bearer ")
-		userId, err := m.jwtProvider.VerifyAccessToken(rawToken)
+		if strings.HasPrefix(rawToken, entity.ServiceAccountKeyPrefix) {
+			output, err := m.serviceAccountUsecase.Authenticate(r.Context(), &dto.ServiceAccountAuthenticateIn{Key: rawToken})
+			if err != nil {
+				var throttled *domain.ServiceAccountThrottledError
+				if errors.As(err, &throttled) {
+					w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+				}
+				code, msg := errorx.HTTPErrorTranslator(err)
+				w.WriteHeader(code)
+				encoder.Encode(domain.NewErrorResponse(code, msg))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), entity.AuthUserIDKey, output.UserID)
+			ctx = context.WithValue(ctx, entity.AuthServiceAccountKey, true)
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+			audit := &dto.ServiceAccountAuditIn{
+				UserID:     output.UserID,
+				Method:     r.Method,
+				Path:       r.URL.EscapedPath(),
+				StatusCode: ww.Status(),
+				UserAgent:  r.UserAgent(),
+				IPAddress:  httpsvr.ClientIP(r),
+			}
+			if err := m.serviceAccountUsecase.Audit(r.Context(), audit); err != nil {
+				log.Println("[ERROR]", err)
+			}
+			return
+		}
+
+		if strings.HasPrefix(rawToken, entity.PersonalTokenPrefix) {
+			output, err := m.personalTokenUsecase.Authenticate(r.Context(), &dto.PersonalTokenAuthenticateIn{Token: rawToken})
+			if err != nil {
+				code, msg := errorx.HTTPErrorTranslator(err)
+				w.WriteHeader(code)
+				encoder.Encode(domain.NewErrorResponse(code, msg))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), entity.AuthUserIDKey, output.UserID)
+			ctx = context.WithValue(ctx, entity.AuthScopesKey, output.Scopes)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		claims, err := m.jwtProvider.VerifyAccessToken(rawToken)
 		if err != nil {
 			code, msg := errorx.HTTPErrorTranslator(err)
 			w.WriteHeader(code)
@@ -42,7 +103,105 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
-		ctx := context.WithValue(r.Context(), entity.AuthUserIDKey, userId)
+		payload := dto.RevocationEnsureActiveIn{TokenID: claims.TokenID, UserID: claims.UserID, IssuedAt: claims.IssuedAt}
+		if err := m.revocationUsecase.EnsureActive(r.Context(), &payload); err != nil {
+			code, msg := errorx.HTTPErrorTranslator(err)
+			w.WriteHeader(code)
+			encoder.Encode(domain.NewErrorResponse(code, msg))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), entity.AuthUserIDKey, claims.UserID)
+		ctx = context.WithValue(ctx, entity.AuthSessionIDKey, claims.SessionID)
+		ctx = context.WithValue(ctx, entity.AuthTokenKey, claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// RequireVerified only let users with a verified email through.
+// It must be used after Authenticate.
+func (m *Middleware) RequireVerified(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+
+		payload := dto.UserEnsureVerifiedIn{UserID: entity.GetAuthContext(r.Context())}
+		if err := m.userUsecase.EnsureVerified(r.Context(), &payload); err != nil {
+			code, msg := errorx.HTTPErrorTranslator(err)
+			w.WriteHeader(code)
+			encoder.Encode(domain.NewErrorResponse(code, msg))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole only let users with any of the roles through.
+// It must be used after Authenticate.
+func (m *Middleware) RequireRole(roles ...entity.Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			encoder := json.NewEncoder(w)
+
+			payload := dto.UserEnsureRoleIn{UserID: entity.GetAuthContext(r.Context()), Roles: roles}
+			if err := m.userUsecase.EnsureRole(r.Context(), &payload); err != nil {
+				code, msg := errorx.HTTPErrorTranslator(err)
+				w.WriteHeader(code)
+				encoder.Encode(domain.NewErrorResponse(code, msg))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireScope only let requests allowed to act with every scope through.
+// Requests authenticated by a session are not limited by scopes. It must be used after Authenticate.
+func (m *Middleware) RequireScope(scopes ...entity.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			encoder := json.NewEncoder(w)
+
+			for _, scope := range scopes {
+				if !entity.HasScope(r.Context(), scope) {
+					code, msg := errorx.HTTPErrorTranslator(entity.ErrScopeMissing)
+					w.WriteHeader(code)
+					encoder.Encode(domain.NewErrorResponse(code, msg))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSession only let requests authenticated by a session through,
+// so a personal access token or a service account can not manage the account or mint new tokens.
+// It must be used after Authenticate.
+func (m *Middleware) RequireSession(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+
+		if entity.IsServiceAccountContext(r.Context()) {
+			code, msg := errorx.HTTPErrorTranslator(entity.ErrServiceAccountForbidden)
+			w.WriteHeader(code)
+			encoder.Encode(domain.NewErrorResponse(code, msg))
+			return
+		}
+
+		if _, limited := entity.GetAuthScopesContext(r.Context()); limited {
+			code, msg := errorx.HTTPErrorTranslator(entity.ErrPersonalTokenForbidden)
+			w.WriteHeader(code)
+			encoder.Encode(domain.NewErrorResponse(code, msg))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -1,14 +1,19 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 	"github.com/edwintantawi/taskit/internal/domain/mocks"
 	"github.com/edwintantawi/taskit/pkg/errorx"
@@ -24,8 +29,12 @@ func TestHTTPAuthMiddlewareSuite(t *testing.T) {
 }
 
 type dependency struct {
-	req         *http.Request
-	jwtProvider *mocks.JWTProvider
+	req                   *http.Request
+	jwtProvider           *mocks.JWTProvider
+	userUsecase           *mocks.UserUsecase
+	personalTokenUsecase  *mocks.PersonalTokenUsecase
+	serviceAccountUsecase *mocks.ServiceAccountUsecase
+	revocationUsecase     *mocks.RevocationUsecase
 }
 
 func (s *HTTPAuthMiddlewareTestSuite) TestAuthentication() {
@@ -38,6 +47,7 @@ func (s *HTTPAuthMiddlewareTestSuite) TestAuthentication() {
 		message     string
 		error       string
 		body        string
+		retryAfter  string
 	}
 	tests := []struct {
 		name     string
@@ -82,7 +92,31 @@ func (s *HTTPAuthMiddlewareTestSuite) TestAuthentication() {
 				d.req.Header.Set("Authorization", "Bearer xxxxx.xxxxx.xxxxx")
 
 				d.jwtProvider.On("VerifyAccessToken", "xxxxx.xxxxx.xxxxx").
-					Return(entity.UserID(""), test.ErrUnexpected)
+					Return(entity.AuthClaims{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with error when access token is revoked",
+			isError: true,
+			args: args{
+				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusUnauthorized,
+				message:     http.StatusText(http.StatusUnauthorized),
+				error:       "Access token is revoked",
+			},
+			setup: func(d *dependency) {
+				d.req.Header.Set("Authorization", "Bearer xxxxx.xxxxx.xxxxx")
+
+				d.jwtProvider.On("VerifyAccessToken", "xxxxx.xxxxx.xxxxx").
+					Return(entity.AuthClaims{TokenID: "token-xxxxx", UserID: "user-xxxxx", SessionID: "auth-xxxxx", IssuedAt: test.TimeBeforeNow}, nil)
+
+				d.revocationUsecase.On("EnsureActive", mock.Anything, &dto.RevocationEnsureActiveIn{TokenID: "token-xxxxx", UserID: "user-xxxxx", IssuedAt: test.TimeBeforeNow}).
+					Return(entity.ErrAccessTokenRevoked)
 			},
 		},
 		{
@@ -92,18 +126,159 @@ func (s *HTTPAuthMiddlewareTestSuite) TestAuthentication() {
 				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 					w.WriteHeader(http.StatusOK)
 					userID := entity.GetAuthContext(r.Context())
-					w.Write([]byte(userID))
+					sessionID := entity.GetAuthSessionContext(r.Context())
+					claims := entity.GetAuthTokenContext(r.Context())
+					w.Write([]byte(string(userID) + "/" + string(sessionID) + "/" + claims.TokenID))
 				}),
 			},
 			expected: expected{
 				statusCode: http.StatusOK,
-				body:       "user-xxxxx",
+				body:       "user-xxxxx/auth-xxxxx/token-xxxxx",
 			},
 			setup: func(d *dependency) {
 				d.req.Header.Set("Authorization", "Bearer xxxxx.xxxxx.xxxxx")
 
 				d.jwtProvider.On("VerifyAccessToken", "xxxxx.xxxxx.xxxxx").
-					Return(entity.UserID("user-xxxxx"), nil)
+					Return(entity.AuthClaims{TokenID: "token-xxxxx", UserID: "user-xxxxx", SessionID: "auth-xxxxx", IssuedAt: test.TimeBeforeNow}, nil)
+
+				d.revocationUsecase.On("EnsureActive", mock.Anything, &dto.RevocationEnsureActiveIn{TokenID: "token-xxxxx", UserID: "user-xxxxx", IssuedAt: test.TimeBeforeNow}).
+					Return(nil)
+			},
+		},
+		{
+			name:    "it should response with error when personal access token is not valid",
+			isError: true,
+			args: args{
+				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusUnauthorized,
+				message:     http.StatusText(http.StatusUnauthorized),
+				error:       "Personal access token is invalid",
+			},
+			setup: func(d *dependency) {
+				d.req.Header.Set("Authorization", "Bearer tkt_xxxxx")
+
+				d.personalTokenUsecase.On("Authenticate", mock.Anything, &dto.PersonalTokenAuthenticateIn{Token: "tkt_xxxxx"}).
+					Return(dto.PersonalTokenAuthenticateOut{}, domain.ErrPersonalTokenInvalid)
+			},
+		},
+		{
+			name:    "it should forward to next handler with granted scopes when personal access token is valid",
+			isError: false,
+			args: args{
+				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+					userID := entity.GetAuthContext(r.Context())
+					scopes, _ := entity.GetAuthScopesContext(r.Context())
+					w.Write([]byte(string(userID) + "/" + string(scopes[0])))
+				}),
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				body:       "user-xxxxx/tasks:read",
+			},
+			setup: func(d *dependency) {
+				d.req.Header.Set("Authorization", "Bearer tkt_xxxxx")
+
+				d.personalTokenUsecase.On("Authenticate", mock.Anything, &dto.PersonalTokenAuthenticateIn{Token: "tkt_xxxxx"}).
+					Return(dto.PersonalTokenAuthenticateOut{UserID: "user-xxxxx", Scopes: []entity.Scope{entity.ScopeTasksRead}}, nil)
+			},
+		},
+		{
+			name:    "it should response with error when service account API key is not valid",
+			isError: true,
+			args: args{
+				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusUnauthorized,
+				message:     http.StatusText(http.StatusUnauthorized),
+				error:       "Service account API key is invalid",
+			},
+			setup: func(d *dependency) {
+				d.req.Header.Set("Authorization", "Bearer tks_xxxxx")
+
+				d.serviceAccountUsecase.On("Authenticate", mock.Anything, &dto.ServiceAccountAuthenticateIn{Key: "tks_xxxxx"}).
+					Return(dto.ServiceAccountAuthenticateOut{}, domain.ErrServiceAccountKeyInvalid)
+			},
+		},
+		{
+			name:    "it should response with error and retry after header when service account is throttled",
+			isError: true,
+			args: args{
+				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusTooManyRequests,
+				message:     http.StatusText(http.StatusTooManyRequests),
+				error:       "Too many requests, please try again later",
+				retryAfter:  "2",
+			},
+			setup: func(d *dependency) {
+				d.req.Header.Set("Authorization", "Bearer tks_xxxxx")
+
+				d.serviceAccountUsecase.On("Authenticate", mock.Anything, &dto.ServiceAccountAuthenticateIn{Key: "tks_xxxxx"}).
+					Return(dto.ServiceAccountAuthenticateOut{}, &domain.ServiceAccountThrottledError{RetryAfter: 1500 * time.Millisecond})
+			},
+		},
+		{
+			name:    "it should forward to next handler marked as service account when service account API key is valid",
+			isError: false,
+			args: args{
+				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+					userID := entity.GetAuthContext(r.Context())
+					isServiceAccount := entity.IsServiceAccountContext(r.Context())
+					w.Write([]byte(string(userID) + "/" + strconv.FormatBool(isServiceAccount)))
+				}),
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				body:       "user-xxxxx/true",
+			},
+			setup: func(d *dependency) {
+				d.req.Header.Set("Authorization", "Bearer tks_xxxxx")
+
+				d.serviceAccountUsecase.On("Authenticate", mock.Anything, &dto.ServiceAccountAuthenticateIn{Key: "tks_xxxxx"}).
+					Return(dto.ServiceAccountAuthenticateOut{UserID: "user-xxxxx"}, nil)
+				d.serviceAccountUsecase.On("Audit", mock.Anything, &dto.ServiceAccountAuditIn{
+					UserID:     "user-xxxxx",
+					Method:     "GET",
+					Path:       "/",
+					StatusCode: http.StatusOK,
+					IPAddress:  "192.0.2.1",
+				}).Return(nil)
+			},
+		},
+		{
+			name:    "it should still forward to next handler when recording the service account request fails",
+			isError: false,
+			args: args{
+				handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+					w.Write([]byte(string(entity.GetAuthContext(r.Context()))))
+				}),
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+				body:       "user-xxxxx",
+			},
+			setup: func(d *dependency) {
+				d.req.Header.Set("Authorization", "Bearer tks_xxxxx")
+
+				d.serviceAccountUsecase.On("Authenticate", mock.Anything, &dto.ServiceAccountAuthenticateIn{Key: "tks_xxxxx"}).
+					Return(dto.ServiceAccountAuthenticateOut{UserID: "user-xxxxx"}, nil)
+				d.serviceAccountUsecase.On("Audit", mock.Anything, mock.Anything).Return(test.ErrUnexpected)
 			},
 		},
 	}
@@ -112,13 +287,16 @@ func (s *HTTPAuthMiddlewareTestSuite) TestAuthentication() {
 		s.Run(t.name, func() {
 			req := httptest.NewRequest("GET", "/", nil)
 			dep := &dependency{
-				jwtProvider: &mocks.JWTProvider{},
-				req:         req,
+				jwtProvider:           &mocks.JWTProvider{},
+				personalTokenUsecase:  &mocks.PersonalTokenUsecase{},
+				serviceAccountUsecase: &mocks.ServiceAccountUsecase{},
+				revocationUsecase:     &mocks.RevocationUsecase{},
+				req:                   req,
 			}
 			t.setup(dep)
 
 			rr := httptest.NewRecorder()
-			middleware := New(dep.jwtProvider)
+			middleware := New(dep.jwtProvider, nil, dep.personalTokenUsecase, dep.serviceAccountUsecase, dep.revocationUsecase)
 			handler := middleware.Authenticate(t.args.handler)
 
 			handler.ServeHTTP(rr, dep.req)
@@ -132,6 +310,7 @@ func (s *HTTPAuthMiddlewareTestSuite) TestAuthentication() {
 				s.Equal(t.expected.statusCode, resBody.StatusCode)
 				s.Equal(t.expected.message, resBody.Message)
 				s.Equal(t.expected.error, resBody.Error)
+				s.Equal(t.expected.retryAfter, rr.Header().Get("Retry-After"))
 			} else {
 				s.Equal(t.expected.statusCode, rr.Code)
 				s.Equal(t.expected.body, rr.Body.String())
@@ -139,3 +318,328 @@ func (s *HTTPAuthMiddlewareTestSuite) TestAuthentication() {
 		})
 	}
 }
+
+func (s *HTTPAuthMiddlewareTestSuite) TestRequireVerified() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when user usecase EnsureVerified return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.userUsecase.On("EnsureVerified", mock.Anything, &dto.UserEnsureVerifiedIn{UserID: "user-xxxxx"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with error when email is not verified",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Email address is not verified",
+			},
+			setup: func(d *dependency) {
+				d.userUsecase.On("EnsureVerified", mock.Anything, &dto.UserEnsureVerifiedIn{UserID: "user-xxxxx"}).
+					Return(domain.ErrEmailNotVerified)
+			},
+		},
+		{
+			name:    "it should forward to next handler when email is verified",
+			isError: false,
+			expected: expected{
+				statusCode: http.StatusOK,
+			},
+			setup: func(d *dependency) {
+				d.userUsecase.On("EnsureVerified", mock.Anything, &dto.UserEnsureVerifiedIn{UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			req := httptest.NewRequest("GET", "/", nil)
+			dep := &dependency{
+				userUsecase: &mocks.UserUsecase{},
+				req:         test.InjectAuthContext(req, entity.UserID("user-xxxxx")),
+			}
+			t.setup(dep)
+
+			rr := httptest.NewRecorder()
+			middleware := New(nil, dep.userUsecase, nil, nil, nil)
+			handler := middleware.RequireVerified(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			handler.ServeHTTP(rr, dep.req)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+				s.Equal(t.expected.statusCode, rr.Code)
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				s.Equal(t.expected.statusCode, rr.Code)
+			}
+		})
+	}
+}
+
+func (s *HTTPAuthMiddlewareTestSuite) TestRequireRole() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when user does not have the role",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this resource",
+			},
+			setup: func(d *dependency) {
+				d.userUsecase.On("EnsureRole", mock.Anything, &dto.UserEnsureRoleIn{UserID: "user-xxxxx", Roles: []entity.Role{entity.RoleAdmin}}).
+					Return(domain.ErrRoleForbidden)
+			},
+		},
+		{
+			name:    "it should response with error when user is suspended",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Account is suspended",
+			},
+			setup: func(d *dependency) {
+				d.userUsecase.On("EnsureRole", mock.Anything, &dto.UserEnsureRoleIn{UserID: "user-xxxxx", Roles: []entity.Role{entity.RoleAdmin}}).
+					Return(entity.ErrUserSuspended)
+			},
+		},
+		{
+			name:    "it should forward to next handler when user has the role",
+			isError: false,
+			expected: expected{
+				statusCode: http.StatusOK,
+			},
+			setup: func(d *dependency) {
+				d.userUsecase.On("EnsureRole", mock.Anything, &dto.UserEnsureRoleIn{UserID: "user-xxxxx", Roles: []entity.Role{entity.RoleAdmin}}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			req := httptest.NewRequest("GET", "/", nil)
+			dep := &dependency{
+				userUsecase: &mocks.UserUsecase{},
+				req:         test.InjectAuthContext(req, entity.UserID("user-xxxxx")),
+			}
+			t.setup(dep)
+
+			rr := httptest.NewRecorder()
+			middleware := New(nil, dep.userUsecase, nil, nil, nil)
+			handler := middleware.RequireRole(entity.RoleAdmin)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			handler.ServeHTTP(rr, dep.req)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+				s.Equal(t.expected.statusCode, rr.Code)
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				s.Equal(t.expected.statusCode, rr.Code)
+			}
+		})
+	}
+}
+
+func (s *HTTPAuthMiddlewareTestSuite) TestRequireScope() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		req      func(r *http.Request) *http.Request
+		expected expected
+	}{
+		{
+			name:    "it should response with error when personal access token is missing a scope",
+			isError: true,
+			req: func(r *http.Request) *http.Request {
+				return r.WithContext(context.WithValue(r.Context(), entity.AuthScopesKey, []entity.Scope{entity.ScopeTasksRead}))
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Personal access token is missing the required scope",
+			},
+		},
+		{
+			name:    "it should forward to next handler when personal access token is granted every scope",
+			isError: false,
+			req: func(r *http.Request) *http.Request {
+				return r.WithContext(context.WithValue(r.Context(), entity.AuthScopesKey, []entity.Scope{entity.ScopeTasksRead, entity.ScopeTasksWrite}))
+			},
+			expected: expected{
+				statusCode: http.StatusOK,
+			},
+		},
+		{
+			name:    "it should forward to next handler when request is authenticated by a session",
+			isError: false,
+			req:     func(r *http.Request) *http.Request { return r },
+			expected: expected{
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			req := t.req(httptest.NewRequest("GET", "/", nil))
+
+			rr := httptest.NewRecorder()
+			middleware := New(nil, nil, nil, nil, nil)
+			handler := middleware.RequireScope(entity.ScopeTasksRead, entity.ScopeTasksWrite)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			handler.ServeHTTP(rr, req)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+				s.Equal(t.expected.statusCode, rr.Code)
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				s.Equal(t.expected.statusCode, rr.Code)
+			}
+		})
+	}
+}
+
+func (s *HTTPAuthMiddlewareTestSuite) TestRequireSession() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		req      func(r *http.Request) *http.Request
+		expected expected
+	}{
+		{
+			name:    "it should response with error when request is authenticated by a personal access token",
+			isError: true,
+			req: func(r *http.Request) *http.Request {
+				return r.WithContext(context.WithValue(r.Context(), entity.AuthScopesKey, []entity.Scope{entity.ScopeTasksRead}))
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Personal access tokens can not be used for this action",
+			},
+		},
+		{
+			name:    "it should response with error when request is authenticated by a service account",
+			isError: true,
+			req: func(r *http.Request) *http.Request {
+				return r.WithContext(context.WithValue(r.Context(), entity.AuthServiceAccountKey, true))
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Service accounts can not be used for this action",
+			},
+		},
+		{
+			name:    "it should forward to next handler when request is authenticated by a session",
+			isError: false,
+			req:     func(r *http.Request) *http.Request { return r },
+			expected: expected{
+				statusCode: http.StatusOK,
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			req := t.req(httptest.NewRequest("GET", "/", nil))
+
+			rr := httptest.NewRecorder()
+			middleware := New(nil, nil, nil, nil, nil)
+			handler := middleware.RequireSession(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			handler.ServeHTTP(rr, req)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+				s.Equal(t.expected.statusCode, rr.Code)
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				s.Equal(t.expected.statusCode, rr.Code)
+			}
+		})
+	}
+}
@@ -4,37 +4,40 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 )
 
 type Repository struct {
-	db         *sql.DB
-	idProvider domain.IDProvider
+	db          *sql.DB
+	idProvider  domain.IDProvider
+	tokenHasher domain.TokenHasher
 }
 
 // New create a new auth repository.
-func New(db *sql.DB, idProvider domain.IDProvider) Repository {
-	return Repository{db: db, idProvider: idProvider}
+// Refresh tokens are hashed with tokenHasher before they are stored or looked up.
+func New(db *sql.DB, idProvider domain.IDProvider, tokenHasher domain.TokenHasher) Repository {
+	return Repository{db: db, idProvider: idProvider, tokenHasher: tokenHasher}
 }
 
 // Store save a new auth to database.
-func (r *Repository) Store(ctx context.Context, a *entity.Auth) error {
-	id := r.idProvider.Generate()
-	q := `INSERT INTO authentications (id, user_id, token, expires_at) VALUES ($1, $2, $3, $4)`
-	_, err := r.db.ExecContext(ctx, q, id, a.UserID, a.Token, a.ExpiresAt)
+func (r *Repository) Store(ctx context.Context, a *entity.Auth) (entity.AuthID, error) {
+	id := entity.AuthID(r.idProvider.Generate())
+	q := `INSERT INTO authentications (id, user_id, token_hash, expires_at, user_agent, ip_address) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.ExecContext(ctx, q, id, a.UserID, r.tokenHasher.Hash(a.Token), a.ExpiresAt, a.UserAgent, a.IPAddress)
 	if err != nil {
-		return err
+		return "", err
 	}
-	return nil
+	return id, nil
 }
 
 // VerifyAvailableByToken check if a authentication is available by token.
 func (r *Repository) VerifyAvailableByToken(ctx context.Context, token string) error {
 	var id entity.AuthID
-	q := `SELECT id FROM authentications WHERE token = $1`
-	row := r.db.QueryRowContext(ctx, q, token)
+	q := `SELECT id FROM authentications WHERE token_hash = $1`
+	row := r.db.QueryRowContext(ctx, q, r.tokenHasher.Hash(token))
 	err := row.Scan(&id)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.ErrAuthNotFound
@@ -46,8 +49,8 @@ func (r *Repository) VerifyAvailableByToken(ctx context.Context, token string) e
 
 // Delete remove an auth from database.
 func (r *Repository) DeleteByToken(ctx context.Context, token string) error {
-	q := `DELETE FROM authentications WHERE token = $1`
-	_, err := r.db.ExecContext(ctx, q, token)
+	q := `DELETE FROM authentications WHERE token_hash = $1`
+	_, err := r.db.ExecContext(ctx, q, r.tokenHasher.Hash(token))
 	if err != nil {
 		return err
 	}
@@ -57,9 +60,134 @@ func (r *Repository) DeleteByToken(ctx context.Context, token string) error {
 // FindByToken find an auth by token.
 func (r *Repository) FindByToken(ctx context.Context, token string) (entity.Auth, error) {
 	var a entity.Auth
-	q := `SELECT id, user_id, token, expires_at FROM authentications WHERE token = $1`
-	row := r.db.QueryRowContext(ctx, q, token)
-	err := row.Scan(&a.ID, &a.UserID, &a.Token, &a.ExpiresAt)
+	q := `SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE token_hash = $1`
+	row := r.db.QueryRowContext(ctx, q, r.tokenHasher.Hash(token))
+	err := row.Scan(&a.ID, &a.UserID, &a.ExpiresAt, &a.UserAgent, &a.IPAddress, &a.CreatedAt, &a.LastUsedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return a, domain.ErrAuthNotFound
+	} else if err != nil {
+		return a, err
+	}
+	return a, nil
+}
+
+// DeleteByUserID remove every auth of a user from database.
+func (r *Repository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	q := `DELETE FROM authentications WHERE user_id = $1`
+	_, err := r.db.ExecContext(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteOthersByUserID remove every auth of a user except the one with the given id.
+func (r *Repository) DeleteOthersByUserID(ctx context.Context, userID entity.UserID, authID entity.AuthID) error {
+	q := `DELETE FROM authentications WHERE user_id = $1 AND id <> $2`
+	_, err := r.db.ExecContext(ctx, q, userID, authID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteOldestByUserID remove the oldest auths of a user, keeping the newest keep ones.
+func (r *Repository) DeleteOldestByUserID(ctx context.Context, userID entity.UserID, keep int) error {
+	q := `DELETE FROM authentications WHERE id IN (SELECT id FROM authentications WHERE user_id = $1 ORDER BY created_at DESC, id DESC OFFSET $2)`
+	_, err := r.db.ExecContext(ctx, q, userID, keep)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteExpired remove up to limit auths expired before the given time and return how many were removed.
+func (r *Repository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := `DELETE FROM authentications WHERE id IN (SELECT id FROM authentications WHERE expires_at < $1 LIMIT $2)`
+	result, err := r.db.ExecContext(ctx, q, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// FindByID find an auth by id.
+func (r *Repository) FindByID(ctx context.Context, authID entity.AuthID) (entity.Auth, error) {
+	var a entity.Auth
+	q := `SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE id = $1`
+	row := r.db.QueryRowContext(ctx, q, authID)
+	err := row.Scan(&a.ID, &a.UserID, &a.ExpiresAt, &a.UserAgent, &a.IPAddress, &a.CreatedAt, &a.LastUsedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return a, domain.ErrAuthNotFound
+	} else if err != nil {
+		return a, err
+	}
+	return a, nil
+}
+
+// FindAllByUserID find all unexpired auths of a user, most recently used first.
+func (r *Repository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Auth, error) {
+	q := `SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE user_id = $1 AND expires_at > NOW() ORDER BY last_used_at DESC`
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	auths := make([]entity.Auth, 0)
+	for rows.Next() {
+		var a entity.Auth
+		err := rows.Scan(&a.ID, &a.UserID, &a.ExpiresAt, &a.UserAgent, &a.IPAddress, &a.CreatedAt, &a.LastUsedAt)
+		if err != nil {
+			return nil, err
+		}
+		auths = append(auths, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return auths, nil
+}
+
+// Rotate replace the token of an auth and record its latest use.
+// The previous token is kept in the family history so a later reuse can be detected.
+// It returns ErrAuthNotFound when the previous token is no longer the current one.
+func (r *Repository) Rotate(ctx context.Context, previousToken string, a *entity.Auth) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	previousTokenHash := r.tokenHasher.Hash(previousToken)
+	q := `UPDATE authentications SET token_hash = $2, expires_at = $3, user_agent = $4, ip_address = $5, last_used_at = NOW() WHERE id = $1 AND token_hash = $6`
+	result, err := tx.ExecContext(ctx, q, a.ID, r.tokenHasher.Hash(a.Token), a.ExpiresAt, a.UserAgent, a.IPAddress, previousTokenHash)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrAuthNotFound
+	}
+
+	q = `INSERT INTO rotated_refresh_tokens (token_hash, family_id) VALUES ($1, $2) ON CONFLICT (token_hash) DO NOTHING`
+	if _, err := tx.ExecContext(ctx, q, previousTokenHash, a.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// FindByRotatedToken find the auth whose family once used the given, already rotated, token.
+func (r *Repository) FindByRotatedToken(ctx context.Context, token string) (entity.Auth, error) {
+	var a entity.Auth
+	q := `SELECT a.id, a.user_id, a.expires_at, a.user_agent, a.ip_address, a.created_at, a.last_used_at FROM rotated_refresh_tokens r JOIN authentications a ON a.id = r.family_id WHERE r.token_hash = $1`
+	row := r.db.QueryRowContext(ctx, q, r.tokenHasher.Hash(token))
+	err := row.Scan(&a.ID, &a.UserID, &a.ExpiresAt, &a.UserAgent, &a.IPAddress, &a.CreatedAt, &a.LastUsedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return a, domain.ErrAuthNotFound
 	} else if err != nil {
@@ -67,3 +195,13 @@ func (r *Repository) FindByToken(ctx context.Context, token string) (entity.Auth
 	}
 	return a, nil
 }
+
+// DeleteByID remove an auth by id.
+func (r *Repository) DeleteByID(ctx context.Context, authID entity.AuthID) error {
+	q := `DELETE FROM authentications WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, authID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/edwintantawi/taskit/internal/domain"
@@ -24,8 +25,30 @@ func TestAuthRepositorySuite(t *testing.T) {
 }
 
 type dependency struct {
-	mockDB     sqlmock.Sqlmock
-	idProvider *mocks.IDProvider
+	mockDB      sqlmock.Sqlmock
+	idProvider  *mocks.IDProvider
+	tokenHasher *mocks.TokenHasher
+}
+
+// newTokenHasher create a token hasher mock that prefixes the raw token.
+func newTokenHasher() *mocks.TokenHasher {
+	tokenHasher := &mocks.TokenHasher{}
+	tokenHasher.On("Hash", mock.Anything).Return(func(raw string) string { return "hashed:" + raw })
+	return tokenHasher
+}
+
+var authColumns = []string{"id", "user_id", "expires_at", "user_agent", "ip_address", "created_at", "last_used_at"}
+
+func newAuth() entity.Auth {
+	return entity.Auth{
+		ID:         "auth-xxxxx",
+		UserID:     "user-xxxxx",
+		ExpiresAt:  test.TimeAfterNow,
+		UserAgent:  "Mozilla/5.0",
+		IPAddress:  "203.0.113.7",
+		CreatedAt:  test.TimeBeforeNow,
+		LastUsedAt: test.TimeBeforeNow,
+	}
 }
 
 func (s *AuthRepositoryTestSuite) TestStore() {
@@ -34,6 +57,7 @@ func (s *AuthRepositoryTestSuite) TestStore() {
 		auth *entity.Auth
 	}
 	type expected struct {
+		id  entity.AuthID
 		err error
 	}
 	tests := []struct {
@@ -50,30 +74,34 @@ func (s *AuthRepositoryTestSuite) TestStore() {
 					UserID:    "user-xxxxx",
 					Token:     "yyyyy.yyyyy.yyyyy",
 					ExpiresAt: test.TimeAfterNow,
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
 				}},
 			expected: expected{
+				id:  "",
 				err: test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
 				d.idProvider.On("Generate").Return(string("auth-xxxxx"))
-				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO authentications (id, user_id, token, expires_at) VALUES ($1, $2, $3, $4)`)).
-					WithArgs("auth-xxxxx", "user-xxxxx", "yyyyy.yyyyy.yyyyy", test.TimeAfterNow).
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO authentications (id, user_id, token_hash, expires_at, user_agent, ip_address) VALUES ($1, $2, $3, $4, $5, $6)`)).
+					WithArgs("auth-xxxxx", "user-xxxxx", "hashed:yyyyy.yyyyy.yyyyy", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7").
 					WillReturnError(test.ErrDatabase)
 			},
 		},
 		{
-			name: "it should return error nil when successfully store",
+			name: "it should return error nil and auth id when successfully store",
 			args: args{
 				ctx:  context.Background(),
-				auth: &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow},
+				auth: &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"},
 			},
 			expected: expected{
+				id:  "auth-xxxxx",
 				err: nil,
 			},
 			setup: func(d *dependency) {
 				d.idProvider.On("Generate").Return(string("auth-xxxxx"))
-				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO authentications (id, user_id, token, expires_at) VALUES ($1, $2, $3, $4)`)).
-					WithArgs("auth-xxxxx", "user-xxxxx", "yyyyy.yyyyy.yyyyy", test.TimeAfterNow).
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO authentications (id, user_id, token_hash, expires_at, user_agent, ip_address) VALUES ($1, $2, $3, $4, $5, $6)`)).
+					WithArgs("auth-xxxxx", "user-xxxxx", "hashed:yyyyy.yyyyy.yyyyy", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
@@ -87,15 +115,17 @@ func (s *AuthRepositoryTestSuite) TestStore() {
 			}
 
 			d := &dependency{
-				mockDB:     mockDB,
-				idProvider: &mocks.IDProvider{},
+				mockDB:      mockDB,
+				idProvider:  &mocks.IDProvider{},
+				tokenHasher: newTokenHasher(),
 			}
 			t.setup(d)
 
-			repository := New(db, d.idProvider)
-			err = repository.Store(t.args.ctx, t.args.auth)
+			repository := New(db, d.idProvider, d.tokenHasher)
+			id, err := repository.Store(t.args.ctx, t.args.auth)
 
 			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.id, id)
 		})
 	}
 }
@@ -124,8 +154,8 @@ func (s *AuthRepositoryTestSuite) TestVerifyAvailableByID() {
 				err: test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnError(test.ErrDatabase)
 			},
 		},
@@ -139,8 +169,8 @@ func (s *AuthRepositoryTestSuite) TestVerifyAvailableByID() {
 				err: domain.ErrAuthNotFound,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnError(sql.ErrNoRows)
 			},
 		},
@@ -154,8 +184,8 @@ func (s *AuthRepositoryTestSuite) TestVerifyAvailableByID() {
 				err: test.ErrRowScan,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnError(test.ErrRowScan)
 			},
 		},
@@ -170,8 +200,8 @@ func (s *AuthRepositoryTestSuite) TestVerifyAvailableByID() {
 			},
 			setup: func(d *dependency) {
 				mockRow := sqlmock.NewRows([]string{"id"}).AddRow("auth-xxxxx")
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnRows(mockRow)
 			},
 		},
@@ -185,11 +215,12 @@ func (s *AuthRepositoryTestSuite) TestVerifyAvailableByID() {
 			}
 
 			d := &dependency{
-				mockDB: mockDB,
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
 			}
 			t.setup(d)
 
-			repository := New(db, nil)
+			repository := New(db, nil, d.tokenHasher)
 			err = repository.VerifyAvailableByToken(t.args.ctx, t.args.token)
 
 			s.Equal(t.expected.err, err)
@@ -221,8 +252,8 @@ func (s *AuthRepositoryTestSuite) TestDelete() {
 				err: test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnError(test.ErrDatabase)
 			},
 		},
@@ -236,8 +267,8 @@ func (s *AuthRepositoryTestSuite) TestDelete() {
 				err: nil,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
@@ -251,11 +282,12 @@ func (s *AuthRepositoryTestSuite) TestDelete() {
 			}
 
 			d := &dependency{
-				mockDB: mockDB,
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
 			}
 			t.setup(d)
 
-			repository := New(db, nil)
+			repository := New(db, nil, d.tokenHasher)
 			err = repository.DeleteByToken(t.args.ctx, t.args.token)
 
 			s.Equal(t.expected.err, err)
@@ -289,8 +321,8 @@ func (s *AuthRepositoryTestSuite) TestFindByToken() {
 				err:  test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, token, expires_at FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnError(test.ErrDatabase)
 			},
 		},
@@ -305,8 +337,8 @@ func (s *AuthRepositoryTestSuite) TestFindByToken() {
 				err:  domain.ErrAuthNotFound,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, token, expires_at FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnError(sql.ErrNoRows)
 			},
 		},
@@ -321,8 +353,8 @@ func (s *AuthRepositoryTestSuite) TestFindByToken() {
 				err:  test.ErrRowScan,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, token, expires_at FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnError(test.ErrRowScan)
 			},
 		},
@@ -333,20 +365,15 @@ func (s *AuthRepositoryTestSuite) TestFindByToken() {
 				token: "yyyyy.yyyyy.yyyyy",
 			},
 			expected: expected{
-				auth: entity.Auth{
-					ID:        "auth-xxxxx",
-					UserID:    "user-xxxxx",
-					Token:     "yyyyy.yyyyy.yyyyy",
-					ExpiresAt: test.TimeAfterNow,
-				},
-				err: nil,
+				auth: newAuth(),
+				err:  nil,
 			},
 			setup: func(d *dependency) {
-				mockRow := sqlmock.NewRows([]string{"id", "user_id", "token", "expires_at"}).
-					AddRow("auth-xxxxx", "user-xxxxx", "yyyyy.yyyyy.yyyyy", test.TimeAfterNow)
+				mockRow := sqlmock.NewRows(authColumns).
+					AddRow("auth-xxxxx", "user-xxxxx", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", test.TimeBeforeNow, test.TimeBeforeNow)
 
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, token, expires_at FROM authentications WHERE token = $1`)).
-					WithArgs("yyyyy.yyyyy.yyyyy").
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE token_hash = $1`)).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy").
 					WillReturnRows(mockRow)
 			},
 		},
@@ -360,11 +387,12 @@ func (s *AuthRepositoryTestSuite) TestFindByToken() {
 			}
 
 			d := &dependency{
-				mockDB: mockDB,
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
 			}
 			t.setup(d)
 
-			repository := New(db, nil)
+			repository := New(db, nil, d.tokenHasher)
 			auth, err := repository.FindByToken(t.args.ctx, t.args.token)
 
 			s.Equal(t.expected.err, err)
@@ -372,3 +400,605 @@ func (s *AuthRepositoryTestSuite) TestFindByToken() {
 		})
 	}
 }
+
+func (s *AuthRepositoryTestSuite) TestDeleteByUserID() {
+	type args struct {
+		ctx    context.Context
+		userID entity.UserID
+	}
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name: "it should return error when database fail to delete",
+			args: args{
+				ctx:    context.Background(),
+				userID: "user-xxxxx",
+			},
+			expected: expected{
+				err: test.ErrDatabase,
+			},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM authentications WHERE user_id = $1`)).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name: "it should return error nil when successfully delete",
+			args: args{
+				ctx:    context.Background(),
+				userID: "user-xxxxx",
+			},
+			expected: expected{
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM authentications WHERE user_id = $1`)).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			err = repository.DeleteByUserID(t.args.ctx, t.args.userID)
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *AuthRepositoryTestSuite) TestDeleteOthersByUserID() {
+	type args struct {
+		ctx    context.Context
+		userID entity.UserID
+	}
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name: "it should return error when database fail to delete",
+			args: args{
+				ctx:    context.Background(),
+				userID: "user-xxxxx",
+			},
+			expected: expected{
+				err: test.ErrDatabase,
+			},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM authentications WHERE user_id = $1 AND id <> $2`)).
+					WithArgs("user-xxxxx", "auth-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name: "it should return error nil when successfully delete",
+			args: args{
+				ctx:    context.Background(),
+				userID: "user-xxxxx",
+			},
+			expected: expected{
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM authentications WHERE user_id = $1 AND id <> $2`)).
+					WithArgs("user-xxxxx", "auth-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 3))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			err = repository.DeleteOthersByUserID(t.args.ctx, t.args.userID, "auth-xxxxx")
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *AuthRepositoryTestSuite) TestDeleteOldestByUserID() {
+	query := regexp.QuoteMeta(`DELETE FROM authentications WHERE id IN (SELECT id FROM authentications WHERE user_id = $1 ORDER BY created_at DESC, id DESC OFFSET $2)`)
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", 5).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", 5).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			err = repository.DeleteOldestByUserID(context.Background(), "user-xxxxx", 5)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *AuthRepositoryTestSuite) TestDeleteExpired() {
+	query := regexp.QuoteMeta(`DELETE FROM authentications WHERE id IN (SELECT id FROM authentications WHERE expires_at < $1 LIMIT $2)`)
+
+	type expected struct {
+		deleted int64
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{deleted: 0, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs(test.TimeBeforeNow, 100).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and the number of deleted auths when successfully delete",
+			expected: expected{deleted: 42, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs(test.TimeBeforeNow, 100).
+					WillReturnResult(sqlmock.NewResult(0, 42))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			deleted, err := repository.DeleteExpired(context.Background(), test.TimeBeforeNow, 100)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.deleted, deleted)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *AuthRepositoryTestSuite) TestFindByID() {
+	query := regexp.QuoteMeta(`SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE id = $1`)
+
+	type expected struct {
+		auth entity.Auth
+		err  error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{auth: entity.Auth{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("auth-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrAuthNotFound when row not found",
+			expected: expected{auth: entity.Auth{}, err: domain.ErrAuthNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("auth-xxxxx").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and authentication when found",
+			expected: expected{auth: newAuth(), err: nil},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(authColumns).
+					AddRow("auth-xxxxx", "user-xxxxx", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("auth-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			auth, err := repository.FindByID(context.Background(), "auth-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.auth, auth)
+		})
+	}
+}
+
+func (s *AuthRepositoryTestSuite) TestFindAllByUserID() {
+	query := regexp.QuoteMeta(`SELECT id, user_id, expires_at, user_agent, ip_address, created_at, last_used_at FROM authentications WHERE user_id = $1 AND expires_at > NOW() ORDER BY last_used_at DESC`)
+
+	type expected struct {
+		auths []entity.Auth
+		err   error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			expected: expected{auths: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when fail to scan row",
+			expected: expected{auths: nil, err: test.ErrRowScan},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(authColumns).
+					AddRow("auth-xxxxx", "user-xxxxx", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", test.TimeBeforeNow, test.TimeBeforeNow).
+					RowError(0, test.ErrRowScan)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name:     "it should return error nil and empty authentications when user has no session",
+			expected: expected{auths: []entity.Auth{}, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnRows(sqlmock.NewRows(authColumns))
+			},
+		},
+		{
+			name:     "it should return error nil and authentications when found",
+			expected: expected{auths: []entity.Auth{newAuth()}, err: nil},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(authColumns).
+					AddRow("auth-xxxxx", "user-xxxxx", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			auths, err := repository.FindAllByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.auths, auths)
+		})
+	}
+}
+
+func (s *AuthRepositoryTestSuite) TestFindByRotatedToken() {
+	query := regexp.QuoteMeta(`SELECT a.id, a.user_id, a.expires_at, a.user_agent, a.ip_address, a.created_at, a.last_used_at FROM rotated_refresh_tokens r JOIN authentications a ON a.id = r.family_id WHERE r.token_hash = $1`)
+
+	type expected struct {
+		auth entity.Auth
+		err  error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{auth: entity.Auth{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("hashed:xxxxx.xxxxx.xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrAuthNotFound when row not found",
+			expected: expected{auth: entity.Auth{}, err: domain.ErrAuthNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("hashed:xxxxx.xxxxx.xxxxx").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and authentication of the family when found",
+			expected: expected{auth: newAuth(), err: nil},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(authColumns).
+					AddRow("auth-xxxxx", "user-xxxxx", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("hashed:xxxxx.xxxxx.xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			auth, err := repository.FindByRotatedToken(context.Background(), "xxxxx.xxxxx.xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.auth, auth)
+		})
+	}
+}
+
+func (s *AuthRepositoryTestSuite) TestRotate() {
+	updateQuery := regexp.QuoteMeta(`UPDATE authentications SET token_hash = $2, expires_at = $3, user_agent = $4, ip_address = $5, last_used_at = NOW() WHERE id = $1 AND token_hash = $6`)
+	insertQuery := regexp.QuoteMeta(`INSERT INTO rotated_refresh_tokens (token_hash, family_id) VALUES ($1, $2) ON CONFLICT (token_hash) DO NOTHING`)
+	auth := &entity.Auth{ID: "auth-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to begin transaction",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateQuery).
+					WithArgs("auth-xxxxx", "hashed:zzzzz.zzzzz.zzzzz", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", "hashed:yyyyy.yyyyy.yyyyy").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error ErrAuthNotFound and rollback when previous token is no longer current",
+			expected: domain.ErrAuthNotFound,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateQuery).
+					WithArgs("auth-xxxxx", "hashed:zzzzz.zzzzz.zzzzz", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", "hashed:yyyyy.yyyyy.yyyyy").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to record rotated token",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateQuery).
+					WithArgs("auth-xxxxx", "hashed:zzzzz.zzzzz.zzzzz", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", "hashed:yyyyy.yyyyy.yyyyy").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectExec(insertQuery).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy", "auth-xxxxx").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to commit transaction",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateQuery).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectExec(insertQuery).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully rotate",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateQuery).
+					WithArgs("auth-xxxxx", "hashed:zzzzz.zzzzz.zzzzz", test.TimeAfterNow, "Mozilla/5.0", "203.0.113.7", "hashed:yyyyy.yyyyy.yyyyy").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectExec(insertQuery).
+					WithArgs("hashed:yyyyy.yyyyy.yyyyy", "auth-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			err = repository.Rotate(context.Background(), "yyyyy.yyyyy.yyyyy", auth)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *AuthRepositoryTestSuite) TestDeleteByID() {
+	query := regexp.QuoteMeta(`DELETE FROM authentications WHERE id = $1`)
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("auth-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("auth-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:      mockDB,
+				tokenHasher: newTokenHasher(),
+			}
+			t.setup(d)
+
+			repository := New(db, nil, d.tokenHasher)
+			err = repository.DeleteByID(context.Background(), "auth-xxxxx")
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
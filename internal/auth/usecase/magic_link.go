@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// magicLinkTTL is how long a login link stays valid.
+const magicLinkTTL = 15 * time.Minute
+
+var (
+	// accountMagicLinkThrottle keep a single inbox from being flooded with login links.
+	accountMagicLinkThrottle = entity.LoginThrottle{
+		Window:          time.Hour,
+		Free:            3,
+		BaseDelay:       time.Minute,
+		MaxDelay:        15 * time.Minute,
+		Lockout:         10,
+		LockoutDuration: time.Hour,
+	}
+	// ipMagicLinkThrottle slow down an ip address requesting login links for many emails,
+	// it is more tolerant as many users can share an ip address.
+	ipMagicLinkThrottle = entity.LoginThrottle{
+		Window:          time.Hour,
+		Free:            10,
+		BaseDelay:       time.Minute,
+		MaxDelay:        15 * time.Minute,
+		Lockout:         50,
+		LockoutDuration: time.Hour,
+	}
+)
+
+// RequestMagicLink mail a single use login link, invalidating the previous ones.
+// Unknown emails are ignored so the result never reveals which emails are registered,
+// a link bound to the browser gets its nonce even then for the same reason.
+// Service accounts are ignored too, as they can not log in with a link.
+// Every request counts against the email and the ip address, registered or not, so it can't flood an inbox.
+func (u *Usecase) RequestMagicLink(ctx context.Context, payload *dto.AuthMagicLinkRequestIn) (dto.AuthMagicLinkRequestOut, error) {
+	if err := u.ensureLoginMethod(ctx, entity.LoginMethodMagicLink); err != nil {
+		return dto.AuthMagicLinkRequestOut{}, err
+	}
+
+	attempts := magicLinkAttempts(payload.Email, payload.IPAddress)
+	if err := u.checkMagicLinkThrottle(ctx, attempts); err != nil {
+		return dto.AuthMagicLinkRequestOut{}, err
+	}
+	if err := u.storeLoginAttempts(ctx, attempts); err != nil {
+		return dto.AuthMagicLinkRequestOut{}, err
+	}
+
+	var output dto.AuthMagicLinkRequestOut
+	if payload.BindBrowser {
+		nonce, err := u.tokenProvider.Generate()
+		if err != nil {
+			return dto.AuthMagicLinkRequestOut{}, err
+		}
+		output.Nonce = nonce
+	}
+
+	user, err := u.userRepository.FindByEmail(ctx, payload.Email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return output, nil
+	} else if err != nil {
+		return dto.AuthMagicLinkRequestOut{}, err
+	}
+	if user.Role == entity.RoleServiceAccount {
+		return output, nil
+	}
+
+	token, err := u.tokenProvider.Generate()
+	if err != nil {
+		return dto.AuthMagicLinkRequestOut{}, err
+	}
+
+	if err := u.magicLinkRepository.DeleteByUserID(ctx, user.ID); err != nil {
+		return dto.AuthMagicLinkRequestOut{}, err
+	}
+	magicLink := &entity.MagicLink{
+		UserID:    user.ID,
+		TokenHash: u.tokenProvider.Hash(token),
+		ExpiresAt: time.Now().Add(magicLinkTTL),
+	}
+	if output.Nonce != "" {
+		magicLink.NonceHash = u.tokenProvider.Hash(output.Nonce)
+	}
+	if err := u.magicLinkRepository.Store(ctx, magicLink); err != nil {
+		return dto.AuthMagicLinkRequestOut{}, err
+	}
+
+	mail := &entity.Mail{
+		To:       user.Email,
+		Template: entity.MailTemplateMagicLink,
+		Data:     map[string]string{"Name": user.Name, "Token": token},
+	}
+	if err := u.mailer.Send(ctx, mail); err != nil {
+		return dto.AuthMagicLinkRequestOut{}, err
+	}
+	return output, nil
+}
+
+// magicLinkAttempts return the email and, when known, the ip address login link requests are counted against.
+func magicLinkAttempts(email string, ipAddress string) []entity.LoginAttempt {
+	attempts := []entity.LoginAttempt{{Scope: entity.LoginAttemptScopeMagicLinkAccount, Identifier: strings.ToLower(email)}}
+	if ipAddress != "" {
+		attempts = append(attempts, entity.LoginAttempt{Scope: entity.LoginAttemptScopeMagicLinkIP, Identifier: ipAddress})
+	}
+	return attempts
+}
+
+// checkMagicLinkThrottle return a MagicLinkThrottledError with the longest wait when any of the attempts is throttled.
+func (u *Usecase) checkMagicLinkThrottle(ctx context.Context, attempts []entity.LoginAttempt) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, a := range attempts {
+		throttle := accountMagicLinkThrottle
+		if a.Scope == entity.LoginAttemptScopeMagicLinkIP {
+			throttle = ipMagicLinkThrottle
+		}
+		requests, err := u.loginAttemptRepository.CountSince(ctx, a.Scope, a.Identifier, now.Add(-throttle.Window))
+		if err != nil {
+			return err
+		}
+		if wait := throttle.RetryAfter(requests, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return &domain.MagicLinkThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// LoginMagicLink exchange a login link for the actual tokens.
+// Opening the link proves the user owns the email address, so it is marked as verified.
+// Users with two-factor authentication enabled are still challenged for their second factor.
+func (u *Usecase) LoginMagicLink(ctx context.Context, payload *dto.AuthMagicLinkLoginIn) (dto.AuthLoginOut, error) {
+	if err := u.ensureLoginMethod(ctx, entity.LoginMethodMagicLink); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	// Using the link up first, so a link confirmed twice at once only logs in once.
+	magicLink, err := u.magicLinkRepository.Consume(ctx, u.tokenProvider.Hash(payload.Token))
+	if errors.Is(err, domain.ErrMagicLinkNotFound) {
+		return dto.AuthLoginOut{}, domain.ErrMagicLinkTokenInvalid
+	} else if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := magicLink.VerifyTokenExpires(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	var nonceHash string
+	if payload.Nonce != "" {
+		nonceHash = u.tokenProvider.Hash(payload.Nonce)
+	}
+	if err := magicLink.VerifyNonce(nonceHash); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	user, err := u.userRepository.FindByID(ctx, magicLink.UserID)
+	if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := user.VerifyNotSuspended(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := user.VerifyNotServiceAccount(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if !user.IsEmailVerified() {
+		if err := u.userRepository.MarkEmailVerified(ctx, user.ID); err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+	}
+
+	return u.complete(ctx, user.ID, payload.UserAgent, payload.IPAddress)
+}
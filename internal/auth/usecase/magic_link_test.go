@@ -0,0 +1,544 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+// matchMagicLink match a login link of the user that expires in fifteen minutes.
+func matchMagicLink(nonceHash string) any {
+	return mock.MatchedBy(func(m *entity.MagicLink) bool {
+		expiresIn := time.Until(m.ExpiresAt)
+		return m.UserID == "user-xxxxx" && m.TokenHash == "magic_token_hash" && m.NonceHash == nonceHash &&
+			expiresIn > 14*time.Minute && expiresIn <= 15*time.Minute
+	})
+}
+
+var magicLinkMail = &entity.Mail{
+	To:       "gopher@go.dev",
+	Template: entity.MailTemplateMagicLink,
+	Data:     map[string]string{"Name": "Gopher", "Token": "magic_token"},
+}
+
+// allowMagicLink expect the email to be under its throttle and record the request.
+func allowMagicLink(d *dependency) {
+	d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeMagicLinkAccount, "gopher@go.dev", mock.AnythingOfType("time.Time")).
+		Return(entity.LoginFailures{}, nil)
+	d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeMagicLinkAccount, Identifier: "gopher@go.dev"}).
+		Return(nil)
+}
+
+var verifiedAt = entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}
+
+func (s *AuthUsecaseTestSuite) TestRequestMagicLink() {
+	type args struct {
+		payload *dto.AuthMagicLinkRequestIn
+	}
+	type expected struct {
+		output dto.AuthMagicLinkRequestOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrLoginMethodDisabled when magic link login is disabled",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: domain.ErrLoginMethodDisabled},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(false, nil)
+			},
+		},
+		{
+			name:     "it should return error when login attempt repository CountSince return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeMagicLinkAccount, "gopher@go.dev", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error MagicLinkThrottledError without looking up the email when the ip address requested too many links",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "Gopher@go.dev", IPAddress: "203.0.113.7"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: &domain.MagicLinkThrottledError{RetryAfter: ipMagicLinkThrottle.LockoutDuration}},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeMagicLinkAccount, "gopher@go.dev", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeMagicLinkIP, "203.0.113.7", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{Count: ipMagicLinkThrottle.Lockout, LastFailedAt: time.Now()}, nil)
+			},
+		},
+		{
+			name:     "it should return error when login attempt repository Store return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeMagicLinkAccount, "gopher@go.dev", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeMagicLinkAccount, Identifier: "gopher@go.dev"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when generate nonce failed",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", BindBrowser: true}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected).Once()
+			},
+		},
+		{
+			name:     "it should return error when user repository FindByEmail return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and the nonce without sending a mail when email is not registered",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", BindBrowser: true}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{Nonce: "nonce"}, err: nil},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.tokenProvider.On("Generate").Return("nonce", nil).Once()
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error nil without sending a mail when email belongs to a service account",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: nil},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Name: "CI", Email: "gopher@go.dev", Role: entity.RoleServiceAccount}, nil)
+			},
+		},
+		{
+			name:     "it should return error when generate token failed",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}, nil)
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected).Once()
+			},
+		},
+		{
+			name:     "it should return error when magic link repository DeleteByUserID return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}, nil)
+				d.tokenProvider.On("Generate").Return("magic_token", nil).Once()
+				d.magicLinkRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when magic link repository Store return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}, nil)
+				d.tokenProvider.On("Generate").Return("magic_token", nil).Once()
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.magicLinkRepository.On("Store", context.Background(), matchMagicLink("")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when mailer Send return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}, nil)
+				d.tokenProvider.On("Generate").Return("magic_token", nil).Once()
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.magicLinkRepository.On("Store", context.Background(), matchMagicLink("")).
+					Return(nil)
+				d.mailer.On("Send", context.Background(), magicLinkMail).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully mail a login link",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev"}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{}, err: nil},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}, nil)
+				d.tokenProvider.On("Generate").Return("magic_token", nil).Once()
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.magicLinkRepository.On("Store", context.Background(), matchMagicLink("")).
+					Return(nil)
+				d.mailer.On("Send", context.Background(), magicLinkMail).
+					Return(nil)
+			},
+		},
+		{
+			name:     "it should return error nil and the nonce when successfully mail a login link bound to the browser",
+			args:     args{payload: &dto.AuthMagicLinkRequestIn{Email: "gopher@go.dev", BindBrowser: true}},
+			expected: expected{output: dto.AuthMagicLinkRequestOut{Nonce: "nonce"}, err: nil},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				allowMagicLink(d)
+				d.tokenProvider.On("Generate").Return("nonce", nil).Once()
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}, nil)
+				d.tokenProvider.On("Generate").Return("magic_token", nil).Once()
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.tokenProvider.On("Hash", "nonce").Return("nonce_hash")
+				d.magicLinkRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.magicLinkRepository.On("Store", context.Background(), matchMagicLink("nonce_hash")).
+					Return(nil)
+				d.mailer.On("Send", context.Background(), magicLinkMail).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				userRepository:          &mocks.UserRepository{},
+				tokenProvider:           &mocks.TokenProvider{},
+				magicLinkRepository:     &mocks.MagicLinkRepository{},
+				loginMethodRepository:   &mocks.LoginMethodRepository{},
+				mailer:                  &mocks.Mailer{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+				loginAttemptRepository:  &mocks.LoginAttemptRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			output, err := usecase.RequestMagicLink(context.Background(), t.args.payload)
+
+			var throttled *domain.MagicLinkThrottledError
+			if errors.As(t.expected.err, &throttled) {
+				var actual *domain.MagicLinkThrottledError
+				s.Require().ErrorAs(err, &actual)
+				s.InDelta(throttled.RetryAfter, actual.RetryAfter, float64(time.Second))
+			} else {
+				s.Equal(t.expected.err, err)
+			}
+			s.Equal(t.expected.output, output)
+			d.mailer.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *AuthUsecaseTestSuite) TestLoginMagicLink() {
+	magicLink := entity.MagicLink{ID: "magic-link-xxxxx", UserID: "user-xxxxx", TokenHash: "magic_token_hash", ExpiresAt: test.TimeAfterNow}
+	boundMagicLink := magicLink
+	boundMagicLink.NonceHash = "nonce_hash"
+
+	type args struct {
+		payload *dto.AuthMagicLinkLoginIn
+	}
+	type expected struct {
+		output dto.AuthLoginOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrLoginMethodDisabled when magic link login is disabled",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: domain.ErrLoginMethodDisabled},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(false, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrMagicLinkTokenInvalid when login link is not found",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: domain.ErrMagicLinkTokenInvalid},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(entity.MagicLink{}, domain.ErrMagicLinkNotFound)
+			},
+		},
+		{
+			name:     "it should return error when magic link repository Consume return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(entity.MagicLink{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrMagicLinkTokenExpired when login link is expired",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: entity.ErrMagicLinkTokenExpired},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(entity.MagicLink{UserID: "user-xxxxx", ExpiresAt: test.TimeBeforeNow}, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrMagicLinkNonceMismatch when login link is bound to another browser",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: entity.ErrMagicLinkNonceMismatch},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(boundMagicLink, nil)
+			},
+		},
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(magicLink, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrUserSuspended when user is suspended",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: entity.ErrUserSuspended},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(magicLink, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", SuspendedAt: verifiedAt}, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrUserServiceAccount when user is a service account",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: entity.ErrUserServiceAccount},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(magicLink, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleServiceAccount}, nil)
+			},
+		},
+		{
+			name:     "it should return error when user repository MarkEmailVerified return unexpected error",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(magicLink, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.userRepository.On("MarkEmailVerified", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and mfa token when user has two-factor authentication enabled",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token"}},
+			expected: expected{output: dto.AuthLoginOut{MFAToken: "mfa_token"}, err: nil},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(magicLink, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", EmailVerifiedAt: verifiedAt}, nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET", EnabledAt: verifiedAt}, nil)
+				d.tokenProvider.On("Generate").Return("mfa_token", nil)
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+				d.twoFactorRepository.On("StoreChallenge", context.Background(), matchChallenge("user-xxxxx")).
+					Return(nil)
+			},
+		},
+		{
+			name:     "it should return error nil and output when successfully log in with a login link bound to the browser",
+			args:     args{payload: &dto.AuthMagicLinkLoginIn{Token: "magic_token", Nonce: "nonce", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}},
+			expected: expected{output: dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, err: nil},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodMagicLink).
+					Return(true, nil)
+				d.tokenProvider.On("Hash", "magic_token").Return("magic_token_hash")
+				d.tokenProvider.On("Hash", "nonce").Return("nonce_hash")
+				d.magicLinkRepository.On("Consume", context.Background(), "magic_token_hash").
+					Return(boundMagicLink, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+				d.userRepository.On("MarkEmailVerified", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID("auth-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				authRepository:          &mocks.AuthRepository{},
+				userRepository:          &mocks.UserRepository{},
+				twoFactorRepository:     &mocks.TwoFactorRepository{},
+				magicLinkRepository:     &mocks.MagicLinkRepository{},
+				loginMethodRepository:   &mocks.LoginMethodRepository{},
+				jwtProvider:             &mocks.JWTProvider{},
+				tokenProvider:           &mocks.TokenProvider{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			output, err := usecase.LoginMagicLink(context.Background(), t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *AuthUsecaseTestSuite) TestGetLoginMethods() {
+	type expected struct {
+		output dto.AuthLoginMethodsOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when login method repository FindAll return unexpected error",
+			expected: expected{output: dto.AuthLoginMethodsOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and only the enabled login methods when success",
+			expected: expected{output: dto.AuthLoginMethodsOut{Methods: []entity.LoginMethod{entity.LoginMethodPassword}}, err: nil},
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("FindAll", context.Background()).
+					Return([]entity.LoginMethodSetting{
+						{Method: entity.LoginMethodMagicLink, Enabled: false, UpdatedAt: test.TimeBeforeNow},
+						{Method: entity.LoginMethodPassword, Enabled: true, UpdatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				loginMethodRepository:   &mocks.LoginMethodRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			output, err := usecase.GetLoginMethods(context.Background())
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
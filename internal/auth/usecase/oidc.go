@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// oidcStateTTL is how long a user has to log in at the provider.
+const oidcStateTTL = 10 * time.Minute
+
+// StartOIDC start a login at an OpenID Connect provider.
+// The state, nonce and pkce verifier are kept server side until the provider redirects back,
+// the state is returned too so the browser that started the login can keep it.
+func (u *Usecase) StartOIDC(ctx context.Context, payload *dto.AuthOIDCStartIn) (dto.AuthOIDCStartOut, error) {
+	state, err := u.tokenProvider.Generate()
+	if err != nil {
+		return dto.AuthOIDCStartOut{}, err
+	}
+	nonce, err := u.tokenProvider.Generate()
+	if err != nil {
+		return dto.AuthOIDCStartOut{}, err
+	}
+	codeVerifier, err := u.tokenProvider.Generate()
+	if err != nil {
+		return dto.AuthOIDCStartOut{}, err
+	}
+
+	authorizationURL, err := u.oidcProvider.AuthCodeURL(ctx, payload.Provider, state, nonce, codeVerifier)
+	if err != nil {
+		return dto.AuthOIDCStartOut{}, err
+	}
+
+	oidcState := &entity.OIDCState{
+		Provider:     payload.Provider,
+		StateHash:    u.tokenProvider.Hash(state),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(oidcStateTTL),
+	}
+	if err := u.identityRepository.StoreState(ctx, oidcState); err != nil {
+		return dto.AuthOIDCStartOut{}, err
+	}
+
+	return dto.AuthOIDCStartOut{AuthorizationURL: authorizationURL, State: state}, nil
+}
+
+// LoginOIDC finish a login at an OpenID Connect provider.
+// The state must come back to the browser that started the login, otherwise an attacker
+// could get a victim to finish a login the attacker started and use the attacker's account.
+// The external identity is linked to an existing user or a new user is provisioned.
+func (u *Usecase) LoginOIDC(ctx context.Context, payload *dto.AuthOIDCLoginIn) (dto.AuthLoginOut, error) {
+	if subtle.ConstantTimeCompare([]byte(payload.State), []byte(payload.BrowserState)) != 1 {
+		return dto.AuthLoginOut{}, domain.ErrOIDCStateInvalid
+	}
+
+	state, err := u.identityRepository.ConsumeState(ctx, u.tokenProvider.Hash(payload.State))
+	if errors.Is(err, domain.ErrOIDCStateNotFound) {
+		return dto.AuthLoginOut{}, domain.ErrOIDCStateInvalid
+	} else if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if state.Provider != payload.Provider {
+		return dto.AuthLoginOut{}, domain.ErrOIDCStateInvalid
+	}
+	if err := state.VerifyExpires(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	claims, err := u.oidcProvider.Exchange(ctx, state.Provider, payload.Code, state.CodeVerifier, state.Nonce)
+	if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	userID, err := u.resolveIdentity(ctx, state.Provider, &claims)
+	if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	user, err := u.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := user.VerifyNotSuspended(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := user.VerifyNotServiceAccount(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	return u.complete(ctx, userID, payload.UserAgent, payload.IPAddress)
+}
+
+// resolveIdentity find the user of an external identity, linking it on first login.
+// An account is only linked or created when the provider verified the email address,
+// otherwise anyone could take it over, or take the email first, by registering it at the provider.
+func (u *Usecase) resolveIdentity(ctx context.Context, provider string, claims *entity.OIDCClaims) (entity.UserID, error) {
+	identity, err := u.identityRepository.FindByProviderSubject(ctx, provider, claims.Subject)
+	if err == nil {
+		return identity.UserID, nil
+	} else if !errors.Is(err, domain.ErrUserIdentityNotFound) {
+		return "", err
+	}
+
+	if claims.Email == "" {
+		return "", domain.ErrOIDCEmailMissing
+	}
+
+	var userID entity.UserID
+	user, err := u.userRepository.FindByEmail(ctx, claims.Email)
+	switch {
+	case err == nil:
+		if !claims.EmailVerified {
+			return "", domain.ErrOIDCEmailConflict
+		}
+		userID = user.ID
+	case errors.Is(err, domain.ErrUserNotFound):
+		if !claims.EmailVerified {
+			return "", domain.ErrOIDCEmailUnverified
+		}
+		userID, err = u.provision(ctx, claims)
+		if err != nil {
+			return "", err
+		}
+	default:
+		return "", err
+	}
+
+	identity = entity.UserIdentity{UserID: userID, Provider: provider, Subject: claims.Subject, Email: claims.Email}
+	if err := u.identityRepository.Store(ctx, &identity); err != nil {
+		return "", err
+	}
+	return userID, nil
+}
+
+// provision create a verified user for an external identity whose email the provider verified.
+// The user gets a random password, a password reset lets them log in without the provider.
+func (u *Usecase) provision(ctx context.Context, claims *entity.OIDCClaims) (entity.UserID, error) {
+	password, err := u.tokenProvider.Generate()
+	if err != nil {
+		return "", err
+	}
+	securePassword, err := u.hashProvider.Hash(password)
+	if err != nil {
+		return "", err
+	}
+
+	name := claims.Name
+	if name == "" {
+		name, _, _ = strings.Cut(claims.Email, "@")
+	}
+	userID, err := u.userRepository.Store(ctx, &entity.User{Name: name, Email: claims.Email, Password: string(securePassword)})
+	if err != nil {
+		return "", err
+	}
+
+	if err := u.userRepository.MarkEmailVerified(ctx, userID); err != nil {
+		return "", err
+	}
+	return userID, nil
+}
@@ -0,0 +1,489 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+// matchOIDCState match the pending login stored when starting an OpenID Connect login.
+func matchOIDCState() any {
+	return mock.MatchedBy(func(s *entity.OIDCState) bool {
+		expiresIn := time.Until(s.ExpiresAt)
+		return s.Provider == "acme" && s.StateHash == "state_hash" && s.Nonce == "nonce" && s.CodeVerifier == "code_verifier" &&
+			expiresIn > 9*time.Minute && expiresIn <= 10*time.Minute
+	})
+}
+
+func (s *AuthUsecaseTestSuite) TestStartOIDC() {
+	type expected struct {
+		output dto.AuthOIDCStartOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when generate state failed",
+			expected: expected{output: dto.AuthOIDCStartOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected).Once()
+			},
+		},
+		{
+			name:     "it should return error when oidc provider AuthCodeURL return error",
+			expected: expected{output: dto.AuthOIDCStartOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Generate").Return("state", nil).Once()
+				d.tokenProvider.On("Generate").Return("nonce", nil).Once()
+				d.tokenProvider.On("Generate").Return("code_verifier", nil).Once()
+
+				d.oidcProvider.On("AuthCodeURL", context.Background(), "acme", "state", "nonce", "code_verifier").
+					Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when identity repository StoreState return unexpected error",
+			expected: expected{output: dto.AuthOIDCStartOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Generate").Return("state", nil).Once()
+				d.tokenProvider.On("Generate").Return("nonce", nil).Once()
+				d.tokenProvider.On("Generate").Return("code_verifier", nil).Once()
+				d.tokenProvider.On("Hash", "state").Return("state_hash")
+
+				d.oidcProvider.On("AuthCodeURL", context.Background(), "acme", "state", "nonce", "code_verifier").
+					Return("https://idp.acme.dev/authorize?state=state", nil)
+
+				d.identityRepository.On("StoreState", context.Background(), matchOIDCState()).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and authorization url when success",
+			expected: expected{output: dto.AuthOIDCStartOut{AuthorizationURL: "https://idp.acme.dev/authorize?state=state", State: "state"}, err: nil},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Generate").Return("state", nil).Once()
+				d.tokenProvider.On("Generate").Return("nonce", nil).Once()
+				d.tokenProvider.On("Generate").Return("code_verifier", nil).Once()
+				d.tokenProvider.On("Hash", "state").Return("state_hash")
+
+				d.oidcProvider.On("AuthCodeURL", context.Background(), "acme", "state", "nonce", "code_verifier").
+					Return("https://idp.acme.dev/authorize?state=state", nil)
+
+				d.identityRepository.On("StoreState", context.Background(), matchOIDCState()).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				tokenProvider:           &mocks.TokenProvider{},
+				oidcProvider:            &mocks.OIDCProvider{},
+				identityRepository:      &mocks.IdentityRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			output, err := usecase.StartOIDC(context.Background(), &dto.AuthOIDCStartIn{Provider: "acme"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *AuthUsecaseTestSuite) TestLoginOIDC() {
+	type expected struct {
+		output dto.AuthLoginOut
+		err    error
+	}
+	state := entity.OIDCState{ID: "state-xxxxx", Provider: "acme", StateHash: "state_hash", Nonce: "nonce", CodeVerifier: "code_verifier", ExpiresAt: test.TimeAfterNow}
+	claims := entity.OIDCClaims{Subject: "subject-xxxxx", Email: "gopher@go.dev", EmailVerified: true, Name: "Gopher"}
+	tokens := dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}
+
+	exchange := func(d *dependency, claims entity.OIDCClaims) {
+		d.tokenProvider.On("Hash", "state").Return("state_hash")
+
+		d.identityRepository.On("ConsumeState", context.Background(), "state_hash").
+			Return(state, nil)
+
+		d.oidcProvider.On("Exchange", context.Background(), "acme", "code", "code_verifier", "nonce").
+			Return(claims, nil)
+	}
+	issue := func(d *dependency) {
+		d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+			Return(entity.User{ID: "user-xxxxx"}, nil)
+
+		d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+			Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+
+		d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+			Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+
+		d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+			Return(entity.AuthID("auth-xxxxx"), nil)
+		d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+			Return(nil)
+
+		d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+			Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+	}
+
+	tests := []struct {
+		name     string
+		provider string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrOIDCStateInvalid when state is unknown or already used",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: domain.ErrOIDCStateInvalid},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "state").Return("state_hash")
+
+				d.identityRepository.On("ConsumeState", context.Background(), "state_hash").
+					Return(entity.OIDCState{}, domain.ErrOIDCStateNotFound)
+			},
+		},
+		{
+			name:     "it should return error when identity repository ConsumeState return unexpected error",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "state").Return("state_hash")
+
+				d.identityRepository.On("ConsumeState", context.Background(), "state_hash").
+					Return(entity.OIDCState{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrOIDCStateInvalid when state was issued for another provider",
+			provider: "globex",
+			expected: expected{output: dto.AuthLoginOut{}, err: domain.ErrOIDCStateInvalid},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "state").Return("state_hash")
+
+				d.identityRepository.On("ConsumeState", context.Background(), "state_hash").
+					Return(state, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrOIDCStateExpired when state is expired",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: entity.ErrOIDCStateExpired},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "state").Return("state_hash")
+
+				d.identityRepository.On("ConsumeState", context.Background(), "state_hash").
+					Return(entity.OIDCState{Provider: "acme", ExpiresAt: test.TimeBeforeNow}, nil)
+			},
+		},
+		{
+			name:     "it should return error when oidc provider Exchange return error",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "state").Return("state_hash")
+
+				d.identityRepository.On("ConsumeState", context.Background(), "state_hash").
+					Return(state, nil)
+
+				d.oidcProvider.On("Exchange", context.Background(), "acme", "code", "code_verifier", "nonce").
+					Return(entity.OIDCClaims{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when identity repository FindByProviderSubject return unexpected error",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and output when identity is already linked",
+			provider: "acme",
+			expected: expected{output: tokens, err: nil},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{ID: "identity-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				issue(d)
+			},
+		},
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{ID: "identity-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrUserSuspended when user is suspended",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: entity.ErrUserSuspended},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{ID: "identity-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", SuspendedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrUserServiceAccount when user is a service account",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: entity.ErrUserServiceAccount},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{ID: "identity-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleServiceAccount}, nil)
+			},
+		},
+		{
+			name:     "it should return error nil and mfa token when user has two-factor authentication enabled",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{MFAToken: "mfa_token"}, err: nil},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{ID: "identity-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{UserID: "user-xxxxx", EnabledAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
+
+				d.tokenProvider.On("Generate").Return("mfa_token", nil)
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("StoreChallenge", context.Background(), matchChallenge("user-xxxxx")).
+					Return(nil)
+			},
+		},
+		{
+			name:     "it should return error ErrOIDCEmailMissing when provider does not share an email",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: domain.ErrOIDCEmailMissing},
+			setup: func(d *dependency) {
+				exchange(d, entity.OIDCClaims{Subject: "subject-xxxxx"})
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, domain.ErrUserIdentityNotFound)
+			},
+		},
+		{
+			name:     "it should return error when user repository FindByEmail return unexpected error",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, domain.ErrUserIdentityNotFound)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrOIDCEmailConflict when email of an existing user is not verified by the provider",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: domain.ErrOIDCEmailConflict},
+			setup: func(d *dependency) {
+				exchange(d, entity.OIDCClaims{Subject: "subject-xxxxx", Email: "gopher@go.dev", EmailVerified: false})
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, domain.ErrUserIdentityNotFound)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Email: "gopher@go.dev"}, nil)
+			},
+		},
+		{
+			name:     "it should return error when identity repository Store return unexpected error",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, domain.ErrUserIdentityNotFound)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Email: "gopher@go.dev"}, nil)
+
+				d.identityRepository.On("Store", context.Background(), &entity.UserIdentity{UserID: "user-xxxxx", Provider: "acme", Subject: "subject-xxxxx", Email: "gopher@go.dev"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and output when identity is linked to an existing user",
+			provider: "acme",
+			expected: expected{output: tokens, err: nil},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, domain.ErrUserIdentityNotFound)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Email: "gopher@go.dev"}, nil)
+
+				d.identityRepository.On("Store", context.Background(), &entity.UserIdentity{UserID: "user-xxxxx", Provider: "acme", Subject: "subject-xxxxx", Email: "gopher@go.dev"}).
+					Return(nil)
+
+				issue(d)
+			},
+		},
+		{
+			name:     "it should return error ErrOIDCEmailUnverified without creating a user when email is not verified by the provider",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: domain.ErrOIDCEmailUnverified},
+			setup: func(d *dependency) {
+				exchange(d, entity.OIDCClaims{Subject: "subject-xxxxx", Email: "gopher@go.dev", EmailVerified: false})
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, domain.ErrUserIdentityNotFound)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error when user repository Store return unexpected error",
+			provider: "acme",
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				exchange(d, claims)
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, domain.ErrUserIdentityNotFound)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+
+				d.tokenProvider.On("Generate").Return("random_password", nil)
+
+				d.hashProvider.On("Hash", "random_password").
+					Return([]byte("random_hashed_password"), nil)
+
+				d.userRepository.On("Store", context.Background(), &entity.User{Name: "Gopher", Email: "gopher@go.dev", Password: "random_hashed_password"}).
+					Return(entity.UserID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and output when a new user is provisioned",
+			provider: "acme",
+			expected: expected{output: tokens, err: nil},
+			setup: func(d *dependency) {
+				exchange(d, entity.OIDCClaims{Subject: "subject-xxxxx", Email: "gopher@go.dev", EmailVerified: true})
+
+				d.identityRepository.On("FindByProviderSubject", context.Background(), "acme", "subject-xxxxx").
+					Return(entity.UserIdentity{}, domain.ErrUserIdentityNotFound)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+
+				d.tokenProvider.On("Generate").Return("random_password", nil)
+
+				d.hashProvider.On("Hash", "random_password").
+					Return([]byte("random_hashed_password"), nil)
+
+				d.userRepository.On("Store", context.Background(), &entity.User{Name: "gopher", Email: "gopher@go.dev", Password: "random_hashed_password"}).
+					Return(entity.UserID("user-xxxxx"), nil)
+
+				d.userRepository.On("MarkEmailVerified", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+
+				d.identityRepository.On("Store", context.Background(), &entity.UserIdentity{UserID: "user-xxxxx", Provider: "acme", Subject: "subject-xxxxx", Email: "gopher@go.dev"}).
+					Return(nil)
+
+				issue(d)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				authRepository:          &mocks.AuthRepository{},
+				userRepository:          &mocks.UserRepository{},
+				twoFactorRepository:     &mocks.TwoFactorRepository{},
+				identityRepository:      &mocks.IdentityRepository{},
+				hashProvider:            &mocks.HashProvider{},
+				jwtProvider:             &mocks.JWTProvider{},
+				tokenProvider:           &mocks.TokenProvider{},
+				oidcProvider:            &mocks.OIDCProvider{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			output, err := usecase.LoginOIDC(context.Background(), &dto.AuthOIDCLoginIn{Provider: t.provider, Code: "code", State: "state", BrowserState: "state", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *AuthUsecaseTestSuite) TestLoginOIDCBrowserState() {
+	tests := []struct {
+		name         string
+		browserState string
+	}{
+		{name: "it should return error ErrOIDCStateInvalid when browser has no state", browserState: ""},
+		{name: "it should return error ErrOIDCStateInvalid when state was started by another browser", browserState: "other_state"},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				tokenProvider:      &mocks.TokenProvider{},
+				identityRepository: &mocks.IdentityRepository{},
+			}
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			output, err := usecase.LoginOIDC(context.Background(), &dto.AuthOIDCLoginIn{Provider: "acme", Code: "code", State: "state", BrowserState: t.browserState})
+
+			s.Equal(domain.ErrOIDCStateInvalid, err)
+			s.Equal(dto.AuthLoginOut{}, output)
+			d.identityRepository.AssertNotCalled(s.T(), "ConsumeState", mock.Anything, mock.Anything)
+		})
+	}
+}
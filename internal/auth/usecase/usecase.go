@@ -3,66 +3,384 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 )
 
+const (
+	// mfaChallengeTTL is how long a user has to enter the second factor after the password.
+	mfaChallengeTTL = 5 * time.Minute
+	// maxMFAChallengeAttempts is how many wrong codes a challenge tolerates before it is dropped.
+	maxMFAChallengeAttempts = 5
+)
+
+var (
+	// accountLoginThrottle slow down password guessing against a single account.
+	accountLoginThrottle = entity.LoginThrottle{
+		Window:          time.Hour,
+		Free:            3,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		Lockout:         10,
+		LockoutDuration: 15 * time.Minute,
+	}
+	// ipLoginThrottle slow down an ip address trying many accounts,
+	// it is more tolerant as many users can share an ip address.
+	ipLoginThrottle = entity.LoginThrottle{
+		Window:          time.Hour,
+		Free:            20,
+		BaseDelay:       time.Second,
+		MaxDelay:        time.Minute,
+		Lockout:         100,
+		LockoutDuration: 15 * time.Minute,
+	}
+)
+
 type Usecase struct {
-	validator      domain.ValidatorProvider
-	authRepository domain.AuthRepository
-	userRepository domain.UserRepository
-	hashProvider   domain.HashProvider
-	jwtProvider    domain.JWTProvider
+	validator               domain.ValidatorProvider
+	authRepository          domain.AuthRepository
+	userRepository          domain.UserRepository
+	twoFactorRepository     domain.TwoFactorRepository
+	identityRepository      domain.IdentityRepository
+	securityEventRepository domain.SecurityEventRepository
+	loginAttemptRepository  domain.LoginAttemptRepository
+	revocationRepository    domain.RevocationRepository
+	magicLinkRepository     domain.MagicLinkRepository
+	loginMethodRepository   domain.LoginMethodRepository
+	hashProvider            domain.HashProvider
+	jwtProvider             domain.JWTProvider
+	tokenProvider           domain.TokenProvider
+	totpProvider            domain.TOTPProvider
+	oidcProvider            domain.OIDCProvider
+	mailer                  domain.Mailer
+	maxSessions             int
 }
 
 // New create a new auth usecase.
+// Users are limited to maxSessions sessions at once, zero means no limit.
 func New(
 	validator domain.ValidatorProvider,
 	authRepository domain.AuthRepository,
 	userRepository domain.UserRepository,
+	twoFactorRepository domain.TwoFactorRepository,
+	identityRepository domain.IdentityRepository,
+	securityEventRepository domain.SecurityEventRepository,
+	loginAttemptRepository domain.LoginAttemptRepository,
+	revocationRepository domain.RevocationRepository,
+	magicLinkRepository domain.MagicLinkRepository,
+	loginMethodRepository domain.LoginMethodRepository,
 	hashProvider domain.HashProvider,
 	jwtProvider domain.JWTProvider,
+	tokenProvider domain.TokenProvider,
+	totpProvider domain.TOTPProvider,
+	oidcProvider domain.OIDCProvider,
+	mailer domain.Mailer,
+	maxSessions int,
 ) Usecase {
 	return Usecase{
-		validator:      validator,
-		authRepository: authRepository,
-		userRepository: userRepository,
-		hashProvider:   hashProvider,
-		jwtProvider:    jwtProvider,
+		validator:               validator,
+		authRepository:          authRepository,
+		userRepository:          userRepository,
+		twoFactorRepository:     twoFactorRepository,
+		identityRepository:      identityRepository,
+		securityEventRepository: securityEventRepository,
+		loginAttemptRepository:  loginAttemptRepository,
+		revocationRepository:    revocationRepository,
+		magicLinkRepository:     magicLinkRepository,
+		loginMethodRepository:   loginMethodRepository,
+		hashProvider:            hashProvider,
+		jwtProvider:             jwtProvider,
+		tokenProvider:           tokenProvider,
+		totpProvider:            totpProvider,
+		oidcProvider:            oidcProvider,
+		mailer:                  mailer,
+		maxSessions:             maxSessions,
 	}
 }
 
 // Login authenticates a user.
+// Users with two-factor authentication enabled get an mfa token instead of the actual tokens.
+// An unknown email and a wrong password fail the same way, and repeated failures
+// against an account or from an ip address are throttled before the password is checked.
 func (u *Usecase) Login(ctx context.Context, payload *dto.AuthLoginIn) (dto.AuthLoginOut, error) {
+	if err := u.ensureLoginMethod(ctx, entity.LoginMethodPassword); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
 	user := entity.User{Email: payload.Email, Password: payload.Password}
 	if err := u.validator.Validate(&user); err != nil {
 		return dto.AuthLoginOut{}, err
 	}
 
+	attempts := loginAttempts(user.Email, payload.IPAddress)
+	if err := u.checkLoginThrottle(ctx, attempts); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
 	targetUser, err := u.userRepository.FindByEmail(ctx, user.Email)
 	if errors.Is(err, domain.ErrUserNotFound) {
-		return dto.AuthLoginOut{}, domain.ErrEmailNotExist
+		// Hash anyway, so an unknown email takes as long as a wrong password.
+		u.hashProvider.Hash(user.Password)
+		return dto.AuthLoginOut{}, u.failLogin(ctx, attempts)
 	} else if err != nil {
 		return dto.AuthLoginOut{}, err
 	}
 
 	if err := u.hashProvider.Compare(user.Password, targetUser.Password); err != nil {
-		return dto.AuthLoginOut{}, domain.ErrPasswordIncorrect
+		if err := u.recordEvent(ctx, targetUser.ID, entity.SecurityEventLoginFailed, payload.UserAgent, payload.IPAddress); err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+		return dto.AuthLoginOut{}, u.failLogin(ctx, attempts)
+	}
+	if err := targetUser.VerifyNotSuspended(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := targetUser.VerifyNotServiceAccount(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	// Upgrade bcrypt and outdated hashes while the raw password is at hand.
+	if u.hashProvider.NeedsRehash(targetUser.Password) {
+		securePassword, err := u.hashProvider.Hash(user.Password)
+		if err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+		if err := u.userRepository.UpdatePassword(ctx, targetUser.ID, string(securePassword)); err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+	}
+
+	output, err := u.complete(ctx, targetUser.ID, payload.UserAgent, payload.IPAddress)
+	if err != nil || output.MFAToken != "" {
+		return output, err
+	}
+	if err := u.forgiveLogin(ctx, attempts); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	return output, nil
+}
+
+// ensureLoginMethod return ErrLoginMethodDisabled unless an administrator enabled the login method.
+func (u *Usecase) ensureLoginMethod(ctx context.Context, method entity.LoginMethod) error {
+	enabled, err := u.loginMethodRepository.IsEnabled(ctx, method)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return domain.ErrLoginMethodDisabled
+	}
+	return nil
+}
+
+// loginAttempts return the account and, when known, the ip address failed logins are counted against.
+func loginAttempts(email string, ipAddress string) []entity.LoginAttempt {
+	attempts := []entity.LoginAttempt{{Scope: entity.LoginAttemptScopeAccount, Identifier: strings.ToLower(email)}}
+	if ipAddress != "" {
+		attempts = append(attempts, entity.LoginAttempt{Scope: entity.LoginAttemptScopeIP, Identifier: ipAddress})
+	}
+	return attempts
+}
+
+// checkLoginThrottle return a LoginThrottledError with the longest wait when any of the attempts is throttled.
+func (u *Usecase) checkLoginThrottle(ctx context.Context, attempts []entity.LoginAttempt) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, a := range attempts {
+		throttle := loginThrottle(a.Scope)
+		failures, err := u.loginAttemptRepository.CountSince(ctx, a.Scope, a.Identifier, now.Add(-throttle.Window))
+		if err != nil {
+			return err
+		}
+		if wait := throttle.RetryAfter(failures, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return &domain.LoginThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// failLogin record a failed login against every attempt and return ErrCredentialsInvalid.
+func (u *Usecase) failLogin(ctx context.Context, attempts []entity.LoginAttempt) error {
+	if err := u.storeLoginAttempts(ctx, attempts); err != nil {
+		return err
+	}
+	return domain.ErrCredentialsInvalid
+}
+
+// storeLoginAttempts record a failed attempt against every attempt.
+func (u *Usecase) storeLoginAttempts(ctx context.Context, attempts []entity.LoginAttempt) error {
+	for i := range attempts {
+		if err := u.loginAttemptRepository.Store(ctx, &attempts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// forgiveLogin clear the failures of the account once every factor of a login passed.
+// Only the account is forgiven, otherwise an attacker could reset their ip address with an account of their own.
+func (u *Usecase) forgiveLogin(ctx context.Context, attempts []entity.LoginAttempt) error {
+	return u.loginAttemptRepository.DeleteByIdentifier(ctx, entity.LoginAttemptScopeAccount, attempts[0].Identifier)
+}
+
+func loginThrottle(scope entity.LoginAttemptScope) entity.LoginThrottle {
+	if scope == entity.LoginAttemptScopeIP {
+		return ipLoginThrottle
+	}
+	return accountLoginThrottle
+}
+
+// VerifyMFA exchange an mfa token and a TOTP or recovery code for the actual tokens.
+// Wrong codes count as failed logins of the account and the ip address, so opening
+// fresh challenges with a known password does not give unlimited guesses.
+func (u *Usecase) VerifyMFA(ctx context.Context, payload *dto.AuthVerifyMFAIn) (dto.AuthLoginOut, error) {
+	challenge, err := u.twoFactorRepository.FindChallengeByTokenHash(ctx, u.tokenProvider.Hash(payload.MFAToken))
+	if errors.Is(err, domain.ErrMFAChallengeNotFound) {
+		return dto.AuthLoginOut{}, domain.ErrMFAChallengeInvalid
+	} else if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := challenge.VerifyTokenExpires(); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if challenge.Attempts >= maxMFAChallengeAttempts {
+		if err := u.twoFactorRepository.DeleteChallengeByID(ctx, challenge.ID); err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+		return dto.AuthLoginOut{}, domain.ErrMFAChallengeInvalid
+	}
+
+	user, err := u.userRepository.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	attempts := loginAttempts(user.Email, payload.IPAddress)
+	if err := u.checkLoginThrottle(ctx, attempts); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	twoFactor, err := u.twoFactorRepository.FindByUserID(ctx, challenge.UserID)
+	if errors.Is(err, domain.ErrTwoFactorNotFound) {
+		return dto.AuthLoginOut{}, domain.ErrMFAChallengeInvalid
+	} else if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	err = u.verifyCode(ctx, &twoFactor, payload.Code)
+	if errors.Is(err, domain.ErrTwoFactorCodeInvalid) {
+		if err := u.twoFactorRepository.IncrementChallengeAttempts(ctx, challenge.ID); err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+		if err := u.recordEvent(ctx, challenge.UserID, entity.SecurityEventLoginFailed, payload.UserAgent, payload.IPAddress); err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+		if err := u.storeLoginAttempts(ctx, attempts); err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+		return dto.AuthLoginOut{}, domain.ErrTwoFactorCodeInvalid
+	} else if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	if err := u.twoFactorRepository.DeleteChallengeByID(ctx, challenge.ID); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := u.forgiveLogin(ctx, attempts); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	return u.issue(ctx, challenge.UserID, payload.UserAgent, payload.IPAddress)
+}
+
+// complete finish a login whose first factor is verified.
+// Users with two-factor authentication enabled are challenged for their second factor.
+func (u *Usecase) complete(ctx context.Context, userID entity.UserID, userAgent string, ipAddress string) (dto.AuthLoginOut, error) {
+	twoFactor, err := u.twoFactorRepository.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrTwoFactorNotFound) {
+		return dto.AuthLoginOut{}, err
+	}
+	if twoFactor.IsEnabled() {
+		return u.challenge(ctx, userID)
 	}
 
-	accessToken, _, err := u.jwtProvider.GenerateAccessToken(targetUser.ID)
+	return u.issue(ctx, userID, userAgent, ipAddress)
+}
+
+// challenge start the second step of a two-factor login.
+func (u *Usecase) challenge(ctx context.Context, userID entity.UserID) (dto.AuthLoginOut, error) {
+	token, err := u.tokenProvider.Generate()
 	if err != nil {
 		return dto.AuthLoginOut{}, err
 	}
-	refreshToken, expires, err := u.jwtProvider.GenerateRefreshToken(targetUser.ID)
+
+	challenge := &entity.MFAChallenge{
+		UserID:    userID,
+		TokenHash: u.tokenProvider.Hash(token),
+		ExpiresAt: time.Now().Add(mfaChallengeTTL),
+	}
+	if err := u.twoFactorRepository.StoreChallenge(ctx, challenge); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+
+	return dto.AuthLoginOut{MFAToken: token}, nil
+}
+
+// verifyCode accept either a TOTP code, at most once per time step, or an unused recovery code.
+func (u *Usecase) verifyCode(ctx context.Context, twoFactor *entity.TwoFactor, code string) error {
+	if step, ok := u.totpProvider.Validate(twoFactor.Secret, code, time.Now()); ok {
+		err := u.twoFactorRepository.UseStep(ctx, twoFactor.UserID, step)
+		if errors.Is(err, domain.ErrTwoFactorStepUsed) {
+			return domain.ErrTwoFactorCodeInvalid
+		}
+		return err
+	}
+
+	codeHash := u.tokenProvider.Hash(entity.NormalizeRecoveryCode(code))
+	err := u.twoFactorRepository.ConsumeRecoveryCode(ctx, twoFactor.UserID, codeHash)
+	if errors.Is(err, domain.ErrRecoveryCodeNotFound) {
+		return domain.ErrTwoFactorCodeInvalid
+	}
+	return err
+}
+
+// issue create a new session for the user and return its tokens.
+// Every login method ends here, so this is where a successful login is recorded.
+func (u *Usecase) issue(ctx context.Context, userID entity.UserID, userAgent string, ipAddress string) (dto.AuthLoginOut, error) {
+	refreshToken, expires, err := u.jwtProvider.GenerateRefreshToken(userID)
 	if err != nil {
 		return dto.AuthLoginOut{}, err
 	}
 
-	auth := &entity.Auth{UserID: targetUser.ID, Token: refreshToken, ExpiresAt: expires}
-	if err := u.authRepository.Store(ctx, auth); err != nil {
+	auth := &entity.Auth{
+		UserID:    userID,
+		Token:     refreshToken,
+		ExpiresAt: expires,
+		UserAgent: userAgent,
+		IPAddress: ipAddress,
+	}
+	authID, err := u.authRepository.Store(ctx, auth)
+	if err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	if err := u.recordEvent(ctx, userID, entity.SecurityEventLogin, userAgent, ipAddress); err != nil {
+		return dto.AuthLoginOut{}, err
+	}
+	// The new session is the newest, so signing out all but the newest ones evicts the oldest.
+	if u.maxSessions > 0 {
+		if err := u.authRepository.DeleteOldestByUserID(ctx, userID, u.maxSessions); err != nil {
+			return dto.AuthLoginOut{}, err
+		}
+	}
+
+	accessToken, _, err := u.jwtProvider.GenerateAccessToken(userID, authID)
+	if err != nil {
 		return dto.AuthLoginOut{}, err
 	}
 
@@ -80,7 +398,15 @@ func (u *Usecase) Logout(ctx context.Context, payload *dto.AuthLogoutIn) error {
 		return err
 	}
 
-	return nil
+	// Revoke the access token the logout was made with, instead of letting it live until it expires.
+	if payload.AccessToken.TokenID != "" {
+		revoked := entity.RevokedToken{TokenID: payload.AccessToken.TokenID, UserID: payload.AccessToken.UserID, ExpiresAt: payload.AccessToken.ExpiresAt}
+		if err := u.revocationRepository.Store(ctx, &revoked); err != nil {
+			return err
+		}
+	}
+
+	return u.recordEvent(ctx, payload.AccessToken.UserID, entity.SecurityEventLogout, payload.UserAgent, payload.IPAddress)
 }
 
 // GetProfile get user authenticated profile.
@@ -89,36 +415,102 @@ func (u *Usecase) GetProfile(ctx context.Context, payload *dto.AuthProfileIn) (d
 	if err != nil {
 		return dto.AuthProfileOut{}, err
 	}
-	return dto.AuthProfileOut{ID: user.ID, Name: user.Name, Email: user.Email}, nil
+	return dto.AuthProfileOut{ID: user.ID, Name: user.Name, Email: user.Email, EmailVerified: user.IsEmailVerified()}, nil
+}
+
+// GetLoginMethods get the login methods users can log in with, so clients only offer those.
+func (u *Usecase) GetLoginMethods(ctx context.Context) (dto.AuthLoginMethodsOut, error) {
+	settings, err := u.loginMethodRepository.FindAll(ctx)
+	if err != nil {
+		return dto.AuthLoginMethodsOut{}, err
+	}
+
+	methods := make([]entity.LoginMethod, 0, len(settings))
+	for _, s := range settings {
+		if s.Enabled {
+			methods = append(methods, s.Method)
+		}
+	}
+	return dto.AuthLoginMethodsOut{Methods: methods}, nil
+}
+
+// GetJWKS get the public keys other services verify access tokens with.
+func (u *Usecase) GetJWKS(ctx context.Context) (dto.AuthJWKSOut, error) {
+	return dto.AuthJWKSOut{Keys: u.jwtProvider.PublicKeys()}, nil
 }
 
 // Refresh refresh user authentication token.
+// The token is rotated in place so the session keeps its id and creation time.
+// Presenting an already rotated token revokes its whole family, since either
+// the token was stolen or the legitimate client lost the race against a thief.
 func (u *Usecase) Refresh(ctx context.Context, payload *dto.AuthRefreshIn) (dto.AuthRefreshOut, error) {
 	auth, err := u.authRepository.FindByToken(ctx, payload.RefreshToken)
-	if err != nil {
+	if errors.Is(err, domain.ErrAuthNotFound) {
+		return dto.AuthRefreshOut{}, u.detectReuse(ctx, payload, err)
+	} else if err != nil {
 		return dto.AuthRefreshOut{}, err
 	}
 	if err := auth.VerifyTokenExpires(); err != nil {
 		return dto.AuthRefreshOut{}, err
 	}
 
-	accessToken, _, err := u.jwtProvider.GenerateAccessToken(auth.UserID)
+	user, err := u.userRepository.FindByID(ctx, auth.UserID)
 	if err != nil {
 		return dto.AuthRefreshOut{}, err
 	}
+	if err := user.VerifyNotSuspended(); err != nil {
+		return dto.AuthRefreshOut{}, err
+	}
+
 	refreshToken, expires, err := u.jwtProvider.GenerateRefreshToken(auth.UserID)
 	if err != nil {
 		return dto.AuthRefreshOut{}, err
 	}
 
-	if err := u.authRepository.DeleteByToken(ctx, auth.Token); err != nil {
+	auth.Token = refreshToken
+	auth.ExpiresAt = expires
+	auth.UserAgent = payload.UserAgent
+	auth.IPAddress = payload.IPAddress
+	err = u.authRepository.Rotate(ctx, payload.RefreshToken, &auth)
+	if errors.Is(err, domain.ErrAuthNotFound) {
+		return dto.AuthRefreshOut{}, u.detectReuse(ctx, payload, err)
+	} else if err != nil {
+		return dto.AuthRefreshOut{}, err
+	}
+	if err := u.recordEvent(ctx, auth.UserID, entity.SecurityEventRefresh, payload.UserAgent, payload.IPAddress); err != nil {
 		return dto.AuthRefreshOut{}, err
 	}
 
-	newAuth := &entity.Auth{UserID: auth.UserID, Token: refreshToken, ExpiresAt: expires}
-	if err := u.authRepository.Store(ctx, newAuth); err != nil {
+	accessToken, _, err := u.jwtProvider.GenerateAccessToken(auth.UserID, auth.ID)
+	if err != nil {
 		return dto.AuthRefreshOut{}, err
 	}
 
 	return dto.AuthRefreshOut{AccessToken: accessToken, RefreshToken: refreshToken}, nil
 }
+
+// detectReuse revoke the family of an already rotated refresh token and record the reuse.
+// It returns notFoundErr unchanged when the token never belonged to a known family.
+func (u *Usecase) detectReuse(ctx context.Context, payload *dto.AuthRefreshIn, notFoundErr error) error {
+	family, err := u.authRepository.FindByRotatedToken(ctx, payload.RefreshToken)
+	if errors.Is(err, domain.ErrAuthNotFound) {
+		return notFoundErr
+	} else if err != nil {
+		return err
+	}
+
+	if err := u.authRepository.DeleteByID(ctx, family.ID); err != nil {
+		return err
+	}
+	if err := u.recordEvent(ctx, family.UserID, entity.SecurityEventRefreshTokenReuse, payload.UserAgent, payload.IPAddress); err != nil {
+		return err
+	}
+
+	return domain.ErrAuthTokenReused
+}
+
+// recordEvent append a security event to the history of the user.
+func (u *Usecase) recordEvent(ctx context.Context, userID entity.UserID, eventType entity.SecurityEventType, userAgent string, ipAddress string) error {
+	event := &entity.SecurityEvent{UserID: userID, Type: eventType, UserAgent: userAgent, IPAddress: ipAddress}
+	return u.securityEventRepository.Store(ctx, event)
+}
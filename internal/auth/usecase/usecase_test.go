@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"testing"
 	"time"
 
@@ -29,6 +30,26 @@ type dependency struct {
 	userRepository *mocks.UserRepository
 	hashProvider   *mocks.HashProvider
 	jwtProvider    *mocks.JWTProvider
+	tokenProvider  *mocks.TokenProvider
+	totpProvider   *mocks.TOTPProvider
+	oidcProvider   *mocks.OIDCProvider
+
+	twoFactorRepository     *mocks.TwoFactorRepository
+	identityRepository      *mocks.IdentityRepository
+	securityEventRepository *mocks.SecurityEventRepository
+	loginAttemptRepository  *mocks.LoginAttemptRepository
+	revocationRepository    *mocks.RevocationRepository
+	magicLinkRepository     *mocks.MagicLinkRepository
+	loginMethodRepository   *mocks.LoginMethodRepository
+	mailer                  *mocks.Mailer
+}
+
+// matchChallenge match an mfa challenge of the user that expires in five minutes.
+func matchChallenge(userID entity.UserID) any {
+	return mock.MatchedBy(func(c *entity.MFAChallenge) bool {
+		expiresIn := time.Until(c.ExpiresAt)
+		return c.UserID == userID && c.TokenHash == "mfa_token_hash" && expiresIn > 4*time.Minute && expiresIn <= 5*time.Minute
+	})
 }
 
 func (s *AuthUsecaseTestSuite) TestLogin() {
@@ -65,23 +86,61 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 			},
 		},
 		{
-			name: "it should return error ErrEmailNotExist when email not found",
+			name: "it should return error when login attempt repository CountSince return unexpected error",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthLoginIn{
-					Email: "gopher@go.dev",
+					Email:     "gopher@go.dev",
+					IPAddress: "203.0.113.7",
 				},
 			},
 			expected: expected{
 				output: dto.AuthLoginOut{},
-				err:    domain.ErrEmailNotExist,
+				err:    test.ErrUnexpected,
 			},
 			setup: func(d *dependency) {
 				d.validator.On("Validate", mock.Anything).
 					Return(nil)
 
-				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrCredentialsInvalid and record the failure when email not found",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "Gopher@go.dev",
+					Password:  "secret_password",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    domain.ErrCredentialsInvalid,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").
 					Return(entity.User{}, domain.ErrUserNotFound)
+
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("secret_hashed_password"), nil)
+
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeAccount, Identifier: "gopher@go.dev"}).
+					Return(nil)
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeIP, Identifier: "203.0.113.7"}).
+					Return(nil)
 			},
 		},
 		{
@@ -100,12 +159,15 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 				d.validator.On("Validate", mock.Anything).
 					Return(nil)
 
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
 				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
 					Return(entity.User{}, test.ErrUnexpected)
 			},
 		},
 		{
-			name: "it should return error ErrPasswordIncorrect when password is incorrect",
+			name: "it should return error when login attempt repository Store return unexpected error",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthLoginIn{
@@ -115,21 +177,30 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 			},
 			expected: expected{
 				output: dto.AuthLoginOut{},
-				err:    domain.ErrPasswordIncorrect,
+				err:    test.ErrUnexpected,
 			},
 			setup: func(d *dependency) {
 				d.validator.On("Validate", mock.Anything).
 					Return(nil)
 
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
 				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
-					Return(entity.User{Password: "secret_hashed_password"}, nil)
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
 
 				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
 					Return(test.ErrUnexpected)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed}).
+					Return(nil)
+
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeAccount, Identifier: "gopher@go.dev"}).
+					Return(test.ErrUnexpected)
 			},
 		},
 		{
-			name: "it should return error when generate access token failed",
+			name: "it should return error when security event repository Store return unexpected error on a failed login",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthLoginIn{
@@ -145,18 +216,59 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 				d.validator.On("Validate", mock.Anything).
 					Return(nil)
 
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(test.ErrUnexpected)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrCredentialsInvalid and record the failure when password is incorrect",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    domain.ErrCredentialsInvalid,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
 				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
 					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
 
 				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(test.ErrUnexpected)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed, IPAddress: "203.0.113.7"}).
 					Return(nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("", time.Time{}, test.ErrUnexpected)
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeAccount, Identifier: "gopher@go.dev"}).
+					Return(nil)
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeIP, Identifier: "203.0.113.7"}).
+					Return(nil)
 			},
 		},
 		{
-			name: "it should return error when generate refresh token failed",
+			name: "it should return error ErrUserSuspended when user is suspended",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthLoginIn{
@@ -166,27 +278,51 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 			},
 			expected: expected{
 				output: dto.AuthLoginOut{},
-				err:    test.ErrUnexpected,
+				err:    entity.ErrUserSuspended,
 			},
 			setup: func(d *dependency) {
 				d.validator.On("Validate", mock.Anything).
 					Return(nil)
 
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
 				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
-					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password", SuspendedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
 
 				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
 					Return(nil)
+			},
+		},
+		{
+			name: "it should return error ErrUserServiceAccount when user is a service account",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:    "gopher@go.dev",
+					Password: "secret_password",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    entity.ErrUserServiceAccount,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
 
-				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
-					Return("", time.Time{}, test.ErrUnexpected)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password", Role: entity.RoleServiceAccount}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
 			},
 		},
 		{
-			name: "it should return error when auth respository Store return unexpected error",
+			name: "it should return error when hash password for rehash failed",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthLoginIn{
@@ -202,29 +338,63 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 				d.validator.On("Validate", mock.Anything).
 					Return(nil)
 
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
 				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
-					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+					Return(entity.User{ID: "user-xxxxx", Password: "bcrypt_hashed_password"}, nil)
 
-				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+				d.hashProvider.On("Compare", "secret_password", "bcrypt_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "bcrypt_hashed_password").
+					Return(true)
+				d.hashProvider.On("Hash", "secret_password").
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when user repository UpdatePassword for rehash return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:    "gopher@go.dev",
+					Password: "secret_password",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
 					Return(nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
 
-				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
-					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "bcrypt_hashed_password"}, nil)
 
-				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}).
+				d.hashProvider.On("Compare", "secret_password", "bcrypt_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "bcrypt_hashed_password").
+					Return(true)
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("secret_hashed_password"), nil)
+
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "secret_hashed_password").
 					Return(test.ErrUnexpected)
 			},
 		},
 		{
-			name: "it should return error nil and output when success",
+			name: "it should return error nil and output with the password rehashed when hash is outdated",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthLoginIn{
-					Email:    "gopher@go.dev",
-					Password: "secret_password",
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
 				},
 			},
 			expected: expected{
@@ -238,20 +408,1084 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 				d.validator.On("Validate", mock.Anything).
 					Return(nil)
 
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "bcrypt_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "bcrypt_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "bcrypt_hashed_password").
+					Return(true)
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("secret_hashed_password"), nil)
+
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "secret_hashed_password").
+					Return(nil)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID("auth-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+			},
+		},
+		{
+			name: "it should return error when two factor repository FindByUserID return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when generate mfa token failed",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET", EnabledAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
+
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when two factor repository StoreChallenge return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET", EnabledAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
+
+				d.tokenProvider.On("Generate").Return("mfa_token", nil)
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("StoreChallenge", context.Background(), matchChallenge("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and mfa token only when two factor is enabled",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{MFAToken: "mfa_token"},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET", EnabledAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
+
+				d.tokenProvider.On("Generate").Return("mfa_token", nil)
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("StoreChallenge", context.Background(), matchChallenge("user-xxxxx")).
+					Return(nil)
+			},
+		},
+		{
+			name: "it should return error when generate refresh token failed",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
 				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
 					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
 
-				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("", time.Time{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when auth respository Store return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when security event repository Store return unexpected error on a successful login",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID("auth-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when generate access token failed",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID("auth-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("", time.Time{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when login attempt repository DeleteByIdentifier return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID("auth-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and output when success",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLoginIn{
+					Email:     "gopher@go.dev",
+					Password:  "secret_password",
+					UserAgent: "Mozilla/5.0",
+					IPAddress: "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthLoginOut{
+					AccessToken:  "xxxxx.xxxxx.xxxxx",
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+					Return(entity.LoginFailures{}, nil)
+
+				d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+
+				d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+					Return(nil)
+				d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+					Return(false)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(nil)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID("auth-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				validator:           &mocks.ValidatorProvider{},
+				userRepository:      &mocks.UserRepository{},
+				authRepository:      &mocks.AuthRepository{},
+				hashProvider:        &mocks.HashProvider{},
+				jwtProvider:         &mocks.JWTProvider{},
+				tokenProvider:       &mocks.TokenProvider{},
+				twoFactorRepository: &mocks.TwoFactorRepository{},
+
+				loginAttemptRepository:  &mocks.LoginAttemptRepository{},
+				loginMethodRepository:   &mocks.LoginMethodRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodPassword).
+				Return(true, nil)
+			t.setup(d)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			output, err := usecase.Login(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *AuthUsecaseTestSuite) TestLoginThrottle() {
+	tests := []struct {
+		name       string
+		account    entity.LoginFailures
+		ip         entity.LoginFailures
+		retryAfter time.Duration
+	}{
+		{
+			name:       "it should return error LoginThrottledError when account has too many failures in a row",
+			account:    entity.LoginFailures{Count: accountLoginThrottle.Free + 2, LastFailedAt: time.Now()},
+			ip:         entity.LoginFailures{},
+			retryAfter: 2 * accountLoginThrottle.BaseDelay,
+		},
+		{
+			name:       "it should return error LoginThrottledError when account is locked out",
+			account:    entity.LoginFailures{Count: accountLoginThrottle.Lockout, LastFailedAt: time.Now()},
+			ip:         entity.LoginFailures{},
+			retryAfter: accountLoginThrottle.LockoutDuration,
+		},
+		{
+			name:       "it should return error LoginThrottledError with the longest wait when ip address is locked out",
+			account:    entity.LoginFailures{Count: accountLoginThrottle.Free + 1, LastFailedAt: time.Now()},
+			ip:         entity.LoginFailures{Count: ipLoginThrottle.Lockout, LastFailedAt: time.Now()},
+			retryAfter: ipLoginThrottle.LockoutDuration,
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				validator:               &mocks.ValidatorProvider{},
+				userRepository:          &mocks.UserRepository{},
+				hashProvider:            &mocks.HashProvider{},
+				loginAttemptRepository:  &mocks.LoginAttemptRepository{},
+				loginMethodRepository:   &mocks.LoginMethodRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodPassword).
+				Return(true, nil)
+			d.validator.On("Validate", mock.Anything).
+				Return(nil)
+			d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+				Return(t.account, nil)
+			d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+				Return(t.ip, nil)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			_, err := usecase.Login(context.Background(), &dto.AuthLoginIn{Email: "gopher@go.dev", Password: "secret_password", IPAddress: "203.0.113.7"})
+
+			var throttled *domain.LoginThrottledError
+			s.Require().ErrorAs(err, &throttled)
+			s.InDelta(t.retryAfter, throttled.RetryAfter, float64(time.Second))
+			d.userRepository.AssertNotCalled(s.T(), "FindByEmail", mock.Anything, mock.Anything)
+			d.hashProvider.AssertNotCalled(s.T(), "Compare", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (s *AuthUsecaseTestSuite) TestLoginMethodDisabled() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when login method repository IsEnabled return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodPassword).
+					Return(false, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrLoginMethodDisabled when password login is disabled",
+			expected: domain.ErrLoginMethodDisabled,
+			setup: func(d *dependency) {
+				d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodPassword).
+					Return(false, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				validator:               &mocks.ValidatorProvider{},
+				loginMethodRepository:   &mocks.LoginMethodRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			_, err := usecase.Login(context.Background(), &dto.AuthLoginIn{Email: "gopher@go.dev", Password: "secret_password"})
+
+			s.Equal(t.expected, err)
+			d.validator.AssertNotCalled(s.T(), "Validate", mock.Anything)
+		})
+	}
+}
+
+func (s *AuthUsecaseTestSuite) TestLoginSessionLimit() {
+	type expected struct {
+		output dto.AuthLoginOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when auth repository DeleteOldestByUserID return unexpected error",
+			expected: expected{output: dto.AuthLoginOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.authRepository.On("DeleteOldestByUserID", context.Background(), entity.UserID("user-xxxxx"), 3).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and output after evicting the oldest sessions when success",
+			expected: expected{output: dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"}, err: nil},
+			setup: func(d *dependency) {
+				d.authRepository.On("DeleteOldestByUserID", context.Background(), entity.UserID("user-xxxxx"), 3).
+					Return(nil)
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				validator:               &mocks.ValidatorProvider{},
+				userRepository:          &mocks.UserRepository{},
+				authRepository:          &mocks.AuthRepository{},
+				hashProvider:            &mocks.HashProvider{},
+				jwtProvider:             &mocks.JWTProvider{},
+				twoFactorRepository:     &mocks.TwoFactorRepository{},
+				loginAttemptRepository:  &mocks.LoginAttemptRepository{},
+				loginMethodRepository:   &mocks.LoginMethodRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			d.loginMethodRepository.On("IsEnabled", context.Background(), entity.LoginMethodPassword).
+				Return(true, nil)
+			d.validator.On("Validate", mock.Anything).
+				Return(nil)
+			d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+				Return(entity.LoginFailures{}, nil)
+			d.userRepository.On("FindByEmail", context.Background(), "gopher@go.dev").
+				Return(entity.User{ID: "user-xxxxx", Password: "secret_hashed_password"}, nil)
+			d.hashProvider.On("Compare", "secret_password", "secret_hashed_password").
+				Return(nil)
+			d.hashProvider.On("NeedsRehash", "secret_hashed_password").
+				Return(false)
+			d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+				Return(nil)
+			d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+				Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+			d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+				Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+			d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}).
+				Return(entity.AuthID("auth-xxxxx"), nil)
+			d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin}).
+				Return(nil)
+			t.setup(d)
+
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 3)
+			output, err := usecase.Login(context.Background(), &dto.AuthLoginIn{Email: "gopher@go.dev", Password: "secret_password"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *AuthUsecaseTestSuite) TestVerifyMFA() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.AuthVerifyMFAIn
+	}
+	type expected struct {
+		output dto.AuthLoginOut
+		err    error
+	}
+	payload := &dto.AuthVerifyMFAIn{
+		MFAToken:  "mfa_token",
+		Code:      "123456",
+		UserAgent: "Mozilla/5.0",
+		IPAddress: "203.0.113.7",
+	}
+	challenge := entity.MFAChallenge{ID: "challenge-xxxxx", UserID: "user-xxxxx", TokenHash: "mfa_token_hash", ExpiresAt: test.TimeAfterNow}
+	twoFactor := entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET", EnabledAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}
+	// allowAttempt stub the owner of the challenge and a login throttle that let the code be tried.
+	allowAttempt := func(d *dependency) {
+		d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+			Return(entity.User{ID: "user-xxxxx", Email: "gopher@go.dev"}, nil)
+		d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+			Return(entity.LoginFailures{}, nil)
+		d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+			Return(entity.LoginFailures{}, nil)
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name: "it should return error ErrMFAChallengeInvalid when challenge not found",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    domain.ErrMFAChallengeInvalid,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(entity.MFAChallenge{}, domain.ErrMFAChallengeNotFound)
+			},
+		},
+		{
+			name: "it should return error when two factor repository FindChallengeByTokenHash return unexpected error",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(entity.MFAChallenge{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrMFAChallengeExpired when challenge is expired",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    entity.ErrMFAChallengeExpired,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(entity.MFAChallenge{ID: "challenge-xxxxx", UserID: "user-xxxxx", ExpiresAt: test.TimeBeforeNow}, nil)
+			},
+		},
+		{
+			name: "it should return error ErrMFAChallengeInvalid and drop the challenge when attempts are exhausted",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    domain.ErrMFAChallengeInvalid,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(entity.MFAChallenge{ID: "challenge-xxxxx", UserID: "user-xxxxx", Attempts: 5, ExpiresAt: test.TimeAfterNow}, nil)
+
+				d.twoFactorRepository.On("DeleteChallengeByID", context.Background(), entity.MFAChallengeID("challenge-xxxxx")).
+					Return(nil)
+			},
+		},
+		{
+			name: "it should return error when user repository FindByID return unexpected error",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrMFAChallengeInvalid when two factor was disabled meanwhile",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    domain.ErrMFAChallengeInvalid,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+			},
+		},
+		{
+			name: "it should return error ErrTwoFactorCodeInvalid, count the attempt and record the failure when code is invalid",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    domain.ErrTwoFactorCodeInvalid,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+				d.tokenProvider.On("Hash", "123456").Return("recovery_code_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(twoFactor, nil)
+
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).
+					Return(int64(0), false)
+
+				d.twoFactorRepository.On("ConsumeRecoveryCode", context.Background(), entity.UserID("user-xxxxx"), "recovery_code_hash").
+					Return(domain.ErrRecoveryCodeNotFound)
+
+				d.twoFactorRepository.On("IncrementChallengeAttempts", context.Background(), entity.MFAChallengeID("challenge-xxxxx")).
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeAccount, Identifier: "gopher@go.dev"}).
+					Return(nil)
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeIP, Identifier: "203.0.113.7"}).
+					Return(nil)
+			},
+		},
+		{
+			name: "it should return error when login attempt repository Store return unexpected error on an invalid code",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+				d.tokenProvider.On("Hash", "123456").Return("recovery_code_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(twoFactor, nil)
+
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).
+					Return(int64(0), false)
+
+				d.twoFactorRepository.On("ConsumeRecoveryCode", context.Background(), entity.UserID("user-xxxxx"), "recovery_code_hash").
+					Return(domain.ErrRecoveryCodeNotFound)
+
+				d.twoFactorRepository.On("IncrementChallengeAttempts", context.Background(), entity.MFAChallengeID("challenge-xxxxx")).
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeAccount, Identifier: "gopher@go.dev"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrTwoFactorCodeInvalid when totp code step was already used",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    domain.ErrTwoFactorCodeInvalid,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(twoFactor, nil)
+
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).
+					Return(int64(55555), true)
+
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(domain.ErrTwoFactorStepUsed)
+
+				d.twoFactorRepository.On("IncrementChallengeAttempts", context.Background(), entity.MFAChallengeID("challenge-xxxxx")).
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLoginFailed, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.loginAttemptRepository.On("Store", context.Background(), mock.AnythingOfType("*entity.LoginAttempt")).
+					Return(nil)
+			},
+		},
+		{
+			name: "it should return error when two factor repository UseStep return unexpected error",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(twoFactor, nil)
+
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).
+					Return(int64(55555), true)
+
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and output when recovery code is valid",
+			args: args{ctx: context.Background(), payload: &dto.AuthVerifyMFAIn{MFAToken: "mfa_token", Code: "ABCDE-FGHIJ", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}},
+			expected: expected{
+				output: dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+				d.tokenProvider.On("Hash", "abcde-fghij").Return("recovery_code_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(twoFactor, nil)
+
+				d.totpProvider.On("Validate", "SECRET", "ABCDE-FGHIJ", mock.Anything).
+					Return(int64(0), false)
+
+				d.twoFactorRepository.On("ConsumeRecoveryCode", context.Background(), entity.UserID("user-xxxxx"), "recovery_code_hash").
+					Return(nil)
+
+				d.twoFactorRepository.On("DeleteChallengeByID", context.Background(), entity.MFAChallengeID("challenge-xxxxx")).
+					Return(nil)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(nil)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID("auth-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+			},
+		},
+		{
+			name: "it should return error when two factor repository DeleteChallengeByID return unexpected error",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(twoFactor, nil)
+
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).
+					Return(int64(55555), true)
+
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(nil)
+
+				d.twoFactorRepository.On("DeleteChallengeByID", context.Background(), entity.MFAChallengeID("challenge-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when login attempt repository DeleteByIdentifier return unexpected error",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(twoFactor, nil)
+
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).
+					Return(int64(55555), true)
+
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(nil)
+
+				d.twoFactorRepository.On("DeleteChallengeByID", context.Background(), entity.MFAChallengeID("challenge-xxxxx")).
+					Return(nil)
+
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and output when totp code is valid",
+			args: args{ctx: context.Background(), payload: payload},
+			expected: expected{
+				output: dto.AuthLoginOut{AccessToken: "xxxxx.xxxxx.xxxxx", RefreshToken: "yyyyy.yyyyy.yyyyy"},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+
+				d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+					Return(challenge, nil)
+				allowAttempt(d)
+
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(twoFactor, nil)
+
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).
+					Return(int64(55555), true)
+
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(nil)
+
+				d.twoFactorRepository.On("DeleteChallengeByID", context.Background(), entity.MFAChallengeID("challenge-xxxxx")).
 					Return(nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+				d.loginAttemptRepository.On("DeleteByIdentifier", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					Return(nil)
 
 				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
 					Return("yyyyy.yyyyy.yyyyy", test.TimeAfterNow, nil)
 
-				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}).
+				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(entity.AuthID("auth-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
 					Return(nil)
+
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
 			},
 		},
 	}
@@ -259,16 +1493,19 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 	for _, t := range tests {
 		s.Run(t.name, func() {
 			d := &dependency{
-				validator:      &mocks.ValidatorProvider{},
-				userRepository: &mocks.UserRepository{},
-				authRepository: &mocks.AuthRepository{},
-				hashProvider:   &mocks.HashProvider{},
-				jwtProvider:    &mocks.JWTProvider{},
+				authRepository:          &mocks.AuthRepository{},
+				jwtProvider:             &mocks.JWTProvider{},
+				tokenProvider:           &mocks.TokenProvider{},
+				totpProvider:            &mocks.TOTPProvider{},
+				twoFactorRepository:     &mocks.TwoFactorRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
+				userRepository:          &mocks.UserRepository{},
+				loginAttemptRepository:  &mocks.LoginAttemptRepository{},
 			}
 			t.setup(d)
 
-			usecase := New(d.validator, d.authRepository, d.userRepository, d.hashProvider, d.jwtProvider)
-			output, err := usecase.Login(t.args.ctx, t.args.payload)
+			usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+			output, err := usecase.VerifyMFA(t.args.ctx, t.args.payload)
 
 			s.Equal(t.expected.err, err)
 			s.Equal(t.expected.output, output)
@@ -276,6 +1513,33 @@ func (s *AuthUsecaseTestSuite) TestLogin() {
 	}
 }
 
+func (s *AuthUsecaseTestSuite) TestVerifyMFAThrottle() {
+	d := &dependency{
+		tokenProvider:          &mocks.TokenProvider{},
+		totpProvider:           &mocks.TOTPProvider{},
+		twoFactorRepository:    &mocks.TwoFactorRepository{},
+		userRepository:         &mocks.UserRepository{},
+		loginAttemptRepository: &mocks.LoginAttemptRepository{},
+	}
+	d.tokenProvider.On("Hash", "mfa_token").Return("mfa_token_hash")
+	d.twoFactorRepository.On("FindChallengeByTokenHash", context.Background(), "mfa_token_hash").
+		Return(entity.MFAChallenge{ID: "challenge-xxxxx", UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}, nil)
+	d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+		Return(entity.User{ID: "user-xxxxx", Email: "gopher@go.dev"}, nil)
+	d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev", mock.Anything).
+		Return(entity.LoginFailures{Count: accountLoginThrottle.Lockout, LastFailedAt: time.Now()}, nil)
+	d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", mock.Anything).
+		Return(entity.LoginFailures{}, nil)
+
+	usecase := New(d.validator, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+	_, err := usecase.VerifyMFA(context.Background(), &dto.AuthVerifyMFAIn{MFAToken: "mfa_token", Code: "123456", IPAddress: "203.0.113.7"})
+
+	var throttled *domain.LoginThrottledError
+	s.Require().ErrorAs(err, &throttled)
+	s.InDelta(accountLoginThrottle.LockoutDuration, throttled.RetryAfter, float64(time.Second))
+	d.totpProvider.AssertNotCalled(s.T(), "Validate", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func (s *AuthUsecaseTestSuite) TestLogout() {
 	type args struct {
 		ctx     context.Context
@@ -326,11 +1590,86 @@ func (s *AuthUsecaseTestSuite) TestLogout() {
 			},
 		},
 		{
-			name: "it should return error nil when successfully delete authentication",
+			name: "it should return error when revocation repository Store return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLogoutIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					AccessToken:  entity.AuthClaims{TokenID: "token-xxxxx", UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow},
+				},
+			},
+			expected: expected{
+				err: test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("VerifyAvailableByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(nil)
+
+				d.authRepository.On("DeleteByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(nil)
+
+				d.revocationRepository.On("Store", context.Background(), &entity.RevokedToken{TokenID: "token-xxxxx", UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and revoke the access token when successfully delete authentication",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLogoutIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					AccessToken:  entity.AuthClaims{TokenID: "token-xxxxx", UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow},
+				},
+			},
+			expected: expected{
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("VerifyAvailableByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(nil)
+
+				d.authRepository.On("DeleteByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(nil)
+
+				d.revocationRepository.On("Store", context.Background(), &entity.RevokedToken{TokenID: "token-xxxxx", UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}).
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogout}).
+					Return(nil)
+			},
+		},
+		{
+			name: "it should return error when security event repository Store return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthLogoutIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					AccessToken:  entity.AuthClaims{UserID: "user-xxxxx"},
+				},
+			},
+			expected: expected{
+				err: test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("VerifyAvailableByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(nil)
+
+				d.authRepository.On("DeleteByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogout}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and record the logout when successfully delete authentication",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthLogoutIn{
 					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					AccessToken:  entity.AuthClaims{UserID: "user-xxxxx"},
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
 				},
 			},
 			expected: expected{
@@ -342,6 +1681,9 @@ func (s *AuthUsecaseTestSuite) TestLogout() {
 
 				d.authRepository.On("DeleteByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
 					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventLogout, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
 			},
 		},
 	}
@@ -349,11 +1691,13 @@ func (s *AuthUsecaseTestSuite) TestLogout() {
 	for _, t := range tests {
 		s.Run(t.name, func() {
 			d := &dependency{
-				authRepository: &mocks.AuthRepository{},
+				authRepository:          &mocks.AuthRepository{},
+				revocationRepository:    &mocks.RevocationRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
 			}
 			t.setup(d)
 
-			usecase := New(nil, d.authRepository, d.userRepository, d.hashProvider, d.jwtProvider)
+			usecase := New(nil, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
 			err := usecase.Logout(t.args.ctx, t.args.payload)
 
 			s.Equal(t.expected.err, err)
@@ -403,15 +1747,16 @@ func (s *AuthUsecaseTestSuite) TestGetProfile() {
 			},
 			expected: expected{
 				output: dto.AuthProfileOut{
-					ID:    "user-xxxxx",
-					Name:  "Gopher",
-					Email: "gopher@go.dev",
+					ID:            "user-xxxxx",
+					Name:          "Gopher",
+					Email:         "gopher@go.dev",
+					EmailVerified: true,
 				},
 				err: nil,
 			},
 			setup: func(d *dependency) {
 				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
-					Return(entity.User{ID: entity.UserID("user-xxxxx"), Name: "Gopher", Email: "gopher@go.dev"}, nil)
+					Return(entity.User{ID: entity.UserID("user-xxxxx"), Name: "Gopher", Email: "gopher@go.dev", EmailVerifiedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
 			},
 		},
 	}
@@ -419,11 +1764,12 @@ func (s *AuthUsecaseTestSuite) TestGetProfile() {
 	for _, t := range tests {
 		s.Run(t.name, func() {
 			d := &dependency{
-				userRepository: &mocks.UserRepository{},
+				userRepository:          &mocks.UserRepository{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
 			}
 			t.setup(d)
 
-			usecase := New(nil, d.authRepository, d.userRepository, d.hashProvider, d.jwtProvider)
+			usecase := New(nil, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
 			output, err := usecase.GetProfile(t.args.ctx, t.args.payload)
 
 			s.Equal(t.expected.err, err)
@@ -432,6 +1778,23 @@ func (s *AuthUsecaseTestSuite) TestGetProfile() {
 	}
 }
 
+func (s *AuthUsecaseTestSuite) TestGetJWKS() {
+	s.Run("it should return error nil and the public keys of jwt provider", func() {
+		keys := []entity.JWK{{KeyType: "OKP", KeyID: "key-xxxxx", Use: "sig", Algorithm: "EdDSA", Curve: "Ed25519", X: "xxxxx"}}
+		d := &dependency{
+			jwtProvider:             &mocks.JWTProvider{},
+			securityEventRepository: &mocks.SecurityEventRepository{},
+		}
+		d.jwtProvider.On("PublicKeys").Return(keys)
+
+		usecase := New(nil, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
+		output, err := usecase.GetJWKS(context.Background())
+
+		s.NoError(err)
+		s.Equal(dto.AuthJWKSOut{Keys: keys}, output)
+	})
+}
+
 func (s *AuthUsecaseTestSuite) TestRefresh() {
 	type args struct {
 		ctx     context.Context
@@ -464,6 +1827,131 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 					Return(entity.Auth{}, test.ErrUnexpected)
 			},
 		},
+		{
+			name: "it should return error ErrAuthNotFound when token is unknown and never rotated",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthRefreshIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthRefreshOut{},
+				err:    domain.ErrAuthNotFound,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{}, domain.ErrAuthNotFound)
+
+				d.authRepository.On("FindByRotatedToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{}, domain.ErrAuthNotFound)
+			},
+		},
+		{
+			name: "it should return error when auth repository FindByRotatedToken return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthRefreshIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthRefreshOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{}, domain.ErrAuthNotFound)
+
+				d.authRepository.On("FindByRotatedToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when auth repository DeleteByID return unexpected error on token reuse",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthRefreshIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthRefreshOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{}, domain.ErrAuthNotFound)
+
+				d.authRepository.On("FindByRotatedToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow}, nil)
+
+				d.authRepository.On("DeleteByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when security event repository Store return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthRefreshIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthRefreshOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{}, domain.ErrAuthNotFound)
+
+				d.authRepository.On("FindByRotatedToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow}, nil)
+
+				d.authRepository.On("DeleteByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventRefreshTokenReuse, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrAuthTokenReused and revoke the family when token was already rotated",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthRefreshIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthRefreshOut{},
+				err:    domain.ErrAuthTokenReused,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{}, domain.ErrAuthNotFound)
+
+				d.authRepository.On("FindByRotatedToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow}, nil)
+
+				d.authRepository.On("DeleteByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventRefreshTokenReuse, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+			},
+		},
 		{
 			name: "it should return error ErrAuthTokenExpired when token is expired",
 			args: args{
@@ -482,7 +1970,7 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 			},
 		},
 		{
-			name: "it should return error when generate new access token failed",
+			name: "it should return error when user repository FindByID return unexpected error",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthRefreshIn{
@@ -495,10 +1983,30 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 			},
 			setup: func(d *dependency) {
 				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
-					Return(entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("", time.Time{}, test.ErrUnexpected)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrUserSuspended when user is suspended",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthRefreshIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+				},
+			},
+			expected: expected{
+				output: dto.AuthRefreshOut{},
+				err:    entity.ErrUserSuspended,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
+
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", SuspendedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
 			},
 		},
 		{
@@ -507,6 +2015,8 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 				ctx: context.Background(),
 				payload: &dto.AuthRefreshIn{
 					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
 				},
 			},
 			expected: expected{
@@ -515,21 +2025,23 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 			},
 			setup: func(d *dependency) {
 				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
-					Return(entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
 
 				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
 					Return("", time.Time{}, test.ErrUnexpected)
 			},
 		},
 		{
-			name: "it should return error when auth respository Delete return unexpected error",
+			name: "it should return error when auth respository Rotate return unexpected error",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthRefreshIn{
 					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
 				},
 			},
 			expected: expected{
@@ -538,24 +2050,63 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 			},
 			setup: func(d *dependency) {
 				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
-					Return(entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
 
 				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
 					Return("zzzzz.zzzzz.zzzzz", test.TimeAfterNow, nil)
 
-				d.authRepository.On("DeleteByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+				d.authRepository.On("Rotate", context.Background(), "yyyyy.yyyyy.yyyyy", &entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
 					Return(test.ErrUnexpected)
 			},
 		},
 		{
-			name: "it should return error when auth respository Store return unexpected error",
+			name: "it should return error ErrAuthTokenReused when token was rotated concurrently",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthRefreshIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthRefreshOut{},
+				err:    domain.ErrAuthTokenReused,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
+
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("zzzzz.zzzzz.zzzzz", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Rotate", context.Background(), "yyyyy.yyyyy.yyyyy", &entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(domain.ErrAuthNotFound)
+
+				d.authRepository.On("FindByRotatedToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow}, nil)
+
+				d.authRepository.On("DeleteByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventRefreshTokenReuse, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+			},
+		},
+		{
+			name: "it should return error when security event repository Store return unexpected error",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthRefreshIn{
 					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
 				},
 			},
 			expected: expected{
@@ -564,27 +2115,63 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 			},
 			setup: func(d *dependency) {
 				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
-					Return(entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
 
 				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
 					Return("zzzzz.zzzzz.zzzzz", test.TimeAfterNow, nil)
 
-				d.authRepository.On("DeleteByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+				d.authRepository.On("Rotate", context.Background(), "yyyyy.yyyyy.yyyyy", &entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
 					Return(nil)
 
-				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow}).
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventRefresh, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
 					Return(test.ErrUnexpected)
 			},
 		},
+		{
+			name: "it should return error when generate new access token failed",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.AuthRefreshIn{
+					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
+				},
+			},
+			expected: expected{
+				output: dto.AuthRefreshOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
+
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+
+				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
+					Return("zzzzz.zzzzz.zzzzz", test.TimeAfterNow, nil)
+
+				d.authRepository.On("Rotate", context.Background(), "yyyyy.yyyyy.yyyyy", &entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventRefresh, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("", time.Time{}, test.ErrUnexpected)
+			},
+		},
 		{
 			name: "it should return error nil and output when success",
 			args: args{
 				ctx: context.Background(),
 				payload: &dto.AuthRefreshIn{
 					RefreshToken: "yyyyy.yyyyy.yyyyy",
+					UserAgent:    "Mozilla/5.0",
+					IPAddress:    "203.0.113.7",
 				},
 			},
 			expected: expected{
@@ -596,19 +2183,22 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 			},
 			setup: func(d *dependency) {
 				d.authRepository.On("FindByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
-					Return(entity.Auth{UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "yyyyy.yyyyy.yyyyy", ExpiresAt: test.TimeAfterNow}, nil)
 
-				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx")).
-					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
 
 				d.jwtProvider.On("GenerateRefreshToken", entity.UserID("user-xxxxx")).
 					Return("zzzzz.zzzzz.zzzzz", test.TimeAfterNow, nil)
 
-				d.authRepository.On("DeleteByToken", context.Background(), "yyyyy.yyyyy.yyyyy").
+				d.authRepository.On("Rotate", context.Background(), "yyyyy.yyyyy.yyyyy", &entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
 					Return(nil)
 
-				d.authRepository.On("Store", context.Background(), &entity.Auth{UserID: "user-xxxxx", Token: "zzzzz.zzzzz.zzzzz", ExpiresAt: test.TimeAfterNow}).
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventRefresh, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
 					Return(nil)
+
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("xxxxx.xxxxx.xxxxx", test.TimeAfterNow, nil)
 			},
 		},
 	}
@@ -616,12 +2206,14 @@ func (s *AuthUsecaseTestSuite) TestRefresh() {
 	for _, t := range tests {
 		s.Run(t.name, func() {
 			d := &dependency{
-				authRepository: &mocks.AuthRepository{},
-				jwtProvider:    &mocks.JWTProvider{},
+				authRepository:          &mocks.AuthRepository{},
+				userRepository:          &mocks.UserRepository{},
+				jwtProvider:             &mocks.JWTProvider{},
+				securityEventRepository: &mocks.SecurityEventRepository{},
 			}
 			t.setup(d)
 
-			usecase := New(nil, d.authRepository, d.userRepository, d.hashProvider, d.jwtProvider)
+			usecase := New(nil, d.authRepository, d.userRepository, d.twoFactorRepository, d.identityRepository, d.securityEventRepository, d.loginAttemptRepository, d.revocationRepository, d.magicLinkRepository, d.loginMethodRepository, d.hashProvider, d.jwtProvider, d.tokenProvider, d.totpProvider, d.oidcProvider, d.mailer, 0)
 			output, err := usecase.Refresh(t.args.ctx, t.args.payload)
 
 			s.Equal(t.expected.err, err)
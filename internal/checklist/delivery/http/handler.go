@@ -0,0 +1,131 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+)
+
+type HTTPHandler struct {
+	validator        domain.ValidatorProvider
+	checklistUsecase domain.ChecklistUsecase
+}
+
+// New creates a new checklist handler.
+func New(validator domain.ValidatorProvider, checklistUsecase domain.ChecklistUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, checklistUsecase: checklistUsecase}
+}
+
+// POST /tasks/{task_id}/checklist to add new checklist item to a task.
+func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.ChecklistItemCreateIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.TaskID = entity.TaskID(chi.URLParam(r, "task_id"))
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.checklistUsecase.Add(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusCreated, "Successfully added new checklist item", output))
+}
+
+// PUT /tasks/{task_id}/checklist/{item_id}/toggle to check or uncheck a checklist item.
+func (h *HTTPHandler) PutToggle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.ChecklistItemToggleIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.TaskID = entity.TaskID(chi.URLParam(r, "task_id"))
+	payload.ItemID = entity.ChecklistItemID(chi.URLParam(r, "item_id"))
+
+	output, err := h.checklistUsecase.Toggle(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully toggled checklist item", output))
+}
+
+// PUT /tasks/{task_id}/checklist/order to reorder the checklist of a task.
+func (h *HTTPHandler) PutOrder(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.ChecklistReorderIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.TaskID = entity.TaskID(chi.URLParam(r, "task_id"))
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	if err := h.checklistUsecase.Reorder(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully reordered checklist", nil))
+}
+
+// DELETE /tasks/{task_id}/checklist/{item_id} to remove a checklist item.
+func (h *HTTPHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.ChecklistItemRemoveIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.TaskID = entity.TaskID(chi.URLParam(r, "task_id"))
+	payload.ItemID = entity.ChecklistItemID(chi.URLParam(r, "item_id"))
+
+	if err := h.checklistUsecase.Remove(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully deleted checklist item", nil))
+}
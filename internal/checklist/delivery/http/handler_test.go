@@ -0,0 +1,383 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type ChecklistHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestChecklistHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ChecklistHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	req              *http.Request
+	validator        *mocks.ValidatorProvider
+	checklistUsecase *mocks.ChecklistUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *ChecklistHTTPHandlerTestSuite) assertResponse(rr *httptest.ResponseRecorder, isError bool, expected expected) {
+	s.Equal(expected.contentType, rr.Header().Get("Content-Type"))
+	s.Equal(expected.statusCode, rr.Code)
+
+	if isError {
+		var resBody domain.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.error, resBody.Error)
+	} else {
+		var resBody domain.SuccessResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.payload, resBody.Payload)
+	}
+}
+
+func (s *ChecklistHTTPHandlerTestSuite) TestPost() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Text is required field",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrTextEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when checklist usecase Add return error",
+			isError:     true,
+			requestBody: []byte(`{"text":"Buy milk"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this task",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.checklistUsecase.On("Add", mock.Anything, &dto.ChecklistItemCreateIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Text: "Buy milk"}).
+					Return(dto.ChecklistItemCreateOut{}, domain.ErrTaskAuthorization)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"text":"Buy milk"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusCreated,
+				message:     "Successfully added new checklist item",
+				payload:     map[string]any{"id": "item-xxxxx"},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.checklistUsecase.On("Add", mock.Anything, &dto.ChecklistItemCreateIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Text: "Buy milk"}).
+					Return(dto.ChecklistItemCreateOut{ID: "item-xxxxx"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/{task_id}/checklist", bytes.NewReader(t.requestBody))
+			req = test.InjectChiRouterParams(req, map[string]string{"task_id": "task-xxxxx"})
+
+			d := &dependency{
+				req:              req,
+				validator:        &mocks.ValidatorProvider{},
+				checklistUsecase: &mocks.ChecklistUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.checklistUsecase)
+			handler.Post(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *ChecklistHTTPHandlerTestSuite) TestPutToggle() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when checklist usecase Toggle return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "Checklist item not found",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.checklistUsecase.On("Toggle", mock.Anything, &dto.ChecklistItemToggleIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"}).
+					Return(dto.ChecklistItemToggleOut{}, domain.ErrChecklistItemNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully toggled checklist item",
+				payload:     map[string]any{"id": "item-xxxxx", "is_checked": true},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.checklistUsecase.On("Toggle", mock.Anything, &dto.ChecklistItemToggleIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"}).
+					Return(dto.ChecklistItemToggleOut{ID: "item-xxxxx", IsChecked: true}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/{task_id}/checklist/{item_id}/toggle", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"task_id": "task-xxxxx", "item_id": "item-xxxxx"})
+
+			d := &dependency{
+				req:              req,
+				checklistUsecase: &mocks.ChecklistUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.checklistUsecase)
+			handler.PutToggle(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *ChecklistHTTPHandlerTestSuite) TestPutOrder() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{"item_ids":[]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Item ids is required field",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrItemIDsEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when checklist usecase Reorder return error",
+			isError:     true,
+			requestBody: []byte(`{"item_ids":["item-yyyyy"]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Item ids must list every checklist item exactly once",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.checklistUsecase.On("Reorder", mock.Anything, &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", ItemIDs: []entity.ChecklistItemID{"item-yyyyy"}}).
+					Return(domain.ErrChecklistOrderMismatch)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"item_ids":["item-yyyyy","item-xxxxx"]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully reordered checklist",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.checklistUsecase.On("Reorder", mock.Anything, &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", ItemIDs: []entity.ChecklistItemID{"item-yyyyy", "item-xxxxx"}}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/{task_id}/checklist/order", bytes.NewReader(t.requestBody))
+			req = test.InjectChiRouterParams(req, map[string]string{"task_id": "task-xxxxx"})
+
+			d := &dependency{
+				req:              req,
+				validator:        &mocks.ValidatorProvider{},
+				checklistUsecase: &mocks.ChecklistUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.checklistUsecase)
+			handler.PutOrder(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *ChecklistHTTPHandlerTestSuite) TestDelete() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when checklist usecase Remove return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "Checklist item not found",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.checklistUsecase.On("Remove", mock.Anything, &dto.ChecklistItemRemoveIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"}).
+					Return(domain.ErrChecklistItemNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully deleted checklist item",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.checklistUsecase.On("Remove", mock.Anything, &dto.ChecklistItemRemoveIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/{task_id}/checklist/{item_id}", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"task_id": "task-xxxxx", "item_id": "item-xxxxx"})
+
+			d := &dependency{
+				req:              req,
+				checklistUsecase: &mocks.ChecklistUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.checklistUsecase)
+			handler.Delete(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new checklist repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new checklist item after the last item of the task.
+func (r *Repository) Store(ctx context.Context, c *entity.ChecklistItem) (entity.ChecklistItemID, error) {
+	id := r.idProvider.Generate()
+	q := `INSERT INTO checklist_items (id, task_id, text, position) VALUES ($1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM checklist_items WHERE task_id = $2))`
+	_, err := r.db.ExecContext(ctx, q, id, c.TaskID, c.Text)
+	if err != nil {
+		return "", err
+	}
+	return entity.ChecklistItemID(id), nil
+}
+
+// FindByID get checklist item by id.
+func (r *Repository) FindByID(ctx context.Context, itemID entity.ChecklistItemID) (entity.ChecklistItem, error) {
+	var item entity.ChecklistItem
+	q := `SELECT id, task_id, text, is_checked, position, created_at, updated_at FROM checklist_items WHERE id = $1`
+	row := r.db.QueryRowContext(ctx, q, itemID)
+	err := row.Scan(&item.ID, &item.TaskID, &item.Text, &item.IsChecked, &item.Position, &item.CreatedAt, &item.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ChecklistItem{}, domain.ErrChecklistItemNotFound
+	} else if err != nil {
+		return entity.ChecklistItem{}, err
+	}
+	return item, nil
+}
+
+// FindAllByTaskIDs get the checklist items of many tasks in a single query, ordered by position.
+func (r *Repository) FindAllByTaskIDs(ctx context.Context, taskIDs []entity.TaskID) ([]entity.ChecklistItem, error) {
+	ids := make([]string, len(taskIDs))
+	for i, taskID := range taskIDs {
+		ids[i] = string(taskID)
+	}
+
+	q := `SELECT id, task_id, text, is_checked, position, created_at, updated_at FROM checklist_items WHERE task_id = ANY($1) ORDER BY position`
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]entity.ChecklistItem, 0)
+	for rows.Next() {
+		var item entity.ChecklistItem
+		err := rows.Scan(&item.ID, &item.TaskID, &item.Text, &item.IsChecked, &item.Position, &item.CreatedAt, &item.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Update update checklist item text and checked state by id.
+func (r *Repository) Update(ctx context.Context, c *entity.ChecklistItem) (entity.ChecklistItemID, error) {
+	c.UpdatedAt = time.Now()
+	q := `UPDATE checklist_items SET text = $2, is_checked = $3, updated_at = $4 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, c.ID, c.Text, c.IsChecked, c.UpdatedAt)
+	if err != nil {
+		return "", err
+	}
+	return c.ID, nil
+}
+
+// DeleteByID delete checklist item by id.
+func (r *Repository) DeleteByID(ctx context.Context, itemID entity.ChecklistItemID) error {
+	q := `DELETE FROM checklist_items WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, itemID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdatePositions set the position of each checklist item to its index in itemIDs.
+func (r *Repository) UpdatePositions(ctx context.Context, itemIDs []entity.ChecklistItemID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q := `UPDATE checklist_items SET position = $2 WHERE id = $1`
+	for position, itemID := range itemIDs {
+		if _, err := tx.ExecContext(ctx, q, itemID, position); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,486 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type ChecklistRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestChecklistRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ChecklistRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	insertItemQuery     = regexp.QuoteMeta(`INSERT INTO checklist_items (id, task_id, text, position) VALUES ($1, $2, $3, (SELECT COALESCE(MAX(position) + 1, 0) FROM checklist_items WHERE task_id = $2))`)
+	selectItemQuery     = regexp.QuoteMeta(`SELECT id, task_id, text, is_checked, position, created_at, updated_at FROM checklist_items WHERE id = $1`)
+	selectItemsQuery    = regexp.QuoteMeta(`SELECT id, task_id, text, is_checked, position, created_at, updated_at FROM checklist_items WHERE task_id = ANY($1) ORDER BY position`)
+	updateItemQuery     = regexp.QuoteMeta(`UPDATE checklist_items SET text = $2, is_checked = $3, updated_at = $4 WHERE id = $1`)
+	deleteItemQuery     = regexp.QuoteMeta(`DELETE FROM checklist_items WHERE id = $1`)
+	updatePositionQuery = regexp.QuoteMeta(`UPDATE checklist_items SET position = $2 WHERE id = $1`)
+)
+
+var columns = []string{"id", "task_id", "text", "is_checked", "position", "created_at", "updated_at"}
+
+func (s *ChecklistRepositoryTestSuite) TestStore() {
+	type args struct {
+		ctx  context.Context
+		item *entity.ChecklistItem
+	}
+	type expected struct {
+		itemID entity.ChecklistItemID
+		err    error
+	}
+	item := &entity.ChecklistItem{TaskID: "task-xxxxx", Text: "Buy milk"}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			args:     args{ctx: context.Background(), item: item},
+			expected: expected{itemID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("item-xxxxx")
+
+				d.mockDB.ExpectExec(insertItemQuery).
+					WithArgs("item-xxxxx", "task-xxxxx", "Buy milk").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and item id when successfully store",
+			args:     args{ctx: context.Background(), item: item},
+			expected: expected{itemID: "item-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("item-xxxxx")
+
+				d.mockDB.ExpectExec(insertItemQuery).
+					WithArgs("item-xxxxx", "task-xxxxx", "Buy milk").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			itemID, err := repository.Store(t.args.ctx, t.args.item)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.itemID, itemID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ChecklistRepositoryTestSuite) TestFindByID() {
+	type args struct {
+		ctx    context.Context
+		itemID entity.ChecklistItemID
+	}
+	type expected struct {
+		item entity.ChecklistItem
+		err  error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrChecklistItemNotFound when item is not exist",
+			args:     args{ctx: context.Background(), itemID: "item-xxxxx"},
+			expected: expected{item: entity.ChecklistItem{}, err: domain.ErrChecklistItemNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectItemQuery).
+					WithArgs("item-xxxxx").
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name:     "it should return error when database fail to query",
+			args:     args{ctx: context.Background(), itemID: "item-xxxxx"},
+			expected: expected{item: entity.ChecklistItem{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectItemQuery).
+					WithArgs("item-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name: "it should return error nil and item when successfully query",
+			args: args{ctx: context.Background(), itemID: "item-xxxxx"},
+			expected: expected{
+				item: entity.ChecklistItem{ID: "item-xxxxx", TaskID: "task-xxxxx", Text: "Buy milk", IsChecked: true, Position: 2, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				err:  nil,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(columns).
+					AddRow("item-xxxxx", "task-xxxxx", "Buy milk", true, 2, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(selectItemQuery).
+					WithArgs("item-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			item, err := repository.FindByID(t.args.ctx, t.args.itemID)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.item, item)
+		})
+	}
+}
+
+func (s *ChecklistRepositoryTestSuite) TestFindAllByTaskIDs() {
+	type args struct {
+		ctx     context.Context
+		taskIDs []entity.TaskID
+	}
+	type expected struct {
+		items         []entity.ChecklistItem
+		allowAnyError bool
+		err           error
+	}
+	taskIDs := []entity.TaskID{"task-xxxxx", "task-yyyyy"}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			args:     args{ctx: context.Background(), taskIDs: taskIDs},
+			expected: expected{items: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectItemsQuery).
+					WithArgs(pq.Array([]string{"task-xxxxx", "task-yyyyy"})).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database rows fail to scan",
+			args:     args{ctx: context.Background(), taskIDs: taskIDs},
+			expected: expected{items: nil, allowAnyError: true, err: errors.New("anything")},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(columns).
+					AddRow("item-xxxxx", "task-xxxxx", "Buy milk", false, "first", test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(selectItemsQuery).
+					WithArgs(pq.Array([]string{"task-xxxxx", "task-yyyyy"})).
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name:     "it should return error when database rows error",
+			args:     args{ctx: context.Background(), taskIDs: taskIDs},
+			expected: expected{items: nil, err: test.ErrRows},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(columns).
+					AddRow("item-xxxxx", "task-xxxxx", "Buy milk", false, 0, test.TimeBeforeNow, test.TimeBeforeNow).
+					RowError(0, test.ErrRows)
+
+				d.mockDB.ExpectQuery(selectItemsQuery).
+					WithArgs(pq.Array([]string{"task-xxxxx", "task-yyyyy"})).
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name: "it should return error nil and items of all tasks when successfully query",
+			args: args{ctx: context.Background(), taskIDs: taskIDs},
+			expected: expected{
+				items: []entity.ChecklistItem{
+					{ID: "item-xxxxx", TaskID: "task-xxxxx", Text: "Buy milk", IsChecked: true, Position: 0, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					{ID: "item-yyyyy", TaskID: "task-yyyyy", Text: "Call mom", IsChecked: false, Position: 0, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(columns).
+					AddRow("item-xxxxx", "task-xxxxx", "Buy milk", true, 0, test.TimeBeforeNow, test.TimeBeforeNow).
+					AddRow("item-yyyyy", "task-yyyyy", "Call mom", false, 0, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(selectItemsQuery).
+					WithArgs(pq.Array([]string{"task-xxxxx", "task-yyyyy"})).
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			items, err := repository.FindAllByTaskIDs(t.args.ctx, t.args.taskIDs)
+
+			if t.expected.allowAnyError {
+				s.Error(err)
+			} else {
+				s.Equal(t.expected.err, err)
+			}
+			s.Equal(t.expected.items, items)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ChecklistRepositoryTestSuite) TestUpdate() {
+	type args struct {
+		ctx  context.Context
+		item *entity.ChecklistItem
+	}
+	type expected struct {
+		itemID entity.ChecklistItemID
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			args:     args{ctx: context.Background(), item: &entity.ChecklistItem{ID: "item-xxxxx", Text: "Buy milk", IsChecked: true}},
+			expected: expected{itemID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(updateItemQuery).
+					WithArgs("item-xxxxx", "Buy milk", true, sqlmock.AnyArg()).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and item id when successfully update",
+			args:     args{ctx: context.Background(), item: &entity.ChecklistItem{ID: "item-xxxxx", Text: "Buy milk", IsChecked: true}},
+			expected: expected{itemID: "item-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(updateItemQuery).
+					WithArgs("item-xxxxx", "Buy milk", true, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			itemID, err := repository.Update(t.args.ctx, t.args.item)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.itemID, itemID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ChecklistRepositoryTestSuite) TestDeleteByID() {
+	type args struct {
+		ctx    context.Context
+		itemID entity.ChecklistItemID
+	}
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			args:     args{ctx: context.Background(), itemID: "item-xxxxx"},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteItemQuery).
+					WithArgs("item-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			args:     args{ctx: context.Background(), itemID: "item-xxxxx"},
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteItemQuery).
+					WithArgs("item-xxxxx").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.DeleteByID(t.args.ctx, t.args.itemID)
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *ChecklistRepositoryTestSuite) TestUpdatePositions() {
+	type args struct {
+		ctx     context.Context
+		itemIDs []entity.ChecklistItemID
+	}
+	type expected struct {
+		err error
+	}
+	itemIDs := []entity.ChecklistItemID{"item-yyyyy", "item-xxxxx"}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to begin transaction",
+			args:     args{ctx: context.Background(), itemIDs: itemIDs},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to update position",
+			args:     args{ctx: context.Background(), itemIDs: itemIDs},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WithArgs("item-yyyyy", 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WithArgs("item-xxxxx", 1).
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to commit transaction",
+			args:     args{ctx: context.Background(), itemIDs: itemIDs},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update positions",
+			args:     args{ctx: context.Background(), itemIDs: itemIDs},
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WithArgs("item-yyyyy", 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WithArgs("item-xxxxx", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.UpdatePositions(t.args.ctx, t.args.itemIDs)
+
+			s.Equal(t.expected.err, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
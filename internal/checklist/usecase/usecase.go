@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Usecase struct {
+	checklistRepository domain.ChecklistRepository
+	taskRepository      domain.TaskRepository
+}
+
+// New create a new checklist usecase.
+func New(checklistRepository domain.ChecklistRepository, taskRepository domain.TaskRepository) Usecase {
+	return Usecase{checklistRepository: checklistRepository, taskRepository: taskRepository}
+}
+
+// Add add a new item at the end of the checklist of a task.
+func (u *Usecase) Add(ctx context.Context, payload *dto.ChecklistItemCreateIn) (dto.ChecklistItemCreateOut, error) {
+	if err := u.authorizeTask(ctx, payload.TaskID, payload.UserID); err != nil {
+		return dto.ChecklistItemCreateOut{}, err
+	}
+
+	item := &entity.ChecklistItem{TaskID: payload.TaskID, Text: payload.Text}
+	itemID, err := u.checklistRepository.Store(ctx, item)
+	if err != nil {
+		return dto.ChecklistItemCreateOut{}, err
+	}
+	return dto.ChecklistItemCreateOut{ID: itemID}, nil
+}
+
+// Toggle flip the checked state of a checklist item.
+func (u *Usecase) Toggle(ctx context.Context, payload *dto.ChecklistItemToggleIn) (dto.ChecklistItemToggleOut, error) {
+	item, err := u.findItem(ctx, payload.ItemID, payload.TaskID, payload.UserID)
+	if err != nil {
+		return dto.ChecklistItemToggleOut{}, err
+	}
+
+	item.IsChecked = !item.IsChecked
+	itemID, err := u.checklistRepository.Update(ctx, &item)
+	if err != nil {
+		return dto.ChecklistItemToggleOut{}, err
+	}
+	return dto.ChecklistItemToggleOut{ID: itemID, IsChecked: item.IsChecked}, nil
+}
+
+// Reorder change the position of the checklist items of a task.
+// The given ids must contain every item of the checklist exactly once.
+func (u *Usecase) Reorder(ctx context.Context, payload *dto.ChecklistReorderIn) error {
+	if err := u.authorizeTask(ctx, payload.TaskID, payload.UserID); err != nil {
+		return err
+	}
+
+	items, err := u.checklistRepository.FindAllByTaskIDs(ctx, []entity.TaskID{payload.TaskID})
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[entity.ChecklistItemID]bool, len(items))
+	for _, item := range items {
+		owned[item.ID] = true
+	}
+	if len(payload.ItemIDs) != len(owned) {
+		return domain.ErrChecklistOrderMismatch
+	}
+	for _, itemID := range payload.ItemIDs {
+		if !owned[itemID] {
+			return domain.ErrChecklistOrderMismatch
+		}
+		delete(owned, itemID)
+	}
+
+	if err := u.checklistRepository.UpdatePositions(ctx, payload.ItemIDs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Remove remove an item from the checklist of a task.
+func (u *Usecase) Remove(ctx context.Context, payload *dto.ChecklistItemRemoveIn) error {
+	if _, err := u.findItem(ctx, payload.ItemID, payload.TaskID, payload.UserID); err != nil {
+		return err
+	}
+	if err := u.checklistRepository.DeleteByID(ctx, payload.ItemID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// authorizeTask check that the task exists and is owned by the user.
+func (u *Usecase) authorizeTask(ctx context.Context, taskID entity.TaskID, userID entity.UserID) error {
+	task, err := u.taskRepository.FindByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+	if task.UserID != userID {
+		return domain.ErrTaskAuthorization
+	}
+	return nil
+}
+
+// findItem get a checklist item that belongs to a task owned by the user.
+func (u *Usecase) findItem(ctx context.Context, itemID entity.ChecklistItemID, taskID entity.TaskID, userID entity.UserID) (entity.ChecklistItem, error) {
+	if err := u.authorizeTask(ctx, taskID, userID); err != nil {
+		return entity.ChecklistItem{}, err
+	}
+
+	item, err := u.checklistRepository.FindByID(ctx, itemID)
+	if err != nil {
+		return entity.ChecklistItem{}, err
+	}
+	if item.TaskID != taskID {
+		return entity.ChecklistItem{}, domain.ErrChecklistItemNotFound
+	}
+	return item, nil
+}
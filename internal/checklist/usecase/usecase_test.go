@@ -0,0 +1,391 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type ChecklistUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestChecklistUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ChecklistUsecaseTestSuite))
+}
+
+type dependency struct {
+	checklistRepository *mocks.ChecklistRepository
+	taskRepository      *mocks.TaskRepository
+}
+
+func newDependency() *dependency {
+	return &dependency{
+		checklistRepository: &mocks.ChecklistRepository{},
+		taskRepository:      &mocks.TaskRepository{},
+	}
+}
+
+func newTask() entity.Task {
+	return entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx"}
+}
+
+func newItem() entity.ChecklistItem {
+	return entity.ChecklistItem{ID: "item-xxxxx", TaskID: "task-xxxxx", Text: "Buy milk", Position: 0}
+}
+
+func (s *ChecklistUsecaseTestSuite) TestAdd() {
+	type expected struct {
+		output dto.ChecklistItemCreateOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.ChecklistItemCreateIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when task repository FindByID return unexpected error",
+			payload:  &dto.ChecklistItemCreateIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Text: "Buy milk"},
+			expected: expected{output: dto.ChecklistItemCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrTaskAuthorization when user is not the owner of the task",
+			payload:  &dto.ChecklistItemCreateIn{TaskID: "task-xxxxx", UserID: "user-yyyyy", Text: "Buy milk"},
+			expected: expected{output: dto.ChecklistItemCreateOut{}, err: domain.ErrTaskAuthorization},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+			},
+		},
+		{
+			name:     "it should return error when checklist repository Store return unexpected error",
+			payload:  &dto.ChecklistItemCreateIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Text: "Buy milk"},
+			expected: expected{output: dto.ChecklistItemCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("Store", context.Background(), &entity.ChecklistItem{TaskID: "task-xxxxx", Text: "Buy milk"}).
+					Return(entity.ChecklistItemID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and item id when success",
+			payload:  &dto.ChecklistItemCreateIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Text: "Buy milk"},
+			expected: expected{output: dto.ChecklistItemCreateOut{ID: "item-xxxxx"}, err: nil},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("Store", context.Background(), &entity.ChecklistItem{TaskID: "task-xxxxx", Text: "Buy milk"}).
+					Return(entity.ChecklistItemID("item-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.checklistRepository, d.taskRepository)
+			output, err := usecase.Add(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *ChecklistUsecaseTestSuite) TestToggle() {
+	type expected struct {
+		output dto.ChecklistItemToggleOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.ChecklistItemToggleIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrTaskAuthorization when user is not the owner of the task",
+			payload:  &dto.ChecklistItemToggleIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-yyyyy"},
+			expected: expected{output: dto.ChecklistItemToggleOut{}, err: domain.ErrTaskAuthorization},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+			},
+		},
+		{
+			name:     "it should return error when checklist repository FindByID return unexpected error",
+			payload:  &dto.ChecklistItemToggleIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: dto.ChecklistItemToggleOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(entity.ChecklistItem{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrChecklistItemNotFound when item belongs to another task",
+			payload:  &dto.ChecklistItemToggleIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: dto.ChecklistItemToggleOut{}, err: domain.ErrChecklistItemNotFound},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(entity.ChecklistItem{ID: "item-xxxxx", TaskID: "task-yyyyy"}, nil)
+			},
+		},
+		{
+			name:     "it should return error when checklist repository Update return unexpected error",
+			payload:  &dto.ChecklistItemToggleIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: dto.ChecklistItemToggleOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(newItem(), nil)
+
+				item := newItem()
+				item.IsChecked = true
+				d.checklistRepository.On("Update", context.Background(), &item).
+					Return(entity.ChecklistItemID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and checked item when success toggle unchecked item",
+			payload:  &dto.ChecklistItemToggleIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: dto.ChecklistItemToggleOut{ID: "item-xxxxx", IsChecked: true}, err: nil},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(newItem(), nil)
+
+				item := newItem()
+				item.IsChecked = true
+				d.checklistRepository.On("Update", context.Background(), &item).
+					Return(entity.ChecklistItemID("item-xxxxx"), nil)
+			},
+		},
+		{
+			name:     "it should return error nil and unchecked item when success toggle checked item",
+			payload:  &dto.ChecklistItemToggleIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: dto.ChecklistItemToggleOut{ID: "item-xxxxx", IsChecked: false}, err: nil},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+
+				item := newItem()
+				item.IsChecked = true
+				d.checklistRepository.On("FindByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(item, nil)
+
+				updated := newItem()
+				d.checklistRepository.On("Update", context.Background(), &updated).
+					Return(entity.ChecklistItemID("item-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.checklistRepository, d.taskRepository)
+			output, err := usecase.Toggle(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *ChecklistUsecaseTestSuite) TestReorder() {
+	items := []entity.ChecklistItem{
+		{ID: "item-xxxxx", TaskID: "task-xxxxx", Position: 0},
+		{ID: "item-yyyyy", TaskID: "task-xxxxx", Position: 1},
+	}
+
+	tests := []struct {
+		name     string
+		payload  *dto.ChecklistReorderIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrTaskAuthorization when user is not the owner of the task",
+			payload:  &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-yyyyy", ItemIDs: []entity.ChecklistItemID{"item-yyyyy", "item-xxxxx"}},
+			expected: domain.ErrTaskAuthorization,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+			},
+		},
+		{
+			name:     "it should return error when checklist repository FindAllByTaskIDs return unexpected error",
+			payload:  &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", ItemIDs: []entity.ChecklistItemID{"item-yyyyy", "item-xxxxx"}},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrChecklistOrderMismatch when an item is missing",
+			payload:  &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", ItemIDs: []entity.ChecklistItemID{"item-yyyyy"}},
+			expected: domain.ErrChecklistOrderMismatch,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return(items, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrChecklistOrderMismatch when an item is duplicated",
+			payload:  &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", ItemIDs: []entity.ChecklistItemID{"item-xxxxx", "item-xxxxx"}},
+			expected: domain.ErrChecklistOrderMismatch,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return(items, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrChecklistOrderMismatch when an item belongs to another task",
+			payload:  &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", ItemIDs: []entity.ChecklistItemID{"item-zzzzz", "item-xxxxx"}},
+			expected: domain.ErrChecklistOrderMismatch,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return(items, nil)
+			},
+		},
+		{
+			name:     "it should return error when checklist repository UpdatePositions return unexpected error",
+			payload:  &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", ItemIDs: []entity.ChecklistItemID{"item-yyyyy", "item-xxxxx"}},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return(items, nil)
+				d.checklistRepository.On("UpdatePositions", context.Background(), []entity.ChecklistItemID{"item-yyyyy", "item-xxxxx"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			payload:  &dto.ChecklistReorderIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", ItemIDs: []entity.ChecklistItemID{"item-yyyyy", "item-xxxxx"}},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return(items, nil)
+				d.checklistRepository.On("UpdatePositions", context.Background(), []entity.ChecklistItemID{"item-yyyyy", "item-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.checklistRepository, d.taskRepository)
+			err := usecase.Reorder(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *ChecklistUsecaseTestSuite) TestRemove() {
+	tests := []struct {
+		name     string
+		payload  *dto.ChecklistItemRemoveIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when task repository FindByID return unexpected error",
+			payload:  &dto.ChecklistItemRemoveIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrChecklistItemNotFound when item belongs to another task",
+			payload:  &dto.ChecklistItemRemoveIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: domain.ErrChecklistItemNotFound,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(entity.ChecklistItem{ID: "item-xxxxx", TaskID: "task-yyyyy"}, nil)
+			},
+		},
+		{
+			name:     "it should return error when checklist repository DeleteByID return unexpected error",
+			payload:  &dto.ChecklistItemRemoveIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(newItem(), nil)
+				d.checklistRepository.On("DeleteByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			payload:  &dto.ChecklistItemRemoveIn{ItemID: "item-xxxxx", TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(newTask(), nil)
+				d.checklistRepository.On("FindByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(newItem(), nil)
+				d.checklistRepository.On("DeleteByID", context.Background(), entity.ChecklistItemID("item-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.checklistRepository, d.taskRepository)
+			err := usecase.Remove(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+const (
+	// AdminUserDefaultLimit is how many users are listed when no limit is given.
+	AdminUserDefaultLimit = 50
+	// AdminUserMaxLimit is the most users listed at once.
+	AdminUserMaxLimit = 100
+)
+
+// AdminSeedIn represents the input of seeding the initial administrator.
+type AdminSeedIn struct {
+	Email    string `json:"-"`
+	Password string `json:"-"`
+}
+
+// AdminUserGetAllIn represents the input of listing and searching users.
+type AdminUserGetAllIn struct {
+	Search string `json:"-"`
+	Limit  int    `json:"-"`
+	Offset int    `json:"-"`
+}
+
+func (a *AdminUserGetAllIn) Validate() error {
+	switch {
+	case a.Limit < 0 || a.Limit > AdminUserMaxLimit:
+		return ErrLimitInvalid
+	case a.Offset < 0:
+		return ErrOffsetInvalid
+	}
+	return nil
+}
+
+// AdminUserGetAllOut represents the output of listing and searching users.
+type AdminUserGetAllOut struct {
+	ID            entity.UserID   `json:"id"`
+	Name          string          `json:"name"`
+	Email         string          `json:"email"`
+	Role          entity.Role     `json:"role"`
+	EmailVerified bool            `json:"email_verified"`
+	SuspendedAt   entity.NullTime `json:"suspended_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// AdminUserGetByIDIn represents the input of user retrieval by an administrator.
+type AdminUserGetByIDIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// AdminUserUsageOut represents how much a user stores and how many ways they are signed in.
+type AdminUserUsageOut struct {
+	Tasks          int `json:"tasks"`
+	Templates      int `json:"templates"`
+	Filters        int `json:"filters"`
+	Sessions       int `json:"sessions"`
+	PersonalTokens int `json:"personal_tokens"`
+}
+
+// AdminUserGetByIDOut represents the output of user retrieval by an administrator.
+type AdminUserGetByIDOut struct {
+	ID               entity.UserID     `json:"id"`
+	Name             string            `json:"name"`
+	Email            string            `json:"email"`
+	Role             entity.Role       `json:"role"`
+	EmailVerified    bool              `json:"email_verified"`
+	TwoFactorEnabled bool              `json:"two_factor_enabled"`
+	SuspendedAt      entity.NullTime   `json:"suspended_at"`
+	CreatedAt        time.Time         `json:"created_at"`
+	Usage            AdminUserUsageOut `json:"usage"`
+}
+
+// AdminUserSuspendIn represents the input of suspending a user.
+type AdminUserSuspendIn struct {
+	AdminID entity.UserID `json:"-"`
+	UserID  entity.UserID `json:"-"`
+}
+
+// AdminUserUnsuspendIn represents the input of lifting the suspension of a user.
+type AdminUserUnsuspendIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// AdminUserLogoutIn represents the input of signing a user out of every session.
+type AdminUserLogoutIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// AdminUserResetTwoFactorIn represents the input of removing the two-factor authentication of a user.
+type AdminUserResetTwoFactorIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// AdminLoginMethodGetAllOut represents the output of listing the login methods.
+type AdminLoginMethodGetAllOut struct {
+	Method    entity.LoginMethod `json:"method"`
+	Enabled   bool               `json:"enabled"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+// AdminLoginMethodUpdateIn represents the input of turning a login method on or off.
+type AdminLoginMethodUpdateIn struct {
+	Method  entity.LoginMethod `json:"-"`
+	Enabled *bool              `json:"enabled"`
+}
+
+func (a *AdminLoginMethodUpdateIn) Validate() error {
+	switch {
+	case a.Enabled == nil:
+		return ErrEnabledEmpty
+	}
+	return nil
+}
+
+// AdminSecurityEventGetAllIn represents the input of listing and filtering the security events of every user.
+type AdminSecurityEventGetAllIn struct {
+	UserID    entity.UserID            `json:"-"`
+	Type      entity.SecurityEventType `json:"-"`
+	IPAddress string                   `json:"-"`
+	Since     time.Time                `json:"-"`
+	Until     time.Time                `json:"-"`
+	Limit     int                      `json:"-"`
+	Offset    int                      `json:"-"`
+}
+
+func (a *AdminSecurityEventGetAllIn) Validate() error {
+	switch {
+	case a.Limit < 0 || a.Limit > SecurityEventMaxLimit:
+		return ErrLimitInvalid
+	case a.Offset < 0:
+		return ErrOffsetInvalid
+	case !a.Since.IsZero() && !a.Until.IsZero() && !a.Since.Before(a.Until):
+		return ErrTimeRangeInvalid
+	}
+	return nil
+}
+
+// AdminSecurityEventGetAllOut represents the output of listing and filtering the security events of every user.
+type AdminSecurityEventGetAllOut struct {
+	ID        entity.SecurityEventID   `json:"id"`
+	UserID    entity.UserID            `json:"user_id"`
+	Type      entity.SecurityEventType `json:"type"`
+	UserAgent string                   `json:"user_agent"`
+	IPAddress string                   `json:"ip_address"`
+	Detail    string                   `json:"detail,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestAdminDTOSuite(t *testing.T) {
+	suite.Run(t, new(AdminDTOTestSuite))
+}
+
+func (s *AdminDTOTestSuite) TestAdminUserGetAllIn() {
+	tests := []struct {
+		name     string
+		input    AdminUserGetAllIn
+		expected error
+	}{
+		{name: "it should return error when limit is negative", input: AdminUserGetAllIn{Limit: -1}, expected: ErrLimitInvalid},
+		{name: "it should return error when limit is above the maximum", input: AdminUserGetAllIn{Limit: AdminUserMaxLimit + 1}, expected: ErrLimitInvalid},
+		{name: "it should return error when offset is negative", input: AdminUserGetAllIn{Offset: -1}, expected: ErrOffsetInvalid},
+		{name: "it should return nil when limit is not given", input: AdminUserGetAllIn{Search: "gopher"}, expected: nil},
+		{name: "it should return nil when all fields are valid", input: AdminUserGetAllIn{Limit: AdminUserMaxLimit, Offset: 100}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *AdminDTOTestSuite) TestAdminLoginMethodUpdateIn() {
+	enabled := false
+
+	tests := []struct {
+		name     string
+		input    AdminLoginMethodUpdateIn
+		expected error
+	}{
+		{name: "it should return error when enabled is not given", input: AdminLoginMethodUpdateIn{Method: "password"}, expected: ErrEnabledEmpty},
+		{name: "it should return nil when all fields are valid", input: AdminLoginMethodUpdateIn{Method: "password", Enabled: &enabled}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *AdminDTOTestSuite) TestAdminSecurityEventGetAllIn() {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		input    AdminSecurityEventGetAllIn
+		expected error
+	}{
+		{name: "it should return error when limit is negative", input: AdminSecurityEventGetAllIn{Limit: -1}, expected: ErrLimitInvalid},
+		{name: "it should return error when limit is above the maximum", input: AdminSecurityEventGetAllIn{Limit: SecurityEventMaxLimit + 1}, expected: ErrLimitInvalid},
+		{name: "it should return error when offset is negative", input: AdminSecurityEventGetAllIn{Offset: -1}, expected: ErrOffsetInvalid},
+		{name: "it should return error when since is not before until", input: AdminSecurityEventGetAllIn{Since: now, Until: now}, expected: ErrTimeRangeInvalid},
+		{name: "it should return nil when no filter is given", input: AdminSecurityEventGetAllIn{}, expected: nil},
+		{name: "it should return nil when only since is given", input: AdminSecurityEventGetAllIn{Since: now}, expected: nil},
+		{name: "it should return nil when all fields are valid", input: AdminSecurityEventGetAllIn{UserID: "user-xxxxx", Type: "login", IPAddress: "203.0.113.7", Since: now.Add(-time.Hour), Until: now, Limit: SecurityEventMaxLimit, Offset: 100}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
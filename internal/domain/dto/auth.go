@@ -4,8 +4,10 @@ import "github.com/edwintantawi/taskit/internal/domain/entity"
 
 // AuthLoginIn represent login input.
 type AuthLoginIn struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email     string `json:"email"`
+	Password  string `json:"password"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
 }
 
 func (a *AuthLoginIn) Validate() error {
@@ -19,14 +21,115 @@ func (a *AuthLoginIn) Validate() error {
 }
 
 // AuthLoginOut represent login output.
+// When the user has two-factor authentication enabled only MFAToken is set,
+// it has to be exchanged together with a code for the actual tokens.
 type AuthLoginOut struct {
-	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	AccessToken  string `json:"access_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	MFAToken     string `json:"mfa_token,omitempty"`
+	CSRFToken    string `json:"csrf_token,omitempty"`
+}
+
+// AuthVerifyMFAIn represent the second step of a two-factor login input.
+type AuthVerifyMFAIn struct {
+	MFAToken  string `json:"mfa_token"`
+	Code      string `json:"code"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
+}
+
+func (a *AuthVerifyMFAIn) Validate() error {
+	switch {
+	case a.MFAToken == "":
+		return ErrMFATokenEmpty
+	case a.Code == "":
+		return ErrCodeEmpty
+	}
+	return nil
+}
+
+// AuthOIDCStartIn represent OpenID Connect login start input.
+type AuthOIDCStartIn struct {
+	Provider string `json:"-"`
+}
+
+// AuthOIDCStartOut represent OpenID Connect login start output.
+// State binds the login to the browser, the handler keeps it in a cookie.
+type AuthOIDCStartOut struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"-"`
+}
+
+// AuthOIDCLoginIn represent OpenID Connect login callback input.
+// BrowserState is the state kept in the cookie of the browser that started the login.
+type AuthOIDCLoginIn struct {
+	Provider     string `json:"-"`
+	Code         string `json:"code"`
+	State        string `json:"state"`
+	BrowserState string `json:"-"`
+	UserAgent    string `json:"-"`
+	IPAddress    string `json:"-"`
+}
+
+func (a *AuthOIDCLoginIn) Validate() error {
+	switch {
+	case a.Code == "":
+		return ErrCodeEmpty
+	case a.State == "":
+		return ErrStateEmpty
+	}
+	return nil
+}
+
+// AuthMagicLinkRequestIn represent magic link request input.
+// BindBrowser restricts the link to the browser that requested it.
+type AuthMagicLinkRequestIn struct {
+	Email       string `json:"email"`
+	BindBrowser bool   `json:"bind_browser"`
+	IPAddress   string `json:"-"`
+}
+
+func (a *AuthMagicLinkRequestIn) Validate() error {
+	switch {
+	case a.Email == "":
+		return ErrEmailEmpty
+	}
+	return nil
+}
+
+// AuthMagicLinkRequestOut represent magic link request output.
+// Nonce is only set for links bound to the browser, the handler keeps it in a cookie.
+type AuthMagicLinkRequestOut struct {
+	Nonce string `json:"-"`
+}
+
+// AuthMagicLinkLoginIn represent magic link login input.
+type AuthMagicLinkLoginIn struct {
+	Token     string `json:"token"`
+	Nonce     string `json:"-"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
+}
+
+func (a *AuthMagicLinkLoginIn) Validate() error {
+	switch {
+	case a.Token == "":
+		return ErrTokenEmpty
+	}
+	return nil
+}
+
+// AuthLoginMethodsOut represent the login methods users can log in with.
+type AuthLoginMethodsOut struct {
+	Methods []entity.LoginMethod `json:"methods"`
 }
 
 // AuthLogoutIn represent logout input.
 type AuthLogoutIn struct {
-	RefreshToken string `json:"refresh_token"`
+	RefreshToken string            `json:"refresh_token"`
+	AccessToken  entity.AuthClaims `json:"-"`
+	UserAgent    string            `json:"-"`
+	IPAddress    string            `json:"-"`
 }
 
 func (a *AuthLogoutIn) Validate() error {
@@ -44,14 +147,17 @@ type AuthProfileIn struct {
 
 // AuthProfileOut represent get profile output.
 type AuthProfileOut struct {
-	ID    entity.UserID `json:"id"`
-	Name  string        `json:"name"`
-	Email string        `json:"email"`
+	ID            entity.UserID `json:"id"`
+	Name          string        `json:"name"`
+	Email         string        `json:"email"`
+	EmailVerified bool          `json:"email_verified"`
 }
 
 // AuthRefreshIn represent refresh input.
 type AuthRefreshIn struct {
 	RefreshToken string `json:"refresh_token"`
+	UserAgent    string `json:"-"`
+	IPAddress    string `json:"-"`
 }
 
 func (a *AuthRefreshIn) Validate() error {
@@ -65,5 +171,11 @@ func (a *AuthRefreshIn) Validate() error {
 // AuthRefreshOut represent refresh output.
 type AuthRefreshOut struct {
 	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	CSRFToken    string `json:"csrf_token,omitempty"`
+}
+
+// AuthJWKSOut represent the JSON Web Key Set access tokens can be verified with.
+type AuthJWKSOut struct {
+	Keys []entity.JWK `json:"keys"`
 }
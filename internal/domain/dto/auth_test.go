@@ -33,6 +33,80 @@ func (s *AuthDTOTestSuite) TestAuthLoginIn() {
 	}
 }
 
+func (s *AuthDTOTestSuite) TestAuthVerifyMFAIn() {
+	tests := []struct {
+		name     string
+		input    AuthVerifyMFAIn
+		expected error
+	}{
+		{name: "it should return error when mfa token is empty", input: AuthVerifyMFAIn{Code: "123456"}, expected: ErrMFATokenEmpty},
+		{name: "it should return error when code is empty", input: AuthVerifyMFAIn{MFAToken: "mfa_token"}, expected: ErrCodeEmpty},
+		{name: "it should return nil when all fields are valid", input: AuthVerifyMFAIn{MFAToken: "mfa_token", Code: "123456"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *AuthDTOTestSuite) TestAuthOIDCLoginIn() {
+	tests := []struct {
+		name     string
+		input    AuthOIDCLoginIn
+		expected error
+	}{
+		{name: "it should return error when code is empty", input: AuthOIDCLoginIn{State: "state"}, expected: ErrCodeEmpty},
+		{name: "it should return error when state is empty", input: AuthOIDCLoginIn{Code: "code"}, expected: ErrStateEmpty},
+		{name: "it should return nil when all fields are valid", input: AuthOIDCLoginIn{Code: "code", State: "state"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *AuthDTOTestSuite) TestAuthMagicLinkRequestIn() {
+	tests := []struct {
+		name     string
+		input    AuthMagicLinkRequestIn
+		expected error
+	}{
+		{name: "it should return error when email is empty", input: AuthMagicLinkRequestIn{BindBrowser: true}, expected: ErrEmailEmpty},
+		{name: "it should return nil when all fields are valid", input: AuthMagicLinkRequestIn{Email: "gopher@go.dev"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *AuthDTOTestSuite) TestAuthMagicLinkLoginIn() {
+	tests := []struct {
+		name     string
+		input    AuthMagicLinkLoginIn
+		expected error
+	}{
+		{name: "it should return error when token is empty", input: AuthMagicLinkLoginIn{Nonce: "nonce"}, expected: ErrTokenEmpty},
+		{name: "it should return nil when all fields are valid", input: AuthMagicLinkLoginIn{Token: "token"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
 func (s *AuthDTOTestSuite) TestAuthLogoutIn() {
 	tests := []struct {
 		name     string
@@ -0,0 +1,85 @@
+package dto
+
+import "github.com/edwintantawi/taskit/internal/domain/entity"
+
+// ChecklistItemCreateIn represents the input of checklist item creation.
+type ChecklistItemCreateIn struct {
+	TaskID entity.TaskID `json:"-"`
+	UserID entity.UserID `json:"-"`
+	Text   string        `json:"text"`
+}
+
+func (c *ChecklistItemCreateIn) Validate() error {
+	switch {
+	case c.Text == "":
+		return ErrTextEmpty
+	}
+	return nil
+}
+
+// ChecklistItemCreateOut represents the output of checklist item creation.
+type ChecklistItemCreateOut struct {
+	ID entity.ChecklistItemID `json:"id"`
+}
+
+// ChecklistItemToggleIn represents the input of checklist item toggling.
+type ChecklistItemToggleIn struct {
+	ItemID entity.ChecklistItemID `json:"-"`
+	TaskID entity.TaskID          `json:"-"`
+	UserID entity.UserID          `json:"-"`
+}
+
+// ChecklistItemToggleOut represents the output of checklist item toggling.
+type ChecklistItemToggleOut struct {
+	ID        entity.ChecklistItemID `json:"id"`
+	IsChecked bool                   `json:"is_checked"`
+}
+
+// ChecklistReorderIn represents the input of checklist reordering.
+type ChecklistReorderIn struct {
+	TaskID  entity.TaskID            `json:"-"`
+	UserID  entity.UserID            `json:"-"`
+	ItemIDs []entity.ChecklistItemID `json:"item_ids"`
+}
+
+func (c *ChecklistReorderIn) Validate() error {
+	switch {
+	case len(c.ItemIDs) == 0:
+		return ErrItemIDsEmpty
+	}
+	return nil
+}
+
+// ChecklistItemRemoveIn represents the input of checklist item removal.
+type ChecklistItemRemoveIn struct {
+	ItemID entity.ChecklistItemID `json:"-"`
+	TaskID entity.TaskID          `json:"-"`
+	UserID entity.UserID          `json:"-"`
+}
+
+// ChecklistItemOut represents a checklist item inside a task output.
+type ChecklistItemOut struct {
+	ID        entity.ChecklistItemID `json:"id"`
+	Text      string                 `json:"text"`
+	IsChecked bool                   `json:"is_checked"`
+	Position  int                    `json:"position"`
+}
+
+// ChecklistProgress represents how many checklist items of a task are checked.
+type ChecklistProgress struct {
+	Checked int `json:"checked"`
+	Total   int `json:"total"`
+}
+
+// NewChecklistOut build the checklist output and its progress from the items of a task.
+func NewChecklistOut(items []entity.ChecklistItem) ([]ChecklistItemOut, ChecklistProgress) {
+	checklist := make([]ChecklistItemOut, len(items))
+	progress := ChecklistProgress{Total: len(items)}
+	for i, item := range items {
+		checklist[i] = ChecklistItemOut{ID: item.ID, Text: item.Text, IsChecked: item.IsChecked, Position: item.Position}
+		if item.IsChecked {
+			progress.Checked++
+		}
+	}
+	return checklist, progress
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type ChecklistDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestChecklistDTOSuite(t *testing.T) {
+	suite.Run(t, new(ChecklistDTOTestSuite))
+}
+
+func (s *ChecklistDTOTestSuite) TestChecklistItemCreateIn() {
+	tests := []struct {
+		name     string
+		input    ChecklistItemCreateIn
+		expected error
+	}{
+		{name: "it should return error when text is empty", input: ChecklistItemCreateIn{}, expected: ErrTextEmpty},
+		{name: "it should return nil when all fields are valid", input: ChecklistItemCreateIn{Text: "Buy milk"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *ChecklistDTOTestSuite) TestChecklistReorderIn() {
+	tests := []struct {
+		name     string
+		input    ChecklistReorderIn
+		expected error
+	}{
+		{name: "it should return error when item ids is empty", input: ChecklistReorderIn{}, expected: ErrItemIDsEmpty},
+		{name: "it should return nil when all fields are valid", input: ChecklistReorderIn{ItemIDs: []entity.ChecklistItemID{"item-xxxxx"}}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *ChecklistDTOTestSuite) TestNewChecklistOut() {
+	s.Run("it should return empty checklist and progress when there are no items", func() {
+		checklist, progress := NewChecklistOut(nil)
+
+		s.Equal([]ChecklistItemOut{}, checklist)
+		s.Equal(ChecklistProgress{Checked: 0, Total: 0}, progress)
+	})
+
+	s.Run("it should return checklist and count checked items", func() {
+		items := []entity.ChecklistItem{
+			{ID: "item-xxxxx", TaskID: "task-xxxxx", Text: "Buy milk", IsChecked: true, Position: 0},
+			{ID: "item-yyyyy", TaskID: "task-xxxxx", Text: "Buy eggs", IsChecked: false, Position: 1},
+		}
+
+		checklist, progress := NewChecklistOut(items)
+
+		s.Equal([]ChecklistItemOut{
+			{ID: "item-xxxxx", Text: "Buy milk", IsChecked: true, Position: 0},
+			{ID: "item-yyyyy", Text: "Buy eggs", IsChecked: false, Position: 1},
+		}, checklist)
+		s.Equal(ChecklistProgress{Checked: 1, Total: 2}, progress)
+	})
+}
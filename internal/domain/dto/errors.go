@@ -7,7 +7,42 @@ var (
 	ErrPasswordEmpty = errors.New("dto.password_empty")
 	ErrNameEmpty     = errors.New("dto.name_empty")
 
+	ErrCurrentPasswordEmpty = errors.New("dto.current_password_empty")
+	ErrNewPasswordEmpty     = errors.New("dto.new_password_empty")
+
 	ErrRefreshTokenEmpty = errors.New("dto.refresh_token_empty")
+	ErrTokenEmpty        = errors.New("dto.token_empty")
+	ErrCSRFTokenInvalid  = errors.New("dto.csrf_token_invalid")
 
 	ErrContentEmpty = errors.New("dto.content_empty")
+
+	ErrTaskIDsEmpty  = errors.New("dto.task_ids_empty")
+	ErrAnchorInvalid = errors.New("dto.anchor_invalid")
+
+	ErrDateInvalid     = errors.New("dto.date_invalid")
+	ErrTimeZoneInvalid = errors.New("dto.time_zone_invalid")
+
+	ErrQueryEmpty     = errors.New("dto.query_empty")
+	ErrFilterIDsEmpty = errors.New("dto.filter_ids_empty")
+
+	ErrSnoozeEmpty         = errors.New("dto.snooze_empty")
+	ErrSnoozeConflict      = errors.New("dto.snooze_conflict")
+	ErrSnoozePresetInvalid = errors.New("dto.snooze_preset_invalid")
+
+	ErrTextEmpty    = errors.New("dto.text_empty")
+	ErrItemIDsEmpty = errors.New("dto.item_ids_empty")
+
+	ErrCodeEmpty     = errors.New("dto.code_empty")
+	ErrMFATokenEmpty = errors.New("dto.mfa_token_empty")
+	ErrStateEmpty    = errors.New("dto.state_empty")
+
+	ErrScopesEmpty = errors.New("dto.scopes_empty")
+
+	ErrLimitInvalid  = errors.New("dto.limit_invalid")
+	ErrOffsetInvalid = errors.New("dto.offset_invalid")
+
+	ErrEnabledEmpty = errors.New("dto.enabled_empty")
+
+	ErrTimeInvalid      = errors.New("dto.time_invalid")
+	ErrTimeRangeInvalid = errors.New("dto.time_range_invalid")
 )
@@ -0,0 +1,119 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// FilterCreateIn represents the input of filter creation.
+type FilterCreateIn struct {
+	UserID entity.UserID `json:"-"`
+	Name   string        `json:"name"`
+	Query  string        `json:"query"`
+}
+
+func (f *FilterCreateIn) Validate() error {
+	switch {
+	case f.Name == "":
+		return ErrNameEmpty
+	case f.Query == "":
+		return ErrQueryEmpty
+	}
+	return nil
+}
+
+// FilterCreateOut represents the output of filter creation.
+type FilterCreateOut struct {
+	ID entity.FilterID `json:"id"`
+}
+
+// FilterGetAllIn represents the input of filters retrieval.
+type FilterGetAllIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// FilterGetAllOut represents the output of filters retrieval.
+type FilterGetAllOut struct {
+	ID        entity.FilterID `json:"id"`
+	Name      string          `json:"name"`
+	Query     string          `json:"query"`
+	Position  int             `json:"position"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// FilterGetByIDIn represents the input of filter retrieval.
+type FilterGetByIDIn struct {
+	FilterID entity.FilterID `json:"-"`
+	UserID   entity.UserID   `json:"-"`
+}
+
+// FilterGetByIDOut represents the output of filter retrieval.
+type FilterGetByIDOut struct {
+	ID        entity.FilterID `json:"id"`
+	Name      string          `json:"name"`
+	Query     string          `json:"query"`
+	Position  int             `json:"position"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// FilterUpdateIn represents the input of filter update.
+type FilterUpdateIn struct {
+	FilterID entity.FilterID `json:"-"`
+	UserID   entity.UserID   `json:"-"`
+	Name     string          `json:"name"`
+	Query    string          `json:"query"`
+}
+
+func (f *FilterUpdateIn) Validate() error {
+	switch {
+	case f.Name == "":
+		return ErrNameEmpty
+	case f.Query == "":
+		return ErrQueryEmpty
+	}
+	return nil
+}
+
+// FilterUpdateOut represents the output of filter update.
+type FilterUpdateOut struct {
+	ID entity.FilterID `json:"id"`
+}
+
+// FilterRemoveIn represents the input of filter removal.
+type FilterRemoveIn struct {
+	FilterID entity.FilterID `json:"-"`
+	UserID   entity.UserID   `json:"-"`
+}
+
+// FilterReorderIn represents the input of filters reordering, FilterIDs lists every filter of the user in the new order.
+type FilterReorderIn struct {
+	UserID    entity.UserID     `json:"-"`
+	FilterIDs []entity.FilterID `json:"filter_ids"`
+}
+
+func (f *FilterReorderIn) Validate() error {
+	switch {
+	case len(f.FilterIDs) == 0:
+		return ErrFilterIDsEmpty
+	}
+	return nil
+}
+
+// FilterGetTasksIn represents the input of the tasks retrieval of a filter.
+type FilterGetTasksIn struct {
+	FilterID       entity.FilterID `json:"-"`
+	UserID         entity.UserID   `json:"-"`
+	TimeZone       string          `json:"-"`
+	IncludeSnoozed bool            `json:"-"`
+}
+
+func (f *FilterGetTasksIn) Validate() error {
+	switch {
+	case f.TimeZone != "" && !isTimeZone(f.TimeZone):
+		return ErrTimeZoneInvalid
+	}
+	return nil
+}
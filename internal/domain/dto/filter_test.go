@@ -0,0 +1,91 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type FilterDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestFilterDTOSuite(t *testing.T) {
+	suite.Run(t, new(FilterDTOTestSuite))
+}
+
+func (s *FilterDTOTestSuite) TestFilterCreateIn() {
+	tests := []struct {
+		name     string
+		input    FilterCreateIn
+		expected error
+	}{
+		{name: "it should return error when name is empty", input: FilterCreateIn{Query: "is_completed = false"}, expected: ErrNameEmpty},
+		{name: "it should return error when query is empty", input: FilterCreateIn{Name: "Open"}, expected: ErrQueryEmpty},
+		{name: "it should return nil when all fields are valid", input: FilterCreateIn{Name: "Open", Query: "is_completed = false"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *FilterDTOTestSuite) TestFilterUpdateIn() {
+	tests := []struct {
+		name     string
+		input    FilterUpdateIn
+		expected error
+	}{
+		{name: "it should return error when name is empty", input: FilterUpdateIn{Query: "is_completed = false"}, expected: ErrNameEmpty},
+		{name: "it should return error when query is empty", input: FilterUpdateIn{Name: "Open"}, expected: ErrQueryEmpty},
+		{name: "it should return nil when all fields are valid", input: FilterUpdateIn{Name: "Open", Query: "is_completed = false"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *FilterDTOTestSuite) TestFilterReorderIn() {
+	tests := []struct {
+		name     string
+		input    FilterReorderIn
+		expected error
+	}{
+		{name: "it should return error when filter ids is empty", input: FilterReorderIn{}, expected: ErrFilterIDsEmpty},
+		{name: "it should return nil when all fields are valid", input: FilterReorderIn{FilterIDs: []entity.FilterID{"filter-xxxxx"}}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *FilterDTOTestSuite) TestFilterGetTasksIn() {
+	tests := []struct {
+		name     string
+		input    FilterGetTasksIn
+		expected error
+	}{
+		{name: "it should return error when time zone is invalid", input: FilterGetTasksIn{TimeZone: "Mars/Olympus"}, expected: ErrTimeZoneInvalid},
+		{name: "it should return nil when time zone is empty", input: FilterGetTasksIn{}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
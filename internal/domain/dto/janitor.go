@@ -0,0 +1,9 @@
+package dto
+
+// JanitorRunOut represent a janitor run output.
+type JanitorRunOut struct {
+	Authentications int64
+	LoginAttempts   int64
+	OIDCStates      int64
+	MFAChallenges   int64
+}
@@ -0,0 +1,33 @@
+package dto
+
+// PasswordResetRequestIn represents the input of requesting a password reset.
+type PasswordResetRequestIn struct {
+	Email     string `json:"email"`
+	IPAddress string `json:"-"`
+}
+
+func (p *PasswordResetRequestIn) Validate() error {
+	switch {
+	case p.Email == "":
+		return ErrEmailEmpty
+	}
+	return nil
+}
+
+// PasswordResetConfirmIn represents the input of confirming a password reset.
+type PasswordResetConfirmIn struct {
+	Token     string `json:"token"`
+	Password  string `json:"password"`
+	UserAgent string `json:"-"`
+	IPAddress string `json:"-"`
+}
+
+func (p *PasswordResetConfirmIn) Validate() error {
+	switch {
+	case p.Token == "":
+		return ErrTokenEmpty
+	case p.Password == "":
+		return ErrPasswordEmpty
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordResetDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestPasswordResetDTOSuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetDTOTestSuite))
+}
+
+func (s *PasswordResetDTOTestSuite) TestPasswordResetRequestIn() {
+	tests := []struct {
+		name     string
+		input    PasswordResetRequestIn
+		expected error
+	}{
+		{name: "it should return error when email is empty", input: PasswordResetRequestIn{}, expected: ErrEmailEmpty},
+		{name: "it should return nil when all fields are valid", input: PasswordResetRequestIn{Email: "gopher@go.dev"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *PasswordResetDTOTestSuite) TestPasswordResetConfirmIn() {
+	tests := []struct {
+		name     string
+		input    PasswordResetConfirmIn
+		expected error
+	}{
+		{name: "it should return error when token is empty", input: PasswordResetConfirmIn{Password: "new_password"}, expected: ErrTokenEmpty},
+		{name: "it should return error when password is empty", input: PasswordResetConfirmIn{Token: "raw_token"}, expected: ErrPasswordEmpty},
+		{name: "it should return nil when all fields are valid", input: PasswordResetConfirmIn{Token: "raw_token", Password: "new_password"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// PersonalTokenCreateIn represents the input of personal access token creation.
+type PersonalTokenCreateIn struct {
+	UserID    entity.UserID   `json:"-"`
+	Name      string          `json:"name"`
+	Scopes    []string        `json:"scopes"`
+	ExpiresAt entity.NullTime `json:"expires_at"`
+}
+
+func (p *PersonalTokenCreateIn) Validate() error {
+	switch {
+	case p.Name == "":
+		return ErrNameEmpty
+	case len(p.Scopes) == 0:
+		return ErrScopesEmpty
+	}
+	return nil
+}
+
+// PersonalTokenCreateOut represents the output of personal access token creation.
+// Token is only returned here, it can not be retrieved again.
+type PersonalTokenCreateOut struct {
+	ID        entity.PersonalTokenID `json:"id"`
+	Name      string                 `json:"name"`
+	Token     string                 `json:"token"`
+	Scopes    []entity.Scope         `json:"scopes"`
+	ExpiresAt entity.NullTime        `json:"expires_at"`
+}
+
+// PersonalTokenGetAllIn represents the input of personal access tokens retrieval.
+type PersonalTokenGetAllIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// PersonalTokenGetAllOut represents the output of personal access tokens retrieval.
+type PersonalTokenGetAllOut struct {
+	ID         entity.PersonalTokenID `json:"id"`
+	Name       string                 `json:"name"`
+	Scopes     []entity.Scope         `json:"scopes"`
+	ExpiresAt  entity.NullTime        `json:"expires_at"`
+	LastUsedAt entity.NullTime        `json:"last_used_at"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// PersonalTokenRevokeIn represents the input of revoking a personal access token.
+type PersonalTokenRevokeIn struct {
+	UserID  entity.UserID          `json:"-"`
+	TokenID entity.PersonalTokenID `json:"-"`
+}
+
+// PersonalTokenAuthenticateIn represents the input of authenticating with a personal access token.
+type PersonalTokenAuthenticateIn struct {
+	Token string `json:"-"`
+}
+
+// PersonalTokenAuthenticateOut represents the output of authenticating with a personal access token.
+type PersonalTokenAuthenticateOut struct {
+	UserID entity.UserID
+	Scopes []entity.Scope
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PersonalTokenDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestPersonalTokenDTOSuite(t *testing.T) {
+	suite.Run(t, new(PersonalTokenDTOTestSuite))
+}
+
+func (s *PersonalTokenDTOTestSuite) TestPersonalTokenCreateIn() {
+	tests := []struct {
+		name     string
+		input    PersonalTokenCreateIn
+		expected error
+	}{
+		{name: "it should return error when name is empty", input: PersonalTokenCreateIn{Scopes: []string{"tasks:read"}}, expected: ErrNameEmpty},
+		{name: "it should return error when scopes are empty", input: PersonalTokenCreateIn{Name: "CI"}, expected: ErrScopesEmpty},
+		{name: "it should return nil when all fields are valid", input: PersonalTokenCreateIn{Name: "CI", Scopes: []string{"tasks:read"}}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
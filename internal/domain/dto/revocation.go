@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// RevocationEnsureActiveIn represent the access token to check for revocation.
+type RevocationEnsureActiveIn struct {
+	TokenID  string
+	UserID   entity.UserID
+	IssuedAt time.Time
+}
+
+// RevocationPruneOut represent prune expired revocations output.
+type RevocationPruneOut struct {
+	Deleted int64
+}
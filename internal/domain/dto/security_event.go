@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+const (
+	// SecurityEventDefaultLimit is the number of security events listed when no limit is given.
+	SecurityEventDefaultLimit = 50
+	// SecurityEventMaxLimit is the most security events listed at once, the same as for users.
+	SecurityEventMaxLimit = AdminUserMaxLimit
+)
+
+// SecurityEventGetAllIn represent get all security events of a user input.
+type SecurityEventGetAllIn struct {
+	UserID entity.UserID `json:"-"`
+	Limit  int           `json:"-"`
+	Offset int           `json:"-"`
+}
+
+func (s *SecurityEventGetAllIn) Validate() error {
+	switch {
+	case s.Limit < 0 || s.Limit > SecurityEventMaxLimit:
+		return ErrLimitInvalid
+	case s.Offset < 0:
+		return ErrOffsetInvalid
+	}
+	return nil
+}
+
+// SecurityEventGetAllOut represent get all security events of a user output.
+type SecurityEventGetAllOut struct {
+	ID        entity.SecurityEventID   `json:"id"`
+	Type      entity.SecurityEventType `json:"type"`
+	UserAgent string                   `json:"user_agent"`
+	IPAddress string                   `json:"ip_address"`
+	Detail    string                   `json:"detail,omitempty"`
+	CreatedAt time.Time                `json:"created_at"`
+}
+
+// SecurityEventPruneOut represent prune security events past their retention output.
+type SecurityEventPruneOut struct {
+	Deleted int64
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type SecurityEventDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestSecurityEventDTOSuite(t *testing.T) {
+	suite.Run(t, new(SecurityEventDTOTestSuite))
+}
+
+func (s *SecurityEventDTOTestSuite) TestSecurityEventGetAllIn() {
+	tests := []struct {
+		name     string
+		input    SecurityEventGetAllIn
+		expected error
+	}{
+		{name: "it should return error when limit is negative", input: SecurityEventGetAllIn{UserID: "user-xxxxx", Limit: -1}, expected: ErrLimitInvalid},
+		{name: "it should return error when limit is above the maximum", input: SecurityEventGetAllIn{UserID: "user-xxxxx", Limit: SecurityEventMaxLimit + 1}, expected: ErrLimitInvalid},
+		{name: "it should return error when offset is negative", input: SecurityEventGetAllIn{UserID: "user-xxxxx", Offset: -1}, expected: ErrOffsetInvalid},
+		{name: "it should return nil when limit is not given", input: SecurityEventGetAllIn{UserID: "user-xxxxx"}, expected: nil},
+		{name: "it should return nil when all fields are valid", input: SecurityEventGetAllIn{UserID: "user-xxxxx", Limit: SecurityEventMaxLimit, Offset: 100}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
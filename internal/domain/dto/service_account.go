@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// ServiceAccountCreateIn represents the input of service account creation.
+type ServiceAccountCreateIn struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func (s *ServiceAccountCreateIn) Validate() error {
+	switch {
+	case s.Name == "":
+		return ErrNameEmpty
+	case s.Email == "":
+		return ErrEmailEmpty
+	}
+	return nil
+}
+
+// ServiceAccountCreateOut represents the output of service account creation.
+// Key is the first API key of the account, it can not be retrieved again.
+type ServiceAccountCreateOut struct {
+	ID    entity.UserID              `json:"id"`
+	Name  string                     `json:"name"`
+	Email string                     `json:"email"`
+	KeyID entity.ServiceAccountKeyID `json:"key_id"`
+	Key   string                     `json:"key"`
+}
+
+// ServiceAccountKeyGetAllIn represents the input of service account API keys retrieval.
+type ServiceAccountKeyGetAllIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// ServiceAccountKeyGetAllOut represents the output of service account API keys retrieval.
+type ServiceAccountKeyGetAllOut struct {
+	ID         entity.ServiceAccountKeyID `json:"id"`
+	ExpiresAt  entity.NullTime            `json:"expires_at"`
+	LastUsedAt entity.NullTime            `json:"last_used_at"`
+	CreatedAt  time.Time                  `json:"created_at"`
+}
+
+// ServiceAccountKeyRotateIn represents the input of rotating the API key of a service account.
+type ServiceAccountKeyRotateIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// ServiceAccountKeyRotateOut represents the output of rotating the API key of a service account.
+// Key is only returned here, the previous key keeps working until PreviousKeyExpiresAt.
+type ServiceAccountKeyRotateOut struct {
+	ID                   entity.ServiceAccountKeyID `json:"id"`
+	Key                  string                     `json:"key"`
+	PreviousKeyExpiresAt time.Time                  `json:"previous_key_expires_at"`
+}
+
+// ServiceAccountKeyRevokeIn represents the input of revoking an API key of a service account.
+type ServiceAccountKeyRevokeIn struct {
+	UserID entity.UserID              `json:"-"`
+	KeyID  entity.ServiceAccountKeyID `json:"-"`
+}
+
+// ServiceAccountAuthenticateIn represents the input of authenticating with a service account API key.
+type ServiceAccountAuthenticateIn struct {
+	Key string `json:"-"`
+}
+
+// ServiceAccountAuthenticateOut represents the output of authenticating with a service account API key.
+type ServiceAccountAuthenticateOut struct {
+	UserID entity.UserID
+}
+
+// ServiceAccountAuditIn represents a request made by a service account, once it is served.
+type ServiceAccountAuditIn struct {
+	UserID     entity.UserID
+	Method     string
+	Path       string
+	StatusCode int
+	UserAgent  string
+	IPAddress  string
+}
@@ -0,0 +1,34 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceAccountDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestServiceAccountDTOSuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountDTOTestSuite))
+}
+
+func (s *ServiceAccountDTOTestSuite) TestServiceAccountCreateIn() {
+	tests := []struct {
+		name     string
+		input    ServiceAccountCreateIn
+		expected error
+	}{
+		{name: "it should return error when name is empty", input: ServiceAccountCreateIn{Email: "ci@go.dev"}, expected: ErrNameEmpty},
+		{name: "it should return error when email is empty", input: ServiceAccountCreateIn{Name: "CI"}, expected: ErrEmailEmpty},
+		{name: "it should return nil when all fields are valid", input: ServiceAccountCreateIn{Name: "CI", Email: "ci@go.dev"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// SessionGetAllIn represents the input of session retrieval.
+type SessionGetAllIn struct {
+	UserID    entity.UserID `json:"-"`
+	SessionID entity.AuthID `json:"-"`
+}
+
+// SessionGetAllOut represents the output of session retrieval.
+type SessionGetAllOut struct {
+	ID         entity.AuthID `json:"id"`
+	UserAgent  string        `json:"user_agent"`
+	IPAddress  string        `json:"ip_address"`
+	IsCurrent  bool          `json:"is_current"`
+	CreatedAt  time.Time     `json:"created_at"`
+	LastUsedAt time.Time     `json:"last_used_at"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+}
+
+// SessionRevokeIn represents the input of revoking a session.
+type SessionRevokeIn struct {
+	UserID    entity.UserID `json:"-"`
+	SessionID entity.AuthID `json:"-"`
+}
+
+// SessionRevokeOthersIn represents the input of revoking every session but the current one.
+type SessionRevokeOthersIn struct {
+	UserID    entity.UserID `json:"-"`
+	SessionID entity.AuthID `json:"-"`
+}
@@ -0,0 +1,62 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// StatsDateLayout is the date format used by stats ranges and periods.
+const StatsDateLayout = "2006-01-02"
+
+// StatsGetIn represents the input of stats retrieval.
+type StatsGetIn struct {
+	UserID   entity.UserID `json:"-"`
+	From     string        `json:"-"`
+	To       string        `json:"-"`
+	TimeZone string        `json:"-"`
+}
+
+func (s *StatsGetIn) Validate() error {
+	switch {
+	case s.From != "" && !isDate(s.From):
+		return ErrDateInvalid
+	case s.To != "" && !isDate(s.To):
+		return ErrDateInvalid
+	case s.TimeZone != "" && !isTimeZone(s.TimeZone):
+		return ErrTimeZoneInvalid
+	}
+	return nil
+}
+
+// StatsPeriod represents the task activity of a single day, week or month.
+type StatsPeriod struct {
+	Start     string `json:"start"`
+	Created   int    `json:"created"`
+	Completed int    `json:"completed"`
+	Overdue   int    `json:"overdue"`
+}
+
+// StatsGetOut represents the output of stats retrieval.
+type StatsGetOut struct {
+	From                     string        `json:"from"`
+	To                       string        `json:"to"`
+	TimeZone                 string        `json:"time_zone"`
+	Daily                    []StatsPeriod `json:"daily"`
+	Weekly                   []StatsPeriod `json:"weekly"`
+	Monthly                  []StatsPeriod `json:"monthly"`
+	CurrentStreak            int           `json:"current_streak"`
+	LongestStreak            int           `json:"longest_streak"`
+	OverdueCount             int           `json:"overdue_count"`
+	AverageCompletionSeconds int64         `json:"average_completion_seconds"`
+}
+
+func isDate(value string) bool {
+	_, err := time.Parse(StatsDateLayout, value)
+	return err == nil
+}
+
+func isTimeZone(value string) bool {
+	_, err := time.LoadLocation(value)
+	return err == nil
+}
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatsDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestStatsDTOSuite(t *testing.T) {
+	suite.Run(t, new(StatsDTOTestSuite))
+}
+
+func (s *StatsDTOTestSuite) TestStatsGetIn() {
+	tests := []struct {
+		name     string
+		input    StatsGetIn
+		expected error
+	}{
+		{name: "it should return error when from is invalid", input: StatsGetIn{From: "01-11-2026"}, expected: ErrDateInvalid},
+		{name: "it should return error when to is invalid", input: StatsGetIn{To: "2026-13-01"}, expected: ErrDateInvalid},
+		{name: "it should return error when time zone is invalid", input: StatsGetIn{TimeZone: "Mars/Olympus"}, expected: ErrTimeZoneInvalid},
+		{name: "it should return nil when all fields are empty", input: StatsGetIn{}, expected: nil},
+		{name: "it should return nil when all fields are valid", input: StatsGetIn{From: "2026-11-01", To: "2026-11-30", TimeZone: "Asia/Jakarta"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
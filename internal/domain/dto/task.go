@@ -12,6 +12,7 @@ type TaskCreateIn struct {
 	Content     string          `json:"content"`
 	Description string          `json:"description"`
 	DueDate     entity.NullTime `json:"due_date"`
+	StartDate   entity.NullTime `json:"start_date"`
 }
 
 func (t *TaskCreateIn) Validate() error {
@@ -29,18 +30,33 @@ type TaskCreateOut struct {
 
 // TaskGetAllIn represents the input of task retrieval.
 type TaskGetAllIn struct {
-	UserID entity.UserID `json:"-"`
+	UserID         entity.UserID `json:"-"`
+	Query          string        `json:"-"`
+	TimeZone       string        `json:"-"`
+	IncludeSnoozed bool          `json:"-"`
+}
+
+func (t *TaskGetAllIn) Validate() error {
+	switch {
+	case t.TimeZone != "" && !isTimeZone(t.TimeZone):
+		return ErrTimeZoneInvalid
+	}
+	return nil
 }
 
 // TaskGetAllOut represents the output of task retrieval.
 type TaskGetAllOut struct {
-	ID          entity.TaskID   `json:"id"`
-	Content     string          `json:"content"`
-	Description string          `json:"description"`
-	IsCompleted bool            `json:"is_completed"`
-	DueDate     entity.NullTime `json:"due_date"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID                entity.TaskID      `json:"id"`
+	Content           string             `json:"content"`
+	Description       string             `json:"description"`
+	IsCompleted       bool               `json:"is_completed"`
+	DueDate           entity.NullTime    `json:"due_date"`
+	StartDate         entity.NullTime    `json:"start_date"`
+	CompletedAt       entity.NullTime    `json:"completed_at"`
+	Checklist         []ChecklistItemOut `json:"checklist"`
+	ChecklistProgress ChecklistProgress  `json:"checklist_progress"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
 }
 
 // TaskRemoveIn represents the input of task removal.
@@ -57,13 +73,17 @@ type TaskGetByIDIn struct {
 
 // TaskGetByIDOut represents the output of task retrieval.
 type TaskGetByIDOut struct {
-	ID          entity.TaskID   `json:"id"`
-	Content     string          `json:"content"`
-	Description string          `json:"description"`
-	IsCompleted bool            `json:"is_completed"`
-	DueDate     entity.NullTime `json:"due_date"`
-	CreatedAt   time.Time       `json:"created_at"`
-	UpdatedAt   time.Time       `json:"updated_at"`
+	ID                entity.TaskID      `json:"id"`
+	Content           string             `json:"content"`
+	Description       string             `json:"description"`
+	IsCompleted       bool               `json:"is_completed"`
+	DueDate           entity.NullTime    `json:"due_date"`
+	StartDate         entity.NullTime    `json:"start_date"`
+	CompletedAt       entity.NullTime    `json:"completed_at"`
+	Checklist         []ChecklistItemOut `json:"checklist"`
+	ChecklistProgress ChecklistProgress  `json:"checklist_progress"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
 }
 
 // TaskUpdateIn represents the input of task update
@@ -74,6 +94,7 @@ type TaskUpdateIn struct {
 	Description string          `json:"description"`
 	IsCompleted bool            `json:"is_completed"`
 	DueDate     entity.NullTime `json:"due_date"`
+	StartDate   entity.NullTime `json:"start_date"`
 }
 
 func (t *TaskUpdateIn) Validate() error {
@@ -88,3 +109,38 @@ func (t *TaskUpdateIn) Validate() error {
 type TaskUpdateOut struct {
 	ID entity.TaskID `json:"id"`
 }
+
+// TaskSnoozeIn represents the input of task snoozing, either a preset or an exact time.
+type TaskSnoozeIn struct {
+	TaskID   entity.TaskID       `json:"-"`
+	UserID   entity.UserID       `json:"-"`
+	Preset   entity.SnoozePreset `json:"preset"`
+	Until    entity.NullTime     `json:"until"`
+	TimeZone string              `json:"-"`
+}
+
+func (t *TaskSnoozeIn) Validate() error {
+	switch {
+	case t.Preset == "" && !t.Until.Valid:
+		return ErrSnoozeEmpty
+	case t.Preset != "" && t.Until.Valid:
+		return ErrSnoozeConflict
+	case t.Preset != "" && !t.Preset.IsValid():
+		return ErrSnoozePresetInvalid
+	case t.TimeZone != "" && !isTimeZone(t.TimeZone):
+		return ErrTimeZoneInvalid
+	}
+	return nil
+}
+
+// TaskSnoozeOut represents the output of task snoozing.
+type TaskSnoozeOut struct {
+	ID        entity.TaskID `json:"id"`
+	StartDate time.Time     `json:"start_date"`
+}
+
+// TaskUnsnoozeIn represents the input of task unsnoozing.
+type TaskUnsnoozeIn struct {
+	TaskID entity.TaskID `json:"-"`
+	UserID entity.UserID `json:"-"`
+}
@@ -1,9 +1,13 @@
 package dto
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
 )
 
 type TaskDTOTestSuite struct {
@@ -69,3 +73,45 @@ func (s *TaskDTOTestSuite) TestTaskUpdateIn() {
 		})
 	}
 }
+
+func (s *TaskDTOTestSuite) TestTaskGetAllIn() {
+	tests := []struct {
+		name     string
+		input    TaskGetAllIn
+		expected error
+	}{
+		{name: "it should return error when time zone is invalid", input: TaskGetAllIn{TimeZone: "Mars/Olympus"}, expected: ErrTimeZoneInvalid},
+		{name: "it should return nil when all fields are valid", input: TaskGetAllIn{Query: "is_completed = false", TimeZone: "Asia/Jakarta"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *TaskDTOTestSuite) TestTaskSnoozeIn() {
+	until := entity.NullTime{NullTime: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true}}
+
+	tests := []struct {
+		name     string
+		input    TaskSnoozeIn
+		expected error
+	}{
+		{name: "it should return error when neither preset nor until is provided", input: TaskSnoozeIn{}, expected: ErrSnoozeEmpty},
+		{name: "it should return error when both preset and until are provided", input: TaskSnoozeIn{Preset: entity.SnoozeTomorrow, Until: until}, expected: ErrSnoozeConflict},
+		{name: "it should return error when preset is unknown", input: TaskSnoozeIn{Preset: "someday"}, expected: ErrSnoozePresetInvalid},
+		{name: "it should return error when time zone is invalid", input: TaskSnoozeIn{Preset: entity.SnoozeTomorrow, TimeZone: "Mars/Olympus"}, expected: ErrTimeZoneInvalid},
+		{name: "it should return nil when preset is valid", input: TaskSnoozeIn{Preset: entity.SnoozeNextWeek, TimeZone: "Asia/Jakarta"}, expected: nil},
+		{name: "it should return nil when until is provided", input: TaskSnoozeIn{Until: until}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
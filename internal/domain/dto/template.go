@@ -0,0 +1,166 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// TemplateDateLayout is the layout of template anchor dates.
+const TemplateDateLayout = "2006-01-02"
+
+// TemplateTask represents a task blueprint of a template.
+type TemplateTask struct {
+	Content       string           `json:"content"`
+	Description   string           `json:"description"`
+	DueOffsetDays entity.NullInt64 `json:"due_offset_days"`
+	Children      []TemplateTask   `json:"children"`
+}
+
+func (t *TemplateTask) Validate() error {
+	switch {
+	case t.Content == "":
+		return ErrContentEmpty
+	}
+	for i := range t.Children {
+		if err := t.Children[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TemplateCreateIn represents the input of template creation.
+type TemplateCreateIn struct {
+	UserID      entity.UserID  `json:"-"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Tasks       []TemplateTask `json:"tasks"`
+}
+
+func (t *TemplateCreateIn) Validate() error {
+	switch {
+	case t.Name == "":
+		return ErrNameEmpty
+	}
+	return validateTemplateTasks(t.Tasks)
+}
+
+// TemplateCreateOut represents the output of template creation.
+type TemplateCreateOut struct {
+	ID entity.TemplateID `json:"id"`
+}
+
+// TemplateCreateFromTasksIn represents the input of template creation from existing tasks.
+type TemplateCreateFromTasksIn struct {
+	UserID      entity.UserID   `json:"-"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	TaskIDs     []entity.TaskID `json:"task_ids"`
+	Anchor      string          `json:"anchor"`
+}
+
+func (t *TemplateCreateFromTasksIn) Validate() error {
+	switch {
+	case t.Name == "":
+		return ErrNameEmpty
+	case len(t.TaskIDs) == 0:
+		return ErrTaskIDsEmpty
+	case t.Anchor != "" && !isTemplateDate(t.Anchor):
+		return ErrAnchorInvalid
+	}
+	return nil
+}
+
+// TemplateGetAllIn represents the input of templates retrieval.
+type TemplateGetAllIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// TemplateGetAllOut represents the output of templates retrieval.
+type TemplateGetAllOut struct {
+	ID          entity.TemplateID `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// TemplateGetByIDIn represents the input of template retrieval.
+type TemplateGetByIDIn struct {
+	TemplateID entity.TemplateID `json:"-"`
+	UserID     entity.UserID     `json:"-"`
+}
+
+// TemplateGetByIDOut represents the output of template retrieval.
+type TemplateGetByIDOut struct {
+	ID          entity.TemplateID `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Tasks       []TemplateTask    `json:"tasks"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
+
+// TemplateUpdateIn represents the input of template update.
+type TemplateUpdateIn struct {
+	TemplateID  entity.TemplateID `json:"-"`
+	UserID      entity.UserID     `json:"-"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Tasks       []TemplateTask    `json:"tasks"`
+}
+
+func (t *TemplateUpdateIn) Validate() error {
+	switch {
+	case t.Name == "":
+		return ErrNameEmpty
+	}
+	return validateTemplateTasks(t.Tasks)
+}
+
+// TemplateUpdateOut represents the output of template update.
+type TemplateUpdateOut struct {
+	ID entity.TemplateID `json:"id"`
+}
+
+// TemplateRemoveIn represents the input of template removal.
+type TemplateRemoveIn struct {
+	TemplateID entity.TemplateID `json:"-"`
+	UserID     entity.UserID     `json:"-"`
+}
+
+// TemplateInstantiateIn represents the input of template instantiation.
+type TemplateInstantiateIn struct {
+	TemplateID entity.TemplateID `json:"-"`
+	UserID     entity.UserID     `json:"-"`
+	Anchor     string            `json:"-"`
+	Variables  map[string]string `json:"variables"`
+}
+
+func (t *TemplateInstantiateIn) Validate() error {
+	switch {
+	case t.Anchor != "" && !isTemplateDate(t.Anchor):
+		return ErrAnchorInvalid
+	}
+	return nil
+}
+
+// TemplateInstantiateOut represents the output of template instantiation.
+type TemplateInstantiateOut struct {
+	TaskIDs []entity.TaskID `json:"task_ids"`
+}
+
+func validateTemplateTasks(tasks []TemplateTask) error {
+	for i := range tasks {
+		if err := tasks[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isTemplateDate(value string) bool {
+	_, err := time.Parse(TemplateDateLayout, value)
+	return err == nil
+}
@@ -0,0 +1,96 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type TemplateDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestTemplateDTOSuite(t *testing.T) {
+	suite.Run(t, new(TemplateDTOTestSuite))
+}
+
+func (s *TemplateDTOTestSuite) TestTemplateCreateIn() {
+	tests := []struct {
+		name     string
+		input    TemplateCreateIn
+		expected error
+	}{
+		{name: "it should return error when name is empty", input: TemplateCreateIn{}, expected: ErrNameEmpty},
+		{name: "it should return error when task content is empty", input: TemplateCreateIn{Name: "Onboarding", Tasks: []TemplateTask{{}}}, expected: ErrContentEmpty},
+		{name: "it should return error when nested task content is empty", input: TemplateCreateIn{Name: "Onboarding", Tasks: []TemplateTask{{Content: "Laptop", Children: []TemplateTask{{}}}}}, expected: ErrContentEmpty},
+		{name: "it should return nil when all fields are valid", input: TemplateCreateIn{Name: "Onboarding", Tasks: []TemplateTask{{Content: "Laptop", Children: []TemplateTask{{Content: "Charger"}}}}}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *TemplateDTOTestSuite) TestTemplateCreateFromTasksIn() {
+	tests := []struct {
+		name     string
+		input    TemplateCreateFromTasksIn
+		expected error
+	}{
+		{name: "it should return error when name is empty", input: TemplateCreateFromTasksIn{}, expected: ErrNameEmpty},
+		{name: "it should return error when task ids is empty", input: TemplateCreateFromTasksIn{Name: "Release"}, expected: ErrTaskIDsEmpty},
+		{name: "it should return error when anchor is invalid", input: TemplateCreateFromTasksIn{Name: "Release", TaskIDs: []entity.TaskID{"task-xxxxx"}, Anchor: "01-11-2026"}, expected: ErrAnchorInvalid},
+		{name: "it should return nil when anchor is empty", input: TemplateCreateFromTasksIn{Name: "Release", TaskIDs: []entity.TaskID{"task-xxxxx"}}, expected: nil},
+		{name: "it should return nil when all fields are valid", input: TemplateCreateFromTasksIn{Name: "Release", TaskIDs: []entity.TaskID{"task-xxxxx"}, Anchor: "2026-11-01"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *TemplateDTOTestSuite) TestTemplateUpdateIn() {
+	tests := []struct {
+		name     string
+		input    TemplateUpdateIn
+		expected error
+	}{
+		{name: "it should return error when name is empty", input: TemplateUpdateIn{}, expected: ErrNameEmpty},
+		{name: "it should return error when task content is empty", input: TemplateUpdateIn{Name: "Onboarding", Tasks: []TemplateTask{{}}}, expected: ErrContentEmpty},
+		{name: "it should return nil when all fields are valid", input: TemplateUpdateIn{Name: "Onboarding", Tasks: []TemplateTask{{Content: "Laptop"}}}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *TemplateDTOTestSuite) TestTemplateInstantiateIn() {
+	tests := []struct {
+		name     string
+		input    TemplateInstantiateIn
+		expected error
+	}{
+		{name: "it should return error when anchor is invalid", input: TemplateInstantiateIn{Anchor: "tomorrow"}, expected: ErrAnchorInvalid},
+		{name: "it should return nil when anchor is empty", input: TemplateInstantiateIn{}, expected: nil},
+		{name: "it should return nil when anchor is valid", input: TemplateInstantiateIn{Anchor: "2026-11-01"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package dto
+
+import "github.com/edwintantawi/taskit/internal/domain/entity"
+
+// TwoFactorSetupIn represent two-factor setup input.
+type TwoFactorSetupIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// TwoFactorSetupOut represent two-factor setup output.
+// QRCode is a PNG image of the uri as a data url, ready to be shown by the web app.
+type TwoFactorSetupOut struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+	QRCode string `json:"qr_code"`
+}
+
+// TwoFactorEnableIn represent two-factor enable input.
+type TwoFactorEnableIn struct {
+	UserID    entity.UserID `json:"-"`
+	Code      string        `json:"code"`
+	UserAgent string        `json:"-"`
+	IPAddress string        `json:"-"`
+}
+
+func (t *TwoFactorEnableIn) Validate() error {
+	switch {
+	case t.Code == "":
+		return ErrCodeEmpty
+	}
+	return nil
+}
+
+// TwoFactorEnableOut represent two-factor enable output.
+type TwoFactorEnableOut struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorDisableIn represent two-factor disable input.
+type TwoFactorDisableIn struct {
+	UserID    entity.UserID `json:"-"`
+	Password  string        `json:"password"`
+	Code      string        `json:"code"`
+	UserAgent string        `json:"-"`
+	IPAddress string        `json:"-"`
+}
+
+func (t *TwoFactorDisableIn) Validate() error {
+	switch {
+	case t.Password == "":
+		return ErrPasswordEmpty
+	case t.Code == "":
+		return ErrCodeEmpty
+	}
+	return nil
+}
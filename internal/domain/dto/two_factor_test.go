@@ -0,0 +1,52 @@
+package dto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TwoFactorDTOTestSuite struct {
+	suite.Suite
+}
+
+func TestTwoFactorDTOSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorDTOTestSuite))
+}
+
+func (s *TwoFactorDTOTestSuite) TestTwoFactorEnableIn() {
+	tests := []struct {
+		name     string
+		input    TwoFactorEnableIn
+		expected error
+	}{
+		{name: "it should return error when code is empty", input: TwoFactorEnableIn{}, expected: ErrCodeEmpty},
+		{name: "it should return nil when all fields are valid", input: TwoFactorEnableIn{Code: "123456"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *TwoFactorDTOTestSuite) TestTwoFactorDisableIn() {
+	tests := []struct {
+		name     string
+		input    TwoFactorDisableIn
+		expected error
+	}{
+		{name: "it should return error when password is empty", input: TwoFactorDisableIn{Code: "123456"}, expected: ErrPasswordEmpty},
+		{name: "it should return error when code is empty", input: TwoFactorDisableIn{Password: "secret_password"}, expected: ErrCodeEmpty},
+		{name: "it should return nil when all fields are valid", input: TwoFactorDisableIn{Password: "secret_password", Code: "123456"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
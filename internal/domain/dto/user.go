@@ -28,3 +28,98 @@ type UserCreateOut struct {
 	ID    entity.UserID `json:"id"`
 	Email string        `json:"email"`
 }
+
+// UserVerifyIn represents the input of email verification.
+type UserVerifyIn struct {
+	Token string `json:"token"`
+}
+
+func (u *UserVerifyIn) Validate() error {
+	switch {
+	case u.Token == "":
+		return ErrTokenEmpty
+	}
+	return nil
+}
+
+// UserResendVerificationIn represents the input of resending the verification email.
+type UserResendVerificationIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// UserEnsureVerifiedIn represents the input of checking that a user is verified.
+type UserEnsureVerifiedIn struct {
+	UserID entity.UserID `json:"-"`
+}
+
+// UserEnsureRoleIn represents the input of checking that a user has one of the roles.
+type UserEnsureRoleIn struct {
+	UserID entity.UserID `json:"-"`
+	Roles  []entity.Role `json:"-"`
+}
+
+// UserUpdateIn represents the input of updating the profile of a user.
+type UserUpdateIn struct {
+	UserID entity.UserID `json:"-"`
+	Name   string        `json:"name"`
+	Email  string        `json:"email"`
+}
+
+func (u *UserUpdateIn) Validate() error {
+	switch {
+	case u.Email == "":
+		return ErrEmailEmpty
+	case u.Name == "":
+		return ErrNameEmpty
+	}
+	return nil
+}
+
+// UserUpdateOut represents the output of updating the profile of a user.
+type UserUpdateOut struct {
+	ID            entity.UserID `json:"id"`
+	Name          string        `json:"name"`
+	Email         string        `json:"email"`
+	EmailVerified bool          `json:"email_verified"`
+}
+
+// UserChangePasswordIn represents the input of changing the password of a user.
+// SessionID is the current session, which is kept signed in.
+type UserChangePasswordIn struct {
+	UserID          entity.UserID `json:"-"`
+	SessionID       entity.AuthID `json:"-"`
+	CurrentPassword string        `json:"current_password"`
+	NewPassword     string        `json:"new_password"`
+	UserAgent       string        `json:"-"`
+	IPAddress       string        `json:"-"`
+}
+
+func (u *UserChangePasswordIn) Validate() error {
+	switch {
+	case u.CurrentPassword == "":
+		return ErrCurrentPasswordEmpty
+	case u.NewPassword == "":
+		return ErrNewPasswordEmpty
+	}
+	return nil
+}
+
+// UserChangePasswordOut represents the output of changing the password of a user.
+// AccessToken replaces the access token of the current session, which the password change revoked.
+type UserChangePasswordOut struct {
+	AccessToken string `json:"access_token"`
+}
+
+// UserDeleteIn represents the input of deleting the account of a user.
+type UserDeleteIn struct {
+	UserID   entity.UserID `json:"-"`
+	Password string        `json:"password"`
+}
+
+func (u *UserDeleteIn) Validate() error {
+	switch {
+	case u.Password == "":
+		return ErrPasswordEmpty
+	}
+	return nil
+}
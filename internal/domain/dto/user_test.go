@@ -33,3 +33,77 @@ func (s *UserDTOTestSuite) TestUserCreateIn() {
 		})
 	}
 }
+
+func (s *UserDTOTestSuite) TestUserVerifyIn() {
+	tests := []struct {
+		name     string
+		input    UserVerifyIn
+		expected error
+	}{
+		{name: "it should return error when token is empty", input: UserVerifyIn{}, expected: ErrTokenEmpty},
+		{name: "it should return nil when all fields are valid", input: UserVerifyIn{Token: "raw_token"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *UserDTOTestSuite) TestUserUpdateIn() {
+	tests := []struct {
+		name     string
+		input    UserUpdateIn
+		expected error
+	}{
+		{name: "it should return error when email is empty", input: UserUpdateIn{}, expected: ErrEmailEmpty},
+		{name: "it should return error when name is empty", input: UserUpdateIn{Email: "gopher@go.dev"}, expected: ErrNameEmpty},
+		{name: "it should return nil when all fields are valid", input: UserUpdateIn{Email: "gopher@go.dev", Name: "Gopher"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *UserDTOTestSuite) TestUserChangePasswordIn() {
+	tests := []struct {
+		name     string
+		input    UserChangePasswordIn
+		expected error
+	}{
+		{name: "it should return error when current password is empty", input: UserChangePasswordIn{}, expected: ErrCurrentPasswordEmpty},
+		{name: "it should return error when new password is empty", input: UserChangePasswordIn{CurrentPassword: "123456"}, expected: ErrNewPasswordEmpty},
+		{name: "it should return nil when all fields are valid", input: UserChangePasswordIn{CurrentPassword: "123456", NewPassword: "654321"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
+
+func (s *UserDTOTestSuite) TestUserDeleteIn() {
+	tests := []struct {
+		name     string
+		input    UserDeleteIn
+		expected error
+	}{
+		{name: "it should return error when password is empty", input: UserDeleteIn{}, expected: ErrPasswordEmpty},
+		{name: "it should return nil when all fields are valid", input: UserDeleteIn{Password: "123456"}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			err := test.input.Validate()
+			s.Equal(test.expected, err)
+		})
+	}
+}
@@ -8,20 +8,53 @@ import (
 
 // Auth entity errors.
 var (
-	ErrAuthTokenExpired = errors.New("auth.entity.token.expired")
+	ErrAuthTokenExpired   = errors.New("auth.entity.token.expired")
+	ErrAccessTokenRevoked = errors.New("auth.entity.access_token_revoked")
 )
 
 type AuthID string
 type authUserIDKey string
+type authSessionIDKey string
+type authTokenKey string
 
 // AuthUserIDKey is the key for the user_id value in the context.
 const AuthUserIDKey = authUserIDKey("user_id")
 
+// AuthSessionIDKey is the key for the session_id value in the context.
+const AuthSessionIDKey = authSessionIDKey("session_id")
+
+// AuthTokenKey is the key for the access token claims in the context.
+const AuthTokenKey = authTokenKey("token")
+
 // Auth represents an authentication in the system.
+// Every auth is a session of a device, identified by its refresh token.
+// Token holds the raw refresh token only while it is issued, the database keeps its hash.
 type Auth struct {
-	ID        AuthID
+	ID         AuthID
+	UserID     UserID
+	Token      string
+	ExpiresAt  time.Time
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  time.Time
+	LastUsedAt time.Time
+}
+
+// AuthClaims represents the claims carried by an access token.
+// TokenID is the jti claim, an access token is revoked by it.
+type AuthClaims struct {
+	TokenID   string
+	UserID    UserID
+	SessionID AuthID
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// RevokedToken represents an access token revoked before it expires.
+// It is only kept until the token expires, an expired token is rejected anyway.
+type RevokedToken struct {
+	TokenID   string
 	UserID    UserID
-	Token     string
 	ExpiresAt time.Time
 }
 
@@ -33,6 +66,16 @@ func (a *Auth) VerifyTokenExpires() error {
 	return nil
 }
 
+// VerifyIssuedAfter checks the token was not issued before the revocation watermark of its user.
+// Issue times only have a second precision, so a token issued within the second of the watermark is kept,
+// otherwise a token issued right after a password change would already be revoked.
+func (c *AuthClaims) VerifyIssuedAfter(watermark time.Time) error {
+	if c.IssuedAt.Before(watermark.Truncate(time.Second)) {
+		return ErrAccessTokenRevoked
+	}
+	return nil
+}
+
 // GetAuthContext get the AuthUserIDKey from the context.
 func GetAuthContext(ctx context.Context) UserID {
 	userID := ctx.Value(AuthUserIDKey)
@@ -41,3 +84,30 @@ func GetAuthContext(ctx context.Context) UserID {
 	}
 	return userID.(UserID)
 }
+
+// GetAuthSessionContext get the AuthSessionIDKey from the context.
+// It returns an empty id when the request is not tied to a session.
+func GetAuthSessionContext(ctx context.Context) AuthID {
+	sessionID, _ := ctx.Value(AuthSessionIDKey).(AuthID)
+	return sessionID
+}
+
+// GetAuthTokenContext get the AuthTokenKey from the context.
+// It returns empty claims when the request is not authenticated by an access token.
+func GetAuthTokenContext(ctx context.Context) AuthClaims {
+	claims, _ := ctx.Value(AuthTokenKey).(AuthClaims)
+	return claims
+}
+
+// JWK represents a public key of the JSON Web Key Set other services verify access tokens with.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
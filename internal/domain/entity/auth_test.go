@@ -33,6 +33,28 @@ func (s *AuthEntityTestSuite) TestVerifyTokenExpires() {
 	}
 }
 
+func (s *AuthEntityTestSuite) TestVerifyIssuedAfter() {
+	watermark := time.Date(2023, 1, 2, 15, 4, 5, 500, time.UTC)
+
+	tests := []struct {
+		name      string
+		input     AuthClaims
+		watermark time.Time
+		expected  error
+	}{
+		{name: "it should return nil when user has no watermark", input: AuthClaims{IssuedAt: watermark}, watermark: time.Time{}, expected: nil},
+		{name: "it should return error when token is issued before the watermark", input: AuthClaims{IssuedAt: watermark.Add(-1 * time.Second)}, watermark: watermark, expected: ErrAccessTokenRevoked},
+		{name: "it should return nil when token is issued within the second of the watermark", input: AuthClaims{IssuedAt: watermark.Truncate(time.Second)}, watermark: watermark, expected: nil},
+		{name: "it should return nil when token is issued after the watermark", input: AuthClaims{IssuedAt: watermark.Add(time.Minute)}, watermark: watermark, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyIssuedAfter(test.watermark))
+		})
+	}
+}
+
 func (s *AuthEntityTestSuite) TestGetAuthContext() {
 	s.Run("it should panic when auth context is not set", func() {
 		s.Panics(func() {
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type ChecklistItemID string
+
+// ChecklistItem represents a lightweight item to check off inside a task, items are listed by position.
+type ChecklistItem struct {
+	ID        ChecklistItemID
+	TaskID    TaskID
+	Text      string
+	IsChecked bool
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
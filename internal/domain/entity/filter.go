@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+type FilterID string
+
+// Filter represents a saved task query, filters are listed by position.
+type Filter struct {
+	ID        FilterID
+	UserID    UserID
+	Name      string
+	Query     string
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Identity entity errors.
+var (
+	ErrOIDCStateExpired = errors.New("identity.entity.state_expired")
+)
+
+type UserIdentityID string
+
+// UserIdentity links a user to an account at an external OpenID Connect provider.
+type UserIdentity struct {
+	ID        UserIdentityID
+	UserID    UserID
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+type OIDCStateID string
+
+// OIDCState represents an OpenID Connect login waiting for the provider callback.
+// Only the hash of the state is kept, the raw state travels through the browser.
+type OIDCState struct {
+	ID           OIDCStateID
+	Provider     string
+	StateHash    string
+	Nonce        string
+	CodeVerifier string
+	ExpiresAt    time.Time
+	CreatedAt    time.Time
+}
+
+// VerifyExpires checks if the login attempt has expired.
+func (s *OIDCState) VerifyExpires() error {
+	if s.ExpiresAt.Before(time.Now()) {
+		return ErrOIDCStateExpired
+	}
+	return nil
+}
+
+// OIDCClaims represents the verified claims of an ID token.
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IdentityEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestIdentityEntitySuite(t *testing.T) {
+	suite.Run(t, new(IdentityEntityTestSuite))
+}
+
+func (s *IdentityEntityTestSuite) TestVerifyExpires() {
+	tests := []struct {
+		name     string
+		input    OIDCState
+		expected error
+	}{
+		{name: "it should return error when state is expired", input: OIDCState{ExpiresAt: time.Now().Add(-1 * time.Minute)}, expected: ErrOIDCStateExpired},
+		{name: "it should return nil when state is not expired", input: OIDCState{ExpiresAt: time.Now().Add(1 * time.Minute)}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyExpires())
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+type LoginAttemptID string
+type LoginAttemptScope string
+
+// Login attempt scopes, failed logins are counted per account and per ip address,
+// and so are password reset and login link requests, apart from them.
+const (
+	LoginAttemptScopeAccount          LoginAttemptScope = "account"
+	LoginAttemptScopeIP               LoginAttemptScope = "ip"
+	LoginAttemptScopeResetAccount     LoginAttemptScope = "reset_account"
+	LoginAttemptScopeResetIP          LoginAttemptScope = "reset_ip"
+	LoginAttemptScopeMagicLinkAccount LoginAttemptScope = "magic_link_account"
+	LoginAttemptScopeMagicLinkIP      LoginAttemptScope = "magic_link_ip"
+)
+
+// LoginAttemptRetention is how long failed login attempts are kept,
+// it outlasts every login throttle window so deleting older ones never lifts a throttle.
+const LoginAttemptRetention = 24 * time.Hour
+
+// LoginAttempt represents a failed login against an account or from an ip address.
+type LoginAttempt struct {
+	ID         LoginAttemptID
+	Scope      LoginAttemptScope
+	Identifier string
+	CreatedAt  time.Time
+}
+
+// LoginFailures summarizes the failed login attempts of one scope and identifier.
+type LoginFailures struct {
+	Count        int
+	LastFailedAt time.Time
+}
+
+// LoginThrottle describes how failed logins slow down the next attempts.
+// Failures within Window are counted, the first Free failures cost nothing,
+// then every failure doubles the delay from BaseDelay up to MaxDelay,
+// until Lockout failures lock further attempts for LockoutDuration.
+type LoginThrottle struct {
+	Window          time.Duration
+	Free            int
+	BaseDelay       time.Duration
+	MaxDelay        time.Duration
+	Lockout         int
+	LockoutDuration time.Duration
+}
+
+// RetryAfter return how long to wait until the next attempt is allowed, zero when it is allowed now.
+func (t LoginThrottle) RetryAfter(f LoginFailures, now time.Time) time.Duration {
+	if f.Count <= t.Free {
+		return 0
+	}
+
+	var delay time.Duration
+	if f.Count >= t.Lockout {
+		delay = t.LockoutDuration
+	} else {
+		delay = t.MaxDelay
+		if shift := f.Count - t.Free - 1; shift < 32 && t.BaseDelay<<shift < t.MaxDelay {
+			delay = t.BaseDelay << shift
+		}
+	}
+
+	if wait := f.LastFailedAt.Add(delay).Sub(now); wait > 0 {
+		return wait
+	}
+	return 0
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LoginAttemptEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestLoginAttemptEntitySuite(t *testing.T) {
+	suite.Run(t, new(LoginAttemptEntityTestSuite))
+}
+
+func (s *LoginAttemptEntityTestSuite) TestRetryAfter() {
+	throttle := LoginThrottle{Window: time.Hour, Free: 3, BaseDelay: time.Second, MaxDelay: 8 * time.Second, Lockout: 10, LockoutDuration: 15 * time.Minute}
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		input    LoginFailures
+		expected time.Duration
+	}{
+		{name: "it should return zero when there are no failures", input: LoginFailures{}, expected: 0},
+		{name: "it should return zero when failures are within the free attempts", input: LoginFailures{Count: 3, LastFailedAt: now}, expected: 0},
+		{name: "it should return the base delay after the first failure over the free attempts", input: LoginFailures{Count: 4, LastFailedAt: now}, expected: time.Second},
+		{name: "it should double the delay for every further failure", input: LoginFailures{Count: 6, LastFailedAt: now}, expected: 4 * time.Second},
+		{name: "it should cap the delay at the max delay", input: LoginFailures{Count: 9, LastFailedAt: now}, expected: 8 * time.Second},
+		{name: "it should lock out when failures reach the lockout", input: LoginFailures{Count: 10, LastFailedAt: now}, expected: 15 * time.Minute},
+		{name: "it should subtract the time passed since the last failure", input: LoginFailures{Count: 10, LastFailedAt: now.Add(-5 * time.Minute)}, expected: 10 * time.Minute},
+		{name: "it should return zero when the delay has passed", input: LoginFailures{Count: 6, LastFailedAt: now.Add(-time.Minute)}, expected: 0},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, throttle.RetryAfter(test.input, now))
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type LoginMethod string
+
+// Login methods an administrator can turn on and off.
+const (
+	LoginMethodPassword  LoginMethod = "password"
+	LoginMethodMagicLink LoginMethod = "magic_link"
+)
+
+// LoginMethodSetting represents whether users can log in with a login method.
+type LoginMethodSetting struct {
+	Method    LoginMethod
+	Enabled   bool
+	UpdatedAt time.Time
+}
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Magic link entity errors.
+var (
+	ErrMagicLinkTokenExpired  = errors.New("magic_link.entity.token_expired")
+	ErrMagicLinkNonceMismatch = errors.New("magic_link.entity.nonce_mismatch")
+)
+
+type MagicLinkID string
+
+// MagicLink represents a pending passwordless login.
+// Only the hashes of the token and of the optional browser nonce are kept,
+// the raw token is mailed to the user and the raw nonce is set as a cookie.
+type MagicLink struct {
+	ID        MagicLinkID
+	UserID    UserID
+	TokenHash string
+	NonceHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// VerifyTokenExpires checks if the magic link token has expired.
+func (m *MagicLink) VerifyTokenExpires() error {
+	if m.ExpiresAt.Before(time.Now()) {
+		return ErrMagicLinkTokenExpired
+	}
+	return nil
+}
+
+// VerifyNonce checks a magic link bound to a browser is confirmed with the nonce of that browser.
+// Links requested without binding accept any nonce.
+func (m *MagicLink) VerifyNonce(nonceHash string) error {
+	if m.NonceHash != "" && m.NonceHash != nonceHash {
+		return ErrMagicLinkNonceMismatch
+	}
+	return nil
+}
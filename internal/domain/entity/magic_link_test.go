@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MagicLinkEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestMagicLinkEntitySuite(t *testing.T) {
+	suite.Run(t, new(MagicLinkEntityTestSuite))
+}
+
+func (s *MagicLinkEntityTestSuite) TestVerifyTokenExpires() {
+	tests := []struct {
+		name     string
+		input    MagicLink
+		expected error
+	}{
+		{name: "it should return error when magic link is expired", input: MagicLink{ExpiresAt: time.Now().Add(-1 * time.Minute)}, expected: ErrMagicLinkTokenExpired},
+		{name: "it should return nil when magic link is not expired", input: MagicLink{ExpiresAt: time.Now().Add(1 * time.Minute)}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyTokenExpires())
+		})
+	}
+}
+
+func (s *MagicLinkEntityTestSuite) TestVerifyNonce() {
+	tests := []struct {
+		name      string
+		input     MagicLink
+		nonceHash string
+		expected  error
+	}{
+		{name: "it should return nil when magic link is not bound to a browser", input: MagicLink{}, nonceHash: "", expected: nil},
+		{name: "it should return nil when nonce matches the bound browser", input: MagicLink{NonceHash: "nonce_hash"}, nonceHash: "nonce_hash", expected: nil},
+		{name: "it should return error when nonce is missing", input: MagicLink{NonceHash: "nonce_hash"}, nonceHash: "", expected: ErrMagicLinkNonceMismatch},
+		{name: "it should return error when nonce belongs to another browser", input: MagicLink{NonceHash: "nonce_hash"}, nonceHash: "other_nonce_hash", expected: ErrMagicLinkNonceMismatch},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyNonce(test.nonceHash))
+		})
+	}
+}
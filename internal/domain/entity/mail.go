@@ -0,0 +1,17 @@
+package entity
+
+// MailTemplate is the name of a template used to render a mail.
+type MailTemplate string
+
+const (
+	MailTemplateVerifyEmail   MailTemplate = "verify_email"
+	MailTemplateResetPassword MailTemplate = "reset_password"
+	MailTemplateMagicLink     MailTemplate = "magic_link"
+)
+
+// Mail represents an email message that is rendered from a template.
+type Mail struct {
+	To       string
+	Template MailTemplate
+	Data     map[string]string
+}
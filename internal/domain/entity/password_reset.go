@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Password reset entity errors.
+var (
+	ErrPasswordResetTokenExpired = errors.New("password_reset.entity.token_expired")
+)
+
+type PasswordResetID string
+
+// PasswordReset represents a pending password reset.
+// Only the hash of the token is kept, the raw token is sent to the user.
+type PasswordReset struct {
+	ID        PasswordResetID
+	UserID    UserID
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// VerifyTokenExpires checks if the password reset token has expired.
+func (p *PasswordReset) VerifyTokenExpires() error {
+	if p.ExpiresAt.Before(time.Now()) {
+		return ErrPasswordResetTokenExpired
+	}
+	return nil
+}
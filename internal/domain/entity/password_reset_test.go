@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PasswordResetEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestPasswordResetEntitySuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetEntityTestSuite))
+}
+
+func (s *PasswordResetEntityTestSuite) TestVerifyTokenExpires() {
+	tests := []struct {
+		name     string
+		input    PasswordReset
+		expected error
+	}{
+		{name: "it should return error when password reset is expired", input: PasswordReset{ExpiresAt: time.Now().Add(-1 * time.Minute)}, expected: ErrPasswordResetTokenExpired},
+		{name: "it should return nil when password reset is not expired", input: PasswordReset{ExpiresAt: time.Now().Add(1 * time.Minute)}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyTokenExpires())
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PersonalTokenEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestPersonalTokenEntitySuite(t *testing.T) {
+	suite.Run(t, new(PersonalTokenEntityTestSuite))
+}
+
+func (s *PersonalTokenEntityTestSuite) TestVerifyExpires() {
+	tests := []struct {
+		name     string
+		input    PersonalToken
+		expected error
+	}{
+		{name: "it should return error when token is expired", input: PersonalToken{ExpiresAt: NullTime{sql.NullTime{Time: time.Now().Add(-1 * time.Minute), Valid: true}}}, expected: ErrPersonalTokenExpired},
+		{name: "it should return nil when token is not expired", input: PersonalToken{ExpiresAt: NullTime{sql.NullTime{Time: time.Now().Add(1 * time.Minute), Valid: true}}}, expected: nil},
+		{name: "it should return nil when token has no expiry", input: PersonalToken{}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyExpires())
+		})
+	}
+}
+
+func (s *PersonalTokenEntityTestSuite) TestParseScopes() {
+	type expected struct {
+		scopes []Scope
+		err    error
+	}
+	tests := []struct {
+		name     string
+		input    []string
+		expected expected
+	}{
+		{name: "it should return error when scope is unknown", input: []string{"tasks:read", "users:write"}, expected: expected{scopes: nil, err: ErrScopeInvalid}},
+		{name: "it should return scopes without duplicates when scopes are known", input: []string{"tasks:read", "tasks:write", "tasks:read"}, expected: expected{scopes: []Scope{ScopeTasksRead, ScopeTasksWrite}, err: nil}},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			scopes, err := ParseScopes(test.input)
+			s.Equal(test.expected.err, err)
+			s.Equal(test.expected.scopes, scopes)
+		})
+	}
+}
+
+func (s *PersonalTokenEntityTestSuite) TestHasScope() {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected bool
+	}{
+		{name: "it should return true when request is authenticated by a session", ctx: context.Background(), expected: true},
+		{name: "it should return true when token is granted the scope", ctx: context.WithValue(context.Background(), AuthScopesKey, []Scope{ScopeTasksRead}), expected: true},
+		{name: "it should return false when token is not granted the scope", ctx: context.WithValue(context.Background(), AuthScopesKey, []Scope{ScopeTasksWrite}), expected: false},
+		{name: "it should return false when token is granted no scope", ctx: context.WithValue(context.Background(), AuthScopesKey, []Scope{}), expected: false},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, HasScope(test.ctx, ScopeTasksRead))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"database/sql"
 	"encoding/json"
+	"time"
 )
 
 // nullBytes represent the bytes for null.
@@ -32,3 +33,34 @@ func (t NullTime) MarshalJSON() ([]byte, error) {
 	}
 	return json.Marshal(t.Time)
 }
+
+// NullInt64 that may be null. NullInt64 embed sql.NullInt64 and implement json Unmarshaler and Marshaler
+type NullInt64 struct {
+	sql.NullInt64
+}
+
+func (i *NullInt64) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, nullBytes) {
+		i.Valid = false
+		return nil
+	}
+	if err := json.Unmarshal(data, &i.Int64); err != nil {
+		return err
+	}
+	i.Valid = true
+	return nil
+}
+
+func (i NullInt64) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return nullBytes, nil
+	}
+	return json.Marshal(i.Int64)
+}
+
+// DaysBetween count calendar days from a date to another date, ignoring the time of day.
+func DaysBetween(from, to time.Time) int {
+	fromDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
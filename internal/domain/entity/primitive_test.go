@@ -84,3 +84,62 @@ func (s *PrimitiveTestSuite) TestNullTimeMarshalJSON() {
 		s.Equal(fmt.Sprintf("\"%s\"", currentTime.Format(time.RFC3339Nano)), string(r))
 	})
 }
+
+func (s *PrimitiveTestSuite) TestNullInt64UnmarshalJSON() {
+	s.Run("it should return error when fail to unmarshal with invalid json", func() {
+		rawJson := `"seven"`
+		var number NullInt64
+		err := json.Unmarshal([]byte(rawJson), &number)
+		s.Error(err)
+		s.False(number.Valid)
+		s.Empty(number.Int64)
+	})
+
+	s.Run("it should successfully unmarshal and return valid false and number is zero value", func() {
+		rawJson := `null`
+		var number NullInt64
+		err := json.Unmarshal([]byte(rawJson), &number)
+		s.NoError(err)
+		s.False(number.Valid)
+		s.Empty(number.Int64)
+	})
+
+	s.Run("it should successfully unmarshal and return valid true and number is actual number form json", func() {
+		rawJson := `-7`
+		var number NullInt64
+		err := json.Unmarshal([]byte(rawJson), &number)
+		s.NoError(err)
+		s.True(number.Valid)
+		s.Equal(int64(-7), number.Int64)
+	})
+}
+
+func (s *PrimitiveTestSuite) TestNullInt64MarshalJSON() {
+	s.Run("it should successfully marshal and return json null when not valid", func() {
+		number := NullInt64{NullInt64: sql.NullInt64{Int64: 0, Valid: false}}
+		r, err := json.Marshal(number)
+		s.NoError(err)
+		s.Equal("null", string(r))
+	})
+
+	s.Run("it should successfully marshal and return json number correctly", func() {
+		number := NullInt64{NullInt64: sql.NullInt64{Int64: 14, Valid: true}}
+		r, err := json.Marshal(number)
+		s.NoError(err)
+		s.Equal("14", string(r))
+	})
+}
+
+func (s *PrimitiveTestSuite) TestDaysBetween() {
+	s.Run("it should count calendar days ignoring the time of day", func() {
+		from := time.Date(2023, 3, 1, 23, 0, 0, 0, time.UTC)
+		to := time.Date(2023, 3, 3, 1, 0, 0, 0, time.UTC)
+		s.Equal(2, DaysBetween(from, to))
+	})
+
+	s.Run("it should return negative days when to is before from", func() {
+		from := time.Date(2023, 3, 3, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2023, 2, 28, 12, 0, 0, 0, time.UTC)
+		s.Equal(-3, DaysBetween(from, to))
+	})
+}
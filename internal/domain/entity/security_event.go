@@ -0,0 +1,45 @@
+package entity
+
+import "time"
+
+type SecurityEventID string
+type SecurityEventType string
+
+// Security event types.
+const (
+	SecurityEventLogin                   SecurityEventType = "login"
+	SecurityEventLoginFailed             SecurityEventType = "login_failed"
+	SecurityEventRefresh                 SecurityEventType = "refresh"
+	SecurityEventLogout                  SecurityEventType = "logout"
+	SecurityEventPasswordChange          SecurityEventType = "password_change"
+	SecurityEventPasswordReset           SecurityEventType = "password_reset"
+	SecurityEventRefreshTokenReuse       SecurityEventType = "refresh_token_reuse"
+	SecurityEventTwoFactorEnable         SecurityEventType = "two_factor_enable"
+	SecurityEventTwoFactorDisable        SecurityEventType = "two_factor_disable"
+	SecurityEventTwoFactorReset          SecurityEventType = "two_factor_reset"
+	SecurityEventServiceAccountCreate    SecurityEventType = "service_account_create"
+	SecurityEventServiceAccountKeyRotate SecurityEventType = "service_account_key_rotate"
+	SecurityEventServiceAccountKeyRevoke SecurityEventType = "service_account_key_revoke"
+	SecurityEventServiceAccountRequest   SecurityEventType = "service_account_request"
+)
+
+// SecurityEvent represents a security relevant activity on a user account.
+// Detail describes the activity further when its type alone is not enough, like the request a service account made.
+type SecurityEvent struct {
+	ID        SecurityEventID
+	UserID    UserID
+	Type      SecurityEventType
+	UserAgent string
+	IPAddress string
+	Detail    string
+	CreatedAt time.Time
+}
+
+// SecurityEventFilter narrows down the security events to list, zero fields match every event.
+type SecurityEventFilter struct {
+	UserID    UserID
+	Type      SecurityEventType
+	IPAddress string
+	Since     time.Time
+	Until     time.Time
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Service account entity errors.
+var (
+	ErrServiceAccountKeyExpired = errors.New("service_account.entity.key_expired")
+	ErrServiceAccountForbidden  = errors.New("service_account.entity.service_account_forbidden")
+)
+
+// ServiceAccountKeyPrefix marks a bearer token as a service account API key rather than a JWT.
+const ServiceAccountKeyPrefix = "tks_"
+
+type ServiceAccountKeyID string
+type authServiceAccountKey string
+
+// AuthServiceAccountKey is the key in the context marking a request authenticated by a service account.
+const AuthServiceAccountKey = authServiceAccountKey("service_account")
+
+// ServiceAccountKey represents an API key a service account authenticates with.
+// Only the hash of the key is kept, the raw key is shown once when it is issued.
+// The current key never expires, a rotated key expires once the overlap window is over.
+type ServiceAccountKey struct {
+	ID         ServiceAccountKeyID
+	UserID     UserID
+	KeyHash    string
+	ExpiresAt  NullTime
+	LastUsedAt NullTime
+	CreatedAt  time.Time
+}
+
+// VerifyExpires checks if the key has expired, keys without expiry never expire.
+func (k *ServiceAccountKey) VerifyExpires() error {
+	if k.ExpiresAt.Valid && k.ExpiresAt.Time.Before(time.Now()) {
+		return ErrServiceAccountKeyExpired
+	}
+	return nil
+}
+
+// IsServiceAccountContext reports whether the request in the context is authenticated by a service account.
+func IsServiceAccountContext(ctx context.Context) bool {
+	isServiceAccount, _ := ctx.Value(AuthServiceAccountKey).(bool)
+	return isServiceAccount
+}
@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceAccountEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestServiceAccountEntitySuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountEntityTestSuite))
+}
+
+func (s *ServiceAccountEntityTestSuite) TestVerifyExpires() {
+	tests := []struct {
+		name     string
+		input    ServiceAccountKey
+		expected error
+	}{
+		{name: "it should return error when key is expired", input: ServiceAccountKey{ExpiresAt: NullTime{sql.NullTime{Time: time.Now().Add(-1 * time.Minute), Valid: true}}}, expected: ErrServiceAccountKeyExpired},
+		{name: "it should return nil when key is within the overlap window", input: ServiceAccountKey{ExpiresAt: NullTime{sql.NullTime{Time: time.Now().Add(1 * time.Minute), Valid: true}}}, expected: nil},
+		{name: "it should return nil when key has no expiry", input: ServiceAccountKey{}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyExpires())
+		})
+	}
+}
+
+func (s *ServiceAccountEntityTestSuite) TestIsServiceAccountContext() {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected bool
+	}{
+		{name: "it should return false when request is not authenticated by a service account", ctx: context.Background(), expected: false},
+		{name: "it should return true when request is authenticated by a service account", ctx: context.WithValue(context.Background(), AuthServiceAccountKey, true), expected: true},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, IsServiceAccountContext(test.ctx))
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"database/sql"
+	"time"
+)
 
 type TaskID string
 
@@ -12,6 +15,70 @@ type Task struct {
 	Description string
 	IsCompleted bool
 	DueDate     NullTime
+	StartDate   NullTime
+	CompletedAt NullTime
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
+
+// TaskFilter represents the criteria to find the tasks of a user.
+// Query is written in the task query language and its relative dates are resolved against Now.
+// Tasks starting after Now are left out unless IncludeSnoozed is set.
+type TaskFilter struct {
+	UserID         UserID
+	Query          string
+	Now            time.Time
+	IncludeSnoozed bool
+}
+
+// SnoozePreset represents a named point in time a task can be snoozed until.
+type SnoozePreset string
+
+const (
+	SnoozeLaterToday SnoozePreset = "later_today"
+	SnoozeTomorrow   SnoozePreset = "tomorrow"
+	SnoozeNextWeek   SnoozePreset = "next_week"
+)
+
+// snoozeMorningHour is the hour of the day snoozed tasks reappear on a following day.
+const snoozeMorningHour = 9
+
+// IsValid check if the preset is known.
+func (p SnoozePreset) IsValid() bool {
+	switch p {
+	case SnoozeLaterToday, SnoozeTomorrow, SnoozeNextWeek:
+		return true
+	}
+	return false
+}
+
+// Resolve return the time the preset points to from now, in the location of now.
+// Later today is three hours from now rounded down to the hour, tomorrow is the next
+// morning and next week is the morning of the coming monday.
+func (p SnoozePreset) Resolve(now time.Time) time.Time {
+	year, month, day := now.Date()
+	switch p {
+	case SnoozeLaterToday:
+		return time.Date(year, month, day, now.Hour()+3, 0, 0, 0, now.Location())
+	case SnoozeTomorrow:
+		return time.Date(year, month, day+1, snoozeMorningHour, 0, 0, 0, now.Location())
+	case SnoozeNextWeek:
+		days := (8 - int(now.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		return time.Date(year, month, day+days, snoozeMorningHour, 0, 0, 0, now.Location())
+	}
+	return now
+}
+
+// SetCompleted changes the completion state and keeps track of when the task was completed.
+func (t *Task) SetCompleted(isCompleted bool, now time.Time) {
+	switch {
+	case isCompleted && !t.IsCompleted:
+		t.CompletedAt = NullTime{NullTime: sql.NullTime{Time: now, Valid: true}}
+	case !isCompleted:
+		t.CompletedAt = NullTime{}
+	}
+	t.IsCompleted = isCompleted
+}
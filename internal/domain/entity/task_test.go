@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TaskEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestTaskEntitySuite(t *testing.T) {
+	suite.Run(t, new(TaskEntityTestSuite))
+}
+
+func (s *TaskEntityTestSuite) TestSetCompleted() {
+	now := time.Now()
+	completedAt := NullTime{NullTime: sql.NullTime{Time: now.Add(-time.Hour), Valid: true}}
+
+	tests := []struct {
+		name        string
+		input       Task
+		isCompleted bool
+		expected    Task
+	}{
+		{name: "it should record completion time when task become completed", input: Task{}, isCompleted: true, expected: Task{IsCompleted: true, CompletedAt: NullTime{NullTime: sql.NullTime{Time: now, Valid: true}}}},
+		{name: "it should keep completion time when task is already completed", input: Task{IsCompleted: true, CompletedAt: completedAt}, isCompleted: true, expected: Task{IsCompleted: true, CompletedAt: completedAt}},
+		{name: "it should clear completion time when task become uncompleted", input: Task{IsCompleted: true, CompletedAt: completedAt}, isCompleted: false, expected: Task{}},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			test.input.SetCompleted(test.isCompleted, now)
+			s.Equal(test.expected, test.input)
+		})
+	}
+}
+
+func (s *TaskEntityTestSuite) TestSnoozePresetIsValid() {
+	s.True(SnoozeLaterToday.IsValid())
+	s.True(SnoozeTomorrow.IsValid())
+	s.True(SnoozeNextWeek.IsValid())
+	s.False(SnoozePreset("someday").IsValid())
+	s.False(SnoozePreset("").IsValid())
+}
+
+func (s *TaskEntityTestSuite) TestSnoozePresetResolve() {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	wednesday := time.Date(2026, 1, 7, 10, 30, 0, 0, jakarta)
+	sunday := time.Date(2026, 1, 11, 22, 15, 0, 0, jakarta)
+	monday := time.Date(2026, 1, 12, 8, 0, 0, 0, jakarta)
+
+	tests := []struct {
+		name     string
+		preset   SnoozePreset
+		now      time.Time
+		expected time.Time
+	}{
+		{name: "it should resolve later today to three hours later on the hour", preset: SnoozeLaterToday, now: wednesday, expected: time.Date(2026, 1, 7, 13, 0, 0, 0, jakarta)},
+		{name: "it should resolve later today past midnight to the next day", preset: SnoozeLaterToday, now: sunday, expected: time.Date(2026, 1, 12, 1, 0, 0, 0, jakarta)},
+		{name: "it should resolve tomorrow to the next morning", preset: SnoozeTomorrow, now: wednesday, expected: time.Date(2026, 1, 8, 9, 0, 0, 0, jakarta)},
+		{name: "it should resolve next week to the coming monday morning", preset: SnoozeNextWeek, now: wednesday, expected: time.Date(2026, 1, 12, 9, 0, 0, 0, jakarta)},
+		{name: "it should resolve next week on sunday to the following day", preset: SnoozeNextWeek, now: sunday, expected: time.Date(2026, 1, 12, 9, 0, 0, 0, jakarta)},
+		{name: "it should resolve next week on monday to a week later", preset: SnoozeNextWeek, now: monday, expected: time.Date(2026, 1, 19, 9, 0, 0, 0, jakarta)},
+		{name: "it should resolve unknown preset to now", preset: SnoozePreset("someday"), now: wednesday, expected: wednesday},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.preset.Resolve(test.now))
+		})
+	}
+}
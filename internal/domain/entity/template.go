@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"database/sql"
+	"errors"
+	"regexp"
+	"time"
+)
+
+// Template entity errors.
+var (
+	ErrTemplateVariableMissing = errors.New("template.entity.variable_missing")
+)
+
+var templateVariableRegex = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+
+type TemplateID string
+
+type TemplateTaskID string
+
+// Template represents a reusable tree of task blueprints.
+type Template struct {
+	ID          TemplateID
+	UserID      UserID
+	Name        string
+	Description string
+	Tasks       []TemplateTask
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TemplateTask represents a task blueprint inside a template.
+// DueOffsetDays is relative to the anchor date given on instantiation.
+type TemplateTask struct {
+	ID            TemplateTaskID
+	Content       string
+	Description   string
+	DueOffsetDays NullInt64
+	Children      []TemplateTask
+}
+
+// Instantiate creates the tasks described by the template blueprints in depth-first order.
+// Due dates are computed from the anchor date and every {{variable}} is substituted.
+func (t *Template) Instantiate(userID UserID, anchor time.Time, variables map[string]string) ([]Task, error) {
+	anchor = time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, anchor.Location())
+
+	var tasks []Task
+	var walk func(blueprints []TemplateTask) error
+	walk = func(blueprints []TemplateTask) error {
+		for _, blueprint := range blueprints {
+			content, err := RenderTemplateText(blueprint.Content, variables)
+			if err != nil {
+				return err
+			}
+			description, err := RenderTemplateText(blueprint.Description, variables)
+			if err != nil {
+				return err
+			}
+
+			task := Task{UserID: userID, Content: content, Description: description}
+			if blueprint.DueOffsetDays.Valid {
+				dueDate := anchor.AddDate(0, 0, int(blueprint.DueOffsetDays.Int64))
+				task.DueDate = NullTime{NullTime: sql.NullTime{Time: dueDate, Valid: true}}
+			}
+			tasks = append(tasks, task)
+
+			if err := walk(blueprint.Children); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := walk(t.Tasks); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+// RenderTemplateText replaces every {{variable}} in text with its value.
+func RenderTemplateText(text string, variables map[string]string) (string, error) {
+	var err error
+	result := templateVariableRegex.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariableRegex.FindStringSubmatch(match)[1]
+		value, ok := variables[name]
+		if !ok {
+			err = ErrTemplateVariableMissing
+			return match
+		}
+		return value
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TemplateEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestTemplateEntitySuite(t *testing.T) {
+	suite.Run(t, new(TemplateEntityTestSuite))
+}
+
+func (s *TemplateEntityTestSuite) TestRenderTemplateText() {
+	tests := []struct {
+		name      string
+		text      string
+		variables map[string]string
+		expected  string
+		err       error
+	}{
+		{name: "it should return text as is when there is no variable", text: "Prepare release", variables: nil, expected: "Prepare release", err: nil},
+		{name: "it should substitute all variables", text: "Release {{version}} for {{ team }}", variables: map[string]string{"version": "v1.2.0", "team": "Gopher"}, expected: "Release v1.2.0 for Gopher", err: nil},
+		{name: "it should return error when variable is missing", text: "Welcome {{name}}", variables: map[string]string{}, expected: "", err: ErrTemplateVariableMissing},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			result, err := RenderTemplateText(test.text, test.variables)
+			s.Equal(test.err, err)
+			s.Equal(test.expected, result)
+		})
+	}
+}
+
+func (s *TemplateEntityTestSuite) TestInstantiate() {
+	anchor := time.Date(2026, 11, 1, 15, 30, 0, 0, time.UTC)
+
+	s.Run("it should return error when a variable is missing", func() {
+		template := Template{Tasks: []TemplateTask{{Content: "Onboard {{name}}"}}}
+
+		tasks, err := template.Instantiate("user-xxxxx", anchor, nil)
+
+		s.Equal(ErrTemplateVariableMissing, err)
+		s.Nil(tasks)
+	})
+
+	s.Run("it should return error when a nested description variable is missing", func() {
+		template := Template{Tasks: []TemplateTask{{Content: "Onboard", Children: []TemplateTask{{Content: "Laptop", Description: "For {{name}}"}}}}}
+
+		tasks, err := template.Instantiate("user-xxxxx", anchor, nil)
+
+		s.Equal(ErrTemplateVariableMissing, err)
+		s.Nil(tasks)
+	})
+
+	s.Run("it should flatten blueprints depth first with computed due dates", func() {
+		template := Template{
+			Tasks: []TemplateTask{
+				{
+					Content:       "Onboard {{name}}",
+					Description:   "Welcome {{name}}",
+					DueOffsetDays: NullInt64{NullInt64: sql.NullInt64{Int64: 7, Valid: true}},
+					Children: []TemplateTask{
+						{Content: "Prepare laptop", DueOffsetDays: NullInt64{NullInt64: sql.NullInt64{Int64: -1, Valid: true}}},
+					},
+				},
+				{Content: "Schedule review"},
+			},
+		}
+
+		tasks, err := template.Instantiate("user-xxxxx", anchor, map[string]string{"name": "Gopher"})
+
+		s.NoError(err)
+		s.Equal([]Task{
+			{
+				UserID:      "user-xxxxx",
+				Content:     "Onboard Gopher",
+				Description: "Welcome Gopher",
+				DueDate:     NullTime{NullTime: sql.NullTime{Time: time.Date(2026, 11, 8, 0, 0, 0, 0, time.UTC), Valid: true}},
+			},
+			{
+				UserID:  "user-xxxxx",
+				Content: "Prepare laptop",
+				DueDate: NullTime{NullTime: sql.NullTime{Time: time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC), Valid: true}},
+			},
+			{
+				UserID:  "user-xxxxx",
+				Content: "Schedule review",
+			},
+		}, tasks)
+	})
+}
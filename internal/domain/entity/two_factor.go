@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// Two factor entity errors.
+var (
+	ErrMFAChallengeExpired = errors.New("two_factor.entity.challenge_expired")
+)
+
+type MFAChallengeID string
+
+// TwoFactor represents the TOTP two-factor authentication of a user.
+// It is pending until the user confirms the secret with a first code.
+type TwoFactor struct {
+	UserID       UserID
+	Secret       string
+	EnabledAt    NullTime
+	LastUsedStep int64
+	CreatedAt    time.Time
+}
+
+// IsEnabled report whether the two-factor authentication has been confirmed.
+func (t *TwoFactor) IsEnabled() bool {
+	return t.EnabledAt.Valid
+}
+
+// MFAChallenge represents a login waiting for its second factor.
+// Only the hash of the token is kept, the raw token is returned to the client.
+type MFAChallenge struct {
+	ID        MFAChallengeID
+	UserID    UserID
+	TokenHash string
+	Attempts  int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// VerifyTokenExpires checks if the challenge token has expired.
+func (c *MFAChallenge) VerifyTokenExpires() error {
+	if c.ExpiresAt.Before(time.Now()) {
+		return ErrMFAChallengeExpired
+	}
+	return nil
+}
+
+// NormalizeRecoveryCode make a recovery code typed by a user comparable with the issued one.
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.Join(strings.Fields(code), ""))
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TwoFactorEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestTwoFactorEntitySuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorEntityTestSuite))
+}
+
+func (s *TwoFactorEntityTestSuite) TestIsEnabled() {
+	pending := TwoFactor{}
+	enabled := TwoFactor{EnabledAt: NullTime{sql.NullTime{Time: time.Now(), Valid: true}}}
+
+	s.False(pending.IsEnabled())
+	s.True(enabled.IsEnabled())
+}
+
+func (s *TwoFactorEntityTestSuite) TestVerifyTokenExpires() {
+	tests := []struct {
+		name     string
+		input    MFAChallenge
+		expected error
+	}{
+		{name: "it should return error when challenge is expired", input: MFAChallenge{ExpiresAt: time.Now().Add(-1 * time.Minute)}, expected: ErrMFAChallengeExpired},
+		{name: "it should return nil when challenge is not expired", input: MFAChallenge{ExpiresAt: time.Now().Add(1 * time.Minute)}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyTokenExpires())
+		})
+	}
+}
+
+func (s *TwoFactorEntityTestSuite) TestNormalizeRecoveryCode() {
+	s.Equal("abcde-fghij", NormalizeRecoveryCode(" ABCDE-fghij "))
+	s.Equal("abcde-fghij", NormalizeRecoveryCode("abcde- fghij"))
+}
@@ -16,29 +16,107 @@ var emailRegex = regexp.MustCompile(emailRegexStr)
 
 // User entity errors.
 var (
-	ErrEmailInvalid     = errors.New("user.entity.email_invalid")
-	ErrPasswordTooShort = errors.New("user.entity.password_too_short")
+	ErrEmailInvalid          = errors.New("user.entity.email_invalid")
+	ErrPasswordTooShort      = errors.New("user.entity.password_too_short")
+	ErrPasswordTooLong       = errors.New("user.entity.password_too_long")
+	ErrPasswordContainsEmail = errors.New("user.entity.password_contains_email")
+	ErrPasswordContainsName  = errors.New("user.entity.password_contains_name")
+	ErrPasswordBreached      = errors.New("user.entity.password_breached")
+	ErrUserSuspended         = errors.New("user.entity.user_suspended")
+	ErrUserServiceAccount    = errors.New("user.entity.user_service_account")
 )
 
+// PasswordLengthError is a password out of the length bounds of the password policy.
+// Err is ErrPasswordTooShort or ErrPasswordTooLong and Limit is the bound it broke.
+type PasswordLengthError struct {
+	Err   error
+	Limit int
+}
+
+func (e *PasswordLengthError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PasswordLengthError) Unwrap() error {
+	return e.Err
+}
+
 type UserID string
 
+// Role decides what a user may manage beyond their own data.
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+	// RoleServiceAccount is a non-human user, it authenticates only with API keys.
+	RoleServiceAccount Role = "service_account"
+)
+
 // User represents a user in the system.
 type User struct {
-	ID        UserID
-	Name      string
-	Email     string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID              UserID
+	Name            string
+	Email           string
+	Password        string
+	Role            Role
+	EmailVerifiedAt NullTime
+	SuspendedAt     NullTime
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// UserUsage represents how much a user stores and how many ways they are signed in.
+type UserUsage struct {
+	Tasks          int
+	Templates      int
+	Filters        int
+	Sessions       int
+	PersonalTokens int
 }
 
 // Validate user fields.
 func (u *User) Validate() error {
-	switch {
-	case !emailRegex.MatchString(u.Email):
+	if err := ValidateEmail(u.Email); err != nil {
+		return err
+	}
+	return ValidatePassword(u.Password)
+}
+
+// ValidateEmail validate an email address.
+func ValidateEmail(email string) error {
+	if !emailRegex.MatchString(email) {
 		return ErrEmailInvalid
-	case len(u.Password) < MinPasswordLength:
+	}
+	return nil
+}
+
+// ValidatePassword validate a raw password.
+func ValidatePassword(password string) error {
+	if len(password) < MinPasswordLength {
 		return ErrPasswordTooShort
 	}
 	return nil
 }
+
+// IsEmailVerified report whether the user has verified their email address.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt.Valid
+}
+
+// VerifyNotSuspended checks if the user is allowed to sign in.
+func (u *User) VerifyNotSuspended() error {
+	if u.SuspendedAt.Valid {
+		return ErrUserSuspended
+	}
+	return nil
+}
+
+// VerifyNotServiceAccount checks if the user is a person allowed to sign in,
+// service accounts authenticate only with API keys.
+func (u *User) VerifyNotServiceAccount() error {
+	if u.Role == RoleServiceAccount {
+		return ErrUserServiceAccount
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package entity
 
 import (
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -32,3 +34,74 @@ func (s *UserEntityTestSuite) TestValidate() {
 		})
 	}
 }
+
+func (s *UserEntityTestSuite) TestIsEmailVerified() {
+	tests := []struct {
+		name     string
+		input    User
+		expected bool
+	}{
+		{name: "it should return false when email is not verified", input: User{}, expected: false},
+		{name: "it should return true when email is verified", input: User{EmailVerifiedAt: NullTime{NullTime: sql.NullTime{Time: time.Now(), Valid: true}}}, expected: true},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.IsEmailVerified())
+		})
+	}
+}
+
+func (s *UserEntityTestSuite) TestValidateEmail() {
+	s.Run("it should return error when email is invalid", func() {
+		s.Equal(ErrEmailInvalid, ValidateEmail("invalid"))
+	})
+
+	s.Run("it should return nil when email is valid", func() {
+		s.Nil(ValidateEmail("gopher@go.dev"))
+	})
+}
+
+func (s *UserEntityTestSuite) TestValidatePassword() {
+	s.Run("it should return error when password is too short", func() {
+		s.Equal(ErrPasswordTooShort, ValidatePassword("123"))
+	})
+
+	s.Run("it should return nil when password is long enough", func() {
+		s.Nil(ValidatePassword("123456"))
+	})
+}
+
+func (s *UserEntityTestSuite) TestVerifyNotSuspended() {
+	tests := []struct {
+		name     string
+		input    User
+		expected error
+	}{
+		{name: "it should return nil when user is not suspended", input: User{}, expected: nil},
+		{name: "it should return error ErrUserSuspended when user is suspended", input: User{SuspendedAt: NullTime{NullTime: sql.NullTime{Time: time.Now(), Valid: true}}}, expected: ErrUserSuspended},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyNotSuspended())
+		})
+	}
+}
+
+func (s *UserEntityTestSuite) TestVerifyNotServiceAccount() {
+	tests := []struct {
+		name     string
+		input    User
+		expected error
+	}{
+		{name: "it should return nil when user is a person", input: User{Role: RoleUser}, expected: nil},
+		{name: "it should return error ErrUserServiceAccount when user is a service account", input: User{Role: RoleServiceAccount}, expected: ErrUserServiceAccount},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyNotServiceAccount())
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// Email verification entity errors.
+var (
+	ErrVerificationTokenExpired = errors.New("verification.entity.token_expired")
+)
+
+type EmailVerificationID string
+
+// EmailVerification represents a pending email address verification.
+// Only the hash of the token is kept, the raw token is sent to the user.
+type EmailVerification struct {
+	ID        EmailVerificationID
+	UserID    UserID
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// VerifyTokenExpires checks if the verification token has expired.
+func (v *EmailVerification) VerifyTokenExpires() error {
+	if v.ExpiresAt.Before(time.Now()) {
+		return ErrVerificationTokenExpired
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EmailVerificationEntityTestSuite struct {
+	suite.Suite
+}
+
+func TestEmailVerificationEntitySuite(t *testing.T) {
+	suite.Run(t, new(EmailVerificationEntityTestSuite))
+}
+
+func (s *EmailVerificationEntityTestSuite) TestVerifyTokenExpires() {
+	tests := []struct {
+		name     string
+		input    EmailVerification
+		expected error
+	}{
+		{name: "it should return error when verification is expired", input: EmailVerification{ExpiresAt: time.Now().Add(-1 * time.Hour)}, expected: ErrVerificationTokenExpired},
+		{name: "it should return nil when verification is not expired", input: EmailVerification{ExpiresAt: time.Now().Add(1 * time.Hour)}, expected: nil},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			s.Equal(test.expected, test.input.VerifyTokenExpires())
+		})
+	}
+}
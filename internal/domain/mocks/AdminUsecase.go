@@ -0,0 +1,205 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdminUsecase is an autogenerated mock type for the AdminUsecase type
+type AdminUsecase struct {
+	mock.Mock
+}
+
+// GetAllUsers provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) GetAllUsers(ctx context.Context, payload *dto.AdminUserGetAllIn) ([]dto.AdminUserGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.AdminUserGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminUserGetAllIn) []dto.AdminUserGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.AdminUserGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AdminUserGetAllIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginMethods provides a mock function with given fields: ctx
+func (_m *AdminUsecase) GetLoginMethods(ctx context.Context) ([]dto.AdminLoginMethodGetAllOut, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []dto.AdminLoginMethodGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context) []dto.AdminLoginMethodGetAllOut); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.AdminLoginMethodGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSecurityEvents provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) GetSecurityEvents(ctx context.Context, payload *dto.AdminSecurityEventGetAllIn) ([]dto.AdminSecurityEventGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.AdminSecurityEventGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminSecurityEventGetAllIn) []dto.AdminSecurityEventGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.AdminSecurityEventGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AdminSecurityEventGetAllIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserByID provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) GetUserByID(ctx context.Context, payload *dto.AdminUserGetByIDIn) (dto.AdminUserGetByIDOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.AdminUserGetByIDOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminUserGetByIDIn) dto.AdminUserGetByIDOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.AdminUserGetByIDOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AdminUserGetByIDIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Logout provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) Logout(ctx context.Context, payload *dto.AdminUserLogoutIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminUserLogoutIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetTwoFactor provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) ResetTwoFactor(ctx context.Context, payload *dto.AdminUserResetTwoFactorIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminUserResetTwoFactorIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Seed provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) Seed(ctx context.Context, payload *dto.AdminSeedIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminSeedIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Suspend provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) Suspend(ctx context.Context, payload *dto.AdminUserSuspendIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminUserSuspendIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unsuspend provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) Unsuspend(ctx context.Context, payload *dto.AdminUserUnsuspendIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminUserUnsuspendIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLoginMethod provides a mock function with given fields: ctx, payload
+func (_m *AdminUsecase) UpdateLoginMethod(ctx context.Context, payload *dto.AdminLoginMethodUpdateIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AdminLoginMethodUpdateIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewAdminUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewAdminUsecase creates a new instance of AdminUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewAdminUsecase(t mockConstructorTestingTNewAdminUsecase) *AdminUsecase {
+	mock := &AdminUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -8,6 +8,8 @@ import (
 	entity "github.com/edwintantawi/taskit/internal/domain/entity"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // AuthRepository is an autogenerated mock type for the AuthRepository type
@@ -15,6 +17,20 @@ type AuthRepository struct {
 	mock.Mock
 }
 
+// DeleteByID provides a mock function with given fields: ctx, authID
+func (_m *AuthRepository) DeleteByID(ctx context.Context, authID entity.AuthID) error {
+	ret := _m.Called(ctx, authID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuthID) error); ok {
+		r0 = rf(ctx, authID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteByToken provides a mock function with given fields: ctx, token
 func (_m *AuthRepository) DeleteByToken(ctx context.Context, token string) error {
 	ret := _m.Called(ctx, token)
@@ -29,6 +45,134 @@ func (_m *AuthRepository) DeleteByToken(ctx context.Context, token string) error
 	return r0
 }
 
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *AuthRepository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, before, limit
+func (_m *AuthRepository) DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteOldestByUserID provides a mock function with given fields: ctx, userID, keep
+func (_m *AuthRepository) DeleteOldestByUserID(ctx context.Context, userID entity.UserID, keep int) error {
+	ret := _m.Called(ctx, userID, keep)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, int) error); ok {
+		r0 = rf(ctx, userID, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOthersByUserID provides a mock function with given fields: ctx, userID, authID
+func (_m *AuthRepository) DeleteOthersByUserID(ctx context.Context, userID entity.UserID, authID entity.AuthID) error {
+	ret := _m.Called(ctx, userID, authID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.AuthID) error); ok {
+		r0 = rf(ctx, userID, authID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *AuthRepository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Auth, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.Auth
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) []entity.Auth); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Auth)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, authID
+func (_m *AuthRepository) FindByID(ctx context.Context, authID entity.AuthID) (entity.Auth, error) {
+	ret := _m.Called(ctx, authID)
+
+	var r0 entity.Auth
+	if rf, ok := ret.Get(0).(func(context.Context, entity.AuthID) entity.Auth); ok {
+		r0 = rf(ctx, authID)
+	} else {
+		r0 = ret.Get(0).(entity.Auth)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.AuthID) error); ok {
+		r1 = rf(ctx, authID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByRotatedToken provides a mock function with given fields: ctx, token
+func (_m *AuthRepository) FindByRotatedToken(ctx context.Context, token string) (entity.Auth, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 entity.Auth
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.Auth); ok {
+		r0 = rf(ctx, token)
+	} else {
+		r0 = ret.Get(0).(entity.Auth)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByToken provides a mock function with given fields: ctx, token
 func (_m *AuthRepository) FindByToken(ctx context.Context, token string) (entity.Auth, error) {
 	ret := _m.Called(ctx, token)
@@ -50,13 +194,13 @@ func (_m *AuthRepository) FindByToken(ctx context.Context, token string) (entity
 	return r0, r1
 }
 
-// Store provides a mock function with given fields: ctx, a
-func (_m *AuthRepository) Store(ctx context.Context, a *entity.Auth) error {
-	ret := _m.Called(ctx, a)
+// Rotate provides a mock function with given fields: ctx, previousToken, a
+func (_m *AuthRepository) Rotate(ctx context.Context, previousToken string, a *entity.Auth) error {
+	ret := _m.Called(ctx, previousToken, a)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Auth) error); ok {
-		r0 = rf(ctx, a)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *entity.Auth) error); ok {
+		r0 = rf(ctx, previousToken, a)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -64,6 +208,27 @@ func (_m *AuthRepository) Store(ctx context.Context, a *entity.Auth) error {
 	return r0
 }
 
+// Store provides a mock function with given fields: ctx, a
+func (_m *AuthRepository) Store(ctx context.Context, a *entity.Auth) (entity.AuthID, error) {
+	ret := _m.Called(ctx, a)
+
+	var r0 entity.AuthID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Auth) entity.AuthID); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Get(0).(entity.AuthID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Auth) error); ok {
+		r1 = rf(ctx, a)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifyAvailableByToken provides a mock function with given fields: ctx, token
 func (_m *AuthRepository) VerifyAvailableByToken(ctx context.Context, token string) error {
 	ret := _m.Called(ctx, token)
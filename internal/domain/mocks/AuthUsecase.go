@@ -15,6 +15,48 @@ type AuthUsecase struct {
 	mock.Mock
 }
 
+// GetJWKS provides a mock function with given fields: ctx
+func (_m *AuthUsecase) GetJWKS(ctx context.Context) (dto.AuthJWKSOut, error) {
+	ret := _m.Called(ctx)
+
+	var r0 dto.AuthJWKSOut
+	if rf, ok := ret.Get(0).(func(context.Context) dto.AuthJWKSOut); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(dto.AuthJWKSOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLoginMethods provides a mock function with given fields: ctx
+func (_m *AuthUsecase) GetLoginMethods(ctx context.Context) (dto.AuthLoginMethodsOut, error) {
+	ret := _m.Called(ctx)
+
+	var r0 dto.AuthLoginMethodsOut
+	if rf, ok := ret.Get(0).(func(context.Context) dto.AuthLoginMethodsOut); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(dto.AuthLoginMethodsOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetProfile provides a mock function with given fields: ctx, payload
 func (_m *AuthUsecase) GetProfile(ctx context.Context, payload *dto.AuthProfileIn) (dto.AuthProfileOut, error) {
 	ret := _m.Called(ctx, payload)
@@ -57,6 +99,48 @@ func (_m *AuthUsecase) Login(ctx context.Context, payload *dto.AuthLoginIn) (dto
 	return r0, r1
 }
 
+// LoginMagicLink provides a mock function with given fields: ctx, payload
+func (_m *AuthUsecase) LoginMagicLink(ctx context.Context, payload *dto.AuthMagicLinkLoginIn) (dto.AuthLoginOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.AuthLoginOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuthMagicLinkLoginIn) dto.AuthLoginOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.AuthLoginOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AuthMagicLinkLoginIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginOIDC provides a mock function with given fields: ctx, payload
+func (_m *AuthUsecase) LoginOIDC(ctx context.Context, payload *dto.AuthOIDCLoginIn) (dto.AuthLoginOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.AuthLoginOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuthOIDCLoginIn) dto.AuthLoginOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.AuthLoginOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AuthOIDCLoginIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Logout provides a mock function with given fields: ctx, payload
 func (_m *AuthUsecase) Logout(ctx context.Context, payload *dto.AuthLogoutIn) error {
 	ret := _m.Called(ctx, payload)
@@ -92,6 +176,69 @@ func (_m *AuthUsecase) Refresh(ctx context.Context, payload *dto.AuthRefreshIn)
 	return r0, r1
 }
 
+// RequestMagicLink provides a mock function with given fields: ctx, payload
+func (_m *AuthUsecase) RequestMagicLink(ctx context.Context, payload *dto.AuthMagicLinkRequestIn) (dto.AuthMagicLinkRequestOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.AuthMagicLinkRequestOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuthMagicLinkRequestIn) dto.AuthMagicLinkRequestOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.AuthMagicLinkRequestOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AuthMagicLinkRequestIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartOIDC provides a mock function with given fields: ctx, payload
+func (_m *AuthUsecase) StartOIDC(ctx context.Context, payload *dto.AuthOIDCStartIn) (dto.AuthOIDCStartOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.AuthOIDCStartOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuthOIDCStartIn) dto.AuthOIDCStartOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.AuthOIDCStartOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AuthOIDCStartIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyMFA provides a mock function with given fields: ctx, payload
+func (_m *AuthUsecase) VerifyMFA(ctx context.Context, payload *dto.AuthVerifyMFAIn) (dto.AuthLoginOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.AuthLoginOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.AuthVerifyMFAIn) dto.AuthLoginOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.AuthLoginOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.AuthVerifyMFAIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewAuthUsecase interface {
 	mock.TestingT
 	Cleanup(func())
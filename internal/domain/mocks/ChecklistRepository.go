@@ -0,0 +1,145 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChecklistRepository is an autogenerated mock type for the ChecklistRepository type
+type ChecklistRepository struct {
+	mock.Mock
+}
+
+// DeleteByID provides a mock function with given fields: ctx, itemID
+func (_m *ChecklistRepository) DeleteByID(ctx context.Context, itemID entity.ChecklistItemID) error {
+	ret := _m.Called(ctx, itemID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ChecklistItemID) error); ok {
+		r0 = rf(ctx, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAllByTaskIDs provides a mock function with given fields: ctx, taskIDs
+func (_m *ChecklistRepository) FindAllByTaskIDs(ctx context.Context, taskIDs []entity.TaskID) ([]entity.ChecklistItem, error) {
+	ret := _m.Called(ctx, taskIDs)
+
+	var r0 []entity.ChecklistItem
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.TaskID) []entity.ChecklistItem); ok {
+		r0 = rf(ctx, taskIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ChecklistItem)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.TaskID) error); ok {
+		r1 = rf(ctx, taskIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, itemID
+func (_m *ChecklistRepository) FindByID(ctx context.Context, itemID entity.ChecklistItemID) (entity.ChecklistItem, error) {
+	ret := _m.Called(ctx, itemID)
+
+	var r0 entity.ChecklistItem
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ChecklistItemID) entity.ChecklistItem); ok {
+		r0 = rf(ctx, itemID)
+	} else {
+		r0 = ret.Get(0).(entity.ChecklistItem)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ChecklistItemID) error); ok {
+		r1 = rf(ctx, itemID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, c
+func (_m *ChecklistRepository) Store(ctx context.Context, c *entity.ChecklistItem) (entity.ChecklistItemID, error) {
+	ret := _m.Called(ctx, c)
+
+	var r0 entity.ChecklistItemID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ChecklistItem) entity.ChecklistItemID); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Get(0).(entity.ChecklistItemID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.ChecklistItem) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, c
+func (_m *ChecklistRepository) Update(ctx context.Context, c *entity.ChecklistItem) (entity.ChecklistItemID, error) {
+	ret := _m.Called(ctx, c)
+
+	var r0 entity.ChecklistItemID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ChecklistItem) entity.ChecklistItemID); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Get(0).(entity.ChecklistItemID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.ChecklistItem) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePositions provides a mock function with given fields: ctx, itemIDs
+func (_m *ChecklistRepository) UpdatePositions(ctx context.Context, itemIDs []entity.ChecklistItemID) error {
+	ret := _m.Called(ctx, itemIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.ChecklistItemID) error); ok {
+		r0 = rf(ctx, itemIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewChecklistRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewChecklistRepository creates a new instance of ChecklistRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewChecklistRepository(t mockConstructorTestingTNewChecklistRepository) *ChecklistRepository {
+	mock := &ChecklistRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChecklistUsecase is an autogenerated mock type for the ChecklistUsecase type
+type ChecklistUsecase struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: ctx, payload
+func (_m *ChecklistUsecase) Add(ctx context.Context, payload *dto.ChecklistItemCreateIn) (dto.ChecklistItemCreateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.ChecklistItemCreateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ChecklistItemCreateIn) dto.ChecklistItemCreateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.ChecklistItemCreateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.ChecklistItemCreateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: ctx, payload
+func (_m *ChecklistUsecase) Remove(ctx context.Context, payload *dto.ChecklistItemRemoveIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ChecklistItemRemoveIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reorder provides a mock function with given fields: ctx, payload
+func (_m *ChecklistUsecase) Reorder(ctx context.Context, payload *dto.ChecklistReorderIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ChecklistReorderIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Toggle provides a mock function with given fields: ctx, payload
+func (_m *ChecklistUsecase) Toggle(ctx context.Context, payload *dto.ChecklistItemToggleIn) (dto.ChecklistItemToggleOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.ChecklistItemToggleOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ChecklistItemToggleIn) dto.ChecklistItemToggleOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.ChecklistItemToggleOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.ChecklistItemToggleIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewChecklistUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewChecklistUsecase creates a new instance of ChecklistUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewChecklistUsecase(t mockConstructorTestingTNewChecklistUsecase) *ChecklistUsecase {
+	mock := &ChecklistUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
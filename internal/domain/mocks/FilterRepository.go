@@ -0,0 +1,145 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FilterRepository is an autogenerated mock type for the FilterRepository type
+type FilterRepository struct {
+	mock.Mock
+}
+
+// DeleteByID provides a mock function with given fields: ctx, filterID
+func (_m *FilterRepository) DeleteByID(ctx context.Context, filterID entity.FilterID) error {
+	ret := _m.Called(ctx, filterID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.FilterID) error); ok {
+		r0 = rf(ctx, filterID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *FilterRepository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Filter, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.Filter
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) []entity.Filter); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Filter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, filterID
+func (_m *FilterRepository) FindByID(ctx context.Context, filterID entity.FilterID) (entity.Filter, error) {
+	ret := _m.Called(ctx, filterID)
+
+	var r0 entity.Filter
+	if rf, ok := ret.Get(0).(func(context.Context, entity.FilterID) entity.Filter); ok {
+		r0 = rf(ctx, filterID)
+	} else {
+		r0 = ret.Get(0).(entity.Filter)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.FilterID) error); ok {
+		r1 = rf(ctx, filterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, f
+func (_m *FilterRepository) Store(ctx context.Context, f *entity.Filter) (entity.FilterID, error) {
+	ret := _m.Called(ctx, f)
+
+	var r0 entity.FilterID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Filter) entity.FilterID); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Get(0).(entity.FilterID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Filter) error); ok {
+		r1 = rf(ctx, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, f
+func (_m *FilterRepository) Update(ctx context.Context, f *entity.Filter) (entity.FilterID, error) {
+	ret := _m.Called(ctx, f)
+
+	var r0 entity.FilterID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Filter) entity.FilterID); ok {
+		r0 = rf(ctx, f)
+	} else {
+		r0 = ret.Get(0).(entity.FilterID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Filter) error); ok {
+		r1 = rf(ctx, f)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePositions provides a mock function with given fields: ctx, filterIDs
+func (_m *FilterRepository) UpdatePositions(ctx context.Context, filterIDs []entity.FilterID) error {
+	ret := _m.Called(ctx, filterIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.FilterID) error); ok {
+		r0 = rf(ctx, filterIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewFilterRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFilterRepository creates a new instance of FilterRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFilterRepository(t mockConstructorTestingTNewFilterRepository) *FilterRepository {
+	mock := &FilterRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
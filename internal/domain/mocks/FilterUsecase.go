@@ -0,0 +1,168 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FilterUsecase is an autogenerated mock type for the FilterUsecase type
+type FilterUsecase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, payload
+func (_m *FilterUsecase) Create(ctx context.Context, payload *dto.FilterCreateIn) (dto.FilterCreateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.FilterCreateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.FilterCreateIn) dto.FilterCreateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.FilterCreateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.FilterCreateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, payload
+func (_m *FilterUsecase) GetAll(ctx context.Context, payload *dto.FilterGetAllIn) ([]dto.FilterGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.FilterGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.FilterGetAllIn) []dto.FilterGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.FilterGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.FilterGetAllIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, payload
+func (_m *FilterUsecase) GetByID(ctx context.Context, payload *dto.FilterGetByIDIn) (dto.FilterGetByIDOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.FilterGetByIDOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.FilterGetByIDIn) dto.FilterGetByIDOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.FilterGetByIDOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.FilterGetByIDIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTasks provides a mock function with given fields: ctx, payload
+func (_m *FilterUsecase) GetTasks(ctx context.Context, payload *dto.FilterGetTasksIn) ([]dto.TaskGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.TaskGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.FilterGetTasksIn) []dto.TaskGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.TaskGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.FilterGetTasksIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: ctx, payload
+func (_m *FilterUsecase) Remove(ctx context.Context, payload *dto.FilterRemoveIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.FilterRemoveIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reorder provides a mock function with given fields: ctx, payload
+func (_m *FilterUsecase) Reorder(ctx context.Context, payload *dto.FilterReorderIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.FilterReorderIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, payload
+func (_m *FilterUsecase) Update(ctx context.Context, payload *dto.FilterUpdateIn) (dto.FilterUpdateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.FilterUpdateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.FilterUpdateIn) dto.FilterUpdateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.FilterUpdateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.FilterUpdateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewFilterUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFilterUsecase creates a new instance of FilterUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFilterUsecase(t mockConstructorTestingTNewFilterUsecase) *FilterUsecase {
+	mock := &FilterUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
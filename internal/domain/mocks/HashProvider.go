@@ -46,6 +46,20 @@ func (_m *HashProvider) Hash(raw string) ([]byte, error) {
 	return r0, r1
 }
 
+// NeedsRehash provides a mock function with given fields: hashed
+func (_m *HashProvider) NeedsRehash(hashed string) bool {
+	ret := _m.Called(hashed)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(hashed)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewHashProvider interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,124 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// IdentityRepository is an autogenerated mock type for the IdentityRepository type
+type IdentityRepository struct {
+	mock.Mock
+}
+
+// ConsumeState provides a mock function with given fields: ctx, stateHash
+func (_m *IdentityRepository) ConsumeState(ctx context.Context, stateHash string) (entity.OIDCState, error) {
+	ret := _m.Called(ctx, stateHash)
+
+	var r0 entity.OIDCState
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.OIDCState); ok {
+		r0 = rf(ctx, stateHash)
+	} else {
+		r0 = ret.Get(0).(entity.OIDCState)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stateHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExpiredStates provides a mock function with given fields: ctx, before, limit
+func (_m *IdentityRepository) DeleteExpiredStates(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByProviderSubject provides a mock function with given fields: ctx, provider, subject
+func (_m *IdentityRepository) FindByProviderSubject(ctx context.Context, provider string, subject string) (entity.UserIdentity, error) {
+	ret := _m.Called(ctx, provider, subject)
+
+	var r0 entity.UserIdentity
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) entity.UserIdentity); ok {
+		r0 = rf(ctx, provider, subject)
+	} else {
+		r0 = ret.Get(0).(entity.UserIdentity)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, provider, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, i
+func (_m *IdentityRepository) Store(ctx context.Context, i *entity.UserIdentity) error {
+	ret := _m.Called(ctx, i)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.UserIdentity) error); ok {
+		r0 = rf(ctx, i)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreState provides a mock function with given fields: ctx, s
+func (_m *IdentityRepository) StoreState(ctx context.Context, s *entity.OIDCState) error {
+	ret := _m.Called(ctx, s)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OIDCState) error); ok {
+		r0 = rf(ctx, s)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewIdentityRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewIdentityRepository creates a new instance of IdentityRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewIdentityRepository(t mockConstructorTestingTNewIdentityRepository) *IdentityRepository {
+	mock := &IdentityRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
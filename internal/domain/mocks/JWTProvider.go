@@ -14,27 +14,27 @@ type JWTProvider struct {
 	mock.Mock
 }
 
-// GenerateAccessToken provides a mock function with given fields: userID
-func (_m *JWTProvider) GenerateAccessToken(userID entity.UserID) (string, time.Time, error) {
-	ret := _m.Called(userID)
+// GenerateAccessToken provides a mock function with given fields: userID, sessionID
+func (_m *JWTProvider) GenerateAccessToken(userID entity.UserID, sessionID entity.AuthID) (string, time.Time, error) {
+	ret := _m.Called(userID, sessionID)
 
 	var r0 string
-	if rf, ok := ret.Get(0).(func(entity.UserID) string); ok {
-		r0 = rf(userID)
+	if rf, ok := ret.Get(0).(func(entity.UserID, entity.AuthID) string); ok {
+		r0 = rf(userID, sessionID)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
 	var r1 time.Time
-	if rf, ok := ret.Get(1).(func(entity.UserID) time.Time); ok {
-		r1 = rf(userID)
+	if rf, ok := ret.Get(1).(func(entity.UserID, entity.AuthID) time.Time); ok {
+		r1 = rf(userID, sessionID)
 	} else {
 		r1 = ret.Get(1).(time.Time)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(entity.UserID) error); ok {
-		r2 = rf(userID)
+	if rf, ok := ret.Get(2).(func(entity.UserID, entity.AuthID) error); ok {
+		r2 = rf(userID, sessionID)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -70,15 +70,31 @@ func (_m *JWTProvider) GenerateRefreshToken(userID entity.UserID) (string, time.
 	return r0, r1, r2
 }
 
+// PublicKeys provides a mock function with given fields:
+func (_m *JWTProvider) PublicKeys() []entity.JWK {
+	ret := _m.Called()
+
+	var r0 []entity.JWK
+	if rf, ok := ret.Get(0).(func() []entity.JWK); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.JWK)
+		}
+	}
+
+	return r0
+}
+
 // VerifyAccessToken provides a mock function with given fields: rawToken
-func (_m *JWTProvider) VerifyAccessToken(rawToken string) (entity.UserID, error) {
+func (_m *JWTProvider) VerifyAccessToken(rawToken string) (entity.AuthClaims, error) {
 	ret := _m.Called(rawToken)
 
-	var r0 entity.UserID
-	if rf, ok := ret.Get(0).(func(string) entity.UserID); ok {
+	var r0 entity.AuthClaims
+	if rf, ok := ret.Get(0).(func(string) entity.AuthClaims); ok {
 		r0 = rf(rawToken)
 	} else {
-		r0 = ret.Get(0).(entity.UserID)
+		r0 = ret.Get(0).(entity.AuthClaims)
 	}
 
 	var r1 error
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JanitorUsecase is an autogenerated mock type for the JanitorUsecase type
+type JanitorUsecase struct {
+	mock.Mock
+}
+
+// Run provides a mock function with given fields: ctx
+func (_m *JanitorUsecase) Run(ctx context.Context) (dto.JanitorRunOut, error) {
+	ret := _m.Called(ctx)
+
+	var r0 dto.JanitorRunOut
+	if rf, ok := ret.Get(0).(func(context.Context) dto.JanitorRunOut); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(dto.JanitorRunOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewJanitorUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewJanitorUsecase creates a new instance of JanitorUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewJanitorUsecase(t mockConstructorTestingTNewJanitorUsecase) *JanitorUsecase {
+	mock := &JanitorUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,103 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LoginAttemptRepository is an autogenerated mock type for the LoginAttemptRepository type
+type LoginAttemptRepository struct {
+	mock.Mock
+}
+
+// CountSince provides a mock function with given fields: ctx, scope, identifier, since
+func (_m *LoginAttemptRepository) CountSince(ctx context.Context, scope entity.LoginAttemptScope, identifier string, since time.Time) (entity.LoginFailures, error) {
+	ret := _m.Called(ctx, scope, identifier, since)
+
+	var r0 entity.LoginFailures
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginAttemptScope, string, time.Time) entity.LoginFailures); ok {
+		r0 = rf(ctx, scope, identifier, since)
+	} else {
+		r0 = ret.Get(0).(entity.LoginFailures)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.LoginAttemptScope, string, time.Time) error); ok {
+		r1 = rf(ctx, scope, identifier, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByIdentifier provides a mock function with given fields: ctx, scope, identifier
+func (_m *LoginAttemptRepository) DeleteByIdentifier(ctx context.Context, scope entity.LoginAttemptScope, identifier string) error {
+	ret := _m.Called(ctx, scope, identifier)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginAttemptScope, string) error); ok {
+		r0 = rf(ctx, scope, identifier)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteOlderThan provides a mock function with given fields: ctx, before, limit
+func (_m *LoginAttemptRepository) DeleteOlderThan(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, a
+func (_m *LoginAttemptRepository) Store(ctx context.Context, a *entity.LoginAttempt) error {
+	ret := _m.Called(ctx, a)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.LoginAttempt) error); ok {
+		r0 = rf(ctx, a)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewLoginAttemptRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLoginAttemptRepository(t mockConstructorTestingTNewLoginAttemptRepository) *LoginAttemptRepository {
+	mock := &LoginAttemptRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
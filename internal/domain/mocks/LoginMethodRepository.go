@@ -0,0 +1,89 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LoginMethodRepository is an autogenerated mock type for the LoginMethodRepository type
+type LoginMethodRepository struct {
+	mock.Mock
+}
+
+// FindAll provides a mock function with given fields: ctx
+func (_m *LoginMethodRepository) FindAll(ctx context.Context) ([]entity.LoginMethodSetting, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []entity.LoginMethodSetting
+	if rf, ok := ret.Get(0).(func(context.Context) []entity.LoginMethodSetting); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.LoginMethodSetting)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsEnabled provides a mock function with given fields: ctx, method
+func (_m *LoginMethodRepository) IsEnabled(ctx context.Context, method entity.LoginMethod) (bool, error) {
+	ret := _m.Called(ctx, method)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginMethod) bool); ok {
+		r0 = rf(ctx, method)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.LoginMethod) error); ok {
+		r1 = rf(ctx, method)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, method, enabled
+func (_m *LoginMethodRepository) Update(ctx context.Context, method entity.LoginMethod, enabled bool) error {
+	ret := _m.Called(ctx, method, enabled)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.LoginMethod, bool) error); ok {
+		r0 = rf(ctx, method, enabled)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewLoginMethodRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewLoginMethodRepository creates a new instance of LoginMethodRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewLoginMethodRepository(t mockConstructorTestingTNewLoginMethodRepository) *LoginMethodRepository {
+	mock := &LoginMethodRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
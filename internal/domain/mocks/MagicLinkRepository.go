@@ -0,0 +1,80 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MagicLinkRepository is an autogenerated mock type for the MagicLinkRepository type
+type MagicLinkRepository struct {
+	mock.Mock
+}
+
+// Consume provides a mock function with given fields: ctx, tokenHash
+func (_m *MagicLinkRepository) Consume(ctx context.Context, tokenHash string) (entity.MagicLink, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 entity.MagicLink
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.MagicLink); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.MagicLink)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *MagicLinkRepository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store provides a mock function with given fields: ctx, m
+func (_m *MagicLinkRepository) Store(ctx context.Context, m *entity.MagicLink) error {
+	ret := _m.Called(ctx, m)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.MagicLink) error); ok {
+		r0 = rf(ctx, m)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewMagicLinkRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMagicLinkRepository creates a new instance of MagicLinkRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMagicLinkRepository(t mockConstructorTestingTNewMagicLinkRepository) *MagicLinkRepository {
+	mock := &MagicLinkRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,35 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// MetricsProvider is an autogenerated mock type for the MetricsProvider type
+type MetricsProvider struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: name, delta
+func (_m *MetricsProvider) Add(name string, delta int64) {
+	_m.Called(name, delta)
+}
+
+// Set provides a mock function with given fields: name, value
+func (_m *MetricsProvider) Set(name string, value int64) {
+	_m.Called(name, value)
+}
+
+type mockConstructorTestingTNewMetricsProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewMetricsProvider creates a new instance of MetricsProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewMetricsProvider(t mockConstructorTestingTNewMetricsProvider) *MetricsProvider {
+	mock := &MetricsProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,73 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OIDCProvider is an autogenerated mock type for the OIDCProvider type
+type OIDCProvider struct {
+	mock.Mock
+}
+
+// AuthCodeURL provides a mock function with given fields: ctx, provider, state, nonce, codeVerifier
+func (_m *OIDCProvider) AuthCodeURL(ctx context.Context, provider string, state string, nonce string, codeVerifier string) (string, error) {
+	ret := _m.Called(ctx, provider, state, nonce, codeVerifier)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) string); ok {
+		r0 = rf(ctx, provider, state, nonce, codeVerifier)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, provider, state, nonce, codeVerifier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Exchange provides a mock function with given fields: ctx, provider, code, codeVerifier, nonce
+func (_m *OIDCProvider) Exchange(ctx context.Context, provider string, code string, codeVerifier string, nonce string) (entity.OIDCClaims, error) {
+	ret := _m.Called(ctx, provider, code, codeVerifier, nonce)
+
+	var r0 entity.OIDCClaims
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) entity.OIDCClaims); ok {
+		r0 = rf(ctx, provider, code, codeVerifier, nonce)
+	} else {
+		r0 = ret.Get(0).(entity.OIDCClaims)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, provider, code, codeVerifier, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewOIDCProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewOIDCProvider creates a new instance of OIDCProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewOIDCProvider(t mockConstructorTestingTNewOIDCProvider) *OIDCProvider {
+	mock := &OIDCProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,42 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordPolicyProvider is an autogenerated mock type for the PasswordPolicyProvider type
+type PasswordPolicyProvider struct {
+	mock.Mock
+}
+
+// Check provides a mock function with given fields: password, user
+func (_m *PasswordPolicyProvider) Check(password string, user *entity.User) error {
+	ret := _m.Called(password, user)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *entity.User) error); ok {
+		r0 = rf(password, user)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPasswordPolicyProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPasswordPolicyProvider creates a new instance of PasswordPolicyProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPasswordPolicyProvider(t mockConstructorTestingTNewPasswordPolicyProvider) *PasswordPolicyProvider {
+	mock := &PasswordPolicyProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
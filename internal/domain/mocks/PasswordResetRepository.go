@@ -0,0 +1,101 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordResetRepository is an autogenerated mock type for the PasswordResetRepository type
+type PasswordResetRepository struct {
+	mock.Mock
+}
+
+// Consume provides a mock function with given fields: ctx, tokenHash
+func (_m *PasswordResetRepository) Consume(ctx context.Context, tokenHash string) (entity.PasswordReset, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 entity.PasswordReset
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.PasswordReset); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.PasswordReset)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *PasswordResetRepository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *PasswordResetRepository) FindByTokenHash(ctx context.Context, tokenHash string) (entity.PasswordReset, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 entity.PasswordReset
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.PasswordReset); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.PasswordReset)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, p
+func (_m *PasswordResetRepository) Store(ctx context.Context, p *entity.PasswordReset) error {
+	ret := _m.Called(ctx, p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PasswordReset) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPasswordResetRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPasswordResetRepository creates a new instance of PasswordResetRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPasswordResetRepository(t mockConstructorTestingTNewPasswordResetRepository) *PasswordResetRepository {
+	mock := &PasswordResetRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PasswordResetUsecase is an autogenerated mock type for the PasswordResetUsecase type
+type PasswordResetUsecase struct {
+	mock.Mock
+}
+
+// Confirm provides a mock function with given fields: ctx, payload
+func (_m *PasswordResetUsecase) Confirm(ctx context.Context, payload *dto.PasswordResetConfirmIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.PasswordResetConfirmIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Request provides a mock function with given fields: ctx, payload
+func (_m *PasswordResetUsecase) Request(ctx context.Context, payload *dto.PasswordResetRequestIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.PasswordResetRequestIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPasswordResetUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPasswordResetUsecase creates a new instance of PasswordResetUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPasswordResetUsecase(t mockConstructorTestingTNewPasswordResetUsecase) *PasswordResetUsecase {
+	mock := &PasswordResetUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalTokenRepository is an autogenerated mock type for the PersonalTokenRepository type
+type PersonalTokenRepository struct {
+	mock.Mock
+}
+
+// DeleteByID provides a mock function with given fields: ctx, tokenID
+func (_m *PersonalTokenRepository) DeleteByID(ctx context.Context, tokenID entity.PersonalTokenID) error {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PersonalTokenID) error); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *PersonalTokenRepository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.PersonalToken, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.PersonalToken
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) []entity.PersonalToken); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.PersonalToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, tokenID
+func (_m *PersonalTokenRepository) FindByID(ctx context.Context, tokenID entity.PersonalTokenID) (entity.PersonalToken, error) {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 entity.PersonalToken
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PersonalTokenID) entity.PersonalToken); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		r0 = ret.Get(0).(entity.PersonalToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PersonalTokenID) error); ok {
+		r1 = rf(ctx, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *PersonalTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (entity.PersonalToken, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 entity.PersonalToken
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.PersonalToken); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.PersonalToken)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, t
+func (_m *PersonalTokenRepository) Store(ctx context.Context, t *entity.PersonalToken) (entity.PersonalTokenID, error) {
+	ret := _m.Called(ctx, t)
+
+	var r0 entity.PersonalTokenID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PersonalToken) entity.PersonalTokenID); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(entity.PersonalTokenID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.PersonalToken) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Touch provides a mock function with given fields: ctx, tokenID
+func (_m *PersonalTokenRepository) Touch(ctx context.Context, tokenID entity.PersonalTokenID) error {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PersonalTokenID) error); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPersonalTokenRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPersonalTokenRepository creates a new instance of PersonalTokenRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPersonalTokenRepository(t mockConstructorTestingTNewPersonalTokenRepository) *PersonalTokenRepository {
+	mock := &PersonalTokenRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PersonalTokenUsecase is an autogenerated mock type for the PersonalTokenUsecase type
+type PersonalTokenUsecase struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, payload
+func (_m *PersonalTokenUsecase) Authenticate(ctx context.Context, payload *dto.PersonalTokenAuthenticateIn) (dto.PersonalTokenAuthenticateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.PersonalTokenAuthenticateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.PersonalTokenAuthenticateIn) dto.PersonalTokenAuthenticateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.PersonalTokenAuthenticateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.PersonalTokenAuthenticateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, payload
+func (_m *PersonalTokenUsecase) Create(ctx context.Context, payload *dto.PersonalTokenCreateIn) (dto.PersonalTokenCreateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.PersonalTokenCreateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.PersonalTokenCreateIn) dto.PersonalTokenCreateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.PersonalTokenCreateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.PersonalTokenCreateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, payload
+func (_m *PersonalTokenUsecase) GetAll(ctx context.Context, payload *dto.PersonalTokenGetAllIn) ([]dto.PersonalTokenGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.PersonalTokenGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.PersonalTokenGetAllIn) []dto.PersonalTokenGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.PersonalTokenGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.PersonalTokenGetAllIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, payload
+func (_m *PersonalTokenUsecase) Revoke(ctx context.Context, payload *dto.PersonalTokenRevokeIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.PersonalTokenRevokeIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewPersonalTokenUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewPersonalTokenUsecase creates a new instance of PersonalTokenUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewPersonalTokenUsecase(t mockConstructorTestingTNewPersonalTokenUsecase) *PersonalTokenUsecase {
+	mock := &PersonalTokenUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,124 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RevocationRepository is an autogenerated mock type for the RevocationRepository type
+type RevocationRepository struct {
+	mock.Mock
+}
+
+// DeleteExpired provides a mock function with given fields: ctx, watermarkBefore
+func (_m *RevocationRepository) DeleteExpired(ctx context.Context, watermarkBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, watermarkBefore)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, watermarkBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, watermarkBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindWatermark provides a mock function with given fields: ctx, userID
+func (_m *RevocationRepository) FindWatermark(ctx context.Context, userID entity.UserID) (time.Time, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 time.Time
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) time.Time); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsRevoked provides a mock function with given fields: ctx, tokenID
+func (_m *RevocationRepository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	ret := _m.Called(ctx, tokenID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, tokenID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, t
+func (_m *RevocationRepository) Store(ctx context.Context, t *entity.RevokedToken) error {
+	ret := _m.Called(ctx, t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.RevokedToken) error); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreWatermark provides a mock function with given fields: ctx, userID, revokedBefore
+func (_m *RevocationRepository) StoreWatermark(ctx context.Context, userID entity.UserID, revokedBefore time.Time) error {
+	ret := _m.Called(ctx, userID, revokedBefore)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, time.Time) error); ok {
+		r0 = rf(ctx, userID, revokedBefore)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRevocationRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRevocationRepository creates a new instance of RevocationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRevocationRepository(t mockConstructorTestingTNewRevocationRepository) *RevocationRepository {
+	mock := &RevocationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
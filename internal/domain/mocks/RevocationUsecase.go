@@ -0,0 +1,66 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RevocationUsecase is an autogenerated mock type for the RevocationUsecase type
+type RevocationUsecase struct {
+	mock.Mock
+}
+
+// EnsureActive provides a mock function with given fields: ctx, payload
+func (_m *RevocationUsecase) EnsureActive(ctx context.Context, payload *dto.RevocationEnsureActiveIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.RevocationEnsureActiveIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Prune provides a mock function with given fields: ctx
+func (_m *RevocationUsecase) Prune(ctx context.Context) (dto.RevocationPruneOut, error) {
+	ret := _m.Called(ctx)
+
+	var r0 dto.RevocationPruneOut
+	if rf, ok := ret.Get(0).(func(context.Context) dto.RevocationPruneOut); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(dto.RevocationPruneOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRevocationUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRevocationUsecase creates a new instance of RevocationUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRevocationUsecase(t mockConstructorTestingTNewRevocationUsecase) *RevocationUsecase {
+	mock := &RevocationUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// SecurityEventRepository is an autogenerated mock type for the SecurityEventRepository type
+type SecurityEventRepository struct {
+	mock.Mock
+}
+
+// DeleteBefore provides a mock function with given fields: ctx, before
+func (_m *SecurityEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindAll provides a mock function with given fields: ctx, filter, limit, offset
+func (_m *SecurityEventRepository) FindAll(ctx context.Context, filter entity.SecurityEventFilter, limit int, offset int) ([]entity.SecurityEvent, error) {
+	ret := _m.Called(ctx, filter, limit, offset)
+
+	var r0 []entity.SecurityEvent
+	if rf, ok := ret.Get(0).(func(context.Context, entity.SecurityEventFilter, int, int) []entity.SecurityEvent); ok {
+		r0 = rf(ctx, filter, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SecurityEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.SecurityEventFilter, int, int) error); ok {
+		r1 = rf(ctx, filter, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, e
+func (_m *SecurityEventRepository) Store(ctx context.Context, e *entity.SecurityEvent) error {
+	ret := _m.Called(ctx, e)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.SecurityEvent) error); ok {
+		r0 = rf(ctx, e)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSecurityEventRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSecurityEventRepository creates a new instance of SecurityEventRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSecurityEventRepository(t mockConstructorTestingTNewSecurityEventRepository) *SecurityEventRepository {
+	mock := &SecurityEventRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
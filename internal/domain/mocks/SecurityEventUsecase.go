@@ -0,0 +1,75 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SecurityEventUsecase is an autogenerated mock type for the SecurityEventUsecase type
+type SecurityEventUsecase struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, payload
+func (_m *SecurityEventUsecase) GetAll(ctx context.Context, payload *dto.SecurityEventGetAllIn) ([]dto.SecurityEventGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.SecurityEventGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.SecurityEventGetAllIn) []dto.SecurityEventGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.SecurityEventGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.SecurityEventGetAllIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Prune provides a mock function with given fields: ctx
+func (_m *SecurityEventUsecase) Prune(ctx context.Context) (dto.SecurityEventPruneOut, error) {
+	ret := _m.Called(ctx)
+
+	var r0 dto.SecurityEventPruneOut
+	if rf, ok := ret.Get(0).(func(context.Context) dto.SecurityEventPruneOut); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(dto.SecurityEventPruneOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewSecurityEventUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSecurityEventUsecase creates a new instance of SecurityEventUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSecurityEventUsecase(t mockConstructorTestingTNewSecurityEventUsecase) *SecurityEventUsecase {
+	mock := &SecurityEventUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
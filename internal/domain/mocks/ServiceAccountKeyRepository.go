@@ -0,0 +1,168 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// ServiceAccountKeyRepository is an autogenerated mock type for the ServiceAccountKeyRepository type
+type ServiceAccountKeyRepository struct {
+	mock.Mock
+}
+
+// DeleteByID provides a mock function with given fields: ctx, keyID
+func (_m *ServiceAccountKeyRepository) DeleteByID(ctx context.Context, keyID entity.ServiceAccountKeyID) error {
+	ret := _m.Called(ctx, keyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ServiceAccountKeyID) error); ok {
+		r0 = rf(ctx, keyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *ServiceAccountKeyRepository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.ServiceAccountKey, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.ServiceAccountKey
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) []entity.ServiceAccountKey); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.ServiceAccountKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, keyID
+func (_m *ServiceAccountKeyRepository) FindByID(ctx context.Context, keyID entity.ServiceAccountKeyID) (entity.ServiceAccountKey, error) {
+	ret := _m.Called(ctx, keyID)
+
+	var r0 entity.ServiceAccountKey
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ServiceAccountKeyID) entity.ServiceAccountKey); ok {
+		r0 = rf(ctx, keyID)
+	} else {
+		r0 = ret.Get(0).(entity.ServiceAccountKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.ServiceAccountKeyID) error); ok {
+		r1 = rf(ctx, keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByKeyHash provides a mock function with given fields: ctx, keyHash
+func (_m *ServiceAccountKeyRepository) FindByKeyHash(ctx context.Context, keyHash string) (entity.ServiceAccountKey, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	var r0 entity.ServiceAccountKey
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.ServiceAccountKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		r0 = ret.Get(0).(entity.ServiceAccountKey)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rotate provides a mock function with given fields: ctx, k, expiresAt
+func (_m *ServiceAccountKeyRepository) Rotate(ctx context.Context, k *entity.ServiceAccountKey, expiresAt time.Time) (entity.ServiceAccountKeyID, error) {
+	ret := _m.Called(ctx, k, expiresAt)
+
+	var r0 entity.ServiceAccountKeyID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ServiceAccountKey, time.Time) entity.ServiceAccountKeyID); ok {
+		r0 = rf(ctx, k, expiresAt)
+	} else {
+		r0 = ret.Get(0).(entity.ServiceAccountKeyID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.ServiceAccountKey, time.Time) error); ok {
+		r1 = rf(ctx, k, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, k
+func (_m *ServiceAccountKeyRepository) Store(ctx context.Context, k *entity.ServiceAccountKey) (entity.ServiceAccountKeyID, error) {
+	ret := _m.Called(ctx, k)
+
+	var r0 entity.ServiceAccountKeyID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.ServiceAccountKey) entity.ServiceAccountKeyID); ok {
+		r0 = rf(ctx, k)
+	} else {
+		r0 = ret.Get(0).(entity.ServiceAccountKeyID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.ServiceAccountKey) error); ok {
+		r1 = rf(ctx, k)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Touch provides a mock function with given fields: ctx, keyID
+func (_m *ServiceAccountKeyRepository) Touch(ctx context.Context, keyID entity.ServiceAccountKeyID) error {
+	ret := _m.Called(ctx, keyID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.ServiceAccountKeyID) error); ok {
+		r0 = rf(ctx, keyID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewServiceAccountKeyRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewServiceAccountKeyRepository creates a new instance of ServiceAccountKeyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewServiceAccountKeyRepository(t mockConstructorTestingTNewServiceAccountKeyRepository) *ServiceAccountKeyRepository {
+	mock := &ServiceAccountKeyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
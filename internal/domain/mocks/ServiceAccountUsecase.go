@@ -0,0 +1,145 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ServiceAccountUsecase is an autogenerated mock type for the ServiceAccountUsecase type
+type ServiceAccountUsecase struct {
+	mock.Mock
+}
+
+// Audit provides a mock function with given fields: ctx, payload
+func (_m *ServiceAccountUsecase) Audit(ctx context.Context, payload *dto.ServiceAccountAuditIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ServiceAccountAuditIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Authenticate provides a mock function with given fields: ctx, payload
+func (_m *ServiceAccountUsecase) Authenticate(ctx context.Context, payload *dto.ServiceAccountAuthenticateIn) (dto.ServiceAccountAuthenticateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.ServiceAccountAuthenticateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ServiceAccountAuthenticateIn) dto.ServiceAccountAuthenticateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.ServiceAccountAuthenticateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.ServiceAccountAuthenticateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, payload
+func (_m *ServiceAccountUsecase) Create(ctx context.Context, payload *dto.ServiceAccountCreateIn) (dto.ServiceAccountCreateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.ServiceAccountCreateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ServiceAccountCreateIn) dto.ServiceAccountCreateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.ServiceAccountCreateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.ServiceAccountCreateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeys provides a mock function with given fields: ctx, payload
+func (_m *ServiceAccountUsecase) GetKeys(ctx context.Context, payload *dto.ServiceAccountKeyGetAllIn) ([]dto.ServiceAccountKeyGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.ServiceAccountKeyGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ServiceAccountKeyGetAllIn) []dto.ServiceAccountKeyGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.ServiceAccountKeyGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.ServiceAccountKeyGetAllIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeKey provides a mock function with given fields: ctx, payload
+func (_m *ServiceAccountUsecase) RevokeKey(ctx context.Context, payload *dto.ServiceAccountKeyRevokeIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ServiceAccountKeyRevokeIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RotateKey provides a mock function with given fields: ctx, payload
+func (_m *ServiceAccountUsecase) RotateKey(ctx context.Context, payload *dto.ServiceAccountKeyRotateIn) (dto.ServiceAccountKeyRotateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.ServiceAccountKeyRotateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.ServiceAccountKeyRotateIn) dto.ServiceAccountKeyRotateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.ServiceAccountKeyRotateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.ServiceAccountKeyRotateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewServiceAccountUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewServiceAccountUsecase creates a new instance of ServiceAccountUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewServiceAccountUsecase(t mockConstructorTestingTNewServiceAccountUsecase) *ServiceAccountUsecase {
+	mock := &ServiceAccountUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
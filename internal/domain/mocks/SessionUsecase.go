@@ -0,0 +1,82 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SessionUsecase is an autogenerated mock type for the SessionUsecase type
+type SessionUsecase struct {
+	mock.Mock
+}
+
+// GetAll provides a mock function with given fields: ctx, payload
+func (_m *SessionUsecase) GetAll(ctx context.Context, payload *dto.SessionGetAllIn) ([]dto.SessionGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.SessionGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.SessionGetAllIn) []dto.SessionGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.SessionGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.SessionGetAllIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, payload
+func (_m *SessionUsecase) Revoke(ctx context.Context, payload *dto.SessionRevokeIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.SessionRevokeIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RevokeOthers provides a mock function with given fields: ctx, payload
+func (_m *SessionUsecase) RevokeOthers(ctx context.Context, payload *dto.SessionRevokeOthersIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.SessionRevokeOthersIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewSessionUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewSessionUsecase creates a new instance of SessionUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewSessionUsecase(t mockConstructorTestingTNewSessionUsecase) *SessionUsecase {
+	mock := &SessionUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,52 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StatsUsecase is an autogenerated mock type for the StatsUsecase type
+type StatsUsecase struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, payload
+func (_m *StatsUsecase) Get(ctx context.Context, payload *dto.StatsGetIn) (dto.StatsGetOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.StatsGetOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.StatsGetIn) dto.StatsGetOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.StatsGetOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.StatsGetIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewStatsUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewStatsUsecase creates a new instance of StatsUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewStatsUsecase(t mockConstructorTestingTNewStatsUsecase) *StatsUsecase {
+	mock := &StatsUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,129 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TOTPProvider is an autogenerated mock type for the TOTPProvider type
+type TOTPProvider struct {
+	mock.Mock
+}
+
+// GenerateRecoveryCode provides a mock function with given fields:
+func (_m *TOTPProvider) GenerateRecoveryCode() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GenerateSecret provides a mock function with given fields:
+func (_m *TOTPProvider) GenerateSecret() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// QRCode provides a mock function with given fields: uri
+func (_m *TOTPProvider) QRCode(uri string) ([]byte, error) {
+	ret := _m.Called(uri)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(string) []byte); ok {
+		r0 = rf(uri)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(uri)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// URI provides a mock function with given fields: secret, account
+func (_m *TOTPProvider) URI(secret string, account string) string {
+	ret := _m.Called(secret, account)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(secret, account)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Validate provides a mock function with given fields: secret, code, at
+func (_m *TOTPProvider) Validate(secret string, code string, at time.Time) (int64, bool) {
+	ret := _m.Called(secret, code, at)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(string, string, time.Time) int64); ok {
+		r0 = rf(secret, code, at)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(string, string, time.Time) bool); ok {
+		r1 = rf(secret, code, at)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTOTPProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTOTPProvider creates a new instance of TOTPProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTOTPProvider(t mockConstructorTestingTNewTOTPProvider) *TOTPProvider {
+	mock := &TOTPProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
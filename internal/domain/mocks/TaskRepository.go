@@ -29,6 +29,29 @@ func (_m *TaskRepository) DeleteByID(ctx context.Context, taskID entity.TaskID)
 	return r0
 }
 
+// FindAllByFilter provides a mock function with given fields: ctx, filter
+func (_m *TaskRepository) FindAllByFilter(ctx context.Context, filter entity.TaskFilter) ([]entity.Task, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []entity.Task
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TaskFilter) []entity.Task); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Task)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.TaskFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindAllByUserID provides a mock function with given fields: ctx, userID
 func (_m *TaskRepository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Task, error) {
 	ret := _m.Called(ctx, userID)
@@ -94,6 +117,29 @@ func (_m *TaskRepository) Store(ctx context.Context, t *entity.Task) (entity.Tas
 	return r0, r1
 }
 
+// StoreMany provides a mock function with given fields: ctx, tasks
+func (_m *TaskRepository) StoreMany(ctx context.Context, tasks []entity.Task) ([]entity.TaskID, error) {
+	ret := _m.Called(ctx, tasks)
+
+	var r0 []entity.TaskID
+	if rf, ok := ret.Get(0).(func(context.Context, []entity.Task) []entity.TaskID); ok {
+		r0 = rf(ctx, tasks)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.TaskID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []entity.Task) error); ok {
+		r1 = rf(ctx, tasks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, t
 func (_m *TaskRepository) Update(ctx context.Context, t *entity.Task) (entity.TaskID, error) {
 	ret := _m.Called(ctx, t)
@@ -94,6 +94,41 @@ func (_m *TaskUsecase) Remove(ctx context.Context, payload *dto.TaskRemoveIn) er
 	return r0
 }
 
+// Snooze provides a mock function with given fields: ctx, payload
+func (_m *TaskUsecase) Snooze(ctx context.Context, payload *dto.TaskSnoozeIn) (dto.TaskSnoozeOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.TaskSnoozeOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TaskSnoozeIn) dto.TaskSnoozeOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.TaskSnoozeOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TaskSnoozeIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unsnooze provides a mock function with given fields: ctx, payload
+func (_m *TaskUsecase) Unsnooze(ctx context.Context, payload *dto.TaskUnsnoozeIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TaskUnsnoozeIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, payload
 func (_m *TaskUsecase) Update(ctx context.Context, payload *dto.TaskUpdateIn) (dto.TaskUpdateOut, error) {
 	ret := _m.Called(ctx, payload)
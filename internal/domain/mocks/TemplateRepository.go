@@ -0,0 +1,131 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TemplateRepository is an autogenerated mock type for the TemplateRepository type
+type TemplateRepository struct {
+	mock.Mock
+}
+
+// DeleteByID provides a mock function with given fields: ctx, templateID
+func (_m *TemplateRepository) DeleteByID(ctx context.Context, templateID entity.TemplateID) error {
+	ret := _m.Called(ctx, templateID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TemplateID) error); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAllByUserID provides a mock function with given fields: ctx, userID
+func (_m *TemplateRepository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Template, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 []entity.Template
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) []entity.Template); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.Template)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindByID provides a mock function with given fields: ctx, templateID
+func (_m *TemplateRepository) FindByID(ctx context.Context, templateID entity.TemplateID) (entity.Template, error) {
+	ret := _m.Called(ctx, templateID)
+
+	var r0 entity.Template
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TemplateID) entity.Template); ok {
+		r0 = rf(ctx, templateID)
+	} else {
+		r0 = ret.Get(0).(entity.Template)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.TemplateID) error); ok {
+		r1 = rf(ctx, templateID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, t
+func (_m *TemplateRepository) Store(ctx context.Context, t *entity.Template) (entity.TemplateID, error) {
+	ret := _m.Called(ctx, t)
+
+	var r0 entity.TemplateID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Template) entity.TemplateID); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(entity.TemplateID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Template) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, t
+func (_m *TemplateRepository) Update(ctx context.Context, t *entity.Template) (entity.TemplateID, error) {
+	ret := _m.Called(ctx, t)
+
+	var r0 entity.TemplateID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Template) entity.TemplateID); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Get(0).(entity.TemplateID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Template) error); ok {
+		r1 = rf(ctx, t)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTemplateRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTemplateRepository creates a new instance of TemplateRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTemplateRepository(t mockConstructorTestingTNewTemplateRepository) *TemplateRepository {
+	mock := &TemplateRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
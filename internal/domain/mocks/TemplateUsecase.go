@@ -0,0 +1,173 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TemplateUsecase is an autogenerated mock type for the TemplateUsecase type
+type TemplateUsecase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, payload
+func (_m *TemplateUsecase) Create(ctx context.Context, payload *dto.TemplateCreateIn) (dto.TemplateCreateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.TemplateCreateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TemplateCreateIn) dto.TemplateCreateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.TemplateCreateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TemplateCreateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateFromTasks provides a mock function with given fields: ctx, payload
+func (_m *TemplateUsecase) CreateFromTasks(ctx context.Context, payload *dto.TemplateCreateFromTasksIn) (dto.TemplateCreateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.TemplateCreateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TemplateCreateFromTasksIn) dto.TemplateCreateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.TemplateCreateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TemplateCreateFromTasksIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, payload
+func (_m *TemplateUsecase) GetAll(ctx context.Context, payload *dto.TemplateGetAllIn) ([]dto.TemplateGetAllOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 []dto.TemplateGetAllOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TemplateGetAllIn) []dto.TemplateGetAllOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]dto.TemplateGetAllOut)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TemplateGetAllIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, payload
+func (_m *TemplateUsecase) GetByID(ctx context.Context, payload *dto.TemplateGetByIDIn) (dto.TemplateGetByIDOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.TemplateGetByIDOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TemplateGetByIDIn) dto.TemplateGetByIDOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.TemplateGetByIDOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TemplateGetByIDIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Instantiate provides a mock function with given fields: ctx, payload
+func (_m *TemplateUsecase) Instantiate(ctx context.Context, payload *dto.TemplateInstantiateIn) (dto.TemplateInstantiateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.TemplateInstantiateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TemplateInstantiateIn) dto.TemplateInstantiateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.TemplateInstantiateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TemplateInstantiateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Remove provides a mock function with given fields: ctx, payload
+func (_m *TemplateUsecase) Remove(ctx context.Context, payload *dto.TemplateRemoveIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TemplateRemoveIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, payload
+func (_m *TemplateUsecase) Update(ctx context.Context, payload *dto.TemplateUpdateIn) (dto.TemplateUpdateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.TemplateUpdateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TemplateUpdateIn) dto.TemplateUpdateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.TemplateUpdateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TemplateUpdateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTemplateUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTemplateUsecase creates a new instance of TemplateUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTemplateUsecase(t mockConstructorTestingTNewTemplateUsecase) *TemplateUsecase {
+	mock := &TemplateUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
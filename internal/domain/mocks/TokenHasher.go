@@ -0,0 +1,39 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// TokenHasher is an autogenerated mock type for the TokenHasher type
+type TokenHasher struct {
+	mock.Mock
+}
+
+// Hash provides a mock function with given fields: raw
+func (_m *TokenHasher) Hash(raw string) string {
+	ret := _m.Called(raw)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(raw)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTokenHasher interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenHasher creates a new instance of TokenHasher. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenHasher(t mockConstructorTestingTNewTokenHasher) *TokenHasher {
+	mock := &TokenHasher{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
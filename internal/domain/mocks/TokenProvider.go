@@ -0,0 +1,60 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import mock "github.com/stretchr/testify/mock"
+
+// TokenProvider is an autogenerated mock type for the TokenProvider type
+type TokenProvider struct {
+	mock.Mock
+}
+
+// Generate provides a mock function with given fields:
+func (_m *TokenProvider) Generate() (string, error) {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Hash provides a mock function with given fields: raw
+func (_m *TokenProvider) Hash(raw string) string {
+	ret := _m.Called(raw)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(raw)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTokenProvider interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTokenProvider creates a new instance of TokenProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTokenProvider(t mockConstructorTestingTNewTokenProvider) *TokenProvider {
+	mock := &TokenProvider{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,222 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// TwoFactorRepository is an autogenerated mock type for the TwoFactorRepository type
+type TwoFactorRepository struct {
+	mock.Mock
+}
+
+// ConsumeRecoveryCode provides a mock function with given fields: ctx, userID, codeHash
+func (_m *TwoFactorRepository) ConsumeRecoveryCode(ctx context.Context, userID entity.UserID, codeHash string) error {
+	ret := _m.Called(ctx, userID, codeHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, string) error); ok {
+		r0 = rf(ctx, userID, codeHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorRepository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteChallengeByID provides a mock function with given fields: ctx, challengeID
+func (_m *TwoFactorRepository) DeleteChallengeByID(ctx context.Context, challengeID entity.MFAChallengeID) error {
+	ret := _m.Called(ctx, challengeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.MFAChallengeID) error); ok {
+		r0 = rf(ctx, challengeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteExpiredChallenges provides a mock function with given fields: ctx, before, limit
+func (_m *TwoFactorRepository) DeleteExpiredChallenges(ctx context.Context, before time.Time, limit int) (int64, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) int64); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Enable provides a mock function with given fields: ctx, userID, step
+func (_m *TwoFactorRepository) Enable(ctx context.Context, userID entity.UserID, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindByUserID provides a mock function with given fields: ctx, userID
+func (_m *TwoFactorRepository) FindByUserID(ctx context.Context, userID entity.UserID) (entity.TwoFactor, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 entity.TwoFactor
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) entity.TwoFactor); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.TwoFactor)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindChallengeByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *TwoFactorRepository) FindChallengeByTokenHash(ctx context.Context, tokenHash string) (entity.MFAChallenge, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 entity.MFAChallenge
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.MFAChallenge); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.MFAChallenge)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IncrementChallengeAttempts provides a mock function with given fields: ctx, challengeID
+func (_m *TwoFactorRepository) IncrementChallengeAttempts(ctx context.Context, challengeID entity.MFAChallengeID) error {
+	ret := _m.Called(ctx, challengeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.MFAChallengeID) error); ok {
+		r0 = rf(ctx, challengeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Store provides a mock function with given fields: ctx, t
+func (_m *TwoFactorRepository) Store(ctx context.Context, t *entity.TwoFactor) error {
+	ret := _m.Called(ctx, t)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.TwoFactor) error); ok {
+		r0 = rf(ctx, t)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreChallenge provides a mock function with given fields: ctx, c
+func (_m *TwoFactorRepository) StoreChallenge(ctx context.Context, c *entity.MFAChallenge) error {
+	ret := _m.Called(ctx, c)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.MFAChallenge) error); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StoreRecoveryCodes provides a mock function with given fields: ctx, userID, codeHashes
+func (_m *TwoFactorRepository) StoreRecoveryCodes(ctx context.Context, userID entity.UserID, codeHashes []string) error {
+	ret := _m.Called(ctx, userID, codeHashes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, []string) error); ok {
+		r0 = rf(ctx, userID, codeHashes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseStep provides a mock function with given fields: ctx, userID, step
+func (_m *TwoFactorRepository) UseStep(ctx context.Context, userID entity.UserID, step int64) error {
+	ret := _m.Called(ctx, userID, step)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, int64) error); ok {
+		r0 = rf(ctx, userID, step)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewTwoFactorRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTwoFactorRepository creates a new instance of TwoFactorRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTwoFactorRepository(t mockConstructorTestingTNewTwoFactorRepository) *TwoFactorRepository {
+	mock := &TwoFactorRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,87 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	dto "github.com/edwintantawi/taskit/internal/domain/dto"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TwoFactorUsecase is an autogenerated mock type for the TwoFactorUsecase type
+type TwoFactorUsecase struct {
+	mock.Mock
+}
+
+// Disable provides a mock function with given fields: ctx, payload
+func (_m *TwoFactorUsecase) Disable(ctx context.Context, payload *dto.TwoFactorDisableIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TwoFactorDisableIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Enable provides a mock function with given fields: ctx, payload
+func (_m *TwoFactorUsecase) Enable(ctx context.Context, payload *dto.TwoFactorEnableIn) (dto.TwoFactorEnableOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.TwoFactorEnableOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TwoFactorEnableIn) dto.TwoFactorEnableOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.TwoFactorEnableOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TwoFactorEnableIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Setup provides a mock function with given fields: ctx, payload
+func (_m *TwoFactorUsecase) Setup(ctx context.Context, payload *dto.TwoFactorSetupIn) (dto.TwoFactorSetupOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.TwoFactorSetupOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.TwoFactorSetupIn) dto.TwoFactorSetupOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.TwoFactorSetupOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.TwoFactorSetupIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewTwoFactorUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewTwoFactorUsecase creates a new instance of TwoFactorUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewTwoFactorUsecase(t mockConstructorTestingTNewTwoFactorUsecase) *TwoFactorUsecase {
+	mock := &TwoFactorUsecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -15,6 +15,64 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CountUsage provides a mock function with given fields: ctx, id
+func (_m *UserRepository) CountUsage(ctx context.Context, id entity.UserID) (entity.UserUsage, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 entity.UserUsage
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) entity.UserUsage); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(entity.UserUsage)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *UserRepository) Delete(ctx context.Context, id entity.UserID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAll provides a mock function with given fields: ctx, search, limit, offset
+func (_m *UserRepository) FindAll(ctx context.Context, search string, limit int, offset int) ([]entity.User, error) {
+	ret := _m.Called(ctx, search, limit, offset)
+
+	var r0 []entity.User
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []entity.User); ok {
+		r0 = rf(ctx, search, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, search, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindByEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) FindByEmail(ctx context.Context, email string) (entity.User, error) {
 	ret := _m.Called(ctx, email)
@@ -57,6 +115,20 @@ func (_m *UserRepository) FindByID(ctx context.Context, id entity.UserID) (entit
 	return r0, r1
 }
 
+// MarkEmailVerified provides a mock function with given fields: ctx, id
+func (_m *UserRepository) MarkEmailVerified(ctx context.Context, id entity.UserID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Store provides a mock function with given fields: ctx, u
 func (_m *UserRepository) Store(ctx context.Context, u *entity.User) (entity.UserID, error) {
 	ret := _m.Called(ctx, u)
@@ -78,6 +150,97 @@ func (_m *UserRepository) Store(ctx context.Context, u *entity.User) (entity.Use
 	return r0, r1
 }
 
+// StoreServiceAccount provides a mock function with given fields: ctx, u
+func (_m *UserRepository) StoreServiceAccount(ctx context.Context, u *entity.User) (entity.UserID, error) {
+	ret := _m.Called(ctx, u)
+
+	var r0 entity.UserID
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) entity.UserID); ok {
+		r0 = rf(ctx, u)
+	} else {
+		r0 = ret.Get(0).(entity.UserID)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.User) error); ok {
+		r1 = rf(ctx, u)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Suspend provides a mock function with given fields: ctx, id
+func (_m *UserRepository) Suspend(ctx context.Context, id entity.UserID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unsuspend provides a mock function with given fields: ctx, id
+func (_m *UserRepository) Unsuspend(ctx context.Context, id entity.UserID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, u
+func (_m *UserRepository) Update(ctx context.Context, u *entity.User) error {
+	ret := _m.Called(ctx, u)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
+		r0 = rf(ctx, u)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePassword provides a mock function with given fields: ctx, id, password
+func (_m *UserRepository) UpdatePassword(ctx context.Context, id entity.UserID, password string) error {
+	ret := _m.Called(ctx, id, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, string) error); ok {
+		r0 = rf(ctx, id, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateRole provides a mock function with given fields: ctx, id, role
+func (_m *UserRepository) UpdateRole(ctx context.Context, id entity.UserID, role entity.Role) error {
+	ret := _m.Called(ctx, id, role)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID, entity.Role) error); ok {
+		r0 = rf(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // VerifyAvailableEmail provides a mock function with given fields: ctx, email
 func (_m *UserRepository) VerifyAvailableEmail(ctx context.Context, email string) error {
 	ret := _m.Called(ctx, email)
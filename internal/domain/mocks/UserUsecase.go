@@ -15,6 +15,27 @@ type UserUsecase struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: ctx, payload
+func (_m *UserUsecase) ChangePassword(ctx context.Context, payload *dto.UserChangePasswordIn) (dto.UserChangePasswordOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.UserChangePasswordOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.UserChangePasswordIn) dto.UserChangePasswordOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.UserChangePasswordOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.UserChangePasswordIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, payload
 func (_m *UserUsecase) Create(ctx context.Context, payload *dto.UserCreateIn) (dto.UserCreateOut, error) {
 	ret := _m.Called(ctx, payload)
@@ -36,6 +57,97 @@ func (_m *UserUsecase) Create(ctx context.Context, payload *dto.UserCreateIn) (d
 	return r0, r1
 }
 
+// Delete provides a mock function with given fields: ctx, payload
+func (_m *UserUsecase) Delete(ctx context.Context, payload *dto.UserDeleteIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.UserDeleteIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureRole provides a mock function with given fields: ctx, payload
+func (_m *UserUsecase) EnsureRole(ctx context.Context, payload *dto.UserEnsureRoleIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.UserEnsureRoleIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnsureVerified provides a mock function with given fields: ctx, payload
+func (_m *UserUsecase) EnsureVerified(ctx context.Context, payload *dto.UserEnsureVerifiedIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.UserEnsureVerifiedIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResendVerification provides a mock function with given fields: ctx, payload
+func (_m *UserUsecase) ResendVerification(ctx context.Context, payload *dto.UserResendVerificationIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.UserResendVerificationIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, payload
+func (_m *UserUsecase) Update(ctx context.Context, payload *dto.UserUpdateIn) (dto.UserUpdateOut, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 dto.UserUpdateOut
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.UserUpdateIn) dto.UserUpdateOut); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Get(0).(dto.UserUpdateOut)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *dto.UserUpdateIn) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verify provides a mock function with given fields: ctx, payload
+func (_m *UserUsecase) Verify(ctx context.Context, payload *dto.UserVerifyIn) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.UserVerifyIn) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewUserUsecase interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,101 @@
+// Code generated by mockery v2.15.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entity "github.com/edwintantawi/taskit/internal/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VerificationRepository is an autogenerated mock type for the VerificationRepository type
+type VerificationRepository struct {
+	mock.Mock
+}
+
+// Consume provides a mock function with given fields: ctx, tokenHash
+func (_m *VerificationRepository) Consume(ctx context.Context, tokenHash string) (entity.EmailVerification, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	var r0 entity.EmailVerification
+	if rf, ok := ret.Get(0).(func(context.Context, string) entity.EmailVerification); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		r0 = ret.Get(0).(entity.EmailVerification)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteByUserID provides a mock function with given fields: ctx, userID
+func (_m *VerificationRepository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	ret := _m.Called(ctx, userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) error); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindLatestByUserID provides a mock function with given fields: ctx, userID
+func (_m *VerificationRepository) FindLatestByUserID(ctx context.Context, userID entity.UserID) (entity.EmailVerification, error) {
+	ret := _m.Called(ctx, userID)
+
+	var r0 entity.EmailVerification
+	if rf, ok := ret.Get(0).(func(context.Context, entity.UserID) entity.EmailVerification); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(entity.EmailVerification)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, entity.UserID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Store provides a mock function with given fields: ctx, v
+func (_m *VerificationRepository) Store(ctx context.Context, v *entity.EmailVerification) error {
+	ret := _m.Called(ctx, v)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.EmailVerification) error); ok {
+		r0 = rf(ctx, v)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewVerificationRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewVerificationRepository creates a new instance of VerificationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewVerificationRepository(t mockConstructorTestingTNewVerificationRepository) *VerificationRepository {
+	mock := &VerificationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
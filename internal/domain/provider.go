@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"time"
 
 	"github.com/edwintantawi/taskit/internal/domain/entity"
@@ -15,13 +16,20 @@ type IDProvider interface {
 type HashProvider interface {
 	Hash(raw string) ([]byte, error)
 	Compare(raw string, hashed string) error
+	NeedsRehash(hashed string) bool
+}
+
+// PasswordPolicyProvider represent password policy contract.
+type PasswordPolicyProvider interface {
+	Check(password string, user *entity.User) error
 }
 
 // JWTProvider represent jwt generator contract.
 type JWTProvider interface {
-	GenerateAccessToken(userID entity.UserID) (string, time.Time, error)
+	GenerateAccessToken(userID entity.UserID, sessionID entity.AuthID) (string, time.Time, error)
 	GenerateRefreshToken(userID entity.UserID) (string, time.Time, error)
-	VerifyAccessToken(rawToken string) (entity.UserID, error)
+	VerifyAccessToken(rawToken string) (entity.AuthClaims, error)
+	PublicKeys() []entity.JWK
 }
 
 // Validater represent object with validate method.
@@ -33,3 +41,46 @@ type Validater interface {
 type ValidatorProvider interface {
 	Validate(validater Validater) error
 }
+
+// TokenProvider represent opaque token generator contract.
+type TokenProvider interface {
+	Generate() (string, error)
+	Hash(raw string) string
+}
+
+// TokenHasher represent keyed token hasher contract.
+type TokenHasher interface {
+	Hash(raw string) string
+}
+
+// TOTPProvider represent time based one-time password contract.
+type TOTPProvider interface {
+	GenerateSecret() (string, error)
+	GenerateRecoveryCode() (string, error)
+	URI(secret string, account string) string
+	QRCode(uri string) ([]byte, error)
+	Validate(secret string, code string, at time.Time) (int64, bool)
+}
+
+// OIDCProvider represent OpenID Connect relying party contract.
+type OIDCProvider interface {
+	AuthCodeURL(ctx context.Context, provider string, state string, nonce string, codeVerifier string) (string, error)
+	Exchange(ctx context.Context, provider string, code string, codeVerifier string, nonce string) (entity.OIDCClaims, error)
+}
+
+// MetricsProvider represent metrics recorder contract.
+type MetricsProvider interface {
+	Add(name string, delta int64)
+	Set(name string, value int64)
+}
+
+// RateLimiter represent request rate limiter contract.
+// Allow reports whether a request of the key is allowed, and how long to wait when it is not.
+type RateLimiter interface {
+	Allow(key string) (bool, time.Duration)
+}
+
+// Mailer represent mail sender contract.
+type Mailer interface {
+	Send(ctx context.Context, mail *entity.Mail) error
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 )
@@ -23,20 +24,198 @@ var (
 	ErrTaskNotFound = errors.New("task.repository.task_not_found")
 )
 
+// Template repository errors.
+var (
+	ErrTemplateNotFound = errors.New("template.repository.template_not_found")
+)
+
+// Filter repository errors.
+var (
+	ErrFilterNotFound = errors.New("filter.repository.filter_not_found")
+)
+
+// Verification repository errors.
+var (
+	ErrVerificationNotFound = errors.New("verification.repository.verification_not_found")
+)
+
+// Password reset repository errors.
+var (
+	ErrPasswordResetNotFound = errors.New("password_reset.repository.password_reset_not_found")
+)
+
+// Magic link repository errors.
+var (
+	ErrMagicLinkNotFound = errors.New("magic_link.repository.magic_link_not_found")
+)
+
+// Login method repository errors.
+var (
+	ErrLoginMethodNotFound = errors.New("login_method.repository.method_not_found")
+)
+
+// Two factor repository errors.
+var (
+	ErrTwoFactorNotFound    = errors.New("two_factor.repository.two_factor_not_found")
+	ErrTwoFactorStepUsed    = errors.New("two_factor.repository.step_used")
+	ErrRecoveryCodeNotFound = errors.New("two_factor.repository.recovery_code_not_found")
+	ErrMFAChallengeNotFound = errors.New("two_factor.repository.challenge_not_found")
+)
+
+// Identity repository errors.
+var (
+	ErrUserIdentityNotFound = errors.New("identity.repository.identity_not_found")
+	ErrOIDCStateNotFound    = errors.New("identity.repository.state_not_found")
+)
+
+// Personal token repository errors.
+var (
+	ErrPersonalTokenNotFound = errors.New("personal_token.repository.token_not_found")
+)
+
+// Service account repository errors.
+var (
+	ErrServiceAccountKeyNotFound = errors.New("service_account.repository.key_not_found")
+)
+
+// Checklist repository errors.
+var (
+	ErrChecklistItemNotFound = errors.New("checklist.repository.item_not_found")
+)
+
 // UserRepository represent user repository contract.
 type UserRepository interface {
 	Store(ctx context.Context, u *entity.User) (entity.UserID, error)
+	StoreServiceAccount(ctx context.Context, u *entity.User) (entity.UserID, error)
 	VerifyAvailableEmail(ctx context.Context, email string) error
 	FindByEmail(ctx context.Context, email string) (entity.User, error)
 	FindByID(ctx context.Context, id entity.UserID) (entity.User, error)
+	FindAll(ctx context.Context, search string, limit int, offset int) ([]entity.User, error)
+	CountUsage(ctx context.Context, id entity.UserID) (entity.UserUsage, error)
+	MarkEmailVerified(ctx context.Context, id entity.UserID) error
+	UpdatePassword(ctx context.Context, id entity.UserID, password string) error
+	Update(ctx context.Context, u *entity.User) error
+	UpdateRole(ctx context.Context, id entity.UserID, role entity.Role) error
+	Suspend(ctx context.Context, id entity.UserID) error
+	Unsuspend(ctx context.Context, id entity.UserID) error
+	Delete(ctx context.Context, id entity.UserID) error
+}
+
+// VerificationRepository represent email verification repository contract.
+type VerificationRepository interface {
+	Store(ctx context.Context, v *entity.EmailVerification) error
+	FindLatestByUserID(ctx context.Context, userID entity.UserID) (entity.EmailVerification, error)
+	Consume(ctx context.Context, tokenHash string) (entity.EmailVerification, error)
+	DeleteByUserID(ctx context.Context, userID entity.UserID) error
+}
+
+// PasswordResetRepository represent password reset repository contract.
+type PasswordResetRepository interface {
+	Store(ctx context.Context, p *entity.PasswordReset) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (entity.PasswordReset, error)
+	Consume(ctx context.Context, tokenHash string) (entity.PasswordReset, error)
+	DeleteByUserID(ctx context.Context, userID entity.UserID) error
+}
+
+// MagicLinkRepository represent magic link repository contract.
+type MagicLinkRepository interface {
+	Store(ctx context.Context, m *entity.MagicLink) error
+	Consume(ctx context.Context, tokenHash string) (entity.MagicLink, error)
+	DeleteByUserID(ctx context.Context, userID entity.UserID) error
+}
+
+// LoginMethodRepository represent login method setting repository contract.
+type LoginMethodRepository interface {
+	FindAll(ctx context.Context) ([]entity.LoginMethodSetting, error)
+	IsEnabled(ctx context.Context, method entity.LoginMethod) (bool, error)
+	Update(ctx context.Context, method entity.LoginMethod, enabled bool) error
 }
 
 // AuthRepository represent auth repository contract.
 type AuthRepository interface {
-	Store(ctx context.Context, a *entity.Auth) error
+	Store(ctx context.Context, a *entity.Auth) (entity.AuthID, error)
 	VerifyAvailableByToken(ctx context.Context, token string) error
 	DeleteByToken(ctx context.Context, token string) error
 	FindByToken(ctx context.Context, token string) (entity.Auth, error)
+	FindByID(ctx context.Context, authID entity.AuthID) (entity.Auth, error)
+	FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Auth, error)
+	FindByRotatedToken(ctx context.Context, token string) (entity.Auth, error)
+	Rotate(ctx context.Context, previousToken string, a *entity.Auth) error
+	DeleteByID(ctx context.Context, authID entity.AuthID) error
+	DeleteByUserID(ctx context.Context, userID entity.UserID) error
+	DeleteOthersByUserID(ctx context.Context, userID entity.UserID, authID entity.AuthID) error
+	DeleteOldestByUserID(ctx context.Context, userID entity.UserID, keep int) error
+	DeleteExpired(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// TwoFactorRepository represent two-factor authentication repository contract.
+type TwoFactorRepository interface {
+	Store(ctx context.Context, t *entity.TwoFactor) error
+	FindByUserID(ctx context.Context, userID entity.UserID) (entity.TwoFactor, error)
+	Enable(ctx context.Context, userID entity.UserID, step int64) error
+	UseStep(ctx context.Context, userID entity.UserID, step int64) error
+	DeleteByUserID(ctx context.Context, userID entity.UserID) error
+	StoreRecoveryCodes(ctx context.Context, userID entity.UserID, codeHashes []string) error
+	ConsumeRecoveryCode(ctx context.Context, userID entity.UserID, codeHash string) error
+	StoreChallenge(ctx context.Context, c *entity.MFAChallenge) error
+	FindChallengeByTokenHash(ctx context.Context, tokenHash string) (entity.MFAChallenge, error)
+	IncrementChallengeAttempts(ctx context.Context, challengeID entity.MFAChallengeID) error
+	DeleteChallengeByID(ctx context.Context, challengeID entity.MFAChallengeID) error
+	DeleteExpiredChallenges(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// IdentityRepository represent external identity repository contract.
+type IdentityRepository interface {
+	Store(ctx context.Context, i *entity.UserIdentity) error
+	FindByProviderSubject(ctx context.Context, provider string, subject string) (entity.UserIdentity, error)
+	StoreState(ctx context.Context, s *entity.OIDCState) error
+	ConsumeState(ctx context.Context, stateHash string) (entity.OIDCState, error)
+	DeleteExpiredStates(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// PersonalTokenRepository represent personal access token repository contract.
+type PersonalTokenRepository interface {
+	Store(ctx context.Context, t *entity.PersonalToken) (entity.PersonalTokenID, error)
+	FindByID(ctx context.Context, tokenID entity.PersonalTokenID) (entity.PersonalToken, error)
+	FindByTokenHash(ctx context.Context, tokenHash string) (entity.PersonalToken, error)
+	FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.PersonalToken, error)
+	Touch(ctx context.Context, tokenID entity.PersonalTokenID) error
+	DeleteByID(ctx context.Context, tokenID entity.PersonalTokenID) error
+}
+
+// ServiceAccountKeyRepository represent service account API key repository contract.
+type ServiceAccountKeyRepository interface {
+	Store(ctx context.Context, k *entity.ServiceAccountKey) (entity.ServiceAccountKeyID, error)
+	FindByID(ctx context.Context, keyID entity.ServiceAccountKeyID) (entity.ServiceAccountKey, error)
+	FindByKeyHash(ctx context.Context, keyHash string) (entity.ServiceAccountKey, error)
+	FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.ServiceAccountKey, error)
+	Rotate(ctx context.Context, k *entity.ServiceAccountKey, expiresAt time.Time) (entity.ServiceAccountKeyID, error)
+	Touch(ctx context.Context, keyID entity.ServiceAccountKeyID) error
+	DeleteByID(ctx context.Context, keyID entity.ServiceAccountKeyID) error
+}
+
+// SecurityEventRepository represent security event repository contract.
+type SecurityEventRepository interface {
+	Store(ctx context.Context, e *entity.SecurityEvent) error
+	FindAll(ctx context.Context, filter entity.SecurityEventFilter, limit int, offset int) ([]entity.SecurityEvent, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// LoginAttemptRepository represent failed login attempt repository contract.
+type LoginAttemptRepository interface {
+	Store(ctx context.Context, a *entity.LoginAttempt) error
+	CountSince(ctx context.Context, scope entity.LoginAttemptScope, identifier string, since time.Time) (entity.LoginFailures, error)
+	DeleteByIdentifier(ctx context.Context, scope entity.LoginAttemptScope, identifier string) error
+	DeleteOlderThan(ctx context.Context, before time.Time, limit int) (int64, error)
+}
+
+// RevocationRepository represent access token revocation repository contract.
+type RevocationRepository interface {
+	Store(ctx context.Context, t *entity.RevokedToken) error
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+	StoreWatermark(ctx context.Context, userID entity.UserID, revokedBefore time.Time) error
+	FindWatermark(ctx context.Context, userID entity.UserID) (time.Time, error)
+	DeleteExpired(ctx context.Context, watermarkBefore time.Time) (int64, error)
 }
 
 // TaskRepository represent task repository contract.
@@ -44,7 +223,38 @@ type TaskRepository interface {
 	Store(ctx context.Context, t *entity.Task) (entity.TaskID, error)
 	FindByID(ctx context.Context, taskID entity.TaskID) (entity.Task, error)
 	FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Task, error)
+	FindAllByFilter(ctx context.Context, filter entity.TaskFilter) ([]entity.Task, error)
 	VerifyAvailableByID(ctx context.Context, taskID entity.TaskID) error
 	DeleteByID(ctx context.Context, taskID entity.TaskID) error
 	Update(ctx context.Context, t *entity.Task) (entity.TaskID, error)
+	StoreMany(ctx context.Context, tasks []entity.Task) ([]entity.TaskID, error)
+}
+
+// TemplateRepository represent template repository contract.
+type TemplateRepository interface {
+	Store(ctx context.Context, t *entity.Template) (entity.TemplateID, error)
+	FindByID(ctx context.Context, templateID entity.TemplateID) (entity.Template, error)
+	FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Template, error)
+	Update(ctx context.Context, t *entity.Template) (entity.TemplateID, error)
+	DeleteByID(ctx context.Context, templateID entity.TemplateID) error
+}
+
+// FilterRepository represent filter repository contract.
+type FilterRepository interface {
+	Store(ctx context.Context, f *entity.Filter) (entity.FilterID, error)
+	FindByID(ctx context.Context, filterID entity.FilterID) (entity.Filter, error)
+	FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Filter, error)
+	Update(ctx context.Context, f *entity.Filter) (entity.FilterID, error)
+	DeleteByID(ctx context.Context, filterID entity.FilterID) error
+	UpdatePositions(ctx context.Context, filterIDs []entity.FilterID) error
+}
+
+// ChecklistRepository represent checklist repository contract.
+type ChecklistRepository interface {
+	Store(ctx context.Context, c *entity.ChecklistItem) (entity.ChecklistItemID, error)
+	FindByID(ctx context.Context, itemID entity.ChecklistItemID) (entity.ChecklistItem, error)
+	FindAllByTaskIDs(ctx context.Context, taskIDs []entity.TaskID) ([]entity.ChecklistItem, error)
+	Update(ctx context.Context, c *entity.ChecklistItem) (entity.ChecklistItemID, error)
+	DeleteByID(ctx context.Context, itemID entity.ChecklistItemID) error
+	UpdatePositions(ctx context.Context, itemIDs []entity.ChecklistItemID) error
 }
@@ -3,24 +3,150 @@ package domain
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 )
 
+// User usecase errors.
+var (
+	ErrEmailNotVerified         = errors.New("user.usecase.email_not_verified")
+	ErrEmailAlreadyVerified     = errors.New("user.usecase.email_already_verified")
+	ErrVerificationTokenInvalid = errors.New("user.usecase.verification_token_invalid")
+	ErrVerificationThrottled    = errors.New("user.usecase.verification_throttled")
+	ErrRoleForbidden            = errors.New("user.usecase.role_forbidden")
+)
+
 // Auth usecase errors.
 var (
-	ErrEmailNotExist     = errors.New("auth.usecase.email_not_exist")
-	ErrPasswordIncorrect = errors.New("auth.usecase.password_incorrect")
+	ErrCredentialsInvalid    = errors.New("auth.usecase.credentials_invalid")
+	ErrPasswordIncorrect     = errors.New("auth.usecase.password_incorrect")
+	ErrAuthTokenReused       = errors.New("auth.usecase.token_reused")
+	ErrOIDCStateInvalid      = errors.New("auth.usecase.oidc_state_invalid")
+	ErrOIDCEmailMissing      = errors.New("auth.usecase.oidc_email_missing")
+	ErrOIDCEmailConflict     = errors.New("auth.usecase.oidc_email_conflict")
+	ErrOIDCEmailUnverified   = errors.New("auth.usecase.oidc_email_unverified")
+	ErrLoginMethodDisabled   = errors.New("auth.usecase.login_method_disabled")
+	ErrMagicLinkTokenInvalid = errors.New("auth.usecase.magic_link_token_invalid")
+)
+
+// LoginThrottledError is returned instead of checking the credentials
+// while an account or an ip address has too many failed login attempts.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return "auth.usecase.login_throttled"
+}
+
+// Two factor usecase errors.
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two_factor.usecase.already_enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two_factor.usecase.not_enabled")
+	ErrTwoFactorNotSetup       = errors.New("two_factor.usecase.not_setup")
+	ErrTwoFactorCodeInvalid    = errors.New("two_factor.usecase.code_invalid")
+	ErrMFAChallengeInvalid     = errors.New("two_factor.usecase.challenge_invalid")
+)
+
+// Password reset usecase errors.
+var (
+	ErrPasswordResetTokenInvalid = errors.New("password_reset.usecase.token_invalid")
+)
+
+// MagicLinkThrottledError is returned instead of mailing a login link
+// while an email or an ip address requested too many of them.
+type MagicLinkThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *MagicLinkThrottledError) Error() string {
+	return "auth.usecase.magic_link_throttled"
+}
+
+// PasswordResetThrottledError is returned instead of sending a password reset email
+// while an email or an ip address requested too many of them.
+type PasswordResetThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *PasswordResetThrottledError) Error() string {
+	return "password_reset.usecase.throttled"
+}
+
+// Session usecase errors.
+var (
+	ErrSessionAuthorization = errors.New("session.usecase.session_forbidden")
+	ErrSessionUnknown       = errors.New("session.usecase.session_unknown")
+)
+
+// Personal token usecase errors.
+var (
+	ErrPersonalTokenAuthorization = errors.New("personal_token.usecase.token_forbidden")
+	ErrPersonalTokenInvalid       = errors.New("personal_token.usecase.token_invalid")
+	ErrPersonalTokenExpiryInPast  = errors.New("personal_token.usecase.expiry_in_past")
+)
+
+// Service account usecase errors.
+var (
+	ErrServiceAccountNotFound   = errors.New("service_account.usecase.service_account_not_found")
+	ErrServiceAccountKeyInvalid = errors.New("service_account.usecase.key_invalid")
+)
+
+// ServiceAccountThrottledError is returned instead of serving the request
+// while a service account has used up its requests of the current window.
+type ServiceAccountThrottledError struct {
+	RetryAfter time.Duration
+}
+
+func (e *ServiceAccountThrottledError) Error() string {
+	return "service_account.usecase.throttled"
+}
+
+// Admin usecase errors.
+var (
+	ErrAdminSelfSuspend    = errors.New("admin.usecase.self_suspend")
+	ErrLoginMethodLast     = errors.New("admin.usecase.last_login_method")
+	ErrAdminSeedUnverified = errors.New("admin.usecase.seed_unverified")
 )
 
 // Task usecase errors.
 var (
 	ErrTaskAuthorization = errors.New("task.usecase.task_forbidden")
+	ErrTaskSnoozeInPast  = errors.New("task.usecase.snooze_in_past")
+)
+
+// Template usecase errors.
+var (
+	ErrTemplateAuthorization = errors.New("template.usecase.template_forbidden")
+)
+
+// Stats usecase errors.
+var (
+	ErrStatsRangeInvalid = errors.New("stats.usecase.range_invalid")
+)
+
+// Filter usecase errors.
+var (
+	ErrFilterAuthorization = errors.New("filter.usecase.filter_forbidden")
+	ErrFilterOrderMismatch = errors.New("filter.usecase.order_mismatch")
+)
+
+// Checklist usecase errors.
+var (
+	ErrChecklistOrderMismatch = errors.New("checklist.usecase.order_mismatch")
 )
 
 // UserUsecase represent user usecase contract.
 type UserUsecase interface {
 	Create(ctx context.Context, payload *dto.UserCreateIn) (dto.UserCreateOut, error)
+	Verify(ctx context.Context, payload *dto.UserVerifyIn) error
+	ResendVerification(ctx context.Context, payload *dto.UserResendVerificationIn) error
+	EnsureVerified(ctx context.Context, payload *dto.UserEnsureVerifiedIn) error
+	EnsureRole(ctx context.Context, payload *dto.UserEnsureRoleIn) error
+	Update(ctx context.Context, payload *dto.UserUpdateIn) (dto.UserUpdateOut, error)
+	ChangePassword(ctx context.Context, payload *dto.UserChangePasswordIn) (dto.UserChangePasswordOut, error)
+	Delete(ctx context.Context, payload *dto.UserDeleteIn) error
 }
 
 // AuthUsecase represent auth usecase contract.
@@ -29,6 +155,82 @@ type AuthUsecase interface {
 	Logout(ctx context.Context, payload *dto.AuthLogoutIn) error
 	GetProfile(ctx context.Context, payload *dto.AuthProfileIn) (dto.AuthProfileOut, error)
 	Refresh(ctx context.Context, payload *dto.AuthRefreshIn) (dto.AuthRefreshOut, error)
+	VerifyMFA(ctx context.Context, payload *dto.AuthVerifyMFAIn) (dto.AuthLoginOut, error)
+	StartOIDC(ctx context.Context, payload *dto.AuthOIDCStartIn) (dto.AuthOIDCStartOut, error)
+	LoginOIDC(ctx context.Context, payload *dto.AuthOIDCLoginIn) (dto.AuthLoginOut, error)
+	RequestMagicLink(ctx context.Context, payload *dto.AuthMagicLinkRequestIn) (dto.AuthMagicLinkRequestOut, error)
+	LoginMagicLink(ctx context.Context, payload *dto.AuthMagicLinkLoginIn) (dto.AuthLoginOut, error)
+	GetLoginMethods(ctx context.Context) (dto.AuthLoginMethodsOut, error)
+	GetJWKS(ctx context.Context) (dto.AuthJWKSOut, error)
+}
+
+// TwoFactorUsecase represent two-factor authentication usecase contract.
+type TwoFactorUsecase interface {
+	Setup(ctx context.Context, payload *dto.TwoFactorSetupIn) (dto.TwoFactorSetupOut, error)
+	Enable(ctx context.Context, payload *dto.TwoFactorEnableIn) (dto.TwoFactorEnableOut, error)
+	Disable(ctx context.Context, payload *dto.TwoFactorDisableIn) error
+}
+
+// PasswordResetUsecase represent password reset usecase contract.
+type PasswordResetUsecase interface {
+	Request(ctx context.Context, payload *dto.PasswordResetRequestIn) error
+	Confirm(ctx context.Context, payload *dto.PasswordResetConfirmIn) error
+}
+
+// SessionUsecase represent session usecase contract.
+type SessionUsecase interface {
+	GetAll(ctx context.Context, payload *dto.SessionGetAllIn) ([]dto.SessionGetAllOut, error)
+	Revoke(ctx context.Context, payload *dto.SessionRevokeIn) error
+	RevokeOthers(ctx context.Context, payload *dto.SessionRevokeOthersIn) error
+}
+
+// PersonalTokenUsecase represent personal access token usecase contract.
+type PersonalTokenUsecase interface {
+	Create(ctx context.Context, payload *dto.PersonalTokenCreateIn) (dto.PersonalTokenCreateOut, error)
+	GetAll(ctx context.Context, payload *dto.PersonalTokenGetAllIn) ([]dto.PersonalTokenGetAllOut, error)
+	Revoke(ctx context.Context, payload *dto.PersonalTokenRevokeIn) error
+	Authenticate(ctx context.Context, payload *dto.PersonalTokenAuthenticateIn) (dto.PersonalTokenAuthenticateOut, error)
+}
+
+// ServiceAccountUsecase represent service account usecase contract.
+type ServiceAccountUsecase interface {
+	Create(ctx context.Context, payload *dto.ServiceAccountCreateIn) (dto.ServiceAccountCreateOut, error)
+	GetKeys(ctx context.Context, payload *dto.ServiceAccountKeyGetAllIn) ([]dto.ServiceAccountKeyGetAllOut, error)
+	RotateKey(ctx context.Context, payload *dto.ServiceAccountKeyRotateIn) (dto.ServiceAccountKeyRotateOut, error)
+	RevokeKey(ctx context.Context, payload *dto.ServiceAccountKeyRevokeIn) error
+	Authenticate(ctx context.Context, payload *dto.ServiceAccountAuthenticateIn) (dto.ServiceAccountAuthenticateOut, error)
+	Audit(ctx context.Context, payload *dto.ServiceAccountAuditIn) error
+}
+
+// RevocationUsecase represent access token revocation usecase contract.
+type RevocationUsecase interface {
+	EnsureActive(ctx context.Context, payload *dto.RevocationEnsureActiveIn) error
+	Prune(ctx context.Context) (dto.RevocationPruneOut, error)
+}
+
+// SecurityEventUsecase represent security event usecase contract.
+type SecurityEventUsecase interface {
+	GetAll(ctx context.Context, payload *dto.SecurityEventGetAllIn) ([]dto.SecurityEventGetAllOut, error)
+	Prune(ctx context.Context) (dto.SecurityEventPruneOut, error)
+}
+
+// JanitorUsecase represent expired data cleanup usecase contract.
+type JanitorUsecase interface {
+	Run(ctx context.Context) (dto.JanitorRunOut, error)
+}
+
+// AdminUsecase represent administration usecase contract.
+type AdminUsecase interface {
+	Seed(ctx context.Context, payload *dto.AdminSeedIn) error
+	GetAllUsers(ctx context.Context, payload *dto.AdminUserGetAllIn) ([]dto.AdminUserGetAllOut, error)
+	GetUserByID(ctx context.Context, payload *dto.AdminUserGetByIDIn) (dto.AdminUserGetByIDOut, error)
+	Suspend(ctx context.Context, payload *dto.AdminUserSuspendIn) error
+	Unsuspend(ctx context.Context, payload *dto.AdminUserUnsuspendIn) error
+	Logout(ctx context.Context, payload *dto.AdminUserLogoutIn) error
+	ResetTwoFactor(ctx context.Context, payload *dto.AdminUserResetTwoFactorIn) error
+	GetLoginMethods(ctx context.Context) ([]dto.AdminLoginMethodGetAllOut, error)
+	UpdateLoginMethod(ctx context.Context, payload *dto.AdminLoginMethodUpdateIn) error
+	GetSecurityEvents(ctx context.Context, payload *dto.AdminSecurityEventGetAllIn) ([]dto.AdminSecurityEventGetAllOut, error)
 }
 
 // TaskUsecase represent task usecase contract.
@@ -38,4 +240,41 @@ type TaskUsecase interface {
 	Remove(ctx context.Context, payload *dto.TaskRemoveIn) error
 	GetByID(ctx context.Context, payload *dto.TaskGetByIDIn) (dto.TaskGetByIDOut, error)
 	Update(ctx context.Context, payload *dto.TaskUpdateIn) (dto.TaskUpdateOut, error)
+	Snooze(ctx context.Context, payload *dto.TaskSnoozeIn) (dto.TaskSnoozeOut, error)
+	Unsnooze(ctx context.Context, payload *dto.TaskUnsnoozeIn) error
+}
+
+// TemplateUsecase represent template usecase contract.
+type TemplateUsecase interface {
+	Create(ctx context.Context, payload *dto.TemplateCreateIn) (dto.TemplateCreateOut, error)
+	CreateFromTasks(ctx context.Context, payload *dto.TemplateCreateFromTasksIn) (dto.TemplateCreateOut, error)
+	GetAll(ctx context.Context, payload *dto.TemplateGetAllIn) ([]dto.TemplateGetAllOut, error)
+	GetByID(ctx context.Context, payload *dto.TemplateGetByIDIn) (dto.TemplateGetByIDOut, error)
+	Update(ctx context.Context, payload *dto.TemplateUpdateIn) (dto.TemplateUpdateOut, error)
+	Remove(ctx context.Context, payload *dto.TemplateRemoveIn) error
+	Instantiate(ctx context.Context, payload *dto.TemplateInstantiateIn) (dto.TemplateInstantiateOut, error)
+}
+
+// StatsUsecase represent stats usecase contract.
+type StatsUsecase interface {
+	Get(ctx context.Context, payload *dto.StatsGetIn) (dto.StatsGetOut, error)
+}
+
+// FilterUsecase represent filter usecase contract.
+type FilterUsecase interface {
+	Create(ctx context.Context, payload *dto.FilterCreateIn) (dto.FilterCreateOut, error)
+	GetAll(ctx context.Context, payload *dto.FilterGetAllIn) ([]dto.FilterGetAllOut, error)
+	GetByID(ctx context.Context, payload *dto.FilterGetByIDIn) (dto.FilterGetByIDOut, error)
+	Update(ctx context.Context, payload *dto.FilterUpdateIn) (dto.FilterUpdateOut, error)
+	Remove(ctx context.Context, payload *dto.FilterRemoveIn) error
+	Reorder(ctx context.Context, payload *dto.FilterReorderIn) error
+	GetTasks(ctx context.Context, payload *dto.FilterGetTasksIn) ([]dto.TaskGetAllOut, error)
+}
+
+// ChecklistUsecase represent checklist usecase contract.
+type ChecklistUsecase interface {
+	Add(ctx context.Context, payload *dto.ChecklistItemCreateIn) (dto.ChecklistItemCreateOut, error)
+	Toggle(ctx context.Context, payload *dto.ChecklistItemToggleIn) (dto.ChecklistItemToggleOut, error)
+	Reorder(ctx context.Context, payload *dto.ChecklistReorderIn) error
+	Remove(ctx context.Context, payload *dto.ChecklistItemRemoveIn) error
 }
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+)
+
+type HTTPHandler struct {
+	validator     domain.ValidatorProvider
+	filterUsecase domain.FilterUsecase
+}
+
+// New creates a new filter handler.
+func New(validator domain.ValidatorProvider, filterUsecase domain.FilterUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, filterUsecase: filterUsecase}
+}
+
+// POST /filters to create new filter.
+func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.FilterCreateIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserID = entity.GetAuthContext(r.Context())
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.filterUsecase.Create(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusCreated, "Successfully created new filter", output))
+}
+
+// GET /filters to get all filters.
+func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.FilterGetAllIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+
+	output, err := h.filterUsecase.GetAll(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// GET /filters/{filter_id} to get filter by filter id.
+func (h *HTTPHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.FilterGetByIDIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.FilterID = entity.FilterID(chi.URLParam(r, "filter_id"))
+
+	output, err := h.filterUsecase.GetByID(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// PUT /filters/{filter_id} to update filter by filter id.
+func (h *HTTPHandler) Put(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.FilterUpdateIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.FilterID = entity.FilterID(chi.URLParam(r, "filter_id"))
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.filterUsecase.Update(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully updated filter", output))
+}
+
+// DELETE /filters/{filter_id} to remove filter.
+func (h *HTTPHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.FilterRemoveIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.FilterID = entity.FilterID(chi.URLParam(r, "filter_id"))
+
+	if err := h.filterUsecase.Remove(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully deleted filter", nil))
+}
+
+// PUT /filters/order to reorder all filters.
+func (h *HTTPHandler) PutOrder(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.FilterReorderIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserID = entity.GetAuthContext(r.Context())
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	if err := h.filterUsecase.Reorder(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully reordered filters", nil))
+}
+
+// GET /filters/{filter_id}/tasks?tz=Area/City&include_snoozed=true to get all tasks matching a filter.
+func (h *HTTPHandler) GetTasks(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.FilterGetTasksIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.FilterID = entity.FilterID(chi.URLParam(r, "filter_id"))
+	query := r.URL.Query()
+	payload.TimeZone = query.Get("tz")
+	payload.IncludeSnoozed = query.Get("include_snoozed") == "true"
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.filterUsecase.GetTasks(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
@@ -0,0 +1,636 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/pkg/tql"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type FilterHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestFilterHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(FilterHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	req           *http.Request
+	validator     *mocks.ValidatorProvider
+	filterUsecase *mocks.FilterUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *FilterHTTPHandlerTestSuite) assertResponse(rr *httptest.ResponseRecorder, isError bool, expected expected) {
+	s.Equal(expected.contentType, rr.Header().Get("Content-Type"))
+	s.Equal(expected.statusCode, rr.Code)
+
+	if isError {
+		var resBody domain.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.error, resBody.Error)
+	} else {
+		var resBody domain.SuccessResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.payload, resBody.Payload)
+	}
+}
+
+func (s *FilterHTTPHandlerTestSuite) TestPost() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{"name":"Open"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Query is required field",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrQueryEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when filter usecase Create return query error",
+			isError:     true,
+			requestBody: []byte(`{"name":"Open","query":"is_completed ="}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Query is invalid: expected true or false but found end of query at position 14",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.filterUsecase.On("Create", mock.Anything, &dto.FilterCreateIn{UserID: "user-xxxxx", Name: "Open", Query: "is_completed ="}).
+					Return(dto.FilterCreateOut{}, &tql.Error{Pos: 14, Msg: "expected true or false but found end of query"})
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"name":"Open","query":"is_completed = false"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusCreated,
+				message:     "Successfully created new filter",
+				payload:     map[string]any{"id": "filter-xxxxx"},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.filterUsecase.On("Create", mock.Anything, &dto.FilterCreateIn{UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false"}).
+					Return(dto.FilterCreateOut{ID: "filter-xxxxx"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				req:           req,
+				validator:     &mocks.ValidatorProvider{},
+				filterUsecase: &mocks.FilterUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.filterUsecase)
+			handler.Post(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *FilterHTTPHandlerTestSuite) TestGet() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when filter usecase GetAll return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.filterUsecase.On("GetAll", mock.Anything, &dto.FilterGetAllIn{UserID: "user-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{"id": "filter-xxxxx", "name": "Open", "query": "is_completed = false", "position": float64(0), "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
+				},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.filterUsecase.On("GetAll", mock.Anything, &dto.FilterGetAllIn{UserID: "user-xxxxx"}).
+					Return([]dto.FilterGetAllOut{
+						{ID: "filter-xxxxx", Name: "Open", Query: "is_completed = false", CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+
+			d := &dependency{
+				req:           req,
+				filterUsecase: &mocks.FilterUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.filterUsecase)
+			handler.Get(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *FilterHTTPHandlerTestSuite) TestGetByID() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when filter usecase GetByID return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "Filter not found",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.filterUsecase.On("GetByID", mock.Anything, &dto.FilterGetByIDIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"}).
+					Return(dto.FilterGetByIDOut{}, domain.ErrFilterNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload:     map[string]any{"id": "filter-xxxxx", "name": "Open", "query": "is_completed = false", "position": float64(1), "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.filterUsecase.On("GetByID", mock.Anything, &dto.FilterGetByIDIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"}).
+					Return(dto.FilterGetByIDOut{ID: "filter-xxxxx", Name: "Open", Query: "is_completed = false", Position: 1, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"filter_id": "filter-xxxxx"})
+
+			d := &dependency{
+				req:           req,
+				filterUsecase: &mocks.FilterUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.filterUsecase)
+			handler.GetByID(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *FilterHTTPHandlerTestSuite) TestPut() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{"query":"is_completed = false"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Name is required field",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrNameEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when filter usecase Update return error",
+			isError:     true,
+			requestBody: []byte(`{"name":"Open","query":"is_completed = false"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this filter",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.filterUsecase.On("Update", mock.Anything, &dto.FilterUpdateIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false"}).
+					Return(dto.FilterUpdateOut{}, domain.ErrFilterAuthorization)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"name":"Open","query":"is_completed = false"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully updated filter",
+				payload:     map[string]any{"id": "filter-xxxxx"},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.filterUsecase.On("Update", mock.Anything, &dto.FilterUpdateIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false"}).
+					Return(dto.FilterUpdateOut{ID: "filter-xxxxx"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/", bytes.NewReader(t.requestBody))
+			req = test.InjectChiRouterParams(req, map[string]string{"filter_id": "filter-xxxxx"})
+
+			d := &dependency{
+				req:           req,
+				validator:     &mocks.ValidatorProvider{},
+				filterUsecase: &mocks.FilterUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.filterUsecase)
+			handler.Put(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *FilterHTTPHandlerTestSuite) TestDelete() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when filter usecase Remove return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.filterUsecase.On("Remove", mock.Anything, &dto.FilterRemoveIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully deleted filter",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.filterUsecase.On("Remove", mock.Anything, &dto.FilterRemoveIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"filter_id": "filter-xxxxx"})
+
+			d := &dependency{
+				req:           req,
+				filterUsecase: &mocks.FilterUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.filterUsecase)
+			handler.Delete(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *FilterHTTPHandlerTestSuite) TestPutOrder() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Filter ids is required field",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrFilterIDsEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when filter usecase Reorder return error",
+			isError:     true,
+			requestBody: []byte(`{"filter_ids":["filter-xxxxx"]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Filter ids must list every filter exactly once",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.filterUsecase.On("Reorder", mock.Anything, &dto.FilterReorderIn{UserID: "user-xxxxx", FilterIDs: []entity.FilterID{"filter-xxxxx"}}).
+					Return(domain.ErrFilterOrderMismatch)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"filter_ids":["filter-yyyyy","filter-xxxxx"]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully reordered filters",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.filterUsecase.On("Reorder", mock.Anything, &dto.FilterReorderIn{UserID: "user-xxxxx", FilterIDs: []entity.FilterID{"filter-yyyyy", "filter-xxxxx"}}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				req:           req,
+				validator:     &mocks.ValidatorProvider{},
+				filterUsecase: &mocks.FilterUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.filterUsecase)
+			handler.PutOrder(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *FilterHTTPHandlerTestSuite) TestGetTasks() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Time zone is invalid",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrTimeZoneInvalid)
+			},
+		},
+		{
+			name:    "it should response with error when filter usecase GetTasks return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this filter",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.filterUsecase.On("GetTasks", mock.Anything, &dto.FilterGetTasksIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", TimeZone: "Asia/Jakarta"}).
+					Return(nil, domain.ErrFilterAuthorization)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{"id": "task-xxxxx", "content": "task_xxxxx_content", "description": "", "is_completed": false, "due_date": nil, "start_date": nil, "completed_at": nil, "checklist": nil, "checklist_progress": map[string]any{"checked": float64(0), "total": float64(0)}, "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
+				},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.filterUsecase.On("GetTasks", mock.Anything, &dto.FilterGetTasksIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", TimeZone: "Asia/Jakarta"}).
+					Return([]dto.TaskGetAllOut{
+						{ID: "task-xxxxx", Content: "task_xxxxx_content", CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/?tz=Asia/Jakarta", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"filter_id": "filter-xxxxx"})
+
+			d := &dependency{
+				req:           req,
+				validator:     &mocks.ValidatorProvider{},
+				filterUsecase: &mocks.FilterUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.filterUsecase)
+			handler.GetTasks(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new filter repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new filter after the last filter of the user.
+func (r *Repository) Store(ctx context.Context, f *entity.Filter) (entity.FilterID, error) {
+	id := r.idProvider.Generate()
+	q := `INSERT INTO filters (id, user_id, name, query, position) VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX(position) + 1, 0) FROM filters WHERE user_id = $2))`
+	_, err := r.db.ExecContext(ctx, q, id, f.UserID, f.Name, f.Query)
+	if err != nil {
+		return "", err
+	}
+	return entity.FilterID(id), nil
+}
+
+// FindByID get filter by id.
+func (r *Repository) FindByID(ctx context.Context, filterID entity.FilterID) (entity.Filter, error) {
+	var filter entity.Filter
+	q := `SELECT id, user_id, name, query, position, created_at, updated_at FROM filters WHERE id = $1`
+	row := r.db.QueryRowContext(ctx, q, filterID)
+	err := row.Scan(&filter.ID, &filter.UserID, &filter.Name, &filter.Query, &filter.Position, &filter.CreatedAt, &filter.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Filter{}, domain.ErrFilterNotFound
+	} else if err != nil {
+		return entity.Filter{}, err
+	}
+	return filter, nil
+}
+
+// FindAllByUserID get all filters owned by a user ordered by position.
+func (r *Repository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Filter, error) {
+	q := `SELECT id, name, query, position, created_at, updated_at FROM filters WHERE user_id = $1 ORDER BY position`
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filters := make([]entity.Filter, 0)
+	for rows.Next() {
+		var filter entity.Filter
+		err := rows.Scan(&filter.ID, &filter.Name, &filter.Query, &filter.Position, &filter.CreatedAt, &filter.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+// Update update filter name and query by id.
+func (r *Repository) Update(ctx context.Context, f *entity.Filter) (entity.FilterID, error) {
+	f.UpdatedAt = time.Now()
+	q := `UPDATE filters SET name = $2, query = $3, updated_at = $4 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, f.ID, f.Name, f.Query, f.UpdatedAt)
+	if err != nil {
+		return "", err
+	}
+	return f.ID, nil
+}
+
+// DeleteByID delete filter by id.
+func (r *Repository) DeleteByID(ctx context.Context, filterID entity.FilterID) error {
+	q := `DELETE FROM filters WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, filterID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdatePositions set the position of each filter to its index in filterIDs.
+func (r *Repository) UpdatePositions(ctx context.Context, filterIDs []entity.FilterID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	q := `UPDATE filters SET position = $2 WHERE id = $1`
+	for position, filterID := range filterIDs {
+		if _, err := tx.ExecContext(ctx, q, filterID, position); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,482 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type FilterRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestFilterRepositorySuite(t *testing.T) {
+	suite.Run(t, new(FilterRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	insertFilterQuery   = regexp.QuoteMeta(`INSERT INTO filters (id, user_id, name, query, position) VALUES ($1, $2, $3, $4, (SELECT COALESCE(MAX(position) + 1, 0) FROM filters WHERE user_id = $2))`)
+	selectFilterQuery   = regexp.QuoteMeta(`SELECT id, user_id, name, query, position, created_at, updated_at FROM filters WHERE id = $1`)
+	selectFiltersQuery  = regexp.QuoteMeta(`SELECT id, name, query, position, created_at, updated_at FROM filters WHERE user_id = $1 ORDER BY position`)
+	updateFilterQuery   = regexp.QuoteMeta(`UPDATE filters SET name = $2, query = $3, updated_at = $4 WHERE id = $1`)
+	deleteFilterQuery   = regexp.QuoteMeta(`DELETE FROM filters WHERE id = $1`)
+	updatePositionQuery = regexp.QuoteMeta(`UPDATE filters SET position = $2 WHERE id = $1`)
+)
+
+func (s *FilterRepositoryTestSuite) TestStore() {
+	type args struct {
+		ctx    context.Context
+		filter *entity.Filter
+	}
+	type expected struct {
+		filterID entity.FilterID
+		err      error
+	}
+	filter := &entity.Filter{UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false"}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			args:     args{ctx: context.Background(), filter: filter},
+			expected: expected{filterID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("filter-xxxxx")
+
+				d.mockDB.ExpectExec(insertFilterQuery).
+					WithArgs("filter-xxxxx", "user-xxxxx", "Open", "is_completed = false").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and filter id when successfully store",
+			args:     args{ctx: context.Background(), filter: filter},
+			expected: expected{filterID: "filter-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("filter-xxxxx")
+
+				d.mockDB.ExpectExec(insertFilterQuery).
+					WithArgs("filter-xxxxx", "user-xxxxx", "Open", "is_completed = false").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			filterID, err := repository.Store(t.args.ctx, t.args.filter)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.filterID, filterID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *FilterRepositoryTestSuite) TestFindByID() {
+	type args struct {
+		ctx      context.Context
+		filterID entity.FilterID
+	}
+	type expected struct {
+		filter entity.Filter
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrFilterNotFound when filter is not exist",
+			args:     args{ctx: context.Background(), filterID: "filter-xxxxx"},
+			expected: expected{filter: entity.Filter{}, err: domain.ErrFilterNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectFilterQuery).
+					WithArgs("filter-xxxxx").
+					WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "name", "query", "position", "created_at", "updated_at"}))
+			},
+		},
+		{
+			name:     "it should return error when database fail to query",
+			args:     args{ctx: context.Background(), filterID: "filter-xxxxx"},
+			expected: expected{filter: entity.Filter{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectFilterQuery).
+					WithArgs("filter-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name: "it should return error nil and filter when successfully query",
+			args: args{ctx: context.Background(), filterID: "filter-xxxxx"},
+			expected: expected{
+				filter: entity.Filter{ID: "filter-xxxxx", UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false", Position: 2, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "user_id", "name", "query", "position", "created_at", "updated_at"}).
+					AddRow("filter-xxxxx", "user-xxxxx", "Open", "is_completed = false", 2, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(selectFilterQuery).
+					WithArgs("filter-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			filter, err := repository.FindByID(t.args.ctx, t.args.filterID)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.filter, filter)
+		})
+	}
+}
+
+func (s *FilterRepositoryTestSuite) TestFindAllByUserID() {
+	type args struct {
+		ctx    context.Context
+		userID entity.UserID
+	}
+	type expected struct {
+		filters       []entity.Filter
+		allowAnyError bool
+		err           error
+	}
+	columns := []string{"id", "name", "query", "position", "created_at", "updated_at"}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{filters: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectFiltersQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database rows fail to scan",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{filters: nil, allowAnyError: true, err: errors.New("anything")},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(columns).
+					AddRow("filter-xxxxx", "Open", "is_completed = false", "first", test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(selectFiltersQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name:     "it should return error when database rows error",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{filters: nil, err: test.ErrRows},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(columns).
+					AddRow("filter-xxxxx", "Open", "is_completed = false", 0, test.TimeBeforeNow, test.TimeBeforeNow).
+					RowError(0, test.ErrRows)
+
+				d.mockDB.ExpectQuery(selectFiltersQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name: "it should return error nil and all filters when successfully query",
+			args: args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{
+				filters: []entity.Filter{
+					{ID: "filter-xxxxx", Name: "Open", Query: "is_completed = false", Position: 0, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					{ID: "filter-yyyyy", Name: "Overdue", Query: "due_date < today", Position: 1, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows(columns).
+					AddRow("filter-xxxxx", "Open", "is_completed = false", 0, test.TimeBeforeNow, test.TimeBeforeNow).
+					AddRow("filter-yyyyy", "Overdue", "due_date < today", 1, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(selectFiltersQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			filters, err := repository.FindAllByUserID(t.args.ctx, t.args.userID)
+
+			if t.expected.allowAnyError {
+				s.Error(err)
+			} else {
+				s.Equal(t.expected.err, err)
+			}
+			s.Equal(t.expected.filters, filters)
+		})
+	}
+}
+
+func (s *FilterRepositoryTestSuite) TestUpdate() {
+	type args struct {
+		ctx    context.Context
+		filter *entity.Filter
+	}
+	type expected struct {
+		filterID entity.FilterID
+		err      error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			args:     args{ctx: context.Background(), filter: &entity.Filter{ID: "filter-xxxxx", Name: "Open", Query: "is_completed = false"}},
+			expected: expected{filterID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(updateFilterQuery).
+					WithArgs("filter-xxxxx", "Open", "is_completed = false", sqlmock.AnyArg()).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and filter id when successfully update",
+			args:     args{ctx: context.Background(), filter: &entity.Filter{ID: "filter-xxxxx", Name: "Open", Query: "is_completed = false"}},
+			expected: expected{filterID: "filter-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(updateFilterQuery).
+					WithArgs("filter-xxxxx", "Open", "is_completed = false", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			filterID, err := repository.Update(t.args.ctx, t.args.filter)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.filterID, filterID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *FilterRepositoryTestSuite) TestDeleteByID() {
+	type args struct {
+		ctx      context.Context
+		filterID entity.FilterID
+	}
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			args:     args{ctx: context.Background(), filterID: "filter-xxxxx"},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteFilterQuery).
+					WithArgs("filter-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			args:     args{ctx: context.Background(), filterID: "filter-xxxxx"},
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteFilterQuery).
+					WithArgs("filter-xxxxx").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.DeleteByID(t.args.ctx, t.args.filterID)
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *FilterRepositoryTestSuite) TestUpdatePositions() {
+	type args struct {
+		ctx       context.Context
+		filterIDs []entity.FilterID
+	}
+	type expected struct {
+		err error
+	}
+	filterIDs := []entity.FilterID{"filter-yyyyy", "filter-xxxxx"}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to begin transaction",
+			args:     args{ctx: context.Background(), filterIDs: filterIDs},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to update position",
+			args:     args{ctx: context.Background(), filterIDs: filterIDs},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WithArgs("filter-yyyyy", 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WithArgs("filter-xxxxx", 1).
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to commit transaction",
+			args:     args{ctx: context.Background(), filterIDs: filterIDs},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update positions",
+			args:     args{ctx: context.Background(), filterIDs: filterIDs},
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WithArgs("filter-yyyyy", 0).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(updatePositionQuery).
+					WithArgs("filter-xxxxx", 1).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.UpdatePositions(t.args.ctx, t.args.filterIDs)
+
+			s.Equal(t.expected.err, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/tql"
+)
+
+type Usecase struct {
+	filterRepository    domain.FilterRepository
+	taskRepository      domain.TaskRepository
+	checklistRepository domain.ChecklistRepository
+}
+
+// New create a new filter usecase.
+func New(filterRepository domain.FilterRepository, taskRepository domain.TaskRepository, checklistRepository domain.ChecklistRepository) Usecase {
+	return Usecase{filterRepository: filterRepository, taskRepository: taskRepository, checklistRepository: checklistRepository}
+}
+
+// Create create a new filter, the query must be a valid task query.
+func (u *Usecase) Create(ctx context.Context, payload *dto.FilterCreateIn) (dto.FilterCreateOut, error) {
+	if _, err := tql.Parse(payload.Query); err != nil {
+		return dto.FilterCreateOut{}, err
+	}
+
+	filter := &entity.Filter{UserID: payload.UserID, Name: payload.Name, Query: payload.Query}
+	filterID, err := u.filterRepository.Store(ctx, filter)
+	if err != nil {
+		return dto.FilterCreateOut{}, err
+	}
+	return dto.FilterCreateOut{ID: filterID}, nil
+}
+
+// GetAll get all filters ordered by position.
+func (u *Usecase) GetAll(ctx context.Context, payload *dto.FilterGetAllIn) ([]dto.FilterGetAllOut, error) {
+	filters, err := u.filterRepository.FindAllByUserID(ctx, payload.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.FilterGetAllOut, len(filters))
+	for i, filter := range filters {
+		output[i] = dto.FilterGetAllOut{
+			ID:        filter.ID,
+			Name:      filter.Name,
+			Query:     filter.Query,
+			Position:  filter.Position,
+			CreatedAt: filter.CreatedAt,
+			UpdatedAt: filter.UpdatedAt,
+		}
+	}
+	return output, nil
+}
+
+// GetByID get filter by id.
+func (u *Usecase) GetByID(ctx context.Context, payload *dto.FilterGetByIDIn) (dto.FilterGetByIDOut, error) {
+	filter, err := u.filterRepository.FindByID(ctx, payload.FilterID)
+	if err != nil {
+		return dto.FilterGetByIDOut{}, err
+	}
+	if filter.UserID != payload.UserID {
+		return dto.FilterGetByIDOut{}, domain.ErrFilterAuthorization
+	}
+
+	output := dto.FilterGetByIDOut{
+		ID:        filter.ID,
+		Name:      filter.Name,
+		Query:     filter.Query,
+		Position:  filter.Position,
+		CreatedAt: filter.CreatedAt,
+		UpdatedAt: filter.UpdatedAt,
+	}
+	return output, nil
+}
+
+// Update update filter by id.
+func (u *Usecase) Update(ctx context.Context, payload *dto.FilterUpdateIn) (dto.FilterUpdateOut, error) {
+	if _, err := tql.Parse(payload.Query); err != nil {
+		return dto.FilterUpdateOut{}, err
+	}
+
+	filter, err := u.filterRepository.FindByID(ctx, payload.FilterID)
+	if err != nil {
+		return dto.FilterUpdateOut{}, err
+	}
+	if filter.UserID != payload.UserID {
+		return dto.FilterUpdateOut{}, domain.ErrFilterAuthorization
+	}
+
+	filter.Name = payload.Name
+	filter.Query = payload.Query
+
+	filterID, err := u.filterRepository.Update(ctx, &filter)
+	if err != nil {
+		return dto.FilterUpdateOut{}, err
+	}
+	return dto.FilterUpdateOut{ID: filterID}, nil
+}
+
+// Remove remove a filter.
+func (u *Usecase) Remove(ctx context.Context, payload *dto.FilterRemoveIn) error {
+	filter, err := u.filterRepository.FindByID(ctx, payload.FilterID)
+	if err != nil {
+		return err
+	}
+	if filter.UserID != payload.UserID {
+		return domain.ErrFilterAuthorization
+	}
+	if err := u.filterRepository.DeleteByID(ctx, payload.FilterID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Reorder change the position of the filters of a user.
+// The given ids must contain every filter of the user exactly once.
+func (u *Usecase) Reorder(ctx context.Context, payload *dto.FilterReorderIn) error {
+	filters, err := u.filterRepository.FindAllByUserID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+
+	owned := make(map[entity.FilterID]bool, len(filters))
+	for _, filter := range filters {
+		owned[filter.ID] = true
+	}
+	if len(payload.FilterIDs) != len(owned) {
+		return domain.ErrFilterOrderMismatch
+	}
+	for _, filterID := range payload.FilterIDs {
+		if !owned[filterID] {
+			return domain.ErrFilterOrderMismatch
+		}
+		delete(owned, filterID)
+	}
+
+	if err := u.filterRepository.UpdatePositions(ctx, payload.FilterIDs); err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetTasks get all tasks matching the query of a filter.
+// Relative dates of the query are resolved in the given time zone, UTC by default.
+func (u *Usecase) GetTasks(ctx context.Context, payload *dto.FilterGetTasksIn) ([]dto.TaskGetAllOut, error) {
+	filter, err := u.filterRepository.FindByID(ctx, payload.FilterID)
+	if err != nil {
+		return nil, err
+	}
+	if filter.UserID != payload.UserID {
+		return nil, domain.ErrFilterAuthorization
+	}
+
+	location := time.UTC
+	if payload.TimeZone != "" {
+		location, _ = time.LoadLocation(payload.TimeZone)
+	}
+	taskFilter := entity.TaskFilter{
+		UserID:         payload.UserID,
+		Query:          filter.Query,
+		Now:            time.Now().In(location),
+		IncludeSnoozed: payload.IncludeSnoozed,
+	}
+	tasks, err := u.taskRepository.FindAllByFilter(ctx, taskFilter)
+	if err != nil {
+		return nil, err
+	}
+
+	checklists := make(map[entity.TaskID][]entity.ChecklistItem, len(tasks))
+	if len(tasks) > 0 {
+		taskIDs := make([]entity.TaskID, len(tasks))
+		for i, task := range tasks {
+			taskIDs[i] = task.ID
+		}
+		items, err := u.checklistRepository.FindAllByTaskIDs(ctx, taskIDs)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			checklists[item.TaskID] = append(checklists[item.TaskID], item)
+		}
+	}
+
+	output := make([]dto.TaskGetAllOut, len(tasks))
+	for i, task := range tasks {
+		checklist, progress := dto.NewChecklistOut(checklists[task.ID])
+		output[i] = dto.TaskGetAllOut{
+			ID:                task.ID,
+			Content:           task.Content,
+			Description:       task.Description,
+			IsCompleted:       task.IsCompleted,
+			DueDate:           task.DueDate,
+			StartDate:         task.StartDate,
+			CompletedAt:       task.CompletedAt,
+			Checklist:         checklist,
+			ChecklistProgress: progress,
+			CreatedAt:         task.CreatedAt,
+			UpdatedAt:         task.UpdatedAt,
+		}
+	}
+	return output, nil
+}
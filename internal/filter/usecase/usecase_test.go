@@ -0,0 +1,521 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/tql"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type FilterUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestFilterUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(FilterUsecaseTestSuite))
+}
+
+type dependency struct {
+	filterRepository    *mocks.FilterRepository
+	taskRepository      *mocks.TaskRepository
+	checklistRepository *mocks.ChecklistRepository
+}
+
+func newDependency() *dependency {
+	return &dependency{
+		filterRepository:    &mocks.FilterRepository{},
+		taskRepository:      &mocks.TaskRepository{},
+		checklistRepository: &mocks.ChecklistRepository{},
+	}
+}
+
+func newFilter() entity.Filter {
+	return entity.Filter{
+		ID:        "filter-xxxxx",
+		UserID:    "user-xxxxx",
+		Name:      "Open",
+		Query:     "is_completed = false",
+		Position:  1,
+		CreatedAt: test.TimeBeforeNow,
+		UpdatedAt: test.TimeBeforeNow,
+	}
+}
+
+func (s *FilterUsecaseTestSuite) TestCreate() {
+	type expected struct {
+		output dto.FilterCreateOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.FilterCreateIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when query is invalid",
+			payload:  &dto.FilterCreateIn{UserID: "user-xxxxx", Name: "Open", Query: "is_completed ="},
+			expected: expected{output: dto.FilterCreateOut{}, err: &tql.Error{Pos: 14, Msg: "expected true or false but found end of query"}},
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error when filter repository Store return unexpected error",
+			payload:  &dto.FilterCreateIn{UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false"},
+			expected: expected{output: dto.FilterCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.filterRepository.On("Store", context.Background(), &entity.Filter{UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false"}).
+					Return(entity.FilterID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and filter id when success",
+			payload:  &dto.FilterCreateIn{UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false"},
+			expected: expected{output: dto.FilterCreateOut{ID: "filter-xxxxx"}, err: nil},
+			setup: func(d *dependency) {
+				d.filterRepository.On("Store", context.Background(), &entity.Filter{UserID: "user-xxxxx", Name: "Open", Query: "is_completed = false"}).
+					Return(entity.FilterID("filter-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.filterRepository, d.taskRepository, d.checklistRepository)
+			output, err := usecase.Create(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *FilterUsecaseTestSuite) TestGetAll() {
+	type expected struct {
+		output []dto.FilterGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when filter repository FindAllByUserID return unexpected error",
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and filters when success",
+			expected: expected{
+				output: []dto.FilterGetAllOut{
+					{ID: "filter-xxxxx", Name: "Open", Query: "is_completed = false", Position: 1, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return([]entity.Filter{newFilter()}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.filterRepository, d.taskRepository, d.checklistRepository)
+			output, err := usecase.GetAll(context.Background(), &dto.FilterGetAllIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *FilterUsecaseTestSuite) TestGetByID() {
+	type expected struct {
+		output dto.FilterGetByIDOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.FilterGetByIDIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when filter repository FindByID return unexpected error",
+			payload:  &dto.FilterGetByIDIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: dto.FilterGetByIDOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(entity.Filter{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrFilterAuthorization when user is not the owner",
+			payload:  &dto.FilterGetByIDIn{FilterID: "filter-xxxxx", UserID: "user-yyyyy"},
+			expected: expected{output: dto.FilterGetByIDOut{}, err: domain.ErrFilterAuthorization},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+			},
+		},
+		{
+			name:    "it should return error nil and filter when success",
+			payload: &dto.FilterGetByIDIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{
+				output: dto.FilterGetByIDOut{ID: "filter-xxxxx", Name: "Open", Query: "is_completed = false", Position: 1, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.filterRepository, d.taskRepository, d.checklistRepository)
+			output, err := usecase.GetByID(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *FilterUsecaseTestSuite) TestUpdate() {
+	type expected struct {
+		output dto.FilterUpdateOut
+		err    error
+	}
+	updated := newFilter()
+	updated.Name = "Overdue"
+	updated.Query = "due_date < today"
+	tests := []struct {
+		name     string
+		payload  *dto.FilterUpdateIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when query is invalid",
+			payload:  &dto.FilterUpdateIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", Name: "Overdue", Query: "due_date << today"},
+			expected: expected{output: dto.FilterUpdateOut{}, err: &tql.Error{Pos: 10, Msg: `expected a date but found "<"`}},
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error when filter repository FindByID return unexpected error",
+			payload:  &dto.FilterUpdateIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", Name: "Overdue", Query: "due_date < today"},
+			expected: expected{output: dto.FilterUpdateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(entity.Filter{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrFilterAuthorization when user is not the owner",
+			payload:  &dto.FilterUpdateIn{FilterID: "filter-xxxxx", UserID: "user-yyyyy", Name: "Overdue", Query: "due_date < today"},
+			expected: expected{output: dto.FilterUpdateOut{}, err: domain.ErrFilterAuthorization},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+			},
+		},
+		{
+			name:     "it should return error when filter repository Update return unexpected error",
+			payload:  &dto.FilterUpdateIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", Name: "Overdue", Query: "due_date < today"},
+			expected: expected{output: dto.FilterUpdateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+				d.filterRepository.On("Update", context.Background(), &updated).
+					Return(entity.FilterID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and filter id when success",
+			payload:  &dto.FilterUpdateIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", Name: "Overdue", Query: "due_date < today"},
+			expected: expected{output: dto.FilterUpdateOut{ID: "filter-xxxxx"}, err: nil},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+				d.filterRepository.On("Update", context.Background(), &updated).
+					Return(entity.FilterID("filter-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.filterRepository, d.taskRepository, d.checklistRepository)
+			output, err := usecase.Update(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *FilterUsecaseTestSuite) TestRemove() {
+	tests := []struct {
+		name     string
+		payload  *dto.FilterRemoveIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when filter repository FindByID return unexpected error",
+			payload:  &dto.FilterRemoveIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(entity.Filter{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrFilterAuthorization when user is not the owner",
+			payload:  &dto.FilterRemoveIn{FilterID: "filter-xxxxx", UserID: "user-yyyyy"},
+			expected: domain.ErrFilterAuthorization,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+			},
+		},
+		{
+			name:     "it should return error when filter repository DeleteByID return unexpected error",
+			payload:  &dto.FilterRemoveIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+				d.filterRepository.On("DeleteByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			payload:  &dto.FilterRemoveIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+				d.filterRepository.On("DeleteByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.filterRepository, d.taskRepository, d.checklistRepository)
+			err := usecase.Remove(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *FilterUsecaseTestSuite) TestReorder() {
+	filters := []entity.Filter{{ID: "filter-xxxxx"}, {ID: "filter-yyyyy"}}
+	tests := []struct {
+		name     string
+		payload  *dto.FilterReorderIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when filter repository FindAllByUserID return unexpected error",
+			payload:  &dto.FilterReorderIn{UserID: "user-xxxxx", FilterIDs: []entity.FilterID{"filter-yyyyy", "filter-xxxxx"}},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrFilterOrderMismatch when a filter is missing",
+			payload:  &dto.FilterReorderIn{UserID: "user-xxxxx", FilterIDs: []entity.FilterID{"filter-yyyyy"}},
+			expected: domain.ErrFilterOrderMismatch,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(filters, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrFilterOrderMismatch when a filter is duplicated",
+			payload:  &dto.FilterReorderIn{UserID: "user-xxxxx", FilterIDs: []entity.FilterID{"filter-yyyyy", "filter-yyyyy"}},
+			expected: domain.ErrFilterOrderMismatch,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(filters, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrFilterOrderMismatch when a filter is not owned by user",
+			payload:  &dto.FilterReorderIn{UserID: "user-xxxxx", FilterIDs: []entity.FilterID{"filter-yyyyy", "filter-zzzzz"}},
+			expected: domain.ErrFilterOrderMismatch,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(filters, nil)
+			},
+		},
+		{
+			name:     "it should return error when filter repository UpdatePositions return unexpected error",
+			payload:  &dto.FilterReorderIn{UserID: "user-xxxxx", FilterIDs: []entity.FilterID{"filter-yyyyy", "filter-xxxxx"}},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(filters, nil)
+				d.filterRepository.On("UpdatePositions", context.Background(), []entity.FilterID{"filter-yyyyy", "filter-xxxxx"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			payload:  &dto.FilterReorderIn{UserID: "user-xxxxx", FilterIDs: []entity.FilterID{"filter-yyyyy", "filter-xxxxx"}},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(filters, nil)
+				d.filterRepository.On("UpdatePositions", context.Background(), []entity.FilterID{"filter-yyyyy", "filter-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.filterRepository, d.taskRepository, d.checklistRepository)
+			err := usecase.Reorder(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *FilterUsecaseTestSuite) TestGetTasks() {
+	type expected struct {
+		output []dto.TaskGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.FilterGetTasksIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when filter repository FindByID return unexpected error",
+			payload:  &dto.FilterGetTasksIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(entity.Filter{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrFilterAuthorization when user is not the owner",
+			payload:  &dto.FilterGetTasksIn{FilterID: "filter-xxxxx", UserID: "user-yyyyy"},
+			expected: expected{output: nil, err: domain.ErrFilterAuthorization},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+			},
+		},
+		{
+			name:     "it should return error when task repository FindAllByFilter return unexpected error",
+			payload:  &dto.FilterGetTasksIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.AnythingOfType("entity.TaskFilter")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when checklist repository FindAllByTaskIDs return unexpected error",
+			payload:  &dto.FilterGetTasksIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx"},
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.AnythingOfType("entity.TaskFilter")).
+					Return([]entity.Task{{ID: "task-xxxxx"}}, nil)
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should return error nil and matching tasks when success",
+			payload: &dto.FilterGetTasksIn{FilterID: "filter-xxxxx", UserID: "user-xxxxx", TimeZone: "Asia/Jakarta"},
+			expected: expected{
+				output: []dto.TaskGetAllOut{
+					{
+						ID:                "task-xxxxx",
+						Content:           "task_xxxxx_content",
+						Checklist:         []dto.ChecklistItemOut{{ID: "item-xxxxx", Text: "Buy milk", IsChecked: false, Position: 0}},
+						ChecklistProgress: dto.ChecklistProgress{Checked: 0, Total: 1},
+						CreatedAt:         test.TimeBeforeNow,
+						UpdatedAt:         test.TimeBeforeNow,
+					},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.filterRepository.On("FindByID", context.Background(), entity.FilterID("filter-xxxxx")).
+					Return(newFilter(), nil)
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.MatchedBy(func(filter entity.TaskFilter) bool {
+					return filter.UserID == "user-xxxxx" && filter.Query == "is_completed = false" && filter.Now.Location().String() == "Asia/Jakarta"
+				})).Return([]entity.Task{
+					{ID: "task-xxxxx", Content: "task_xxxxx_content", CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				}, nil)
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return([]entity.ChecklistItem{{ID: "item-xxxxx", TaskID: "task-xxxxx", Text: "Buy milk", Position: 0}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.filterRepository, d.taskRepository, d.checklistRepository)
+			output, err := usecase.GetTasks(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new external identity repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store link a user to an external identity.
+func (r *Repository) Store(ctx context.Context, i *entity.UserIdentity) error {
+	id := entity.UserIdentityID(r.idProvider.Generate())
+	q := `INSERT INTO user_identities (id, user_id, provider, subject, email) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.ExecContext(ctx, q, id, i.UserID, i.Provider, i.Subject, i.Email)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindByProviderSubject find the identity of a provider account.
+func (r *Repository) FindByProviderSubject(ctx context.Context, provider string, subject string) (entity.UserIdentity, error) {
+	var i entity.UserIdentity
+	q := `SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE provider = $1 AND subject = $2`
+	err := r.db.QueryRowContext(ctx, q, provider, subject).Scan(&i.ID, &i.UserID, &i.Provider, &i.Subject, &i.Email, &i.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return i, domain.ErrUserIdentityNotFound
+	} else if err != nil {
+		return i, err
+	}
+	return i, nil
+}
+
+// StoreState save a pending OpenID Connect login to database.
+func (r *Repository) StoreState(ctx context.Context, s *entity.OIDCState) error {
+	id := entity.OIDCStateID(r.idProvider.Generate())
+	q := `INSERT INTO oidc_states (id, provider, state_hash, nonce, code_verifier, expires_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.ExecContext(ctx, q, id, s.Provider, s.StateHash, s.Nonce, s.CodeVerifier, s.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ConsumeState delete a pending OpenID Connect login and return it, so a state can only be used once.
+func (r *Repository) ConsumeState(ctx context.Context, stateHash string) (entity.OIDCState, error) {
+	var s entity.OIDCState
+	q := `DELETE FROM oidc_states WHERE state_hash = $1 RETURNING id, provider, state_hash, nonce, code_verifier, expires_at, created_at`
+	err := r.db.QueryRowContext(ctx, q, stateHash).Scan(&s.ID, &s.Provider, &s.StateHash, &s.Nonce, &s.CodeVerifier, &s.ExpiresAt, &s.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return s, domain.ErrOIDCStateNotFound
+	} else if err != nil {
+		return s, err
+	}
+	return s, nil
+}
+
+// DeleteExpiredStates remove up to limit pending OpenID Connect logins expired before the given time and return how many were removed.
+func (r *Repository) DeleteExpiredStates(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := `DELETE FROM oidc_states WHERE id IN (SELECT id FROM oidc_states WHERE expires_at < $1 LIMIT $2)`
+	result, err := r.db.ExecContext(ctx, q, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
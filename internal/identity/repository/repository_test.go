@@ -0,0 +1,327 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type IdentityRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestIdentityRepositorySuite(t *testing.T) {
+	suite.Run(t, new(IdentityRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	storeQuery                 = regexp.QuoteMeta(`INSERT INTO user_identities (id, user_id, provider, subject, email) VALUES ($1, $2, $3, $4, $5)`)
+	findByProviderSubjectQuery = regexp.QuoteMeta(`SELECT id, user_id, provider, subject, email, created_at FROM user_identities WHERE provider = $1 AND subject = $2`)
+	storeStateQuery            = regexp.QuoteMeta(`INSERT INTO oidc_states (id, provider, state_hash, nonce, code_verifier, expires_at) VALUES ($1, $2, $3, $4, $5, $6)`)
+	consumeStateQuery          = regexp.QuoteMeta(`DELETE FROM oidc_states WHERE state_hash = $1 RETURNING id, provider, state_hash, nonce, code_verifier, expires_at, created_at`)
+	deleteExpiredStatesQuery   = regexp.QuoteMeta(`DELETE FROM oidc_states WHERE id IN (SELECT id FROM oidc_states WHERE expires_at < $1 LIMIT $2)`)
+)
+
+func (s *IdentityRepositoryTestSuite) TestStore() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("identity-xxxxx")
+
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("identity-xxxxx", "user-xxxxx", "acme", "subject-xxxxx", "gopher@go.dev").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("identity-xxxxx")
+
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("identity-xxxxx", "user-xxxxx", "acme", "subject-xxxxx", "gopher@go.dev").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.Store(context.Background(), &entity.UserIdentity{UserID: "user-xxxxx", Provider: "acme", Subject: "subject-xxxxx", Email: "gopher@go.dev"})
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *IdentityRepositoryTestSuite) TestFindByProviderSubject() {
+	type expected struct {
+		identity entity.UserIdentity
+		err      error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{identity: entity.UserIdentity{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByProviderSubjectQuery).
+					WithArgs("acme", "subject-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrUserIdentityNotFound when row not found",
+			expected: expected{identity: entity.UserIdentity{}, err: domain.ErrUserIdentityNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByProviderSubjectQuery).
+					WithArgs("acme", "subject-xxxxx").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "it should return error nil and identity when found",
+			expected: expected{
+				identity: entity.UserIdentity{ID: "identity-xxxxx", UserID: "user-xxxxx", Provider: "acme", Subject: "subject-xxxxx", Email: "gopher@go.dev", CreatedAt: test.TimeBeforeNow},
+				err:      nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "provider", "subject", "email", "created_at"}).
+					AddRow("identity-xxxxx", "user-xxxxx", "acme", "subject-xxxxx", "gopher@go.dev", test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findByProviderSubjectQuery).
+					WithArgs("acme", "subject-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			identity, err := repository.FindByProviderSubject(context.Background(), "acme", "subject-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.identity, identity)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *IdentityRepositoryTestSuite) TestStoreState() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("state-xxxxx")
+
+				d.mockDB.ExpectExec(storeStateQuery).
+					WithArgs("state-xxxxx", "acme", "state_hash", "nonce", "code_verifier", test.TimeAfterNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("state-xxxxx")
+
+				d.mockDB.ExpectExec(storeStateQuery).
+					WithArgs("state-xxxxx", "acme", "state_hash", "nonce", "code_verifier", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.StoreState(context.Background(), &entity.OIDCState{Provider: "acme", StateHash: "state_hash", Nonce: "nonce", CodeVerifier: "code_verifier", ExpiresAt: test.TimeAfterNow})
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *IdentityRepositoryTestSuite) TestConsumeState() {
+	type expected struct {
+		state entity.OIDCState
+		err   error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{state: entity.OIDCState{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeStateQuery).
+					WithArgs("state_hash").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrOIDCStateNotFound when state is unknown or already used",
+			expected: expected{state: entity.OIDCState{}, err: domain.ErrOIDCStateNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeStateQuery).
+					WithArgs("state_hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "it should return error nil and state when consumed",
+			expected: expected{
+				state: entity.OIDCState{ID: "state-xxxxx", Provider: "acme", StateHash: "state_hash", Nonce: "nonce", CodeVerifier: "code_verifier", ExpiresAt: test.TimeAfterNow, CreatedAt: test.TimeBeforeNow},
+				err:   nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "provider", "state_hash", "nonce", "code_verifier", "expires_at", "created_at"}).
+					AddRow("state-xxxxx", "acme", "state_hash", "nonce", "code_verifier", test.TimeAfterNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(consumeStateQuery).
+					WithArgs("state_hash").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			state, err := repository.ConsumeState(context.Background(), "state_hash")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.state, state)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *IdentityRepositoryTestSuite) TestDeleteExpiredStates() {
+	type expected struct {
+		deleted int64
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{deleted: 0, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteExpiredStatesQuery).
+					WithArgs(test.TimeBeforeNow, 100).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and the number of deleted states when successfully delete",
+			expected: expected{deleted: 42, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteExpiredStatesQuery).
+					WithArgs(test.TimeBeforeNow, 100).
+					WillReturnResult(sqlmock.NewResult(0, 42))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			deleted, err := repository.DeleteExpiredStates(context.Background(), test.TimeBeforeNow, 100)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.deleted, deleted)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// defaultBatchSize is how many expired rows are deleted at once when no batch size is configured.
+const defaultBatchSize = 1000
+
+// Metrics reported by the janitor.
+const (
+	metricRuns                   = "taskit_janitor_runs_total"
+	metricFailures               = "taskit_janitor_failures_total"
+	metricBatches                = "taskit_janitor_batches_total"
+	metricAuthenticationsDeleted = "taskit_janitor_authentications_deleted_total"
+	metricLoginAttemptsDeleted   = "taskit_janitor_login_attempts_deleted_total"
+	metricOIDCStatesDeleted      = "taskit_janitor_oidc_states_deleted_total"
+	metricMFAChallengesDeleted   = "taskit_janitor_mfa_challenges_deleted_total"
+	metricLastRunDuration        = "taskit_janitor_last_run_duration_milliseconds"
+	metricLastSuccess            = "taskit_janitor_last_success_timestamp_seconds"
+)
+
+type Usecase struct {
+	authRepository         domain.AuthRepository
+	loginAttemptRepository domain.LoginAttemptRepository
+	identityRepository     domain.IdentityRepository
+	twoFactorRepository    domain.TwoFactorRepository
+	metrics                domain.MetricsProvider
+	batchSize              int
+}
+
+// New create a new janitor usecase.
+func New(authRepository domain.AuthRepository, loginAttemptRepository domain.LoginAttemptRepository, identityRepository domain.IdentityRepository, twoFactorRepository domain.TwoFactorRepository, metrics domain.MetricsProvider, batchSize int) Usecase {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return Usecase{authRepository: authRepository, loginAttemptRepository: loginAttemptRepository, identityRepository: identityRepository, twoFactorRepository: twoFactorRepository, metrics: metrics, batchSize: batchSize}
+}
+
+// Run delete the expired authentications, the failed login attempts past their retention,
+// and the OpenID Connect and two-factor logins that were never finished.
+// They are deleted in batches until a batch comes back short, so a large backlog never holds a long lock on a table.
+func (u *Usecase) Run(ctx context.Context) (dto.JanitorRunOut, error) {
+	start := time.Now()
+	u.metrics.Add(metricRuns, 1)
+
+	var output dto.JanitorRunOut
+	var err error
+	output.Authentications, err = u.deleteInBatches(metricAuthenticationsDeleted, func(limit int) (int64, error) {
+		return u.authRepository.DeleteExpired(ctx, start, limit)
+	})
+	if err != nil {
+		return dto.JanitorRunOut{}, err
+	}
+	output.LoginAttempts, err = u.deleteInBatches(metricLoginAttemptsDeleted, func(limit int) (int64, error) {
+		return u.loginAttemptRepository.DeleteOlderThan(ctx, start.Add(-entity.LoginAttemptRetention), limit)
+	})
+	if err != nil {
+		return dto.JanitorRunOut{}, err
+	}
+	output.OIDCStates, err = u.deleteInBatches(metricOIDCStatesDeleted, func(limit int) (int64, error) {
+		return u.identityRepository.DeleteExpiredStates(ctx, start, limit)
+	})
+	if err != nil {
+		return dto.JanitorRunOut{}, err
+	}
+	output.MFAChallenges, err = u.deleteInBatches(metricMFAChallengesDeleted, func(limit int) (int64, error) {
+		return u.twoFactorRepository.DeleteExpiredChallenges(ctx, start, limit)
+	})
+	if err != nil {
+		return dto.JanitorRunOut{}, err
+	}
+
+	u.metrics.Set(metricLastRunDuration, time.Since(start).Milliseconds())
+	u.metrics.Set(metricLastSuccess, time.Now().Unix())
+	return output, nil
+}
+
+// deleteInBatches call deleteBatch until a batch comes back short and return how many rows were deleted,
+// every batch is counted in the metric of the deleted rows.
+func (u *Usecase) deleteInBatches(metricDeleted string, deleteBatch func(limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		deleted, err := deleteBatch(u.batchSize)
+		if err != nil {
+			u.metrics.Add(metricFailures, 1)
+			return 0, err
+		}
+		u.metrics.Add(metricBatches, 1)
+		u.metrics.Add(metricDeleted, deleted)
+		total += deleted
+
+		if deleted < int64(u.batchSize) {
+			return total, nil
+		}
+	}
+}
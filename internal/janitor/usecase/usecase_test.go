@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type JanitorUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestJanitorUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(JanitorUsecaseTestSuite))
+}
+
+type dependency struct {
+	authRepository         *mocks.AuthRepository
+	loginAttemptRepository *mocks.LoginAttemptRepository
+	identityRepository     *mocks.IdentityRepository
+	twoFactorRepository    *mocks.TwoFactorRepository
+	metrics                *mocks.MetricsProvider
+}
+
+func (s *JanitorUsecaseTestSuite) TestRun() {
+	// matchNow match the time the run started at.
+	matchNow := mock.MatchedBy(func(t time.Time) bool {
+		return time.Since(t) < time.Minute
+	})
+	// matchRetention match the oldest failed login attempt kept by a run.
+	matchRetention := mock.MatchedBy(func(t time.Time) bool {
+		return time.Since(t.Add(entity.LoginAttemptRetention)) < time.Minute
+	})
+
+	type expected struct {
+		output dto.JanitorRunOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error and count the failure when auth repository DeleteExpired return unexpected error",
+			expected: expected{output: dto.JanitorRunOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.metrics.On("Add", metricRuns, int64(1))
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(0), test.ErrUnexpected)
+				d.metrics.On("Add", metricFailures, int64(1))
+			},
+		},
+		{
+			name:     "it should return error and count the failure when a later batch fail",
+			expected: expected{output: dto.JanitorRunOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.metrics.On("Add", metricRuns, int64(1))
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(2), nil).Once()
+				d.metrics.On("Add", metricBatches, int64(1)).Once()
+				d.metrics.On("Add", metricAuthenticationsDeleted, int64(2)).Once()
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(0), test.ErrUnexpected).Once()
+				d.metrics.On("Add", metricFailures, int64(1))
+			},
+		},
+		{
+			name:     "it should return error and count the failure when login attempt repository DeleteOlderThan return unexpected error",
+			expected: expected{output: dto.JanitorRunOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.metrics.On("Add", metricRuns, int64(1))
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricBatches, int64(1)).Once()
+				d.metrics.On("Add", metricAuthenticationsDeleted, int64(0))
+				d.loginAttemptRepository.On("DeleteOlderThan", context.Background(), matchRetention, 2).
+					Return(int64(0), test.ErrUnexpected)
+				d.metrics.On("Add", metricFailures, int64(1))
+			},
+		},
+		{
+			name:     "it should return error and count the failure when identity repository DeleteExpiredStates return unexpected error",
+			expected: expected{output: dto.JanitorRunOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.metrics.On("Add", metricRuns, int64(1))
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricBatches, int64(1)).Twice()
+				d.metrics.On("Add", metricAuthenticationsDeleted, int64(0))
+				d.loginAttemptRepository.On("DeleteOlderThan", context.Background(), matchRetention, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricLoginAttemptsDeleted, int64(0))
+				d.identityRepository.On("DeleteExpiredStates", context.Background(), matchNow, 2).
+					Return(int64(0), test.ErrUnexpected)
+				d.metrics.On("Add", metricFailures, int64(1))
+			},
+		},
+		{
+			name:     "it should return error and count the failure when two factor repository DeleteExpiredChallenges return unexpected error",
+			expected: expected{output: dto.JanitorRunOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.metrics.On("Add", metricRuns, int64(1))
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricBatches, int64(1)).Times(3)
+				d.metrics.On("Add", metricAuthenticationsDeleted, int64(0))
+				d.loginAttemptRepository.On("DeleteOlderThan", context.Background(), matchRetention, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricLoginAttemptsDeleted, int64(0))
+				d.identityRepository.On("DeleteExpiredStates", context.Background(), matchNow, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricOIDCStatesDeleted, int64(0))
+				d.twoFactorRepository.On("DeleteExpiredChallenges", context.Background(), matchNow, 2).
+					Return(int64(0), test.ErrUnexpected)
+				d.metrics.On("Add", metricFailures, int64(1))
+			},
+		},
+		{
+			name:     "it should return error nil and delete in batches until a batch is short when success",
+			expected: expected{output: dto.JanitorRunOut{Authentications: 5, LoginAttempts: 3, OIDCStates: 1, MFAChallenges: 2}, err: nil},
+			setup: func(d *dependency) {
+				d.metrics.On("Add", metricRuns, int64(1))
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(2), nil).Twice()
+				d.metrics.On("Add", metricBatches, int64(1)).Times(8)
+				d.metrics.On("Add", metricAuthenticationsDeleted, int64(2)).Twice()
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(1), nil).Once()
+				d.metrics.On("Add", metricAuthenticationsDeleted, int64(1)).Once()
+				d.loginAttemptRepository.On("DeleteOlderThan", context.Background(), matchRetention, 2).
+					Return(int64(2), nil).Once()
+				d.metrics.On("Add", metricLoginAttemptsDeleted, int64(2)).Once()
+				d.loginAttemptRepository.On("DeleteOlderThan", context.Background(), matchRetention, 2).
+					Return(int64(1), nil).Once()
+				d.metrics.On("Add", metricLoginAttemptsDeleted, int64(1)).Once()
+				d.identityRepository.On("DeleteExpiredStates", context.Background(), matchNow, 2).
+					Return(int64(1), nil).Once()
+				d.metrics.On("Add", metricOIDCStatesDeleted, int64(1)).Once()
+				d.twoFactorRepository.On("DeleteExpiredChallenges", context.Background(), matchNow, 2).
+					Return(int64(2), nil).Once()
+				d.metrics.On("Add", metricMFAChallengesDeleted, int64(2)).Once()
+				d.twoFactorRepository.On("DeleteExpiredChallenges", context.Background(), matchNow, 2).
+					Return(int64(0), nil).Once()
+				d.metrics.On("Add", metricMFAChallengesDeleted, int64(0)).Once()
+				d.metrics.On("Set", metricLastRunDuration, mock.AnythingOfType("int64"))
+				d.metrics.On("Set", metricLastSuccess, mock.AnythingOfType("int64"))
+			},
+		},
+		{
+			name:     "it should return error nil and record the run when nothing is expired",
+			expected: expected{output: dto.JanitorRunOut{Authentications: 0, LoginAttempts: 0, OIDCStates: 0, MFAChallenges: 0}, err: nil},
+			setup: func(d *dependency) {
+				d.metrics.On("Add", metricRuns, int64(1))
+				d.authRepository.On("DeleteExpired", context.Background(), matchNow, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricBatches, int64(1)).Times(4)
+				d.metrics.On("Add", metricAuthenticationsDeleted, int64(0))
+				d.loginAttemptRepository.On("DeleteOlderThan", context.Background(), matchRetention, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricLoginAttemptsDeleted, int64(0))
+				d.identityRepository.On("DeleteExpiredStates", context.Background(), matchNow, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricOIDCStatesDeleted, int64(0))
+				d.twoFactorRepository.On("DeleteExpiredChallenges", context.Background(), matchNow, 2).
+					Return(int64(0), nil)
+				d.metrics.On("Add", metricMFAChallengesDeleted, int64(0))
+				d.metrics.On("Set", metricLastRunDuration, mock.AnythingOfType("int64"))
+				d.metrics.On("Set", metricLastSuccess, mock.AnythingOfType("int64"))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				authRepository:         &mocks.AuthRepository{},
+				loginAttemptRepository: &mocks.LoginAttemptRepository{},
+				identityRepository:     &mocks.IdentityRepository{},
+				twoFactorRepository:    &mocks.TwoFactorRepository{},
+				metrics:                &mocks.MetricsProvider{},
+			}
+			t.setup(d)
+
+			usecase := New(d.authRepository, d.loginAttemptRepository, d.identityRepository, d.twoFactorRepository, d.metrics, 2)
+			output, err := usecase.Run(context.Background())
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+			d.authRepository.AssertExpectations(s.T())
+			d.loginAttemptRepository.AssertExpectations(s.T())
+			d.identityRepository.AssertExpectations(s.T())
+			d.twoFactorRepository.AssertExpectations(s.T())
+			d.metrics.AssertExpectations(s.T())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new login attempt repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new failed login attempt to database.
+func (r *Repository) Store(ctx context.Context, a *entity.LoginAttempt) error {
+	id := entity.LoginAttemptID(r.idProvider.Generate())
+	q := `INSERT INTO login_attempts (id, scope, identifier) VALUES ($1, $2, $3)`
+	_, err := r.db.ExecContext(ctx, q, id, a.Scope, a.Identifier)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// CountSince count the failed login attempts of an identifier since a time and find the latest one.
+// Counting in the database makes the throttling hold across every replica of the api.
+func (r *Repository) CountSince(ctx context.Context, scope entity.LoginAttemptScope, identifier string, since time.Time) (entity.LoginFailures, error) {
+	var f entity.LoginFailures
+	var lastFailedAt sql.NullTime
+	q := `SELECT COUNT(id), MAX(created_at) FROM login_attempts WHERE scope = $1 AND identifier = $2 AND created_at > $3`
+	err := r.db.QueryRowContext(ctx, q, scope, identifier, since).Scan(&f.Count, &lastFailedAt)
+	if err != nil {
+		return f, err
+	}
+	f.LastFailedAt = lastFailedAt.Time
+	return f, nil
+}
+
+// DeleteByIdentifier delete every failed login attempt of an identifier.
+func (r *Repository) DeleteByIdentifier(ctx context.Context, scope entity.LoginAttemptScope, identifier string) error {
+	q := `DELETE FROM login_attempts WHERE scope = $1 AND identifier = $2`
+	_, err := r.db.ExecContext(ctx, q, scope, identifier)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteOlderThan remove up to limit failed login attempts made before the given time and return how many were removed.
+func (r *Repository) DeleteOlderThan(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := `DELETE FROM login_attempts WHERE id IN (SELECT id FROM login_attempts WHERE created_at < $1 LIMIT $2)`
+	result, err := r.db.ExecContext(ctx, q, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,252 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type LoginAttemptRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestLoginAttemptRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LoginAttemptRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+func (s *LoginAttemptRepositoryTestSuite) TestStore() {
+	query := regexp.QuoteMeta(`INSERT INTO login_attempts (id, scope, identifier) VALUES ($1, $2, $3)`)
+	attempt := &entity.LoginAttempt{Scope: entity.LoginAttemptScopeAccount, Identifier: "gopher@go.dev"}
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("login-attempt-xxxxx")
+
+				d.mockDB.ExpectExec(query).
+					WithArgs("login-attempt-xxxxx", entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("login-attempt-xxxxx")
+
+				d.mockDB.ExpectExec(query).
+					WithArgs("login-attempt-xxxxx", entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.Store(context.Background(), attempt)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *LoginAttemptRepositoryTestSuite) TestCountSince() {
+	query := regexp.QuoteMeta(`SELECT COUNT(id), MAX(created_at) FROM login_attempts WHERE scope = $1 AND identifier = $2 AND created_at > $3`)
+
+	type expected struct {
+		failures entity.LoginFailures
+		err      error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			expected: expected{failures: entity.LoginFailures{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs(entity.LoginAttemptScopeIP, "203.0.113.7", test.TimeBeforeNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and no failures when there are no attempts",
+			expected: expected{failures: entity.LoginFailures{}, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"count", "max"}).AddRow(0, nil)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs(entity.LoginAttemptScopeIP, "203.0.113.7", test.TimeBeforeNow).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:     "it should return error nil and the failures when there are attempts",
+			expected: expected{failures: entity.LoginFailures{Count: 3, LastFailedAt: test.TimeAfterNow}, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"count", "max"}).AddRow(3, test.TimeAfterNow)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs(entity.LoginAttemptScopeIP, "203.0.113.7", test.TimeBeforeNow).
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			failures, err := repository.CountSince(context.Background(), entity.LoginAttemptScopeIP, "203.0.113.7", test.TimeBeforeNow)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.failures, failures)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *LoginAttemptRepositoryTestSuite) TestDeleteByIdentifier() {
+	query := regexp.QuoteMeta(`DELETE FROM login_attempts WHERE scope = $1 AND identifier = $2`)
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs(entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs(entity.LoginAttemptScopeAccount, "gopher@go.dev").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.DeleteByIdentifier(context.Background(), entity.LoginAttemptScopeAccount, "gopher@go.dev")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *LoginAttemptRepositoryTestSuite) TestDeleteOlderThan() {
+	query := regexp.QuoteMeta(`DELETE FROM login_attempts WHERE id IN (SELECT id FROM login_attempts WHERE created_at < $1 LIMIT $2)`)
+
+	type expected struct {
+		deleted int64
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{deleted: 0, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs(test.TimeBeforeNow, 100).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and the number of deleted attempts when successfully delete",
+			expected: expected{deleted: 42, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs(test.TimeBeforeNow, 100).
+					WillReturnResult(sqlmock.NewResult(0, 42))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			deleted, err := repository.DeleteOlderThan(context.Background(), test.TimeBeforeNow, 100)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.deleted, deleted)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
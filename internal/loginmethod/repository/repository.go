@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+// New create a new login method setting repository.
+func New(db *sql.DB) Repository {
+	return Repository{db: db}
+}
+
+// FindAll find the settings of every login method.
+func (r *Repository) FindAll(ctx context.Context) ([]entity.LoginMethodSetting, error) {
+	q := `SELECT method, enabled, updated_at FROM login_methods ORDER BY method`
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	settings := make([]entity.LoginMethodSetting, 0)
+	for rows.Next() {
+		var m entity.LoginMethodSetting
+		err := rows.Scan(&m.Method, &m.Enabled, &m.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		settings = append(settings, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+// IsEnabled check whether users can log in with a login method, unknown methods are disabled.
+func (r *Repository) IsEnabled(ctx context.Context, method entity.LoginMethod) (bool, error) {
+	var enabled bool
+	q := `SELECT enabled FROM login_methods WHERE method = $1`
+	err := r.db.QueryRowContext(ctx, q, method).Scan(&enabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return enabled, nil
+}
+
+// Update turn a login method on or off.
+func (r *Repository) Update(ctx context.Context, method entity.LoginMethod, enabled bool) error {
+	q := `UPDATE login_methods SET enabled = $2, updated_at = NOW() WHERE method = $1`
+	result, err := r.db.ExecContext(ctx, q, method, enabled)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrLoginMethodNotFound
+	}
+	return nil
+}
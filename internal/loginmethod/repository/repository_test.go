@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type LoginMethodRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestLoginMethodRepositorySuite(t *testing.T) {
+	suite.Run(t, new(LoginMethodRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB sqlmock.Sqlmock
+}
+
+var (
+	findAllQuery   = regexp.QuoteMeta(`SELECT method, enabled, updated_at FROM login_methods ORDER BY method`)
+	isEnabledQuery = regexp.QuoteMeta(`SELECT enabled FROM login_methods WHERE method = $1`)
+	updateQuery    = regexp.QuoteMeta(`UPDATE login_methods SET enabled = $2, updated_at = NOW() WHERE method = $1`)
+)
+
+func (s *LoginMethodRepositoryTestSuite) TestFindAll() {
+	type expected struct {
+		settings []entity.LoginMethodSetting
+		err      error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{settings: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findAllQuery).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when row scan fail",
+			expected: expected{settings: nil, err: test.ErrRowScan},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"method", "enabled", "updated_at"}).
+					AddRow("magic_link", false, test.TimeBeforeNow).
+					RowError(0, test.ErrRowScan)
+
+				d.mockDB.ExpectQuery(findAllQuery).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "it should return error nil and settings when found",
+			expected: expected{
+				settings: []entity.LoginMethodSetting{
+					{Method: entity.LoginMethodMagicLink, Enabled: false, UpdatedAt: test.TimeBeforeNow},
+					{Method: entity.LoginMethodPassword, Enabled: true, UpdatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"method", "enabled", "updated_at"}).
+					AddRow("magic_link", false, test.TimeBeforeNow).
+					AddRow("password", true, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findAllQuery).
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db)
+			settings, err := repository.FindAll(context.Background())
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.settings, settings)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *LoginMethodRepositoryTestSuite) TestIsEnabled() {
+	type expected struct {
+		enabled bool
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			expected: expected{enabled: false, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(isEnabledQuery).
+					WithArgs("magic_link").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and false when login method is unknown",
+			expected: expected{enabled: false, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(isEnabledQuery).
+					WithArgs("magic_link").
+					WillReturnRows(sqlmock.NewRows([]string{"enabled"}))
+			},
+		},
+		{
+			name:     "it should return error nil and true when login method is enabled",
+			expected: expected{enabled: true, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(isEnabledQuery).
+					WithArgs("magic_link").
+					WillReturnRows(sqlmock.NewRows([]string{"enabled"}).AddRow(true))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db)
+			enabled, err := repository.IsEnabled(context.Background(), entity.LoginMethodMagicLink)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.enabled, enabled)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *LoginMethodRepositoryTestSuite) TestUpdate() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(updateQuery).
+					WithArgs("magic_link", true).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to report affected rows",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(updateQuery).
+					WithArgs("magic_link", true).
+					WillReturnResult(sqlmock.NewErrorResult(test.ErrDatabase))
+			},
+		},
+		{
+			name:     "it should return error ErrLoginMethodNotFound when login method is unknown",
+			expected: domain.ErrLoginMethodNotFound,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(updateQuery).
+					WithArgs("magic_link", true).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(updateQuery).
+					WithArgs("magic_link", true).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db)
+			err = repository.Update(context.Background(), entity.LoginMethodMagicLink, true)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
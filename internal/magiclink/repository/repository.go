@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new magic link repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new magic link to database.
+func (r *Repository) Store(ctx context.Context, m *entity.MagicLink) error {
+	id := entity.MagicLinkID(r.idProvider.Generate())
+	q := `INSERT INTO magic_links (id, user_id, token_hash, nonce_hash, expires_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.ExecContext(ctx, q, id, m.UserID, m.TokenHash, m.NonceHash, m.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Consume delete a magic link by token hash and return it.
+// Deleting and reading in one statement makes every link single use.
+func (r *Repository) Consume(ctx context.Context, tokenHash string) (entity.MagicLink, error) {
+	var m entity.MagicLink
+	q := `DELETE FROM magic_links WHERE token_hash = $1 RETURNING id, user_id, token_hash, nonce_hash, expires_at, created_at`
+	err := r.db.QueryRowContext(ctx, q, tokenHash).Scan(&m.ID, &m.UserID, &m.TokenHash, &m.NonceHash, &m.ExpiresAt, &m.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return m, domain.ErrMagicLinkNotFound
+	} else if err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// DeleteByUserID delete every magic link of a user.
+func (r *Repository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	q := `DELETE FROM magic_links WHERE user_id = $1`
+	_, err := r.db.ExecContext(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type MagicLinkRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestMagicLinkRepositorySuite(t *testing.T) {
+	suite.Run(t, new(MagicLinkRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	insertMagicLinkQuery = regexp.QuoteMeta(`INSERT INTO magic_links (id, user_id, token_hash, nonce_hash, expires_at) VALUES ($1, $2, $3, $4, $5)`)
+	consumeQuery         = regexp.QuoteMeta(`DELETE FROM magic_links WHERE token_hash = $1 RETURNING id, user_id, token_hash, nonce_hash, expires_at, created_at`)
+	deleteByUserIDQuery  = regexp.QuoteMeta(`DELETE FROM magic_links WHERE user_id = $1`)
+)
+
+var columns = []string{"id", "user_id", "token_hash", "nonce_hash", "expires_at", "created_at"}
+
+func newMagicLink() entity.MagicLink {
+	return entity.MagicLink{
+		ID:        "magic-link-xxxxx",
+		UserID:    "user-xxxxx",
+		TokenHash: "token_hash",
+		NonceHash: "nonce_hash",
+		ExpiresAt: test.TimeAfterNow,
+		CreatedAt: test.TimeBeforeNow,
+	}
+}
+
+func (s *MagicLinkRepositoryTestSuite) TestStore() {
+	magicLink := &entity.MagicLink{UserID: "user-xxxxx", TokenHash: "token_hash", NonceHash: "nonce_hash", ExpiresAt: test.TimeAfterNow}
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("magic-link-xxxxx")
+
+				d.mockDB.ExpectExec(insertMagicLinkQuery).
+					WithArgs("magic-link-xxxxx", "user-xxxxx", "token_hash", "nonce_hash", test.TimeAfterNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("magic-link-xxxxx")
+
+				d.mockDB.ExpectExec(insertMagicLinkQuery).
+					WithArgs("magic-link-xxxxx", "user-xxxxx", "token_hash", "nonce_hash", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.Store(context.Background(), magicLink)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *MagicLinkRepositoryTestSuite) TestConsume() {
+	type expected struct {
+		magicLink entity.MagicLink
+		err       error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{magicLink: entity.MagicLink{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrMagicLinkNotFound when token is unknown or already used",
+			expected: expected{magicLink: entity.MagicLink{}, err: domain.ErrMagicLinkNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and the consumed magic link when success",
+			expected: expected{magicLink: newMagicLink(), err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("magic-link-xxxxx", "user-xxxxx", "token_hash", "nonce_hash", test.TimeAfterNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			magicLink, err := repository.Consume(context.Background(), "token_hash")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.magicLink, magicLink)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *MagicLinkRepositoryTestSuite) TestDeleteByUserID() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.DeleteByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/pkg/httpsvr"
+)
+
+type HTTPHandler struct {
+	validator            domain.ValidatorProvider
+	passwordResetUsecase domain.PasswordResetUsecase
+}
+
+// New creates a new password reset handler.
+func New(validator domain.ValidatorProvider, passwordResetUsecase domain.PasswordResetUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, passwordResetUsecase: passwordResetUsecase}
+}
+
+// POST /password-resets to request a password reset email.
+// The response is the same whether or not the email is registered, only too many requests are refused.
+func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.PasswordResetRequestIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.IPAddress = httpsvr.ClientIP(r)
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	if err := h.passwordResetUsecase.Request(r.Context(), &payload); err != nil {
+		var throttled *domain.PasswordResetThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			code, msg := errorx.HTTPErrorTranslator(err)
+			w.WriteHeader(code)
+			encoder.Encode(domain.NewErrorResponse(code, msg))
+			return
+		}
+		log.Println("[ERROR]", err)
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusAccepted, "If the email is registered, a password reset link has been sent", nil))
+}
+
+// POST /password-resets/confirm to set a new password with a password reset token.
+func (h *HTTPHandler) PostConfirm(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.PasswordResetConfirmIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserAgent = r.UserAgent()
+	payload.IPAddress = httpsvr.ClientIP(r)
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	if err := h.passwordResetUsecase.Confirm(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully reset password", nil))
+}
@@ -0,0 +1,283 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type PasswordResetHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestPasswordResetHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	req                  *http.Request
+	validator            *mocks.ValidatorProvider
+	passwordResetUsecase *mocks.PasswordResetUsecase
+}
+
+func (s *PasswordResetHTTPHandlerTestSuite) TestPost() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		retryAfter  string
+	}
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Email is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.PasswordResetRequestIn{IPAddress: "192.0.2.1"}).
+					Return(dto.ErrEmailEmpty)
+			},
+		},
+		{
+			name:        "it should response with error and retry after when password reset usecase Request return throttled error",
+			isError:     true,
+			requestBody: []byte(`{"email":"gopher@go.dev"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusTooManyRequests,
+				message:     http.StatusText(http.StatusTooManyRequests),
+				error:       "Too many password reset requests, please try again later",
+				retryAfter:  "90",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.PasswordResetRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.passwordResetUsecase.On("Request", mock.Anything, &dto.PasswordResetRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(&domain.PasswordResetThrottledError{RetryAfter: 90 * time.Second})
+			},
+		},
+		{
+			name:        "it should response with accepted even when password reset usecase Request return error",
+			isError:     false,
+			requestBody: []byte(`{"email":"gopher@go.dev"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusAccepted,
+				message:     "If the email is registered, a password reset link has been sent",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.PasswordResetRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.passwordResetUsecase.On("Request", mock.Anything, &dto.PasswordResetRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:        "it should response with accepted when success",
+			isError:     false,
+			requestBody: []byte(`{"email":"gopher@go.dev"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusAccepted,
+				message:     "If the email is registered, a password reset link has been sent",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.PasswordResetRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.passwordResetUsecase.On("Request", mock.Anything, &dto.PasswordResetRequestIn{Email: "gopher@go.dev", IPAddress: "192.0.2.1"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				validator:            &mocks.ValidatorProvider{},
+				passwordResetUsecase: &mocks.PasswordResetUsecase{},
+				req:                  req,
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.passwordResetUsecase)
+			handler.Post(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+			s.Equal(t.expected.retryAfter, rr.Header().Get("Retry-After"))
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Nil(resBody.Payload)
+			}
+		})
+	}
+}
+
+func (s *PasswordResetHTTPHandlerTestSuite) TestPostConfirm() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Token is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.PasswordResetConfirmIn{IPAddress: "192.0.2.1"}).
+					Return(dto.ErrTokenEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when password reset usecase Confirm return error",
+			isError:     true,
+			requestBody: []byte(`{"token":"raw_token","password":"new_secret_password"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Password reset token is expired",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.passwordResetUsecase.On("Confirm", mock.Anything, &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password", IPAddress: "192.0.2.1"}).
+					Return(entity.ErrPasswordResetTokenExpired)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"token":"raw_token","password":"new_secret_password"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully reset password",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.passwordResetUsecase.On("Confirm", mock.Anything, &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password", IPAddress: "192.0.2.1"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/confirm", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				validator:            &mocks.ValidatorProvider{},
+				passwordResetUsecase: &mocks.PasswordResetUsecase{},
+				req:                  req,
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.passwordResetUsecase)
+			handler.PostConfirm(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Nil(resBody.Payload)
+			}
+		})
+	}
+}
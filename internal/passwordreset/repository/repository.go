@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new password reset repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new password reset to database.
+func (r *Repository) Store(ctx context.Context, p *entity.PasswordReset) error {
+	id := entity.PasswordResetID(r.idProvider.Generate())
+	q := `INSERT INTO password_resets (id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.ExecContext(ctx, q, id, p.UserID, p.TokenHash, p.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindByTokenHash find a password reset by token hash without using it up.
+func (r *Repository) FindByTokenHash(ctx context.Context, tokenHash string) (entity.PasswordReset, error) {
+	var p entity.PasswordReset
+	q := `SELECT id, user_id, token_hash, expires_at, created_at FROM password_resets WHERE token_hash = $1`
+	err := r.db.QueryRowContext(ctx, q, tokenHash).Scan(&p.ID, &p.UserID, &p.TokenHash, &p.ExpiresAt, &p.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return p, domain.ErrPasswordResetNotFound
+	} else if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// Consume delete a password reset by token hash and return it.
+// Deleting and reading in one statement makes every token single use.
+func (r *Repository) Consume(ctx context.Context, tokenHash string) (entity.PasswordReset, error) {
+	var p entity.PasswordReset
+	q := `DELETE FROM password_resets WHERE token_hash = $1 RETURNING id, user_id, token_hash, expires_at, created_at`
+	err := r.db.QueryRowContext(ctx, q, tokenHash).Scan(&p.ID, &p.UserID, &p.TokenHash, &p.ExpiresAt, &p.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return p, domain.ErrPasswordResetNotFound
+	} else if err != nil {
+		return p, err
+	}
+	return p, nil
+}
+
+// DeleteByUserID delete every password reset of a user.
+func (r *Repository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	q := `DELETE FROM password_resets WHERE user_id = $1`
+	_, err := r.db.ExecContext(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
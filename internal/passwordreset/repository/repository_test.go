@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type PasswordResetRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestPasswordResetRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	insertPasswordResetQuery = regexp.QuoteMeta(`INSERT INTO password_resets (id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4)`)
+	findByTokenHashQuery     = regexp.QuoteMeta(`SELECT id, user_id, token_hash, expires_at, created_at FROM password_resets WHERE token_hash = $1`)
+	consumeQuery             = regexp.QuoteMeta(`DELETE FROM password_resets WHERE token_hash = $1 RETURNING id, user_id, token_hash, expires_at, created_at`)
+	deleteByUserIDQuery      = regexp.QuoteMeta(`DELETE FROM password_resets WHERE user_id = $1`)
+)
+
+var columns = []string{"id", "user_id", "token_hash", "expires_at", "created_at"}
+
+func newPasswordReset() entity.PasswordReset {
+	return entity.PasswordReset{
+		ID:        "password-reset-xxxxx",
+		UserID:    "user-xxxxx",
+		TokenHash: "token_hash",
+		ExpiresAt: test.TimeAfterNow,
+		CreatedAt: test.TimeBeforeNow,
+	}
+}
+
+func (s *PasswordResetRepositoryTestSuite) TestStore() {
+	passwordReset := &entity.PasswordReset{UserID: "user-xxxxx", TokenHash: "token_hash", ExpiresAt: test.TimeAfterNow}
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("password-reset-xxxxx")
+
+				d.mockDB.ExpectExec(insertPasswordResetQuery).
+					WithArgs("password-reset-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("password-reset-xxxxx")
+
+				d.mockDB.ExpectExec(insertPasswordResetQuery).
+					WithArgs("password-reset-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.Store(context.Background(), passwordReset)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *PasswordResetRepositoryTestSuite) TestFindByTokenHash() {
+	type expected struct {
+		passwordReset entity.PasswordReset
+		err           error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			expected: expected{passwordReset: entity.PasswordReset{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByTokenHashQuery).
+					WithArgs("token_hash").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrPasswordResetNotFound when token is unknown or already used",
+			expected: expected{passwordReset: entity.PasswordReset{}, err: domain.ErrPasswordResetNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByTokenHashQuery).
+					WithArgs("token_hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and the password reset when found",
+			expected: expected{passwordReset: newPasswordReset(), err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("password-reset-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findByTokenHashQuery).
+					WithArgs("token_hash").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			passwordReset, err := repository.FindByTokenHash(context.Background(), "token_hash")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.passwordReset, passwordReset)
+		})
+	}
+}
+
+func (s *PasswordResetRepositoryTestSuite) TestConsume() {
+	type expected struct {
+		passwordReset entity.PasswordReset
+		err           error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{passwordReset: entity.PasswordReset{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrPasswordResetNotFound when token is unknown or already used",
+			expected: expected{passwordReset: entity.PasswordReset{}, err: domain.ErrPasswordResetNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and the consumed password reset when success",
+			expected: expected{passwordReset: newPasswordReset(), err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("password-reset-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			passwordReset, err := repository.Consume(context.Background(), "token_hash")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.passwordReset, passwordReset)
+		})
+	}
+}
+
+func (s *PasswordResetRepositoryTestSuite) TestDeleteByUserID() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.DeleteByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
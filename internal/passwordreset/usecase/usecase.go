@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// passwordResetTokenTTL is how long a password reset link stays valid.
+const passwordResetTokenTTL = 30 * time.Minute
+
+var (
+	// accountResetThrottle keep a single inbox from being flooded with password reset emails.
+	accountResetThrottle = entity.LoginThrottle{
+		Window:          time.Hour,
+		Free:            3,
+		BaseDelay:       time.Minute,
+		MaxDelay:        15 * time.Minute,
+		Lockout:         10,
+		LockoutDuration: time.Hour,
+	}
+	// ipResetThrottle slow down an ip address requesting resets for many emails,
+	// it is more tolerant as many users can share an ip address.
+	ipResetThrottle = entity.LoginThrottle{
+		Window:          time.Hour,
+		Free:            10,
+		BaseDelay:       time.Minute,
+		MaxDelay:        15 * time.Minute,
+		Lockout:         50,
+		LockoutDuration: time.Hour,
+	}
+)
+
+type Usecase struct {
+	passwordResetRepository domain.PasswordResetRepository
+	userRepository          domain.UserRepository
+	authRepository          domain.AuthRepository
+	revocationRepository    domain.RevocationRepository
+	securityEventRepository domain.SecurityEventRepository
+	loginAttemptRepository  domain.LoginAttemptRepository
+	hashProvider            domain.HashProvider
+	passwordPolicy          domain.PasswordPolicyProvider
+	tokenProvider           domain.TokenProvider
+	mailer                  domain.Mailer
+}
+
+// New create a new password reset usecase.
+func New(
+	passwordResetRepository domain.PasswordResetRepository,
+	userRepository domain.UserRepository,
+	authRepository domain.AuthRepository,
+	revocationRepository domain.RevocationRepository,
+	securityEventRepository domain.SecurityEventRepository,
+	loginAttemptRepository domain.LoginAttemptRepository,
+	hashProvider domain.HashProvider,
+	passwordPolicy domain.PasswordPolicyProvider,
+	tokenProvider domain.TokenProvider,
+	mailer domain.Mailer,
+) Usecase {
+	return Usecase{
+		passwordResetRepository: passwordResetRepository,
+		userRepository:          userRepository,
+		authRepository:          authRepository,
+		revocationRepository:    revocationRepository,
+		securityEventRepository: securityEventRepository,
+		loginAttemptRepository:  loginAttemptRepository,
+		hashProvider:            hashProvider,
+		passwordPolicy:          passwordPolicy,
+		tokenProvider:           tokenProvider,
+		mailer:                  mailer,
+	}
+}
+
+// Request send a password reset email, invalidating the previous ones.
+// Unknown emails are ignored so the result never reveals which emails are registered,
+// and so are service accounts, which have no password to reset.
+// Every request counts against the email and the ip address, registered or not, so it can't flood an inbox.
+func (u *Usecase) Request(ctx context.Context, payload *dto.PasswordResetRequestIn) error {
+	attempts := resetAttempts(payload.Email, payload.IPAddress)
+	if err := u.checkResetThrottle(ctx, attempts); err != nil {
+		return err
+	}
+	for i := range attempts {
+		if err := u.loginAttemptRepository.Store(ctx, &attempts[i]); err != nil {
+			return err
+		}
+	}
+
+	user, err := u.userRepository.FindByEmail(ctx, payload.Email)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if user.Role == entity.RoleServiceAccount {
+		return nil
+	}
+
+	token, err := u.tokenProvider.Generate()
+	if err != nil {
+		return err
+	}
+
+	if err := u.passwordResetRepository.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	passwordReset := &entity.PasswordReset{
+		UserID:    user.ID,
+		TokenHash: u.tokenProvider.Hash(token),
+		ExpiresAt: time.Now().Add(passwordResetTokenTTL),
+	}
+	if err := u.passwordResetRepository.Store(ctx, passwordReset); err != nil {
+		return err
+	}
+
+	mail := &entity.Mail{
+		To:       user.Email,
+		Template: entity.MailTemplateResetPassword,
+		Data:     map[string]string{"Name": user.Name, "Token": token},
+	}
+	return u.mailer.Send(ctx, mail)
+}
+
+// resetAttempts return the email and, when known, the ip address password reset requests are counted against.
+func resetAttempts(email string, ipAddress string) []entity.LoginAttempt {
+	attempts := []entity.LoginAttempt{{Scope: entity.LoginAttemptScopeResetAccount, Identifier: strings.ToLower(email)}}
+	if ipAddress != "" {
+		attempts = append(attempts, entity.LoginAttempt{Scope: entity.LoginAttemptScopeResetIP, Identifier: ipAddress})
+	}
+	return attempts
+}
+
+// checkResetThrottle return a PasswordResetThrottledError with the longest wait when any of the attempts is throttled.
+func (u *Usecase) checkResetThrottle(ctx context.Context, attempts []entity.LoginAttempt) error {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, a := range attempts {
+		throttle := accountResetThrottle
+		if a.Scope == entity.LoginAttemptScopeResetIP {
+			throttle = ipResetThrottle
+		}
+		requests, err := u.loginAttemptRepository.CountSince(ctx, a.Scope, a.Identifier, now.Add(-throttle.Window))
+		if err != nil {
+			return err
+		}
+		if wait := throttle.RetryAfter(requests, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+
+	if retryAfter > 0 {
+		return &domain.PasswordResetThrottledError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Confirm set a new password with a password reset token and sign out every session of the user.
+// The token is only used up once the new password passes the password policy.
+func (u *Usecase) Confirm(ctx context.Context, payload *dto.PasswordResetConfirmIn) error {
+	tokenHash := u.tokenProvider.Hash(payload.Token)
+	passwordReset, err := u.passwordResetRepository.FindByTokenHash(ctx, tokenHash)
+	if errors.Is(err, domain.ErrPasswordResetNotFound) {
+		return domain.ErrPasswordResetTokenInvalid
+	} else if err != nil {
+		return err
+	}
+	if err := passwordReset.VerifyTokenExpires(); err != nil {
+		return err
+	}
+
+	user, err := u.userRepository.FindByID(ctx, passwordReset.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.passwordPolicy.Check(payload.Password, &user); err != nil {
+		return err
+	}
+
+	// Using the token up is atomic, so a token confirmed twice at once only sets one password.
+	if _, err := u.passwordResetRepository.Consume(ctx, tokenHash); errors.Is(err, domain.ErrPasswordResetNotFound) {
+		return domain.ErrPasswordResetTokenInvalid
+	} else if err != nil {
+		return err
+	}
+
+	securePassword, err := u.hashProvider.Hash(payload.Password)
+	if err != nil {
+		return err
+	}
+	if err := u.userRepository.UpdatePassword(ctx, passwordReset.UserID, string(securePassword)); err != nil {
+		return err
+	}
+	if err := u.passwordResetRepository.DeleteByUserID(ctx, passwordReset.UserID); err != nil {
+		return err
+	}
+	if err := u.authRepository.DeleteByUserID(ctx, passwordReset.UserID); err != nil {
+		return err
+	}
+	if err := u.revocationRepository.StoreWatermark(ctx, passwordReset.UserID, time.Now()); err != nil {
+		return err
+	}
+
+	event := &entity.SecurityEvent{UserID: passwordReset.UserID, Type: entity.SecurityEventPasswordReset, UserAgent: payload.UserAgent, IPAddress: payload.IPAddress}
+	return u.securityEventRepository.Store(ctx, event)
+}
@@ -0,0 +1,453 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type PasswordResetUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestPasswordResetUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PasswordResetUsecaseTestSuite))
+}
+
+type dependency struct {
+	passwordResetRepository *mocks.PasswordResetRepository
+	userRepository          *mocks.UserRepository
+	authRepository          *mocks.AuthRepository
+	revocationRepository    *mocks.RevocationRepository
+	securityEventRepository *mocks.SecurityEventRepository
+	loginAttemptRepository  *mocks.LoginAttemptRepository
+	hashProvider            *mocks.HashProvider
+	passwordPolicy          *mocks.PasswordPolicyProvider
+	tokenProvider           *mocks.TokenProvider
+	mailer                  *mocks.Mailer
+}
+
+func newDependency() *dependency {
+	return &dependency{
+		passwordResetRepository: &mocks.PasswordResetRepository{},
+		userRepository:          &mocks.UserRepository{},
+		authRepository:          &mocks.AuthRepository{},
+		revocationRepository:    &mocks.RevocationRepository{},
+		securityEventRepository: &mocks.SecurityEventRepository{},
+		loginAttemptRepository:  &mocks.LoginAttemptRepository{},
+		hashProvider:            &mocks.HashProvider{},
+		passwordPolicy:          &mocks.PasswordPolicyProvider{},
+		tokenProvider:           &mocks.TokenProvider{},
+		mailer:                  &mocks.Mailer{},
+	}
+}
+
+func newUsecase(d *dependency) Usecase {
+	return New(d.passwordResetRepository, d.userRepository, d.authRepository, d.revocationRepository, d.securityEventRepository, d.loginAttemptRepository, d.hashProvider, d.passwordPolicy, d.tokenProvider, d.mailer)
+}
+
+// matchPasswordReset match a password reset of the user that expires in half an hour.
+func matchPasswordReset(userID entity.UserID) any {
+	return mock.MatchedBy(func(p *entity.PasswordReset) bool {
+		expiresIn := time.Until(p.ExpiresAt)
+		return p.UserID == userID && p.TokenHash == "token_hash" && expiresIn > 29*time.Minute && expiresIn <= 30*time.Minute
+	})
+}
+
+var resetMail = &entity.Mail{
+	To:       "gopher@go.dev",
+	Template: entity.MailTemplateResetPassword,
+	Data:     map[string]string{"Name": "Gopher", "Token": "raw_token"},
+}
+
+func (s *PasswordResetUsecaseTestSuite) TestRequest() {
+	user := entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}
+	payload := &dto.PasswordResetRequestIn{Email: "Gopher@go.dev", IPAddress: "203.0.113.7"}
+
+	// allowRequest expect the email and the ip address to be under their throttle and record the request.
+	allowRequest := func(d *dependency) {
+		d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeResetAccount, "gopher@go.dev", mock.AnythingOfType("time.Time")).
+			Return(entity.LoginFailures{}, nil)
+		d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeResetIP, "203.0.113.7", mock.AnythingOfType("time.Time")).
+			Return(entity.LoginFailures{}, nil)
+		d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeResetAccount, Identifier: "gopher@go.dev"}).
+			Return(nil)
+		d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeResetIP, Identifier: "203.0.113.7"}).
+			Return(nil)
+	}
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when login attempt repository CountSince return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeResetAccount, "gopher@go.dev", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error PasswordResetThrottledError without sending email when the email requested too many resets",
+			expected: &domain.PasswordResetThrottledError{RetryAfter: accountResetThrottle.LockoutDuration},
+			setup: func(d *dependency) {
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeResetAccount, "gopher@go.dev", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{Count: accountResetThrottle.Lockout, LastFailedAt: time.Now()}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeResetIP, "203.0.113.7", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{}, nil)
+			},
+		},
+		{
+			name:     "it should return error when login attempt repository Store return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeResetAccount, "gopher@go.dev", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("CountSince", context.Background(), entity.LoginAttemptScopeResetIP, "203.0.113.7", mock.AnythingOfType("time.Time")).
+					Return(entity.LoginFailures{}, nil)
+				d.loginAttemptRepository.On("Store", context.Background(), &entity.LoginAttempt{Scope: entity.LoginAttemptScopeResetAccount, Identifier: "gopher@go.dev"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil without sending email when email is not registered",
+			expected: nil,
+			setup: func(d *dependency) {
+				allowRequest(d)
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error when user repository FindByEmail return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowRequest(d)
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil without sending email when user is a service account",
+			expected: nil,
+			setup: func(d *dependency) {
+				allowRequest(d)
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").
+					Return(entity.User{ID: "user-xxxxx", Email: "gopher@go.dev", Role: entity.RoleServiceAccount}, nil)
+			},
+		},
+		{
+			name:     "it should return error when token provider Generate return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowRequest(d)
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").Return(user, nil)
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when password reset repository DeleteByUserID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowRequest(d)
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").Return(user, nil)
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when password reset repository Store return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowRequest(d)
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").Return(user, nil)
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.passwordResetRepository.On("Store", context.Background(), matchPasswordReset("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when mailer Send return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowRequest(d)
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").Return(user, nil)
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.passwordResetRepository.On("Store", context.Background(), matchPasswordReset("user-xxxxx")).
+					Return(nil)
+				d.mailer.On("Send", context.Background(), resetMail).Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully send the password reset email",
+			expected: nil,
+			setup: func(d *dependency) {
+				allowRequest(d)
+				d.userRepository.On("FindByEmail", context.Background(), "Gopher@go.dev").Return(user, nil)
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.passwordResetRepository.On("Store", context.Background(), matchPasswordReset("user-xxxxx")).
+					Return(nil)
+				d.mailer.On("Send", context.Background(), resetMail).Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Request(context.Background(), payload)
+
+			var throttled *domain.PasswordResetThrottledError
+			if errors.As(t.expected, &throttled) {
+				var actual *domain.PasswordResetThrottledError
+				s.Require().ErrorAs(err, &actual)
+				s.InDelta(throttled.RetryAfter, actual.RetryAfter, float64(time.Second))
+			} else {
+				s.Equal(t.expected, err)
+			}
+			d.mailer.AssertExpectations(s.T())
+			d.loginAttemptRepository.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *PasswordResetUsecaseTestSuite) TestConfirm() {
+	validReset := entity.PasswordReset{UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}
+	user := entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}
+
+	// allowPassword expect a valid token whose user passes the password policy.
+	allowPassword := func(d *dependency) {
+		d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+		d.passwordResetRepository.On("FindByTokenHash", context.Background(), "token_hash").Return(validReset, nil)
+		d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+		d.passwordPolicy.On("Check", "new_secret_password", &user).Return(nil)
+	}
+
+	tests := []struct {
+		name     string
+		payload  *dto.PasswordResetConfirmIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrPasswordResetTokenInvalid when token is unknown or already used",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: domain.ErrPasswordResetTokenInvalid,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.passwordResetRepository.On("FindByTokenHash", context.Background(), "token_hash").
+					Return(entity.PasswordReset{}, domain.ErrPasswordResetNotFound)
+			},
+		},
+		{
+			name:     "it should return error when password reset repository FindByTokenHash return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.passwordResetRepository.On("FindByTokenHash", context.Background(), "token_hash").
+					Return(entity.PasswordReset{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrPasswordResetTokenExpired when token is expired",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: entity.ErrPasswordResetTokenExpired,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.passwordResetRepository.On("FindByTokenHash", context.Background(), "token_hash").
+					Return(entity.PasswordReset{UserID: "user-xxxxx", ExpiresAt: test.TimeBeforeNow}, nil)
+			},
+		},
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.passwordResetRepository.On("FindByTokenHash", context.Background(), "token_hash").Return(validReset, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error without using the token up when password policy Check return error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "short"},
+			expected: &entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: 8},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.passwordResetRepository.On("FindByTokenHash", context.Background(), "token_hash").Return(validReset, nil)
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.passwordPolicy.On("Check", "short", &user).
+					Return(&entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: 8})
+			},
+		},
+		{
+			name:     "it should return error ErrPasswordResetTokenInvalid when token is used up meanwhile",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: domain.ErrPasswordResetTokenInvalid,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").
+					Return(entity.PasswordReset{}, domain.ErrPasswordResetNotFound)
+			},
+		},
+		{
+			name:     "it should return error when password reset repository Consume return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").
+					Return(entity.PasswordReset{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when hash provider Hash return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").Return(validReset, nil)
+				d.hashProvider.On("Hash", "new_secret_password").Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when user repository UpdatePassword return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").Return(validReset, nil)
+				d.hashProvider.On("Hash", "new_secret_password").Return([]byte("hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "hashed_password").
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when password reset repository DeleteByUserID return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").Return(validReset, nil)
+				d.hashProvider.On("Hash", "new_secret_password").Return([]byte("hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "hashed_password").
+					Return(nil)
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when auth repository DeleteByUserID return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").Return(validReset, nil)
+				d.hashProvider.On("Hash", "new_secret_password").Return([]byte("hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "hashed_password").
+					Return(nil)
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when revocation repository StoreWatermark return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").Return(validReset, nil)
+				d.hashProvider.On("Hash", "new_secret_password").Return([]byte("hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "hashed_password").
+					Return(nil)
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when security event repository Store return unexpected error",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").Return(validReset, nil)
+				d.hashProvider.On("Hash", "new_secret_password").Return([]byte("hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "hashed_password").
+					Return(nil)
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventPasswordReset}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and record the reset when successfully reset the password",
+			payload:  &dto.PasswordResetConfirmIn{Token: "raw_token", Password: "new_secret_password", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"},
+			expected: nil,
+			setup: func(d *dependency) {
+				allowPassword(d)
+				d.passwordResetRepository.On("Consume", context.Background(), "token_hash").Return(validReset, nil)
+				d.hashProvider.On("Hash", "new_secret_password").Return([]byte("hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "hashed_password").
+					Return(nil)
+				d.passwordResetRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.authRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventPasswordReset, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Confirm(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+)
+
+type HTTPHandler struct {
+	validator            domain.ValidatorProvider
+	personalTokenUsecase domain.PersonalTokenUsecase
+}
+
+// New creates a new personal access token handler.
+func New(validator domain.ValidatorProvider, personalTokenUsecase domain.PersonalTokenUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, personalTokenUsecase: personalTokenUsecase}
+}
+
+// POST /tokens to create new personal access token.
+func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.PersonalTokenCreateIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserID = entity.GetAuthContext(r.Context())
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.personalTokenUsecase.Create(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusCreated, "Successfully created new personal access token", output))
+}
+
+// GET /tokens to get all personal access tokens of the authenticated user.
+func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.PersonalTokenGetAllIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+
+	output, err := h.personalTokenUsecase.GetAll(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// DELETE /tokens/{token_id} to revoke a personal access token.
+func (h *HTTPHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.PersonalTokenRevokeIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.TokenID = entity.PersonalTokenID(chi.URLParam(r, "token_id"))
+
+	if err := h.personalTokenUsecase.Revoke(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully revoked personal access token", nil))
+}
@@ -0,0 +1,288 @@
+package http
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type PersonalTokenHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestPersonalTokenHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(PersonalTokenHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	req                  *http.Request
+	validator            *mocks.ValidatorProvider
+	personalTokenUsecase *mocks.PersonalTokenUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *PersonalTokenHTTPHandlerTestSuite) assertResponse(rr *httptest.ResponseRecorder, isError bool, expected expected) {
+	s.Equal(expected.contentType, rr.Header().Get("Content-Type"))
+	s.Equal(expected.statusCode, rr.Code)
+
+	if isError {
+		var resBody domain.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.error, resBody.Error)
+	} else {
+		var resBody domain.SuccessResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.payload, resBody.Payload)
+	}
+}
+
+func (s *PersonalTokenHTTPHandlerTestSuite) TestPost() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{"name":"CI"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Scopes is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrScopesEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when personal token usecase Create return error",
+			isError:     true,
+			requestBody: []byte(`{"name":"CI","scopes":["users:write"]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Scopes must be any of tasks, templates or filters with read or write access",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.personalTokenUsecase.On("Create", mock.Anything, &dto.PersonalTokenCreateIn{UserID: "user-xxxxx", Name: "CI", Scopes: []string{"users:write"}}).
+					Return(dto.PersonalTokenCreateOut{}, entity.ErrScopeInvalid)
+			},
+		},
+		{
+			name:        "it should response with success and the raw token when success",
+			isError:     false,
+			requestBody: []byte(`{"name":"CI","scopes":["tasks:read"]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusCreated,
+				message:     "Successfully created new personal access token",
+				payload: map[string]any{
+					"id":         "token-xxxxx",
+					"name":       "CI",
+					"token":      "tkt_xxxxx",
+					"scopes":     []any{"tasks:read"},
+					"expires_at": nil,
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.personalTokenUsecase.On("Create", mock.Anything, &dto.PersonalTokenCreateIn{UserID: "user-xxxxx", Name: "CI", Scopes: []string{"tasks:read"}}).
+					Return(dto.PersonalTokenCreateOut{ID: "token-xxxxx", Name: "CI", Token: "tkt_xxxxx", Scopes: []entity.Scope{entity.ScopeTasksRead}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				req:                  test.InjectAuthContext(req, entity.UserID("user-xxxxx")),
+				validator:            &mocks.ValidatorProvider{},
+				personalTokenUsecase: &mocks.PersonalTokenUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.personalTokenUsecase)
+			handler.Post(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *PersonalTokenHTTPHandlerTestSuite) TestGet() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when personal token usecase GetAll return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.personalTokenUsecase.On("GetAll", mock.Anything, &dto.PersonalTokenGetAllIn{UserID: "user-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{
+						"id":           "token-xxxxx",
+						"name":         "CI",
+						"scopes":       []any{"tasks:read"},
+						"expires_at":   nil,
+						"last_used_at": test.TimeBeforeNow.Format(time.RFC3339Nano),
+						"created_at":   test.TimeBeforeNow.Format(time.RFC3339Nano),
+					},
+				},
+			},
+			setup: func(d *dependency) {
+				d.personalTokenUsecase.On("GetAll", mock.Anything, &dto.PersonalTokenGetAllIn{UserID: "user-xxxxx"}).
+					Return([]dto.PersonalTokenGetAllOut{
+						{ID: "token-xxxxx", Name: "CI", Scopes: []entity.Scope{entity.ScopeTasksRead}, LastUsedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+
+			d := &dependency{
+				req:                  test.InjectAuthContext(req, entity.UserID("user-xxxxx")),
+				personalTokenUsecase: &mocks.PersonalTokenUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.personalTokenUsecase)
+			handler.Get(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *PersonalTokenHTTPHandlerTestSuite) TestDelete() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when personal token usecase Revoke return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this personal access token",
+			},
+			setup: func(d *dependency) {
+				d.personalTokenUsecase.On("Revoke", mock.Anything, &dto.PersonalTokenRevokeIn{UserID: "user-xxxxx", TokenID: "token-xxxxx"}).
+					Return(domain.ErrPersonalTokenAuthorization)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully revoked personal access token",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.personalTokenUsecase.On("Revoke", mock.Anything, &dto.PersonalTokenRevokeIn{UserID: "user-xxxxx", TokenID: "token-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+			req = test.InjectChiRouterParams(req, map[string]string{"token_id": "token-xxxxx"})
+
+			d := &dependency{
+				req:                  req,
+				personalTokenUsecase: &mocks.PersonalTokenUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.personalTokenUsecase)
+			handler.Delete(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
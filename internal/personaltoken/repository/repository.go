@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new personal access token repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new personal access token to database.
+func (r *Repository) Store(ctx context.Context, t *entity.PersonalToken) (entity.PersonalTokenID, error) {
+	id := r.idProvider.Generate()
+	q := `INSERT INTO personal_tokens (id, user_id, name, token_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.ExecContext(ctx, q, id, t.UserID, t.Name, t.TokenHash, pq.Array(scopesToStrings(t.Scopes)), t.ExpiresAt)
+	if err != nil {
+		return "", err
+	}
+	return entity.PersonalTokenID(id), nil
+}
+
+// FindByID get personal access token by id.
+func (r *Repository) FindByID(ctx context.Context, tokenID entity.PersonalTokenID) (entity.PersonalToken, error) {
+	q := `SELECT id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at FROM personal_tokens WHERE id = $1`
+	return r.findOne(ctx, q, tokenID)
+}
+
+// FindByTokenHash get personal access token by the hash of the raw token.
+func (r *Repository) FindByTokenHash(ctx context.Context, tokenHash string) (entity.PersonalToken, error) {
+	q := `SELECT id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at FROM personal_tokens WHERE token_hash = $1`
+	return r.findOne(ctx, q, tokenHash)
+}
+
+func (r *Repository) findOne(ctx context.Context, q string, arg any) (entity.PersonalToken, error) {
+	var t entity.PersonalToken
+	var scopes pq.StringArray
+	err := r.db.QueryRowContext(ctx, q, arg).Scan(&t.ID, &t.UserID, &t.Name, &t.TokenHash, &scopes, &t.ExpiresAt, &t.LastUsedAt, &t.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PersonalToken{}, domain.ErrPersonalTokenNotFound
+	} else if err != nil {
+		return entity.PersonalToken{}, err
+	}
+	t.Scopes = stringsToScopes(scopes)
+	return t, nil
+}
+
+// FindAllByUserID get all personal access tokens of a user, newest first.
+func (r *Repository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.PersonalToken, error) {
+	q := `SELECT id, user_id, name, scopes, expires_at, last_used_at, created_at FROM personal_tokens WHERE user_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]entity.PersonalToken, 0)
+	for rows.Next() {
+		var t entity.PersonalToken
+		var scopes pq.StringArray
+		err := rows.Scan(&t.ID, &t.UserID, &t.Name, &scopes, &t.ExpiresAt, &t.LastUsedAt, &t.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		t.Scopes = stringsToScopes(scopes)
+		tokens = append(tokens, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// Touch record that a personal access token was used.
+// The time is kept with a minute precision, so busy tokens do not cause a write on every request.
+func (r *Repository) Touch(ctx context.Context, tokenID entity.PersonalTokenID) error {
+	q := `UPDATE personal_tokens SET last_used_at = NOW() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`
+	_, err := r.db.ExecContext(ctx, q, tokenID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteByID delete personal access token by id.
+func (r *Repository) DeleteByID(ctx context.Context, tokenID entity.PersonalTokenID) error {
+	q := `DELETE FROM personal_tokens WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, tokenID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func scopesToStrings(scopes []entity.Scope) []string {
+	raw := make([]string, len(scopes))
+	for i, scope := range scopes {
+		raw[i] = string(scope)
+	}
+	return raw
+}
+
+func stringsToScopes(raw []string) []entity.Scope {
+	scopes := make([]entity.Scope, len(raw))
+	for i, r := range raw {
+		scopes[i] = entity.Scope(r)
+	}
+	return scopes
+}
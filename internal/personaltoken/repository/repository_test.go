@@ -0,0 +1,382 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type PersonalTokenRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestPersonalTokenRepositorySuite(t *testing.T) {
+	suite.Run(t, new(PersonalTokenRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	storeQuery           = regexp.QuoteMeta(`INSERT INTO personal_tokens (id, user_id, name, token_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5, $6)`)
+	findByIDQuery        = regexp.QuoteMeta(`SELECT id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at FROM personal_tokens WHERE id = $1`)
+	findByTokenHashQuery = regexp.QuoteMeta(`SELECT id, user_id, name, token_hash, scopes, expires_at, last_used_at, created_at FROM personal_tokens WHERE token_hash = $1`)
+	findAllByUserIDQuery = regexp.QuoteMeta(`SELECT id, user_id, name, scopes, expires_at, last_used_at, created_at FROM personal_tokens WHERE user_id = $1 ORDER BY created_at DESC`)
+	touchQuery           = regexp.QuoteMeta(`UPDATE personal_tokens SET last_used_at = NOW() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`)
+	deleteByIDQuery      = regexp.QuoteMeta(`DELETE FROM personal_tokens WHERE id = $1`)
+)
+
+var token = entity.PersonalToken{
+	ID:        "token-xxxxx",
+	UserID:    "user-xxxxx",
+	Name:      "CI",
+	TokenHash: "hashed_token",
+	Scopes:    []entity.Scope{entity.ScopeTasksRead, entity.ScopeTasksWrite},
+	ExpiresAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
+	CreatedAt: test.TimeBeforeNow,
+}
+
+func (s *PersonalTokenRepositoryTestSuite) TestStore() {
+	type expected struct {
+		tokenID entity.PersonalTokenID
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: expected{tokenID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("token-xxxxx")
+
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("token-xxxxx", "user-xxxxx", "CI", "hashed_token", pq.Array([]string{"tasks:read", "tasks:write"}), test.TimeAfterNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and token id when successfully store",
+			expected: expected{tokenID: "token-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("token-xxxxx")
+
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("token-xxxxx", "user-xxxxx", "CI", "hashed_token", pq.Array([]string{"tasks:read", "tasks:write"}), test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			tokenID, err := repository.Store(context.Background(), &token)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.tokenID, tokenID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *PersonalTokenRepositoryTestSuite) TestFindByID() {
+	type expected struct {
+		token entity.PersonalToken
+		err   error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{token: entity.PersonalToken{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByIDQuery).
+					WithArgs("token-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrPersonalTokenNotFound when row not found",
+			expected: expected{token: entity.PersonalToken{}, err: domain.ErrPersonalTokenNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByIDQuery).
+					WithArgs("token-xxxxx").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and token when found",
+			expected: expected{token: token, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "name", "token_hash", "scopes", "expires_at", "last_used_at", "created_at"}).
+					AddRow("token-xxxxx", "user-xxxxx", "CI", "hashed_token", "{tasks:read,tasks:write}", test.TimeAfterNow, nil, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findByIDQuery).
+					WithArgs("token-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			result, err := repository.FindByID(context.Background(), "token-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.token, result)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *PersonalTokenRepositoryTestSuite) TestFindByTokenHash() {
+	type expected struct {
+		token entity.PersonalToken
+		err   error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrPersonalTokenNotFound when row not found",
+			expected: expected{token: entity.PersonalToken{}, err: domain.ErrPersonalTokenNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByTokenHashQuery).
+					WithArgs("hashed_token").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and token when found",
+			expected: expected{token: token, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "name", "token_hash", "scopes", "expires_at", "last_used_at", "created_at"}).
+					AddRow("token-xxxxx", "user-xxxxx", "CI", "hashed_token", "{tasks:read,tasks:write}", test.TimeAfterNow, nil, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findByTokenHashQuery).
+					WithArgs("hashed_token").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			result, err := repository.FindByTokenHash(context.Background(), "hashed_token")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.token, result)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *PersonalTokenRepositoryTestSuite) TestFindAllByUserID() {
+	type expected struct {
+		tokens []entity.PersonalToken
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{tokens: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findAllByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when row scan fail",
+			expected: expected{tokens: nil, err: test.ErrRowScan},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "name", "scopes", "expires_at", "last_used_at", "created_at"}).
+					AddRow("token-xxxxx", "user-xxxxx", "CI", "{tasks:read}", nil, nil, test.TimeBeforeNow).
+					RowError(0, test.ErrRowScan)
+
+				d.mockDB.ExpectQuery(findAllByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "it should return error nil and tokens when found",
+			expected: expected{
+				tokens: []entity.PersonalToken{
+					{ID: "token-xxxxx", UserID: "user-xxxxx", Name: "CI", Scopes: []entity.Scope{entity.ScopeTasksRead}, LastUsedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, CreatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "name", "scopes", "expires_at", "last_used_at", "created_at"}).
+					AddRow("token-xxxxx", "user-xxxxx", "CI", "{tasks:read}", nil, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findAllByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			tokens, err := repository.FindAllByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.tokens, tokens)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *PersonalTokenRepositoryTestSuite) TestTouch() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(touchQuery).
+					WithArgs("token-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(touchQuery).
+					WithArgs("token-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.Touch(context.Background(), "token-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *PersonalTokenRepositoryTestSuite) TestDeleteByID() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByIDQuery).
+					WithArgs("token-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByIDQuery).
+					WithArgs("token-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.DeleteByID(context.Background(), "token-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
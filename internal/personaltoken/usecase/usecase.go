@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Usecase struct {
+	personalTokenRepository domain.PersonalTokenRepository
+	tokenProvider           domain.TokenProvider
+}
+
+// New create a new personal access token usecase.
+func New(personalTokenRepository domain.PersonalTokenRepository, tokenProvider domain.TokenProvider) Usecase {
+	return Usecase{personalTokenRepository: personalTokenRepository, tokenProvider: tokenProvider}
+}
+
+// Create create a new personal access token, the raw token is only returned here.
+func (u *Usecase) Create(ctx context.Context, payload *dto.PersonalTokenCreateIn) (dto.PersonalTokenCreateOut, error) {
+	scopes, err := entity.ParseScopes(payload.Scopes)
+	if err != nil {
+		return dto.PersonalTokenCreateOut{}, err
+	}
+	if payload.ExpiresAt.Valid && payload.ExpiresAt.Time.Before(time.Now()) {
+		return dto.PersonalTokenCreateOut{}, domain.ErrPersonalTokenExpiryInPast
+	}
+
+	rawToken, err := u.tokenProvider.Generate()
+	if err != nil {
+		return dto.PersonalTokenCreateOut{}, err
+	}
+	rawToken = entity.PersonalTokenPrefix + rawToken
+
+	token := &entity.PersonalToken{
+		UserID:    payload.UserID,
+		Name:      payload.Name,
+		TokenHash: u.tokenProvider.Hash(rawToken),
+		Scopes:    scopes,
+		ExpiresAt: payload.ExpiresAt,
+	}
+	tokenID, err := u.personalTokenRepository.Store(ctx, token)
+	if err != nil {
+		return dto.PersonalTokenCreateOut{}, err
+	}
+
+	return dto.PersonalTokenCreateOut{
+		ID:        tokenID,
+		Name:      token.Name,
+		Token:     rawToken,
+		Scopes:    token.Scopes,
+		ExpiresAt: token.ExpiresAt,
+	}, nil
+}
+
+// GetAll get all personal access tokens of a user.
+func (u *Usecase) GetAll(ctx context.Context, payload *dto.PersonalTokenGetAllIn) ([]dto.PersonalTokenGetAllOut, error) {
+	tokens, err := u.personalTokenRepository.FindAllByUserID(ctx, payload.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.PersonalTokenGetAllOut, len(tokens))
+	for i, token := range tokens {
+		output[i] = dto.PersonalTokenGetAllOut{
+			ID:         token.ID,
+			Name:       token.Name,
+			Scopes:     token.Scopes,
+			ExpiresAt:  token.ExpiresAt,
+			LastUsedAt: token.LastUsedAt,
+			CreatedAt:  token.CreatedAt,
+		}
+	}
+	return output, nil
+}
+
+// Revoke delete a personal access token of a user.
+func (u *Usecase) Revoke(ctx context.Context, payload *dto.PersonalTokenRevokeIn) error {
+	token, err := u.personalTokenRepository.FindByID(ctx, payload.TokenID)
+	if err != nil {
+		return err
+	}
+	if token.UserID != payload.UserID {
+		return domain.ErrPersonalTokenAuthorization
+	}
+	return u.personalTokenRepository.DeleteByID(ctx, token.ID)
+}
+
+// Authenticate resolve the user and the granted scopes of a raw personal access token.
+func (u *Usecase) Authenticate(ctx context.Context, payload *dto.PersonalTokenAuthenticateIn) (dto.PersonalTokenAuthenticateOut, error) {
+	token, err := u.personalTokenRepository.FindByTokenHash(ctx, u.tokenProvider.Hash(payload.Token))
+	if errors.Is(err, domain.ErrPersonalTokenNotFound) {
+		return dto.PersonalTokenAuthenticateOut{}, domain.ErrPersonalTokenInvalid
+	} else if err != nil {
+		return dto.PersonalTokenAuthenticateOut{}, err
+	}
+	if err := token.VerifyExpires(); err != nil {
+		return dto.PersonalTokenAuthenticateOut{}, err
+	}
+
+	if err := u.personalTokenRepository.Touch(ctx, token.ID); err != nil {
+		return dto.PersonalTokenAuthenticateOut{}, err
+	}
+
+	return dto.PersonalTokenAuthenticateOut{UserID: token.UserID, Scopes: token.Scopes}, nil
+}
@@ -0,0 +1,297 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type PersonalTokenUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestPersonalTokenUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(PersonalTokenUsecaseTestSuite))
+}
+
+type dependency struct {
+	personalTokenRepository *mocks.PersonalTokenRepository
+	tokenProvider           *mocks.TokenProvider
+}
+
+var (
+	expiresAt = entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}
+	expiredAt = entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}
+)
+
+func (s *PersonalTokenUsecaseTestSuite) TestCreate() {
+	type args struct {
+		payload *dto.PersonalTokenCreateIn
+	}
+	type expected struct {
+		output dto.PersonalTokenCreateOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrScopeInvalid when scope is unknown",
+			args:     args{payload: &dto.PersonalTokenCreateIn{UserID: "user-xxxxx", Name: "CI", Scopes: []string{"users:write"}}},
+			expected: expected{output: dto.PersonalTokenCreateOut{}, err: entity.ErrScopeInvalid},
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error ErrPersonalTokenExpiryInPast when expiry is in the past",
+			args:     args{payload: &dto.PersonalTokenCreateIn{UserID: "user-xxxxx", Name: "CI", Scopes: []string{"tasks:read"}, ExpiresAt: expiredAt}},
+			expected: expected{output: dto.PersonalTokenCreateOut{}, err: domain.ErrPersonalTokenExpiryInPast},
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error when token provider Generate return unexpected error",
+			args:     args{payload: &dto.PersonalTokenCreateIn{UserID: "user-xxxxx", Name: "CI", Scopes: []string{"tasks:read"}}},
+			expected: expected{output: dto.PersonalTokenCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when personal token repository Store return unexpected error",
+			args:     args{payload: &dto.PersonalTokenCreateIn{UserID: "user-xxxxx", Name: "CI", Scopes: []string{"tasks:read"}}},
+			expected: expected{output: dto.PersonalTokenCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Generate").Return("xxxxx", nil)
+				d.tokenProvider.On("Hash", "tkt_xxxxx").Return("hashed_token")
+				d.personalTokenRepository.On("Store", context.Background(), &entity.PersonalToken{UserID: "user-xxxxx", Name: "CI", TokenHash: "hashed_token", Scopes: []entity.Scope{entity.ScopeTasksRead}}).
+					Return(entity.PersonalTokenID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and the raw token when success",
+			args: args{payload: &dto.PersonalTokenCreateIn{UserID: "user-xxxxx", Name: "CI", Scopes: []string{"tasks:read", "tasks:write"}, ExpiresAt: expiresAt}},
+			expected: expected{
+				output: dto.PersonalTokenCreateOut{ID: "token-xxxxx", Name: "CI", Token: "tkt_xxxxx", Scopes: []entity.Scope{entity.ScopeTasksRead, entity.ScopeTasksWrite}, ExpiresAt: expiresAt},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Generate").Return("xxxxx", nil)
+				d.tokenProvider.On("Hash", "tkt_xxxxx").Return("hashed_token")
+				d.personalTokenRepository.On("Store", context.Background(), &entity.PersonalToken{UserID: "user-xxxxx", Name: "CI", TokenHash: "hashed_token", Scopes: []entity.Scope{entity.ScopeTasksRead, entity.ScopeTasksWrite}, ExpiresAt: expiresAt}).
+					Return(entity.PersonalTokenID("token-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				personalTokenRepository: &mocks.PersonalTokenRepository{},
+				tokenProvider:           &mocks.TokenProvider{},
+			}
+			t.setup(d)
+
+			usecase := New(d.personalTokenRepository, d.tokenProvider)
+			output, err := usecase.Create(context.Background(), t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *PersonalTokenUsecaseTestSuite) TestGetAll() {
+	type expected struct {
+		output []dto.PersonalTokenGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when personal token repository FindAllByUserID return unexpected error",
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.personalTokenRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and tokens without their hash when success",
+			expected: expected{
+				output: []dto.PersonalTokenGetAllOut{
+					{ID: "token-xxxxx", Name: "CI", Scopes: []entity.Scope{entity.ScopeTasksRead}, ExpiresAt: expiresAt, CreatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.personalTokenRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return([]entity.PersonalToken{{ID: "token-xxxxx", UserID: "user-xxxxx", Name: "CI", TokenHash: "hashed_token", Scopes: []entity.Scope{entity.ScopeTasksRead}, ExpiresAt: expiresAt, CreatedAt: test.TimeBeforeNow}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				personalTokenRepository: &mocks.PersonalTokenRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.personalTokenRepository, nil)
+			output, err := usecase.GetAll(context.Background(), &dto.PersonalTokenGetAllIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *PersonalTokenUsecaseTestSuite) TestRevoke() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when personal token repository FindByID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.personalTokenRepository.On("FindByID", context.Background(), entity.PersonalTokenID("token-xxxxx")).
+					Return(entity.PersonalToken{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrPersonalTokenAuthorization when token is owned by another user",
+			expected: domain.ErrPersonalTokenAuthorization,
+			setup: func(d *dependency) {
+				d.personalTokenRepository.On("FindByID", context.Background(), entity.PersonalTokenID("token-xxxxx")).
+					Return(entity.PersonalToken{ID: "token-xxxxx", UserID: "user-yyyyy"}, nil)
+			},
+		},
+		{
+			name:     "it should return error when personal token repository DeleteByID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.personalTokenRepository.On("FindByID", context.Background(), entity.PersonalTokenID("token-xxxxx")).
+					Return(entity.PersonalToken{ID: "token-xxxxx", UserID: "user-xxxxx"}, nil)
+				d.personalTokenRepository.On("DeleteByID", context.Background(), entity.PersonalTokenID("token-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.personalTokenRepository.On("FindByID", context.Background(), entity.PersonalTokenID("token-xxxxx")).
+					Return(entity.PersonalToken{ID: "token-xxxxx", UserID: "user-xxxxx"}, nil)
+				d.personalTokenRepository.On("DeleteByID", context.Background(), entity.PersonalTokenID("token-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				personalTokenRepository: &mocks.PersonalTokenRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.personalTokenRepository, nil)
+			err := usecase.Revoke(context.Background(), &dto.PersonalTokenRevokeIn{UserID: "user-xxxxx", TokenID: "token-xxxxx"})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *PersonalTokenUsecaseTestSuite) TestAuthenticate() {
+	type expected struct {
+		output dto.PersonalTokenAuthenticateOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrPersonalTokenInvalid when token is not found",
+			expected: expected{output: dto.PersonalTokenAuthenticateOut{}, err: domain.ErrPersonalTokenInvalid},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tkt_xxxxx").Return("hashed_token")
+				d.personalTokenRepository.On("FindByTokenHash", context.Background(), "hashed_token").
+					Return(entity.PersonalToken{}, domain.ErrPersonalTokenNotFound)
+			},
+		},
+		{
+			name:     "it should return error when personal token repository FindByTokenHash return unexpected error",
+			expected: expected{output: dto.PersonalTokenAuthenticateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tkt_xxxxx").Return("hashed_token")
+				d.personalTokenRepository.On("FindByTokenHash", context.Background(), "hashed_token").
+					Return(entity.PersonalToken{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrPersonalTokenExpired when token is expired",
+			expected: expected{output: dto.PersonalTokenAuthenticateOut{}, err: entity.ErrPersonalTokenExpired},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tkt_xxxxx").Return("hashed_token")
+				d.personalTokenRepository.On("FindByTokenHash", context.Background(), "hashed_token").
+					Return(entity.PersonalToken{ID: "token-xxxxx", UserID: "user-xxxxx", ExpiresAt: expiredAt}, nil)
+			},
+		},
+		{
+			name:     "it should return error when personal token repository Touch return unexpected error",
+			expected: expected{output: dto.PersonalTokenAuthenticateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tkt_xxxxx").Return("hashed_token")
+				d.personalTokenRepository.On("FindByTokenHash", context.Background(), "hashed_token").
+					Return(entity.PersonalToken{ID: "token-xxxxx", UserID: "user-xxxxx", ExpiresAt: expiresAt}, nil)
+				d.personalTokenRepository.On("Touch", context.Background(), entity.PersonalTokenID("token-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and the user with granted scopes when success",
+			expected: expected{output: dto.PersonalTokenAuthenticateOut{UserID: "user-xxxxx", Scopes: []entity.Scope{entity.ScopeTasksRead}}, err: nil},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tkt_xxxxx").Return("hashed_token")
+				d.personalTokenRepository.On("FindByTokenHash", context.Background(), "hashed_token").
+					Return(entity.PersonalToken{ID: "token-xxxxx", UserID: "user-xxxxx", Scopes: []entity.Scope{entity.ScopeTasksRead}}, nil)
+				d.personalTokenRepository.On("Touch", context.Background(), entity.PersonalTokenID("token-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				personalTokenRepository: &mocks.PersonalTokenRepository{},
+				tokenProvider:           &mocks.TokenProvider{},
+			}
+			t.setup(d)
+
+			usecase := New(d.personalTokenRepository, d.tokenProvider)
+			output, err := usecase.Authenticate(context.Background(), &dto.PersonalTokenAuthenticateIn{Token: "tkt_xxxxx"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/lru"
+)
+
+const (
+	// defaultCacheSize is the number of access tokens and users cached when no size is configured.
+	defaultCacheSize = 10000
+	// defaultCacheTTL is how long a lookup is cached when no ttl is configured.
+	defaultCacheTTL = 15 * time.Second
+)
+
+type Cache struct {
+	repository domain.RevocationRepository
+	ttl        time.Duration
+	tokens     *lru.Cache[string, bool]
+	watermarks *lru.Cache[entity.UserID, time.Time]
+}
+
+// NewCache wrap a revocation repository with an in-memory LRU cache, so most requests skip the database.
+// Tokens revoked through the cache are cached until they expire, every other lookup is cached for ttl,
+// which bounds how long a revocation made by another replica of the api goes unnoticed.
+func NewCache(repository domain.RevocationRepository, size int, ttl time.Duration) Cache {
+	if size <= 0 {
+		size = defaultCacheSize
+	}
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	return Cache{
+		repository: repository,
+		ttl:        ttl,
+		tokens:     lru.New[string, bool](size),
+		watermarks: lru.New[entity.UserID, time.Time](size),
+	}
+}
+
+// Store save a revoked access token and cache it until it expires.
+func (c *Cache) Store(ctx context.Context, t *entity.RevokedToken) error {
+	if err := c.repository.Store(ctx, t); err != nil {
+		return err
+	}
+	c.tokens.Add(t.TokenID, true, t.ExpiresAt)
+	return nil
+}
+
+// IsRevoked check whether an access token is revoked, from the cache when possible.
+func (c *Cache) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	if revoked, ok := c.tokens.Get(tokenID); ok {
+		return revoked, nil
+	}
+
+	revoked, err := c.repository.IsRevoked(ctx, tokenID)
+	if err != nil {
+		return false, err
+	}
+	c.tokens.Add(tokenID, revoked, time.Now().Add(c.ttl))
+	return revoked, nil
+}
+
+// StoreWatermark save the watermark of a user and cache it, so this replica honors it right away.
+func (c *Cache) StoreWatermark(ctx context.Context, userID entity.UserID, revokedBefore time.Time) error {
+	if err := c.repository.StoreWatermark(ctx, userID, revokedBefore); err != nil {
+		return err
+	}
+	c.watermarks.Add(userID, revokedBefore, time.Now().Add(c.ttl))
+	return nil
+}
+
+// FindWatermark get the watermark of a user, from the cache when possible.
+func (c *Cache) FindWatermark(ctx context.Context, userID entity.UserID) (time.Time, error) {
+	if revokedBefore, ok := c.watermarks.Get(userID); ok {
+		return revokedBefore, nil
+	}
+
+	revokedBefore, err := c.repository.FindWatermark(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	c.watermarks.Add(userID, revokedBefore, time.Now().Add(c.ttl))
+	return revokedBefore, nil
+}
+
+// DeleteExpired delete expired revocations from the database, cached entries expire on their own.
+func (c *Cache) DeleteExpired(ctx context.Context, watermarkBefore time.Time) (int64, error) {
+	return c.repository.DeleteExpired(ctx, watermarkBefore)
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type RevocationCacheTestSuite struct {
+	suite.Suite
+}
+
+func TestRevocationCacheSuite(t *testing.T) {
+	suite.Run(t, new(RevocationCacheTestSuite))
+}
+
+func (s *RevocationCacheTestSuite) TestStore() {
+	token := &entity.RevokedToken{TokenID: "token-xxxxx", UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}
+
+	s.Run("it should return error and cache nothing when repository Store return unexpected error", func() {
+		repository := &mocks.RevocationRepository{}
+		repository.On("Store", context.Background(), token).Return(test.ErrUnexpected)
+		repository.On("IsRevoked", context.Background(), "token-xxxxx").Return(false, nil)
+
+		cache := NewCache(repository, 10, time.Minute)
+		err := cache.Store(context.Background(), token)
+		s.Equal(test.ErrUnexpected, err)
+
+		revoked, err := cache.IsRevoked(context.Background(), "token-xxxxx")
+		s.NoError(err)
+		s.False(revoked)
+	})
+
+	s.Run("it should cache the access token as revoked when successfully store", func() {
+		repository := &mocks.RevocationRepository{}
+		repository.On("Store", context.Background(), token).Return(nil)
+
+		cache := NewCache(repository, 10, time.Minute)
+		err := cache.Store(context.Background(), token)
+		s.NoError(err)
+
+		revoked, err := cache.IsRevoked(context.Background(), "token-xxxxx")
+		s.NoError(err)
+		s.True(revoked)
+		repository.AssertNotCalled(s.T(), "IsRevoked", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *RevocationCacheTestSuite) TestIsRevoked() {
+	s.Run("it should return error when repository IsRevoked return unexpected error", func() {
+		repository := &mocks.RevocationRepository{}
+		repository.On("IsRevoked", context.Background(), "token-xxxxx").Return(false, test.ErrUnexpected)
+
+		cache := NewCache(repository, 10, time.Minute)
+		_, err := cache.IsRevoked(context.Background(), "token-xxxxx")
+
+		s.Equal(test.ErrUnexpected, err)
+	})
+
+	s.Run("it should query the repository once while the lookup is cached", func() {
+		repository := &mocks.RevocationRepository{}
+		repository.On("IsRevoked", context.Background(), "token-xxxxx").Return(false, nil)
+
+		cache := NewCache(repository, 10, time.Minute)
+		for i := 0; i < 3; i++ {
+			revoked, err := cache.IsRevoked(context.Background(), "token-xxxxx")
+			s.NoError(err)
+			s.False(revoked)
+		}
+
+		repository.AssertNumberOfCalls(s.T(), "IsRevoked", 1)
+	})
+}
+
+func (s *RevocationCacheTestSuite) TestStoreWatermark() {
+	s.Run("it should return error when repository StoreWatermark return unexpected error", func() {
+		repository := &mocks.RevocationRepository{}
+		repository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), test.TimeBeforeNow).
+			Return(test.ErrUnexpected)
+
+		cache := NewCache(repository, 10, time.Minute)
+		err := cache.StoreWatermark(context.Background(), "user-xxxxx", test.TimeBeforeNow)
+
+		s.Equal(test.ErrUnexpected, err)
+	})
+
+	s.Run("it should cache the watermark when successfully store", func() {
+		repository := &mocks.RevocationRepository{}
+		repository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), test.TimeBeforeNow).
+			Return(nil)
+
+		cache := NewCache(repository, 10, time.Minute)
+		err := cache.StoreWatermark(context.Background(), "user-xxxxx", test.TimeBeforeNow)
+		s.NoError(err)
+
+		revokedBefore, err := cache.FindWatermark(context.Background(), "user-xxxxx")
+		s.NoError(err)
+		s.Equal(test.TimeBeforeNow, revokedBefore)
+		repository.AssertNotCalled(s.T(), "FindWatermark", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *RevocationCacheTestSuite) TestFindWatermark() {
+	s.Run("it should return error when repository FindWatermark return unexpected error", func() {
+		repository := &mocks.RevocationRepository{}
+		repository.On("FindWatermark", context.Background(), entity.UserID("user-xxxxx")).
+			Return(time.Time{}, test.ErrUnexpected)
+
+		cache := NewCache(repository, 10, time.Minute)
+		_, err := cache.FindWatermark(context.Background(), "user-xxxxx")
+
+		s.Equal(test.ErrUnexpected, err)
+	})
+
+	s.Run("it should query the repository again once the lookup expires", func() {
+		repository := &mocks.RevocationRepository{}
+		repository.On("FindWatermark", context.Background(), entity.UserID("user-xxxxx")).
+			Return(time.Time{}, nil)
+
+		cache := NewCache(repository, 10, time.Nanosecond)
+		for i := 0; i < 2; i++ {
+			revokedBefore, err := cache.FindWatermark(context.Background(), "user-xxxxx")
+			s.NoError(err)
+			s.True(revokedBefore.IsZero())
+			time.Sleep(time.Millisecond)
+		}
+
+		repository.AssertNumberOfCalls(s.T(), "FindWatermark", 2)
+	})
+}
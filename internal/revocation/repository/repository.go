@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+// New create a new access token revocation repository.
+func New(db *sql.DB) Repository {
+	return Repository{db: db}
+}
+
+// Store save a revoked access token to database, revoking it twice is a no-op.
+func (r *Repository) Store(ctx context.Context, t *entity.RevokedToken) error {
+	q := `INSERT INTO revoked_access_tokens (token_id, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (token_id) DO NOTHING`
+	_, err := r.db.ExecContext(ctx, q, t.TokenID, t.UserID, t.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// IsRevoked check whether an access token is revoked.
+func (r *Repository) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var revoked bool
+	q := `SELECT EXISTS (SELECT 1 FROM revoked_access_tokens WHERE token_id = $1)`
+	err := r.db.QueryRowContext(ctx, q, tokenID).Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+	return revoked, nil
+}
+
+// StoreWatermark revoke every access token of a user issued before a time.
+// The watermark only moves forward, so an older revocation never undoes a newer one.
+func (r *Repository) StoreWatermark(ctx context.Context, userID entity.UserID, revokedBefore time.Time) error {
+	q := `INSERT INTO access_token_watermarks (user_id, revoked_before) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE SET revoked_before = GREATEST(access_token_watermarks.revoked_before, EXCLUDED.revoked_before)`
+	_, err := r.db.ExecContext(ctx, q, userID, revokedBefore)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindWatermark get the time access tokens of a user issued before are revoked, it is zero when there is none.
+func (r *Repository) FindWatermark(ctx context.Context, userID entity.UserID) (time.Time, error) {
+	var revokedBefore time.Time
+	q := `SELECT revoked_before FROM access_token_watermarks WHERE user_id = $1`
+	err := r.db.QueryRowContext(ctx, q, userID).Scan(&revokedBefore)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, err
+	}
+	return revokedBefore, nil
+}
+
+// DeleteExpired delete the revoked access tokens that are expired anyway
+// and the watermarks older than any access token still valid.
+func (r *Repository) DeleteExpired(ctx context.Context, watermarkBefore time.Time) (int64, error) {
+	q := `DELETE FROM revoked_access_tokens WHERE expires_at < NOW()`
+	result, err := r.db.ExecContext(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+	tokens, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	q = `DELETE FROM access_token_watermarks WHERE revoked_before < $1`
+	result, err = r.db.ExecContext(ctx, q, watermarkBefore)
+	if err != nil {
+		return 0, err
+	}
+	watermarks, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return tokens + watermarks, nil
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type RevocationRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestRevocationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(RevocationRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB sqlmock.Sqlmock
+}
+
+func (s *RevocationRepositoryTestSuite) TestStore() {
+	query := regexp.QuoteMeta(`INSERT INTO revoked_access_tokens (token_id, user_id, expires_at) VALUES ($1, $2, $3) ON CONFLICT (token_id) DO NOTHING`)
+	token := &entity.RevokedToken{TokenID: "token-xxxxx", UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("token-xxxxx", "user-xxxxx", test.TimeAfterNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("token-xxxxx", "user-xxxxx", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db)
+			err = repository.Store(context.Background(), token)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *RevocationRepositoryTestSuite) TestIsRevoked() {
+	query := regexp.QuoteMeta(`SELECT EXISTS (SELECT 1 FROM revoked_access_tokens WHERE token_id = $1)`)
+
+	type expected struct {
+		revoked bool
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			expected: expected{revoked: false, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("token-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and true when access token is revoked",
+			expected: expected{revoked: true, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"exists"}).AddRow(true)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("token-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db)
+			revoked, err := repository.IsRevoked(context.Background(), "token-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.revoked, revoked)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *RevocationRepositoryTestSuite) TestStoreWatermark() {
+	query := regexp.QuoteMeta(`INSERT INTO access_token_watermarks (user_id, revoked_before) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE SET revoked_before = GREATEST(access_token_watermarks.revoked_before, EXCLUDED.revoked_before)`)
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", test.TimeBeforeNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", test.TimeBeforeNow).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db)
+			err = repository.StoreWatermark(context.Background(), "user-xxxxx", test.TimeBeforeNow)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *RevocationRepositoryTestSuite) TestFindWatermark() {
+	query := regexp.QuoteMeta(`SELECT revoked_before FROM access_token_watermarks WHERE user_id = $1`)
+
+	type expected struct {
+		revokedBefore time.Time
+		err           error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			expected: expected{revokedBefore: time.Time{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and zero time when user has no watermark",
+			expected: expected{revokedBefore: time.Time{}, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"revoked_before"})
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:     "it should return error nil and the watermark when user has one",
+			expected: expected{revokedBefore: test.TimeBeforeNow, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"revoked_before"}).AddRow(test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db)
+			revokedBefore, err := repository.FindWatermark(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.revokedBefore, revokedBefore)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *RevocationRepositoryTestSuite) TestDeleteExpired() {
+	tokensQuery := regexp.QuoteMeta(`DELETE FROM revoked_access_tokens WHERE expires_at < NOW()`)
+	watermarksQuery := regexp.QuoteMeta(`DELETE FROM access_token_watermarks WHERE revoked_before < $1`)
+
+	type expected struct {
+		deleted int64
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete revoked access tokens",
+			expected: expected{deleted: 0, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(tokensQuery).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to delete watermarks",
+			expected: expected{deleted: 0, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(tokensQuery).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				d.mockDB.ExpectExec(watermarksQuery).
+					WithArgs(test.TimeBeforeNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and the number of deleted rows when successfully delete",
+			expected: expected{deleted: 3, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(tokensQuery).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				d.mockDB.ExpectExec(watermarksQuery).
+					WithArgs(test.TimeBeforeNow).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db)
+			deleted, err := repository.DeleteExpired(context.Background(), test.TimeBeforeNow)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.deleted, deleted)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Usecase struct {
+	revocationRepository  domain.RevocationRepository
+	accessTokenExpiration time.Duration
+}
+
+// New create a new access token revocation usecase.
+// accessTokenExpiration is the lifetime of access tokens, a watermark older than it revokes nothing.
+func New(revocationRepository domain.RevocationRepository, accessTokenExpiration time.Duration) Usecase {
+	return Usecase{revocationRepository: revocationRepository, accessTokenExpiration: accessTokenExpiration}
+}
+
+// EnsureActive ensure an access token is neither revoked by its id
+// nor issued before the revocation watermark of its user.
+func (u *Usecase) EnsureActive(ctx context.Context, payload *dto.RevocationEnsureActiveIn) error {
+	revoked, err := u.revocationRepository.IsRevoked(ctx, payload.TokenID)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return entity.ErrAccessTokenRevoked
+	}
+
+	watermark, err := u.revocationRepository.FindWatermark(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	claims := entity.AuthClaims{TokenID: payload.TokenID, UserID: payload.UserID, IssuedAt: payload.IssuedAt}
+	return claims.VerifyIssuedAfter(watermark)
+}
+
+// Prune delete the revocations that no longer revoke an unexpired access token.
+func (u *Usecase) Prune(ctx context.Context) (dto.RevocationPruneOut, error) {
+	deleted, err := u.revocationRepository.DeleteExpired(ctx, time.Now().Add(-u.accessTokenExpiration))
+	if err != nil {
+		return dto.RevocationPruneOut{}, err
+	}
+	return dto.RevocationPruneOut{Deleted: deleted}, nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type RevocationUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestRevocationUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(RevocationUsecaseTestSuite))
+}
+
+type dependency struct {
+	revocationRepository *mocks.RevocationRepository
+}
+
+func (s *RevocationUsecaseTestSuite) TestEnsureActive() {
+	payload := &dto.RevocationEnsureActiveIn{TokenID: "token-xxxxx", UserID: "user-xxxxx", IssuedAt: test.TimeBeforeNow}
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when revocation repository IsRevoked return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.revocationRepository.On("IsRevoked", context.Background(), "token-xxxxx").
+					Return(false, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrAccessTokenRevoked when access token is revoked",
+			expected: entity.ErrAccessTokenRevoked,
+			setup: func(d *dependency) {
+				d.revocationRepository.On("IsRevoked", context.Background(), "token-xxxxx").
+					Return(true, nil)
+			},
+		},
+		{
+			name:     "it should return error when revocation repository FindWatermark return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.revocationRepository.On("IsRevoked", context.Background(), "token-xxxxx").
+					Return(false, nil)
+				d.revocationRepository.On("FindWatermark", context.Background(), entity.UserID("user-xxxxx")).
+					Return(time.Time{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrAccessTokenRevoked when access token is issued before the watermark",
+			expected: entity.ErrAccessTokenRevoked,
+			setup: func(d *dependency) {
+				d.revocationRepository.On("IsRevoked", context.Background(), "token-xxxxx").
+					Return(false, nil)
+				d.revocationRepository.On("FindWatermark", context.Background(), entity.UserID("user-xxxxx")).
+					Return(time.Now(), nil)
+			},
+		},
+		{
+			name:     "it should return error nil when user has no watermark",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.revocationRepository.On("IsRevoked", context.Background(), "token-xxxxx").
+					Return(false, nil)
+				d.revocationRepository.On("FindWatermark", context.Background(), entity.UserID("user-xxxxx")).
+					Return(time.Time{}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				revocationRepository: &mocks.RevocationRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.revocationRepository, time.Hour)
+			err := usecase.EnsureActive(context.Background(), payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *RevocationUsecaseTestSuite) TestPrune() {
+	// matchWatermarkBefore match a time one access token lifetime before now.
+	matchWatermarkBefore := mock.MatchedBy(func(t time.Time) bool {
+		before := time.Since(t)
+		return before >= time.Hour && before < time.Hour+time.Minute
+	})
+
+	type expected struct {
+		output dto.RevocationPruneOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when revocation repository DeleteExpired return unexpected error",
+			expected: expected{output: dto.RevocationPruneOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.revocationRepository.On("DeleteExpired", context.Background(), matchWatermarkBefore).
+					Return(int64(0), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and the number of deleted revocations when success",
+			expected: expected{output: dto.RevocationPruneOut{Deleted: 3}, err: nil},
+			setup: func(d *dependency) {
+				d.revocationRepository.On("DeleteExpired", context.Background(), matchWatermarkBefore).
+					Return(int64(3), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				revocationRepository: &mocks.RevocationRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.revocationRepository, time.Hour)
+			output, err := usecase.Prune(context.Background())
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
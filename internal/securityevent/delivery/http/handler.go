@@ -0,0 +1,66 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+)
+
+type HTTPHandler struct {
+	validator            domain.ValidatorProvider
+	securityEventUsecase domain.SecurityEventUsecase
+}
+
+// New creates a new security event handler.
+func New(validator domain.ValidatorProvider, securityEventUsecase domain.SecurityEventUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, securityEventUsecase: securityEventUsecase}
+}
+
+// GET /users/me/security-events?limit=50&offset=0 to get the security events of the authenticated user.
+func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	query := r.URL.Query()
+	var payload dto.SecurityEventGetAllIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+
+	var err error
+	if payload.Limit, err = queryInt(query.Get("limit")); err != nil {
+		err = dto.ErrLimitInvalid
+	} else if payload.Offset, err = queryInt(query.Get("offset")); err != nil {
+		err = dto.ErrOffsetInvalid
+	} else {
+		err = h.validator.Validate(&payload)
+	}
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.securityEventUsecase.GetAll(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// queryInt parse a paging query parameter, a missing one is 0.
+func queryInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
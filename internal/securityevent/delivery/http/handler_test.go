@@ -0,0 +1,171 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type SecurityEventHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestSecurityEventHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SecurityEventHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	validator            *mocks.ValidatorProvider
+	securityEventUsecase *mocks.SecurityEventUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *SecurityEventHTTPHandlerTestSuite) TestGet() {
+	tests := []struct {
+		name     string
+		isError  bool
+		query    string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when limit is not a number",
+			isError: true,
+			query:   "?limit=all",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Limit must be a number between 0 and 100",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when offset is not a number",
+			isError: true,
+			query:   "?offset=next",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Offset must be zero or a positive number",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			query:   "?limit=1000",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Limit must be a number between 0 and 100",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrLimitInvalid)
+			},
+		},
+		{
+			name:    "it should response with error when security event usecase GetAll return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.securityEventUsecase.On("GetAll", mock.Anything, &dto.SecurityEventGetAllIn{UserID: "user-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			query:   "?limit=10&offset=20",
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{
+						"id":         "security-event-xxxxx",
+						"type":       "login",
+						"user_agent": "Mozilla/5.0",
+						"ip_address": "203.0.113.7",
+						"created_at": test.TimeBeforeNow.Format(time.RFC3339Nano),
+					},
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.securityEventUsecase.On("GetAll", mock.Anything, &dto.SecurityEventGetAllIn{UserID: "user-xxxxx", Limit: 10, Offset: 20}).
+					Return([]dto.SecurityEventGetAllOut{
+						{ID: "security-event-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/"+t.query, nil)
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+
+			d := &dependency{
+				validator:            &mocks.ValidatorProvider{},
+				securityEventUsecase: &mocks.SecurityEventUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.securityEventUsecase)
+			handler.Get(rr, req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, resBody.Payload)
+			}
+		})
+	}
+}
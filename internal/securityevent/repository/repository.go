@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new security event repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new security event to database.
+func (r *Repository) Store(ctx context.Context, e *entity.SecurityEvent) error {
+	id := entity.SecurityEventID(r.idProvider.Generate())
+	q := `INSERT INTO security_events (id, user_id, type, user_agent, ip_address, detail) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.ExecContext(ctx, q, id, e.UserID, e.Type, e.UserAgent, e.IPAddress, e.Detail)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindAll get the security events matching the filter, newest first.
+func (r *Repository) FindAll(ctx context.Context, filter entity.SecurityEventFilter, limit int, offset int) ([]entity.SecurityEvent, error) {
+	since := sql.NullTime{Time: filter.Since, Valid: !filter.Since.IsZero()}
+	until := sql.NullTime{Time: filter.Until, Valid: !filter.Until.IsZero()}
+	q := `SELECT id, user_id, type, user_agent, ip_address, detail, created_at FROM security_events WHERE ($1 = '' OR user_id = $1) AND ($2 = '' OR type = $2) AND ($3 = '' OR ip_address = $3) AND ($4::TIMESTAMP IS NULL OR created_at >= $4) AND ($5::TIMESTAMP IS NULL OR created_at < $5) ORDER BY created_at DESC LIMIT $6 OFFSET $7`
+	rows, err := r.db.QueryContext(ctx, q, filter.UserID, filter.Type, filter.IPAddress, since, until, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]entity.SecurityEvent, 0)
+	for rows.Next() {
+		var e entity.SecurityEvent
+		err := rows.Scan(&e.ID, &e.UserID, &e.Type, &e.UserAgent, &e.IPAddress, &e.Detail, &e.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// DeleteBefore delete the security events created before the time and return how many were deleted.
+func (r *Repository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	q := `DELETE FROM security_events WHERE created_at < $1`
+	result, err := r.db.ExecContext(ctx, q, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,227 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type SecurityEventRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestSecurityEventRepositorySuite(t *testing.T) {
+	suite.Run(t, new(SecurityEventRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+func (s *SecurityEventRepositoryTestSuite) TestStore() {
+	query := regexp.QuoteMeta(`INSERT INTO security_events (id, user_id, type, user_agent, ip_address, detail) VALUES ($1, $2, $3, $4, $5, $6)`)
+	event := &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventServiceAccountRequest, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", Detail: "GET /api/tasks 200"}
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("security-event-xxxxx")
+
+				d.mockDB.ExpectExec(query).
+					WithArgs("security-event-xxxxx", "user-xxxxx", entity.SecurityEventServiceAccountRequest, "Mozilla/5.0", "203.0.113.7", "GET /api/tasks 200").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("security-event-xxxxx")
+
+				d.mockDB.ExpectExec(query).
+					WithArgs("security-event-xxxxx", "user-xxxxx", entity.SecurityEventServiceAccountRequest, "Mozilla/5.0", "203.0.113.7", "GET /api/tasks 200").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.Store(context.Background(), event)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *SecurityEventRepositoryTestSuite) TestFindAll() {
+	query := regexp.QuoteMeta(`SELECT id, user_id, type, user_agent, ip_address, detail, created_at FROM security_events WHERE ($1 = '' OR user_id = $1) AND ($2 = '' OR type = $2) AND ($3 = '' OR ip_address = $3) AND ($4::TIMESTAMP IS NULL OR created_at >= $4) AND ($5::TIMESTAMP IS NULL OR created_at < $5) ORDER BY created_at DESC LIMIT $6 OFFSET $7`)
+	columns := []string{"id", "user_id", "type", "user_agent", "ip_address", "detail", "created_at"}
+
+	type args struct {
+		filter entity.SecurityEventFilter
+	}
+	type expected struct {
+		events []entity.SecurityEvent
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			args:     args{filter: entity.SecurityEventFilter{UserID: "user-xxxxx"}},
+			expected: expected{events: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx", "", "", sql.NullTime{}, sql.NullTime{}, 50, 0).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to scan",
+			args:     args{filter: entity.SecurityEventFilter{UserID: "user-xxxxx"}},
+			expected: expected{events: nil, err: test.ErrRowScan},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("security-event-xxxxx", "user-xxxxx", "login", "Mozilla/5.0", "203.0.113.7", "", test.TimeBeforeNow).
+					RowError(0, test.ErrRowScan)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx", "", "", sql.NullTime{}, sql.NullTime{}, 50, 0).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:     "it should return error nil and empty events when nothing matches",
+			args:     args{filter: entity.SecurityEventFilter{UserID: "user-xxxxx"}},
+			expected: expected{events: []entity.SecurityEvent{}, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx", "", "", sql.NullTime{}, sql.NullTime{}, 50, 0).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "it should return error nil and the matching events when successfully find",
+			args: args{filter: entity.SecurityEventFilter{Type: entity.SecurityEventLoginFailed, IPAddress: "203.0.113.7", Since: test.TimeBeforeNow, Until: test.TimeAfterNow}},
+			expected: expected{
+				events: []entity.SecurityEvent{
+					{ID: "security-event-xxxxx", UserID: "user-xxxxx", Type: entity.SecurityEventServiceAccountRequest, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", Detail: "GET /api/tasks 200", CreatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("security-event-xxxxx", "user-xxxxx", "service_account_request", "Mozilla/5.0", "203.0.113.7", "GET /api/tasks 200", test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("", "login_failed", "203.0.113.7", sql.NullTime{Time: test.TimeBeforeNow, Valid: true}, sql.NullTime{Time: test.TimeAfterNow, Valid: true}, 50, 0).
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			events, err := repository.FindAll(context.Background(), t.args.filter, 50, 0)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.events, events)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *SecurityEventRepositoryTestSuite) TestDeleteBefore() {
+	query := regexp.QuoteMeta(`DELETE FROM security_events WHERE created_at < $1`)
+
+	type expected struct {
+		deleted int64
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{deleted: 0, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs(test.TimeBeforeNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and the number of deleted events when successfully delete",
+			expected: expected{deleted: 4, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs(test.TimeBeforeNow).
+					WillReturnResult(sqlmock.NewResult(0, 4))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			deleted, err := repository.DeleteBefore(context.Background(), test.TimeBeforeNow)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.deleted, deleted)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// defaultRetention is how long security events are kept when no retention is configured.
+const defaultRetention = 90 * 24 * time.Hour
+
+type Usecase struct {
+	securityEventRepository domain.SecurityEventRepository
+	retention               time.Duration
+}
+
+// New create a new security event usecase, events older than retention are pruned.
+func New(securityEventRepository domain.SecurityEventRepository, retention time.Duration) Usecase {
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+	return Usecase{securityEventRepository: securityEventRepository, retention: retention}
+}
+
+// GetAll get the security events of a user, newest first.
+func (u *Usecase) GetAll(ctx context.Context, payload *dto.SecurityEventGetAllIn) ([]dto.SecurityEventGetAllOut, error) {
+	limit := payload.Limit
+	if limit == 0 {
+		limit = dto.SecurityEventDefaultLimit
+	}
+
+	events, err := u.securityEventRepository.FindAll(ctx, entity.SecurityEventFilter{UserID: payload.UserID}, limit, payload.Offset)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.SecurityEventGetAllOut, len(events))
+	for i, e := range events {
+		output[i] = dto.SecurityEventGetAllOut{
+			ID:        e.ID,
+			Type:      e.Type,
+			UserAgent: e.UserAgent,
+			IPAddress: e.IPAddress,
+			Detail:    e.Detail,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+	return output, nil
+}
+
+// Prune delete the security events past their retention.
+func (u *Usecase) Prune(ctx context.Context) (dto.SecurityEventPruneOut, error) {
+	deleted, err := u.securityEventRepository.DeleteBefore(ctx, time.Now().Add(-u.retention))
+	if err != nil {
+		return dto.SecurityEventPruneOut{}, err
+	}
+	return dto.SecurityEventPruneOut{Deleted: deleted}, nil
+}
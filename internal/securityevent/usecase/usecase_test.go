@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type SecurityEventUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestSecurityEventUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(SecurityEventUsecaseTestSuite))
+}
+
+type dependency struct {
+	securityEventRepository *mocks.SecurityEventRepository
+}
+
+func (s *SecurityEventUsecaseTestSuite) TestGetAll() {
+	type args struct {
+		payload *dto.SecurityEventGetAllIn
+	}
+	type expected struct {
+		output []dto.SecurityEventGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when security event repository FindAll return unexpected error",
+			args:     args{payload: &dto.SecurityEventGetAllIn{UserID: "user-xxxxx"}},
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.securityEventRepository.On("FindAll", context.Background(), entity.SecurityEventFilter{UserID: "user-xxxxx"}, dto.SecurityEventDefaultLimit, 0).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and the events of the user when success",
+			args: args{payload: &dto.SecurityEventGetAllIn{UserID: "user-xxxxx", Limit: 10, Offset: 20}},
+			expected: expected{
+				output: []dto.SecurityEventGetAllOut{
+					{ID: "security-event-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", CreatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.securityEventRepository.On("FindAll", context.Background(), entity.SecurityEventFilter{UserID: "user-xxxxx"}, 10, 20).
+					Return([]entity.SecurityEvent{
+						{ID: "security-event-xxxxx", UserID: "user-xxxxx", Type: entity.SecurityEventLogin, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.securityEventRepository, time.Hour)
+			output, err := usecase.GetAll(context.Background(), t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *SecurityEventUsecaseTestSuite) TestPrune() {
+	// matchRetention match a time one retention before now.
+	matchRetention := mock.MatchedBy(func(t time.Time) bool {
+		before := time.Since(t)
+		return before >= time.Hour && before < time.Hour+time.Minute
+	})
+
+	type expected struct {
+		output dto.SecurityEventPruneOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when security event repository DeleteBefore return unexpected error",
+			expected: expected{output: dto.SecurityEventPruneOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.securityEventRepository.On("DeleteBefore", context.Background(), matchRetention).
+					Return(int64(0), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and the number of deleted events when success",
+			expected: expected{output: dto.SecurityEventPruneOut{Deleted: 5}, err: nil},
+			setup: func(d *dependency) {
+				d.securityEventRepository.On("DeleteBefore", context.Background(), matchRetention).
+					Return(int64(5), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				securityEventRepository: &mocks.SecurityEventRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.securityEventRepository, time.Hour)
+			output, err := usecase.Prune(context.Background())
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+)
+
+type HTTPHandler struct {
+	validator             domain.ValidatorProvider
+	serviceAccountUsecase domain.ServiceAccountUsecase
+}
+
+// New creates a new service account handler.
+func New(validator domain.ValidatorProvider, serviceAccountUsecase domain.ServiceAccountUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, serviceAccountUsecase: serviceAccountUsecase}
+}
+
+// POST /admin/service-accounts to create new service account with its first API key.
+func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.ServiceAccountCreateIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.serviceAccountUsecase.Create(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusCreated, "Successfully created new service account", output))
+}
+
+// GET /admin/service-accounts/{user_id}/keys to get all API keys of a service account.
+func (h *HTTPHandler) GetKeys(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.ServiceAccountKeyGetAllIn
+	payload.UserID = entity.UserID(chi.URLParam(r, "user_id"))
+
+	output, err := h.serviceAccountUsecase.GetKeys(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// POST /admin/service-accounts/{user_id}/keys to rotate the API key of a service account.
+func (h *HTTPHandler) PostKey(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.ServiceAccountKeyRotateIn
+	payload.UserID = entity.UserID(chi.URLParam(r, "user_id"))
+
+	output, err := h.serviceAccountUsecase.RotateKey(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusCreated, "Successfully rotated service account API key", output))
+}
+
+// DELETE /admin/service-accounts/{user_id}/keys/{key_id} to revoke an API key of a service account.
+func (h *HTTPHandler) DeleteKey(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.ServiceAccountKeyRevokeIn
+	payload.UserID = entity.UserID(chi.URLParam(r, "user_id"))
+	payload.KeyID = entity.ServiceAccountKeyID(chi.URLParam(r, "key_id"))
+
+	if err := h.serviceAccountUsecase.RevokeKey(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully revoked service account API key", nil))
+}
@@ -0,0 +1,344 @@
+package http
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type ServiceAccountHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestServiceAccountHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	req                   *http.Request
+	validator             *mocks.ValidatorProvider
+	serviceAccountUsecase *mocks.ServiceAccountUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *ServiceAccountHTTPHandlerTestSuite) assertResponse(rr *httptest.ResponseRecorder, isError bool, expected expected) {
+	s.Equal(expected.contentType, rr.Header().Get("Content-Type"))
+	s.Equal(expected.statusCode, rr.Code)
+
+	if isError {
+		var resBody domain.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.error, resBody.Error)
+	} else {
+		var resBody domain.SuccessResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.payload, resBody.Payload)
+	}
+}
+
+func (s *ServiceAccountHTTPHandlerTestSuite) TestPost() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{"name":"CI"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Email is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrEmailEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when service account usecase Create return error",
+			isError:     true,
+			requestBody: []byte(`{"name":"CI","email":"ci@go.dev"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Email is not available",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.serviceAccountUsecase.On("Create", mock.Anything, &dto.ServiceAccountCreateIn{Name: "CI", Email: "ci@go.dev"}).
+					Return(dto.ServiceAccountCreateOut{}, domain.ErrEmailNotAvailable)
+			},
+		},
+		{
+			name:        "it should response with success and the raw key when success",
+			isError:     false,
+			requestBody: []byte(`{"name":"CI","email":"ci@go.dev"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusCreated,
+				message:     "Successfully created new service account",
+				payload: map[string]any{
+					"id":     "user-xxxxx",
+					"name":   "CI",
+					"email":  "ci@go.dev",
+					"key_id": "key-xxxxx",
+					"key":    "tks_xxxxx",
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.serviceAccountUsecase.On("Create", mock.Anything, &dto.ServiceAccountCreateIn{Name: "CI", Email: "ci@go.dev"}).
+					Return(dto.ServiceAccountCreateOut{ID: "user-xxxxx", Name: "CI", Email: "ci@go.dev", KeyID: "key-xxxxx", Key: "tks_xxxxx"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				req:                   req,
+				validator:             &mocks.ValidatorProvider{},
+				serviceAccountUsecase: &mocks.ServiceAccountUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.serviceAccountUsecase)
+			handler.Post(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *ServiceAccountHTTPHandlerTestSuite) TestGetKeys() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when service account usecase GetKeys return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "Service account not found",
+			},
+			setup: func(d *dependency) {
+				d.serviceAccountUsecase.On("GetKeys", mock.Anything, &dto.ServiceAccountKeyGetAllIn{UserID: "user-xxxxx"}).
+					Return(nil, domain.ErrServiceAccountNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{
+						"id":           "key-xxxxx",
+						"expires_at":   test.TimeAfterNow.Format(time.RFC3339Nano),
+						"last_used_at": nil,
+						"created_at":   test.TimeBeforeNow.Format(time.RFC3339Nano),
+					},
+				},
+			},
+			setup: func(d *dependency) {
+				d.serviceAccountUsecase.On("GetKeys", mock.Anything, &dto.ServiceAccountKeyGetAllIn{UserID: "user-xxxxx"}).
+					Return([]dto.ServiceAccountKeyGetAllOut{
+						{ID: "key-xxxxx", ExpiresAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+
+			d := &dependency{
+				req:                   test.InjectChiRouterParams(req, map[string]string{"user_id": "user-xxxxx"}),
+				serviceAccountUsecase: &mocks.ServiceAccountUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.serviceAccountUsecase)
+			handler.GetKeys(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *ServiceAccountHTTPHandlerTestSuite) TestPostKey() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when service account usecase RotateKey return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.serviceAccountUsecase.On("RotateKey", mock.Anything, &dto.ServiceAccountKeyRotateIn{UserID: "user-xxxxx"}).
+					Return(dto.ServiceAccountKeyRotateOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success and the raw key when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusCreated,
+				message:     "Successfully rotated service account API key",
+				payload: map[string]any{
+					"id":                      "key-yyyyy",
+					"key":                     "tks_yyyyy",
+					"previous_key_expires_at": test.TimeAfterNow.Format(time.RFC3339Nano),
+				},
+			},
+			setup: func(d *dependency) {
+				d.serviceAccountUsecase.On("RotateKey", mock.Anything, &dto.ServiceAccountKeyRotateIn{UserID: "user-xxxxx"}).
+					Return(dto.ServiceAccountKeyRotateOut{ID: "key-yyyyy", Key: "tks_yyyyy", PreviousKeyExpiresAt: test.TimeAfterNow}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", nil)
+
+			d := &dependency{
+				req:                   test.InjectChiRouterParams(req, map[string]string{"user_id": "user-xxxxx"}),
+				serviceAccountUsecase: &mocks.ServiceAccountUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.serviceAccountUsecase)
+			handler.PostKey(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *ServiceAccountHTTPHandlerTestSuite) TestDeleteKey() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when service account usecase RevokeKey return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "Service account API key not found",
+			},
+			setup: func(d *dependency) {
+				d.serviceAccountUsecase.On("RevokeKey", mock.Anything, &dto.ServiceAccountKeyRevokeIn{UserID: "user-xxxxx", KeyID: "key-xxxxx"}).
+					Return(domain.ErrServiceAccountKeyNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully revoked service account API key",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.serviceAccountUsecase.On("RevokeKey", mock.Anything, &dto.ServiceAccountKeyRevokeIn{UserID: "user-xxxxx", KeyID: "key-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+
+			d := &dependency{
+				req:                   test.InjectChiRouterParams(req, map[string]string{"user_id": "user-xxxxx", "key_id": "key-xxxxx"}),
+				serviceAccountUsecase: &mocks.ServiceAccountUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.serviceAccountUsecase)
+			handler.DeleteKey(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
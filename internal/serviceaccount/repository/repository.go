@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new service account API key repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new service account API key to database.
+func (r *Repository) Store(ctx context.Context, k *entity.ServiceAccountKey) (entity.ServiceAccountKeyID, error) {
+	id := r.idProvider.Generate()
+	q := `INSERT INTO service_account_keys (id, user_id, key_hash) VALUES ($1, $2, $3)`
+	_, err := r.db.ExecContext(ctx, q, id, k.UserID, k.KeyHash)
+	if err != nil {
+		return "", err
+	}
+	return entity.ServiceAccountKeyID(id), nil
+}
+
+// FindByID get service account API key by id.
+func (r *Repository) FindByID(ctx context.Context, keyID entity.ServiceAccountKeyID) (entity.ServiceAccountKey, error) {
+	q := `SELECT id, user_id, key_hash, expires_at, last_used_at, created_at FROM service_account_keys WHERE id = $1`
+	return r.findOne(ctx, q, keyID)
+}
+
+// FindByKeyHash get service account API key by the hash of the raw key.
+func (r *Repository) FindByKeyHash(ctx context.Context, keyHash string) (entity.ServiceAccountKey, error) {
+	q := `SELECT id, user_id, key_hash, expires_at, last_used_at, created_at FROM service_account_keys WHERE key_hash = $1`
+	return r.findOne(ctx, q, keyHash)
+}
+
+func (r *Repository) findOne(ctx context.Context, q string, arg any) (entity.ServiceAccountKey, error) {
+	var k entity.ServiceAccountKey
+	err := r.db.QueryRowContext(ctx, q, arg).Scan(&k.ID, &k.UserID, &k.KeyHash, &k.ExpiresAt, &k.LastUsedAt, &k.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ServiceAccountKey{}, domain.ErrServiceAccountKeyNotFound
+	} else if err != nil {
+		return entity.ServiceAccountKey{}, err
+	}
+	return k, nil
+}
+
+// FindAllByUserID get all API keys of a service account, newest first.
+func (r *Repository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.ServiceAccountKey, error) {
+	q := `SELECT id, user_id, expires_at, last_used_at, created_at FROM service_account_keys WHERE user_id = $1 ORDER BY created_at DESC`
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]entity.ServiceAccountKey, 0)
+	for rows.Next() {
+		var k entity.ServiceAccountKey
+		err := rows.Scan(&k.ID, &k.UserID, &k.ExpiresAt, &k.LastUsedAt, &k.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Rotate save a new API key for a service account and let its current key, the one without expiry, expire at expiresAt.
+// The keys an earlier rotation already let expire are deleted, all in a single transaction.
+// The service account is locked first, so rotations of the same account run one after the other,
+// even when it has no key left to lock.
+func (r *Repository) Rotate(ctx context.Context, k *entity.ServiceAccountKey, expiresAt time.Time) (entity.ServiceAccountKeyID, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	var userID entity.UserID
+	err = tx.QueryRowContext(ctx, `SELECT id FROM users WHERE id = $1 FOR UPDATE`, k.UserID).Scan(&userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", domain.ErrUserNotFound
+	} else if err != nil {
+		return "", err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM service_account_keys WHERE user_id = $1 AND expires_at IS NOT NULL`, k.UserID); err != nil {
+		return "", err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE service_account_keys SET expires_at = $2 WHERE user_id = $1 AND expires_at IS NULL`, k.UserID, expiresAt); err != nil {
+		return "", err
+	}
+	id := r.idProvider.Generate()
+	q := `INSERT INTO service_account_keys (id, user_id, key_hash) VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, q, id, k.UserID, k.KeyHash); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return entity.ServiceAccountKeyID(id), nil
+}
+
+// Touch record that a service account API key was used.
+// The time is kept with a minute precision, so busy keys do not cause a write on every request.
+func (r *Repository) Touch(ctx context.Context, keyID entity.ServiceAccountKeyID) error {
+	q := `UPDATE service_account_keys SET last_used_at = NOW() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`
+	_, err := r.db.ExecContext(ctx, q, keyID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteByID delete service account API key by id.
+func (r *Repository) DeleteByID(ctx context.Context, keyID entity.ServiceAccountKeyID) error {
+	q := `DELETE FROM service_account_keys WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, keyID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
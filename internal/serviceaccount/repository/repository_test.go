@@ -0,0 +1,526 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type ServiceAccountKeyRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestServiceAccountKeyRepositorySuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountKeyRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	storeQuery           = regexp.QuoteMeta(`INSERT INTO service_account_keys (id, user_id, key_hash) VALUES ($1, $2, $3)`)
+	findByIDQuery        = regexp.QuoteMeta(`SELECT id, user_id, key_hash, expires_at, last_used_at, created_at FROM service_account_keys WHERE id = $1`)
+	findByKeyHashQuery   = regexp.QuoteMeta(`SELECT id, user_id, key_hash, expires_at, last_used_at, created_at FROM service_account_keys WHERE key_hash = $1`)
+	findAllByUserIDQuery = regexp.QuoteMeta(`SELECT id, user_id, expires_at, last_used_at, created_at FROM service_account_keys WHERE user_id = $1 ORDER BY created_at DESC`)
+	lockUserQuery        = regexp.QuoteMeta(`SELECT id FROM users WHERE id = $1 FOR UPDATE`)
+	expireCurrentQuery   = regexp.QuoteMeta(`UPDATE service_account_keys SET expires_at = $2 WHERE user_id = $1 AND expires_at IS NULL`)
+	deleteExpiringQuery  = regexp.QuoteMeta(`DELETE FROM service_account_keys WHERE user_id = $1 AND expires_at IS NOT NULL`)
+	touchQuery           = regexp.QuoteMeta(`UPDATE service_account_keys SET last_used_at = NOW() WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')`)
+	deleteByIDQuery      = regexp.QuoteMeta(`DELETE FROM service_account_keys WHERE id = $1`)
+)
+
+var key = entity.ServiceAccountKey{
+	ID:        "key-xxxxx",
+	UserID:    "user-xxxxx",
+	KeyHash:   "hashed_key",
+	ExpiresAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
+	CreatedAt: test.TimeBeforeNow,
+}
+
+func (s *ServiceAccountKeyRepositoryTestSuite) TestStore() {
+	type expected struct {
+		keyID entity.ServiceAccountKeyID
+		err   error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: expected{keyID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("key-xxxxx")
+
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("key-xxxxx", "user-xxxxx", "hashed_key").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and key id when successfully store",
+			expected: expected{keyID: "key-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("key-xxxxx")
+
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("key-xxxxx", "user-xxxxx", "hashed_key").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			keyID, err := repository.Store(context.Background(), &key)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.keyID, keyID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ServiceAccountKeyRepositoryTestSuite) TestFindByID() {
+	type expected struct {
+		key entity.ServiceAccountKey
+		err error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{key: entity.ServiceAccountKey{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByIDQuery).
+					WithArgs("key-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrServiceAccountKeyNotFound when row not found",
+			expected: expected{key: entity.ServiceAccountKey{}, err: domain.ErrServiceAccountKeyNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByIDQuery).
+					WithArgs("key-xxxxx").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and key when found",
+			expected: expected{key: key, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "key_hash", "expires_at", "last_used_at", "created_at"}).
+					AddRow("key-xxxxx", "user-xxxxx", "hashed_key", test.TimeAfterNow, nil, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findByIDQuery).
+					WithArgs("key-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			result, err := repository.FindByID(context.Background(), "key-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.key, result)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ServiceAccountKeyRepositoryTestSuite) TestFindByKeyHash() {
+	type expected struct {
+		key entity.ServiceAccountKey
+		err error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{key: entity.ServiceAccountKey{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByKeyHashQuery).
+					WithArgs("hashed_key").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrServiceAccountKeyNotFound when row not found",
+			expected: expected{key: entity.ServiceAccountKey{}, err: domain.ErrServiceAccountKeyNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByKeyHashQuery).
+					WithArgs("hashed_key").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and key when found",
+			expected: expected{key: key, err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "key_hash", "expires_at", "last_used_at", "created_at"}).
+					AddRow("key-xxxxx", "user-xxxxx", "hashed_key", test.TimeAfterNow, nil, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findByKeyHashQuery).
+					WithArgs("hashed_key").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			result, err := repository.FindByKeyHash(context.Background(), "hashed_key")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.key, result)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ServiceAccountKeyRepositoryTestSuite) TestFindAllByUserID() {
+	type expected struct {
+		keys []entity.ServiceAccountKey
+		err  error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{keys: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findAllByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when row scan fail",
+			expected: expected{keys: nil, err: test.ErrRowScan},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "expires_at", "last_used_at", "created_at"}).
+					AddRow("key-xxxxx", "user-xxxxx", nil, nil, test.TimeBeforeNow).
+					RowError(0, test.ErrRowScan)
+
+				d.mockDB.ExpectQuery(findAllByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name: "it should return error nil and keys when found",
+			expected: expected{
+				keys: []entity.ServiceAccountKey{
+					{ID: "key-yyyyy", UserID: "user-xxxxx", LastUsedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, CreatedAt: test.TimeBeforeNow},
+					{ID: "key-xxxxx", UserID: "user-xxxxx", ExpiresAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CreatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "expires_at", "last_used_at", "created_at"}).
+					AddRow("key-yyyyy", "user-xxxxx", nil, test.TimeBeforeNow, test.TimeBeforeNow).
+					AddRow("key-xxxxx", "user-xxxxx", test.TimeAfterNow, nil, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findAllByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			keys, err := repository.FindAllByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.keys, keys)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ServiceAccountKeyRepositoryTestSuite) TestRotate() {
+	type expected struct {
+		keyID entity.ServiceAccountKeyID
+		err   error
+	}
+	// lockAndRotate expect the service account to be locked and its rotated out keys deleted.
+	lockAndRotate := func(d *dependency) {
+		d.mockDB.ExpectBegin()
+		d.mockDB.ExpectQuery(lockUserQuery).
+			WithArgs("user-xxxxx").
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user-xxxxx"))
+		d.mockDB.ExpectExec(deleteExpiringQuery).
+			WithArgs("user-xxxxx").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to begin transaction",
+			expected: expected{keyID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrUserNotFound and rollback when service account does not exist",
+			expected: expected{keyID: "", err: domain.ErrUserNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectQuery(lockUserQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(sql.ErrNoRows)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to delete rotated out keys",
+			expected: expected{keyID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectQuery(lockUserQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("user-xxxxx"))
+				d.mockDB.ExpectExec(deleteExpiringQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to expire the current key",
+			expected: expected{keyID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				lockAndRotate(d)
+				d.mockDB.ExpectExec(expireCurrentQuery).
+					WithArgs("user-xxxxx", test.TimeAfterNow).
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to store the new key",
+			expected: expected{keyID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				lockAndRotate(d)
+				d.mockDB.ExpectExec(expireCurrentQuery).
+					WithArgs("user-xxxxx", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.idProvider.On("Generate").Return("key-yyyyy")
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("key-yyyyy", "user-xxxxx", "hashed_key").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to commit transaction",
+			expected: expected{keyID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				lockAndRotate(d)
+				d.mockDB.ExpectExec(expireCurrentQuery).
+					WithArgs("user-xxxxx", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.idProvider.On("Generate").Return("key-yyyyy")
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("key-yyyyy", "user-xxxxx", "hashed_key").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and the new key id when successfully rotate",
+			expected: expected{keyID: "key-yyyyy", err: nil},
+			setup: func(d *dependency) {
+				lockAndRotate(d)
+				d.mockDB.ExpectExec(expireCurrentQuery).
+					WithArgs("user-xxxxx", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.idProvider.On("Generate").Return("key-yyyyy")
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("key-yyyyy", "user-xxxxx", "hashed_key").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			keyID, err := repository.Rotate(context.Background(), &entity.ServiceAccountKey{UserID: "user-xxxxx", KeyHash: "hashed_key"}, test.TimeAfterNow)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.keyID, keyID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ServiceAccountKeyRepositoryTestSuite) TestTouch() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(touchQuery).
+					WithArgs("key-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(touchQuery).
+					WithArgs("key-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.Touch(context.Background(), "key-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *ServiceAccountKeyRepositoryTestSuite) TestDeleteByID() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByIDQuery).
+					WithArgs("key-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByIDQuery).
+					WithArgs("key-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.DeleteByID(context.Background(), "key-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
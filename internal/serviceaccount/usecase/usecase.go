@@ -0,0 +1,215 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// defaultKeyOverlap is how long a rotated API key keeps working when no overlap is configured.
+const defaultKeyOverlap = 24 * time.Hour
+
+type Usecase struct {
+	serviceAccountKeyRepository domain.ServiceAccountKeyRepository
+	userRepository              domain.UserRepository
+	securityEventRepository     domain.SecurityEventRepository
+	tokenProvider               domain.TokenProvider
+	rateLimiter                 domain.RateLimiter
+	keyOverlap                  time.Duration
+}
+
+// New create a new service account usecase.
+func New(
+	serviceAccountKeyRepository domain.ServiceAccountKeyRepository,
+	userRepository domain.UserRepository,
+	securityEventRepository domain.SecurityEventRepository,
+	tokenProvider domain.TokenProvider,
+	rateLimiter domain.RateLimiter,
+	keyOverlap time.Duration,
+) Usecase {
+	if keyOverlap <= 0 {
+		keyOverlap = defaultKeyOverlap
+	}
+	return Usecase{
+		serviceAccountKeyRepository: serviceAccountKeyRepository,
+		userRepository:              userRepository,
+		securityEventRepository:     securityEventRepository,
+		tokenProvider:               tokenProvider,
+		rateLimiter:                 rateLimiter,
+		keyOverlap:                  keyOverlap,
+	}
+}
+
+// Create create a verified service account without a password and issue its first API key.
+// The raw key is only returned here.
+func (u *Usecase) Create(ctx context.Context, payload *dto.ServiceAccountCreateIn) (dto.ServiceAccountCreateOut, error) {
+	if err := entity.ValidateEmail(payload.Email); err != nil {
+		return dto.ServiceAccountCreateOut{}, err
+	}
+	if err := u.userRepository.VerifyAvailableEmail(ctx, payload.Email); err != nil {
+		return dto.ServiceAccountCreateOut{}, err
+	}
+
+	user := entity.User{Name: payload.Name, Email: payload.Email}
+	userID, err := u.userRepository.StoreServiceAccount(ctx, &user)
+	if err != nil {
+		return dto.ServiceAccountCreateOut{}, err
+	}
+
+	keyID, rawKey, err := u.issue(ctx, userID)
+	if err != nil {
+		return dto.ServiceAccountCreateOut{}, err
+	}
+	if err := u.recordEvent(ctx, userID, entity.SecurityEventServiceAccountCreate); err != nil {
+		return dto.ServiceAccountCreateOut{}, err
+	}
+
+	return dto.ServiceAccountCreateOut{ID: userID, Name: user.Name, Email: user.Email, KeyID: keyID, Key: rawKey}, nil
+}
+
+// GetKeys get all API keys of a service account.
+func (u *Usecase) GetKeys(ctx context.Context, payload *dto.ServiceAccountKeyGetAllIn) ([]dto.ServiceAccountKeyGetAllOut, error) {
+	if err := u.ensureServiceAccount(ctx, payload.UserID); err != nil {
+		return nil, err
+	}
+
+	keys, err := u.serviceAccountKeyRepository.FindAllByUserID(ctx, payload.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.ServiceAccountKeyGetAllOut, len(keys))
+	for i, key := range keys {
+		output[i] = dto.ServiceAccountKeyGetAllOut{
+			ID:         key.ID,
+			ExpiresAt:  key.ExpiresAt,
+			LastUsedAt: key.LastUsedAt,
+			CreatedAt:  key.CreatedAt,
+		}
+	}
+	return output, nil
+}
+
+// RotateKey issue a new API key for a service account, the raw key is only returned here.
+// The current key keeps working for the overlap window so deployments can switch over,
+// a key still in the window of an earlier rotation is deleted, so at most two keys are valid at once.
+// The repository rotates the keys in a single transaction, so a failed or concurrent rotation can't break that.
+func (u *Usecase) RotateKey(ctx context.Context, payload *dto.ServiceAccountKeyRotateIn) (dto.ServiceAccountKeyRotateOut, error) {
+	if err := u.ensureServiceAccount(ctx, payload.UserID); err != nil {
+		return dto.ServiceAccountKeyRotateOut{}, err
+	}
+
+	key, rawKey, err := u.newKey(payload.UserID)
+	if err != nil {
+		return dto.ServiceAccountKeyRotateOut{}, err
+	}
+	expiresAt := time.Now().Add(u.keyOverlap)
+	keyID, err := u.serviceAccountKeyRepository.Rotate(ctx, key, expiresAt)
+	if err != nil {
+		return dto.ServiceAccountKeyRotateOut{}, err
+	}
+	if err := u.recordEvent(ctx, payload.UserID, entity.SecurityEventServiceAccountKeyRotate); err != nil {
+		return dto.ServiceAccountKeyRotateOut{}, err
+	}
+
+	return dto.ServiceAccountKeyRotateOut{ID: keyID, Key: rawKey, PreviousKeyExpiresAt: expiresAt}, nil
+}
+
+// RevokeKey delete an API key of a service account right away, such as a leaked key.
+func (u *Usecase) RevokeKey(ctx context.Context, payload *dto.ServiceAccountKeyRevokeIn) error {
+	key, err := u.serviceAccountKeyRepository.FindByID(ctx, payload.KeyID)
+	if err != nil {
+		return err
+	}
+	if key.UserID != payload.UserID {
+		return domain.ErrServiceAccountKeyNotFound
+	}
+
+	if err := u.serviceAccountKeyRepository.DeleteByID(ctx, key.ID); err != nil {
+		return err
+	}
+	return u.recordEvent(ctx, key.UserID, entity.SecurityEventServiceAccountKeyRevoke)
+}
+
+// Authenticate resolve the service account of a raw API key.
+// Every service account has its own request budget, a request over it is rejected before the key is touched.
+func (u *Usecase) Authenticate(ctx context.Context, payload *dto.ServiceAccountAuthenticateIn) (dto.ServiceAccountAuthenticateOut, error) {
+	key, err := u.serviceAccountKeyRepository.FindByKeyHash(ctx, u.tokenProvider.Hash(payload.Key))
+	if errors.Is(err, domain.ErrServiceAccountKeyNotFound) {
+		return dto.ServiceAccountAuthenticateOut{}, domain.ErrServiceAccountKeyInvalid
+	} else if err != nil {
+		return dto.ServiceAccountAuthenticateOut{}, err
+	}
+	if err := key.VerifyExpires(); err != nil {
+		return dto.ServiceAccountAuthenticateOut{}, err
+	}
+
+	if ok, retryAfter := u.rateLimiter.Allow(string(key.UserID)); !ok {
+		return dto.ServiceAccountAuthenticateOut{}, &domain.ServiceAccountThrottledError{RetryAfter: retryAfter}
+	}
+
+	if err := u.serviceAccountKeyRepository.Touch(ctx, key.ID); err != nil {
+		return dto.ServiceAccountAuthenticateOut{}, err
+	}
+
+	return dto.ServiceAccountAuthenticateOut{UserID: key.UserID}, nil
+}
+
+// Audit records a request made by a service account as a security event of the account.
+func (u *Usecase) Audit(ctx context.Context, payload *dto.ServiceAccountAuditIn) error {
+	event := &entity.SecurityEvent{
+		UserID:    payload.UserID,
+		Type:      entity.SecurityEventServiceAccountRequest,
+		UserAgent: payload.UserAgent,
+		IPAddress: payload.IPAddress,
+		Detail:    fmt.Sprintf("%s %s %d", payload.Method, payload.Path, payload.StatusCode),
+	}
+	return u.securityEventRepository.Store(ctx, event)
+}
+
+// ensureServiceAccount checks the user exists and is a service account.
+func (u *Usecase) ensureServiceAccount(ctx context.Context, userID entity.UserID) error {
+	user, err := u.userRepository.FindByID(ctx, userID)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return domain.ErrServiceAccountNotFound
+	} else if err != nil {
+		return err
+	}
+	if user.Role != entity.RoleServiceAccount {
+		return domain.ErrServiceAccountNotFound
+	}
+	return nil
+}
+
+// issue store a new API key for a service account and return it with its id.
+func (u *Usecase) issue(ctx context.Context, userID entity.UserID) (entity.ServiceAccountKeyID, string, error) {
+	key, rawKey, err := u.newKey(userID)
+	if err != nil {
+		return "", "", err
+	}
+	keyID, err := u.serviceAccountKeyRepository.Store(ctx, key)
+	if err != nil {
+		return "", "", err
+	}
+	return keyID, rawKey, nil
+}
+
+// newKey generate a raw API key for a service account and the key to store for it.
+func (u *Usecase) newKey(userID entity.UserID) (*entity.ServiceAccountKey, string, error) {
+	rawKey, err := u.tokenProvider.Generate()
+	if err != nil {
+		return nil, "", err
+	}
+	rawKey = entity.ServiceAccountKeyPrefix + rawKey
+	return &entity.ServiceAccountKey{UserID: userID, KeyHash: u.tokenProvider.Hash(rawKey)}, rawKey, nil
+}
+
+// recordEvent store a security event of a service account.
+func (u *Usecase) recordEvent(ctx context.Context, userID entity.UserID, eventType entity.SecurityEventType) error {
+	return u.securityEventRepository.Store(ctx, &entity.SecurityEvent{UserID: userID, Type: eventType})
+}
@@ -0,0 +1,509 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type ServiceAccountUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestServiceAccountUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(ServiceAccountUsecaseTestSuite))
+}
+
+type dependency struct {
+	serviceAccountKeyRepository *mocks.ServiceAccountKeyRepository
+	userRepository              *mocks.UserRepository
+	securityEventRepository     *mocks.SecurityEventRepository
+	tokenProvider               *mocks.TokenProvider
+	rateLimiter                 *mocks.RateLimiter
+}
+
+func newDependency() *dependency {
+	return &dependency{
+		serviceAccountKeyRepository: &mocks.ServiceAccountKeyRepository{},
+		userRepository:              &mocks.UserRepository{},
+		securityEventRepository:     &mocks.SecurityEventRepository{},
+		tokenProvider:               &mocks.TokenProvider{},
+		rateLimiter:                 &mocks.RateLimiter{},
+	}
+}
+
+func newUsecase(d *dependency) Usecase {
+	return New(d.serviceAccountKeyRepository, d.userRepository, d.securityEventRepository, d.tokenProvider, d.rateLimiter, time.Hour)
+}
+
+var (
+	serviceAccount = entity.User{ID: "user-xxxxx", Name: "CI", Email: "ci@go.dev", Role: entity.RoleServiceAccount}
+	expiresAt      = entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}
+	expiredAt      = entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}
+)
+
+// issue set up the expectations of issuing the API key "tks_xxxxx" to the service account.
+func issue(d *dependency) {
+	d.tokenProvider.On("Generate").Return("xxxxx", nil)
+	d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+	d.serviceAccountKeyRepository.On("Store", context.Background(), &entity.ServiceAccountKey{UserID: "user-xxxxx", KeyHash: "hashed_key"}).
+		Return(entity.ServiceAccountKeyID("key-xxxxx"), nil)
+}
+
+func (s *ServiceAccountUsecaseTestSuite) TestCreate() {
+	payload := &dto.ServiceAccountCreateIn{Name: "CI", Email: "ci@go.dev"}
+
+	type expected struct {
+		output dto.ServiceAccountCreateOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.ServiceAccountCreateIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrEmailInvalid when email is invalid",
+			payload:  &dto.ServiceAccountCreateIn{Name: "CI", Email: "ci"},
+			expected: expected{output: dto.ServiceAccountCreateOut{}, err: entity.ErrEmailInvalid},
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error ErrEmailNotAvailable when email is taken",
+			payload:  payload,
+			expected: expected{output: dto.ServiceAccountCreateOut{}, err: domain.ErrEmailNotAvailable},
+			setup: func(d *dependency) {
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "ci@go.dev").
+					Return(domain.ErrEmailNotAvailable)
+			},
+		},
+		{
+			name:     "it should return error when user repository StoreServiceAccount return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.ServiceAccountCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "ci@go.dev").Return(nil)
+				d.userRepository.On("StoreServiceAccount", context.Background(), &entity.User{Name: "CI", Email: "ci@go.dev"}).
+					Return(entity.UserID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when token provider Generate return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.ServiceAccountCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "ci@go.dev").Return(nil)
+				d.userRepository.On("StoreServiceAccount", context.Background(), &entity.User{Name: "CI", Email: "ci@go.dev"}).
+					Return(entity.UserID("user-xxxxx"), nil)
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when security event repository Store return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.ServiceAccountCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "ci@go.dev").Return(nil)
+				d.userRepository.On("StoreServiceAccount", context.Background(), &entity.User{Name: "CI", Email: "ci@go.dev"}).
+					Return(entity.UserID("user-xxxxx"), nil)
+				issue(d)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventServiceAccountCreate}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should return error nil and the first key when success",
+			payload: payload,
+			expected: expected{
+				output: dto.ServiceAccountCreateOut{ID: "user-xxxxx", Name: "CI", Email: "ci@go.dev", KeyID: "key-xxxxx", Key: "tks_xxxxx"},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "ci@go.dev").Return(nil)
+				d.userRepository.On("StoreServiceAccount", context.Background(), &entity.User{Name: "CI", Email: "ci@go.dev"}).
+					Return(entity.UserID("user-xxxxx"), nil)
+				issue(d)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventServiceAccountCreate}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.Create(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *ServiceAccountUsecaseTestSuite) TestGetKeys() {
+	type expected struct {
+		output []dto.ServiceAccountKeyGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrServiceAccountNotFound when user is not found",
+			expected: expected{output: nil, err: domain.ErrServiceAccountNotFound},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, domain.ErrUserNotFound)
+			},
+		},
+		{
+			name:     "it should return error ErrServiceAccountNotFound when user is not a service account",
+			expected: expected{output: nil, err: domain.ErrServiceAccountNotFound},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleUser}, nil)
+			},
+		},
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when service account key repository FindAllByUserID return unexpected error",
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(serviceAccount, nil)
+				d.serviceAccountKeyRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and keys when success",
+			expected: expected{
+				output: []dto.ServiceAccountKeyGetAllOut{
+					{ID: "key-yyyyy", CreatedAt: test.TimeBeforeNow},
+					{ID: "key-xxxxx", ExpiresAt: expiresAt, LastUsedAt: expiredAt, CreatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(serviceAccount, nil)
+				d.serviceAccountKeyRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return([]entity.ServiceAccountKey{
+						{ID: "key-yyyyy", UserID: "user-xxxxx", CreatedAt: test.TimeBeforeNow},
+						{ID: "key-xxxxx", UserID: "user-xxxxx", ExpiresAt: expiresAt, LastUsedAt: expiredAt, CreatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.GetKeys(context.Background(), &dto.ServiceAccountKeyGetAllIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *ServiceAccountUsecaseTestSuite) TestRotateKey() {
+	// matchOverlap match the end of the overlap window of a key rotated now.
+	matchOverlap := mock.MatchedBy(func(t time.Time) bool {
+		return time.Until(t) > 59*time.Minute && time.Until(t) <= time.Hour
+	})
+
+	type expected struct {
+		id  entity.ServiceAccountKeyID
+		key string
+		err error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrServiceAccountNotFound when user is not a service account",
+			expected: expected{err: domain.ErrServiceAccountNotFound},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleAdmin}, nil)
+			},
+		},
+		{
+			name:     "it should return error when generate key failed",
+			expected: expected{err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(serviceAccount, nil)
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when service account key repository Rotate return unexpected error",
+			expected: expected{err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(serviceAccount, nil)
+				d.tokenProvider.On("Generate").Return("xxxxx", nil)
+				d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+				d.serviceAccountKeyRepository.On("Rotate", context.Background(), &entity.ServiceAccountKey{UserID: "user-xxxxx", KeyHash: "hashed_key"}, matchOverlap).
+					Return(entity.ServiceAccountKeyID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and the new key when success",
+			expected: expected{id: "key-xxxxx", key: "tks_xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(serviceAccount, nil)
+				d.tokenProvider.On("Generate").Return("xxxxx", nil)
+				d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+				d.serviceAccountKeyRepository.On("Rotate", context.Background(), &entity.ServiceAccountKey{UserID: "user-xxxxx", KeyHash: "hashed_key"}, matchOverlap).
+					Return(entity.ServiceAccountKeyID("key-xxxxx"), nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventServiceAccountKeyRotate}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.RotateKey(context.Background(), &dto.ServiceAccountKeyRotateIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.id, output.ID)
+			s.Equal(t.expected.key, output.Key)
+			if err == nil {
+				s.WithinDuration(time.Now().Add(time.Hour), output.PreviousKeyExpiresAt, time.Minute)
+			}
+			d.serviceAccountKeyRepository.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *ServiceAccountUsecaseTestSuite) TestRevokeKey() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrServiceAccountKeyNotFound when key is not found",
+			expected: domain.ErrServiceAccountKeyNotFound,
+			setup: func(d *dependency) {
+				d.serviceAccountKeyRepository.On("FindByID", context.Background(), entity.ServiceAccountKeyID("key-xxxxx")).
+					Return(entity.ServiceAccountKey{}, domain.ErrServiceAccountKeyNotFound)
+			},
+		},
+		{
+			name:     "it should return error ErrServiceAccountKeyNotFound when key belongs to another service account",
+			expected: domain.ErrServiceAccountKeyNotFound,
+			setup: func(d *dependency) {
+				d.serviceAccountKeyRepository.On("FindByID", context.Background(), entity.ServiceAccountKeyID("key-xxxxx")).
+					Return(entity.ServiceAccountKey{ID: "key-xxxxx", UserID: "user-yyyyy"}, nil)
+			},
+		},
+		{
+			name:     "it should return error when service account key repository DeleteByID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.serviceAccountKeyRepository.On("FindByID", context.Background(), entity.ServiceAccountKeyID("key-xxxxx")).
+					Return(entity.ServiceAccountKey{ID: "key-xxxxx", UserID: "user-xxxxx"}, nil)
+				d.serviceAccountKeyRepository.On("DeleteByID", context.Background(), entity.ServiceAccountKeyID("key-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.serviceAccountKeyRepository.On("FindByID", context.Background(), entity.ServiceAccountKeyID("key-xxxxx")).
+					Return(entity.ServiceAccountKey{ID: "key-xxxxx", UserID: "user-xxxxx"}, nil)
+				d.serviceAccountKeyRepository.On("DeleteByID", context.Background(), entity.ServiceAccountKeyID("key-xxxxx")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventServiceAccountKeyRevoke}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.RevokeKey(context.Background(), &dto.ServiceAccountKeyRevokeIn{UserID: "user-xxxxx", KeyID: "key-xxxxx"})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *ServiceAccountUsecaseTestSuite) TestAuthenticate() {
+	type expected struct {
+		output dto.ServiceAccountAuthenticateOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrServiceAccountKeyInvalid when key is not found",
+			expected: expected{output: dto.ServiceAccountAuthenticateOut{}, err: domain.ErrServiceAccountKeyInvalid},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+				d.serviceAccountKeyRepository.On("FindByKeyHash", context.Background(), "hashed_key").
+					Return(entity.ServiceAccountKey{}, domain.ErrServiceAccountKeyNotFound)
+			},
+		},
+		{
+			name:     "it should return error when service account key repository FindByKeyHash return unexpected error",
+			expected: expected{output: dto.ServiceAccountAuthenticateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+				d.serviceAccountKeyRepository.On("FindByKeyHash", context.Background(), "hashed_key").
+					Return(entity.ServiceAccountKey{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrServiceAccountKeyExpired when the overlap window of a rotated key is over",
+			expected: expected{output: dto.ServiceAccountAuthenticateOut{}, err: entity.ErrServiceAccountKeyExpired},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+				d.serviceAccountKeyRepository.On("FindByKeyHash", context.Background(), "hashed_key").
+					Return(entity.ServiceAccountKey{ID: "key-xxxxx", UserID: "user-xxxxx", ExpiresAt: expiredAt}, nil)
+			},
+		},
+		{
+			name:     "it should return error ServiceAccountThrottledError when service account is over its rate limit",
+			expected: expected{output: dto.ServiceAccountAuthenticateOut{}, err: &domain.ServiceAccountThrottledError{RetryAfter: 30 * time.Second}},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+				d.serviceAccountKeyRepository.On("FindByKeyHash", context.Background(), "hashed_key").
+					Return(entity.ServiceAccountKey{ID: "key-xxxxx", UserID: "user-xxxxx"}, nil)
+				d.rateLimiter.On("Allow", "user-xxxxx").Return(false, 30*time.Second)
+			},
+		},
+		{
+			name:     "it should return error when service account key repository Touch return unexpected error",
+			expected: expected{output: dto.ServiceAccountAuthenticateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+				d.serviceAccountKeyRepository.On("FindByKeyHash", context.Background(), "hashed_key").
+					Return(entity.ServiceAccountKey{ID: "key-xxxxx", UserID: "user-xxxxx"}, nil)
+				d.rateLimiter.On("Allow", "user-xxxxx").Return(true, time.Duration(0))
+				d.serviceAccountKeyRepository.On("Touch", context.Background(), entity.ServiceAccountKeyID("key-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and the service account when a rotated key is within the overlap window",
+			expected: expected{output: dto.ServiceAccountAuthenticateOut{UserID: "user-xxxxx"}, err: nil},
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "tks_xxxxx").Return("hashed_key")
+				d.serviceAccountKeyRepository.On("FindByKeyHash", context.Background(), "hashed_key").
+					Return(entity.ServiceAccountKey{ID: "key-xxxxx", UserID: "user-xxxxx", ExpiresAt: expiresAt}, nil)
+				d.rateLimiter.On("Allow", "user-xxxxx").Return(true, time.Duration(0))
+				d.serviceAccountKeyRepository.On("Touch", context.Background(), entity.ServiceAccountKeyID("key-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.Authenticate(context.Background(), &dto.ServiceAccountAuthenticateIn{Key: "tks_xxxxx"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *ServiceAccountUsecaseTestSuite) TestAudit() {
+	event := &entity.SecurityEvent{
+		UserID:    "user-xxxxx",
+		Type:      entity.SecurityEventServiceAccountRequest,
+		UserAgent: "ci/1.0",
+		IPAddress: "203.0.113.7",
+		Detail:    "POST /api/tasks 201",
+	}
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when security event repository Store return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.securityEventRepository.On("Store", context.Background(), event).Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.securityEventRepository.On("Store", context.Background(), event).Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Audit(context.Background(), &dto.ServiceAccountAuditIn{
+				UserID:     "user-xxxxx",
+				Method:     "POST",
+				Path:       "/api/tasks",
+				StatusCode: 201,
+				UserAgent:  "ci/1.0",
+				IPAddress:  "203.0.113.7",
+			})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
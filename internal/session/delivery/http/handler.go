@@ -0,0 +1,84 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+)
+
+type HTTPHandler struct {
+	validator      domain.ValidatorProvider
+	sessionUsecase domain.SessionUsecase
+}
+
+// New creates a new session handler.
+func New(validator domain.ValidatorProvider, sessionUsecase domain.SessionUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, sessionUsecase: sessionUsecase}
+}
+
+// GET /sessions to get all active sessions of the authenticated user.
+func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.SessionGetAllIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.SessionID = entity.GetAuthSessionContext(r.Context())
+
+	output, err := h.sessionUsecase.GetAll(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
+
+// DELETE /sessions/{session_id} to sign out a session.
+func (h *HTTPHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.SessionRevokeIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.SessionID = entity.AuthID(chi.URLParam(r, "session_id"))
+
+	if err := h.sessionUsecase.Revoke(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully revoked session", nil))
+}
+
+// DELETE /sessions to sign out every session except the current one.
+func (h *HTTPHandler) DeleteOthers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.SessionRevokeOthersIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.SessionID = entity.GetAuthSessionContext(r.Context())
+
+	if err := h.sessionUsecase.RevokeOthers(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully revoked other sessions", nil))
+}
@@ -0,0 +1,247 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type SessionHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestSessionHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(SessionHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	req            *http.Request
+	sessionUsecase *mocks.SessionUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *SessionHTTPHandlerTestSuite) assertResponse(rr *httptest.ResponseRecorder, isError bool, expected expected) {
+	s.Equal(expected.contentType, rr.Header().Get("Content-Type"))
+	s.Equal(expected.statusCode, rr.Code)
+
+	if isError {
+		var resBody domain.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.error, resBody.Error)
+	} else {
+		var resBody domain.SuccessResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.payload, resBody.Payload)
+	}
+}
+
+func (s *SessionHTTPHandlerTestSuite) TestGet() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when session usecase GetAll return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.sessionUsecase.On("GetAll", mock.Anything, &dto.SessionGetAllIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{
+						"id":           "auth-xxxxx",
+						"user_agent":   "Mozilla/5.0",
+						"ip_address":   "203.0.113.7",
+						"is_current":   true,
+						"created_at":   test.TimeBeforeNow.Format(time.RFC3339Nano),
+						"last_used_at": test.TimeBeforeNow.Format(time.RFC3339Nano),
+						"expires_at":   test.TimeAfterNow.Format(time.RFC3339Nano),
+					},
+				},
+			},
+			setup: func(d *dependency) {
+				d.sessionUsecase.On("GetAll", mock.Anything, &dto.SessionGetAllIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx"}).
+					Return([]dto.SessionGetAllOut{
+						{ID: "auth-xxxxx", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", IsCurrent: true, CreatedAt: test.TimeBeforeNow, LastUsedAt: test.TimeBeforeNow, ExpiresAt: test.TimeAfterNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+			req = test.InjectAuthSessionContext(req, entity.AuthID("auth-xxxxx"))
+
+			d := &dependency{
+				req:            req,
+				sessionUsecase: &mocks.SessionUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.sessionUsecase)
+			handler.Get(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *SessionHTTPHandlerTestSuite) TestDelete() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when session usecase Revoke return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this session",
+			},
+			setup: func(d *dependency) {
+				d.sessionUsecase.On("Revoke", mock.Anything, &dto.SessionRevokeIn{UserID: "user-xxxxx", SessionID: "auth-yyyyy"}).
+					Return(domain.ErrSessionAuthorization)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully revoked session",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.sessionUsecase.On("Revoke", mock.Anything, &dto.SessionRevokeIn{UserID: "user-xxxxx", SessionID: "auth-yyyyy"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+			req = test.InjectChiRouterParams(req, map[string]string{"session_id": "auth-yyyyy"})
+
+			d := &dependency{
+				req:            req,
+				sessionUsecase: &mocks.SessionUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.sessionUsecase)
+			handler.Delete(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *SessionHTTPHandlerTestSuite) TestDeleteOthers() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when session usecase RevokeOthers return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Current session is unknown, please log in again",
+			},
+			setup: func(d *dependency) {
+				d.sessionUsecase.On("RevokeOthers", mock.Anything, &dto.SessionRevokeOthersIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx"}).
+					Return(domain.ErrSessionUnknown)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully revoked other sessions",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.sessionUsecase.On("RevokeOthers", mock.Anything, &dto.SessionRevokeOthersIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+			req = test.InjectAuthSessionContext(req, entity.AuthID("auth-xxxxx"))
+
+			d := &dependency{
+				req:            req,
+				sessionUsecase: &mocks.SessionUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.sessionUsecase)
+			handler.DeleteOthers(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
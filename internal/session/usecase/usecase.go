@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+)
+
+type Usecase struct {
+	authRepository domain.AuthRepository
+}
+
+// New create a new session usecase.
+func New(authRepository domain.AuthRepository) Usecase {
+	return Usecase{authRepository: authRepository}
+}
+
+// GetAll get all active sessions of a user, marking the current one.
+func (u *Usecase) GetAll(ctx context.Context, payload *dto.SessionGetAllIn) ([]dto.SessionGetAllOut, error) {
+	auths, err := u.authRepository.FindAllByUserID(ctx, payload.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.SessionGetAllOut, len(auths))
+	for i, auth := range auths {
+		output[i] = dto.SessionGetAllOut{
+			ID:         auth.ID,
+			UserAgent:  auth.UserAgent,
+			IPAddress:  auth.IPAddress,
+			IsCurrent:  auth.ID == payload.SessionID,
+			CreatedAt:  auth.CreatedAt,
+			LastUsedAt: auth.LastUsedAt,
+			ExpiresAt:  auth.ExpiresAt,
+		}
+	}
+	return output, nil
+}
+
+// Revoke sign out a session of a user.
+func (u *Usecase) Revoke(ctx context.Context, payload *dto.SessionRevokeIn) error {
+	auth, err := u.authRepository.FindByID(ctx, payload.SessionID)
+	if err != nil {
+		return err
+	}
+	if auth.UserID != payload.UserID {
+		return domain.ErrSessionAuthorization
+	}
+	return u.authRepository.DeleteByID(ctx, auth.ID)
+}
+
+// RevokeOthers sign out every session of a user except the current one.
+func (u *Usecase) RevokeOthers(ctx context.Context, payload *dto.SessionRevokeOthersIn) error {
+	if payload.SessionID == "" {
+		return domain.ErrSessionUnknown
+	}
+	return u.authRepository.DeleteOthersByUserID(ctx, payload.UserID, payload.SessionID)
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type SessionUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestSessionUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(SessionUsecaseTestSuite))
+}
+
+type dependency struct {
+	authRepository *mocks.AuthRepository
+}
+
+func (s *SessionUsecaseTestSuite) TestGetAll() {
+	auths := []entity.Auth{
+		{ID: "auth-aaaaa", UserID: "user-xxxxx", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", CreatedAt: test.TimeBeforeNow, LastUsedAt: test.TimeBeforeNow, ExpiresAt: test.TimeAfterNow},
+		{ID: "auth-bbbbb", UserID: "user-xxxxx", UserAgent: "curl/8.0", IPAddress: "198.51.100.2", CreatedAt: test.TimeBeforeNow, LastUsedAt: test.TimeBeforeNow, ExpiresAt: test.TimeAfterNow},
+	}
+
+	type expected struct {
+		output []dto.SessionGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when auth repository FindAllByUserID return unexpected error",
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and sessions with the current one marked when success",
+			expected: expected{
+				output: []dto.SessionGetAllOut{
+					{ID: "auth-aaaaa", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", IsCurrent: false, CreatedAt: test.TimeBeforeNow, LastUsedAt: test.TimeBeforeNow, ExpiresAt: test.TimeAfterNow},
+					{ID: "auth-bbbbb", UserAgent: "curl/8.0", IPAddress: "198.51.100.2", IsCurrent: true, CreatedAt: test.TimeBeforeNow, LastUsedAt: test.TimeBeforeNow, ExpiresAt: test.TimeAfterNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.authRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(auths, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				authRepository: &mocks.AuthRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.authRepository)
+			output, err := usecase.GetAll(context.Background(), &dto.SessionGetAllIn{UserID: "user-xxxxx", SessionID: "auth-bbbbb"})
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *SessionUsecaseTestSuite) TestRevoke() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when auth repository FindByID return error",
+			expected: domain.ErrAuthNotFound,
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(entity.Auth{}, domain.ErrAuthNotFound)
+			},
+		},
+		{
+			name:     "it should return error ErrSessionAuthorization when session is owned by another user",
+			expected: domain.ErrSessionAuthorization,
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-yyyyy"}, nil)
+			},
+		},
+		{
+			name:     "it should return error when auth repository DeleteByID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx"}, nil)
+				d.authRepository.On("DeleteByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully revoke the session",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.authRepository.On("FindByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(entity.Auth{ID: "auth-xxxxx", UserID: "user-xxxxx"}, nil)
+				d.authRepository.On("DeleteByID", context.Background(), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				authRepository: &mocks.AuthRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.authRepository)
+			err := usecase.Revoke(context.Background(), &dto.SessionRevokeIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx"})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *SessionUsecaseTestSuite) TestRevokeOthers() {
+	tests := []struct {
+		name     string
+		payload  *dto.SessionRevokeOthersIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrSessionUnknown when the current session is unknown",
+			payload:  &dto.SessionRevokeOthersIn{UserID: "user-xxxxx"},
+			expected: domain.ErrSessionUnknown,
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error when auth repository DeleteOthersByUserID return unexpected error",
+			payload:  &dto.SessionRevokeOthersIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.authRepository.On("DeleteOthersByUserID", context.Background(), entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully revoke the other sessions",
+			payload:  &dto.SessionRevokeOthersIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.authRepository.On("DeleteOthersByUserID", context.Background(), entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				authRepository: &mocks.AuthRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.authRepository)
+			err := usecase.RevokeOthers(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
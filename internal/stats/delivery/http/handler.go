@@ -0,0 +1,52 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+)
+
+type HTTPHandler struct {
+	validator    domain.ValidatorProvider
+	statsUsecase domain.StatsUsecase
+}
+
+// New creates a new stats handler.
+func New(validator domain.ValidatorProvider, statsUsecase domain.StatsUsecase) HTTPHandler {
+	return HTTPHandler{validator: validator, statsUsecase: statsUsecase}
+}
+
+// GET /stats?from=YYYY-MM-DD&to=YYYY-MM-DD&tz=Area/City to get productivity statistics.
+func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	query := r.URL.Query()
+	var payload dto.StatsGetIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.From = query.Get("from")
+	payload.To = query.Get("to")
+	payload.TimeZone = query.Get("tz")
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.statsUsecase.Get(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, http.StatusText(http.StatusOK), output))
+}
@@ -0,0 +1,162 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type StatsHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestStatsHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(StatsHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	req          *http.Request
+	validator    *mocks.ValidatorProvider
+	statsUsecase *mocks.StatsUsecase
+}
+
+func (s *StatsHTTPHandlerTestSuite) TestGet() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     map[string]any
+	}
+	payload := &dto.StatsGetIn{UserID: "user-xxxxx", From: "2026-01-05", To: "2026-01-11", TimeZone: "Asia/Jakarta"}
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Time zone is invalid",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", payload).
+					Return(dto.ErrTimeZoneInvalid)
+			},
+		},
+		{
+			name:    "it should response with error when stats usecase return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", payload).
+					Return(nil)
+
+				d.statsUsecase.On("Get", mock.Anything, payload).
+					Return(dto.StatsGetOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: map[string]any{
+					"from":                       "2026-01-05",
+					"to":                         "2026-01-11",
+					"time_zone":                  "Asia/Jakarta",
+					"daily":                      []any{map[string]any{"start": "2026-01-05", "created": float64(2), "completed": float64(1), "overdue": float64(0)}},
+					"weekly":                     []any{map[string]any{"start": "2026-01-05", "created": float64(2), "completed": float64(1), "overdue": float64(0)}},
+					"monthly":                    []any{map[string]any{"start": "2026-01-01", "created": float64(2), "completed": float64(1), "overdue": float64(0)}},
+					"current_streak":             float64(1),
+					"longest_streak":             float64(1),
+					"overdue_count":              float64(0),
+					"average_completion_seconds": float64(3600),
+				},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", payload).
+					Return(nil)
+
+				d.statsUsecase.On("Get", mock.Anything, payload).
+					Return(dto.StatsGetOut{
+						From:                     "2026-01-05",
+						To:                       "2026-01-11",
+						TimeZone:                 "Asia/Jakarta",
+						Daily:                    []dto.StatsPeriod{{Start: "2026-01-05", Created: 2, Completed: 1}},
+						Weekly:                   []dto.StatsPeriod{{Start: "2026-01-05", Created: 2, Completed: 1}},
+						Monthly:                  []dto.StatsPeriod{{Start: "2026-01-01", Created: 2, Completed: 1}},
+						CurrentStreak:            1,
+						LongestStreak:            1,
+						AverageCompletionSeconds: 3600,
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/?from=2026-01-05&to=2026-01-11&tz=Asia/Jakarta", nil)
+
+			d := &dependency{
+				req:          req,
+				validator:    &mocks.ValidatorProvider{},
+				statsUsecase: &mocks.StatsUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.statsUsecase)
+			handler.Get(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+				payloadMap := resBody.Payload.(map[string]any)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, payloadMap)
+			}
+		})
+	}
+}
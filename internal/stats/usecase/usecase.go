@@ -0,0 +1,185 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+const (
+	defaultRangeDays = 30
+	maxRangeDays     = 366
+)
+
+type Usecase struct {
+	taskRepository domain.TaskRepository
+}
+
+// New create a new stats usecase.
+func New(taskRepository domain.TaskRepository) Usecase {
+	return Usecase{taskRepository: taskRepository}
+}
+
+// Get compute the task statistics of a user over a date range in the user's time zone.
+// The range defaults to the last 30 days ending today and the time zone defaults to UTC.
+func (u *Usecase) Get(ctx context.Context, payload *dto.StatsGetIn) (dto.StatsGetOut, error) {
+	location := time.UTC
+	if payload.TimeZone != "" {
+		location, _ = time.LoadLocation(payload.TimeZone)
+	}
+	now := time.Now().In(location)
+
+	to := startOfDay(now)
+	if payload.To != "" {
+		to, _ = time.ParseInLocation(dto.StatsDateLayout, payload.To, location)
+	}
+	from := to.AddDate(0, 0, -(defaultRangeDays - 1))
+	if payload.From != "" {
+		from, _ = time.ParseInLocation(dto.StatsDateLayout, payload.From, location)
+	}
+	if to.Before(from) || entity.DaysBetween(from, to) >= maxRangeDays {
+		return dto.StatsGetOut{}, domain.ErrStatsRangeInvalid
+	}
+
+	tasks, err := u.taskRepository.FindAllByUserID(ctx, payload.UserID)
+	if err != nil {
+		return dto.StatsGetOut{}, err
+	}
+
+	return summarize(tasks, from, to, now), nil
+}
+
+// summarize aggregate tasks into daily, weekly and monthly periods of the inclusive range from - to.
+// All dates are interpreted in the location of from.
+func summarize(tasks []entity.Task, from, to, now time.Time) dto.StatsGetOut {
+	location := from.Location()
+	end := to.AddDate(0, 0, 1)
+	inRange := func(t time.Time) bool {
+		return !t.Before(from) && t.Before(end)
+	}
+
+	daily := newPeriods(from, to, startOfDay, func(t time.Time) time.Time { return t.AddDate(0, 0, 1) })
+	weekly := newPeriods(from, to, startOfWeek, func(t time.Time) time.Time { return t.AddDate(0, 0, 7) })
+	monthly := newPeriods(from, to, startOfMonth, func(t time.Time) time.Time { return t.AddDate(0, 1, 0) })
+	record := func(t time.Time, inc func(p *dto.StatsPeriod)) {
+		daily.record(t, inc)
+		weekly.record(t, inc)
+		monthly.record(t, inc)
+	}
+
+	output := dto.StatsGetOut{
+		From:     from.Format(dto.StatsDateLayout),
+		To:       to.Format(dto.StatsDateLayout),
+		TimeZone: location.String(),
+	}
+
+	completedDays := map[string]bool{}
+	var completionTotal time.Duration
+	var completionCount int64
+	for _, task := range tasks {
+		createdAt := task.CreatedAt.In(location)
+		if inRange(createdAt) {
+			record(createdAt, func(p *dto.StatsPeriod) { p.Created++ })
+		}
+
+		completed := task.IsCompleted && task.CompletedAt.Valid
+		completedAt := task.CompletedAt.Time.In(location)
+		if completed && inRange(completedAt) {
+			record(completedAt, func(p *dto.StatsPeriod) { p.Completed++ })
+			completedDays[completedAt.Format(dto.StatsDateLayout)] = true
+			completionTotal += completedAt.Sub(createdAt)
+			completionCount++
+		}
+
+		if !task.DueDate.Valid {
+			continue
+		}
+		dueDate := task.DueDate.Time.In(location)
+		if !completed && dueDate.Before(now) {
+			output.OverdueCount++
+		}
+		missed := (!completed && dueDate.Before(now)) || (completed && completedAt.After(dueDate))
+		if missed && inRange(dueDate) {
+			record(dueDate, func(p *dto.StatsPeriod) { p.Overdue++ })
+		}
+	}
+
+	output.Daily = daily.items
+	output.Weekly = weekly.items
+	output.Monthly = monthly.items
+	output.CurrentStreak, output.LongestStreak = streaks(completedDays, from, to, now)
+	if completionCount > 0 {
+		output.AverageCompletionSeconds = int64(completionTotal.Seconds()) / completionCount
+	}
+	return output
+}
+
+// streaks count the current and longest runs of consecutive days with at least one completed task.
+// The current streak is still alive when nothing has been completed yet today.
+func streaks(completedDays map[string]bool, from, to, now time.Time) (current, longest int) {
+	today := startOfDay(now)
+	last := to
+	if last.After(today) {
+		last = today
+	}
+
+	run := 0
+	for day := from; !day.After(last); day = day.AddDate(0, 0, 1) {
+		if !completedDays[day.Format(dto.StatsDateLayout)] {
+			run = 0
+			continue
+		}
+		run++
+		if run > longest {
+			longest = run
+		}
+	}
+
+	day := last
+	if day.Equal(today) && !completedDays[day.Format(dto.StatsDateLayout)] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for ; !day.Before(from) && completedDays[day.Format(dto.StatsDateLayout)]; day = day.AddDate(0, 0, -1) {
+		current++
+	}
+	return current, longest
+}
+
+type periods struct {
+	items []dto.StatsPeriod
+	index map[string]int
+	start func(t time.Time) time.Time
+}
+
+func newPeriods(from, to time.Time, start, next func(t time.Time) time.Time) periods {
+	p := periods{items: []dto.StatsPeriod{}, index: map[string]int{}, start: start}
+	for t := start(from); !t.After(to); t = next(t) {
+		key := t.Format(dto.StatsDateLayout)
+		p.index[key] = len(p.items)
+		p.items = append(p.items, dto.StatsPeriod{Start: key})
+	}
+	return p
+}
+
+func (p *periods) record(t time.Time, inc func(p *dto.StatsPeriod)) {
+	if i, ok := p.index[p.start(t).Format(dto.StatsDateLayout)]; ok {
+		inc(&p.items[i])
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek return the monday of the week of t.
+func startOfWeek(t time.Time) time.Time {
+	day := startOfDay(t)
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func startOfMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
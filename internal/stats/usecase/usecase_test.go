@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type StatsUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestStatsUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(StatsUsecaseTestSuite))
+}
+
+type dependency struct {
+	taskRepository *mocks.TaskRepository
+}
+
+func at(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
+
+func nullAt(value string) entity.NullTime {
+	return entity.NullTime{NullTime: sql.NullTime{Time: at(value), Valid: true}}
+}
+
+func (s *StatsUsecaseTestSuite) TestGet() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.StatsGetIn
+	}
+	type expected struct {
+		output dto.StatsGetOut
+		err    error
+	}
+	tasks := []entity.Task{
+		{ID: "task-aaaaa", IsCompleted: true, CompletedAt: nullAt("2026-01-05T11:00:00Z"), CreatedAt: at("2026-01-05T09:00:00Z")},
+		{ID: "task-bbbbb", IsCompleted: true, DueDate: nullAt("2026-01-05T23:00:00Z"), CompletedAt: nullAt("2026-01-06T10:00:00Z"), CreatedAt: at("2026-01-05T10:00:00Z")},
+		{ID: "task-ccccc", IsCompleted: false, DueDate: nullAt("2026-01-08T00:00:00Z"), CreatedAt: at("2026-01-07T08:00:00Z")},
+		{ID: "task-ddddd", IsCompleted: true, CompletedAt: nullAt("2026-01-10T00:00:00Z"), CreatedAt: at("2025-12-31T00:00:00Z")},
+		{ID: "task-eeeee", IsCompleted: true, CompletedAt: nullAt("2026-01-11T09:00:00Z"), CreatedAt: at("2026-01-11T08:00:00Z")},
+		{ID: "task-fffff", IsCompleted: false, CreatedAt: at("2026-01-12T08:00:00Z")},
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when from is after to",
+			args:     args{ctx: context.Background(), payload: &dto.StatsGetIn{UserID: "user-xxxxx", From: "2026-01-11", To: "2026-01-05"}},
+			expected: expected{output: dto.StatsGetOut{}, err: domain.ErrStatsRangeInvalid},
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error when range is longer than 366 days",
+			args:     args{ctx: context.Background(), payload: &dto.StatsGetIn{UserID: "user-xxxxx", From: "2025-01-01", To: "2026-01-02"}},
+			expected: expected{output: dto.StatsGetOut{}, err: domain.ErrStatsRangeInvalid},
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error when task repository FindAllByUserID return unexpected error",
+			args:     args{ctx: context.Background(), payload: &dto.StatsGetIn{UserID: "user-xxxxx", From: "2026-01-05", To: "2026-01-11"}},
+			expected: expected{output: dto.StatsGetOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return stats of the range when success",
+			args: args{ctx: context.Background(), payload: &dto.StatsGetIn{UserID: "user-xxxxx", From: "2026-01-05", To: "2026-01-11"}},
+			expected: expected{
+				output: dto.StatsGetOut{
+					From:     "2026-01-05",
+					To:       "2026-01-11",
+					TimeZone: "UTC",
+					Daily: []dto.StatsPeriod{
+						{Start: "2026-01-05", Created: 2, Completed: 1, Overdue: 1},
+						{Start: "2026-01-06", Completed: 1},
+						{Start: "2026-01-07", Created: 1},
+						{Start: "2026-01-08", Overdue: 1},
+						{Start: "2026-01-09"},
+						{Start: "2026-01-10", Completed: 1},
+						{Start: "2026-01-11", Created: 1, Completed: 1},
+					},
+					Weekly:                   []dto.StatsPeriod{{Start: "2026-01-05", Created: 4, Completed: 4, Overdue: 2}},
+					Monthly:                  []dto.StatsPeriod{{Start: "2026-01-01", Created: 4, Completed: 4, Overdue: 2}},
+					CurrentStreak:            2,
+					LongestStreak:            2,
+					OverdueCount:             1,
+					AverageCompletionSeconds: 240300,
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(tasks, nil)
+			},
+		},
+		{
+			name: "it should return stats in the requested time zone when time zone is provided",
+			args: args{ctx: context.Background(), payload: &dto.StatsGetIn{UserID: "user-xxxxx", From: "2026-01-06", To: "2026-01-06", TimeZone: "Asia/Jakarta"}},
+			expected: expected{
+				output: dto.StatsGetOut{
+					From:                     "2026-01-06",
+					To:                       "2026-01-06",
+					TimeZone:                 "Asia/Jakarta",
+					Daily:                    []dto.StatsPeriod{{Start: "2026-01-06", Created: 1, Completed: 1}},
+					Weekly:                   []dto.StatsPeriod{{Start: "2026-01-05", Created: 1, Completed: 1}},
+					Monthly:                  []dto.StatsPeriod{{Start: "2026-01-01", Created: 1, Completed: 1}},
+					CurrentStreak:            1,
+					LongestStreak:            1,
+					AverageCompletionSeconds: 3600,
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return([]entity.Task{
+						{ID: "task-xxxxx", IsCompleted: true, CompletedAt: nullAt("2026-01-05T21:00:00Z"), CreatedAt: at("2026-01-05T20:00:00Z")},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				taskRepository: &mocks.TaskRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.taskRepository)
+			output, err := usecase.Get(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *StatsUsecaseTestSuite) TestGetDefaultRange() {
+	d := &dependency{taskRepository: &mocks.TaskRepository{}}
+	d.taskRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+		Return([]entity.Task{}, nil)
+
+	usecase := New(d.taskRepository)
+	output, err := usecase.Get(context.Background(), &dto.StatsGetIn{UserID: "user-xxxxx"})
+
+	today := time.Now().UTC()
+	s.NoError(err)
+	s.Equal(today.Format(dto.StatsDateLayout), output.To)
+	s.Equal(today.AddDate(0, 0, -29).Format(dto.StatsDateLayout), output.From)
+	s.Len(output.Daily, 30)
+}
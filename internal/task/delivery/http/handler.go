@@ -54,13 +54,24 @@ func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 	encoder.Encode(domain.NewSuccessResponse(http.StatusCreated, "Successfully created new task", output))
 }
 
-// GET /tasks to get all tasks.
+// GET /tasks?q=query&tz=Area/City&include_snoozed=true to get all tasks, optionally filtered by a task query.
 func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	encoder := json.NewEncoder(w)
 
+	query := r.URL.Query()
 	var payload dto.TaskGetAllIn
 	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.Query = query.Get("q")
+	payload.TimeZone = query.Get("tz")
+	payload.IncludeSnoozed = query.Get("include_snoozed") == "true"
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
 
 	output, err := h.taskUsecase.GetAll(r.Context(), &payload)
 	if err != nil {
@@ -147,3 +158,57 @@ func (h *HTTPHandler) Put(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully updated task", output))
 }
+
+// PUT /tasks/{task_id}/snooze?tz=Area/City to hide a task until a preset or an exact time.
+func (h *HTTPHandler) PutSnooze(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.TaskSnoozeIn
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		encoder.Encode(domain.NewErrorResponse(http.StatusBadRequest, "Invalid request body"))
+		return
+	}
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.TaskID = entity.TaskID(chi.URLParam(r, "task_id"))
+	payload.TimeZone = r.URL.Query().Get("tz")
+
+	if err := h.validator.Validate(&payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	output, err := h.taskUsecase.Snooze(r.Context(), &payload)
+	if err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully snoozed task", output))
+}
+
+// DELETE /tasks/{task_id}/snooze to make a snoozed task visible again.
+func (h *HTTPHandler) DeleteSnooze(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	var payload dto.TaskUnsnoozeIn
+	payload.UserID = entity.GetAuthContext(r.Context())
+	payload.TaskID = entity.TaskID(chi.URLParam(r, "task_id"))
+
+	if err := h.taskUsecase.Unsnooze(r.Context(), &payload); err != nil {
+		code, msg := errorx.HTTPErrorTranslator(err)
+		w.WriteHeader(code)
+		encoder.Encode(domain.NewErrorResponse(code, msg))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	encoder.Encode(domain.NewSuccessResponse(http.StatusOK, "Successfully unsnoozed task", nil))
+}
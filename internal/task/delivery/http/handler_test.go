@@ -186,6 +186,22 @@ func (s *TaskHTTPHandlerTestSuite) TestGet() {
 		expected expected
 		setup    func(d *dependency)
 	}{
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Time zone is invalid",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", &dto.TaskGetAllIn{UserID: "user-xxxxx", Query: "is_completed = false", TimeZone: "Asia/Jakarta", IncludeSnoozed: true}).
+					Return(dto.ErrTimeZoneInvalid)
+			},
+		},
 		{
 			name:    "it should response with error when task usecase return unexpected error",
 			isError: true,
@@ -198,7 +214,10 @@ func (s *TaskHTTPHandlerTestSuite) TestGet() {
 			setup: func(d *dependency) {
 				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
 
-				d.taskUsecase.On("GetAll", mock.Anything, &dto.TaskGetAllIn{UserID: "user-xxxxx"}).
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.taskUsecase.On("GetAll", mock.Anything, &dto.TaskGetAllIn{UserID: "user-xxxxx", Query: "is_completed = false", TimeZone: "Asia/Jakarta", IncludeSnoozed: true}).
 					Return(nil, test.ErrUnexpected)
 			},
 		},
@@ -210,17 +229,20 @@ func (s *TaskHTTPHandlerTestSuite) TestGet() {
 				statusCode:  http.StatusOK,
 				message:     http.StatusText(http.StatusOK),
 				payload: []map[string]any{
-					{"id": "task-xxxxx", "content": "task_xxxxx_content", "description": "task_xxxxx_description", "is_completed": false, "due_date": nil, "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
-					{"id": "task-yyyyy", "content": "task_yyyyy_content", "description": "task_yyyyy_description", "is_completed": true, "due_date": test.TimeAfterNow.Format(time.RFC3339Nano), "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
+					{"id": "task-xxxxx", "content": "task_xxxxx_content", "description": "task_xxxxx_description", "is_completed": false, "due_date": nil, "start_date": nil, "completed_at": nil, "checklist": nil, "checklist_progress": map[string]any{"checked": float64(0), "total": float64(0)}, "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
+					{"id": "task-yyyyy", "content": "task_yyyyy_content", "description": "task_yyyyy_description", "is_completed": true, "due_date": test.TimeAfterNow.Format(time.RFC3339Nano), "start_date": nil, "completed_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "checklist": nil, "checklist_progress": map[string]any{"checked": float64(0), "total": float64(0)}, "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
 				},
 			},
 			setup: func(d *dependency) {
 				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
 
-				d.taskUsecase.On("GetAll", mock.Anything, &dto.TaskGetAllIn{UserID: "user-xxxxx"}).
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.taskUsecase.On("GetAll", mock.Anything, &dto.TaskGetAllIn{UserID: "user-xxxxx", Query: "is_completed = false", TimeZone: "Asia/Jakarta", IncludeSnoozed: true}).
 					Return([]dto.TaskGetAllOut{
 						{ID: "task-xxxxx", Content: "task_xxxxx_content", Description: "task_xxxxx_description", IsCompleted: false, DueDate: entity.NullTime{NullTime: sql.NullTime{Valid: false}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
-						{ID: "task-yyyyy", Content: "task_yyyyy_content", Description: "task_yyyyy_description", IsCompleted: true, DueDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+						{ID: "task-yyyyy", Content: "task_yyyyy_content", Description: "task_yyyyy_description", IsCompleted: true, DueDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CompletedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
 					}, nil)
 			},
 		},
@@ -229,15 +251,16 @@ func (s *TaskHTTPHandlerTestSuite) TestGet() {
 	for _, t := range tests {
 		s.Run(t.name, func() {
 			rr := httptest.NewRecorder()
-			req := httptest.NewRequest("GET", "/", nil)
+			req := httptest.NewRequest("GET", "/?q=is_completed+%3D+false&tz=Asia/Jakarta&include_snoozed=true", nil)
 
 			d := &dependency{
 				req:         req,
+				validator:   &mocks.ValidatorProvider{},
 				taskUsecase: &mocks.TaskUsecase{},
 			}
 			t.setup(d)
 
-			handler := New(nil, d.taskUsecase)
+			handler := New(d.validator, d.taskUsecase)
 			handler.Get(rr, d.req)
 
 			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
@@ -409,7 +432,7 @@ func (s *TaskHTTPHandlerTestSuite) TestGetByID() {
 				statusCode:  http.StatusOK,
 				message:     http.StatusText(http.StatusOK),
 				payload: map[string]any{
-					"id": "task-xxxxx", "content": "task_xxxxx_content", "description": "task_xxxxx_description", "is_completed": true, "due_date": test.TimeAfterNow.Format(time.RFC3339Nano), "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano),
+					"id": "task-xxxxx", "content": "task_xxxxx_content", "description": "task_xxxxx_description", "is_completed": true, "due_date": test.TimeAfterNow.Format(time.RFC3339Nano), "start_date": nil, "completed_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "checklist": []any{map[string]any{"id": "item-xxxxx", "text": "Buy milk", "is_checked": true, "position": float64(0)}}, "checklist_progress": map[string]any{"checked": float64(1), "total": float64(1)}, "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano),
 				},
 			},
 			setup: func(d *dependency) {
@@ -422,8 +445,13 @@ func (s *TaskHTTPHandlerTestSuite) TestGetByID() {
 						Description: "task_xxxxx_description",
 						IsCompleted: true,
 						DueDate:     entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
-						CreatedAt:   test.TimeBeforeNow,
-						UpdatedAt:   test.TimeBeforeNow,
+						CompletedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
+						Checklist: []dto.ChecklistItemOut{
+							{ID: "item-xxxxx", Text: "Buy milk", IsChecked: true, Position: 0},
+						},
+						ChecklistProgress: dto.ChecklistProgress{Checked: 1, Total: 1},
+						CreatedAt:         test.TimeBeforeNow,
+						UpdatedAt:         test.TimeBeforeNow,
 					}, nil)
 			},
 		},
@@ -615,3 +643,249 @@ func (s *TaskHTTPHandlerTestSuite) TestPut() {
 		})
 	}
 }
+
+func (s *TaskHTTPHandlerTestSuite) TestPutSnooze() {
+	type args struct {
+		requestBody []byte
+		params      map[string]string
+	}
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     map[string]any
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when request body is invalid or not provided",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:    "it should response with error when payload is not valid",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{"preset":"someday"}`),
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Preset must be one of later_today, tomorrow or next_week",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrSnoozePresetInvalid)
+			},
+		},
+		{
+			name:    "it should response with error when task usecase Snooze return error",
+			isError: true,
+			args: args{
+				requestBody: []byte(`{"preset":"tomorrow"}`),
+				params: map[string]string{
+					"task_id": "task-xxxxx",
+				},
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this task",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.taskUsecase.On("Snooze", mock.Anything, &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Preset: entity.SnoozeTomorrow, TimeZone: "Asia/Jakarta"}).
+					Return(dto.TaskSnoozeOut{}, domain.ErrTaskAuthorization)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			args: args{
+				requestBody: []byte(`{"preset":"tomorrow"}`),
+				params: map[string]string{
+					"task_id": "task-xxxxx",
+				},
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully snoozed task",
+				payload: map[string]any{
+					"id":         "task-xxxxx",
+					"start_date": test.TimeAfterNow.Format(time.RFC3339Nano),
+				},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.taskUsecase.On("Snooze", mock.Anything, &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Preset: entity.SnoozeTomorrow, TimeZone: "Asia/Jakarta"}).
+					Return(dto.TaskSnoozeOut{ID: "task-xxxxx", StartDate: test.TimeAfterNow}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			reqBody := bytes.NewReader(t.args.requestBody)
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/{task_id}/snooze?tz=Asia/Jakarta", reqBody)
+
+			req = test.InjectChiRouterParams(req, t.args.params)
+
+			d := &dependency{
+				req:         req,
+				validator:   &mocks.ValidatorProvider{},
+				taskUsecase: &mocks.TaskUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.taskUsecase)
+			handler.PutSnooze(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+				payloadMap := resBody.Payload.(map[string]any)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, payloadMap)
+			}
+		})
+	}
+}
+
+func (s *TaskHTTPHandlerTestSuite) TestDeleteSnooze() {
+	type args struct {
+		params map[string]string
+	}
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when task usecase Unsnooze return error",
+			isError: true,
+			args: args{
+				params: map[string]string{
+					"task_id": "task-xxxxx",
+				},
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "Task not found",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.taskUsecase.On("Unsnooze", mock.Anything, &dto.TaskUnsnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx"}).
+					Return(domain.ErrTaskNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			args: args{
+				params: map[string]string{
+					"task_id": "task-xxxxx",
+				},
+			},
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully unsnoozed task",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.taskUsecase.On("Unsnooze", mock.Anything, &dto.TaskUnsnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/{task_id}/snooze", nil)
+
+			req = test.InjectChiRouterParams(req, t.args.params)
+
+			d := &dependency{
+				req:         req,
+				taskUsecase: &mocks.TaskUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.taskUsecase)
+			handler.DeleteSnooze(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Nil(resBody.Payload)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/tql"
 )
 
 type Repository struct {
@@ -23,8 +24,8 @@ func New(db *sql.DB, idProvider domain.IDProvider) Repository {
 // Store save a new task.
 func (r *Repository) Store(ctx context.Context, t *entity.Task) (entity.TaskID, error) {
 	id := r.idProvider.Generate()
-	q := `INSERT INTO tasks (id, user_id, content, description, due_date) VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.db.ExecContext(ctx, q, id, t.UserID, t.Content, t.Description, t.DueDate)
+	q := `INSERT INTO tasks (id, user_id, content, description, due_date, start_date) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.ExecContext(ctx, q, id, t.UserID, t.Content, t.Description, t.DueDate, t.StartDate)
 	if err != nil {
 		return "", err
 	}
@@ -34,9 +35,9 @@ func (r *Repository) Store(ctx context.Context, t *entity.Task) (entity.TaskID,
 // FindByID get task by id.
 func (r *Repository) FindByID(ctx context.Context, taskID entity.TaskID) (entity.Task, error) {
 	var task entity.Task
-	q := `SELECT id, user_id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE id = $1`
+	q := `SELECT id, user_id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE id = $1`
 	row := r.db.QueryRowContext(ctx, q, taskID)
-	err := row.Scan(&task.ID, &task.UserID, &task.Content, &task.Description, &task.IsCompleted, &task.DueDate, &task.CreatedAt, &task.UpdatedAt)
+	err := row.Scan(&task.ID, &task.UserID, &task.Content, &task.Description, &task.IsCompleted, &task.DueDate, &task.StartDate, &task.CompletedAt, &task.CreatedAt, &task.UpdatedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return entity.Task{}, domain.ErrTaskNotFound
 	} else if err != nil {
@@ -47,7 +48,7 @@ func (r *Repository) FindByID(ctx context.Context, taskID entity.TaskID) (entity
 
 // FindAllByUserID get all tasks owned by a user by user id.
 func (r *Repository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Task, error) {
-	q := `SELECT id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE user_id = $1`
+	q := `SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1`
 	rows, err := r.db.QueryContext(ctx, q, userID)
 	if err != nil {
 		return nil, err
@@ -57,7 +58,45 @@ func (r *Repository) FindAllByUserID(ctx context.Context, userID entity.UserID)
 	tasks := make([]entity.Task, 0)
 	for rows.Next() {
 		var task entity.Task
-		err := rows.Scan(&task.ID, &task.Content, &task.Description, &task.IsCompleted, &task.DueDate, &task.CreatedAt, &task.UpdatedAt)
+		err := rows.Scan(&task.ID, &task.Content, &task.Description, &task.IsCompleted, &task.DueDate, &task.StartDate, &task.CompletedAt, &task.CreatedAt, &task.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// FindAllByFilter get all tasks owned by a user that match the filter query,
+// tasks starting in the future are left out unless the filter include snoozed tasks.
+func (r *Repository) FindAllByFilter(ctx context.Context, filter entity.TaskFilter) ([]entity.Task, error) {
+	q := `SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1`
+	args := []any{filter.UserID}
+	if !filter.IncludeSnoozed {
+		q += ` AND (start_date IS NULL OR start_date <= $2)`
+		args = append(args, filter.Now.UTC())
+	}
+
+	condition, conditionArgs, err := tql.Compile(filter.Query, tql.Options{Now: filter.Now, Offset: len(args)})
+	if err != nil {
+		return nil, err
+	}
+
+	q += ` AND ` + condition
+	rows, err := r.db.QueryContext(ctx, q, append(args, conditionArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tasks := make([]entity.Task, 0)
+	for rows.Next() {
+		var task entity.Task
+		err := rows.Scan(&task.ID, &task.Content, &task.Description, &task.IsCompleted, &task.DueDate, &task.StartDate, &task.CompletedAt, &task.CreatedAt, &task.UpdatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -97,10 +136,35 @@ func (r *Repository) DeleteByID(ctx context.Context, taskID entity.TaskID) error
 // Update update task by id.
 func (r *Repository) Update(ctx context.Context, t *entity.Task) (entity.TaskID, error) {
 	t.UpdatedAt = time.Now()
-	q := `UPDATE tasks SET content = $2, description = $3, is_completed = $4, due_date = $5, updated_at = $6 WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, q, t.ID, t.Content, t.Description, t.IsCompleted, t.DueDate, t.UpdatedAt)
+	q := `UPDATE tasks SET content = $2, description = $3, is_completed = $4, due_date = $5, start_date = $6, completed_at = $7, updated_at = $8 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, t.ID, t.Content, t.Description, t.IsCompleted, t.DueDate, t.StartDate, t.CompletedAt, t.UpdatedAt)
 	if err != nil {
 		return "", err
 	}
 	return t.ID, nil
 }
+
+// StoreMany save multiple tasks in a single transaction.
+func (r *Repository) StoreMany(ctx context.Context, tasks []entity.Task) ([]entity.TaskID, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	q := `INSERT INTO tasks (id, user_id, content, description, due_date) VALUES ($1, $2, $3, $4, $5)`
+	ids := make([]entity.TaskID, len(tasks))
+	for i, t := range tasks {
+		id := r.idProvider.Generate()
+		_, err := tx.ExecContext(ctx, q, id, t.UserID, t.Content, t.Description, t.DueDate)
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = entity.TaskID(id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
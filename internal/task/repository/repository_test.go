@@ -6,6 +6,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/suite"
@@ -61,8 +62,8 @@ func (s *TaskRepositoryTestSuite) TestStore() {
 			},
 			setup: func(d *dependency) {
 				d.idProvider.On("Generate").Return("task-xxxxx")
-				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date)`)).
-					WithArgs("task-xxxxx", "user-xxxxx", "task_content", "task_description", &test.TimeAfterNow).
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date, start_date)`)).
+					WithArgs("task-xxxxx", "user-xxxxx", "task_content", "task_description", &test.TimeAfterNow, nil).
 					WillReturnError(test.ErrDatabase)
 			},
 		},
@@ -75,6 +76,7 @@ func (s *TaskRepositoryTestSuite) TestStore() {
 					Content:     "task_content",
 					Description: "task_description",
 					DueDate:     entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
+					StartDate:   entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
 				},
 			},
 			expected: expected{
@@ -83,8 +85,8 @@ func (s *TaskRepositoryTestSuite) TestStore() {
 			},
 			setup: func(d *dependency) {
 				d.idProvider.On("Generate").Return("task-xxxxx")
-				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date)`)).
-					WithArgs("task-xxxxx", "user-xxxxx", "task_content", "task_description", &test.TimeAfterNow).
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date, start_date)`)).
+					WithArgs("task-xxxxx", "user-xxxxx", "task_content", "task_description", &test.TimeAfterNow, &test.TimeAfterNow).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
@@ -138,7 +140,7 @@ func (s *TaskRepositoryTestSuite) TestFindByID() {
 				err:  test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE id = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE id = $1")).
 					WithArgs("task-xxxxx").
 					WillReturnError(test.ErrDatabase)
 			},
@@ -154,7 +156,7 @@ func (s *TaskRepositoryTestSuite) TestFindByID() {
 				err:  domain.ErrTaskNotFound,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE id = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE id = $1")).
 					WithArgs("task-xxxxx").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -170,7 +172,7 @@ func (s *TaskRepositoryTestSuite) TestFindByID() {
 				err:  test.ErrRowScan,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE id = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE id = $1")).
 					WithArgs("task-xxxxx").
 					WillReturnError(test.ErrRowScan)
 			},
@@ -194,16 +196,22 @@ func (s *TaskRepositoryTestSuite) TestFindByID() {
 							Valid: true,
 						},
 					},
+					CompletedAt: entity.NullTime{
+						NullTime: sql.NullTime{
+							Time:  test.TimeBeforeNow,
+							Valid: true,
+						},
+					},
 					CreatedAt: test.TimeBeforeNow,
 					UpdatedAt: test.TimeBeforeNow,
 				},
 				err: nil,
 			},
 			setup: func(d *dependency) {
-				mockRow := sqlmock.NewRows([]string{"id", "user_id", "content", "description", "is_completed", "due_date", "created_at", "updated_at"}).
-					AddRow("task-xxxxx", "user-xxxxx", "task_content", "task_description", true, test.TimeAfterNow, test.TimeBeforeNow, test.TimeBeforeNow)
+				mockRow := sqlmock.NewRows([]string{"id", "user_id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"}).
+					AddRow("task-xxxxx", "user-xxxxx", "task_content", "task_description", true, test.TimeAfterNow, nil, test.TimeBeforeNow, test.TimeBeforeNow, test.TimeBeforeNow)
 
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE id = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, user_id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE id = $1")).
 					WithArgs("task-xxxxx").
 					WillReturnRows(mockRow)
 			},
@@ -256,7 +264,7 @@ func (s *TaskRepositoryTestSuite) TestFindAllByUserID() {
 				err:   test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE user_id = $1`)).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1`)).
 					WithArgs("user-xxxxx").
 					WillReturnError(test.ErrDatabase)
 			},
@@ -273,10 +281,10 @@ func (s *TaskRepositoryTestSuite) TestFindAllByUserID() {
 				err:           errors.New("anything"),
 			},
 			setup: func(d *dependency) {
-				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "created_at", "updated_at"}).
-					AddRow(nil, "task_xxxxx_content", "task_yyyyy_description", false, nil, test.TimeBeforeNow, test.TimeBeforeNow)
+				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"}).
+					AddRow(nil, "task_xxxxx_content", "task_yyyyy_description", false, nil, nil, nil, test.TimeBeforeNow, test.TimeBeforeNow)
 
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE user_id = $1`)).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1`)).
 					WithArgs("user-xxxxx").
 					WillReturnRows(mockRow)
 			},
@@ -292,12 +300,12 @@ func (s *TaskRepositoryTestSuite) TestFindAllByUserID() {
 				err:   test.ErrRows,
 			},
 			setup: func(d *dependency) {
-				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "created_at", "updated_at"}).
-					AddRow("task-xxxxx", "task_xxxxx_content", "task_yyyyy_description", false, nil, test.TimeBeforeNow, test.TimeBeforeNow).
-					AddRow("task-yyyyy", "task_yyyyy_content", "task_yyyyy_description", true, test.TimeAfterNow, test.TimeBeforeNow, test.TimeBeforeNow).
+				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"}).
+					AddRow("task-xxxxx", "task_xxxxx_content", "task_yyyyy_description", false, nil, nil, nil, test.TimeBeforeNow, test.TimeBeforeNow).
+					AddRow("task-yyyyy", "task_yyyyy_content", "task_yyyyy_description", true, test.TimeAfterNow, nil, nil, test.TimeBeforeNow, test.TimeBeforeNow).
 					RowError(1, test.ErrRows)
 
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE user_id = $1`)).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1`)).
 					WithArgs("user-xxxxx").
 					WillReturnRows(mockRow)
 			},
@@ -313,8 +321,8 @@ func (s *TaskRepositoryTestSuite) TestFindAllByUserID() {
 				err:   nil,
 			},
 			setup: func(d *dependency) {
-				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "created_at", "updated_at"})
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE user_id = $1`)).
+				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"})
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1`)).
 					WithArgs("user-xxxxx").
 					WillReturnRows(mockRow)
 			},
@@ -342,17 +350,18 @@ func (s *TaskRepositoryTestSuite) TestFindAllByUserID() {
 						Description: "task_yyyyy_description",
 						IsCompleted: true,
 						DueDate:     entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
+						CompletedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
 						CreatedAt:   test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow,
 					},
 				},
 				err: nil,
 			},
 			setup: func(d *dependency) {
-				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "created_at", "updated_at"}).
-					AddRow("task-xxxxx", "task_xxxxx_content", "task_xxxxx_description", false, nil, test.TimeBeforeNow, test.TimeBeforeNow).
-					AddRow("task-yyyyy", "task_yyyyy_content", "task_yyyyy_description", true, test.TimeAfterNow, test.TimeBeforeNow, test.TimeBeforeNow)
+				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"}).
+					AddRow("task-xxxxx", "task_xxxxx_content", "task_xxxxx_description", false, nil, nil, nil, test.TimeBeforeNow, test.TimeBeforeNow).
+					AddRow("task-yyyyy", "task_yyyyy_content", "task_yyyyy_description", true, test.TimeAfterNow, nil, test.TimeBeforeNow, test.TimeBeforeNow, test.TimeBeforeNow)
 
-				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, created_at, updated_at FROM tasks WHERE user_id = $1`)).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1`)).
 					WithArgs("user-xxxxx").
 					WillReturnRows(mockRow)
 			},
@@ -384,6 +393,180 @@ func (s *TaskRepositoryTestSuite) TestFindAllByUserID() {
 	}
 }
 
+func (s *TaskRepositoryTestSuite) TestFindAllByFilter() {
+	type args struct {
+		ctx    context.Context
+		filter entity.TaskFilter
+	}
+	type expected struct {
+		tasks         []entity.Task
+		allowAnyError bool
+		err           error
+	}
+	now := time.Date(2026, 1, 7, 10, 30, 0, 0, time.UTC)
+	today := time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC)
+	filter := entity.TaskFilter{UserID: "user-xxxxx", Query: `due_date < today and "invoice"`, Now: now}
+	query := `SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1 AND (start_date IS NULL OR start_date <= $2) AND ((due_date < $3) AND (content ILIKE $4 OR description ILIKE $4))`
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name: "it should return error when query is invalid",
+			args: args{
+				ctx:    context.Background(),
+				filter: entity.TaskFilter{UserID: "user-xxxxx", Query: "due_date <", Now: now},
+			},
+			expected: expected{
+				tasks:         nil,
+				allowAnyError: true,
+				err:           errors.New("anything"),
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name: "it should return error when database fail to query",
+			args: args{
+				ctx:    context.Background(),
+				filter: filter,
+			},
+			expected: expected{
+				tasks: nil,
+				err:   test.ErrDatabase,
+			},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("user-xxxxx", now, today, "%invoice%").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name: "it should return error when database rows fail to scan",
+			args: args{
+				ctx:    context.Background(),
+				filter: filter,
+			},
+			expected: expected{
+				tasks:         nil,
+				allowAnyError: true,
+				err:           errors.New("anything"),
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"}).
+					AddRow(nil, "task_xxxxx_content", "task_xxxxx_description", false, nil, nil, nil, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("user-xxxxx", now, today, "%invoice%").
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name: "it should return error when database rows error",
+			args: args{
+				ctx:    context.Background(),
+				filter: filter,
+			},
+			expected: expected{
+				tasks: nil,
+				err:   test.ErrRows,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"}).
+					AddRow("task-xxxxx", "task_xxxxx_content", "task_xxxxx_description", false, nil, nil, nil, test.TimeBeforeNow, test.TimeBeforeNow).
+					RowError(0, test.ErrRows)
+
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("user-xxxxx", now, today, "%invoice%").
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name: "it should return error nil and matching tasks when successfully query",
+			args: args{
+				ctx:    context.Background(),
+				filter: filter,
+			},
+			expected: expected{
+				tasks: []entity.Task{
+					{
+						ID:          "task-xxxxx",
+						Content:     "task_xxxxx_content",
+						Description: "send invoice",
+						IsCompleted: false,
+						DueDate:     entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
+						CreatedAt:   test.TimeBeforeNow,
+						UpdatedAt:   test.TimeBeforeNow,
+					},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"}).
+					AddRow("task-xxxxx", "task_xxxxx_content", "send invoice", false, test.TimeBeforeNow, nil, nil, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("user-xxxxx", now, today, "%invoice%").
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name: "it should return error nil and snoozed tasks when filter include snoozed tasks",
+			args: args{
+				ctx:    context.Background(),
+				filter: entity.TaskFilter{UserID: "user-xxxxx", Query: "start_date > now", Now: now, IncludeSnoozed: true},
+			},
+			expected: expected{
+				tasks: []entity.Task{
+					{
+						ID:          "task-xxxxx",
+						Content:     "task_xxxxx_content",
+						Description: "task_xxxxx_description",
+						StartDate:   entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
+						CreatedAt:   test.TimeBeforeNow,
+						UpdatedAt:   test.TimeBeforeNow,
+					},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "content", "description", "is_completed", "due_date", "start_date", "completed_at", "created_at", "updated_at"}).
+					AddRow("task-xxxxx", "task_xxxxx_content", "task_xxxxx_description", false, nil, test.TimeAfterNow, nil, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT id, content, description, is_completed, due_date, start_date, completed_at, created_at, updated_at FROM tasks WHERE user_id = $1 AND (start_date > $2)`)).
+					WithArgs("user-xxxxx", now).
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			tasks, err := repository.FindAllByFilter(t.args.ctx, t.args.filter)
+
+			if t.expected.allowAnyError {
+				s.Error(err)
+			} else {
+				s.Equal(t.expected.err, err)
+			}
+			s.Equal(t.expected.tasks, tasks)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
 func (s *TaskRepositoryTestSuite) TestVerifyAvailableByID() {
 	type args struct {
 		ctx    context.Context
@@ -573,8 +756,8 @@ func (s *TaskRepositoryTestSuite) TestUpdate() {
 				err:    test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE tasks SET content = $2, description = $3, is_completed = $4, due_date = $5, updated_at = $6 WHERE id = $1")).
-					WithArgs("", "", "", false, entity.NullTime{NullTime: sql.NullTime{Valid: false}}, sqlmock.AnyArg()).
+				d.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE tasks SET content = $2, description = $3, is_completed = $4, due_date = $5, start_date = $6, completed_at = $7, updated_at = $8 WHERE id = $1")).
+					WithArgs("", "", "", false, entity.NullTime{NullTime: sql.NullTime{Valid: false}}, entity.NullTime{NullTime: sql.NullTime{Valid: false}}, entity.NullTime{NullTime: sql.NullTime{Valid: false}}, sqlmock.AnyArg()).
 					WillReturnError(test.ErrDatabase)
 			},
 		},
@@ -589,6 +772,7 @@ func (s *TaskRepositoryTestSuite) TestUpdate() {
 					Description: "task_description",
 					IsCompleted: true,
 					DueDate:     entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
+					CompletedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
 					CreatedAt:   test.TimeBeforeNow,
 					UpdatedAt:   test.TimeBeforeNow,
 				},
@@ -598,8 +782,8 @@ func (s *TaskRepositoryTestSuite) TestUpdate() {
 				err:    nil,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE tasks SET content = $2, description = $3, is_completed = $4, due_date = $5, updated_at = $6 WHERE id = $1")).
-					WithArgs("task-xxxxx", "task_content", "task_description", true, entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, sqlmock.AnyArg()).
+				d.mockDB.ExpectExec(regexp.QuoteMeta("UPDATE tasks SET content = $2, description = $3, is_completed = $4, due_date = $5, start_date = $6, completed_at = $7, updated_at = $8 WHERE id = $1")).
+					WithArgs("task-xxxxx", "task_content", "task_description", true, entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, entity.NullTime{NullTime: sql.NullTime{Valid: false}}, entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, sqlmock.AnyArg()).
 					WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
@@ -625,3 +809,131 @@ func (s *TaskRepositoryTestSuite) TestUpdate() {
 		})
 	}
 }
+
+func (s *TaskRepositoryTestSuite) TestStoreMany() {
+	type args struct {
+		ctx   context.Context
+		tasks []entity.Task
+	}
+	type expected struct {
+		taskIDs []entity.TaskID
+		err     error
+	}
+	tasks := []entity.Task{
+		{UserID: "user-xxxxx", Content: "task_xxxxx_content", Description: "task_xxxxx_description", DueDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}},
+		{UserID: "user-xxxxx", Content: "task_yyyyy_content", Description: "task_yyyyy_description"},
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name: "it should return error when database fail to begin transaction",
+			args: args{
+				ctx:   context.Background(),
+				tasks: tasks,
+			},
+			expected: expected{
+				taskIDs: nil,
+				err:     test.ErrDatabase,
+			},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name: "it should return error and rollback when database fail to store a task",
+			args: args{
+				ctx:   context.Background(),
+				tasks: tasks,
+			},
+			expected: expected{
+				taskIDs: nil,
+				err:     test.ErrDatabase,
+			},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("task-xxxxx").Once()
+				d.idProvider.On("Generate").Return("task-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date)`)).
+					WithArgs("task-xxxxx", "user-xxxxx", "task_xxxxx_content", "task_xxxxx_description", &test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date)`)).
+					WithArgs("task-yyyyy", "user-xxxxx", "task_yyyyy_content", "task_yyyyy_description", nil).
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name: "it should return error when database fail to commit transaction",
+			args: args{
+				ctx:   context.Background(),
+				tasks: tasks,
+			},
+			expected: expected{
+				taskIDs: nil,
+				err:     test.ErrDatabase,
+			},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("task-xxxxx").Once()
+				d.idProvider.On("Generate").Return("task-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date)`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date)`)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name: "it should return error nil and task ids when successfully store all tasks",
+			args: args{
+				ctx:   context.Background(),
+				tasks: tasks,
+			},
+			expected: expected{
+				taskIDs: []entity.TaskID{"task-xxxxx", "task-yyyyy"},
+				err:     nil,
+			},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("task-xxxxx").Once()
+				d.idProvider.On("Generate").Return("task-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date)`)).
+					WithArgs("task-xxxxx", "user-xxxxx", "task_xxxxx_content", "task_xxxxx_description", &test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`INSERT INTO tasks (id, user_id, content, description, due_date)`)).
+					WithArgs("task-yyyyy", "user-xxxxx", "task_yyyyy_content", "task_yyyyy_description", nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			taskIDs, err := repository.StoreMany(t.args.ctx, t.args.tasks)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.taskIDs, taskIDs)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,24 +2,28 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/tql"
 )
 
 type Usecase struct {
-	taskRepository domain.TaskRepository
+	taskRepository      domain.TaskRepository
+	checklistRepository domain.ChecklistRepository
 }
 
 // New create a new usecase.
-func New(taskRepository domain.TaskRepository) Usecase {
-	return Usecase{taskRepository: taskRepository}
+func New(taskRepository domain.TaskRepository, checklistRepository domain.ChecklistRepository) Usecase {
+	return Usecase{taskRepository: taskRepository, checklistRepository: checklistRepository}
 }
 
 // Create create a new task.
 func (u *Usecase) Create(ctx context.Context, payload *dto.TaskCreateIn) (dto.TaskCreateOut, error) {
-	task := &entity.Task{UserID: payload.UserID, Content: payload.Content, Description: payload.Description, DueDate: payload.DueDate}
+	task := &entity.Task{UserID: payload.UserID, Content: payload.Content, Description: payload.Description, DueDate: payload.DueDate, StartDate: payload.StartDate}
 
 	taskID, err := u.taskRepository.Store(ctx, task)
 	if err != nil {
@@ -28,23 +32,48 @@ func (u *Usecase) Create(ctx context.Context, payload *dto.TaskCreateIn) (dto.Ta
 	return dto.TaskCreateOut{ID: taskID}, nil
 }
 
-// GetAll get all tasks.
+// GetAll get all tasks, only tasks matching the query are returned when a query is provided.
+// Relative dates of the query are resolved in the given time zone, UTC by default.
+// Snoozed tasks are left out unless they are explicitly included.
 func (u *Usecase) GetAll(ctx context.Context, payload *dto.TaskGetAllIn) ([]dto.TaskGetAllOut, error) {
-	tasks, err := u.taskRepository.FindAllByUserID(ctx, payload.UserID)
+	if _, err := tql.Parse(payload.Query); err != nil {
+		return nil, err
+	}
+	location := time.UTC
+	if payload.TimeZone != "" {
+		location, _ = time.LoadLocation(payload.TimeZone)
+	}
+
+	filter := entity.TaskFilter{
+		UserID:         payload.UserID,
+		Query:          payload.Query,
+		Now:            time.Now().In(location),
+		IncludeSnoozed: payload.IncludeSnoozed,
+	}
+	tasks, err := u.taskRepository.FindAllByFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	checklists, err := u.findChecklists(ctx, tasks)
 	if err != nil {
 		return nil, err
 	}
 
 	output := make([]dto.TaskGetAllOut, len(tasks))
 	for i, task := range tasks {
+		checklist, progress := dto.NewChecklistOut(checklists[task.ID])
 		output[i] = dto.TaskGetAllOut{
-			ID:          task.ID,
-			Content:     task.Content,
-			Description: task.Description,
-			IsCompleted: task.IsCompleted,
-			DueDate:     task.DueDate,
-			CreatedAt:   task.CreatedAt,
-			UpdatedAt:   task.UpdatedAt,
+			ID:                task.ID,
+			Content:           task.Content,
+			Description:       task.Description,
+			IsCompleted:       task.IsCompleted,
+			DueDate:           task.DueDate,
+			StartDate:         task.StartDate,
+			CompletedAt:       task.CompletedAt,
+			Checklist:         checklist,
+			ChecklistProgress: progress,
+			CreatedAt:         task.CreatedAt,
+			UpdatedAt:         task.UpdatedAt,
 		}
 	}
 	return output, nil
@@ -74,15 +103,24 @@ func (u *Usecase) GetByID(ctx context.Context, payload *dto.TaskGetByIDIn) (dto.
 	if task.UserID != payload.UserID {
 		return dto.TaskGetByIDOut{}, domain.ErrTaskAuthorization
 	}
+	items, err := u.checklistRepository.FindAllByTaskIDs(ctx, []entity.TaskID{task.ID})
+	if err != nil {
+		return dto.TaskGetByIDOut{}, err
+	}
 
+	checklist, progress := dto.NewChecklistOut(items)
 	output := dto.TaskGetByIDOut{
-		ID:          task.ID,
-		Content:     task.Content,
-		Description: task.Description,
-		IsCompleted: task.IsCompleted,
-		DueDate:     task.DueDate,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
+		ID:                task.ID,
+		Content:           task.Content,
+		Description:       task.Description,
+		IsCompleted:       task.IsCompleted,
+		DueDate:           task.DueDate,
+		StartDate:         task.StartDate,
+		CompletedAt:       task.CompletedAt,
+		Checklist:         checklist,
+		ChecklistProgress: progress,
+		CreatedAt:         task.CreatedAt,
+		UpdatedAt:         task.UpdatedAt,
 	}
 	return output, nil
 }
@@ -98,8 +136,9 @@ func (u *Usecase) Update(ctx context.Context, payload *dto.TaskUpdateIn) (dto.Ta
 
 	task.Content = payload.Content
 	task.Description = payload.Description
-	task.IsCompleted = payload.IsCompleted
+	task.SetCompleted(payload.IsCompleted, time.Now())
 	task.DueDate = payload.DueDate
+	task.StartDate = payload.StartDate
 
 	taskID, err := u.taskRepository.Update(ctx, &task)
 	if err != nil {
@@ -107,3 +146,75 @@ func (u *Usecase) Update(ctx context.Context, payload *dto.TaskUpdateIn) (dto.Ta
 	}
 	return dto.TaskUpdateOut{ID: taskID}, nil
 }
+
+// Snooze hide a task until the given time or preset, presets are resolved in the given time zone, UTC by default.
+// The start date is stored in UTC, as the column keeps no time zone and filters compare it in UTC.
+func (u *Usecase) Snooze(ctx context.Context, payload *dto.TaskSnoozeIn) (dto.TaskSnoozeOut, error) {
+	location := time.UTC
+	if payload.TimeZone != "" {
+		location, _ = time.LoadLocation(payload.TimeZone)
+	}
+	now := time.Now().In(location)
+
+	until := payload.Until.Time
+	if payload.Preset != "" {
+		until = payload.Preset.Resolve(now)
+	}
+	if !until.After(now) {
+		return dto.TaskSnoozeOut{}, domain.ErrTaskSnoozeInPast
+	}
+	until = until.UTC()
+
+	task, err := u.taskRepository.FindByID(ctx, payload.TaskID)
+	if err != nil {
+		return dto.TaskSnoozeOut{}, err
+	}
+	if task.UserID != payload.UserID {
+		return dto.TaskSnoozeOut{}, domain.ErrTaskAuthorization
+	}
+
+	task.StartDate = entity.NullTime{NullTime: sql.NullTime{Time: until, Valid: true}}
+	taskID, err := u.taskRepository.Update(ctx, &task)
+	if err != nil {
+		return dto.TaskSnoozeOut{}, err
+	}
+	return dto.TaskSnoozeOut{ID: taskID, StartDate: until}, nil
+}
+
+// Unsnooze make a snoozed task visible again right away.
+func (u *Usecase) Unsnooze(ctx context.Context, payload *dto.TaskUnsnoozeIn) error {
+	task, err := u.taskRepository.FindByID(ctx, payload.TaskID)
+	if err != nil {
+		return err
+	}
+	if task.UserID != payload.UserID {
+		return domain.ErrTaskAuthorization
+	}
+
+	task.StartDate = entity.NullTime{}
+	if _, err := u.taskRepository.Update(ctx, &task); err != nil {
+		return err
+	}
+	return nil
+}
+
+// findChecklists get the checklist items of all tasks in a single query, grouped by task id.
+func (u *Usecase) findChecklists(ctx context.Context, tasks []entity.Task) (map[entity.TaskID][]entity.ChecklistItem, error) {
+	checklists := make(map[entity.TaskID][]entity.ChecklistItem, len(tasks))
+	if len(tasks) == 0 {
+		return checklists, nil
+	}
+
+	taskIDs := make([]entity.TaskID, len(tasks))
+	for i, task := range tasks {
+		taskIDs[i] = task.ID
+	}
+	items, err := u.checklistRepository.FindAllByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		checklists[item.TaskID] = append(checklists[item.TaskID], item)
+	}
+	return checklists, nil
+}
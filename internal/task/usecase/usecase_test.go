@@ -4,13 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/tql"
 	"github.com/edwintantawi/taskit/test"
 )
 
@@ -23,7 +26,8 @@ func TestTaskUsecaseSuite(t *testing.T) {
 }
 
 type dependency struct {
-	taskRepository *mocks.TaskRepository
+	taskRepository      *mocks.TaskRepository
+	checklistRepository *mocks.ChecklistRepository
 }
 
 func (s *TaskUsecaseTestSuite) TestCreate() {
@@ -94,11 +98,12 @@ func (s *TaskUsecaseTestSuite) TestCreate() {
 	for _, t := range tests {
 		s.Run(t.name, func() {
 			d := &dependency{
-				taskRepository: &mocks.TaskRepository{},
+				taskRepository:      &mocks.TaskRepository{},
+				checklistRepository: &mocks.ChecklistRepository{},
 			}
 			t.setup(d)
 
-			usecase := New(d.taskRepository)
+			usecase := New(d.taskRepository, d.checklistRepository)
 			output, err := usecase.Create(t.args.ctx, t.args.payload)
 
 			s.Equal(t.expected.err, err)
@@ -133,7 +138,76 @@ func (s *TaskUsecaseTestSuite) TestGetAll() {
 				err:    test.ErrUnexpected,
 			},
 			setup: func(d *dependency) {
-				d.taskRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.AnythingOfType("entity.TaskFilter")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error when query is invalid",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskGetAllIn{UserID: "user-xxxxx", Query: "due_date <"},
+			},
+			expected: expected{
+				output: nil,
+				err:    &tql.Error{Pos: 10, Msg: "expected a date but found end of query"},
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name: "it should return error when task respository FindAllByFilter return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskGetAllIn{UserID: "user-xxxxx", Query: "is_completed = false"},
+			},
+			expected: expected{
+				output: nil,
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.AnythingOfType("entity.TaskFilter")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and matching tasks when query is provided",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskGetAllIn{UserID: "user-xxxxx", Query: "is_completed = false", TimeZone: "Asia/Jakarta"},
+			},
+			expected: expected{
+				output: []dto.TaskGetAllOut{
+					{ID: "task-xxxxx", Content: "task_xxxxx_content", Description: "task_xxxxx_description", IsCompleted: false, Checklist: []dto.ChecklistItemOut{}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				},
+			},
+			setup: func(d *dependency) {
+				tasks := []entity.Task{
+					{ID: "task-xxxxx", Content: "task_xxxxx_content", Description: "task_xxxxx_description", IsCompleted: false, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				}
+
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.MatchedBy(func(filter entity.TaskFilter) bool {
+					return filter.UserID == "user-xxxxx" && filter.Query == "is_completed = false" && filter.Now.Location().String() == "Asia/Jakarta"
+				})).Return(tasks, nil)
+
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return([]entity.ChecklistItem{}, nil)
+			},
+		},
+		{
+			name: "it should return error when checklist repository FindAllByTaskIDs return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskGetAllIn{UserID: "user-xxxxx"},
+			},
+			expected: expected{
+				output: nil,
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.AnythingOfType("entity.TaskFilter")).
+					Return([]entity.Task{{ID: "task-xxxxx"}}, nil)
+
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
 					Return(nil, test.ErrUnexpected)
 			},
 		},
@@ -145,18 +219,49 @@ func (s *TaskUsecaseTestSuite) TestGetAll() {
 			},
 			expected: expected{
 				output: []dto.TaskGetAllOut{
-					{ID: "task-xxxxx", Content: "task_xxxxx_content", Description: "task_xxxxx_description", IsCompleted: false, DueDate: entity.NullTime{NullTime: sql.NullTime{Valid: false}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
-					{ID: "task-yyyyy", Content: "task_yyyyy_content", Description: "task_yyyyy_description", IsCompleted: true, DueDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					{ID: "task-xxxxx", Content: "task_xxxxx_content", Description: "task_xxxxx_description", IsCompleted: false, DueDate: entity.NullTime{NullTime: sql.NullTime{Valid: false}}, Checklist: []dto.ChecklistItemOut{{ID: "item-xxxxx", Text: "Buy milk", IsChecked: true, Position: 0}, {ID: "item-yyyyy", Text: "Buy eggs", IsChecked: false, Position: 1}}, ChecklistProgress: dto.ChecklistProgress{Checked: 1, Total: 2}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					{ID: "task-yyyyy", Content: "task_yyyyy_content", Description: "task_yyyyy_description", IsCompleted: true, DueDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CompletedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, Checklist: []dto.ChecklistItemOut{}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
 				},
 			},
 			setup: func(d *dependency) {
 				tasks := []entity.Task{
 					{ID: "task-xxxxx", Content: "task_xxxxx_content", Description: "task_xxxxx_description", IsCompleted: false, DueDate: entity.NullTime{NullTime: sql.NullTime{Valid: false}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
-					{ID: "task-yyyyy", Content: "task_yyyyy_content", Description: "task_yyyyy_description", IsCompleted: true, DueDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					{ID: "task-yyyyy", Content: "task_yyyyy_content", Description: "task_yyyyy_description", IsCompleted: true, DueDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CompletedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				}
+
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.MatchedBy(func(filter entity.TaskFilter) bool {
+					return filter.UserID == "user-xxxxx" && filter.Query == "" && filter.Now.Location() == time.UTC && !filter.IncludeSnoozed
+				})).Return(tasks, nil)
+
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx", "task-yyyyy"}).
+					Return([]entity.ChecklistItem{
+						{ID: "item-xxxxx", TaskID: "task-xxxxx", Text: "Buy milk", IsChecked: true, Position: 0},
+						{ID: "item-yyyyy", TaskID: "task-xxxxx", Text: "Buy eggs", IsChecked: false, Position: 1},
+					}, nil)
+			},
+		},
+		{
+			name: "it should return error nil and snoozed tasks when snoozed tasks are included",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskGetAllIn{UserID: "user-xxxxx", IncludeSnoozed: true},
+			},
+			expected: expected{
+				output: []dto.TaskGetAllOut{
+					{ID: "task-xxxxx", Content: "task_xxxxx_content", StartDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, Checklist: []dto.ChecklistItemOut{}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				},
+			},
+			setup: func(d *dependency) {
+				tasks := []entity.Task{
+					{ID: "task-xxxxx", Content: "task_xxxxx_content", StartDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}, CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
 				}
 
-				d.taskRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
-					Return(tasks, nil)
+				d.taskRepository.On("FindAllByFilter", context.Background(), mock.MatchedBy(func(filter entity.TaskFilter) bool {
+					return filter.UserID == "user-xxxxx" && filter.IncludeSnoozed
+				})).Return(tasks, nil)
+
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return([]entity.ChecklistItem{}, nil)
 			},
 		},
 	}
@@ -164,11 +269,12 @@ func (s *TaskUsecaseTestSuite) TestGetAll() {
 	for _, t := range tests {
 		s.Run(t.name, func() {
 			d := &dependency{
-				taskRepository: &mocks.TaskRepository{},
+				taskRepository:      &mocks.TaskRepository{},
+				checklistRepository: &mocks.ChecklistRepository{},
 			}
 			t.setup(d)
 
-			usecase := New(d.taskRepository)
+			usecase := New(d.taskRepository, d.checklistRepository)
 			output, err := usecase.GetAll(t.args.ctx, t.args.payload)
 
 			s.Equal(t.expected.err, err)
@@ -269,11 +375,12 @@ func (s *TaskUsecaseTestSuite) TestRemove() {
 
 	for _, t := range tests {
 		d := &dependency{
-			taskRepository: &mocks.TaskRepository{},
+			taskRepository:      &mocks.TaskRepository{},
+			checklistRepository: &mocks.ChecklistRepository{},
 		}
 		t.setup(d)
 
-		usecase := New(d.taskRepository)
+		usecase := New(d.taskRepository, d.checklistRepository)
 		err := usecase.Remove(t.args.ctx, t.args.payload)
 
 		s.Equal(t.expected.err, err)
@@ -328,6 +435,27 @@ func (s *TaskUsecaseTestSuite) TestGetByID() {
 					Return(entity.Task{UserID: "user-yyyyy"}, nil)
 			},
 		},
+		{
+			name: "it should return error when checklist repository FindAllByTaskIDs return unexpected error",
+			args: args{
+				ctx: context.Background(),
+				payload: &dto.TaskGetByIDIn{
+					TaskID: "task-xxxxx",
+					UserID: "user-xxxxx",
+				},
+			},
+			expected: expected{
+				output: dto.TaskGetByIDOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
 		{
 			name: "it should return error nil when success get task",
 			args: args{
@@ -344,8 +472,12 @@ func (s *TaskUsecaseTestSuite) TestGetByID() {
 					Description: "task_description",
 					IsCompleted: true,
 					DueDate:     entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
-					CreatedAt:   test.TimeBeforeNow,
-					UpdatedAt:   test.TimeBeforeNow,
+					Checklist: []dto.ChecklistItemOut{
+						{ID: "item-xxxxx", Text: "Buy milk", IsChecked: true, Position: 0},
+					},
+					ChecklistProgress: dto.ChecklistProgress{Checked: 1, Total: 1},
+					CreatedAt:         test.TimeBeforeNow,
+					UpdatedAt:         test.TimeBeforeNow,
 				},
 				err: nil,
 			},
@@ -361,17 +493,23 @@ func (s *TaskUsecaseTestSuite) TestGetByID() {
 						CreatedAt:   test.TimeBeforeNow,
 						UpdatedAt:   test.TimeBeforeNow,
 					}, nil)
+
+				d.checklistRepository.On("FindAllByTaskIDs", context.Background(), []entity.TaskID{"task-xxxxx"}).
+					Return([]entity.ChecklistItem{
+						{ID: "item-xxxxx", TaskID: "task-xxxxx", Text: "Buy milk", IsChecked: true, Position: 0},
+					}, nil)
 			},
 		},
 	}
 
 	for _, t := range tests {
 		d := &dependency{
-			taskRepository: &mocks.TaskRepository{},
+			taskRepository:      &mocks.TaskRepository{},
+			checklistRepository: &mocks.ChecklistRepository{},
 		}
 		t.setup(d)
 
-		usecase := New(d.taskRepository)
+		usecase := New(d.taskRepository, d.checklistRepository)
 		output, err := usecase.GetByID(t.args.ctx, t.args.payload)
 
 		s.Equal(t.expected.err, err)
@@ -453,6 +591,7 @@ func (s *TaskUsecaseTestSuite) TestUpdate() {
 					Description: "new_description",
 					IsCompleted: true,
 					DueDate:     entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
+					StartDate:   entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
 				},
 			},
 			expected: expected{
@@ -474,16 +613,18 @@ func (s *TaskUsecaseTestSuite) TestUpdate() {
 						UpdatedAt:   test.TimeBeforeNow,
 					}, nil)
 
-				d.taskRepository.On("Update", context.Background(), &entity.Task{
-					ID:          "task-xxxxx",
-					UserID:      "user-xxxxx",
-					Content:     "new_content",
-					Description: "new_description",
-					IsCompleted: true,
-					DueDate:     entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}},
-					CreatedAt:   test.TimeBeforeNow,
-					UpdatedAt:   test.TimeBeforeNow,
-				}).Return(entity.TaskID("task-xxxxx"), nil)
+				d.taskRepository.On("Update", context.Background(), mock.MatchedBy(func(task *entity.Task) bool {
+					return task.ID == "task-xxxxx" &&
+						task.UserID == "user-xxxxx" &&
+						task.Content == "new_content" &&
+						task.Description == "new_description" &&
+						task.IsCompleted &&
+						task.DueDate == entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}} &&
+						task.StartDate == entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}} &&
+						task.CompletedAt.Valid &&
+						task.CreatedAt == test.TimeBeforeNow &&
+						task.UpdatedAt == test.TimeBeforeNow
+				})).Return(entity.TaskID("task-xxxxx"), nil)
 			},
 		},
 	}
@@ -491,11 +632,12 @@ func (s *TaskUsecaseTestSuite) TestUpdate() {
 	for _, t := range tests {
 		s.Run(t.name, func() {
 			d := &dependency{
-				taskRepository: &mocks.TaskRepository{},
+				taskRepository:      &mocks.TaskRepository{},
+				checklistRepository: &mocks.ChecklistRepository{},
 			}
 			t.setup(d)
 
-			usecase := New(d.taskRepository)
+			usecase := New(d.taskRepository, d.checklistRepository)
 			output, err := usecase.Update(t.args.ctx, t.args.payload)
 
 			s.Equal(t.expected.err, err)
@@ -503,3 +645,246 @@ func (s *TaskUsecaseTestSuite) TestUpdate() {
 		})
 	}
 }
+
+func (s *TaskUsecaseTestSuite) TestSnooze() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TaskSnoozeIn
+	}
+	type expected struct {
+		output dto.TaskSnoozeOut
+		err    error
+	}
+	until := entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}
+	storedUntil := entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow.UTC(), Valid: true}}
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	jakartaUntil := entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow.In(jakarta), Valid: true}}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name: "it should return error ErrTaskSnoozeInPast when until is not in the future",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Until: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}},
+			},
+			expected: expected{
+				output: dto.TaskSnoozeOut{},
+				err:    domain.ErrTaskSnoozeInPast,
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name: "it should return error when task repository FindByID return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Until: until},
+			},
+			expected: expected{
+				output: dto.TaskSnoozeOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrTaskAuthorization when task is not own by the user",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Until: until},
+			},
+			expected: expected{
+				output: dto.TaskSnoozeOut{},
+				err:    domain.ErrTaskAuthorization,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{UserID: "user-yyyyy"}, nil)
+			},
+		},
+		{
+			name: "it should return error when task repository Update return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Until: until},
+			},
+			expected: expected{
+				output: dto.TaskSnoozeOut{},
+				err:    test.ErrUnexpected,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.taskRepository.On("Update", context.Background(), &entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx", StartDate: storedUntil}).
+					Return(entity.TaskID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and start date when snoozed until an exact time",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Until: until},
+			},
+			expected: expected{
+				output: dto.TaskSnoozeOut{ID: "task-xxxxx", StartDate: test.TimeAfterNow.UTC()},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.taskRepository.On("Update", context.Background(), &entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx", StartDate: storedUntil}).
+					Return(entity.TaskID("task-xxxxx"), nil)
+			},
+		},
+		{
+			name: "it should return error nil and store the start date in UTC when snoozed until an exact time of another time zone",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Until: jakartaUntil, TimeZone: "Asia/Jakarta"},
+			},
+			expected: expected{
+				output: dto.TaskSnoozeOut{ID: "task-xxxxx", StartDate: test.TimeAfterNow.UTC()},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.taskRepository.On("Update", context.Background(), &entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx", StartDate: storedUntil}).
+					Return(entity.TaskID("task-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				taskRepository:      &mocks.TaskRepository{},
+				checklistRepository: &mocks.ChecklistRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.taskRepository, d.checklistRepository)
+			output, err := usecase.Snooze(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *TaskUsecaseTestSuite) TestSnoozePreset() {
+	d := &dependency{
+		taskRepository:      &mocks.TaskRepository{},
+		checklistRepository: &mocks.ChecklistRepository{},
+	}
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	isTomorrowMorning := func(startDate time.Time) bool {
+		expected := entity.SnoozeTomorrow.Resolve(time.Now().In(jakarta))
+		return startDate.Equal(expected) && startDate.Location() == time.UTC
+	}
+
+	d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+		Return(entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx"}, nil)
+	d.taskRepository.On("Update", context.Background(), mock.MatchedBy(func(task *entity.Task) bool {
+		return task.StartDate.Valid && isTomorrowMorning(task.StartDate.Time)
+	})).Return(entity.TaskID("task-xxxxx"), nil)
+
+	usecase := New(d.taskRepository, d.checklistRepository)
+	output, err := usecase.Snooze(context.Background(), &dto.TaskSnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx", Preset: entity.SnoozeTomorrow, TimeZone: "Asia/Jakarta"})
+
+	s.NoError(err)
+	s.Equal(entity.TaskID("task-xxxxx"), output.ID)
+	s.True(isTomorrowMorning(output.StartDate))
+}
+
+func (s *TaskUsecaseTestSuite) TestUnsnooze() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TaskUnsnoozeIn
+	}
+	snoozed := entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx", StartDate: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeAfterNow, Valid: true}}}
+	tests := []struct {
+		name     string
+		args     args
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name: "it should return error when task repository FindByID return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskUnsnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrTaskAuthorization when task is not own by the user",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskUnsnoozeIn{TaskID: "task-xxxxx", UserID: "user-yyyyy"},
+			},
+			expected: domain.ErrTaskAuthorization,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(snoozed, nil)
+			},
+		},
+		{
+			name: "it should return error when task repository Update return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskUnsnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(snoozed, nil)
+
+				d.taskRepository.On("Update", context.Background(), &entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx"}).
+					Return(entity.TaskID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil when success unsnooze",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TaskUnsnoozeIn{TaskID: "task-xxxxx", UserID: "user-xxxxx"},
+			},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(snoozed, nil)
+
+				d.taskRepository.On("Update", context.Background(), &entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx"}).
+					Return(entity.TaskID("task-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := &dependency{
+				taskRepository:      &mocks.TaskRepository{},
+				checklistRepository: &mocks.ChecklistRepository{},
+			}
+			t.setup(d)
+
+			usecase := New(d.taskRepository, d.checklistRepository)
+			err := usecase.Unsnooze(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
@@ -0,0 +1,667 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type TemplateHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestTemplateHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TemplateHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	req             *http.Request
+	validator       *mocks.ValidatorProvider
+	templateUsecase *mocks.TemplateUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *TemplateHTTPHandlerTestSuite) assertResponse(rr *httptest.ResponseRecorder, isError bool, expected expected) {
+	s.Equal(expected.contentType, rr.Header().Get("Content-Type"))
+	s.Equal(expected.statusCode, rr.Code)
+
+	if isError {
+		var resBody domain.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.error, resBody.Error)
+	} else {
+		var resBody domain.SuccessResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.payload, resBody.Payload)
+	}
+}
+
+func (s *TemplateHTTPHandlerTestSuite) TestPost() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Name is required field",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrNameEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when template usecase Create return unexpected error",
+			isError:     true,
+			requestBody: []byte(`{"name":"template_name"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.templateUsecase.On("Create", mock.Anything, &dto.TemplateCreateIn{UserID: "user-xxxxx", Name: "template_name"}).
+					Return(dto.TemplateCreateOut{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"name":"template_name"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusCreated,
+				message:     "Successfully created new template",
+				payload:     map[string]any{"id": "template-xxxxx"},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.templateUsecase.On("Create", mock.Anything, &dto.TemplateCreateIn{UserID: "user-xxxxx", Name: "template_name"}).
+					Return(dto.TemplateCreateOut{ID: "template-xxxxx"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				req:             req,
+				validator:       &mocks.ValidatorProvider{},
+				templateUsecase: &mocks.TemplateUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.templateUsecase)
+			handler.Post(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *TemplateHTTPHandlerTestSuite) TestPostFromTasks() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{"name":"template_name"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Task ids is required field",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(dto.ErrTaskIDsEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when template usecase CreateFromTasks return error",
+			isError:     true,
+			requestBody: []byte(`{"name":"template_name","task_ids":["task-xxxxx"]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this task",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.templateUsecase.On("CreateFromTasks", mock.Anything, &dto.TemplateCreateFromTasksIn{UserID: "user-xxxxx", Name: "template_name", TaskIDs: []entity.TaskID{"task-xxxxx"}}).
+					Return(dto.TemplateCreateOut{}, domain.ErrTaskAuthorization)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"name":"template_name","task_ids":["task-xxxxx"]}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusCreated,
+				message:     "Successfully created new template",
+				payload:     map[string]any{"id": "template-xxxxx"},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.templateUsecase.On("CreateFromTasks", mock.Anything, &dto.TemplateCreateFromTasksIn{UserID: "user-xxxxx", Name: "template_name", TaskIDs: []entity.TaskID{"task-xxxxx"}}).
+					Return(dto.TemplateCreateOut{ID: "template-xxxxx"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				req:             req,
+				validator:       &mocks.ValidatorProvider{},
+				templateUsecase: &mocks.TemplateUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.templateUsecase)
+			handler.PostFromTasks(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *TemplateHTTPHandlerTestSuite) TestGet() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when template usecase GetAll return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.templateUsecase.On("GetAll", mock.Anything, &dto.TemplateGetAllIn{UserID: "user-xxxxx"}).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: []any{
+					map[string]any{"id": "template-xxxxx", "name": "template_name", "description": "template_description", "created_at": test.TimeBeforeNow.Format(time.RFC3339Nano), "updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano)},
+				},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.templateUsecase.On("GetAll", mock.Anything, &dto.TemplateGetAllIn{UserID: "user-xxxxx"}).
+					Return([]dto.TemplateGetAllOut{
+						{ID: "template-xxxxx", Name: "template_name", Description: "template_description", CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+
+			d := &dependency{
+				req:             req,
+				templateUsecase: &mocks.TemplateUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.templateUsecase)
+			handler.Get(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *TemplateHTTPHandlerTestSuite) TestGetByID() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when template usecase GetByID return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusNotFound,
+				message:     http.StatusText(http.StatusNotFound),
+				error:       "Template not found",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.templateUsecase.On("GetByID", mock.Anything, &dto.TemplateGetByIDIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}).
+					Return(dto.TemplateGetByIDOut{}, domain.ErrTemplateNotFound)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: map[string]any{
+					"id":          "template-xxxxx",
+					"name":        "template_name",
+					"description": "",
+					"tasks": []any{
+						map[string]any{"content": "blueprint_content", "description": "", "due_offset_days": nil, "children": []any{}},
+					},
+					"created_at": test.TimeBeforeNow.Format(time.RFC3339Nano),
+					"updated_at": test.TimeBeforeNow.Format(time.RFC3339Nano),
+				},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.templateUsecase.On("GetByID", mock.Anything, &dto.TemplateGetByIDIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}).
+					Return(dto.TemplateGetByIDOut{
+						ID:        "template-xxxxx",
+						Name:      "template_name",
+						Tasks:     []dto.TemplateTask{{Content: "blueprint_content", Children: []dto.TemplateTask{}}},
+						CreatedAt: test.TimeBeforeNow,
+						UpdatedAt: test.TimeBeforeNow,
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"template_id": "template-xxxxx"})
+
+			d := &dependency{
+				req:             req,
+				templateUsecase: &mocks.TemplateUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.templateUsecase)
+			handler.GetByID(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *TemplateHTTPHandlerTestSuite) TestPut() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(test.ErrValidator)
+			},
+		},
+		{
+			name:        "it should response with error when template usecase Update return error",
+			isError:     true,
+			requestBody: []byte(`{"name":"template_name"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusForbidden,
+				message:     http.StatusText(http.StatusForbidden),
+				error:       "Not have access to this template",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.templateUsecase.On("Update", mock.Anything, &dto.TemplateUpdateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Name: "template_name"}).
+					Return(dto.TemplateUpdateOut{}, domain.ErrTemplateAuthorization)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"name":"template_name"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully updated template",
+				payload:     map[string]any{"id": "template-xxxxx"},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.templateUsecase.On("Update", mock.Anything, &dto.TemplateUpdateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Name: "template_name"}).
+					Return(dto.TemplateUpdateOut{ID: "template-xxxxx"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/", bytes.NewReader(t.requestBody))
+			req = test.InjectChiRouterParams(req, map[string]string{"template_id": "template-xxxxx"})
+
+			d := &dependency{
+				req:             req,
+				validator:       &mocks.ValidatorProvider{},
+				templateUsecase: &mocks.TemplateUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.templateUsecase)
+			handler.Put(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *TemplateHTTPHandlerTestSuite) TestDelete() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when template usecase Remove return unexpected error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.templateUsecase.On("Remove", mock.Anything, &dto.TemplateRemoveIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully deleted template",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.templateUsecase.On("Remove", mock.Anything, &dto.TemplateRemoveIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req = test.InjectChiRouterParams(req, map[string]string{"template_id": "template-xxxxx"})
+
+			d := &dependency{
+				req:             req,
+				templateUsecase: &mocks.TemplateUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.templateUsecase)
+			handler.Delete(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *TemplateHTTPHandlerTestSuite) TestInstantiate() {
+	tests := []struct {
+		name        string
+		isError     bool
+		target      string
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid",
+			isError:     true,
+			target:      "/",
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when anchor is not valid",
+			isError:     true,
+			target:      "/?anchor=tomorrow",
+			requestBody: nil,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Anchor must be a date in YYYY-MM-DD format",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Anchor: "tomorrow"}).
+					Return(dto.ErrAnchorInvalid)
+			},
+		},
+		{
+			name:        "it should response with error when template usecase Instantiate return error",
+			isError:     true,
+			target:      "/?anchor=2026-11-01",
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Template variable is missing",
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.templateUsecase.On("Instantiate", mock.Anything, &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Anchor: "2026-11-01"}).
+					Return(dto.TemplateInstantiateOut{}, entity.ErrTemplateVariableMissing)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			target:      "/?anchor=2026-11-01",
+			requestBody: []byte(`{"variables":{"name":"Gopher"}}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusCreated,
+				message:     "Successfully instantiated template",
+				payload:     map[string]any{"task_ids": []any{"task-xxxxx", "task-yyyyy"}},
+			},
+			setup: func(d *dependency) {
+				d.req = test.InjectAuthContext(d.req, entity.UserID("user-xxxxx"))
+
+				d.validator.On("Validate", mock.Anything).
+					Return(nil)
+
+				d.templateUsecase.On("Instantiate", mock.Anything, &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Anchor: "2026-11-01", Variables: map[string]string{"name": "Gopher"}}).
+					Return(dto.TemplateInstantiateOut{TaskIDs: []entity.TaskID{"task-xxxxx", "task-yyyyy"}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", t.target, bytes.NewReader(t.requestBody))
+			req = test.InjectChiRouterParams(req, map[string]string{"template_id": "template-xxxxx"})
+
+			d := &dependency{
+				req:             req,
+				validator:       &mocks.ValidatorProvider{},
+				templateUsecase: &mocks.TemplateUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.templateUsecase)
+			handler.Instantiate(rr, d.req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
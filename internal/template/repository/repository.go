@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new template repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new template with its task blueprints.
+func (r *Repository) Store(ctx context.Context, t *entity.Template) (entity.TemplateID, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	id := entity.TemplateID(r.idProvider.Generate())
+	q := `INSERT INTO templates (id, user_id, name, description) VALUES ($1, $2, $3, $4)`
+	_, err = tx.ExecContext(ctx, q, id, t.UserID, t.Name, t.Description)
+	if err != nil {
+		return "", err
+	}
+	if err := r.storeTasks(ctx, tx, id, sql.NullString{}, t.Tasks); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// FindByID get template with its task blueprints by id.
+func (r *Repository) FindByID(ctx context.Context, templateID entity.TemplateID) (entity.Template, error) {
+	var template entity.Template
+	q := `SELECT id, user_id, name, description, created_at, updated_at FROM templates WHERE id = $1`
+	row := r.db.QueryRowContext(ctx, q, templateID)
+	err := row.Scan(&template.ID, &template.UserID, &template.Name, &template.Description, &template.CreatedAt, &template.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Template{}, domain.ErrTemplateNotFound
+	} else if err != nil {
+		return entity.Template{}, err
+	}
+
+	q = `SELECT id, parent_id, content, description, due_offset_days FROM template_tasks WHERE template_id = $1 ORDER BY position`
+	rows, err := r.db.QueryContext(ctx, q, templateID)
+	if err != nil {
+		return entity.Template{}, err
+	}
+	defer rows.Close()
+
+	childrenOf := make(map[string][]entity.TemplateTask)
+	for rows.Next() {
+		var task entity.TemplateTask
+		var parentID sql.NullString
+		err := rows.Scan(&task.ID, &parentID, &task.Content, &task.Description, &task.DueOffsetDays)
+		if err != nil {
+			return entity.Template{}, err
+		}
+		childrenOf[parentID.String] = append(childrenOf[parentID.String], task)
+	}
+	if err := rows.Err(); err != nil {
+		return entity.Template{}, err
+	}
+
+	template.Tasks = buildTaskTree(childrenOf, "")
+	return template, nil
+}
+
+// FindAllByUserID get all templates owned by a user by user id, without their task blueprints.
+func (r *Repository) FindAllByUserID(ctx context.Context, userID entity.UserID) ([]entity.Template, error) {
+	q := `SELECT id, name, description, created_at, updated_at FROM templates WHERE user_id = $1`
+	rows, err := r.db.QueryContext(ctx, q, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	templates := make([]entity.Template, 0)
+	for rows.Next() {
+		var template entity.Template
+		err := rows.Scan(&template.ID, &template.Name, &template.Description, &template.CreatedAt, &template.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// Update update template by id and replace all of its task blueprints.
+func (r *Repository) Update(ctx context.Context, t *entity.Template) (entity.TemplateID, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	t.UpdatedAt = time.Now()
+	q := `UPDATE templates SET name = $2, description = $3, updated_at = $4 WHERE id = $1`
+	_, err = tx.ExecContext(ctx, q, t.ID, t.Name, t.Description, t.UpdatedAt)
+	if err != nil {
+		return "", err
+	}
+
+	q = `DELETE FROM template_tasks WHERE template_id = $1`
+	_, err = tx.ExecContext(ctx, q, t.ID)
+	if err != nil {
+		return "", err
+	}
+	if err := r.storeTasks(ctx, tx, t.ID, sql.NullString{}, t.Tasks); err != nil {
+		return "", err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", err
+	}
+	return t.ID, nil
+}
+
+// DeleteByID delete a template and its task blueprints by id.
+func (r *Repository) DeleteByID(ctx context.Context, templateID entity.TemplateID) error {
+	q := `DELETE FROM templates WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, templateID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// storeTasks save task blueprints and their children recursively.
+func (r *Repository) storeTasks(ctx context.Context, tx *sql.Tx, templateID entity.TemplateID, parentID sql.NullString, tasks []entity.TemplateTask) error {
+	q := `INSERT INTO template_tasks (id, template_id, parent_id, position, content, description, due_offset_days) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	for i, task := range tasks {
+		id := r.idProvider.Generate()
+		_, err := tx.ExecContext(ctx, q, id, templateID, parentID, i, task.Content, task.Description, task.DueOffsetDays)
+		if err != nil {
+			return err
+		}
+		if err := r.storeTasks(ctx, tx, templateID, sql.NullString{String: id, Valid: true}, task.Children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildTaskTree assemble task blueprints grouped by parent id into a tree.
+func buildTaskTree(childrenOf map[string][]entity.TemplateTask, parentID string) []entity.TemplateTask {
+	children := childrenOf[parentID]
+	for i := range children {
+		children[i].Children = buildTaskTree(childrenOf, string(children[i].ID))
+	}
+	return children
+}
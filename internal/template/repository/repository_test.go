@@ -0,0 +1,622 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type TemplateRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestTemplateRepositorySuite(t *testing.T) {
+	suite.Run(t, new(TemplateRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	sevenDays = entity.NullInt64{NullInt64: sql.NullInt64{Int64: 7, Valid: true}}
+
+	insertTemplateQuery     = regexp.QuoteMeta(`INSERT INTO templates (id, user_id, name, description) VALUES ($1, $2, $3, $4)`)
+	insertTemplateTaskQuery = regexp.QuoteMeta(`INSERT INTO template_tasks (id, template_id, parent_id, position, content, description, due_offset_days) VALUES ($1, $2, $3, $4, $5, $6, $7)`)
+	selectTemplateQuery     = regexp.QuoteMeta(`SELECT id, user_id, name, description, created_at, updated_at FROM templates WHERE id = $1`)
+	selectTemplateTaskQuery = regexp.QuoteMeta(`SELECT id, parent_id, content, description, due_offset_days FROM template_tasks WHERE template_id = $1 ORDER BY position`)
+	selectTemplatesQuery    = regexp.QuoteMeta(`SELECT id, name, description, created_at, updated_at FROM templates WHERE user_id = $1`)
+	updateTemplateQuery     = regexp.QuoteMeta(`UPDATE templates SET name = $2, description = $3, updated_at = $4 WHERE id = $1`)
+	deleteTemplateTaskQuery = regexp.QuoteMeta(`DELETE FROM template_tasks WHERE template_id = $1`)
+)
+
+func newTemplate() *entity.Template {
+	return &entity.Template{
+		ID:          "template-xxxxx",
+		UserID:      "user-xxxxx",
+		Name:        "template_name",
+		Description: "template_description",
+		Tasks: []entity.TemplateTask{
+			{
+				Content:       "blueprint_xxxxx_content",
+				DueOffsetDays: sevenDays,
+				Children:      []entity.TemplateTask{{Content: "blueprint_yyyyy_content"}},
+			},
+		},
+	}
+}
+
+func (s *TemplateRepositoryTestSuite) TestStore() {
+	type args struct {
+		ctx      context.Context
+		template *entity.Template
+	}
+	type expected struct {
+		templateID entity.TemplateID
+		err        error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to begin transaction",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to store template",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("template-xxxxx").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(insertTemplateQuery).
+					WithArgs("template-xxxxx", "user-xxxxx", "template_name", "template_description").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to store nested task blueprint",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("template-xxxxx").Once()
+				d.idProvider.On("Generate").Return("blueprint-xxxxx").Once()
+				d.idProvider.On("Generate").Return("blueprint-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(insertTemplateQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to commit transaction",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("template-xxxxx").Once()
+				d.idProvider.On("Generate").Return("blueprint-xxxxx").Once()
+				d.idProvider.On("Generate").Return("blueprint-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(insertTemplateQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and template id when successfully store",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "template-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("template-xxxxx").Once()
+				d.idProvider.On("Generate").Return("blueprint-xxxxx").Once()
+				d.idProvider.On("Generate").Return("blueprint-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(insertTemplateQuery).
+					WithArgs("template-xxxxx", "user-xxxxx", "template_name", "template_description").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WithArgs("blueprint-xxxxx", "template-xxxxx", nil, 0, "blueprint_xxxxx_content", "", 7).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WithArgs("blueprint-yyyyy", "template-xxxxx", "blueprint-xxxxx", 0, "blueprint_yyyyy_content", "", nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			templateID, err := repository.Store(t.args.ctx, t.args.template)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.templateID, templateID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TemplateRepositoryTestSuite) TestFindByID() {
+	type args struct {
+		ctx        context.Context
+		templateID entity.TemplateID
+	}
+	type expected struct {
+		template      entity.Template
+		allowAnyError bool
+		err           error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrTemplateNotFound when template is not exist",
+			args:     args{ctx: context.Background(), templateID: "template-xxxxx"},
+			expected: expected{template: entity.Template{}, err: domain.ErrTemplateNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectTemplateQuery).
+					WithArgs("template-xxxxx").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error when database fail to query template",
+			args:     args{ctx: context.Background(), templateID: "template-xxxxx"},
+			expected: expected{template: entity.Template{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectTemplateQuery).
+					WithArgs("template-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to query task blueprints",
+			args:     args{ctx: context.Background(), templateID: "template-xxxxx"},
+			expected: expected{template: entity.Template{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "user_id", "name", "description", "created_at", "updated_at"}).
+					AddRow("template-xxxxx", "user-xxxxx", "template_name", "template_description", test.TimeBeforeNow, test.TimeBeforeNow)
+				d.mockDB.ExpectQuery(selectTemplateQuery).
+					WithArgs("template-xxxxx").
+					WillReturnRows(mockRow)
+				d.mockDB.ExpectQuery(selectTemplateTaskQuery).
+					WithArgs("template-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database task blueprint rows fail to scan",
+			args:     args{ctx: context.Background(), templateID: "template-xxxxx"},
+			expected: expected{template: entity.Template{}, allowAnyError: true, err: errors.New("anything")},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "user_id", "name", "description", "created_at", "updated_at"}).
+					AddRow("template-xxxxx", "user-xxxxx", "template_name", "template_description", test.TimeBeforeNow, test.TimeBeforeNow)
+				d.mockDB.ExpectQuery(selectTemplateQuery).
+					WithArgs("template-xxxxx").
+					WillReturnRows(mockRow)
+
+				mockTaskRows := sqlmock.NewRows([]string{"id", "parent_id", "content", "description", "due_offset_days"}).
+					AddRow(nil, nil, "blueprint_content", "", "seven")
+				d.mockDB.ExpectQuery(selectTemplateTaskQuery).
+					WithArgs("template-xxxxx").
+					WillReturnRows(mockTaskRows)
+			},
+		},
+		{
+			name:     "it should return error when database task blueprint rows error",
+			args:     args{ctx: context.Background(), templateID: "template-xxxxx"},
+			expected: expected{template: entity.Template{}, err: test.ErrRows},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "user_id", "name", "description", "created_at", "updated_at"}).
+					AddRow("template-xxxxx", "user-xxxxx", "template_name", "template_description", test.TimeBeforeNow, test.TimeBeforeNow)
+				d.mockDB.ExpectQuery(selectTemplateQuery).
+					WithArgs("template-xxxxx").
+					WillReturnRows(mockRow)
+
+				mockTaskRows := sqlmock.NewRows([]string{"id", "parent_id", "content", "description", "due_offset_days"}).
+					AddRow("blueprint-xxxxx", nil, "blueprint_content", "", nil).
+					RowError(0, test.ErrRows)
+				d.mockDB.ExpectQuery(selectTemplateTaskQuery).
+					WithArgs("template-xxxxx").
+					WillReturnRows(mockTaskRows)
+			},
+		},
+		{
+			name: "it should return error nil and template with task blueprint tree when successfully query",
+			args: args{ctx: context.Background(), templateID: "template-xxxxx"},
+			expected: expected{
+				template: entity.Template{
+					ID:          "template-xxxxx",
+					UserID:      "user-xxxxx",
+					Name:        "template_name",
+					Description: "template_description",
+					Tasks: []entity.TemplateTask{
+						{
+							ID:            "blueprint-xxxxx",
+							Content:       "blueprint_xxxxx_content",
+							DueOffsetDays: sevenDays,
+							Children: []entity.TemplateTask{
+								{ID: "blueprint-yyyyy", Content: "blueprint_yyyyy_content"},
+							},
+						},
+						{ID: "blueprint-zzzzz", Content: "blueprint_zzzzz_content"},
+					},
+					CreatedAt: test.TimeBeforeNow,
+					UpdatedAt: test.TimeBeforeNow,
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "user_id", "name", "description", "created_at", "updated_at"}).
+					AddRow("template-xxxxx", "user-xxxxx", "template_name", "template_description", test.TimeBeforeNow, test.TimeBeforeNow)
+				d.mockDB.ExpectQuery(selectTemplateQuery).
+					WithArgs("template-xxxxx").
+					WillReturnRows(mockRow)
+
+				mockTaskRows := sqlmock.NewRows([]string{"id", "parent_id", "content", "description", "due_offset_days"}).
+					AddRow("blueprint-xxxxx", nil, "blueprint_xxxxx_content", "", 7).
+					AddRow("blueprint-yyyyy", "blueprint-xxxxx", "blueprint_yyyyy_content", "", nil).
+					AddRow("blueprint-zzzzz", nil, "blueprint_zzzzz_content", "", nil)
+				d.mockDB.ExpectQuery(selectTemplateTaskQuery).
+					WithArgs("template-xxxxx").
+					WillReturnRows(mockTaskRows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			template, err := repository.FindByID(t.args.ctx, t.args.templateID)
+
+			if t.expected.allowAnyError {
+				s.Error(err)
+			} else {
+				s.Equal(t.expected.err, err)
+			}
+			s.Equal(t.expected.template, template)
+		})
+	}
+}
+
+func (s *TemplateRepositoryTestSuite) TestFindAllByUserID() {
+	type args struct {
+		ctx    context.Context
+		userID entity.UserID
+	}
+	type expected struct {
+		templates     []entity.Template
+		allowAnyError bool
+		err           error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{templates: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectTemplatesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database rows fail to scan",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{templates: nil, allowAnyError: true, err: errors.New("anything")},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at"}).
+					AddRow(nil, "template_name", "template_description", "yesterday", test.TimeBeforeNow)
+				d.mockDB.ExpectQuery(selectTemplatesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name:     "it should return error when database rows error",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{templates: nil, err: test.ErrRows},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at"}).
+					AddRow("template-xxxxx", "template_name", "template_description", test.TimeBeforeNow, test.TimeBeforeNow).
+					RowError(0, test.ErrRows)
+				d.mockDB.ExpectQuery(selectTemplatesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+		{
+			name: "it should return error nil and all templates when successfully query",
+			args: args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{
+				templates: []entity.Template{
+					{ID: "template-xxxxx", Name: "template_name", Description: "template_description", CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				mockRow := sqlmock.NewRows([]string{"id", "name", "description", "created_at", "updated_at"}).
+					AddRow("template-xxxxx", "template_name", "template_description", test.TimeBeforeNow, test.TimeBeforeNow)
+				d.mockDB.ExpectQuery(selectTemplatesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(mockRow)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			templates, err := repository.FindAllByUserID(t.args.ctx, t.args.userID)
+
+			if t.expected.allowAnyError {
+				s.Error(err)
+			} else {
+				s.Equal(t.expected.err, err)
+			}
+			s.Equal(t.expected.templates, templates)
+		})
+	}
+}
+
+func (s *TemplateRepositoryTestSuite) TestUpdate() {
+	type args struct {
+		ctx      context.Context
+		template *entity.Template
+	}
+	type expected struct {
+		templateID entity.TemplateID
+		err        error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to begin transaction",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to update template",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateTemplateQuery).
+					WithArgs("template-xxxxx", "template_name", "template_description", sqlmock.AnyArg()).
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to delete old task blueprints",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateTemplateQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(deleteTemplateTaskQuery).
+					WithArgs("template-xxxxx").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to store new task blueprints",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("blueprint-xxxxx").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateTemplateQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(deleteTemplateTaskQuery).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to commit transaction",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("blueprint-xxxxx").Once()
+				d.idProvider.On("Generate").Return("blueprint-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateTemplateQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(deleteTemplateTaskQuery).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and template id when successfully update",
+			args:     args{ctx: context.Background(), template: newTemplate()},
+			expected: expected{templateID: "template-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("blueprint-xxxxx").Once()
+				d.idProvider.On("Generate").Return("blueprint-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(updateTemplateQuery).
+					WithArgs("template-xxxxx", "template_name", "template_description", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(deleteTemplateTaskQuery).
+					WithArgs("template-xxxxx").
+					WillReturnResult(sqlmock.NewResult(1, 2))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WithArgs("blueprint-xxxxx", "template-xxxxx", nil, 0, "blueprint_xxxxx_content", "", 7).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertTemplateTaskQuery).
+					WithArgs("blueprint-yyyyy", "template-xxxxx", "blueprint-xxxxx", 0, "blueprint_yyyyy_content", "", nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			templateID, err := repository.Update(t.args.ctx, t.args.template)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.templateID, templateID)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TemplateRepositoryTestSuite) TestDeleteByID() {
+	type args struct {
+		ctx        context.Context
+		templateID entity.TemplateID
+	}
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			args:     args{ctx: context.Background(), templateID: "template-xxxxx"},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM templates WHERE id = $1`)).
+					WithArgs("template-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			args:     args{ctx: context.Background(), templateID: "template-xxxxx"},
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM templates WHERE id = $1`)).
+					WithArgs("template-xxxxx").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.DeleteByID(t.args.ctx, t.args.templateID)
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
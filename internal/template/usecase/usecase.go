@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Usecase struct {
+	validator          domain.ValidatorProvider
+	templateRepository domain.TemplateRepository
+	taskRepository     domain.TaskRepository
+}
+
+// New create a new template usecase.
+func New(validator domain.ValidatorProvider, templateRepository domain.TemplateRepository, taskRepository domain.TaskRepository) Usecase {
+	return Usecase{validator: validator, templateRepository: templateRepository, taskRepository: taskRepository}
+}
+
+// Create create a new template.
+func (u *Usecase) Create(ctx context.Context, payload *dto.TemplateCreateIn) (dto.TemplateCreateOut, error) {
+	template := &entity.Template{
+		UserID:      payload.UserID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Tasks:       toEntityTemplateTasks(payload.Tasks),
+	}
+
+	templateID, err := u.templateRepository.Store(ctx, template)
+	if err != nil {
+		return dto.TemplateCreateOut{}, err
+	}
+	return dto.TemplateCreateOut{ID: templateID}, nil
+}
+
+// CreateFromTasks create a new template from existing tasks.
+// Due offsets are relative to the given anchor or to the earliest due date of the tasks.
+func (u *Usecase) CreateFromTasks(ctx context.Context, payload *dto.TemplateCreateFromTasksIn) (dto.TemplateCreateOut, error) {
+	tasks := make([]entity.Task, len(payload.TaskIDs))
+	for i, taskID := range payload.TaskIDs {
+		task, err := u.taskRepository.FindByID(ctx, taskID)
+		if err != nil {
+			return dto.TemplateCreateOut{}, err
+		}
+		if task.UserID != payload.UserID {
+			return dto.TemplateCreateOut{}, domain.ErrTaskAuthorization
+		}
+		tasks[i] = task
+	}
+
+	var anchor time.Time
+	if payload.Anchor != "" {
+		anchor, _ = time.Parse(dto.TemplateDateLayout, payload.Anchor)
+	} else {
+		for _, task := range tasks {
+			if task.DueDate.Valid && (anchor.IsZero() || task.DueDate.Time.Before(anchor)) {
+				anchor = task.DueDate.Time
+			}
+		}
+	}
+
+	template := &entity.Template{
+		UserID:      payload.UserID,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Tasks:       make([]entity.TemplateTask, len(tasks)),
+	}
+	for i, task := range tasks {
+		template.Tasks[i] = entity.TemplateTask{Content: task.Content, Description: task.Description}
+		if task.DueDate.Valid {
+			template.Tasks[i].DueOffsetDays = entity.NullInt64{NullInt64: sql.NullInt64{Int64: int64(entity.DaysBetween(anchor, task.DueDate.Time)), Valid: true}}
+		}
+	}
+
+	templateID, err := u.templateRepository.Store(ctx, template)
+	if err != nil {
+		return dto.TemplateCreateOut{}, err
+	}
+	return dto.TemplateCreateOut{ID: templateID}, nil
+}
+
+// GetAll get all templates.
+func (u *Usecase) GetAll(ctx context.Context, payload *dto.TemplateGetAllIn) ([]dto.TemplateGetAllOut, error) {
+	templates, err := u.templateRepository.FindAllByUserID(ctx, payload.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]dto.TemplateGetAllOut, len(templates))
+	for i, template := range templates {
+		output[i] = dto.TemplateGetAllOut{
+			ID:          template.ID,
+			Name:        template.Name,
+			Description: template.Description,
+			CreatedAt:   template.CreatedAt,
+			UpdatedAt:   template.UpdatedAt,
+		}
+	}
+	return output, nil
+}
+
+// GetByID get template by id.
+func (u *Usecase) GetByID(ctx context.Context, payload *dto.TemplateGetByIDIn) (dto.TemplateGetByIDOut, error) {
+	template, err := u.templateRepository.FindByID(ctx, payload.TemplateID)
+	if err != nil {
+		return dto.TemplateGetByIDOut{}, err
+	}
+	if template.UserID != payload.UserID {
+		return dto.TemplateGetByIDOut{}, domain.ErrTemplateAuthorization
+	}
+
+	output := dto.TemplateGetByIDOut{
+		ID:          template.ID,
+		Name:        template.Name,
+		Description: template.Description,
+		Tasks:       toDTOTemplateTasks(template.Tasks),
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+	return output, nil
+}
+
+// Update update template by id.
+func (u *Usecase) Update(ctx context.Context, payload *dto.TemplateUpdateIn) (dto.TemplateUpdateOut, error) {
+	template, err := u.templateRepository.FindByID(ctx, payload.TemplateID)
+	if err != nil {
+		return dto.TemplateUpdateOut{}, err
+	}
+	if template.UserID != payload.UserID {
+		return dto.TemplateUpdateOut{}, domain.ErrTemplateAuthorization
+	}
+
+	template.Name = payload.Name
+	template.Description = payload.Description
+	template.Tasks = toEntityTemplateTasks(payload.Tasks)
+
+	templateID, err := u.templateRepository.Update(ctx, &template)
+	if err != nil {
+		return dto.TemplateUpdateOut{}, err
+	}
+	return dto.TemplateUpdateOut{ID: templateID}, nil
+}
+
+// Remove remove a template.
+func (u *Usecase) Remove(ctx context.Context, payload *dto.TemplateRemoveIn) error {
+	template, err := u.templateRepository.FindByID(ctx, payload.TemplateID)
+	if err != nil {
+		return err
+	}
+	if template.UserID != payload.UserID {
+		return domain.ErrTemplateAuthorization
+	}
+	if err := u.templateRepository.DeleteByID(ctx, payload.TemplateID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Instantiate create all tasks of a template in a single transaction.
+// Anchor defaults to today when it is not provided, every rendered task must pass the task validation.
+func (u *Usecase) Instantiate(ctx context.Context, payload *dto.TemplateInstantiateIn) (dto.TemplateInstantiateOut, error) {
+	template, err := u.templateRepository.FindByID(ctx, payload.TemplateID)
+	if err != nil {
+		return dto.TemplateInstantiateOut{}, err
+	}
+	if template.UserID != payload.UserID {
+		return dto.TemplateInstantiateOut{}, domain.ErrTemplateAuthorization
+	}
+
+	anchor := time.Now().UTC()
+	if payload.Anchor != "" {
+		anchor, _ = time.Parse(dto.TemplateDateLayout, payload.Anchor)
+	}
+
+	tasks, err := template.Instantiate(payload.UserID, anchor, payload.Variables)
+	if err != nil {
+		return dto.TemplateInstantiateOut{}, err
+	}
+	if len(tasks) == 0 {
+		return dto.TemplateInstantiateOut{TaskIDs: []entity.TaskID{}}, nil
+	}
+	for _, task := range tasks {
+		taskPayload := &dto.TaskCreateIn{
+			UserID:      task.UserID,
+			Content:     task.Content,
+			Description: task.Description,
+			DueDate:     task.DueDate,
+			StartDate:   task.StartDate,
+		}
+		if err := u.validator.Validate(taskPayload); err != nil {
+			return dto.TemplateInstantiateOut{}, err
+		}
+	}
+
+	taskIDs, err := u.taskRepository.StoreMany(ctx, tasks)
+	if err != nil {
+		return dto.TemplateInstantiateOut{}, err
+	}
+	return dto.TemplateInstantiateOut{TaskIDs: taskIDs}, nil
+}
+
+func toEntityTemplateTasks(tasks []dto.TemplateTask) []entity.TemplateTask {
+	if len(tasks) == 0 {
+		return nil
+	}
+	result := make([]entity.TemplateTask, len(tasks))
+	for i, task := range tasks {
+		result[i] = entity.TemplateTask{
+			Content:       task.Content,
+			Description:   task.Description,
+			DueOffsetDays: task.DueOffsetDays,
+			Children:      toEntityTemplateTasks(task.Children),
+		}
+	}
+	return result
+}
+
+func toDTOTemplateTasks(tasks []entity.TemplateTask) []dto.TemplateTask {
+	result := make([]dto.TemplateTask, len(tasks))
+	for i, task := range tasks {
+		result[i] = dto.TemplateTask{
+			Content:       task.Content,
+			Description:   task.Description,
+			DueOffsetDays: task.DueOffsetDays,
+			Children:      toDTOTemplateTasks(task.Children),
+		}
+	}
+	return result
+}
@@ -0,0 +1,628 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type TemplateUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestTemplateUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(TemplateUsecaseTestSuite))
+}
+
+type dependency struct {
+	validator          *mocks.ValidatorProvider
+	templateRepository *mocks.TemplateRepository
+	taskRepository     *mocks.TaskRepository
+}
+
+func newDependency() *dependency {
+	return &dependency{
+		validator:          &mocks.ValidatorProvider{},
+		templateRepository: &mocks.TemplateRepository{},
+		taskRepository:     &mocks.TaskRepository{},
+	}
+}
+
+func nullDays(days int64) entity.NullInt64 {
+	return entity.NullInt64{NullInt64: sql.NullInt64{Int64: days, Valid: true}}
+}
+
+func nullDate(year int, month time.Month, day int) entity.NullTime {
+	return entity.NullTime{NullTime: sql.NullTime{Time: time.Date(year, month, day, 0, 0, 0, 0, time.UTC), Valid: true}}
+}
+
+func (s *TemplateUsecaseTestSuite) TestCreate() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TemplateCreateIn
+	}
+	type expected struct {
+		output dto.TemplateCreateOut
+		err    error
+	}
+	payload := &dto.TemplateCreateIn{
+		UserID: "user-xxxxx",
+		Name:   "template_name",
+		Tasks:  []dto.TemplateTask{{Content: "blueprint_content", DueOffsetDays: nullDays(3), Children: []dto.TemplateTask{{Content: "child_content"}}}},
+	}
+	template := &entity.Template{
+		UserID: "user-xxxxx",
+		Name:   "template_name",
+		Tasks:  []entity.TemplateTask{{Content: "blueprint_content", DueOffsetDays: nullDays(3), Children: []entity.TemplateTask{{Content: "child_content"}}}},
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when template repository Store return unexpected error",
+			args:     args{ctx: context.Background(), payload: payload},
+			expected: expected{output: dto.TemplateCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.templateRepository.On("Store", context.Background(), template).
+					Return(entity.TemplateID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and output when successfully create template",
+			args:     args{ctx: context.Background(), payload: payload},
+			expected: expected{output: dto.TemplateCreateOut{ID: "template-xxxxx"}, err: nil},
+			setup: func(d *dependency) {
+				d.templateRepository.On("Store", context.Background(), template).
+					Return(entity.TemplateID("template-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.validator, d.templateRepository, d.taskRepository)
+			output, err := usecase.Create(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *TemplateUsecaseTestSuite) TestCreateFromTasks() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TemplateCreateFromTasksIn
+	}
+	type expected struct {
+		output dto.TemplateCreateOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name: "it should return error when task repository FindByID return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TemplateCreateFromTasksIn{UserID: "user-xxxxx", Name: "template_name", TaskIDs: []entity.TaskID{"task-xxxxx"}},
+			},
+			expected: expected{output: dto.TemplateCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error ErrTaskAuthorization when task is owned by another user",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TemplateCreateFromTasksIn{UserID: "user-xxxxx", Name: "template_name", TaskIDs: []entity.TaskID{"task-xxxxx"}},
+			},
+			expected: expected{output: dto.TemplateCreateOut{}, err: domain.ErrTaskAuthorization},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{ID: "task-xxxxx", UserID: "user-yyyyy"}, nil)
+			},
+		},
+		{
+			name: "it should return error when template repository Store return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TemplateCreateFromTasksIn{UserID: "user-xxxxx", Name: "template_name", TaskIDs: []entity.TaskID{"task-xxxxx"}},
+			},
+			expected: expected{output: dto.TemplateCreateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx", Content: "task_content"}, nil)
+
+				d.templateRepository.On("Store", context.Background(), mock.Anything).
+					Return(entity.TemplateID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should compute offsets from the earliest due date when anchor is not provided",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TemplateCreateFromTasksIn{UserID: "user-xxxxx", Name: "template_name", TaskIDs: []entity.TaskID{"task-xxxxx", "task-yyyyy", "task-zzzzz"}},
+			},
+			expected: expected{output: dto.TemplateCreateOut{ID: "template-xxxxx"}, err: nil},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx", Content: "task_xxxxx_content", DueDate: nullDate(2026, 11, 10)}, nil)
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-yyyyy")).
+					Return(entity.Task{ID: "task-yyyyy", UserID: "user-xxxxx", Content: "task_yyyyy_content", DueDate: nullDate(2026, 11, 3)}, nil)
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-zzzzz")).
+					Return(entity.Task{ID: "task-zzzzz", UserID: "user-xxxxx", Content: "task_zzzzz_content", Description: "task_zzzzz_description"}, nil)
+
+				d.templateRepository.On("Store", context.Background(), &entity.Template{
+					UserID: "user-xxxxx",
+					Name:   "template_name",
+					Tasks: []entity.TemplateTask{
+						{Content: "task_xxxxx_content", DueOffsetDays: nullDays(7)},
+						{Content: "task_yyyyy_content", DueOffsetDays: nullDays(0)},
+						{Content: "task_zzzzz_content", Description: "task_zzzzz_description"},
+					},
+				}).Return(entity.TemplateID("template-xxxxx"), nil)
+			},
+		},
+		{
+			name: "it should compute offsets from the provided anchor",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TemplateCreateFromTasksIn{UserID: "user-xxxxx", Name: "template_name", TaskIDs: []entity.TaskID{"task-xxxxx"}, Anchor: "2026-11-12"},
+			},
+			expected: expected{output: dto.TemplateCreateOut{ID: "template-xxxxx"}, err: nil},
+			setup: func(d *dependency) {
+				d.taskRepository.On("FindByID", context.Background(), entity.TaskID("task-xxxxx")).
+					Return(entity.Task{ID: "task-xxxxx", UserID: "user-xxxxx", Content: "task_xxxxx_content", DueDate: nullDate(2026, 11, 10)}, nil)
+
+				d.templateRepository.On("Store", context.Background(), &entity.Template{
+					UserID: "user-xxxxx",
+					Name:   "template_name",
+					Tasks:  []entity.TemplateTask{{Content: "task_xxxxx_content", DueOffsetDays: nullDays(-2)}},
+				}).Return(entity.TemplateID("template-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.validator, d.templateRepository, d.taskRepository)
+			output, err := usecase.CreateFromTasks(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *TemplateUsecaseTestSuite) TestGetAll() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TemplateGetAllIn
+	}
+	type expected struct {
+		output []dto.TemplateGetAllOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when template repository FindAllByUserID return unexpected error",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateGetAllIn{UserID: "user-xxxxx"}},
+			expected: expected{output: nil, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and templates when success",
+			args: args{ctx: context.Background(), payload: &dto.TemplateGetAllIn{UserID: "user-xxxxx"}},
+			expected: expected{
+				output: []dto.TemplateGetAllOut{
+					{ID: "template-xxxxx", Name: "template_name", Description: "template_description", CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindAllByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return([]entity.Template{
+						{ID: "template-xxxxx", UserID: "user-xxxxx", Name: "template_name", Description: "template_description", CreatedAt: test.TimeBeforeNow, UpdatedAt: test.TimeBeforeNow},
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.validator, d.templateRepository, d.taskRepository)
+			output, err := usecase.GetAll(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *TemplateUsecaseTestSuite) TestGetByID() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TemplateGetByIDIn
+	}
+	type expected struct {
+		output dto.TemplateGetByIDOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when template repository FindByID return error",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateGetByIDIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{output: dto.TemplateGetByIDOut{}, err: domain.ErrTemplateNotFound},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{}, domain.ErrTemplateNotFound)
+			},
+		},
+		{
+			name:     "it should return error ErrTemplateAuthorization when template is owned by another user",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateGetByIDIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{output: dto.TemplateGetByIDOut{}, err: domain.ErrTemplateAuthorization},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{ID: "template-xxxxx", UserID: "user-yyyyy"}, nil)
+			},
+		},
+		{
+			name: "it should return error nil and template with task blueprints when success",
+			args: args{ctx: context.Background(), payload: &dto.TemplateGetByIDIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{
+				output: dto.TemplateGetByIDOut{
+					ID:        "template-xxxxx",
+					Name:      "template_name",
+					Tasks:     []dto.TemplateTask{{Content: "blueprint_content", DueOffsetDays: nullDays(1), Children: []dto.TemplateTask{{Content: "child_content", Children: []dto.TemplateTask{}}}}},
+					CreatedAt: test.TimeBeforeNow,
+					UpdatedAt: test.TimeBeforeNow,
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{
+						ID:        "template-xxxxx",
+						UserID:    "user-xxxxx",
+						Name:      "template_name",
+						Tasks:     []entity.TemplateTask{{ID: "blueprint-xxxxx", Content: "blueprint_content", DueOffsetDays: nullDays(1), Children: []entity.TemplateTask{{ID: "blueprint-yyyyy", Content: "child_content"}}}},
+						CreatedAt: test.TimeBeforeNow,
+						UpdatedAt: test.TimeBeforeNow,
+					}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.validator, d.templateRepository, d.taskRepository)
+			output, err := usecase.GetByID(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *TemplateUsecaseTestSuite) TestUpdate() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TemplateUpdateIn
+	}
+	type expected struct {
+		output dto.TemplateUpdateOut
+		err    error
+	}
+	payload := &dto.TemplateUpdateIn{
+		TemplateID: "template-xxxxx",
+		UserID:     "user-xxxxx",
+		Name:       "new_template_name",
+		Tasks:      []dto.TemplateTask{{Content: "new_blueprint_content"}},
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when template repository FindByID return error",
+			args:     args{ctx: context.Background(), payload: payload},
+			expected: expected{output: dto.TemplateUpdateOut{}, err: domain.ErrTemplateNotFound},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{}, domain.ErrTemplateNotFound)
+			},
+		},
+		{
+			name:     "it should return error ErrTemplateAuthorization when template is owned by another user",
+			args:     args{ctx: context.Background(), payload: payload},
+			expected: expected{output: dto.TemplateUpdateOut{}, err: domain.ErrTemplateAuthorization},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{ID: "template-xxxxx", UserID: "user-yyyyy"}, nil)
+			},
+		},
+		{
+			name:     "it should return error when template repository Update return unexpected error",
+			args:     args{ctx: context.Background(), payload: payload},
+			expected: expected{output: dto.TemplateUpdateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{ID: "template-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.templateRepository.On("Update", context.Background(), mock.Anything).
+					Return(entity.TemplateID(""), test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and output when successfully update template",
+			args:     args{ctx: context.Background(), payload: payload},
+			expected: expected{output: dto.TemplateUpdateOut{ID: "template-xxxxx"}, err: nil},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{ID: "template-xxxxx", UserID: "user-xxxxx", Name: "template_name", CreatedAt: test.TimeBeforeNow}, nil)
+
+				d.templateRepository.On("Update", context.Background(), &entity.Template{
+					ID:        "template-xxxxx",
+					UserID:    "user-xxxxx",
+					Name:      "new_template_name",
+					Tasks:     []entity.TemplateTask{{Content: "new_blueprint_content"}},
+					CreatedAt: test.TimeBeforeNow,
+				}).Return(entity.TemplateID("template-xxxxx"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.validator, d.templateRepository, d.taskRepository)
+			output, err := usecase.Update(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *TemplateUsecaseTestSuite) TestRemove() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TemplateRemoveIn
+	}
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when template repository FindByID return error",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateRemoveIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{err: domain.ErrTemplateNotFound},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{}, domain.ErrTemplateNotFound)
+			},
+		},
+		{
+			name:     "it should return error ErrTemplateAuthorization when template is owned by another user",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateRemoveIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{err: domain.ErrTemplateAuthorization},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{ID: "template-xxxxx", UserID: "user-yyyyy"}, nil)
+			},
+		},
+		{
+			name:     "it should return error when template repository DeleteByID return unexpected error",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateRemoveIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{ID: "template-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.templateRepository.On("DeleteByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully remove template",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateRemoveIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{ID: "template-xxxxx", UserID: "user-xxxxx"}, nil)
+
+				d.templateRepository.On("DeleteByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.validator, d.templateRepository, d.taskRepository)
+			err := usecase.Remove(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *TemplateUsecaseTestSuite) TestInstantiate() {
+	type args struct {
+		ctx     context.Context
+		payload *dto.TemplateInstantiateIn
+	}
+	type expected struct {
+		output dto.TemplateInstantiateOut
+		err    error
+	}
+	template := entity.Template{
+		ID:     "template-xxxxx",
+		UserID: "user-xxxxx",
+		Tasks: []entity.TemplateTask{
+			{Content: "Onboard {{name}}", DueOffsetDays: nullDays(2), Children: []entity.TemplateTask{{Content: "Prepare laptop"}}},
+		},
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when template repository FindByID return error",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{output: dto.TemplateInstantiateOut{}, err: domain.ErrTemplateNotFound},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{}, domain.ErrTemplateNotFound)
+			},
+		},
+		{
+			name:     "it should return error ErrTemplateAuthorization when template is owned by another user",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-yyyyy"}},
+			expected: expected{output: dto.TemplateInstantiateOut{}, err: domain.ErrTemplateAuthorization},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(template, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrTemplateVariableMissing when variable is not provided",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Anchor: "2026-11-01"}},
+			expected: expected{output: dto.TemplateInstantiateOut{}, err: entity.ErrTemplateVariableMissing},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(template, nil)
+			},
+		},
+		{
+			name:     "it should return empty task ids when template has no task blueprint",
+			args:     args{ctx: context.Background(), payload: &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx"}},
+			expected: expected{output: dto.TemplateInstantiateOut{TaskIDs: []entity.TaskID{}}, err: nil},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(entity.Template{ID: "template-xxxxx", UserID: "user-xxxxx"}, nil)
+			},
+		},
+		{
+			name: "it should return error when a rendered task is not valid",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Anchor: "2026-11-01", Variables: map[string]string{"name": "Gopher"}},
+			},
+			expected: expected{output: dto.TemplateInstantiateOut{}, err: dto.ErrContentEmpty},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(template, nil)
+
+				d.validator.On("Validate", &dto.TaskCreateIn{UserID: "user-xxxxx", Content: "Onboard Gopher", DueDate: nullDate(2026, 11, 3)}).
+					Return(dto.ErrContentEmpty)
+			},
+		},
+		{
+			name: "it should return error when task repository StoreMany return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Anchor: "2026-11-01", Variables: map[string]string{"name": "Gopher"}},
+			},
+			expected: expected{output: dto.TemplateInstantiateOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(template, nil)
+				d.validator.On("Validate", mock.Anything).Return(nil)
+
+				d.taskRepository.On("StoreMany", context.Background(), mock.Anything).
+					Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and created task ids when success",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.TemplateInstantiateIn{TemplateID: "template-xxxxx", UserID: "user-xxxxx", Anchor: "2026-11-01", Variables: map[string]string{"name": "Gopher"}},
+			},
+			expected: expected{output: dto.TemplateInstantiateOut{TaskIDs: []entity.TaskID{"task-xxxxx", "task-yyyyy"}}, err: nil},
+			setup: func(d *dependency) {
+				d.templateRepository.On("FindByID", context.Background(), entity.TemplateID("template-xxxxx")).
+					Return(template, nil)
+				d.validator.On("Validate", mock.Anything).Return(nil)
+
+				d.taskRepository.On("StoreMany", context.Background(), []entity.Task{
+					{UserID: "user-xxxxx", Content: "Onboard Gopher", DueDate: nullDate(2026, 11, 3)},
+					{UserID: "user-xxxxx", Content: "Prepare laptop"},
+				}).Return([]entity.TaskID{"task-xxxxx", "task-yyyyy"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := New(d.validator, d.templateRepository, d.taskRepository)
+			output, err := usecase.Instantiate(t.args.ctx, t.args.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
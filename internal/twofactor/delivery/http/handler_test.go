@@ -0,0 +1,309 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/pkg/errorx"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type TwoFactorHTTPHandlerTestSuite struct {
+	suite.Suite
+}
+
+func TestTwoFactorHTTPHandlerSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorHTTPHandlerTestSuite))
+}
+
+type dependency struct {
+	validator        *mocks.ValidatorProvider
+	twoFactorUsecase *mocks.TwoFactorUsecase
+}
+
+type expected struct {
+	contentType string
+	statusCode  int
+	message     string
+	error       string
+	payload     any
+}
+
+func (s *TwoFactorHTTPHandlerTestSuite) assertResponse(rr *httptest.ResponseRecorder, isError bool, expected expected) {
+	s.Equal(expected.contentType, rr.Header().Get("Content-Type"))
+	s.Equal(expected.statusCode, rr.Code)
+
+	if isError {
+		var resBody domain.ErrorResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.error, resBody.Error)
+	} else {
+		var resBody domain.SuccessResponse
+		json.NewDecoder(rr.Body).Decode(&resBody)
+
+		s.Equal(expected.statusCode, resBody.StatusCode)
+		s.Equal(expected.message, resBody.Message)
+		s.Equal(expected.payload, resBody.Payload)
+	}
+}
+
+func (s *TwoFactorHTTPHandlerTestSuite) TestPostSetup() {
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when two factor usecase Setup return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Two-factor authentication is already enabled",
+			},
+			setup: func(d *dependency) {
+				d.twoFactorUsecase.On("Setup", mock.Anything, &dto.TwoFactorSetupIn{UserID: "user-xxxxx"}).
+					Return(dto.TwoFactorSetupOut{}, domain.ErrTwoFactorAlreadyEnabled)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     http.StatusText(http.StatusOK),
+				payload: map[string]any{
+					"secret":  "SECRET",
+					"uri":     "otpauth://totp/Taskit:gopher@go.dev?secret=SECRET",
+					"qr_code": "data:image/png;base64,cW9kZQ==",
+				},
+			},
+			setup: func(d *dependency) {
+				d.twoFactorUsecase.On("Setup", mock.Anything, &dto.TwoFactorSetupIn{UserID: "user-xxxxx"}).
+					Return(dto.TwoFactorSetupOut{Secret: "SECRET", URI: "otpauth://totp/Taskit:gopher@go.dev?secret=SECRET", QRCode: "data:image/png;base64,cW9kZQ=="}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", nil)
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+
+			d := &dependency{
+				twoFactorUsecase: &mocks.TwoFactorUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(nil, d.twoFactorUsecase)
+			handler.PostSetup(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *TwoFactorHTTPHandlerTestSuite) TestPost() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.TwoFactorEnableIn{UserID: "user-xxxxx", IPAddress: "192.0.2.1"}).
+					Return(test.ErrValidator)
+			},
+		},
+		{
+			name:        "it should response with error when two factor usecase Enable return error",
+			isError:     true,
+			requestBody: []byte(`{"code":"123456"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Two-factor code is invalid",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.TwoFactorEnableIn{UserID: "user-xxxxx", Code: "123456", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.twoFactorUsecase.On("Enable", mock.Anything, &dto.TwoFactorEnableIn{UserID: "user-xxxxx", Code: "123456", IPAddress: "192.0.2.1"}).
+					Return(dto.TwoFactorEnableOut{}, domain.ErrTwoFactorCodeInvalid)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"code":"123456"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully enabled two-factor authentication",
+				payload: map[string]any{
+					"recovery_codes": []any{"abcde-fghij"},
+				},
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.TwoFactorEnableIn{UserID: "user-xxxxx", Code: "123456", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.twoFactorUsecase.On("Enable", mock.Anything, &dto.TwoFactorEnableIn{UserID: "user-xxxxx", Code: "123456", IPAddress: "192.0.2.1"}).
+					Return(dto.TwoFactorEnableOut{RecoveryCodes: []string{"abcde-fghij"}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/", bytes.NewReader(t.requestBody))
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+
+			d := &dependency{
+				validator:        &mocks.ValidatorProvider{},
+				twoFactorUsecase: &mocks.TwoFactorUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.twoFactorUsecase)
+			handler.Post(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
+
+func (s *TwoFactorHTTPHandlerTestSuite) TestDelete() {
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusInternalServerError,
+				message:     http.StatusText(http.StatusInternalServerError),
+				error:       errorx.InternalServerErrorMessage,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.TwoFactorDisableIn{UserID: "user-xxxxx", IPAddress: "192.0.2.1"}).
+					Return(test.ErrValidator)
+			},
+		},
+		{
+			name:        "it should response with error when two factor usecase Disable return error",
+			isError:     true,
+			requestBody: []byte(`{"password":"secret_password","code":"123456"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Two-factor authentication is not enabled",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.TwoFactorDisableIn{UserID: "user-xxxxx", Password: "secret_password", Code: "123456", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.twoFactorUsecase.On("Disable", mock.Anything, &dto.TwoFactorDisableIn{UserID: "user-xxxxx", Password: "secret_password", Code: "123456", IPAddress: "192.0.2.1"}).
+					Return(domain.ErrTwoFactorNotEnabled)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"password":"secret_password","code":"123456"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully disabled two-factor authentication",
+				payload:     nil,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.TwoFactorDisableIn{UserID: "user-xxxxx", Password: "secret_password", Code: "123456", IPAddress: "192.0.2.1"}).
+					Return(nil)
+
+				d.twoFactorUsecase.On("Disable", mock.Anything, &dto.TwoFactorDisableIn{UserID: "user-xxxxx", Password: "secret_password", Code: "123456", IPAddress: "192.0.2.1"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/", bytes.NewReader(t.requestBody))
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+
+			d := &dependency{
+				validator:        &mocks.ValidatorProvider{},
+				twoFactorUsecase: &mocks.TwoFactorUsecase{},
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.twoFactorUsecase)
+			handler.Delete(rr, req)
+
+			s.assertResponse(rr, t.isError, t.expected)
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new two-factor authentication repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a pending two-factor authentication, replacing the previous pending secret of the user.
+func (r *Repository) Store(ctx context.Context, t *entity.TwoFactor) error {
+	q := `INSERT INTO two_factors (user_id, secret) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, enabled_at = NULL, last_used_step = 0, created_at = NOW()`
+	_, err := r.db.ExecContext(ctx, q, t.UserID, t.Secret)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindByUserID find the two-factor authentication of a user.
+func (r *Repository) FindByUserID(ctx context.Context, userID entity.UserID) (entity.TwoFactor, error) {
+	var t entity.TwoFactor
+	q := `SELECT user_id, secret, enabled_at, last_used_step, created_at FROM two_factors WHERE user_id = $1`
+	err := r.db.QueryRowContext(ctx, q, userID).Scan(&t.UserID, &t.Secret, &t.EnabledAt, &t.LastUsedStep, &t.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return t, domain.ErrTwoFactorNotFound
+	} else if err != nil {
+		return t, err
+	}
+	return t, nil
+}
+
+// Enable confirm a pending two-factor authentication with the time step of its first code.
+func (r *Repository) Enable(ctx context.Context, userID entity.UserID, step int64) error {
+	q := `UPDATE two_factors SET enabled_at = NOW(), last_used_step = $2 WHERE user_id = $1`
+	_, err := r.db.ExecContext(ctx, q, userID, step)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UseStep record the time step of an accepted code.
+// It returns ErrTwoFactorStepUsed when the step, or a later one, was already used,
+// so the same code can't be replayed even by concurrent requests.
+func (r *Repository) UseStep(ctx context.Context, userID entity.UserID, step int64) error {
+	q := `UPDATE two_factors SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`
+	result, err := r.db.ExecContext(ctx, q, userID, step)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrTwoFactorStepUsed
+	}
+	return nil
+}
+
+// DeleteByUserID remove the two-factor authentication of a user with its recovery codes.
+func (r *Repository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM two_factors WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// StoreRecoveryCodes replace the recovery codes of a user.
+func (r *Repository) StoreRecoveryCodes(ctx context.Context, userID entity.UserID, codeHashes []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	q := `INSERT INTO recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)`
+	for _, codeHash := range codeHashes {
+		if _, err := tx.ExecContext(ctx, q, r.idProvider.Generate(), userID, codeHash); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ConsumeRecoveryCode delete a recovery code of a user so it can only be used once.
+func (r *Repository) ConsumeRecoveryCode(ctx context.Context, userID entity.UserID, codeHash string) error {
+	var id string
+	q := `DELETE FROM recovery_codes WHERE user_id = $1 AND code_hash = $2 RETURNING id`
+	err := r.db.QueryRowContext(ctx, q, userID, codeHash).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.ErrRecoveryCodeNotFound
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// StoreChallenge save a new mfa challenge to database.
+func (r *Repository) StoreChallenge(ctx context.Context, c *entity.MFAChallenge) error {
+	id := entity.MFAChallengeID(r.idProvider.Generate())
+	q := `INSERT INTO mfa_challenges (id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.ExecContext(ctx, q, id, c.UserID, c.TokenHash, c.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindChallengeByTokenHash find an mfa challenge by the hash of its token.
+func (r *Repository) FindChallengeByTokenHash(ctx context.Context, tokenHash string) (entity.MFAChallenge, error) {
+	var c entity.MFAChallenge
+	q := `SELECT id, user_id, token_hash, attempts, expires_at, created_at FROM mfa_challenges WHERE token_hash = $1`
+	err := r.db.QueryRowContext(ctx, q, tokenHash).Scan(&c.ID, &c.UserID, &c.TokenHash, &c.Attempts, &c.ExpiresAt, &c.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c, domain.ErrMFAChallengeNotFound
+	} else if err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// IncrementChallengeAttempts record a failed attempt on an mfa challenge.
+func (r *Repository) IncrementChallengeAttempts(ctx context.Context, challengeID entity.MFAChallengeID) error {
+	q := `UPDATE mfa_challenges SET attempts = attempts + 1 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, challengeID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteChallengeByID remove an mfa challenge by id.
+func (r *Repository) DeleteChallengeByID(ctx context.Context, challengeID entity.MFAChallengeID) error {
+	q := `DELETE FROM mfa_challenges WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, challengeID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// DeleteExpiredChallenges remove up to limit mfa challenges expired before the given time and return how many were removed.
+func (r *Repository) DeleteExpiredChallenges(ctx context.Context, before time.Time, limit int) (int64, error) {
+	q := `DELETE FROM mfa_challenges WHERE id IN (SELECT id FROM mfa_challenges WHERE expires_at < $1 LIMIT $2)`
+	result, err := r.db.ExecContext(ctx, q, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,790 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type TwoFactorRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestTwoFactorRepositorySuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	storeQuery                      = regexp.QuoteMeta(`INSERT INTO two_factors (user_id, secret) VALUES ($1, $2) ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, enabled_at = NULL, last_used_step = 0, created_at = NOW()`)
+	findByUserIDQuery               = regexp.QuoteMeta(`SELECT user_id, secret, enabled_at, last_used_step, created_at FROM two_factors WHERE user_id = $1`)
+	enableQuery                     = regexp.QuoteMeta(`UPDATE two_factors SET enabled_at = NOW(), last_used_step = $2 WHERE user_id = $1`)
+	useStepQuery                    = regexp.QuoteMeta(`UPDATE two_factors SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`)
+	deleteRecoveryCodesQuery        = regexp.QuoteMeta(`DELETE FROM recovery_codes WHERE user_id = $1`)
+	deleteTwoFactorQuery            = regexp.QuoteMeta(`DELETE FROM two_factors WHERE user_id = $1`)
+	insertRecoveryCodeQuery         = regexp.QuoteMeta(`INSERT INTO recovery_codes (id, user_id, code_hash) VALUES ($1, $2, $3)`)
+	consumeRecoveryCodeQuery        = regexp.QuoteMeta(`DELETE FROM recovery_codes WHERE user_id = $1 AND code_hash = $2 RETURNING id`)
+	storeChallengeQuery             = regexp.QuoteMeta(`INSERT INTO mfa_challenges (id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4)`)
+	findChallengeByTokenHashQuery   = regexp.QuoteMeta(`SELECT id, user_id, token_hash, attempts, expires_at, created_at FROM mfa_challenges WHERE token_hash = $1`)
+	incrementChallengeAttemptsQuery = regexp.QuoteMeta(`UPDATE mfa_challenges SET attempts = attempts + 1 WHERE id = $1`)
+	deleteChallengeByIDQuery        = regexp.QuoteMeta(`DELETE FROM mfa_challenges WHERE id = $1`)
+	deleteExpiredChallengesQuery    = regexp.QuoteMeta(`DELETE FROM mfa_challenges WHERE id IN (SELECT id FROM mfa_challenges WHERE expires_at < $1 LIMIT $2)`)
+)
+
+func (s *TwoFactorRepositoryTestSuite) TestStore() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("user-xxxxx", "SECRET").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(storeQuery).
+					WithArgs("user-xxxxx", "SECRET").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.Store(context.Background(), &entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET"})
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestFindByUserID() {
+	type expected struct {
+		twoFactor entity.TwoFactor
+		err       error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{twoFactor: entity.TwoFactor{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorNotFound when row not found",
+			expected: expected{twoFactor: entity.TwoFactor{}, err: domain.ErrTwoFactorNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "it should return error nil and two factor when found",
+			expected: expected{
+				twoFactor: entity.TwoFactor{
+					UserID:       "user-xxxxx",
+					Secret:       "SECRET",
+					EnabledAt:    entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
+					LastUsedStep: 55555,
+					CreatedAt:    test.TimeBeforeNow,
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"user_id", "secret", "enabled_at", "last_used_step", "created_at"}).
+					AddRow("user-xxxxx", "SECRET", test.TimeBeforeNow, 55555, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			twoFactor, err := repository.FindByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.twoFactor, twoFactor)
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestEnable() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(enableQuery).
+					WithArgs("user-xxxxx", 55555).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully enable",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(enableQuery).
+					WithArgs("user-xxxxx", 55555).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.Enable(context.Background(), "user-xxxxx", 55555)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestUseStep() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(useStepQuery).
+					WithArgs("user-xxxxx", 55555).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when database fail to report affected rows",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(useStepQuery).
+					WithArgs("user-xxxxx", 55555).
+					WillReturnResult(sqlmock.NewErrorResult(test.ErrDatabase))
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorStepUsed when step was already used",
+			expected: domain.ErrTwoFactorStepUsed,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(useStepQuery).
+					WithArgs("user-xxxxx", 55555).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name:     "it should return error nil when successfully use step",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(useStepQuery).
+					WithArgs("user-xxxxx", 55555).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.UseStep(context.Background(), "user-xxxxx", 55555)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestDeleteByUserID() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to begin transaction",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to delete recovery codes",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(deleteRecoveryCodesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to delete two factor",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(deleteRecoveryCodesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				d.mockDB.ExpectExec(deleteTwoFactorQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to commit transaction",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(deleteRecoveryCodesQuery).
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				d.mockDB.ExpectExec(deleteTwoFactorQuery).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(deleteRecoveryCodesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 10))
+				d.mockDB.ExpectExec(deleteTwoFactorQuery).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.DeleteByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestStoreRecoveryCodes() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to begin transaction",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to delete previous codes",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(deleteRecoveryCodesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error and rollback when database fail to store a code",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("recovery-code-xxxxx").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(deleteRecoveryCodesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				d.mockDB.ExpectExec(insertRecoveryCodeQuery).
+					WithArgs("recovery-code-xxxxx", "user-xxxxx", "hash_a").
+					WillReturnError(test.ErrDatabase)
+				d.mockDB.ExpectRollback()
+			},
+		},
+		{
+			name:     "it should return error when database fail to commit transaction",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("recovery-code-xxxxx")
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(deleteRecoveryCodesQuery).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				d.mockDB.ExpectExec(insertRecoveryCodeQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertRecoveryCodeQuery).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit().WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store all codes",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("recovery-code-xxxxx").Once()
+				d.idProvider.On("Generate").Return("recovery-code-yyyyy").Once()
+
+				d.mockDB.ExpectBegin()
+				d.mockDB.ExpectExec(deleteRecoveryCodesQuery).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				d.mockDB.ExpectExec(insertRecoveryCodeQuery).
+					WithArgs("recovery-code-xxxxx", "user-xxxxx", "hash_a").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectExec(insertRecoveryCodeQuery).
+					WithArgs("recovery-code-yyyyy", "user-xxxxx", "hash_b").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				d.mockDB.ExpectCommit()
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.StoreRecoveryCodes(context.Background(), "user-xxxxx", []string{"hash_a", "hash_b"})
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestConsumeRecoveryCode() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeRecoveryCodeQuery).
+					WithArgs("user-xxxxx", "hash_a").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrRecoveryCodeNotFound when code is unknown or already used",
+			expected: domain.ErrRecoveryCodeNotFound,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeRecoveryCodeQuery).
+					WithArgs("user-xxxxx", "hash_a").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully consume",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeRecoveryCodeQuery).
+					WithArgs("user-xxxxx", "hash_a").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("recovery-code-xxxxx"))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.ConsumeRecoveryCode(context.Background(), "user-xxxxx", "hash_a")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestStoreChallenge() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("mfa-challenge-xxxxx")
+
+				d.mockDB.ExpectExec(storeChallengeQuery).
+					WithArgs("mfa-challenge-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("mfa-challenge-xxxxx")
+
+				d.mockDB.ExpectExec(storeChallengeQuery).
+					WithArgs("mfa-challenge-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.StoreChallenge(context.Background(), &entity.MFAChallenge{UserID: "user-xxxxx", TokenHash: "token_hash", ExpiresAt: test.TimeAfterNow})
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestFindChallengeByTokenHash() {
+	type expected struct {
+		challenge entity.MFAChallenge
+		err       error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to find",
+			expected: expected{challenge: entity.MFAChallenge{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findChallengeByTokenHashQuery).
+					WithArgs("token_hash").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrMFAChallengeNotFound when row not found",
+			expected: expected{challenge: entity.MFAChallenge{}, err: domain.ErrMFAChallengeNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(findChallengeByTokenHashQuery).
+					WithArgs("token_hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name: "it should return error nil and challenge when found",
+			expected: expected{
+				challenge: entity.MFAChallenge{ID: "mfa-challenge-xxxxx", UserID: "user-xxxxx", TokenHash: "token_hash", Attempts: 2, ExpiresAt: test.TimeAfterNow, CreatedAt: test.TimeBeforeNow},
+				err:       nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "token_hash", "attempts", "expires_at", "created_at"}).
+					AddRow("mfa-challenge-xxxxx", "user-xxxxx", "token_hash", 2, test.TimeAfterNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(findChallengeByTokenHashQuery).
+					WithArgs("token_hash").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			challenge, err := repository.FindChallengeByTokenHash(context.Background(), "token_hash")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.challenge, challenge)
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestIncrementChallengeAttempts() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(incrementChallengeAttemptsQuery).
+					WithArgs("mfa-challenge-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully increment",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(incrementChallengeAttemptsQuery).
+					WithArgs("mfa-challenge-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.IncrementChallengeAttempts(context.Background(), "mfa-challenge-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestDeleteChallengeByID() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteChallengeByIDQuery).
+					WithArgs("mfa-challenge-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteChallengeByIDQuery).
+					WithArgs("mfa-challenge-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.DeleteChallengeByID(context.Background(), "mfa-challenge-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *TwoFactorRepositoryTestSuite) TestDeleteExpiredChallenges() {
+	type expected struct {
+		deleted int64
+		err     error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{deleted: 0, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteExpiredChallengesQuery).
+					WithArgs(test.TimeBeforeNow, 100).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and the number of deleted challenges when successfully delete",
+			expected: expected{deleted: 42, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteExpiredChallengesQuery).
+					WithArgs(test.TimeBeforeNow, 100).
+					WillReturnResult(sqlmock.NewResult(0, 42))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			deleted, err := repository.DeleteExpiredChallenges(context.Background(), test.TimeBeforeNow, 100)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.deleted, deleted)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// recoveryCodeCount is how many one-time recovery codes are issued when enabling two-factor authentication.
+const recoveryCodeCount = 10
+
+type Usecase struct {
+	twoFactorRepository     domain.TwoFactorRepository
+	userRepository          domain.UserRepository
+	securityEventRepository domain.SecurityEventRepository
+	hashProvider            domain.HashProvider
+	tokenProvider           domain.TokenProvider
+	totpProvider            domain.TOTPProvider
+}
+
+// New create a new two-factor authentication usecase.
+func New(
+	twoFactorRepository domain.TwoFactorRepository,
+	userRepository domain.UserRepository,
+	securityEventRepository domain.SecurityEventRepository,
+	hashProvider domain.HashProvider,
+	tokenProvider domain.TokenProvider,
+	totpProvider domain.TOTPProvider,
+) Usecase {
+	return Usecase{
+		twoFactorRepository:     twoFactorRepository,
+		userRepository:          userRepository,
+		securityEventRepository: securityEventRepository,
+		hashProvider:            hashProvider,
+		tokenProvider:           tokenProvider,
+		totpProvider:            totpProvider,
+	}
+}
+
+// Setup generate a new pending secret for the user, replacing any previous pending one.
+func (u *Usecase) Setup(ctx context.Context, payload *dto.TwoFactorSetupIn) (dto.TwoFactorSetupOut, error) {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return dto.TwoFactorSetupOut{}, err
+	}
+
+	twoFactor, err := u.twoFactorRepository.FindByUserID(ctx, payload.UserID)
+	if err != nil && !errors.Is(err, domain.ErrTwoFactorNotFound) {
+		return dto.TwoFactorSetupOut{}, err
+	}
+	if twoFactor.IsEnabled() {
+		return dto.TwoFactorSetupOut{}, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := u.totpProvider.GenerateSecret()
+	if err != nil {
+		return dto.TwoFactorSetupOut{}, err
+	}
+	if err := u.twoFactorRepository.Store(ctx, &entity.TwoFactor{UserID: user.ID, Secret: secret}); err != nil {
+		return dto.TwoFactorSetupOut{}, err
+	}
+
+	uri := u.totpProvider.URI(secret, user.Email)
+	png, err := u.totpProvider.QRCode(uri)
+	if err != nil {
+		return dto.TwoFactorSetupOut{}, err
+	}
+
+	return dto.TwoFactorSetupOut{
+		Secret: secret,
+		URI:    uri,
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
+
+// Enable confirm the pending secret with a first code and issue the recovery codes.
+// The recovery codes are only returned here, the database keeps their hashes.
+func (u *Usecase) Enable(ctx context.Context, payload *dto.TwoFactorEnableIn) (dto.TwoFactorEnableOut, error) {
+	twoFactor, err := u.twoFactorRepository.FindByUserID(ctx, payload.UserID)
+	if errors.Is(err, domain.ErrTwoFactorNotFound) {
+		return dto.TwoFactorEnableOut{}, domain.ErrTwoFactorNotSetup
+	} else if err != nil {
+		return dto.TwoFactorEnableOut{}, err
+	}
+	if twoFactor.IsEnabled() {
+		return dto.TwoFactorEnableOut{}, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok := u.totpProvider.Validate(twoFactor.Secret, payload.Code, time.Now())
+	if !ok {
+		return dto.TwoFactorEnableOut{}, domain.ErrTwoFactorCodeInvalid
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	codeHashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		code, err := u.totpProvider.GenerateRecoveryCode()
+		if err != nil {
+			return dto.TwoFactorEnableOut{}, err
+		}
+		codes[i] = code
+		codeHashes[i] = u.tokenProvider.Hash(entity.NormalizeRecoveryCode(code))
+	}
+
+	if err := u.twoFactorRepository.StoreRecoveryCodes(ctx, payload.UserID, codeHashes); err != nil {
+		return dto.TwoFactorEnableOut{}, err
+	}
+	if err := u.twoFactorRepository.Enable(ctx, payload.UserID, step); err != nil {
+		return dto.TwoFactorEnableOut{}, err
+	}
+	event := &entity.SecurityEvent{UserID: payload.UserID, Type: entity.SecurityEventTwoFactorEnable, UserAgent: payload.UserAgent, IPAddress: payload.IPAddress}
+	if err := u.securityEventRepository.Store(ctx, event); err != nil {
+		return dto.TwoFactorEnableOut{}, err
+	}
+
+	return dto.TwoFactorEnableOut{RecoveryCodes: codes}, nil
+}
+
+// Disable turn off two-factor authentication, it requires both the password and a current code.
+func (u *Usecase) Disable(ctx context.Context, payload *dto.TwoFactorDisableIn) error {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.hashProvider.Compare(payload.Password, user.Password); err != nil {
+		return domain.ErrPasswordIncorrect
+	}
+
+	twoFactor, err := u.twoFactorRepository.FindByUserID(ctx, payload.UserID)
+	if errors.Is(err, domain.ErrTwoFactorNotFound) {
+		return domain.ErrTwoFactorNotEnabled
+	} else if err != nil {
+		return err
+	}
+	if !twoFactor.IsEnabled() {
+		return domain.ErrTwoFactorNotEnabled
+	}
+
+	step, ok := u.totpProvider.Validate(twoFactor.Secret, payload.Code, time.Now())
+	if !ok {
+		return domain.ErrTwoFactorCodeInvalid
+	}
+	err = u.twoFactorRepository.UseStep(ctx, payload.UserID, step)
+	if errors.Is(err, domain.ErrTwoFactorStepUsed) {
+		return domain.ErrTwoFactorCodeInvalid
+	} else if err != nil {
+		return err
+	}
+
+	if err := u.twoFactorRepository.DeleteByUserID(ctx, payload.UserID); err != nil {
+		return err
+	}
+
+	event := &entity.SecurityEvent{UserID: payload.UserID, Type: entity.SecurityEventTwoFactorDisable, UserAgent: payload.UserAgent, IPAddress: payload.IPAddress}
+	return u.securityEventRepository.Store(ctx, event)
+}
@@ -0,0 +1,461 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type TwoFactorUsecaseTestSuite struct {
+	suite.Suite
+}
+
+func TestTwoFactorUsecaseSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorUsecaseTestSuite))
+}
+
+type dependency struct {
+	twoFactorRepository     *mocks.TwoFactorRepository
+	userRepository          *mocks.UserRepository
+	securityEventRepository *mocks.SecurityEventRepository
+	hashProvider            *mocks.HashProvider
+	tokenProvider           *mocks.TokenProvider
+	totpProvider            *mocks.TOTPProvider
+}
+
+func newDependency() *dependency {
+	return &dependency{
+		twoFactorRepository:     &mocks.TwoFactorRepository{},
+		userRepository:          &mocks.UserRepository{},
+		securityEventRepository: &mocks.SecurityEventRepository{},
+		hashProvider:            &mocks.HashProvider{},
+		tokenProvider:           &mocks.TokenProvider{},
+		totpProvider:            &mocks.TOTPProvider{},
+	}
+}
+
+func newUsecase(d *dependency) Usecase {
+	return New(d.twoFactorRepository, d.userRepository, d.securityEventRepository, d.hashProvider, d.tokenProvider, d.totpProvider)
+}
+
+var (
+	user             = entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", Password: "hashed_password"}
+	pendingTwoFactor = entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET"}
+	enabledTwoFactor = entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET", EnabledAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, LastUsedStep: 55554}
+)
+
+func (s *TwoFactorUsecaseTestSuite) TestSetup() {
+	payload := &dto.TwoFactorSetupIn{UserID: "user-xxxxx"}
+
+	type expected struct {
+		output dto.TwoFactorSetupOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			expected: expected{output: dto.TwoFactorSetupOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository FindByUserID return unexpected error",
+			expected: expected{output: dto.TwoFactorSetupOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorAlreadyEnabled when two factor is already enabled",
+			expected: expected{output: dto.TwoFactorSetupOut{}, err: domain.ErrTwoFactorAlreadyEnabled},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(enabledTwoFactor, nil)
+			},
+		},
+		{
+			name:     "it should return error when totp provider GenerateSecret return unexpected error",
+			expected: expected{output: dto.TwoFactorSetupOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+				d.totpProvider.On("GenerateSecret").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository Store return unexpected error",
+			expected: expected{output: dto.TwoFactorSetupOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+				d.totpProvider.On("GenerateSecret").Return("SECRET", nil)
+				d.twoFactorRepository.On("Store", context.Background(), &entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when totp provider QRCode return unexpected error",
+			expected: expected{output: dto.TwoFactorSetupOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+				d.totpProvider.On("GenerateSecret").Return("SECRET", nil)
+				d.twoFactorRepository.On("Store", context.Background(), &entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET"}).
+					Return(nil)
+				d.totpProvider.On("URI", "SECRET", "gopher@go.dev").Return("otpauth://totp/Taskit:gopher@go.dev?secret=SECRET")
+				d.totpProvider.On("QRCode", "otpauth://totp/Taskit:gopher@go.dev?secret=SECRET").Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and output when successfully replace a pending secret",
+			expected: expected{
+				output: dto.TwoFactorSetupOut{
+					Secret: "SECRET",
+					URI:    "otpauth://totp/Taskit:gopher@go.dev?secret=SECRET",
+					QRCode: "data:image/png;base64,iVBORw==",
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(pendingTwoFactor, nil)
+				d.totpProvider.On("GenerateSecret").Return("SECRET", nil)
+				d.twoFactorRepository.On("Store", context.Background(), &entity.TwoFactor{UserID: "user-xxxxx", Secret: "SECRET"}).
+					Return(nil)
+				d.totpProvider.On("URI", "SECRET", "gopher@go.dev").Return("otpauth://totp/Taskit:gopher@go.dev?secret=SECRET")
+				d.totpProvider.On("QRCode", "otpauth://totp/Taskit:gopher@go.dev?secret=SECRET").Return([]byte("\x89PNG"), nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.Setup(context.Background(), payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *TwoFactorUsecaseTestSuite) TestEnable() {
+	payload := &dto.TwoFactorEnableIn{UserID: "user-xxxxx", Code: "123456", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}
+
+	type expected struct {
+		output dto.TwoFactorEnableOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrTwoFactorNotSetup when two factor is not setup",
+			expected: expected{output: dto.TwoFactorEnableOut{}, err: domain.ErrTwoFactorNotSetup},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository FindByUserID return unexpected error",
+			expected: expected{output: dto.TwoFactorEnableOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorAlreadyEnabled when two factor is already enabled",
+			expected: expected{output: dto.TwoFactorEnableOut{}, err: domain.ErrTwoFactorAlreadyEnabled},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(enabledTwoFactor, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorCodeInvalid when code is invalid",
+			expected: expected{output: dto.TwoFactorEnableOut{}, err: domain.ErrTwoFactorCodeInvalid},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(pendingTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(0), false)
+			},
+		},
+		{
+			name:     "it should return error when totp provider GenerateRecoveryCode return unexpected error",
+			expected: expected{output: dto.TwoFactorEnableOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(pendingTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.totpProvider.On("GenerateRecoveryCode").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository StoreRecoveryCodes return unexpected error",
+			expected: expected{output: dto.TwoFactorEnableOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(pendingTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.totpProvider.On("GenerateRecoveryCode").Return("abcde-fghij", nil)
+				d.tokenProvider.On("Hash", "abcde-fghij").Return("code_hash")
+				d.twoFactorRepository.On("StoreRecoveryCodes", context.Background(), entity.UserID("user-xxxxx"), mock.Anything).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository Enable return unexpected error",
+			expected: expected{output: dto.TwoFactorEnableOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(pendingTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.totpProvider.On("GenerateRecoveryCode").Return("abcde-fghij", nil)
+				d.tokenProvider.On("Hash", "abcde-fghij").Return("code_hash")
+				d.twoFactorRepository.On("StoreRecoveryCodes", context.Background(), entity.UserID("user-xxxxx"), mock.Anything).
+					Return(nil)
+				d.twoFactorRepository.On("Enable", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when security event repository Store return unexpected error on enable",
+			expected: expected{output: dto.TwoFactorEnableOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(pendingTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.totpProvider.On("GenerateRecoveryCode").Return("abcde-fghij", nil)
+				d.tokenProvider.On("Hash", "abcde-fghij").Return("code_hash")
+				d.twoFactorRepository.On("StoreRecoveryCodes", context.Background(), entity.UserID("user-xxxxx"), mock.Anything).
+					Return(nil)
+				d.twoFactorRepository.On("Enable", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventTwoFactorEnable, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and recovery codes when successfully enable",
+			expected: expected{
+				output: dto.TwoFactorEnableOut{RecoveryCodes: []string{
+					"abcde-fghij", "abcde-fghij", "abcde-fghij", "abcde-fghij", "abcde-fghij",
+					"abcde-fghij", "abcde-fghij", "abcde-fghij", "abcde-fghij", "abcde-fghij",
+				}},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(pendingTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.totpProvider.On("GenerateRecoveryCode").Return("abcde-fghij", nil)
+				d.tokenProvider.On("Hash", "abcde-fghij").Return("code_hash")
+				d.twoFactorRepository.On("StoreRecoveryCodes", context.Background(), entity.UserID("user-xxxxx"), []string{
+					"code_hash", "code_hash", "code_hash", "code_hash", "code_hash",
+					"code_hash", "code_hash", "code_hash", "code_hash", "code_hash",
+				}).Return(nil)
+				d.twoFactorRepository.On("Enable", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventTwoFactorEnable, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.Enable(context.Background(), payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *TwoFactorUsecaseTestSuite) TestDisable() {
+	payload := &dto.TwoFactorDisableIn{UserID: "user-xxxxx", Password: "secret_password", Code: "123456", UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrPasswordIncorrect when password does not match",
+			expected: domain.ErrPasswordIncorrect,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorNotEnabled when two factor is not found",
+			expected: domain.ErrTwoFactorNotEnabled,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, domain.ErrTwoFactorNotFound)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository FindByUserID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.TwoFactor{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorNotEnabled when two factor is still pending",
+			expected: domain.ErrTwoFactorNotEnabled,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(pendingTwoFactor, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorCodeInvalid when code is invalid",
+			expected: domain.ErrTwoFactorCodeInvalid,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(enabledTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(0), false)
+			},
+		},
+		{
+			name:     "it should return error ErrTwoFactorCodeInvalid when code was already used",
+			expected: domain.ErrTwoFactorCodeInvalid,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(enabledTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55554), true)
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55554)).
+					Return(domain.ErrTwoFactorStepUsed)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository UseStep return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(enabledTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when two factor repository DeleteByUserID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(enabledTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(nil)
+				d.twoFactorRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when security event repository Store return unexpected error on disable",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(enabledTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(nil)
+				d.twoFactorRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventTwoFactorDisable, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully disable",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+				d.hashProvider.On("Compare", "secret_password", "hashed_password").Return(nil)
+				d.twoFactorRepository.On("FindByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(enabledTwoFactor, nil)
+				d.totpProvider.On("Validate", "SECRET", "123456", mock.Anything).Return(int64(55555), true)
+				d.twoFactorRepository.On("UseStep", context.Background(), entity.UserID("user-xxxxx"), int64(55555)).
+					Return(nil)
+				d.twoFactorRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventTwoFactorDisable, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Disable(context.Background(), payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
 	"github.com/edwintantawi/taskit/internal/domain/mocks"
 	"github.com/edwintantawi/taskit/pkg/errorx"
 	"github.com/edwintantawi/taskit/test"
@@ -163,3 +164,549 @@ func (s *UserHTTPHandlerTestSuite) TestPost() {
 		})
 	}
 }
+
+func (s *UserHTTPHandlerTestSuite) TestPostVerify() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Token is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.UserVerifyIn{}).
+					Return(dto.ErrTokenEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when user usecase Verify return error",
+			isError:     true,
+			requestBody: []byte(`{"token":"raw_token"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Verification token is invalid",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.UserVerifyIn{Token: "raw_token"}).
+					Return(nil)
+
+				d.userUsecase.On("Verify", mock.Anything, &dto.UserVerifyIn{Token: "raw_token"}).
+					Return(domain.ErrVerificationTokenInvalid)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"token":"raw_token"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully verified email",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.UserVerifyIn{Token: "raw_token"}).
+					Return(nil)
+
+				d.userUsecase.On("Verify", mock.Anything, &dto.UserVerifyIn{Token: "raw_token"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/verify", bytes.NewReader(t.requestBody))
+
+			d := &dependency{
+				validator:   &mocks.ValidatorProvider{},
+				userUsecase: &mocks.UserUsecase{},
+				req:         req,
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.userUsecase)
+			handler.PostVerify(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Nil(resBody.Payload)
+			}
+		})
+	}
+}
+
+func (s *UserHTTPHandlerTestSuite) TestPostVerifyResend() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name     string
+		isError  bool
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:    "it should response with error when user usecase ResendVerification return error",
+			isError: true,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusTooManyRequests,
+				message:     http.StatusText(http.StatusTooManyRequests),
+				error:       "Verification email was sent recently, please try again later",
+			},
+			setup: func(d *dependency) {
+				d.userUsecase.On("ResendVerification", mock.Anything, &dto.UserResendVerificationIn{UserID: "user-xxxxx"}).
+					Return(domain.ErrVerificationThrottled)
+			},
+		},
+		{
+			name:    "it should response with success when success",
+			isError: false,
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully sent verification email",
+			},
+			setup: func(d *dependency) {
+				d.userUsecase.On("ResendVerification", mock.Anything, &dto.UserResendVerificationIn{UserID: "user-xxxxx"}).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/verify/resend", nil)
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+
+			d := &dependency{
+				userUsecase: &mocks.UserUsecase{},
+				req:         req,
+			}
+			t.setup(d)
+
+			handler := New(nil, d.userUsecase)
+			handler.PostVerifyResend(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Nil(resBody.Payload)
+			}
+		})
+	}
+}
+
+func (s *UserHTTPHandlerTestSuite) TestPutMe() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     map[string]any
+	}
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Email is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.UserUpdateIn{UserID: "user-xxxxx"}).
+					Return(dto.ErrEmailEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when user usecase Update return error",
+			isError:     true,
+			requestBody: []byte(`{"name":"Gopher","email":"gopher@golang.org"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Email is not available",
+			},
+			setup: func(d *dependency) {
+				payload := &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher", Email: "gopher@golang.org"}
+				d.validator.On("Validate", payload).Return(nil)
+				d.userUsecase.On("Update", mock.Anything, payload).
+					Return(dto.UserUpdateOut{}, domain.ErrEmailNotAvailable)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"name":"Gopher","email":"gopher@golang.org"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully updated user",
+				payload: map[string]any{
+					"id":             "user-xxxxx",
+					"name":           "Gopher",
+					"email":          "gopher@golang.org",
+					"email_verified": false,
+				},
+			},
+			setup: func(d *dependency) {
+				payload := &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher", Email: "gopher@golang.org"}
+				d.validator.On("Validate", payload).Return(nil)
+				d.userUsecase.On("Update", mock.Anything, payload).
+					Return(dto.UserUpdateOut{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@golang.org"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/me", bytes.NewReader(t.requestBody))
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+
+			d := &dependency{
+				validator:   &mocks.ValidatorProvider{},
+				userUsecase: &mocks.UserUsecase{},
+				req:         req,
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.userUsecase)
+			handler.PutMe(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, resBody.Payload)
+			}
+		})
+	}
+}
+
+func (s *UserHTTPHandlerTestSuite) TestPutMePassword() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+		payload     any
+	}
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Current password is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.UserChangePasswordIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx", IPAddress: "192.0.2.1"}).
+					Return(dto.ErrCurrentPasswordEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when user usecase ChangePassword return error",
+			isError:     true,
+			requestBody: []byte(`{"current_password":"current_password","new_password":"new_password"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Password is incorrect",
+			},
+			setup: func(d *dependency) {
+				payload := &dto.UserChangePasswordIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx", CurrentPassword: "current_password", NewPassword: "new_password", IPAddress: "192.0.2.1"}
+				d.validator.On("Validate", payload).Return(nil)
+				d.userUsecase.On("ChangePassword", mock.Anything, payload).
+					Return(dto.UserChangePasswordOut{}, domain.ErrPasswordIncorrect)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"current_password":"current_password","new_password":"new_password"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully changed password",
+				payload:     map[string]any{"access_token": "access_token"},
+			},
+			setup: func(d *dependency) {
+				payload := &dto.UserChangePasswordIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx", CurrentPassword: "current_password", NewPassword: "new_password", IPAddress: "192.0.2.1"}
+				d.validator.On("Validate", payload).Return(nil)
+				d.userUsecase.On("ChangePassword", mock.Anything, payload).
+					Return(dto.UserChangePasswordOut{AccessToken: "access_token"}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("PUT", "/me/password", bytes.NewReader(t.requestBody))
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+			req = test.InjectAuthSessionContext(req, entity.AuthID("auth-xxxxx"))
+
+			d := &dependency{
+				validator:   &mocks.ValidatorProvider{},
+				userUsecase: &mocks.UserUsecase{},
+				req:         req,
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.userUsecase)
+			handler.PutMePassword(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.payload, resBody.Payload)
+			}
+		})
+	}
+}
+
+func (s *UserHTTPHandlerTestSuite) TestDeleteMe() {
+	type expected struct {
+		contentType string
+		statusCode  int
+		message     string
+		error       string
+	}
+	tests := []struct {
+		name        string
+		isError     bool
+		requestBody []byte
+		expected    expected
+		setup       func(d *dependency)
+	}{
+		{
+			name:        "it should response with error when request body is invalid or not provided",
+			isError:     true,
+			requestBody: []byte(`{`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Invalid request body",
+			},
+			setup: func(d *dependency) {},
+		},
+		{
+			name:        "it should response with error when payload is not valid",
+			isError:     true,
+			requestBody: []byte(`{}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Password is required field",
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", &dto.UserDeleteIn{UserID: "user-xxxxx"}).
+					Return(dto.ErrPasswordEmpty)
+			},
+		},
+		{
+			name:        "it should response with error when user usecase Delete return error",
+			isError:     true,
+			requestBody: []byte(`{"password":"password"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusBadRequest,
+				message:     http.StatusText(http.StatusBadRequest),
+				error:       "Password is incorrect",
+			},
+			setup: func(d *dependency) {
+				payload := &dto.UserDeleteIn{UserID: "user-xxxxx", Password: "password"}
+				d.validator.On("Validate", payload).Return(nil)
+				d.userUsecase.On("Delete", mock.Anything, payload).
+					Return(domain.ErrPasswordIncorrect)
+			},
+		},
+		{
+			name:        "it should response with success when success",
+			isError:     false,
+			requestBody: []byte(`{"password":"password"}`),
+			expected: expected{
+				contentType: "application/json",
+				statusCode:  http.StatusOK,
+				message:     "Successfully deleted user",
+			},
+			setup: func(d *dependency) {
+				payload := &dto.UserDeleteIn{UserID: "user-xxxxx", Password: "password"}
+				d.validator.On("Validate", payload).Return(nil)
+				d.userUsecase.On("Delete", mock.Anything, payload).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest("DELETE", "/me", bytes.NewReader(t.requestBody))
+			req = test.InjectAuthContext(req, entity.UserID("user-xxxxx"))
+
+			d := &dependency{
+				validator:   &mocks.ValidatorProvider{},
+				userUsecase: &mocks.UserUsecase{},
+				req:         req,
+			}
+			t.setup(d)
+
+			handler := New(d.validator, d.userUsecase)
+			handler.DeleteMe(rr, d.req)
+
+			s.Equal(t.expected.contentType, rr.Header().Get("Content-Type"))
+			s.Equal(t.expected.statusCode, rr.Code)
+
+			if t.isError {
+				var resBody domain.ErrorResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Equal(t.expected.error, resBody.Error)
+			} else {
+				var resBody domain.SuccessResponse
+				json.NewDecoder(rr.Body).Decode(&resBody)
+
+				s.Equal(t.expected.statusCode, resBody.StatusCode)
+				s.Equal(t.expected.message, resBody.Message)
+				s.Nil(resBody.Payload)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
@@ -30,6 +31,18 @@ func (r *Repository) Store(ctx context.Context, u *entity.User) (entity.UserID,
 	return id, nil
 }
 
+// StoreServiceAccount save a new verified service account without a password to database,
+// in a single insert so there is never an account left half way created.
+func (r *Repository) StoreServiceAccount(ctx context.Context, u *entity.User) (entity.UserID, error) {
+	id := entity.UserID(r.idProvider.Generate())
+	q := `INSERT INTO users (id, name, email, password, role, email_verified_at) VALUES ($1, $2, $3, '', $4, NOW())`
+	_, err := r.db.ExecContext(ctx, q, id, u.Name, u.Email, entity.RoleServiceAccount)
+	if err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
 // VerifyAvailableEmail check if the email is available.
 func (r *Repository) VerifyAvailableEmail(ctx context.Context, email string) error {
 	var id entity.UserID
@@ -46,8 +59,8 @@ func (r *Repository) VerifyAvailableEmail(ctx context.Context, email string) err
 // FindByEmail find a user by email.
 func (r *Repository) FindByEmail(ctx context.Context, email string) (entity.User, error) {
 	var u entity.User
-	q := `SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1`
-	err := r.db.QueryRowContext(ctx, q, email).Scan(&u.ID, &u.Name, &u.Email, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+	q := `SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE email = $1`
+	err := r.db.QueryRowContext(ctx, q, email).Scan(&u.ID, &u.Name, &u.Email, &u.Password, &u.Role, &u.EmailVerifiedAt, &u.SuspendedAt, &u.CreatedAt, &u.UpdatedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return u, domain.ErrUserNotFound
 	} else if err != nil {
@@ -59,8 +72,8 @@ func (r *Repository) FindByEmail(ctx context.Context, email string) (entity.User
 // FindByID find a user by id.
 func (r *Repository) FindByID(ctx context.Context, id entity.UserID) (entity.User, error) {
 	var u entity.User
-	q := `SELECT id, name, email, password, created_at, updated_at FROM users WHERE id = $1`
-	err := r.db.QueryRowContext(ctx, q, id).Scan(&u.ID, &u.Name, &u.Email, &u.Password, &u.CreatedAt, &u.UpdatedAt)
+	q := `SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE id = $1`
+	err := r.db.QueryRowContext(ctx, q, id).Scan(&u.ID, &u.Name, &u.Email, &u.Password, &u.Role, &u.EmailVerifiedAt, &u.SuspendedAt, &u.CreatedAt, &u.UpdatedAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return u, domain.ErrUserNotFound
 	} else if err != nil {
@@ -68,3 +81,114 @@ func (r *Repository) FindByID(ctx context.Context, id entity.UserID) (entity.Use
 	}
 	return u, nil
 }
+
+// likeEscaper escape the wildcards of LIKE patterns, so a search matches them literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// FindAll find users whose name or email contains the search, newest first.
+// An empty search matches every user.
+func (r *Repository) FindAll(ctx context.Context, search string, limit int, offset int) ([]entity.User, error) {
+	q := `SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE name ILIKE $1 OR email ILIKE $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+	rows, err := r.db.QueryContext(ctx, q, "%"+likeEscaper.Replace(search)+"%", limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]entity.User, 0)
+	for rows.Next() {
+		var u entity.User
+		err := rows.Scan(&u.ID, &u.Name, &u.Email, &u.Password, &u.Role, &u.EmailVerifiedAt, &u.SuspendedAt, &u.CreatedAt, &u.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+// CountUsage count what a user stores and their active sessions and personal access tokens.
+func (r *Repository) CountUsage(ctx context.Context, id entity.UserID) (entity.UserUsage, error) {
+	var usage entity.UserUsage
+	q := `SELECT (SELECT COUNT(id) FROM tasks WHERE user_id = $1), (SELECT COUNT(id) FROM templates WHERE user_id = $1), (SELECT COUNT(id) FROM filters WHERE user_id = $1), (SELECT COUNT(id) FROM authentications WHERE user_id = $1 AND expires_at > NOW()), (SELECT COUNT(id) FROM personal_tokens WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > NOW()))`
+	err := r.db.QueryRowContext(ctx, q, id).Scan(&usage.Tasks, &usage.Templates, &usage.Filters, &usage.Sessions, &usage.PersonalTokens)
+	if err != nil {
+		return usage, err
+	}
+	return usage, nil
+}
+
+// MarkEmailVerified mark the email of a user as verified.
+func (r *Repository) MarkEmailVerified(ctx context.Context, id entity.UserID) error {
+	q := `UPDATE users SET email_verified_at = NOW(), updated_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdatePassword replace the hashed password of a user.
+func (r *Repository) UpdatePassword(ctx context.Context, id entity.UserID, password string) error {
+	q := `UPDATE users SET password = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, id, password)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Update save the name, email and email verification state of a user.
+func (r *Repository) Update(ctx context.Context, u *entity.User) error {
+	q := `UPDATE users SET name = $2, email = $3, email_verified_at = $4, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, u.ID, u.Name, u.Email, u.EmailVerifiedAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateRole change the role of a user.
+func (r *Repository) UpdateRole(ctx context.Context, id entity.UserID, role entity.Role) error {
+	q := `UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, id, role)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Suspend mark a user as suspended, a user that is already suspended keeps the original time.
+func (r *Repository) Suspend(ctx context.Context, id entity.UserID) error {
+	q := `UPDATE users SET suspended_at = COALESCE(suspended_at, NOW()), updated_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Unsuspend lift the suspension of a user.
+func (r *Repository) Unsuspend(ctx context.Context, id entity.UserID) error {
+	q := `UPDATE users SET suspended_at = NULL, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Delete remove a user from database.
+// Every row owned by the user is removed in the same statement by the cascading foreign keys.
+func (r *Repository) Delete(ctx context.Context, id entity.UserID) error {
+	q := `DELETE FROM users WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -101,6 +101,63 @@ func (s *UserRepositoryTestSuite) TestCreate() {
 	}
 }
 
+func (s *UserRepositoryTestSuite) TestStoreServiceAccount() {
+	query := regexp.QuoteMeta(`INSERT INTO users (id, name, email, password, role, email_verified_at) VALUES ($1, $2, $3, '', $4, NOW())`)
+
+	type expected struct {
+		userID entity.UserID
+		err    error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: expected{userID: "", err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("user-xxxxx")
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", "CI", "ci@go.dev", "service_account").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and user id when successfully store",
+			expected: expected{userID: "user-xxxxx", err: nil},
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("user-xxxxx")
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", "CI", "ci@go.dev", "service_account").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			userID, err := repository.StoreServiceAccount(context.Background(), &entity.User{Name: "CI", Email: "ci@go.dev"})
+
+			s.Equal(t.expected.userID, userID)
+			s.Equal(t.expected.err, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
 func (s *UserRepositoryTestSuite) TestVerifyAvailableEmail() {
 	type args struct {
 		ctx   context.Context
@@ -209,7 +266,7 @@ func (s *UserRepositoryTestSuite) TestFindByEmail() {
 				err:  test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE email = $1")).
 					WithArgs("gopher@go.dev").
 					WillReturnError(test.ErrDatabase)
 			},
@@ -225,7 +282,7 @@ func (s *UserRepositoryTestSuite) TestFindByEmail() {
 				err:  domain.ErrUserNotFound,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE email = $1")).
 					WithArgs("gopher@go.dev").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -238,20 +295,22 @@ func (s *UserRepositoryTestSuite) TestFindByEmail() {
 			},
 			expected: expected{
 				user: entity.User{
-					ID:        "user-xxxxx",
-					Name:      "Gopher",
-					Email:     "gopher@go.dev",
-					Password:  "secret_password",
-					CreatedAt: test.TimeBeforeNow,
-					UpdatedAt: test.TimeBeforeNow,
+					ID:              "user-xxxxx",
+					Name:            "Gopher",
+					Email:           "gopher@go.dev",
+					Password:        "secret_password",
+					Role:            entity.RoleUser,
+					EmailVerifiedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
+					CreatedAt:       test.TimeBeforeNow,
+					UpdatedAt:       test.TimeBeforeNow,
 				},
 				err: nil,
 			},
 			setup: func(d *dependency) {
-				mockRow := sqlmock.NewRows([]string{"id", "name", "email", "password", "created_at", "updated_at"}).
-					AddRow("user-xxxxx", "Gopher", "gopher@go.dev", "secret_password", test.TimeBeforeNow, test.TimeBeforeNow)
+				mockRow := sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "email_verified_at", "suspended_at", "created_at", "updated_at"}).
+					AddRow("user-xxxxx", "Gopher", "gopher@go.dev", "secret_password", "user", test.TimeBeforeNow, nil, test.TimeBeforeNow, test.TimeBeforeNow)
 
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, created_at, updated_at FROM users WHERE email = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE email = $1")).
 					WithArgs("gopher@go.dev").
 					WillReturnRows(mockRow)
 			},
@@ -305,7 +364,7 @@ func (s *UserRepositoryTestSuite) TestFindByID() {
 				err:  test.ErrDatabase,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, created_at, updated_at FROM users WHERE id = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE id = $1")).
 					WithArgs("user-xxxxx").
 					WillReturnError(test.ErrDatabase)
 			},
@@ -321,7 +380,7 @@ func (s *UserRepositoryTestSuite) TestFindByID() {
 				err:  domain.ErrUserNotFound,
 			},
 			setup: func(d *dependency) {
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, created_at, updated_at FROM users WHERE id = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE id = $1")).
 					WithArgs("user-xxxxx").
 					WillReturnError(sql.ErrNoRows)
 			},
@@ -334,20 +393,22 @@ func (s *UserRepositoryTestSuite) TestFindByID() {
 			},
 			expected: expected{
 				user: entity.User{
-					ID:        "user-xxxxx",
-					Name:      "Gopher",
-					Email:     "gopher@go.dev",
-					Password:  "secret_password",
-					CreatedAt: test.TimeBeforeNow,
-					UpdatedAt: test.TimeBeforeNow,
+					ID:              "user-xxxxx",
+					Name:            "Gopher",
+					Email:           "gopher@go.dev",
+					Password:        "secret_password",
+					Role:            entity.RoleUser,
+					EmailVerifiedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
+					CreatedAt:       test.TimeBeforeNow,
+					UpdatedAt:       test.TimeBeforeNow,
 				},
 				err: nil,
 			},
 			setup: func(d *dependency) {
-				mockRow := sqlmock.NewRows([]string{"id", "name", "email", "password", "created_at", "updated_at"}).
-					AddRow("user-xxxxx", "Gopher", "gopher@go.dev", "secret_password", test.TimeBeforeNow, test.TimeBeforeNow)
+				mockRow := sqlmock.NewRows([]string{"id", "name", "email", "password", "role", "email_verified_at", "suspended_at", "created_at", "updated_at"}).
+					AddRow("user-xxxxx", "Gopher", "gopher@go.dev", "secret_password", "user", test.TimeBeforeNow, nil, test.TimeBeforeNow, test.TimeBeforeNow)
 
-				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, created_at, updated_at FROM users WHERE id = $1")).
+				d.mockDB.ExpectQuery(regexp.QuoteMeta("SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE id = $1")).
 					WithArgs("user-xxxxx").
 					WillReturnRows(mockRow)
 			},
@@ -374,3 +435,516 @@ func (s *UserRepositoryTestSuite) TestFindByID() {
 		})
 	}
 }
+
+func (s *UserRepositoryTestSuite) TestFindAll() {
+	query := regexp.QuoteMeta("SELECT id, name, email, password, role, email_verified_at, suspended_at, created_at, updated_at FROM users WHERE name ILIKE $1 OR email ILIKE $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3")
+	columns := []string{"id", "name", "email", "password", "role", "email_verified_at", "suspended_at", "created_at", "updated_at"}
+
+	type expected struct {
+		users []entity.User
+		err   error
+	}
+	tests := []struct {
+		name     string
+		search   string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			search:   "gopher",
+			expected: expected{users: nil, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("%gopher%", 20, 40).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error when row scan fail",
+			search:   "gopher",
+			expected: expected{users: nil, err: test.ErrRowScan},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("user-xxxxx", "Gopher", "gopher@go.dev", "secret_password", "user", nil, nil, test.TimeBeforeNow, test.TimeBeforeNow).
+					RowError(0, test.ErrRowScan)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("%gopher%", 20, 40).
+					WillReturnRows(rows)
+			},
+		},
+		{
+			name:     "it should match wildcards of the search literally",
+			search:   "100%_",
+			expected: expected{users: []entity.User{}, err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs(`%100\%\_%`, 20, 40).
+					WillReturnRows(sqlmock.NewRows(columns))
+			},
+		},
+		{
+			name:   "it should return error nil and users when found",
+			search: "gopher",
+			expected: expected{
+				users: []entity.User{
+					{
+						ID:          "user-xxxxx",
+						Name:        "Gopher",
+						Email:       "gopher@go.dev",
+						Password:    "secret_password",
+						Role:        entity.RoleAdmin,
+						SuspendedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
+						CreatedAt:   test.TimeBeforeNow,
+						UpdatedAt:   test.TimeBeforeNow,
+					},
+				},
+				err: nil,
+			},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("user-xxxxx", "Gopher", "gopher@go.dev", "secret_password", "admin", nil, test.TimeBeforeNow, test.TimeBeforeNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("%gopher%", 20, 40).
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			users, err := repository.FindAll(context.Background(), t.search, 20, 40)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.users, users)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *UserRepositoryTestSuite) TestCountUsage() {
+	query := regexp.QuoteMeta("SELECT (SELECT COUNT(id) FROM tasks WHERE user_id = $1), (SELECT COUNT(id) FROM templates WHERE user_id = $1), (SELECT COUNT(id) FROM filters WHERE user_id = $1), (SELECT COUNT(id) FROM authentications WHERE user_id = $1 AND expires_at > NOW()), (SELECT COUNT(id) FROM personal_tokens WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > NOW()))")
+
+	type expected struct {
+		usage entity.UserUsage
+		err   error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			expected: expected{usage: entity.UserUsage{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil and usage when successfully count",
+			expected: expected{usage: entity.UserUsage{Tasks: 12, Templates: 2, Filters: 3, Sessions: 1, PersonalTokens: 4}, err: nil},
+			setup: func(d *dependency) {
+				row := sqlmock.NewRows([]string{"tasks", "templates", "filters", "sessions", "personal_tokens"}).
+					AddRow(12, 2, 3, 1, 4)
+
+				d.mockDB.ExpectQuery(query).
+					WithArgs("user-xxxxx").
+					WillReturnRows(row)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			usage, err := repository.CountUsage(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.usage, usage)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *UserRepositoryTestSuite) TestMarkEmailVerified() {
+	query := regexp.QuoteMeta("UPDATE users SET email_verified_at = NOW(), updated_at = NOW() WHERE id = $1")
+
+	type args struct {
+		ctx    context.Context
+		userID entity.UserID
+	}
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx"},
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.MarkEmailVerified(t.args.ctx, t.args.userID)
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *UserRepositoryTestSuite) TestUpdatePassword() {
+	query := regexp.QuoteMeta("UPDATE users SET password = $2, updated_at = NOW() WHERE id = $1")
+
+	type args struct {
+		ctx      context.Context
+		userID   entity.UserID
+		password string
+	}
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		args     args
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx", password: "new_hashed_password"},
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", "new_hashed_password").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			args:     args{ctx: context.Background(), userID: "user-xxxxx", password: "new_hashed_password"},
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", "new_hashed_password").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.UpdatePassword(t.args.ctx, t.args.userID, t.args.password)
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *UserRepositoryTestSuite) TestUpdate() {
+	query := regexp.QuoteMeta("UPDATE users SET name = $2, email = $3, email_verified_at = $4, updated_at = NOW() WHERE id = $1")
+	user := &entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}
+
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", "Gopher", "gopher@go.dev", nil).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", "Gopher", "gopher@go.dev", nil).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.Update(context.Background(), user)
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *UserRepositoryTestSuite) TestDelete() {
+	query := regexp.QuoteMeta("DELETE FROM users WHERE id = $1")
+
+	type expected struct {
+		err error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: expected{err: nil},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.Delete(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected.err, err)
+		})
+	}
+}
+
+func (s *UserRepositoryTestSuite) TestUpdateRole() {
+	query := regexp.QuoteMeta("UPDATE users SET role = $2, updated_at = NOW() WHERE id = $1")
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", "admin").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx", "admin").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.UpdateRole(context.Background(), "user-xxxxx", entity.RoleAdmin)
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *UserRepositoryTestSuite) TestSuspend() {
+	query := regexp.QuoteMeta("UPDATE users SET suspended_at = COALESCE(suspended_at, NOW()), updated_at = NOW() WHERE id = $1")
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.Suspend(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
+
+func (s *UserRepositoryTestSuite) TestUnsuspend() {
+	query := regexp.QuoteMeta("UPDATE users SET suspended_at = NULL, updated_at = NOW() WHERE id = $1")
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to update",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully update",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(query).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{mockDB: mockDB}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.Unsuspend(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected, err)
+			s.NoError(mockDB.ExpectationsWereMet())
+		})
+	}
+}
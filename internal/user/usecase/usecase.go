@@ -2,29 +2,76 @@ package usecase
 
 import (
 	"context"
+	"errors"
+	"log"
+	"time"
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 )
 
+const (
+	// verificationTokenTTL is how long a verification link stays valid.
+	verificationTokenTTL = 24 * time.Hour
+	// verificationResendCooldown is the minimum time between two verification emails.
+	verificationResendCooldown = time.Minute
+)
+
 type Usecase struct {
-	validator      domain.ValidatorProvider
-	userRepository domain.UserRepository
-	hashProvider   domain.HashProvider
+	validator               domain.ValidatorProvider
+	userRepository          domain.UserRepository
+	verificationRepository  domain.VerificationRepository
+	authRepository          domain.AuthRepository
+	revocationRepository    domain.RevocationRepository
+	securityEventRepository domain.SecurityEventRepository
+	hashProvider            domain.HashProvider
+	passwordPolicy          domain.PasswordPolicyProvider
+	tokenProvider           domain.TokenProvider
+	jwtProvider             domain.JWTProvider
+	mailer                  domain.Mailer
 }
 
 // New create a new user usecase.
-func New(validator domain.ValidatorProvider, userRepository domain.UserRepository, hashProvider domain.HashProvider) Usecase {
-	return Usecase{validator: validator, userRepository: userRepository, hashProvider: hashProvider}
+func New(
+	validator domain.ValidatorProvider,
+	userRepository domain.UserRepository,
+	verificationRepository domain.VerificationRepository,
+	authRepository domain.AuthRepository,
+	revocationRepository domain.RevocationRepository,
+	securityEventRepository domain.SecurityEventRepository,
+	hashProvider domain.HashProvider,
+	passwordPolicy domain.PasswordPolicyProvider,
+	tokenProvider domain.TokenProvider,
+	jwtProvider domain.JWTProvider,
+	mailer domain.Mailer,
+) Usecase {
+	return Usecase{
+		validator:               validator,
+		userRepository:          userRepository,
+		verificationRepository:  verificationRepository,
+		authRepository:          authRepository,
+		revocationRepository:    revocationRepository,
+		securityEventRepository: securityEventRepository,
+		hashProvider:            hashProvider,
+		passwordPolicy:          passwordPolicy,
+		tokenProvider:           tokenProvider,
+		jwtProvider:             jwtProvider,
+		mailer:                  mailer,
+	}
 }
 
-// Create create a new user.
+// Create create a new user and send the email verification.
+// The user already exists once stored, so a verification that fails to be sent is only logged,
+// the user can ask for a new one instead of being told to register again with a taken email.
 func (u *Usecase) Create(ctx context.Context, payload *dto.UserCreateIn) (dto.UserCreateOut, error) {
 	user := &entity.User{Name: payload.Name, Email: payload.Email, Password: payload.Password}
 	if err := u.validator.Validate(user); err != nil {
 		return dto.UserCreateOut{}, err
 	}
+	if err := u.passwordPolicy.Check(user.Password, user); err != nil {
+		return dto.UserCreateOut{}, err
+	}
 
 	if err := u.userRepository.VerifyAvailableEmail(ctx, user.Email); err != nil {
 		return dto.UserCreateOut{}, err
@@ -40,5 +87,200 @@ func (u *Usecase) Create(ctx context.Context, payload *dto.UserCreateIn) (dto.Us
 	if err != nil {
 		return dto.UserCreateOut{}, err
 	}
+	user.ID = id
+
+	if err := u.sendVerification(ctx, user); err != nil {
+		log.Println("[ERROR]", err)
+	}
 	return dto.UserCreateOut{ID: id, Email: user.Email}, nil
 }
+
+// Verify verify the email of a user with a verification token.
+func (u *Usecase) Verify(ctx context.Context, payload *dto.UserVerifyIn) error {
+	verification, err := u.verificationRepository.Consume(ctx, u.tokenProvider.Hash(payload.Token))
+	if errors.Is(err, domain.ErrVerificationNotFound) {
+		return domain.ErrVerificationTokenInvalid
+	} else if err != nil {
+		return err
+	}
+	if err := verification.VerifyTokenExpires(); err != nil {
+		return err
+	}
+
+	if err := u.userRepository.MarkEmailVerified(ctx, verification.UserID); err != nil {
+		return err
+	}
+	if err := u.verificationRepository.DeleteByUserID(ctx, verification.UserID); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ResendVerification send a new verification email, invalidating the previous ones.
+func (u *Usecase) ResendVerification(ctx context.Context, payload *dto.UserResendVerificationIn) error {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	latest, err := u.verificationRepository.FindLatestByUserID(ctx, user.ID)
+	if err == nil && time.Since(latest.CreatedAt) < verificationResendCooldown {
+		return domain.ErrVerificationThrottled
+	} else if err != nil && !errors.Is(err, domain.ErrVerificationNotFound) {
+		return err
+	}
+
+	if err := u.verificationRepository.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+	return u.sendVerification(ctx, &user)
+}
+
+// EnsureVerified check that the user has verified their email and is not suspended.
+// Checking the suspension here stops the personal access tokens and the unexpired access tokens of a suspended user.
+func (u *Usecase) EnsureVerified(ctx context.Context, payload *dto.UserEnsureVerifiedIn) error {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if err := user.VerifyNotSuspended(); err != nil {
+		return err
+	}
+	if !user.IsEmailVerified() {
+		return domain.ErrEmailNotVerified
+	}
+	return nil
+}
+
+// EnsureRole check that the user has any of the roles and is not suspended.
+func (u *Usecase) EnsureRole(ctx context.Context, payload *dto.UserEnsureRoleIn) error {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if err := user.VerifyNotSuspended(); err != nil {
+		return err
+	}
+	for _, role := range payload.Roles {
+		if user.Role == role {
+			return nil
+		}
+	}
+	return domain.ErrRoleForbidden
+}
+
+// Update update the name and email of a user.
+// Changing the email resets its verification and sends a new verification email.
+func (u *Usecase) Update(ctx context.Context, payload *dto.UserUpdateIn) (dto.UserUpdateOut, error) {
+	if err := entity.ValidateEmail(payload.Email); err != nil {
+		return dto.UserUpdateOut{}, err
+	}
+
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return dto.UserUpdateOut{}, err
+	}
+
+	emailChanged := user.Email != payload.Email
+	if emailChanged {
+		if err := u.userRepository.VerifyAvailableEmail(ctx, payload.Email); err != nil {
+			return dto.UserUpdateOut{}, err
+		}
+		user.EmailVerifiedAt = entity.NullTime{}
+	}
+	user.Name = payload.Name
+	user.Email = payload.Email
+
+	if err := u.userRepository.Update(ctx, &user); err != nil {
+		return dto.UserUpdateOut{}, err
+	}
+
+	if emailChanged {
+		if err := u.verificationRepository.DeleteByUserID(ctx, user.ID); err != nil {
+			return dto.UserUpdateOut{}, err
+		}
+		if err := u.sendVerification(ctx, &user); err != nil {
+			return dto.UserUpdateOut{}, err
+		}
+	}
+	return dto.UserUpdateOut{ID: user.ID, Name: user.Name, Email: user.Email, EmailVerified: user.IsEmailVerified()}, nil
+}
+
+// ChangePassword replace the password of a user and sign out every other session.
+// The watermark revokes every access token issued so far, so the current session gets a new one.
+func (u *Usecase) ChangePassword(ctx context.Context, payload *dto.UserChangePasswordIn) (dto.UserChangePasswordOut, error) {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return dto.UserChangePasswordOut{}, err
+	}
+	if err := u.hashProvider.Compare(payload.CurrentPassword, user.Password); err != nil {
+		return dto.UserChangePasswordOut{}, domain.ErrPasswordIncorrect
+	}
+	if err := u.passwordPolicy.Check(payload.NewPassword, &user); err != nil {
+		return dto.UserChangePasswordOut{}, err
+	}
+
+	securePassword, err := u.hashProvider.Hash(payload.NewPassword)
+	if err != nil {
+		return dto.UserChangePasswordOut{}, err
+	}
+	if err := u.userRepository.UpdatePassword(ctx, user.ID, string(securePassword)); err != nil {
+		return dto.UserChangePasswordOut{}, err
+	}
+	if err := u.authRepository.DeleteOthersByUserID(ctx, user.ID, payload.SessionID); err != nil {
+		return dto.UserChangePasswordOut{}, err
+	}
+	if err := u.revocationRepository.StoreWatermark(ctx, user.ID, time.Now()); err != nil {
+		return dto.UserChangePasswordOut{}, err
+	}
+
+	event := &entity.SecurityEvent{UserID: user.ID, Type: entity.SecurityEventPasswordChange, UserAgent: payload.UserAgent, IPAddress: payload.IPAddress}
+	if err := u.securityEventRepository.Store(ctx, event); err != nil {
+		return dto.UserChangePasswordOut{}, err
+	}
+
+	accessToken, _, err := u.jwtProvider.GenerateAccessToken(user.ID, payload.SessionID)
+	if err != nil {
+		return dto.UserChangePasswordOut{}, err
+	}
+	return dto.UserChangePasswordOut{AccessToken: accessToken}, nil
+}
+
+// Delete delete the account of a user along with all of their data.
+func (u *Usecase) Delete(ctx context.Context, payload *dto.UserDeleteIn) error {
+	user, err := u.userRepository.FindByID(ctx, payload.UserID)
+	if err != nil {
+		return err
+	}
+	if err := u.hashProvider.Compare(payload.Password, user.Password); err != nil {
+		return domain.ErrPasswordIncorrect
+	}
+	return u.userRepository.Delete(ctx, user.ID)
+}
+
+// sendVerification store a new verification token and mail it to the user.
+func (u *Usecase) sendVerification(ctx context.Context, user *entity.User) error {
+	token, err := u.tokenProvider.Generate()
+	if err != nil {
+		return err
+	}
+
+	verification := &entity.EmailVerification{
+		UserID:    user.ID,
+		TokenHash: u.tokenProvider.Hash(token),
+		ExpiresAt: time.Now().Add(verificationTokenTTL),
+	}
+	if err := u.verificationRepository.Store(ctx, verification); err != nil {
+		return err
+	}
+
+	mail := &entity.Mail{
+		To:       user.Email,
+		Template: entity.MailTemplateVerifyEmail,
+		Data:     map[string]string{"Name": user.Name, "Token": token},
+	}
+	return u.mailer.Send(ctx, mail)
+}
@@ -2,11 +2,14 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
+	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 	"github.com/edwintantawi/taskit/internal/domain/mocks"
@@ -22,9 +25,51 @@ func TestUserUsecaseSuite(t *testing.T) {
 }
 
 type dependency struct {
-	validator      *mocks.ValidatorProvider
-	userRepository *mocks.UserRepository
-	hashProvider   *mocks.HashProvider
+	validator               *mocks.ValidatorProvider
+	userRepository          *mocks.UserRepository
+	verificationRepository  *mocks.VerificationRepository
+	authRepository          *mocks.AuthRepository
+	revocationRepository    *mocks.RevocationRepository
+	securityEventRepository *mocks.SecurityEventRepository
+	hashProvider            *mocks.HashProvider
+	passwordPolicy          *mocks.PasswordPolicyProvider
+	tokenProvider           *mocks.TokenProvider
+	jwtProvider             *mocks.JWTProvider
+	mailer                  *mocks.Mailer
+}
+
+func newDependency() *dependency {
+	return &dependency{
+		validator:               &mocks.ValidatorProvider{},
+		userRepository:          &mocks.UserRepository{},
+		verificationRepository:  &mocks.VerificationRepository{},
+		authRepository:          &mocks.AuthRepository{},
+		revocationRepository:    &mocks.RevocationRepository{},
+		securityEventRepository: &mocks.SecurityEventRepository{},
+		hashProvider:            &mocks.HashProvider{},
+		passwordPolicy:          &mocks.PasswordPolicyProvider{},
+		tokenProvider:           &mocks.TokenProvider{},
+		jwtProvider:             &mocks.JWTProvider{},
+		mailer:                  &mocks.Mailer{},
+	}
+}
+
+func newUsecase(d *dependency) Usecase {
+	return New(d.validator, d.userRepository, d.verificationRepository, d.authRepository, d.revocationRepository, d.securityEventRepository, d.hashProvider, d.passwordPolicy, d.tokenProvider, d.jwtProvider, d.mailer)
+}
+
+// matchVerification match a verification of the user that expires in a day.
+func matchVerification(userID entity.UserID) any {
+	return mock.MatchedBy(func(v *entity.EmailVerification) bool {
+		expiresIn := time.Until(v.ExpiresAt)
+		return v.UserID == userID && v.TokenHash == "token_hash" && expiresIn > 23*time.Hour && expiresIn <= 24*time.Hour
+	})
+}
+
+var verifyMail = &entity.Mail{
+	To:       "gopher@go.dev",
+	Template: entity.MailTemplateVerifyEmail,
+	Data:     map[string]string{"Name": "Gopher", "Token": "raw_token"},
 }
 
 func (s *UserUsecaseTestSuite) TestCreate() {
@@ -56,6 +101,22 @@ func (s *UserUsecaseTestSuite) TestCreate() {
 				d.validator.On("Validate", mock.Anything).Return(test.ErrValidator)
 			},
 		},
+		{
+			name: "it should return error when password policy Check return error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.UserCreateIn{Name: "Gopher", Email: "gopher@go.dev", Password: "gopher_password"},
+			},
+			expected: expected{
+				output: dto.UserCreateOut{},
+				err:    entity.ErrPasswordContainsEmail,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).Return(nil)
+				d.passwordPolicy.On("Check", "gopher_password", &entity.User{Name: "Gopher", Email: "gopher@go.dev", Password: "gopher_password"}).
+					Return(entity.ErrPasswordContainsEmail)
+			},
+		},
 		{
 			name: "it should return error when user repository VerifyAvailableEmail return unexpected error",
 			args: args{
@@ -68,6 +129,7 @@ func (s *UserUsecaseTestSuite) TestCreate() {
 			},
 			setup: func(d *dependency) {
 				d.validator.On("Validate", mock.Anything).Return(nil)
+				d.passwordPolicy.On("Check", "secret_password", mock.Anything).Return(nil)
 
 				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@go.dev").
 					Return(test.ErrUnexpected)
@@ -85,6 +147,7 @@ func (s *UserUsecaseTestSuite) TestCreate() {
 			},
 			setup: func(d *dependency) {
 				d.validator.On("Validate", mock.Anything).Return(nil)
+				d.passwordPolicy.On("Check", "secret_password", mock.Anything).Return(nil)
 
 				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@go.dev").
 					Return(nil)
@@ -105,6 +168,7 @@ func (s *UserUsecaseTestSuite) TestCreate() {
 			},
 			setup: func(d *dependency) {
 				d.validator.On("Validate", mock.Anything).Return(nil)
+				d.passwordPolicy.On("Check", "secret_password", mock.Anything).Return(nil)
 
 				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@go.dev").
 					Return(nil)
@@ -116,6 +180,95 @@ func (s *UserUsecaseTestSuite) TestCreate() {
 					Return(entity.UserID(""), test.ErrUnexpected)
 			},
 		},
+		{
+			name: "it should return error nil and output when token provider Generate return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.UserCreateIn{Name: "Gopher", Email: "gopher@go.dev", Password: "secret_password"},
+			},
+			expected: expected{
+				output: dto.UserCreateOut{ID: "user-xxxxx", Email: "gopher@go.dev"},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).Return(nil)
+				d.passwordPolicy.On("Check", "secret_password", mock.Anything).Return(nil)
+
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@go.dev").
+					Return(nil)
+
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("secret_hashed_password"), nil)
+
+				d.userRepository.On("Store", context.Background(), mock.AnythingOfType("*entity.User")).
+					Return(entity.UserID("user-xxxxx"), nil)
+
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and output when verification repository Store return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.UserCreateIn{Name: "Gopher", Email: "gopher@go.dev", Password: "secret_password"},
+			},
+			expected: expected{
+				output: dto.UserCreateOut{ID: "user-xxxxx", Email: "gopher@go.dev"},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).Return(nil)
+				d.passwordPolicy.On("Check", "secret_password", mock.Anything).Return(nil)
+
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@go.dev").
+					Return(nil)
+
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("secret_hashed_password"), nil)
+
+				d.userRepository.On("Store", context.Background(), mock.AnythingOfType("*entity.User")).
+					Return(entity.UserID("user-xxxxx"), nil)
+
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+
+				d.verificationRepository.On("Store", context.Background(), matchVerification("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name: "it should return error nil and output when mailer Send return unexpected error",
+			args: args{
+				ctx:     context.Background(),
+				payload: &dto.UserCreateIn{Name: "Gopher", Email: "gopher@go.dev", Password: "secret_password"},
+			},
+			expected: expected{
+				output: dto.UserCreateOut{ID: "user-xxxxx", Email: "gopher@go.dev"},
+				err:    nil,
+			},
+			setup: func(d *dependency) {
+				d.validator.On("Validate", mock.Anything).Return(nil)
+				d.passwordPolicy.On("Check", "secret_password", mock.Anything).Return(nil)
+
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@go.dev").
+					Return(nil)
+
+				d.hashProvider.On("Hash", "secret_password").
+					Return([]byte("secret_hashed_password"), nil)
+
+				d.userRepository.On("Store", context.Background(), mock.AnythingOfType("*entity.User")).
+					Return(entity.UserID("user-xxxxx"), nil)
+
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+
+				d.verificationRepository.On("Store", context.Background(), matchVerification("user-xxxxx")).
+					Return(nil)
+
+				d.mailer.On("Send", context.Background(), verifyMail).
+					Return(test.ErrUnexpected)
+			},
+		},
 		{
 			name: "it should return error nil and output when success",
 			args: args{
@@ -128,6 +281,7 @@ func (s *UserUsecaseTestSuite) TestCreate() {
 			},
 			setup: func(d *dependency) {
 				d.validator.On("Validate", mock.Anything).Return(nil)
+				d.passwordPolicy.On("Check", "secret_password", mock.Anything).Return(nil)
 
 				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@go.dev").
 					Return(nil)
@@ -137,20 +291,25 @@ func (s *UserUsecaseTestSuite) TestCreate() {
 
 				d.userRepository.On("Store", context.Background(), &entity.User{Name: "Gopher", Email: "gopher@go.dev", Password: "secret_hashed_password"}).
 					Return(entity.UserID("user-xxxxx"), nil)
+
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+
+				d.verificationRepository.On("Store", context.Background(), matchVerification("user-xxxxx")).
+					Return(nil)
+
+				d.mailer.On("Send", context.Background(), verifyMail).
+					Return(nil)
 			},
 		},
 	}
 
 	for _, t := range tests {
 		s.Run(t.name, func() {
-			d := &dependency{
-				validator:      &mocks.ValidatorProvider{},
-				userRepository: &mocks.UserRepository{},
-				hashProvider:   &mocks.HashProvider{},
-			}
+			d := newDependency()
 			t.setup(d)
 
-			usecase := New(d.validator, d.userRepository, d.hashProvider)
+			usecase := newUsecase(d)
 			output, err := usecase.Create(t.args.ctx, t.args.payload)
 
 			s.Equal(t.expected.err, err)
@@ -158,3 +317,756 @@ func (s *UserUsecaseTestSuite) TestCreate() {
 		})
 	}
 }
+
+func (s *UserUsecaseTestSuite) TestVerify() {
+	tests := []struct {
+		name     string
+		payload  *dto.UserVerifyIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrVerificationTokenInvalid when token is unknown or already used",
+			payload:  &dto.UserVerifyIn{Token: "raw_token"},
+			expected: domain.ErrVerificationTokenInvalid,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Consume", context.Background(), "token_hash").
+					Return(entity.EmailVerification{}, domain.ErrVerificationNotFound)
+			},
+		},
+		{
+			name:     "it should return error when verification repository Consume return unexpected error",
+			payload:  &dto.UserVerifyIn{Token: "raw_token"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Consume", context.Background(), "token_hash").
+					Return(entity.EmailVerification{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrVerificationTokenExpired when token is expired",
+			payload:  &dto.UserVerifyIn{Token: "raw_token"},
+			expected: entity.ErrVerificationTokenExpired,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Consume", context.Background(), "token_hash").
+					Return(entity.EmailVerification{UserID: "user-xxxxx", ExpiresAt: test.TimeBeforeNow}, nil)
+			},
+		},
+		{
+			name:     "it should return error when user repository MarkEmailVerified return unexpected error",
+			payload:  &dto.UserVerifyIn{Token: "raw_token"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Consume", context.Background(), "token_hash").
+					Return(entity.EmailVerification{UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}, nil)
+				d.userRepository.On("MarkEmailVerified", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when verification repository DeleteByUserID return unexpected error",
+			payload:  &dto.UserVerifyIn{Token: "raw_token"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Consume", context.Background(), "token_hash").
+					Return(entity.EmailVerification{UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}, nil)
+				d.userRepository.On("MarkEmailVerified", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.verificationRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when success",
+			payload:  &dto.UserVerifyIn{Token: "raw_token"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Consume", context.Background(), "token_hash").
+					Return(entity.EmailVerification{UserID: "user-xxxxx", ExpiresAt: test.TimeAfterNow}, nil)
+				d.userRepository.On("MarkEmailVerified", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.verificationRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Verify(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *UserUsecaseTestSuite) TestResendVerification() {
+	unverifiedUser := entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev"}
+	verifiedUser := unverifiedUser
+	verifiedUser.EmailVerifiedAt = entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}
+
+	tests := []struct {
+		name     string
+		payload  *dto.UserResendVerificationIn
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			payload:  &dto.UserResendVerificationIn{UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrEmailAlreadyVerified when email is already verified",
+			payload:  &dto.UserResendVerificationIn{UserID: "user-xxxxx"},
+			expected: domain.ErrEmailAlreadyVerified,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(verifiedUser, nil)
+			},
+		},
+		{
+			name:     "it should return error when verification repository FindLatestByUserID return unexpected error",
+			payload:  &dto.UserResendVerificationIn{UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(unverifiedUser, nil)
+				d.verificationRepository.On("FindLatestByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.EmailVerification{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrVerificationThrottled when the last email was sent recently",
+			payload:  &dto.UserResendVerificationIn{UserID: "user-xxxxx"},
+			expected: domain.ErrVerificationThrottled,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(unverifiedUser, nil)
+				d.verificationRepository.On("FindLatestByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.EmailVerification{CreatedAt: time.Now().Add(-10 * time.Second)}, nil)
+			},
+		},
+		{
+			name:     "it should return error when verification repository DeleteByUserID return unexpected error",
+			payload:  &dto.UserResendVerificationIn{UserID: "user-xxxxx"},
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(unverifiedUser, nil)
+				d.verificationRepository.On("FindLatestByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.EmailVerification{CreatedAt: test.TimeBeforeNow}, nil)
+				d.verificationRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and send a new email when the previous one is old enough",
+			payload:  &dto.UserResendVerificationIn{UserID: "user-xxxxx"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(unverifiedUser, nil)
+				d.verificationRepository.On("FindLatestByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.EmailVerification{CreatedAt: test.TimeBeforeNow}, nil)
+				d.verificationRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Store", context.Background(), matchVerification("user-xxxxx")).
+					Return(nil)
+				d.mailer.On("Send", context.Background(), verifyMail).
+					Return(nil)
+			},
+		},
+		{
+			name:     "it should return error nil and send a new email when there is no previous verification",
+			payload:  &dto.UserResendVerificationIn{UserID: "user-xxxxx"},
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(unverifiedUser, nil)
+				d.verificationRepository.On("FindLatestByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.EmailVerification{}, domain.ErrVerificationNotFound)
+				d.verificationRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Store", context.Background(), matchVerification("user-xxxxx")).
+					Return(nil)
+				d.mailer.On("Send", context.Background(), verifyMail).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.ResendVerification(context.Background(), t.payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *UserUsecaseTestSuite) TestEnsureVerified() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrUserSuspended when user is suspended",
+			expected: entity.ErrUserSuspended,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", EmailVerifiedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}, SuspendedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrEmailNotVerified when email is not verified",
+			expected: domain.ErrEmailNotVerified,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx"}, nil)
+			},
+		},
+		{
+			name:     "it should return error nil when email is verified",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", EmailVerifiedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.EnsureVerified(context.Background(), &dto.UserEnsureVerifiedIn{UserID: "user-xxxxx"})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *UserUsecaseTestSuite) TestEnsureRole() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrUserSuspended when user is suspended",
+			expected: entity.ErrUserSuspended,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleAdmin, SuspendedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}}}, nil)
+			},
+		},
+		{
+			name:     "it should return error ErrRoleForbidden when user has none of the roles",
+			expected: domain.ErrRoleForbidden,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleUser}, nil)
+			},
+		},
+		{
+			name:     "it should return error nil when user has one of the roles",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{ID: "user-xxxxx", Role: entity.RoleAdmin}, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.EnsureRole(context.Background(), &dto.UserEnsureRoleIn{UserID: "user-xxxxx", Roles: []entity.Role{entity.RoleAdmin}})
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *UserUsecaseTestSuite) TestUpdate() {
+	verifiedUser := entity.User{
+		ID:              "user-xxxxx",
+		Name:            "Gopher",
+		Email:           "gopher@go.dev",
+		Password:        "hashed_password",
+		EmailVerifiedAt: entity.NullTime{NullTime: sql.NullTime{Time: test.TimeBeforeNow, Valid: true}},
+	}
+	renamedUser := verifiedUser
+	renamedUser.Name = "Gopher Go"
+	movedUser := renamedUser
+	movedUser.Email = "gopher@golang.org"
+	movedUser.EmailVerifiedAt = entity.NullTime{}
+
+	moveMail := &entity.Mail{
+		To:       "gopher@golang.org",
+		Template: entity.MailTemplateVerifyEmail,
+		Data:     map[string]string{"Name": "Gopher Go", "Token": "raw_token"},
+	}
+
+	type expected struct {
+		output dto.UserUpdateOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.UserUpdateIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error ErrEmailInvalid when email is invalid",
+			payload:  &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher Go", Email: "invalid"},
+			expected: expected{err: entity.ErrEmailInvalid},
+			setup:    func(d *dependency) {},
+		},
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			payload:  &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@go.dev"},
+			expected: expected{err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrEmailNotAvailable when the new email is already used",
+			payload:  &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@golang.org"},
+			expected: expected{err: domain.ErrEmailNotAvailable},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(verifiedUser, nil)
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@golang.org").
+					Return(domain.ErrEmailNotAvailable)
+			},
+		},
+		{
+			name:     "it should return error when user repository Update return unexpected error",
+			payload:  &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@go.dev"},
+			expected: expected{err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(verifiedUser, nil)
+				d.userRepository.On("Update", context.Background(), &renamedUser).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when verification repository DeleteByUserID return unexpected error",
+			payload:  &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@golang.org"},
+			expected: expected{err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(verifiedUser, nil)
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@golang.org").
+					Return(nil)
+				d.userRepository.On("Update", context.Background(), &movedUser).
+					Return(nil)
+				d.verificationRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when fail to send the verification email",
+			payload:  &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@golang.org"},
+			expected: expected{err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(verifiedUser, nil)
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@golang.org").
+					Return(nil)
+				d.userRepository.On("Update", context.Background(), &movedUser).
+					Return(nil)
+				d.verificationRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.tokenProvider.On("Generate").Return("", test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should keep the email verified when only the name changes",
+			payload:  &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@go.dev"},
+			expected: expected{output: dto.UserUpdateOut{ID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@go.dev", EmailVerified: true}},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(verifiedUser, nil)
+				d.userRepository.On("Update", context.Background(), &renamedUser).
+					Return(nil)
+			},
+		},
+		{
+			name:     "it should reset the verification and send a new verification email when the email changes",
+			payload:  &dto.UserUpdateIn{UserID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@golang.org"},
+			expected: expected{output: dto.UserUpdateOut{ID: "user-xxxxx", Name: "Gopher Go", Email: "gopher@golang.org", EmailVerified: false}},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(verifiedUser, nil)
+				d.userRepository.On("VerifyAvailableEmail", context.Background(), "gopher@golang.org").
+					Return(nil)
+				d.userRepository.On("Update", context.Background(), &movedUser).
+					Return(nil)
+				d.verificationRepository.On("DeleteByUserID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+				d.tokenProvider.On("Generate").Return("raw_token", nil)
+				d.tokenProvider.On("Hash", "raw_token").Return("token_hash")
+				d.verificationRepository.On("Store", context.Background(), matchVerification("user-xxxxx")).
+					Return(nil)
+				d.mailer.On("Send", context.Background(), moveMail).Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.Update(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *UserUsecaseTestSuite) TestChangePassword() {
+	user := entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", Password: "hashed_password"}
+	payload := &dto.UserChangePasswordIn{
+		UserID:          "user-xxxxx",
+		SessionID:       "auth-xxxxx",
+		CurrentPassword: "current_password",
+		NewPassword:     "new_password",
+		UserAgent:       "Mozilla/5.0",
+		IPAddress:       "203.0.113.7",
+	}
+
+	type expected struct {
+		output dto.UserChangePasswordOut
+		err    error
+	}
+	tests := []struct {
+		name     string
+		payload  *dto.UserChangePasswordIn
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrPasswordIncorrect when current password does not match",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: domain.ErrPasswordIncorrect},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when password policy Check return error",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: &entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: 8}},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+				d.passwordPolicy.On("Check", "new_password", &user).
+					Return(&entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: 8})
+			},
+		},
+		{
+			name:     "it should return error when hash provider Hash return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+				d.passwordPolicy.On("Check", "new_password", &user).Return(nil)
+				d.hashProvider.On("Hash", "new_password").Return(nil, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when user repository UpdatePassword return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+				d.passwordPolicy.On("Check", "new_password", &user).Return(nil)
+				d.hashProvider.On("Hash", "new_password").Return([]byte("new_hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "new_hashed_password").
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when auth repository DeleteOthersByUserID return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+				d.passwordPolicy.On("Check", "new_password", &user).Return(nil)
+				d.hashProvider.On("Hash", "new_password").Return([]byte("new_hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "new_hashed_password").
+					Return(nil)
+				d.authRepository.On("DeleteOthersByUserID", context.Background(), entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when revocation repository StoreWatermark return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+				d.passwordPolicy.On("Check", "new_password", &user).Return(nil)
+				d.hashProvider.On("Hash", "new_password").Return([]byte("new_hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "new_hashed_password").
+					Return(nil)
+				d.authRepository.On("DeleteOthersByUserID", context.Background(), entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when security event repository Store return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+				d.passwordPolicy.On("Check", "new_password", &user).Return(nil)
+				d.hashProvider.On("Hash", "new_password").Return([]byte("new_hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "new_hashed_password").
+					Return(nil)
+				d.authRepository.On("DeleteOthersByUserID", context.Background(), entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventPasswordChange, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when jwt provider GenerateAccessToken return unexpected error",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{}, err: test.ErrUnexpected},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+				d.passwordPolicy.On("Check", "new_password", &user).Return(nil)
+				d.hashProvider.On("Hash", "new_password").Return([]byte("new_hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "new_hashed_password").
+					Return(nil)
+				d.authRepository.On("DeleteOthersByUserID", context.Background(), entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventPasswordChange, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("", time.Time{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil and a new access token for the current session when successfully change the password",
+			payload:  payload,
+			expected: expected{output: dto.UserChangePasswordOut{AccessToken: "access_token"}, err: nil},
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+				d.passwordPolicy.On("Check", "new_password", &user).Return(nil)
+				d.hashProvider.On("Hash", "new_password").Return([]byte("new_hashed_password"), nil)
+				d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "new_hashed_password").
+					Return(nil)
+				d.authRepository.On("DeleteOthersByUserID", context.Background(), entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return(nil)
+				d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+					Return(nil)
+				d.securityEventRepository.On("Store", context.Background(), &entity.SecurityEvent{UserID: "user-xxxxx", Type: entity.SecurityEventPasswordChange, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7"}).
+					Return(nil)
+				d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+					Return("access_token", test.TimeAfterNow, nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			output, err := usecase.ChangePassword(context.Background(), t.payload)
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.output, output)
+		})
+	}
+}
+
+func (s *UserUsecaseTestSuite) TestChangePasswordKeepCurrentSession() {
+	user := entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", Password: "hashed_password"}
+	payload := &dto.UserChangePasswordIn{UserID: "user-xxxxx", SessionID: "auth-xxxxx", CurrentPassword: "current_password", NewPassword: "new_password"}
+
+	d := newDependency()
+	d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).Return(user, nil)
+	d.hashProvider.On("Compare", "current_password", "hashed_password").Return(nil)
+	d.passwordPolicy.On("Check", "new_password", &user).Return(nil)
+	d.hashProvider.On("Hash", "new_password").Return([]byte("new_hashed_password"), nil)
+	d.userRepository.On("UpdatePassword", context.Background(), entity.UserID("user-xxxxx"), "new_hashed_password").Return(nil)
+	d.authRepository.On("DeleteOthersByUserID", context.Background(), entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).Return(nil)
+	d.securityEventRepository.On("Store", context.Background(), mock.AnythingOfType("*entity.SecurityEvent")).Return(nil)
+
+	var watermark time.Time
+	d.revocationRepository.On("StoreWatermark", context.Background(), entity.UserID("user-xxxxx"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) { watermark = args.Get(2).(time.Time) }).
+		Return(nil)
+	d.jwtProvider.On("GenerateAccessToken", entity.UserID("user-xxxxx"), entity.AuthID("auth-xxxxx")).
+		Return("access_token", test.TimeAfterNow, nil).
+		Run(func(args mock.Arguments) {
+			// The new access token is issued after the watermark, so the revocation check must keep it.
+			s.False(watermark.IsZero())
+			claims := entity.AuthClaims{UserID: "user-xxxxx", SessionID: "auth-xxxxx", IssuedAt: time.Now().Truncate(time.Second)}
+			s.NoError(claims.VerifyIssuedAfter(watermark))
+		})
+
+	usecase := newUsecase(d)
+	output, err := usecase.ChangePassword(context.Background(), payload)
+
+	s.NoError(err)
+	s.Equal("access_token", output.AccessToken)
+	d.jwtProvider.AssertExpectations(s.T())
+}
+
+func (s *UserUsecaseTestSuite) TestDelete() {
+	user := entity.User{ID: "user-xxxxx", Name: "Gopher", Email: "gopher@go.dev", Password: "hashed_password"}
+	payload := &dto.UserDeleteIn{UserID: "user-xxxxx", Password: "password"}
+
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when user repository FindByID return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(entity.User{}, test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error ErrPasswordIncorrect when password does not match",
+			expected: domain.ErrPasswordIncorrect,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "password", "hashed_password").
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error when user repository Delete return unexpected error",
+			expected: test.ErrUnexpected,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "password", "hashed_password").Return(nil)
+				d.userRepository.On("Delete", context.Background(), entity.UserID("user-xxxxx")).
+					Return(test.ErrUnexpected)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete the account",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.userRepository.On("FindByID", context.Background(), entity.UserID("user-xxxxx")).
+					Return(user, nil)
+				d.hashProvider.On("Compare", "password", "hashed_password").Return(nil)
+				d.userRepository.On("Delete", context.Background(), entity.UserID("user-xxxxx")).
+					Return(nil)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			d := newDependency()
+			t.setup(d)
+
+			usecase := newUsecase(d)
+			err := usecase.Delete(context.Background(), payload)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
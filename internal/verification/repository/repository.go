@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Repository struct {
+	db         *sql.DB
+	idProvider domain.IDProvider
+}
+
+// New create a new email verification repository.
+func New(db *sql.DB, idProvider domain.IDProvider) Repository {
+	return Repository{db: db, idProvider: idProvider}
+}
+
+// Store save a new email verification to database.
+func (r *Repository) Store(ctx context.Context, v *entity.EmailVerification) error {
+	id := entity.EmailVerificationID(r.idProvider.Generate())
+	q := `INSERT INTO email_verifications (id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.ExecContext(ctx, q, id, v.UserID, v.TokenHash, v.ExpiresAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// FindLatestByUserID find the most recently created email verification of a user.
+func (r *Repository) FindLatestByUserID(ctx context.Context, userID entity.UserID) (entity.EmailVerification, error) {
+	var v entity.EmailVerification
+	q := `SELECT id, user_id, token_hash, expires_at, created_at FROM email_verifications WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1`
+	err := r.db.QueryRowContext(ctx, q, userID).Scan(&v.ID, &v.UserID, &v.TokenHash, &v.ExpiresAt, &v.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return v, domain.ErrVerificationNotFound
+	} else if err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// Consume delete an email verification by token hash and return it.
+// Deleting and reading in one statement makes every token single use.
+func (r *Repository) Consume(ctx context.Context, tokenHash string) (entity.EmailVerification, error) {
+	var v entity.EmailVerification
+	q := `DELETE FROM email_verifications WHERE token_hash = $1 RETURNING id, user_id, token_hash, expires_at, created_at`
+	err := r.db.QueryRowContext(ctx, q, tokenHash).Scan(&v.ID, &v.UserID, &v.TokenHash, &v.ExpiresAt, &v.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return v, domain.ErrVerificationNotFound
+	} else if err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// DeleteByUserID delete every email verification of a user.
+func (r *Repository) DeleteByUserID(ctx context.Context, userID entity.UserID) error {
+	q := `DELETE FROM email_verifications WHERE user_id = $1`
+	_, err := r.db.ExecContext(ctx, q, userID)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/internal/domain/mocks"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type VerificationRepositoryTestSuite struct {
+	suite.Suite
+}
+
+func TestVerificationRepositorySuite(t *testing.T) {
+	suite.Run(t, new(VerificationRepositoryTestSuite))
+}
+
+type dependency struct {
+	mockDB     sqlmock.Sqlmock
+	idProvider *mocks.IDProvider
+}
+
+var (
+	insertVerificationQuery = regexp.QuoteMeta(`INSERT INTO email_verifications (id, user_id, token_hash, expires_at) VALUES ($1, $2, $3, $4)`)
+	selectLatestQuery       = regexp.QuoteMeta(`SELECT id, user_id, token_hash, expires_at, created_at FROM email_verifications WHERE user_id = $1 ORDER BY created_at DESC LIMIT 1`)
+	consumeQuery            = regexp.QuoteMeta(`DELETE FROM email_verifications WHERE token_hash = $1 RETURNING id, user_id, token_hash, expires_at, created_at`)
+	deleteByUserIDQuery     = regexp.QuoteMeta(`DELETE FROM email_verifications WHERE user_id = $1`)
+)
+
+var columns = []string{"id", "user_id", "token_hash", "expires_at", "created_at"}
+
+func newVerification() entity.EmailVerification {
+	return entity.EmailVerification{
+		ID:        "verification-xxxxx",
+		UserID:    "user-xxxxx",
+		TokenHash: "token_hash",
+		ExpiresAt: test.TimeAfterNow,
+		CreatedAt: test.TimeBeforeNow,
+	}
+}
+
+func (s *VerificationRepositoryTestSuite) TestStore() {
+	verification := &entity.EmailVerification{UserID: "user-xxxxx", TokenHash: "token_hash", ExpiresAt: test.TimeAfterNow}
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to store",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("verification-xxxxx")
+
+				d.mockDB.ExpectExec(insertVerificationQuery).
+					WithArgs("verification-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow).
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully store",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.idProvider.On("Generate").Return("verification-xxxxx")
+
+				d.mockDB.ExpectExec(insertVerificationQuery).
+					WithArgs("verification-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB:     mockDB,
+				idProvider: &mocks.IDProvider{},
+			}
+			t.setup(d)
+
+			repository := New(db, d.idProvider)
+			err = repository.Store(context.Background(), verification)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *VerificationRepositoryTestSuite) TestFindLatestByUserID() {
+	type expected struct {
+		verification entity.EmailVerification
+		err          error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to query",
+			expected: expected{verification: entity.EmailVerification{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectLatestQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrVerificationNotFound when user has no verification",
+			expected: expected{verification: entity.EmailVerification{}, err: domain.ErrVerificationNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(selectLatestQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and verification when found",
+			expected: expected{verification: newVerification(), err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("verification-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(selectLatestQuery).
+					WithArgs("user-xxxxx").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			verification, err := repository.FindLatestByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.verification, verification)
+		})
+	}
+}
+
+func (s *VerificationRepositoryTestSuite) TestConsume() {
+	type expected struct {
+		verification entity.EmailVerification
+		err          error
+	}
+	tests := []struct {
+		name     string
+		expected expected
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: expected{verification: entity.EmailVerification{}, err: test.ErrDatabase},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error ErrVerificationNotFound when token is unknown or already used",
+			expected: expected{verification: entity.EmailVerification{}, err: domain.ErrVerificationNotFound},
+			setup: func(d *dependency) {
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "it should return error nil and the consumed verification when success",
+			expected: expected{verification: newVerification(), err: nil},
+			setup: func(d *dependency) {
+				rows := sqlmock.NewRows(columns).
+					AddRow("verification-xxxxx", "user-xxxxx", "token_hash", test.TimeAfterNow, test.TimeBeforeNow)
+
+				d.mockDB.ExpectQuery(consumeQuery).
+					WithArgs("token_hash").
+					WillReturnRows(rows)
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			verification, err := repository.Consume(context.Background(), "token_hash")
+
+			s.Equal(t.expected.err, err)
+			s.Equal(t.expected.verification, verification)
+		})
+	}
+}
+
+func (s *VerificationRepositoryTestSuite) TestDeleteByUserID() {
+	tests := []struct {
+		name     string
+		expected error
+		setup    func(d *dependency)
+	}{
+		{
+			name:     "it should return error when database fail to delete",
+			expected: test.ErrDatabase,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnError(test.ErrDatabase)
+			},
+		},
+		{
+			name:     "it should return error nil when successfully delete",
+			expected: nil,
+			setup: func(d *dependency) {
+				d.mockDB.ExpectExec(deleteByUserIDQuery).
+					WithArgs("user-xxxxx").
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			db, mockDB, err := sqlmock.New()
+			if err != nil {
+				s.FailNow("an error '%s' was not expected when opening a database mock connection", err)
+			}
+
+			d := &dependency{
+				mockDB: mockDB,
+			}
+			t.setup(d)
+
+			repository := New(db, nil)
+			err = repository.DeleteByUserID(context.Background(), "user-xxxxx")
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
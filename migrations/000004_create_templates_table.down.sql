@@ -0,0 +1,2 @@
+DROP TABLE template_tasks;
+DROP TABLE templates;
\ No newline at end of file
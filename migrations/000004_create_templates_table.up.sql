@@ -0,0 +1,23 @@
+CREATE TABLE templates (
+  id           VARCHAR(64)   PRIMARY KEY,
+  user_id      VARCHAR(64)   NOT NULL,
+  name         VARCHAR(255)  NOT NULL,
+  description  TEXT          NOT NULL,
+  created_at   TIMESTAMP     NOT NULL DEFAULT NOW(),
+  updated_at   TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_templates_users FOREIGN KEY(user_id) REFERENCES users(id)
+);
+
+CREATE TABLE template_tasks (
+  id               VARCHAR(64)   PRIMARY KEY,
+  template_id      VARCHAR(64)   NOT NULL,
+  parent_id        VARCHAR(64),
+  position         INTEGER       NOT NULL,
+  content          VARCHAR(255)  NOT NULL,
+  description      TEXT          NOT NULL,
+  due_offset_days  INTEGER,
+
+  CONSTRAINT fk_template_tasks_templates FOREIGN KEY(template_id) REFERENCES templates(id) ON DELETE CASCADE,
+  CONSTRAINT fk_template_tasks_template_tasks FOREIGN KEY(parent_id) REFERENCES template_tasks(id) ON DELETE CASCADE
+);
\ No newline at end of file
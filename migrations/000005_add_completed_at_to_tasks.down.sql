@@ -0,0 +1 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE tasks ADD COLUMN completed_at TIMESTAMP;
+
+UPDATE tasks SET completed_at = updated_at WHERE is_completed = TRUE;
\ No newline at end of file
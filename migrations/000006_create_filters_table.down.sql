@@ -0,0 +1 @@
+DROP TABLE filters;
\ No newline at end of file
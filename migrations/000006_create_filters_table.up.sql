@@ -0,0 +1,11 @@
+CREATE TABLE filters (
+  id          VARCHAR(64)   PRIMARY KEY,
+  user_id     VARCHAR(64)   NOT NULL,
+  name        VARCHAR(255)  NOT NULL,
+  query       TEXT          NOT NULL,
+  position    INTEGER       NOT NULL,
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+  updated_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_filters_users FOREIGN KEY(user_id) REFERENCES users(id)
+);
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN start_date TIMESTAMP;
\ No newline at end of file
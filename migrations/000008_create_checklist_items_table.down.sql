@@ -0,0 +1 @@
+DROP TABLE checklist_items;
\ No newline at end of file
@@ -0,0 +1,13 @@
+CREATE TABLE checklist_items (
+  id          VARCHAR(64)   PRIMARY KEY,
+  task_id     VARCHAR(64)   NOT NULL,
+  text        VARCHAR(255)  NOT NULL,
+  is_checked  BOOLEAN       NOT NULL DEFAULT FALSE,
+  position    INTEGER       NOT NULL,
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+  updated_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_checklist_items_tasks FOREIGN KEY(task_id) REFERENCES tasks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_checklist_items_task_id ON checklist_items(task_id);
\ No newline at end of file
@@ -0,0 +1,16 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
+
+-- Accounts created before verification existed keep their access.
+UPDATE users SET email_verified_at = created_at;
+
+CREATE TABLE email_verifications (
+  id          VARCHAR(64)   PRIMARY KEY,
+  user_id     VARCHAR(64)   NOT NULL,
+  token_hash  VARCHAR(64)   NOT NULL UNIQUE,
+  expires_at  TIMESTAMP     NOT NULL,
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_email_verifications_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_email_verifications_user_id ON email_verifications(user_id);
\ No newline at end of file
@@ -0,0 +1,11 @@
+CREATE TABLE password_resets (
+  id          VARCHAR(64)   PRIMARY KEY,
+  user_id     VARCHAR(64)   NOT NULL,
+  token_hash  VARCHAR(64)   NOT NULL UNIQUE,
+  expires_at  TIMESTAMP     NOT NULL,
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_password_resets_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_password_resets_user_id ON password_resets(user_id);
\ No newline at end of file
@@ -0,0 +1,11 @@
+ALTER TABLE authentications DROP CONSTRAINT fk_authentications_users;
+ALTER TABLE authentications ADD CONSTRAINT fk_authentications_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE tasks DROP CONSTRAINT fk_tasks_users;
+ALTER TABLE tasks ADD CONSTRAINT fk_tasks_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE templates DROP CONSTRAINT fk_templates_users;
+ALTER TABLE templates ADD CONSTRAINT fk_templates_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE filters DROP CONSTRAINT fk_filters_users;
+ALTER TABLE filters ADD CONSTRAINT fk_filters_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE;
\ No newline at end of file
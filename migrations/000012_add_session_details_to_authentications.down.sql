@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_authentications_user_id;
+
+ALTER TABLE authentications
+  DROP COLUMN IF EXISTS user_agent,
+  DROP COLUMN IF EXISTS ip_address,
+  DROP COLUMN IF EXISTS created_at,
+  DROP COLUMN IF EXISTS last_used_at;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE authentications
+  ADD COLUMN user_agent    TEXT         NOT NULL DEFAULT '',
+  ADD COLUMN ip_address    VARCHAR(45)  NOT NULL DEFAULT '',
+  ADD COLUMN created_at    TIMESTAMP    NOT NULL DEFAULT NOW(),
+  ADD COLUMN last_used_at  TIMESTAMP    NOT NULL DEFAULT NOW();
+
+CREATE INDEX idx_authentications_user_id ON authentications(user_id);
\ No newline at end of file
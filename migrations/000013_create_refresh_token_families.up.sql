@@ -0,0 +1,22 @@
+CREATE TABLE rotated_refresh_tokens (
+  token       TEXT          PRIMARY KEY,
+  family_id   VARCHAR(64)   NOT NULL,
+  rotated_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_rotated_refresh_tokens_authentications FOREIGN KEY(family_id) REFERENCES authentications(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_rotated_refresh_tokens_family_id ON rotated_refresh_tokens(family_id);
+
+CREATE TABLE security_events (
+  id          VARCHAR(64)   PRIMARY KEY,
+  user_id     VARCHAR(64)   NOT NULL,
+  type        VARCHAR(64)   NOT NULL,
+  user_agent  TEXT          NOT NULL DEFAULT '',
+  ip_address  VARCHAR(45)   NOT NULL DEFAULT '',
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_security_events_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_security_events_user_id ON security_events(user_id);
\ No newline at end of file
@@ -0,0 +1,8 @@
+DELETE FROM authentications;
+
+ALTER TABLE rotated_refresh_tokens ALTER COLUMN token_hash TYPE TEXT;
+ALTER TABLE rotated_refresh_tokens RENAME COLUMN token_hash TO token;
+
+DROP INDEX IF EXISTS idx_authentications_token_hash;
+ALTER TABLE authentications ALTER COLUMN token_hash TYPE TEXT;
+ALTER TABLE authentications RENAME COLUMN token_hash TO token;
\ No newline at end of file
@@ -0,0 +1,8 @@
+DELETE FROM authentications;
+
+ALTER TABLE authentications RENAME COLUMN token TO token_hash;
+ALTER TABLE authentications ALTER COLUMN token_hash TYPE VARCHAR(64);
+CREATE UNIQUE INDEX idx_authentications_token_hash ON authentications(token_hash);
+
+ALTER TABLE rotated_refresh_tokens RENAME COLUMN token TO token_hash;
+ALTER TABLE rotated_refresh_tokens ALTER COLUMN token_hash TYPE VARCHAR(64);
\ No newline at end of file
@@ -0,0 +1,32 @@
+CREATE TABLE two_factors (
+  user_id         VARCHAR(64)   PRIMARY KEY,
+  secret          VARCHAR(64)   NOT NULL,
+  enabled_at      TIMESTAMP,
+  last_used_step  BIGINT        NOT NULL DEFAULT 0,
+  created_at      TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_two_factors_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE recovery_codes (
+  id          VARCHAR(64)   PRIMARY KEY,
+  user_id     VARCHAR(64)   NOT NULL,
+  code_hash   VARCHAR(64)   NOT NULL,
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_recovery_codes_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT uq_recovery_codes_user_id_code_hash UNIQUE (user_id, code_hash)
+);
+
+CREATE TABLE mfa_challenges (
+  id          VARCHAR(64)   PRIMARY KEY,
+  user_id     VARCHAR(64)   NOT NULL,
+  token_hash  VARCHAR(64)   NOT NULL UNIQUE,
+  attempts    INTEGER       NOT NULL DEFAULT 0,
+  expires_at  TIMESTAMP     NOT NULL,
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_mfa_challenges_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_mfa_challenges_user_id ON mfa_challenges(user_id);
\ No newline at end of file
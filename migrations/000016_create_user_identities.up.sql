@@ -0,0 +1,23 @@
+CREATE TABLE user_identities (
+  id          VARCHAR(64)   PRIMARY KEY,
+  user_id     VARCHAR(64)   NOT NULL,
+  provider    VARCHAR(64)   NOT NULL,
+  subject     VARCHAR(255)  NOT NULL,
+  email       VARCHAR(255)  NOT NULL DEFAULT '',
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_user_identities_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+  CONSTRAINT uq_user_identities_provider_subject UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+CREATE TABLE oidc_states (
+  id             VARCHAR(64)   PRIMARY KEY,
+  provider       VARCHAR(64)   NOT NULL,
+  state_hash     VARCHAR(64)   NOT NULL UNIQUE,
+  nonce          VARCHAR(64)   NOT NULL,
+  code_verifier  VARCHAR(128)  NOT NULL,
+  expires_at     TIMESTAMP     NOT NULL,
+  created_at     TIMESTAMP     NOT NULL DEFAULT NOW()
+);
\ No newline at end of file
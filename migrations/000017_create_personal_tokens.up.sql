@@ -0,0 +1,14 @@
+CREATE TABLE personal_tokens (
+  id            VARCHAR(64)   PRIMARY KEY,
+  user_id       VARCHAR(64)   NOT NULL,
+  name          VARCHAR(255)  NOT NULL,
+  token_hash    VARCHAR(64)   NOT NULL UNIQUE,
+  scopes        TEXT[]        NOT NULL DEFAULT '{}',
+  expires_at    TIMESTAMP,
+  last_used_at  TIMESTAMP,
+  created_at    TIMESTAMP     NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_personal_tokens_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_personal_tokens_user_id ON personal_tokens(user_id);
\ No newline at end of file
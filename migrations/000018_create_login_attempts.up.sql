@@ -0,0 +1,8 @@
+CREATE TABLE login_attempts (
+  id          VARCHAR(64)   PRIMARY KEY,
+  scope       VARCHAR(16)   NOT NULL,
+  identifier  VARCHAR(255)  NOT NULL,
+  created_at  TIMESTAMP     NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_attempts_scope_identifier_created_at ON login_attempts(scope, identifier, created_at);
\ No newline at end of file
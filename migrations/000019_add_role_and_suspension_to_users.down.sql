@@ -0,0 +1,3 @@
+ALTER TABLE users
+  DROP COLUMN IF EXISTS role,
+  DROP COLUMN IF EXISTS suspended_at;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS access_token_watermarks;
+DROP TABLE IF EXISTS revoked_access_tokens;
\ No newline at end of file
@@ -0,0 +1,19 @@
+CREATE TABLE revoked_access_tokens (
+  token_id    VARCHAR(64)  PRIMARY KEY,
+  user_id     VARCHAR(64)  NOT NULL,
+  expires_at  TIMESTAMP    NOT NULL,
+  created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_revoked_access_tokens_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_revoked_access_tokens_expires_at ON revoked_access_tokens(expires_at);
+
+CREATE TABLE access_token_watermarks (
+  user_id         VARCHAR(64)  PRIMARY KEY,
+  revoked_before  TIMESTAMP    NOT NULL,
+
+  CONSTRAINT fk_access_token_watermarks_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_access_token_watermarks_revoked_before ON access_token_watermarks(revoked_before);
\ No newline at end of file
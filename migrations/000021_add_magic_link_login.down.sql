@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS login_methods;
+DROP TABLE IF EXISTS magic_links;
\ No newline at end of file
@@ -0,0 +1,20 @@
+CREATE TABLE magic_links (
+  id          VARCHAR(64)  PRIMARY KEY,
+  user_id     VARCHAR(64)  NOT NULL,
+  token_hash  VARCHAR(64)  NOT NULL UNIQUE,
+  nonce_hash  VARCHAR(64)  NOT NULL DEFAULT '',
+  expires_at  TIMESTAMP    NOT NULL,
+  created_at  TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_magic_links_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_magic_links_user_id ON magic_links(user_id);
+
+CREATE TABLE login_methods (
+  method      VARCHAR(32)  PRIMARY KEY,
+  enabled     BOOLEAN      NOT NULL,
+  updated_at  TIMESTAMP    NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO login_methods (method, enabled) VALUES ('password', TRUE), ('magic_link', FALSE);
\ No newline at end of file
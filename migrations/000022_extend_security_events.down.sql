@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS security_events_append_only ON security_events;
+DROP FUNCTION IF EXISTS forbid_security_event_update;
+
+DROP INDEX IF EXISTS idx_security_events_created_at;
+DROP INDEX IF EXISTS idx_security_events_ip_address;
+DROP INDEX IF EXISTS idx_security_events_user_id_created_at;
+
+CREATE INDEX idx_security_events_user_id ON security_events(user_id);
\ No newline at end of file
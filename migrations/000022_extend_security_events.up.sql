@@ -0,0 +1,14 @@
+DROP INDEX idx_security_events_user_id;
+
+CREATE INDEX idx_security_events_user_id_created_at ON security_events(user_id, created_at);
+CREATE INDEX idx_security_events_ip_address ON security_events(ip_address);
+CREATE INDEX idx_security_events_created_at ON security_events(created_at);
+
+CREATE FUNCTION forbid_security_event_update() RETURNS TRIGGER AS $$
+BEGIN
+  RAISE EXCEPTION 'security events are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER security_events_append_only BEFORE UPDATE ON security_events
+  FOR EACH ROW EXECUTE FUNCTION forbid_security_event_update();
\ No newline at end of file
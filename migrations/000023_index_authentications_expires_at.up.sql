@@ -0,0 +1,2 @@
+-- token_hash and user_id are already indexed since 000014 and 000012, the janitor deletes expired sessions by expires_at.
+CREATE INDEX idx_authentications_expires_at ON authentications(expires_at);
\ No newline at end of file
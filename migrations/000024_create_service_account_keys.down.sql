@@ -0,0 +1 @@
+DROP TABLE IF EXISTS service_account_keys;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE service_account_keys (
+  id            VARCHAR(64)  PRIMARY KEY,
+  user_id       VARCHAR(64)  NOT NULL,
+  key_hash      VARCHAR(64)  NOT NULL UNIQUE,
+  expires_at    TIMESTAMP,
+  last_used_at  TIMESTAMP,
+  created_at    TIMESTAMP    NOT NULL DEFAULT NOW(),
+
+  CONSTRAINT fk_service_account_keys_users FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_service_account_keys_user_id ON service_account_keys(user_id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_login_attempts_created_at;
\ No newline at end of file
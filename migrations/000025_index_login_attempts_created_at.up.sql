@@ -0,0 +1,2 @@
+-- The janitor deletes failed login attempts by created_at, the existing index leads with scope and identifier.
+CREATE INDEX idx_login_attempts_created_at ON login_attempts(created_at);
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- The janitor deletes abandoned OpenID Connect logins by expires_at.
+CREATE INDEX idx_oidc_states_expires_at ON oidc_states(expires_at);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_mfa_challenges_expires_at;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- The janitor deletes abandoned two-factor logins by expires_at.
+CREATE INDEX idx_mfa_challenges_expires_at ON mfa_challenges(expires_at);
\ No newline at end of file
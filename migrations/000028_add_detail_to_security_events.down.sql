@@ -0,0 +1 @@
+ALTER TABLE security_events DROP COLUMN detail;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE security_events ADD COLUMN detail TEXT NOT NULL DEFAULT '';
\ No newline at end of file
@@ -1,6 +1,7 @@
 package errorx
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -8,7 +9,9 @@ import (
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/oidc"
 	"github.com/edwintantawi/taskit/pkg/security"
+	"github.com/edwintantawi/taskit/pkg/tql"
 )
 
 // HTTPError message
@@ -19,45 +22,279 @@ var (
 // HTTPErrorTranslator translates error to http status code and human readable error message.
 func HTTPErrorTranslator(err error) (code int, msg string) {
 	log.Println("[ERROR]", err)
+
+	// Task query errors carry the position of the problem.
+	var queryErr *tql.Error
+	if errors.As(err, &queryErr) {
+		return http.StatusBadRequest, "Query is invalid: " + queryErr.Error()
+	}
+
+	// Password policy length errors carry the configured bound.
+	var lengthErr *entity.PasswordLengthError
+	if errors.As(err, &lengthErr) {
+		if lengthErr.Err == entity.ErrPasswordTooLong {
+			return http.StatusBadRequest, fmt.Sprintf("Password must be at most %d characters in length", lengthErr.Limit)
+		}
+		return http.StatusBadRequest, fmt.Sprintf("Password must be at least %d characters in length", lengthErr.Limit)
+	}
+
+	// Throttled logins carry how long to wait, the handler sets it as Retry-After.
+	var throttledErr *domain.LoginThrottledError
+	if errors.As(err, &throttledErr) {
+		return http.StatusTooManyRequests, "Too many failed login attempts, please try again later"
+	}
+
+	// Throttled login links carry how long to wait, the handler sets it as Retry-After.
+	var magicLinkThrottledErr *domain.MagicLinkThrottledError
+	if errors.As(err, &magicLinkThrottledErr) {
+		return http.StatusTooManyRequests, "Too many login link requests, please try again later"
+	}
+
+	// Throttled password resets carry how long to wait, the handler sets it as Retry-After.
+	var passwordResetThrottledErr *domain.PasswordResetThrottledError
+	if errors.As(err, &passwordResetThrottledErr) {
+		return http.StatusTooManyRequests, "Too many password reset requests, please try again later"
+	}
+
+	// Throttled service accounts carry how long to wait, the middleware sets it as Retry-After.
+	var serviceAccountThrottledErr *domain.ServiceAccountThrottledError
+	if errors.As(err, &serviceAccountThrottledErr) {
+		return http.StatusTooManyRequests, "Too many requests, please try again later"
+	}
+
 	switch err {
 	// User entity
 	case entity.ErrEmailInvalid:
 		return http.StatusBadRequest, "Email must be a valid email address"
 	case entity.ErrPasswordTooShort:
 		return http.StatusBadRequest, fmt.Sprintf("Password must be greater then %d character in length", entity.MinPasswordLength)
+	case entity.ErrPasswordContainsEmail:
+		return http.StatusBadRequest, "Password must not contain your email address"
+	case entity.ErrPasswordContainsName:
+		return http.StatusBadRequest, "Password must not contain your name"
+	case entity.ErrPasswordBreached:
+		return http.StatusBadRequest, "Password has appeared in a data breach, please choose another password"
+	case entity.ErrUserSuspended:
+		return http.StatusForbidden, "Account is suspended"
+	case entity.ErrUserServiceAccount:
+		return http.StatusForbidden, "Service accounts can only authenticate with API keys"
 	// User repository
 	case domain.ErrEmailNotAvailable:
 		return http.StatusBadRequest, "Email is not available"
 	case domain.ErrUserNotFound:
 		return http.StatusNotFound, "User not found"
+	// User usecase
+	case domain.ErrEmailNotVerified:
+		return http.StatusForbidden, "Email address is not verified"
+	case domain.ErrEmailAlreadyVerified:
+		return http.StatusBadRequest, "Email address is already verified"
+	case domain.ErrVerificationTokenInvalid:
+		return http.StatusBadRequest, "Verification token is invalid"
+	case domain.ErrVerificationThrottled:
+		return http.StatusTooManyRequests, "Verification email was sent recently, please try again later"
+	case domain.ErrRoleForbidden:
+		return http.StatusForbidden, "Not have access to this resource"
+	// Verification entity
+	case entity.ErrVerificationTokenExpired:
+		return http.StatusBadRequest, "Verification token is expired"
+	// Password reset usecase
+	case domain.ErrPasswordResetTokenInvalid:
+		return http.StatusBadRequest, "Password reset token is invalid"
+	// Password reset entity
+	case entity.ErrPasswordResetTokenExpired:
+		return http.StatusBadRequest, "Password reset token is expired"
 	// Auth entity
 	case entity.ErrAuthTokenExpired:
 		return http.StatusBadRequest, "Refresh token is expired"
+	case entity.ErrAccessTokenRevoked:
+		return http.StatusUnauthorized, "Access token is revoked"
 	// Auth repository
 	case domain.ErrAuthNotFound:
 		return http.StatusNotFound, "Authentication not found"
-	case domain.ErrEmailNotExist:
-		return http.StatusBadRequest, "Email is not exist"
 	// Auth usecase
+	case domain.ErrCredentialsInvalid:
+		return http.StatusBadRequest, "Email or password is incorrect"
 	case domain.ErrPasswordIncorrect:
 		return http.StatusBadRequest, "Password is incorrect"
+	case domain.ErrAuthTokenReused:
+		return http.StatusUnauthorized, "Refresh token has already been used, please log in again"
+	case domain.ErrOIDCStateInvalid:
+		return http.StatusBadRequest, "Login attempt is invalid, please try again"
+	case domain.ErrOIDCEmailMissing:
+		return http.StatusBadRequest, "Identity provider did not share an email address"
+	case domain.ErrOIDCEmailConflict:
+		return http.StatusBadRequest, "An account with this email already exists"
+	case domain.ErrOIDCEmailUnverified:
+		return http.StatusBadRequest, "Identity provider did not verify the email address"
+	case domain.ErrLoginMethodDisabled:
+		return http.StatusForbidden, "Login method is disabled"
+	case domain.ErrMagicLinkTokenInvalid:
+		return http.StatusBadRequest, "Login link is invalid"
+	// Magic link entity
+	case entity.ErrMagicLinkTokenExpired:
+		return http.StatusBadRequest, "Login link is expired"
+	case entity.ErrMagicLinkNonceMismatch:
+		return http.StatusBadRequest, "Login link was requested from another browser"
+	// Login method repository
+	case domain.ErrLoginMethodNotFound:
+		return http.StatusNotFound, "Login method not found"
+	// Identity entity
+	case entity.ErrOIDCStateExpired:
+		return http.StatusBadRequest, "Login attempt is expired, please try again"
+	// Two factor entity
+	case entity.ErrMFAChallengeExpired:
+		return http.StatusUnauthorized, "Two-factor challenge is expired, please log in again"
+	// Two factor usecase
+	case domain.ErrTwoFactorAlreadyEnabled:
+		return http.StatusBadRequest, "Two-factor authentication is already enabled"
+	case domain.ErrTwoFactorNotEnabled:
+		return http.StatusBadRequest, "Two-factor authentication is not enabled"
+	case domain.ErrTwoFactorNotSetup:
+		return http.StatusBadRequest, "Two-factor authentication is not set up"
+	case domain.ErrTwoFactorCodeInvalid:
+		return http.StatusBadRequest, "Two-factor code is invalid"
+	case domain.ErrMFAChallengeInvalid:
+		return http.StatusUnauthorized, "Two-factor challenge is invalid, please log in again"
+	// Session usecase
+	case domain.ErrSessionAuthorization:
+		return http.StatusForbidden, "Not have access to this session"
+	case domain.ErrSessionUnknown:
+		return http.StatusBadRequest, "Current session is unknown, please log in again"
+	// Personal token entity
+	case entity.ErrPersonalTokenExpired:
+		return http.StatusUnauthorized, "Personal access token is expired"
+	case entity.ErrPersonalTokenForbidden:
+		return http.StatusForbidden, "Personal access tokens can not be used for this action"
+	case entity.ErrScopeInvalid:
+		return http.StatusBadRequest, "Scopes must be any of tasks, templates or filters with read or write access"
+	case entity.ErrScopeMissing:
+		return http.StatusForbidden, "Personal access token is missing the required scope"
+	// Personal token repository
+	case domain.ErrPersonalTokenNotFound:
+		return http.StatusNotFound, "Personal access token not found"
+	// Personal token usecase
+	case domain.ErrPersonalTokenAuthorization:
+		return http.StatusForbidden, "Not have access to this personal access token"
+	case domain.ErrPersonalTokenInvalid:
+		return http.StatusUnauthorized, "Personal access token is invalid"
+	case domain.ErrPersonalTokenExpiryInPast:
+		return http.StatusBadRequest, "Expiry time must be in the future"
+	// Service account entity
+	case entity.ErrServiceAccountKeyExpired:
+		return http.StatusUnauthorized, "Service account API key is expired"
+	case entity.ErrServiceAccountForbidden:
+		return http.StatusForbidden, "Service accounts can not be used for this action"
+	// Service account repository
+	case domain.ErrServiceAccountKeyNotFound:
+		return http.StatusNotFound, "Service account API key not found"
+	// Service account usecase
+	case domain.ErrServiceAccountNotFound:
+		return http.StatusNotFound, "Service account not found"
+	case domain.ErrServiceAccountKeyInvalid:
+		return http.StatusUnauthorized, "Service account API key is invalid"
 	// Task repository
 	case domain.ErrTaskNotFound:
 		return http.StatusNotFound, "Task not found"
 	// Task usecase
 	case domain.ErrTaskAuthorization:
 		return http.StatusForbidden, "Not have access to this task"
+	case domain.ErrTaskSnoozeInPast:
+		return http.StatusBadRequest, "Snooze time must be in the future"
+	// Template entity
+	case entity.ErrTemplateVariableMissing:
+		return http.StatusBadRequest, "Template variable is missing"
+	// Template repository
+	case domain.ErrTemplateNotFound:
+		return http.StatusNotFound, "Template not found"
+	// Template usecase
+	case domain.ErrTemplateAuthorization:
+		return http.StatusForbidden, "Not have access to this template"
+	// Filter repository
+	case domain.ErrFilterNotFound:
+		return http.StatusNotFound, "Filter not found"
+	// Filter usecase
+	case domain.ErrFilterAuthorization:
+		return http.StatusForbidden, "Not have access to this filter"
+	case domain.ErrFilterOrderMismatch:
+		return http.StatusBadRequest, "Filter ids must list every filter exactly once"
+	// Checklist repository
+	case domain.ErrChecklistItemNotFound:
+		return http.StatusNotFound, "Checklist item not found"
+	// Checklist usecase
+	case domain.ErrChecklistOrderMismatch:
+		return http.StatusBadRequest, "Item ids must list every checklist item exactly once"
+	// Admin usecase
+	case domain.ErrAdminSelfSuspend:
+		return http.StatusBadRequest, "Can not suspend your own account"
+	case domain.ErrLoginMethodLast:
+		return http.StatusBadRequest, "Can not disable the last login method"
+	// Stats usecase
+	case domain.ErrStatsRangeInvalid:
+		return http.StatusBadRequest, "Date range must start before it ends and span at most 366 days"
 	// DTO
 	case dto.ErrEmailEmpty:
 		return http.StatusBadRequest, "Email is required field"
 	case dto.ErrPasswordEmpty:
 		return http.StatusBadRequest, "Password is required field"
+	case dto.ErrCurrentPasswordEmpty:
+		return http.StatusBadRequest, "Current password is required field"
+	case dto.ErrNewPasswordEmpty:
+		return http.StatusBadRequest, "New password is required field"
 	case dto.ErrNameEmpty:
 		return http.StatusBadRequest, "Name is required field"
 	case dto.ErrRefreshTokenEmpty:
 		return http.StatusBadRequest, "Refresh token is required field"
+	case dto.ErrTokenEmpty:
+		return http.StatusBadRequest, "Token is required field"
+	case dto.ErrCSRFTokenInvalid:
+		return http.StatusForbidden, "CSRF token is missing or invalid"
 	case dto.ErrContentEmpty:
 		return http.StatusBadRequest, "Content is required field"
+	case dto.ErrTaskIDsEmpty:
+		return http.StatusBadRequest, "Task ids is required field"
+	case dto.ErrAnchorInvalid:
+		return http.StatusBadRequest, "Anchor must be a date in YYYY-MM-DD format"
+	case dto.ErrDateInvalid:
+		return http.StatusBadRequest, "Date must be in YYYY-MM-DD format"
+	case dto.ErrTimeZoneInvalid:
+		return http.StatusBadRequest, "Time zone is invalid"
+	case dto.ErrQueryEmpty:
+		return http.StatusBadRequest, "Query is required field"
+	case dto.ErrFilterIDsEmpty:
+		return http.StatusBadRequest, "Filter ids is required field"
+	case dto.ErrSnoozeEmpty:
+		return http.StatusBadRequest, "Preset or until is required field"
+	case dto.ErrSnoozeConflict:
+		return http.StatusBadRequest, "Only one of preset or until can be provided"
+	case dto.ErrSnoozePresetInvalid:
+		return http.StatusBadRequest, "Preset must be one of later_today, tomorrow or next_week"
+	case dto.ErrTextEmpty:
+		return http.StatusBadRequest, "Text is required field"
+	case dto.ErrItemIDsEmpty:
+		return http.StatusBadRequest, "Item ids is required field"
+	case dto.ErrCodeEmpty:
+		return http.StatusBadRequest, "Code is required field"
+	case dto.ErrMFATokenEmpty:
+		return http.StatusBadRequest, "MFA token is required field"
+	case dto.ErrStateEmpty:
+		return http.StatusBadRequest, "State is required field"
+	case dto.ErrScopesEmpty:
+		return http.StatusBadRequest, "Scopes is required field"
+	case dto.ErrLimitInvalid:
+		return http.StatusBadRequest, fmt.Sprintf("Limit must be a number between 0 and %d", dto.AdminUserMaxLimit)
+	case dto.ErrOffsetInvalid:
+		return http.StatusBadRequest, "Offset must be zero or a positive number"
+	case dto.ErrTimeInvalid:
+		return http.StatusBadRequest, "Since and until must be times in RFC 3339 format"
+	case dto.ErrTimeRangeInvalid:
+		return http.StatusBadRequest, "Since must be before until"
+	case dto.ErrEnabledEmpty:
+		return http.StatusBadRequest, "Enabled is required field"
+	// OIDC
+	case oidc.ErrProviderUnknown:
+		return http.StatusNotFound, "Identity provider not found"
+	case oidc.ErrExchangeFailed, oidc.ErrIDTokenInvalid:
+		return http.StatusUnauthorized, "Identity provider login failed"
 	// Security JWT
 	case security.ErrAccessTokenExpired:
 		return http.StatusUnauthorized, "Access token is expired"
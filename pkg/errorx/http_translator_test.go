@@ -4,13 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
 	"github.com/edwintantawi/taskit/internal/domain"
 	"github.com/edwintantawi/taskit/internal/domain/dto"
 	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/pkg/oidc"
 	"github.com/edwintantawi/taskit/pkg/security"
+	"github.com/edwintantawi/taskit/pkg/tql"
 )
 
 type HTTPErrorTranslatorTestSuite struct {
@@ -30,29 +33,139 @@ func (s *HTTPErrorTranslatorTestSuite) TestErrorTranslator() {
 		// User entity
 		{entity.ErrEmailInvalid, 400, "Email must be a valid email address"},
 		{entity.ErrPasswordTooShort, 400, fmt.Sprintf("Password must be greater then %d character in length", entity.MinPasswordLength)},
+		{entity.ErrPasswordContainsEmail, 400, "Password must not contain your email address"},
+		{entity.ErrPasswordContainsName, 400, "Password must not contain your name"},
+		{entity.ErrPasswordBreached, 400, "Password has appeared in a data breach, please choose another password"},
+		{entity.ErrUserSuspended, 403, "Account is suspended"},
+		{&entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: 8}, 400, "Password must be at least 8 characters in length"},
+		{&entity.PasswordLengthError{Err: entity.ErrPasswordTooLong, Limit: 72}, 400, "Password must be at most 72 characters in length"},
 		// User repository
 		{domain.ErrEmailNotAvailable, 400, "Email is not available"},
 		{domain.ErrUserNotFound, 404, "User not found"},
+		{domain.ErrEmailNotVerified, 403, "Email address is not verified"},
+		{domain.ErrEmailAlreadyVerified, 400, "Email address is already verified"},
+		{domain.ErrVerificationTokenInvalid, 400, "Verification token is invalid"},
+		{domain.ErrVerificationThrottled, 429, "Verification email was sent recently, please try again later"},
+		{domain.ErrRoleForbidden, 403, "Not have access to this resource"},
+		{entity.ErrVerificationTokenExpired, 400, "Verification token is expired"},
+		{domain.ErrPasswordResetTokenInvalid, 400, "Password reset token is invalid"},
+		{entity.ErrPasswordResetTokenExpired, 400, "Password reset token is expired"},
 		// Auth entity
 		{entity.ErrAuthTokenExpired, 400, "Refresh token is expired"},
+		{entity.ErrAccessTokenRevoked, 401, "Access token is revoked"},
 		// Auth repository
 		{domain.ErrAuthNotFound, 404, "Authentication not found"},
 		// Auth usecase
+		{domain.ErrCredentialsInvalid, 400, "Email or password is incorrect"},
 		{domain.ErrPasswordIncorrect, 400, "Password is incorrect"},
-		{domain.ErrEmailNotExist, 400, "Email is not exist"},
+		{domain.ErrAuthTokenReused, 401, "Refresh token has already been used, please log in again"},
+		{domain.ErrOIDCStateInvalid, 400, "Login attempt is invalid, please try again"},
+		{domain.ErrOIDCEmailMissing, 400, "Identity provider did not share an email address"},
+		{domain.ErrOIDCEmailConflict, 400, "An account with this email already exists"},
+		{domain.ErrOIDCEmailUnverified, 400, "Identity provider did not verify the email address"},
+		{domain.ErrLoginMethodDisabled, 403, "Login method is disabled"},
+		{domain.ErrMagicLinkTokenInvalid, 400, "Login link is invalid"},
+		// Magic link entity
+		{entity.ErrMagicLinkTokenExpired, 400, "Login link is expired"},
+		{entity.ErrMagicLinkNonceMismatch, 400, "Login link was requested from another browser"},
+		// Login method repository
+		{domain.ErrLoginMethodNotFound, 404, "Login method not found"},
+		// Identity entity
+		{entity.ErrOIDCStateExpired, 400, "Login attempt is expired, please try again"},
+		// Two factor entity
+		{entity.ErrMFAChallengeExpired, 401, "Two-factor challenge is expired, please log in again"},
+		// Two factor usecase
+		{domain.ErrTwoFactorAlreadyEnabled, 400, "Two-factor authentication is already enabled"},
+		{domain.ErrTwoFactorNotEnabled, 400, "Two-factor authentication is not enabled"},
+		{domain.ErrTwoFactorNotSetup, 400, "Two-factor authentication is not set up"},
+		{domain.ErrTwoFactorCodeInvalid, 400, "Two-factor code is invalid"},
+		{domain.ErrMFAChallengeInvalid, 401, "Two-factor challenge is invalid, please log in again"},
 		// Task repository
+		{domain.ErrSessionAuthorization, 403, "Not have access to this session"},
+		{domain.ErrSessionUnknown, 400, "Current session is unknown, please log in again"},
+		// Personal token
+		{entity.ErrPersonalTokenExpired, 401, "Personal access token is expired"},
+		{entity.ErrPersonalTokenForbidden, 403, "Personal access tokens can not be used for this action"},
+		{entity.ErrScopeInvalid, 400, "Scopes must be any of tasks, templates or filters with read or write access"},
+		{entity.ErrScopeMissing, 403, "Personal access token is missing the required scope"},
+		{domain.ErrPersonalTokenNotFound, 404, "Personal access token not found"},
+		{domain.ErrPersonalTokenAuthorization, 403, "Not have access to this personal access token"},
+		{domain.ErrPersonalTokenInvalid, 401, "Personal access token is invalid"},
+		{domain.ErrPersonalTokenExpiryInPast, 400, "Expiry time must be in the future"},
+		// Service account
+		{entity.ErrUserServiceAccount, 403, "Service accounts can only authenticate with API keys"},
+		{entity.ErrServiceAccountKeyExpired, 401, "Service account API key is expired"},
+		{entity.ErrServiceAccountForbidden, 403, "Service accounts can not be used for this action"},
+		{domain.ErrServiceAccountKeyNotFound, 404, "Service account API key not found"},
+		{domain.ErrServiceAccountNotFound, 404, "Service account not found"},
+		{domain.ErrServiceAccountKeyInvalid, 401, "Service account API key is invalid"},
+		{&domain.ServiceAccountThrottledError{RetryAfter: time.Minute}, 429, "Too many requests, please try again later"},
 		{domain.ErrTaskNotFound, 404, "Task not found"},
 		// Task usecase
 		{domain.ErrTaskAuthorization, 403, "Not have access to this task"},
+		{domain.ErrTaskSnoozeInPast, 400, "Snooze time must be in the future"},
+		// Template entity
+		{entity.ErrTemplateVariableMissing, 400, "Template variable is missing"},
+		// Template repository
+		{domain.ErrTemplateNotFound, 404, "Template not found"},
+		// Template usecase
+		{domain.ErrTemplateAuthorization, 403, "Not have access to this template"},
+		// Filter repository
+		{domain.ErrFilterNotFound, 404, "Filter not found"},
+		// Filter usecase
+		{domain.ErrFilterAuthorization, 403, "Not have access to this filter"},
+		{domain.ErrFilterOrderMismatch, 400, "Filter ids must list every filter exactly once"},
+		{domain.ErrChecklistItemNotFound, 404, "Checklist item not found"},
+		{domain.ErrChecklistOrderMismatch, 400, "Item ids must list every checklist item exactly once"},
+		// Admin usecase
+		{domain.ErrAdminSelfSuspend, 400, "Can not suspend your own account"},
+		{domain.ErrLoginMethodLast, 400, "Can not disable the last login method"},
+		// Stats usecase
+		{domain.ErrStatsRangeInvalid, 400, "Date range must start before it ends and span at most 366 days"},
 		// DTO
 		{dto.ErrEmailEmpty, 400, "Email is required field"},
 		{dto.ErrPasswordEmpty, 400, "Password is required field"},
+		{dto.ErrCurrentPasswordEmpty, 400, "Current password is required field"},
+		{dto.ErrNewPasswordEmpty, 400, "New password is required field"},
 		{dto.ErrNameEmpty, 400, "Name is required field"},
 		{dto.ErrRefreshTokenEmpty, 400, "Refresh token is required field"},
+		{dto.ErrTokenEmpty, 400, "Token is required field"},
+		{dto.ErrCSRFTokenInvalid, 403, "CSRF token is missing or invalid"},
 		{dto.ErrContentEmpty, 400, "Content is required field"},
+		{dto.ErrTaskIDsEmpty, 400, "Task ids is required field"},
+		{dto.ErrAnchorInvalid, 400, "Anchor must be a date in YYYY-MM-DD format"},
+		{dto.ErrDateInvalid, 400, "Date must be in YYYY-MM-DD format"},
+		{dto.ErrTimeZoneInvalid, 400, "Time zone is invalid"},
+		{dto.ErrQueryEmpty, 400, "Query is required field"},
+		{dto.ErrFilterIDsEmpty, 400, "Filter ids is required field"},
+		{dto.ErrSnoozeEmpty, 400, "Preset or until is required field"},
+		{dto.ErrSnoozeConflict, 400, "Only one of preset or until can be provided"},
+		{dto.ErrSnoozePresetInvalid, 400, "Preset must be one of later_today, tomorrow or next_week"},
+		{dto.ErrTextEmpty, 400, "Text is required field"},
+		{dto.ErrItemIDsEmpty, 400, "Item ids is required field"},
+		{dto.ErrCodeEmpty, 400, "Code is required field"},
+		{dto.ErrMFATokenEmpty, 400, "MFA token is required field"},
+		{dto.ErrStateEmpty, 400, "State is required field"},
+		{dto.ErrScopesEmpty, 400, "Scopes is required field"},
+		{dto.ErrLimitInvalid, 400, "Limit must be a number between 0 and 100"},
+		{dto.ErrOffsetInvalid, 400, "Offset must be zero or a positive number"},
+		{dto.ErrTimeInvalid, 400, "Since and until must be times in RFC 3339 format"},
+		{dto.ErrTimeRangeInvalid, 400, "Since must be before until"},
+		{dto.ErrEnabledEmpty, 400, "Enabled is required field"},
+		// OIDC
+		{oidc.ErrProviderUnknown, 404, "Identity provider not found"},
+		{oidc.ErrExchangeFailed, 401, "Identity provider login failed"},
+		{oidc.ErrIDTokenInvalid, 401, "Identity provider login failed"},
 		// Security JWT
 		{security.ErrAccessTokenExpired, 401, "Access token is expired"},
 		{security.ErrAccessTokenInvalid, 401, "Access token is invalid"},
+		// Task query
+		{&tql.Error{Pos: 3, Msg: "unexpected \")\""}, 400, "Query is invalid: unexpected \")\" at position 3"},
+		{fmt.Errorf("wrapped: %w", &tql.Error{Pos: 0, Msg: "unknown field \"x\""}), 400, "Query is invalid: unknown field \"x\" at position 0"},
+		// Login throttling
+		{&domain.LoginThrottledError{RetryAfter: time.Minute}, 429, "Too many failed login attempts, please try again later"},
+		{&domain.PasswordResetThrottledError{RetryAfter: time.Minute}, 429, "Too many password reset requests, please try again later"},
+		{&domain.MagicLinkThrottledError{RetryAfter: time.Minute}, 429, "Too many login link requests, please try again later"},
 		// Other
 		{errors.New("other error"), 500, "Something went wrong"},
 	}
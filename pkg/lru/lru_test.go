@@ -0,0 +1,72 @@
+package lru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LRUTestSuite struct {
+	suite.Suite
+}
+
+func TestLRUSuite(t *testing.T) {
+	suite.Run(t, new(LRUTestSuite))
+}
+
+func (s *LRUTestSuite) TestGet() {
+	s.Run("it should return false when key is unknown", func() {
+		cache := New[string, bool](2)
+
+		_, ok := cache.Get("xxxxx")
+		s.False(ok)
+	})
+
+	s.Run("it should return value when key is not expired", func() {
+		cache := New[string, bool](2)
+		cache.Add("xxxxx", true, time.Now().Add(time.Minute))
+
+		value, ok := cache.Get("xxxxx")
+		s.True(ok)
+		s.True(value)
+	})
+
+	s.Run("it should return false and remove the entry when key is expired", func() {
+		cache := New[string, bool](2)
+		cache.Add("xxxxx", true, time.Now().Add(-time.Minute))
+
+		_, ok := cache.Get("xxxxx")
+		s.False(ok)
+		s.Equal(0, cache.Len())
+	})
+}
+
+func (s *LRUTestSuite) TestAdd() {
+	s.Run("it should replace the value of an existing key", func() {
+		cache := New[string, int](2)
+		cache.Add("xxxxx", 1, time.Now().Add(time.Minute))
+		cache.Add("xxxxx", 2, time.Now().Add(time.Minute))
+
+		value, ok := cache.Get("xxxxx")
+		s.True(ok)
+		s.Equal(2, value)
+		s.Equal(1, cache.Len())
+	})
+
+	s.Run("it should evict the least recently used entry when full", func() {
+		cache := New[string, int](2)
+		cache.Add("xxxxx", 1, time.Now().Add(time.Minute))
+		cache.Add("yyyyy", 2, time.Now().Add(time.Minute))
+		cache.Get("xxxxx")
+		cache.Add("zzzzz", 3, time.Now().Add(time.Minute))
+
+		_, ok := cache.Get("yyyyy")
+		s.False(ok)
+		_, ok = cache.Get("xxxxx")
+		s.True(ok)
+		_, ok = cache.Get("zzzzz")
+		s.True(ok)
+		s.Equal(2, cache.Len())
+	})
+}
@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"context"
+	"log"
+
+	"github.com/edwintantawi/taskit/internal/domain"
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Async struct {
+	mailer domain.Mailer
+}
+
+// NewAsync wrap a mailer to deliver mails in the background, so a request never waits on the mail server.
+// A mail sent or not then takes the same time, which keeps responses from revealing who got one.
+func NewAsync(mailer domain.Mailer) Async {
+	return Async{mailer: mailer}
+}
+
+// Send deliver a mail in the background and return right away.
+// The request may be over by the time it is delivered, so delivery errors are only logged.
+func (a *Async) Send(ctx context.Context, mail *entity.Mail) error {
+	go func() {
+		if err := a.mailer.Send(context.Background(), mail); err != nil {
+			log.Printf("Failed to send %s mail: %v", mail.Template, err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,69 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+//go:embed templates
+var templateFS embed.FS
+
+var (
+	textTemplates = texttemplate.Must(texttemplate.ParseFS(templateFS, "templates/*.txt"))
+	htmlTemplates = htmltemplate.Must(htmltemplate.ParseFS(templateFS, "templates/*.html"))
+)
+
+// subjects hold the subject line of every mail template.
+var subjects = map[entity.MailTemplate]string{
+	entity.MailTemplateVerifyEmail:   "Verify your email address",
+	entity.MailTemplateResetPassword: "Reset your password",
+	entity.MailTemplateMagicLink:     "Your login link",
+}
+
+type Config struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// Message represents a rendered mail ready to be delivered.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type renderer struct {
+	appURL string
+}
+
+// render renders the text and html body of a mail.
+// The application url is available to every template as AppURL.
+func (r renderer) render(mail *entity.Mail) (Message, error) {
+	subject, ok := subjects[mail.Template]
+	if !ok {
+		return Message{}, fmt.Errorf("mailer: unknown template %q", mail.Template)
+	}
+
+	data := map[string]string{"AppURL": r.appURL}
+	for key, value := range mail.Data {
+		data[key] = value
+	}
+
+	var text, html bytes.Buffer
+	if err := textTemplates.ExecuteTemplate(&text, string(mail.Template)+".txt", data); err != nil {
+		return Message{}, err
+	}
+	if err := htmlTemplates.ExecuteTemplate(&html, string(mail.Template)+".html", data); err != nil {
+		return Message{}, err
+	}
+	return Message{To: mail.To, Subject: subject, Text: text.String(), HTML: html.String()}, nil
+}
@@ -0,0 +1,138 @@
+package mailer
+
+import (
+	"context"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+	"github.com/edwintantawi/taskit/test"
+)
+
+type MailerTestSuite struct {
+	suite.Suite
+}
+
+func TestMailerSuite(t *testing.T) {
+	suite.Run(t, new(MailerTestSuite))
+}
+
+func (s *MailerTestSuite) TestMemorySend() {
+	s.Run("it should return error when template is unknown", func() {
+		mailer := NewMemory("https://taskit.dev")
+		err := mailer.Send(context.Background(), &entity.Mail{To: "gopher@go.dev", Template: "unknown"})
+
+		s.Error(err)
+		s.Empty(mailer.Messages())
+	})
+
+	s.Run("it should render text and html body when success", func() {
+		mailer := NewMemory("https://taskit.dev")
+		err := mailer.Send(context.Background(), &entity.Mail{
+			To:       "gopher@go.dev",
+			Template: entity.MailTemplateVerifyEmail,
+			Data:     map[string]string{"Name": "<Gopher>", "Token": "raw_token"},
+		})
+
+		s.NoError(err)
+		s.Len(mailer.Messages(), 1)
+
+		msg := mailer.Messages()[0]
+		s.Equal("gopher@go.dev", msg.To)
+		s.Equal("Verify your email address", msg.Subject)
+		s.Contains(msg.Text, "Hi <Gopher>,")
+		s.Contains(msg.Text, "https://taskit.dev/verify-email?token=raw_token")
+		s.Contains(msg.HTML, "Hi &lt;Gopher&gt;,")
+		s.Contains(msg.HTML, `href="https://taskit.dev/verify-email?token=raw_token"`)
+	})
+}
+
+func (s *MailerTestSuite) TestTemplates() {
+	for template, subject := range subjects {
+		s.Run("it should render "+string(template)+" template", func() {
+			mailer := NewMemory("https://taskit.dev")
+			err := mailer.Send(context.Background(), &entity.Mail{
+				To:       "gopher@go.dev",
+				Template: template,
+				Data:     map[string]string{"Name": "Gopher", "Token": "raw_token"},
+			})
+
+			s.NoError(err)
+			msg := mailer.Messages()[0]
+			s.Equal(subject, msg.Subject)
+			s.Contains(msg.Text, "token=raw_token")
+			s.Contains(msg.HTML, "token=raw_token")
+		})
+	}
+}
+
+func (s *MailerTestSuite) TestAsyncSend() {
+	s.Run("it should return error nil and deliver the mail in the background", func() {
+		memory := NewMemory("https://taskit.dev")
+		mailer := NewAsync(&memory)
+		err := mailer.Send(context.Background(), &entity.Mail{
+			To:       "gopher@go.dev",
+			Template: entity.MailTemplateResetPassword,
+			Data:     map[string]string{"Name": "Gopher", "Token": "raw_token"},
+		})
+
+		s.NoError(err)
+		s.Eventually(func() bool { return len(memory.Messages()) == 1 }, time.Second, 10*time.Millisecond)
+		s.Equal("gopher@go.dev", memory.Messages()[0].To)
+	})
+
+	s.Run("it should return error nil when delivery fail", func() {
+		memory := NewMemory("https://taskit.dev")
+		mailer := NewAsync(&memory)
+		err := mailer.Send(context.Background(), &entity.Mail{To: "gopher@go.dev", Template: "unknown"})
+
+		s.NoError(err)
+	})
+}
+
+func (s *MailerTestSuite) TestSMTPSend() {
+	s.Run("it should return error when smtp server return error", func() {
+		mailer := NewSMTP(Config{Host: "localhost", Port: "25", From: "taskit@taskit.dev"}, "https://taskit.dev")
+		mailer.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			return test.ErrUnexpected
+		}
+
+		err := mailer.Send(context.Background(), &entity.Mail{To: "gopher@go.dev", Template: entity.MailTemplateVerifyEmail})
+		s.Equal(test.ErrUnexpected, err)
+	})
+
+	s.Run("it should deliver a multipart message when success", func() {
+		var (
+			gotAddr string
+			gotAuth smtp.Auth
+			gotFrom string
+			gotTo   []string
+			gotMsg  string
+		)
+		mailer := NewSMTP(Config{Host: "localhost", Port: "25", From: "taskit@taskit.dev"}, "https://taskit.dev")
+		mailer.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+			gotAddr, gotAuth, gotFrom, gotTo, gotMsg = addr, a, from, to, string(msg)
+			return nil
+		}
+
+		err := mailer.Send(context.Background(), &entity.Mail{
+			To:       "gopher@go.dev",
+			Template: entity.MailTemplateVerifyEmail,
+			Data:     map[string]string{"Name": "Gopher", "Token": "raw_token"},
+		})
+
+		s.NoError(err)
+		s.Equal("localhost:25", gotAddr)
+		s.Nil(gotAuth)
+		s.Equal("taskit@taskit.dev", gotFrom)
+		s.Equal([]string{"gopher@go.dev"}, gotTo)
+		s.True(strings.HasPrefix(gotMsg, "From: taskit@taskit.dev\r\nTo: gopher@go.dev\r\n"))
+		s.Contains(gotMsg, "Content-Type: multipart/alternative; boundary=")
+		s.Contains(gotMsg, "Content-Type: text/plain; charset=UTF-8")
+		s.Contains(gotMsg, "Content-Type: text/html; charset=UTF-8")
+	})
+}
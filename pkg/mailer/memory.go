@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type Memory struct {
+	renderer renderer
+	mu       sync.Mutex
+	messages []Message
+}
+
+// NewMemory create a new mailer that keeps rendered mails in memory instead of delivering them.
+func NewMemory(appURL string) Memory {
+	return Memory{renderer: renderer{appURL: appURL}}
+}
+
+// Send renders a mail and keeps it in memory.
+func (m *Memory) Send(ctx context.Context, mail *entity.Mail) error {
+	msg, err := m.renderer.render(mail)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages returns every mail sent so far.
+func (m *Memory) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
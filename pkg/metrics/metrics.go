@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+const (
+	typeCounter = "counter"
+	typeGauge   = "gauge"
+)
+
+// Registry keeps counters and gauges in memory and serves them in the Prometheus text format.
+// It is safe for concurrent use.
+type Registry struct {
+	mu     sync.Mutex
+	values map[string]int64
+	types  map[string]string
+}
+
+// New create a new empty metrics registry.
+func New() Registry {
+	return Registry{values: make(map[string]int64), types: make(map[string]string)}
+}
+
+// Add increase the counter name by delta.
+func (r *Registry) Add(name string, delta int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[name] += delta
+	r.types[name] = typeCounter
+}
+
+// Set set the gauge name to value.
+func (r *Registry) Set(name string, value int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.values[name] = value
+	r.types[name] = typeGauge
+}
+
+// ServeHTTP write every metric in the Prometheus text format, sorted by name.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	r.mu.Lock()
+	names := make([]string, 0, len(r.values))
+	for name := range r.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var body []byte
+	for _, name := range names {
+		body = fmt.Appendf(body, "# TYPE %s %s\n%s %d\n", name, r.types[name], name, r.values[name])
+	}
+	r.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.WriteHeader(http.StatusOK)
+	w.Write(body)
+}
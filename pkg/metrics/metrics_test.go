@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsTestSuite struct {
+	suite.Suite
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) TestServeHTTP() {
+	s.Run("it should response with no metrics when nothing was recorded", func() {
+		registry := New()
+		rr := httptest.NewRecorder()
+
+		registry.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal("text/plain; version=0.0.4", rr.Header().Get("Content-Type"))
+		s.Empty(rr.Body.String())
+	})
+
+	s.Run("it should response with counters summed up and gauges replaced, sorted by name", func() {
+		registry := New()
+		registry.Add("taskit_xxxxx_total", 2)
+		registry.Add("taskit_xxxxx_total", 3)
+		registry.Set("taskit_yyyyy", 7)
+		registry.Set("taskit_yyyyy", 4)
+		registry.Add("taskit_aaaaa_total", 1)
+		rr := httptest.NewRecorder()
+
+		registry.ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+		s.Equal(http.StatusOK, rr.Code)
+		s.Equal(
+			"# TYPE taskit_aaaaa_total counter\ntaskit_aaaaa_total 1\n"+
+				"# TYPE taskit_xxxxx_total counter\ntaskit_xxxxx_total 5\n"+
+				"# TYPE taskit_yyyyy gauge\ntaskit_yyyyy 4\n",
+			rr.Body.String(),
+		)
+	})
+}
@@ -0,0 +1,266 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+var (
+	ErrProviderUnknown = errors.New("oidc.provider_unknown")
+	ErrExchangeFailed  = errors.New("oidc.exchange_failed")
+	ErrIDTokenInvalid  = errors.New("oidc.id_token_invalid")
+)
+
+// signingMethods are the ID token algorithms accepted from providers.
+var signingMethods = []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+
+// Config represent an OpenID Connect provider the app can log in with.
+type Config struct {
+	Name         string   `json:"name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"client_id"`
+	ClientSecret string   `json:"client_secret"`
+	RedirectURL  string   `json:"redirect_url"`
+	Scopes       []string `json:"scopes"`
+}
+
+// discovery is the subset of the provider metadata the relying party needs.
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce         string `json:"nonce"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+type provider struct {
+	cfg Config
+
+	mu        sync.Mutex
+	discovery *discovery
+	keys      map[string]any
+}
+
+type Client struct {
+	providers  map[string]*provider
+	httpClient *http.Client
+}
+
+// New create a new OpenID Connect relying party for the configured providers.
+// Provider metadata and keys are discovered lazily on first use.
+func New(configs []Config) Client {
+	providers := make(map[string]*provider, len(configs))
+	for _, cfg := range configs {
+		providers[cfg.Name] = &provider{cfg: cfg}
+	}
+	return Client{providers: providers, httpClient: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// AuthCodeURL build the authorization url of a provider using the authorization code flow with PKCE.
+func (c *Client) AuthCodeURL(ctx context.Context, name string, state string, nonce string, codeVerifier string) (string, error) {
+	p, ok := c.providers[name]
+	if !ok {
+		return "", ErrProviderUnknown
+	}
+	d, err := c.discover(ctx, p)
+	if err != nil {
+		return "", err
+	}
+
+	scopes := p.cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"openid", "email", "profile"}
+	}
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {strings.Join(scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+
+	authURL, err := url.Parse(d.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	for key, values := range authURL.Query() {
+		if _, ok := query[key]; !ok {
+			query[key] = values
+		}
+	}
+	authURL.RawQuery = query.Encode()
+	return authURL.String(), nil
+}
+
+// Exchange redeem an authorization code and return the claims of the verified ID token.
+func (c *Client) Exchange(ctx context.Context, name string, code string, codeVerifier string, nonce string) (entity.OIDCClaims, error) {
+	p, ok := c.providers[name]
+	if !ok {
+		return entity.OIDCClaims{}, ErrProviderUnknown
+	}
+	d, err := c.discover(ctx, p)
+	if err != nil {
+		return entity.OIDCClaims{}, err
+	}
+
+	rawIDToken, err := c.redeem(ctx, p, d, code, codeVerifier)
+	if err != nil {
+		return entity.OIDCClaims{}, err
+	}
+
+	var claims idTokenClaims
+	parser := jwt.NewParser(jwt.WithValidMethods(signingMethods))
+	_, err = parser.ParseWithClaims(rawIDToken, &claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		return c.key(ctx, p, d, kid)
+	})
+	if err != nil {
+		return entity.OIDCClaims{}, ErrIDTokenInvalid
+	}
+	if claims.Issuer != d.Issuer || !claims.VerifyAudience(p.cfg.ClientID, true) || claims.Subject == "" {
+		return entity.OIDCClaims{}, ErrIDTokenInvalid
+	}
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return entity.OIDCClaims{}, ErrIDTokenInvalid
+	}
+
+	return entity.OIDCClaims{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+// redeem exchange the authorization code for an ID token at the token endpoint.
+func (c *Client) redeem(ctx context.Context, p *provider, d *discovery, code string, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return "", ErrExchangeFailed
+	}
+
+	var body struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil || body.IDToken == "" {
+		return "", ErrExchangeFailed
+	}
+	return body.IDToken, nil
+}
+
+// discover fetch and cache the metadata of a provider.
+func (c *Client) discover(ctx context.Context, p *provider) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.discovery != nil {
+		return p.discovery, nil
+	}
+
+	var d discovery
+	wellKnown := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	if err := c.getJSON(ctx, wellKnown, &d); err != nil {
+		return nil, err
+	}
+	if d.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc: discovered issuer %q does not match %q", d.Issuer, p.cfg.Issuer)
+	}
+	p.discovery = &d
+	return p.discovery, nil
+}
+
+// key return the verification key of an ID token.
+// The key set is fetched again when the key id is unknown, so rotated keys are picked up.
+func (c *Client) key(ctx context.Context, p *provider, d *discovery, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := lookupKey(p.keys, kid); ok {
+		return key, nil
+	}
+
+	var set jwks
+	if err := c.getJSON(ctx, d.JWKSURI, &set); err != nil {
+		return nil, err
+	}
+	p.keys = set.publicKeys()
+
+	key, ok := lookupKey(p.keys, kid)
+	if !ok {
+		return nil, ErrIDTokenInvalid
+	}
+	return key, nil
+}
+
+// lookupKey find a key by id, a token without key id is accepted when the set has a single key.
+func lookupKey(keys map[string]any, kid string) (any, bool) {
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	key, ok := keys[kid]
+	return key, ok
+}
+
+func (c *Client) getJSON(ctx context.Context, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("oidc: unexpected status %d from %s", res.StatusCode, url)
+	}
+	return json.NewDecoder(res.Body).Decode(v)
+}
@@ -0,0 +1,252 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+// fakeIdP is an in-process OpenID Connect provider.
+type fakeIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	kid    string
+
+	// codes hold the pkce challenge and nonce of every authorization code granted.
+	codes map[string]url.Values
+	// claims let a test tamper with the issued ID token.
+	claims func(c jwt.MapClaims)
+}
+
+func newFakeIdP() *fakeIdP {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	idp := &fakeIdP{key: key, kid: "key-1", codes: map[string]url.Values{}, claims: func(c jwt.MapClaims) {}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": idp.kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		grant, ok := idp.codes[r.FormValue("code")]
+		delete(idp.codes, r.FormValue("code"))
+		verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if !ok || clientID != "taskit" || clientSecret != "client_secret" ||
+			base64.RawURLEncoding.EncodeToString(verifier[:]) != grant.Get("code_challenge") {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		claims := jwt.MapClaims{
+			"iss":            idp.server.URL,
+			"sub":            "subject-xxxxx",
+			"aud":            "taskit",
+			"exp":            time.Now().Add(time.Minute).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          grant.Get("nonce"),
+			"email":          "gopher@go.dev",
+			"email_verified": true,
+			"name":           "Gopher",
+		}
+		idp.claims(claims)
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = idp.kid
+		signed, _ := token.SignedString(idp.key)
+		json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "access_token": "access_token", "token_type": "Bearer"})
+	})
+	idp.server = httptest.NewServer(mux)
+	return idp
+}
+
+// authorize simulate the user logging in at the provider and return the granted code.
+func (f *fakeIdP) authorize(authURL string) string {
+	u, _ := url.Parse(authURL)
+	f.codes["code-xxxxx"] = u.Query()
+	return "code-xxxxx"
+}
+
+// rotate replace the signing key, as providers do from time to time.
+func (f *fakeIdP) rotate() {
+	f.key, _ = rsa.GenerateKey(rand.Reader, 2048)
+	f.kid = "key-2"
+}
+
+type OIDCTestSuite struct {
+	suite.Suite
+}
+
+func TestOIDCSuite(t *testing.T) {
+	suite.Run(t, new(OIDCTestSuite))
+}
+
+func newClient(idp *fakeIdP) Client {
+	return New([]Config{{
+		Name:         "acme",
+		Issuer:       idp.server.URL,
+		ClientID:     "taskit",
+		ClientSecret: "client_secret",
+		RedirectURL:  "http://localhost:5173/auth/oidc/acme",
+	}})
+}
+
+func (s *OIDCTestSuite) TestAuthCodeURL() {
+	s.Run("it should return error ErrProviderUnknown when provider is not configured", func() {
+		idp := newFakeIdP()
+		defer idp.server.Close()
+		client := newClient(idp)
+
+		_, err := client.AuthCodeURL(context.Background(), "unknown", "state", "nonce", "verifier")
+
+		s.Equal(ErrProviderUnknown, err)
+	})
+
+	s.Run("it should return url with state, nonce and s256 challenge when success", func() {
+		idp := newFakeIdP()
+		defer idp.server.Close()
+		client := newClient(idp)
+
+		authURL, err := client.AuthCodeURL(context.Background(), "acme", "state", "nonce", "verifier")
+		s.NoError(err)
+
+		u, _ := url.Parse(authURL)
+		challenge := sha256.Sum256([]byte("verifier"))
+		s.Equal(idp.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+		s.Equal("code", u.Query().Get("response_type"))
+		s.Equal("taskit", u.Query().Get("client_id"))
+		s.Equal("http://localhost:5173/auth/oidc/acme", u.Query().Get("redirect_uri"))
+		s.Equal("openid email profile", u.Query().Get("scope"))
+		s.Equal("state", u.Query().Get("state"))
+		s.Equal("nonce", u.Query().Get("nonce"))
+		s.Equal(base64.RawURLEncoding.EncodeToString(challenge[:]), u.Query().Get("code_challenge"))
+		s.Equal("S256", u.Query().Get("code_challenge_method"))
+	})
+}
+
+func (s *OIDCTestSuite) TestExchange() {
+	tests := []struct {
+		name     string
+		verifier string
+		nonce    string
+		setup    func(idp *fakeIdP)
+		expected entity.OIDCClaims
+		err      error
+	}{
+		{
+			name:     "it should return error ErrExchangeFailed when code verifier does not match the challenge",
+			verifier: "other_verifier",
+			nonce:    "nonce",
+			setup:    func(idp *fakeIdP) {},
+			err:      ErrExchangeFailed,
+		},
+		{
+			name:     "it should return error ErrIDTokenInvalid when nonce does not match",
+			verifier: "verifier",
+			nonce:    "other_nonce",
+			setup:    func(idp *fakeIdP) {},
+			err:      ErrIDTokenInvalid,
+		},
+		{
+			name:     "it should return error ErrIDTokenInvalid when audience is another client",
+			verifier: "verifier",
+			nonce:    "nonce",
+			setup: func(idp *fakeIdP) {
+				idp.claims = func(c jwt.MapClaims) { c["aud"] = "another_client" }
+			},
+			err: ErrIDTokenInvalid,
+		},
+		{
+			name:     "it should return error ErrIDTokenInvalid when issuer is another provider",
+			verifier: "verifier",
+			nonce:    "nonce",
+			setup: func(idp *fakeIdP) {
+				idp.claims = func(c jwt.MapClaims) { c["iss"] = "https://evil.example" }
+			},
+			err: ErrIDTokenInvalid,
+		},
+		{
+			name:     "it should return error ErrIDTokenInvalid when id token is expired",
+			verifier: "verifier",
+			nonce:    "nonce",
+			setup: func(idp *fakeIdP) {
+				idp.claims = func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }
+			},
+			err: ErrIDTokenInvalid,
+		},
+		{
+			name:     "it should return error ErrIDTokenInvalid when id token is not signed by the provider",
+			verifier: "verifier",
+			nonce:    "nonce",
+			setup: func(idp *fakeIdP) {
+				idp.key, _ = rsa.GenerateKey(rand.Reader, 2048)
+			},
+			err: ErrIDTokenInvalid,
+		},
+		{
+			name:     "it should return error nil and claims when signing key was rotated",
+			verifier: "verifier",
+			nonce:    "nonce",
+			setup: func(idp *fakeIdP) {
+				idp.rotate()
+			},
+			expected: entity.OIDCClaims{Subject: "subject-xxxxx", Email: "gopher@go.dev", EmailVerified: true, Name: "Gopher"},
+		},
+		{
+			name:     "it should return error nil and claims when success",
+			verifier: "verifier",
+			nonce:    "nonce",
+			setup:    func(idp *fakeIdP) {},
+			expected: entity.OIDCClaims{Subject: "subject-xxxxx", Email: "gopher@go.dev", EmailVerified: true, Name: "Gopher"},
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			idp := newFakeIdP()
+			defer idp.server.Close()
+			client := newClient(idp)
+
+			// A first login caches the metadata and the current key set.
+			authURL, err := client.AuthCodeURL(context.Background(), "acme", "state", "nonce", "verifier")
+			s.NoError(err)
+			_, err = client.Exchange(context.Background(), "acme", idp.authorize(authURL), "verifier", "nonce")
+			s.NoError(err)
+
+			t.setup(idp)
+			claims, err := client.Exchange(context.Background(), "acme", idp.authorize(authURL), t.verifier, t.nonce)
+
+			s.Equal(t.err, err)
+			s.Equal(t.expected, claims)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// defaultLimit is how many requests a key may make per window when no limit is configured.
+	defaultLimit = 600
+	// defaultWindow is the window requests are counted in when no window is configured.
+	defaultWindow = time.Minute
+)
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// Limiter allows each key up to limit requests per fixed window, the count starts over with the next window.
+// It is kept in memory, so every replica of the api counts on its own. It is safe for concurrent use.
+type Limiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	windows map[string]*window
+}
+
+// New create a new rate limiter allowing limit requests per window for each key.
+func New(limit int, windowSize time.Duration) Limiter {
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if windowSize <= 0 {
+		windowSize = defaultWindow
+	}
+	return Limiter{limit: limit, window: windowSize, windows: make(map[string]*window)}
+}
+
+// Allow count a request of key and report whether it is within the limit.
+// A request over the limit is not counted, and the wait until the next window is returned with it.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.window {
+		l.sweep(now)
+		w = &window{start: now}
+		l.windows[key] = w
+	}
+
+	if w.count >= l.limit {
+		return false, w.start.Add(l.window).Sub(now)
+	}
+	w.count++
+	return true, 0
+}
+
+// sweep drop the windows that are over, so keys that stopped making requests are not kept forever.
+func (l *Limiter) sweep(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.window {
+			delete(l.windows, key)
+		}
+	}
+}
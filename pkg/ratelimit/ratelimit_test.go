@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+func TestRateLimitSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (s *RateLimitTestSuite) TestAllow() {
+	s.Run("it should allow requests up to the limit and reject the rest until the window is over", func() {
+		limiter := New(2, time.Minute)
+
+		ok, _ := limiter.Allow("user-xxxxx")
+		s.True(ok)
+		ok, _ = limiter.Allow("user-xxxxx")
+		s.True(ok)
+		ok, retryAfter := limiter.Allow("user-xxxxx")
+		s.False(ok)
+		s.InDelta(time.Minute, retryAfter, float64(time.Second))
+	})
+
+	s.Run("it should count every key on its own", func() {
+		limiter := New(1, time.Minute)
+
+		ok, _ := limiter.Allow("user-xxxxx")
+		s.True(ok)
+		ok, _ = limiter.Allow("user-yyyyy")
+		s.True(ok)
+		ok, _ = limiter.Allow("user-xxxxx")
+		s.False(ok)
+	})
+
+	s.Run("it should start over when the window is over", func() {
+		limiter := New(1, 10*time.Millisecond)
+
+		ok, _ := limiter.Allow("user-xxxxx")
+		s.True(ok)
+		ok, _ = limiter.Allow("user-xxxxx")
+		s.False(ok)
+
+		time.Sleep(20 * time.Millisecond)
+		ok, _ = limiter.Allow("user-xxxxx")
+		s.True(ok)
+	})
+}
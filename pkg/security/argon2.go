@@ -0,0 +1,141 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrHashMismatch = errors.New("security.argon2.hash_mismatch")
+	ErrHashInvalid  = errors.New("security.argon2.hash_invalid")
+)
+
+// Default Argon2id parameters, the second recommended configuration of RFC 9106.
+const (
+	defaultArgon2Memory      = 64 * 1024
+	defaultArgon2Iterations  = 3
+	defaultArgon2Parallelism = 4
+	argon2SaltLength         = 16
+	argon2KeyLength          = 32
+)
+
+// Argon2Config is the cost of new hashes, zero values use the defaults.
+type Argon2Config struct {
+	// Memory is in KiB.
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// argon2Params are the parameters a hash was computed with.
+type argon2Params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// Argon2 hashes passwords with Argon2id into PHC strings, such as
+// $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>, so every hash carries its own parameters.
+// Bcrypt hashes of existing users are still verified and reported as needing a rehash.
+type Argon2 struct {
+	params argon2Params
+}
+
+func NewArgon2(cfg Argon2Config) Argon2 {
+	a := Argon2{params: argon2Params{memory: cfg.Memory, iterations: cfg.Iterations, parallelism: cfg.Parallelism}}
+	if a.params.memory == 0 {
+		a.params.memory = defaultArgon2Memory
+	}
+	if a.params.iterations == 0 {
+		a.params.iterations = defaultArgon2Iterations
+	}
+	if a.params.parallelism == 0 {
+		a.params.parallelism = defaultArgon2Parallelism
+	}
+	return a
+}
+
+// Hash hashes a raw string with a random salt.
+func (a *Argon2) Hash(raw string) ([]byte, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	p := a.params
+	key := argon2.IDKey([]byte(raw), salt, p.iterations, p.memory, p.parallelism, argon2KeyLength)
+
+	b64 := base64.RawStdEncoding
+	phc := fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s", argon2.Version, p.memory, p.iterations, p.parallelism, b64.EncodeToString(salt), b64.EncodeToString(key))
+	return []byte(phc), nil
+}
+
+// Compare compares a raw string with an Argon2id or a bcrypt hashed string.
+func (a *Argon2) Compare(raw string, hashed string) error {
+	if isBcrypt(hashed) {
+		return bcrypt.CompareHashAndPassword([]byte(hashed), []byte(raw))
+	}
+
+	p, salt, key, err := parseArgon2(hashed)
+	if err != nil {
+		return err
+	}
+	other := argon2.IDKey([]byte(raw), salt, p.iterations, p.memory, p.parallelism, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrHashMismatch
+	}
+	return nil
+}
+
+// NeedsRehash report whether a hashed string is not Argon2id or is weaker than the configured parameters.
+func (a *Argon2) NeedsRehash(hashed string) bool {
+	p, salt, key, err := parseArgon2(hashed)
+	if err != nil {
+		return true
+	}
+	return p.memory < a.params.memory ||
+		p.iterations < a.params.iterations ||
+		p.parallelism < a.params.parallelism ||
+		len(salt) < argon2SaltLength ||
+		len(key) < argon2KeyLength
+}
+
+func isBcrypt(hashed string) bool {
+	return strings.HasPrefix(hashed, "$2a$") || strings.HasPrefix(hashed, "$2b$") || strings.HasPrefix(hashed, "$2y$")
+}
+
+// parseArgon2 parse an Argon2id PHC string into its parameters, salt and key.
+func parseArgon2(hashed string) (argon2Params, []byte, []byte, error) {
+	var p argon2Params
+	parts := strings.Split(hashed, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != "argon2id" {
+		return p, nil, nil, ErrHashInvalid
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, ErrHashInvalid
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism); err != nil {
+		return p, nil, nil, ErrHashInvalid
+	}
+	if p.memory == 0 || p.iterations == 0 || p.parallelism == 0 {
+		return p, nil, nil, ErrHashInvalid
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, ErrHashInvalid
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, ErrHashInvalid
+	}
+	return p, salt, key, nil
+}
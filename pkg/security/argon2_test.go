@@ -0,0 +1,90 @@
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Argon2TestSuite struct {
+	suite.Suite
+}
+
+func TestArgon2Suite(t *testing.T) {
+	suite.Run(t, new(Argon2TestSuite))
+}
+
+// referenceHash is "password" hashed with salt "somesalt", m=256, t=3 and p=2, a test vector of the reference implementation.
+const referenceHash = "$argon2id$v=19$m=256,t=3,p=2$c29tZXNhbHQ$RmjTCsQYfmh47t6s8P2DxaCjDbLMFu8L"
+
+func (s *Argon2TestSuite) TestHash() {
+	a := NewArgon2(Argon2Config{Memory: 1024, Iterations: 1, Parallelism: 1})
+
+	s.Run("it should return a PHC string with the configured parameters", func() {
+		hashed, err := a.Hash("secret_password")
+		s.Require().NoError(err)
+		s.True(strings.HasPrefix(string(hashed), "$argon2id$v=19$m=1024,t=1,p=1$"))
+	})
+
+	s.Run("it should use a random salt for every hash", func() {
+		first, _ := a.Hash("secret_password")
+		second, _ := a.Hash("secret_password")
+		s.NotEqual(first, second)
+	})
+}
+
+func (s *Argon2TestSuite) TestCompare() {
+	a := NewArgon2(Argon2Config{Memory: 1024, Iterations: 1, Parallelism: 1})
+	hashed, _ := a.Hash("secret_password")
+	bcryptHashed, _ := bcrypt.GenerateFromPassword([]byte("secret_password"), bcrypt.MinCost)
+
+	tests := []struct {
+		name     string
+		raw      string
+		hashed   string
+		expected error
+	}{
+		{name: "it should return error nil when password matches", raw: "secret_password", hashed: string(hashed), expected: nil},
+		{name: "it should return error ErrHashMismatch when password does not match", raw: "other_password", hashed: string(hashed), expected: ErrHashMismatch},
+		{name: "it should return error nil when password matches the reference implementation", raw: "password", hashed: referenceHash, expected: nil},
+		{name: "it should return error nil when password matches a bcrypt hash", raw: "secret_password", hashed: string(bcryptHashed), expected: nil},
+		{name: "it should return error when password does not match a bcrypt hash", raw: "other_password", hashed: string(bcryptHashed), expected: bcrypt.ErrMismatchedHashAndPassword},
+		{name: "it should return error ErrHashInvalid when hash is another algorithm", raw: "password", hashed: "$argon2i$v=19$m=256,t=3,p=2$c29tZXNhbHQ$RmjTCsQYfmh47t6s8P2DxaCjDbLMFu8L", expected: ErrHashInvalid},
+		{name: "it should return error ErrHashInvalid when hash is another version", raw: "password", hashed: "$argon2id$v=16$m=256,t=3,p=2$c29tZXNhbHQ$RmjTCsQYfmh47t6s8P2DxaCjDbLMFu8L", expected: ErrHashInvalid},
+		{name: "it should return error ErrHashInvalid when hash is malformed", raw: "password", hashed: "$argon2id$v=19$m=256$c29tZXNhbHQ", expected: ErrHashInvalid},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			s.Equal(t.expected, a.Compare(t.raw, t.hashed))
+		})
+	}
+}
+
+func (s *Argon2TestSuite) TestNeedsRehash() {
+	a := NewArgon2(Argon2Config{Memory: 1024, Iterations: 2, Parallelism: 1})
+	current, _ := a.Hash("secret_password")
+	stronger := NewArgon2(Argon2Config{Memory: 2048, Iterations: 3, Parallelism: 2})
+	strongerHashed, _ := stronger.Hash("secret_password")
+	bcryptHashed, _ := bcrypt.GenerateFromPassword([]byte("secret_password"), bcrypt.MinCost)
+
+	tests := []struct {
+		name     string
+		hashed   string
+		expected bool
+	}{
+		{name: "it should return false when hash uses the configured parameters", hashed: string(current), expected: false},
+		{name: "it should return false when hash is stronger than the configured parameters", hashed: string(strongerHashed), expected: false},
+		{name: "it should return true when hash uses less memory or iterations", hashed: referenceHash, expected: true},
+		{name: "it should return true when hash is bcrypt", hashed: string(bcryptHashed), expected: true},
+		{name: "it should return true when hash is malformed", hashed: "secret_hashed_password", expected: true},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			s.Equal(t.expected, a.NeedsRehash(t.hashed))
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// MinPepperLength is the minimum length in bytes of a pepper, the size of a SHA-256 key.
+const MinPepperLength = 32
+
+var ErrPepperTooShort = errors.New("security.hmac.pepper_too_short")
+
+// HMAC hashes tokens with HMAC-SHA256 keyed by a server side pepper.
+// Unlike a plain digest, the stored hashes are useless without the pepper,
+// so a database dump alone can't be used to look up or forge tokens.
+type HMAC struct {
+	pepper []byte
+}
+
+// NewHMAC create a new HMAC token hasher.
+// It refuses an empty or short pepper, which would make the stored hashes as weak as a plain digest.
+func NewHMAC(pepper string) (HMAC, error) {
+	if len(pepper) < MinPepperLength {
+		return HMAC{}, ErrPepperTooShort
+	}
+	return HMAC{pepper: []byte(pepper)}, nil
+}
+
+// Hash returns the hex encoded HMAC-SHA256 of a token.
+func (h *HMAC) Hash(raw string) string {
+	mac := hmac.New(sha256.New, h.pepper)
+	mac.Write([]byte(raw))
+	return hex.EncodeToString(mac.Sum(nil))
+}
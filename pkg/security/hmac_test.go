@@ -0,0 +1,59 @@
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HMACTestSuite struct {
+	suite.Suite
+}
+
+func TestHMACSuite(t *testing.T) {
+	suite.Run(t, new(HMACTestSuite))
+}
+
+func (s *HMACTestSuite) TestNewHMAC() {
+	tests := []struct {
+		name     string
+		pepper   string
+		expected error
+	}{
+		{
+			name:     "it should return error ErrPepperTooShort when pepper is empty",
+			pepper:   "",
+			expected: ErrPepperTooShort,
+		},
+		{
+			name:     "it should return error ErrPepperTooShort when pepper is shorter than 32 bytes",
+			pepper:   strings.Repeat("x", MinPepperLength-1),
+			expected: ErrPepperTooShort,
+		},
+		{
+			name:     "it should return error nil when pepper is 32 bytes",
+			pepper:   strings.Repeat("x", MinPepperLength),
+			expected: nil,
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			_, err := NewHMAC(t.pepper)
+
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *HMACTestSuite) TestHash() {
+	first, err := NewHMAC(strings.Repeat("a", MinPepperLength))
+	s.Require().NoError(err)
+	second, err := NewHMAC(strings.Repeat("b", MinPepperLength))
+	s.Require().NoError(err)
+
+	s.Equal(first.Hash("token"), first.Hash("token"))
+	s.NotEqual(first.Hash("token"), first.Hash("other_token"))
+	s.NotEqual(first.Hash("token"), second.Hash("token"))
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 
 	"github.com/edwintantawi/taskit/internal/domain/entity"
 )
@@ -14,53 +15,128 @@ var (
 	ErrAccessTokenInvalid = errors.New("security.jwt.access_token_invalid")
 )
 
+const (
+	// defaultJWTIssuer is the iss claim when no issuer is configured.
+	defaultJWTIssuer = "taskit"
+	// defaultJWTAudience is the aud claim of access tokens when no audience is configured.
+	defaultJWTAudience = "taskit-api"
+)
+
 type jwtClaims struct {
 	jwt.RegisteredClaims
-	UserID entity.UserID `json:"user_id"`
+	UserID    entity.UserID `json:"user_id"`
+	SessionID entity.AuthID `json:"sid,omitempty"`
 }
 
 type JWTTokenConfig struct {
 	Key string
 	Exp int
+	// SigningKeyFile is a PEM private key, tokens are signed with HS256 and Key when it is empty.
+	SigningKeyFile string
+	// VerificationKeyFiles are PEM public keys that are still accepted, such as the previous key during a rotation.
+	VerificationKeyFiles []string
+}
+
+type JWTConfig struct {
+	Issuer       string
+	Audience     string
+	AccessToken  JWTTokenConfig
+	RefreshToken JWTTokenConfig
 }
 
 type JWT struct {
+	issuer              string
+	audience            string
 	accessTokenKey      string
 	refreshTokenKey     string
 	accessTokenExpires  int
 	refreshTokenExpires int
+	signingKey          *jwtKey
+	verificationKeys    []*jwtKey
 }
 
-func NewJWT(accessToken, refreshToken JWTTokenConfig) JWT {
-	return JWT{
-		accessTokenKey:      accessToken.Key,
-		refreshTokenKey:     refreshToken.Key,
-		accessTokenExpires:  accessToken.Exp,
-		refreshTokenExpires: refreshToken.Exp,
+// NewJWT create a new jwt provider.
+// When an access token signing key is configured, access tokens are signed with it
+// and other services can verify them with the published key set instead of a shared secret.
+func NewJWT(cfg JWTConfig) (JWT, error) {
+	j := JWT{
+		issuer:              cfg.Issuer,
+		audience:            cfg.Audience,
+		accessTokenKey:      cfg.AccessToken.Key,
+		refreshTokenKey:     cfg.RefreshToken.Key,
+		accessTokenExpires:  cfg.AccessToken.Exp,
+		refreshTokenExpires: cfg.RefreshToken.Exp,
+	}
+	if j.issuer == "" {
+		j.issuer = defaultJWTIssuer
+	}
+	if j.audience == "" {
+		j.audience = defaultJWTAudience
+	}
+	if cfg.AccessToken.SigningKeyFile == "" {
+		return j, nil
+	}
+
+	signingKey, err := loadSigningKey(cfg.AccessToken.SigningKeyFile)
+	if err != nil {
+		return JWT{}, err
+	}
+	j.signingKey = signingKey
+	j.verificationKeys = []*jwtKey{signingKey}
+	for _, path := range cfg.AccessToken.VerificationKeyFiles {
+		key, err := loadVerificationKey(path)
+		if err != nil {
+			return JWT{}, err
+		}
+		if key.id != signingKey.id {
+			j.verificationKeys = append(j.verificationKeys, key)
+		}
 	}
+	return j, nil
 }
 
-func (j *JWT) GenerateAccessToken(userID entity.UserID) (string, time.Time, error) {
-	expiresTime := time.Now().Add(time.Duration(j.accessTokenExpires) * time.Second)
+func (j *JWT) GenerateAccessToken(userID entity.UserID, sessionID entity.AuthID) (string, time.Time, error) {
+	now := time.Now()
+	expiresTime := now.Add(time.Duration(j.accessTokenExpires) * time.Second)
 	claims := jwtClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    j.issuer,
+			Audience:  jwt.ClaimStrings{j.audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(expiresTime),
 		},
-		UserID: userID,
+		UserID:    userID,
+		SessionID: sessionID,
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString([]byte(j.accessTokenKey))
+	var signedToken string
+	var err error
+	if j.signingKey == nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		signedToken, err = token.SignedString([]byte(j.accessTokenKey))
+	} else {
+		token := jwt.NewWithClaims(j.signingKey.method, claims)
+		token.Header["kid"] = j.signingKey.id
+		signedToken, err = token.SignedString(j.signingKey.private)
+	}
 	if err != nil {
 		return "", time.Time{}, err
 	}
 	return signedToken, expiresTime, nil
 }
 
+// GenerateRefreshToken create a refresh token for a user.
+// Every token carries a unique id, so two tokens issued within the same second never collide.
 func (j *JWT) GenerateRefreshToken(userID entity.UserID) (string, time.Time, error) {
-	expiresTime := time.Now().Add(time.Duration(j.refreshTokenExpires) * time.Second)
+	now := time.Now()
+	expiresTime := now.Add(time.Duration(j.refreshTokenExpires) * time.Second)
 	claims := jwtClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    j.issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(expiresTime),
 		},
 		UserID: userID,
@@ -74,25 +150,62 @@ func (j *JWT) GenerateRefreshToken(userID entity.UserID) (string, time.Time, err
 	return signedToken, expiresTime, nil
 }
 
-func (j *JWT) VerifyAccessToken(rawToken string) (entity.UserID, error) {
-	token, err := jwt.Parse(rawToken, func(t *jwt.Token) (interface{}, error) {
+// VerifyAccessToken verify the signature and the registered claims of an access token.
+// The exp, nbf and iat claims are checked by the parser, iss and aud are checked here.
+// A token must carry a jti and an exp, so it can be revoked until it expires.
+func (j *JWT) VerifyAccessToken(rawToken string) (entity.AuthClaims, error) {
+	var claims jwtClaims
+	token, err := jwt.ParseWithClaims(rawToken, &claims, j.verificationKey)
+
+	if errors.Is(err, jwt.ErrTokenExpired) {
+		return entity.AuthClaims{}, ErrAccessTokenExpired
+	} else if err != nil {
+		return entity.AuthClaims{}, ErrAccessTokenInvalid
+	}
+
+	if !token.Valid || claims.ID == "" || claims.UserID == "" || claims.IssuedAt == nil || claims.ExpiresAt == nil {
+		return entity.AuthClaims{}, ErrAccessTokenInvalid
+	}
+	if !claims.VerifyIssuer(j.issuer, true) || !claims.VerifyAudience(j.audience, true) {
+		return entity.AuthClaims{}, ErrAccessTokenInvalid
+	}
+	return entity.AuthClaims{
+		TokenID:   claims.ID,
+		UserID:    claims.UserID,
+		SessionID: claims.SessionID,
+		IssuedAt:  claims.IssuedAt.Time,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// verificationKey find the key an access token was signed with.
+// The algorithm of the token must be the one of the key, so a token can't pick how its key is used.
+func (j *JWT) verificationKey(t *jwt.Token) (interface{}, error) {
+	if j.signingKey == nil {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("invalid token alg")
 		}
 		return []byte(j.accessTokenKey), nil
-	})
-
-	if errors.Is(err, jwt.ErrTokenExpired) {
-		return "", ErrAccessTokenExpired
-	} else if err != nil {
-		return "", ErrAccessTokenInvalid
 	}
 
-	claims, ok := token.Claims.(jwt.MapClaims)
-	if !ok || !token.Valid {
-		return "", ErrAccessTokenInvalid
+	kid, _ := t.Header["kid"].(string)
+	for _, key := range j.verificationKeys {
+		if key.id == kid {
+			if t.Method.Alg() != key.method.Alg() {
+				return nil, errors.New("invalid token alg")
+			}
+			return key.public, nil
+		}
 	}
+	return nil, errors.New("unknown token key")
+}
 
-	userID := entity.UserID(claims["user_id"].(string))
-	return userID, nil
+// PublicKeys return the keys access tokens can be verified with, the current signing key first.
+// It is empty when access tokens are signed with a shared secret.
+func (j *JWT) PublicKeys() []entity.JWK {
+	keys := make([]entity.JWK, len(j.verificationKeys))
+	for i, key := range j.verificationKeys {
+		keys[i] = key.jwk()
+	}
+	return keys
 }
@@ -0,0 +1,322 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type JWTTestSuite struct {
+	suite.Suite
+}
+
+func TestJWTSuite(t *testing.T) {
+	suite.Run(t, new(JWTTestSuite))
+}
+
+// writeKeys write a private key and its public key as PEM files and return their paths.
+func (s *JWTTestSuite) writeKeys(name string, private crypto.Signer) (string, string) {
+	var privateBlock *pem.Block
+	switch key := private.(type) {
+	case *rsa.PrivateKey:
+		privateBlock = &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	case *ecdsa.PrivateKey:
+		der, err := x509.MarshalECPrivateKey(key)
+		s.Require().NoError(err)
+		privateBlock = &pem.Block{Type: "EC PRIVATE KEY", Bytes: der}
+	default:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		s.Require().NoError(err)
+		privateBlock = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+	publicDER, err := x509.MarshalPKIXPublicKey(private.Public())
+	s.Require().NoError(err)
+
+	dir := s.T().TempDir()
+	privatePath := filepath.Join(dir, name+".pem")
+	publicPath := filepath.Join(dir, name+".pub")
+	s.Require().NoError(os.WriteFile(privatePath, pem.EncodeToMemory(privateBlock), 0o600))
+	s.Require().NoError(os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+	return privatePath, publicPath
+}
+
+func (s *JWTTestSuite) newJWT(signingKeyFile string, verificationKeyFiles ...string) JWT {
+	j, err := NewJWT(JWTConfig{
+		AccessToken:  JWTTokenConfig{Key: "access_secret", Exp: 60, SigningKeyFile: signingKeyFile, VerificationKeyFiles: verificationKeyFiles},
+		RefreshToken: JWTTokenConfig{Key: "refresh_secret", Exp: 60},
+	})
+	s.Require().NoError(err)
+	return j
+}
+
+func (s *JWTTestSuite) TestNewJWT() {
+	s.Run("it should return error when signing key file does not exist", func() {
+		_, err := NewJWT(JWTConfig{AccessToken: JWTTokenConfig{SigningKeyFile: filepath.Join(s.T().TempDir(), "missing.pem")}})
+		s.Error(err)
+	})
+
+	s.Run("it should return error when signing key file is not a private key", func() {
+		rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+		_, publicPath := s.writeKeys("rsa", rsaKey)
+
+		_, err := NewJWT(JWTConfig{AccessToken: JWTTokenConfig{SigningKeyFile: publicPath}})
+		s.Error(err)
+	})
+}
+
+func (s *JWTTestSuite) TestAccessToken() {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name string
+		key  crypto.Signer
+		alg  string
+		kty  string
+	}{
+		{name: "it should sign and verify access tokens with RS256 when key is RSA", key: rsaKey, alg: "RS256", kty: "RSA"},
+		{name: "it should sign and verify access tokens with ES256 when key is ECDSA P-256", key: ecKey, alg: "ES256", kty: "EC"},
+		{name: "it should sign and verify access tokens with EdDSA when key is Ed25519", key: edKey, alg: "EdDSA", kty: "OKP"},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			privatePath, _ := s.writeKeys(t.kty, t.key)
+			j := s.newJWT(privatePath)
+
+			rawToken, expires, err := j.GenerateAccessToken("user-xxxxx", "auth-xxxxx")
+			s.Require().NoError(err)
+
+			claims, err := j.VerifyAccessToken(rawToken)
+			s.NoError(err)
+			s.Equal(entity.UserID("user-xxxxx"), claims.UserID)
+			s.Equal(entity.AuthID("auth-xxxxx"), claims.SessionID)
+			s.True(claims.ExpiresAt.Equal(expires.Truncate(time.Second)))
+
+			keys := j.PublicKeys()
+			s.Len(keys, 1)
+			s.Equal(t.alg, keys[0].Algorithm)
+			s.Equal(t.kty, keys[0].KeyType)
+			s.Equal("sig", keys[0].Use)
+
+			var registered jwt.RegisteredClaims
+			token, _, err := jwt.NewParser().ParseUnverified(rawToken, &registered)
+			s.Require().NoError(err)
+			s.Equal(t.alg, token.Header["alg"])
+			s.Equal(keys[0].KeyID, token.Header["kid"])
+			s.NotEmpty(registered.ID)
+			s.Equal(registered.ID, claims.TokenID)
+			s.True(registered.IssuedAt.Time.Equal(claims.IssuedAt))
+			s.Equal("taskit", registered.Issuer)
+			s.Equal(jwt.ClaimStrings{"taskit-api"}, registered.Audience)
+			s.NotNil(registered.IssuedAt)
+			s.NotNil(registered.NotBefore)
+		})
+	}
+
+	s.Run("it should sign and verify access tokens with HS256 when no signing key is configured", func() {
+		j := s.newJWT("")
+
+		rawToken, _, err := j.GenerateAccessToken("user-xxxxx", "auth-xxxxx")
+		s.Require().NoError(err)
+
+		claims, err := j.VerifyAccessToken(rawToken)
+		s.NoError(err)
+		s.NotEmpty(claims.TokenID)
+		s.Equal(entity.UserID("user-xxxxx"), claims.UserID)
+		s.Equal(entity.AuthID("auth-xxxxx"), claims.SessionID)
+		s.Empty(j.PublicKeys())
+	})
+}
+
+func (s *JWTTestSuite) TestRotation() {
+	previousKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	currentKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	previousPrivate, previousPublic := s.writeKeys("previous", previousKey)
+	currentPrivate, _ := s.writeKeys("current", currentKey)
+
+	previous := s.newJWT(previousPrivate)
+	oldToken, _, err := previous.GenerateAccessToken("user-xxxxx", "auth-xxxxx")
+	s.Require().NoError(err)
+
+	s.Run("it should accept tokens of the previous key while it is a verification key", func() {
+		current := s.newJWT(currentPrivate, previousPublic)
+
+		claims, err := current.VerifyAccessToken(oldToken)
+		s.NoError(err)
+		s.Equal(entity.UserID("user-xxxxx"), claims.UserID)
+
+		keys := current.PublicKeys()
+		s.Len(keys, 2)
+		s.Equal("ES256", keys[0].Algorithm)
+		s.Equal("RS256", keys[1].Algorithm)
+	})
+
+	s.Run("it should return error ErrAccessTokenInvalid when the previous key is retired", func() {
+		current := s.newJWT(currentPrivate)
+
+		_, err := current.VerifyAccessToken(oldToken)
+		s.Equal(ErrAccessTokenInvalid, err)
+	})
+}
+
+func (s *JWTTestSuite) TestVerifyAccessToken() {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	privatePath, _ := s.writeKeys("ec", key)
+	j := s.newJWT(privatePath)
+	kid := j.PublicKeys()[0].KeyID
+
+	sign := func(method jwt.SigningMethod, signingKey any, claims jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, claims)
+		token.Header["kid"] = kid
+		rawToken, err := token.SignedString(signingKey)
+		s.Require().NoError(err)
+		return rawToken
+	}
+	validClaims := func() jwt.MapClaims {
+		now := time.Now()
+		return jwt.MapClaims{
+			"jti":     "token-xxxxx",
+			"user_id": "user-xxxxx",
+			"iss":     "taskit",
+			"aud":     "taskit-api",
+			"iat":     now.Unix(),
+			"nbf":     now.Unix(),
+			"exp":     now.Add(time.Minute).Unix(),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		rawToken func() string
+		expected error
+	}{
+		{
+			name: "it should return error ErrAccessTokenExpired when token is expired",
+			rawToken: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				return sign(jwt.SigningMethodES256, key, claims)
+			},
+			expected: ErrAccessTokenExpired,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when token is not valid yet",
+			rawToken: func() string {
+				claims := validClaims()
+				claims["nbf"] = time.Now().Add(time.Minute).Unix()
+				return sign(jwt.SigningMethodES256, key, claims)
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when token is issued in the future",
+			rawToken: func() string {
+				claims := validClaims()
+				claims["iat"] = time.Now().Add(time.Minute).Unix()
+				return sign(jwt.SigningMethodES256, key, claims)
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when token has no issued at",
+			rawToken: func() string {
+				claims := validClaims()
+				delete(claims, "iat")
+				return sign(jwt.SigningMethodES256, key, claims)
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when token has no id",
+			rawToken: func() string {
+				claims := validClaims()
+				delete(claims, "jti")
+				return sign(jwt.SigningMethodES256, key, claims)
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when token has no expiration",
+			rawToken: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return sign(jwt.SigningMethodES256, key, claims)
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when issuer is another service",
+			rawToken: func() string {
+				claims := validClaims()
+				claims["iss"] = "other"
+				return sign(jwt.SigningMethodES256, key, claims)
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when audience is another service",
+			rawToken: func() string {
+				claims := validClaims()
+				claims["aud"] = "other-api"
+				return sign(jwt.SigningMethodES256, key, claims)
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when token is signed with the shared secret",
+			rawToken: func() string {
+				return sign(jwt.SigningMethodHS256, []byte("access_secret"), validClaims())
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error ErrAccessTokenInvalid when token is signed with an unknown key",
+			rawToken: func() string {
+				otherKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				return sign(jwt.SigningMethodES256, otherKey, validClaims())
+			},
+			expected: ErrAccessTokenInvalid,
+		},
+		{
+			name: "it should return error nil when token is valid",
+			rawToken: func() string {
+				return sign(jwt.SigningMethodES256, key, validClaims())
+			},
+			expected: nil,
+		},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			_, err := j.VerifyAccessToken(t.rawToken())
+			s.Equal(t.expected, err)
+		})
+	}
+}
+
+func (s *JWTTestSuite) TestThumbprint() {
+	s.Run("it should return the thumbprint of the RFC 7638 example key", func() {
+		jwk := entity.JWK{
+			KeyType: "RSA",
+			N:       "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+			E:       "AQAB",
+		}
+		s.Equal("NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", thumbprint(jwk))
+	})
+}
@@ -0,0 +1,124 @@
+package security
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+const (
+	// defaultMinPasswordLength is the shortest password in characters when no minimum is configured.
+	defaultMinPasswordLength = 8
+	// defaultMaxPasswordLength is the longest password in bytes when no maximum is configured,
+	// it bounds the work of hashing a password sent by anyone.
+	defaultMaxPasswordLength = 128
+	// minPersonalInfoLength is the shortest part of an email or name a password is checked to contain,
+	// shorter parts match too many unrelated passwords.
+	minPersonalInfoLength = 3
+)
+
+type PasswordPolicyConfig struct {
+	// MinLength is in characters, it defaults to 8 and can't be lower than entity.MinPasswordLength.
+	MinLength int
+	// MaxLength is in bytes.
+	MaxLength int
+	// BreachedCorpusDir holds SHA-1 range files of breached passwords, named by the
+	// first five hex characters of the hash, with a SUFFIX:COUNT line per password.
+	// Passwords are not checked against a corpus when it is empty.
+	BreachedCorpusDir string
+}
+
+// PasswordPolicy checks new passwords before they are hashed.
+// The breached password corpus is read one range file at a time, in the layout
+// of the k-anonymity range api, so no password or full hash ever leaves the server.
+type PasswordPolicy struct {
+	minLength         int
+	maxLength         int
+	breachedCorpusDir string
+}
+
+func NewPasswordPolicy(cfg PasswordPolicyConfig) PasswordPolicy {
+	p := PasswordPolicy{minLength: cfg.MinLength, maxLength: cfg.MaxLength, breachedCorpusDir: cfg.BreachedCorpusDir}
+	if p.minLength <= 0 {
+		p.minLength = defaultMinPasswordLength
+	}
+	if p.minLength < entity.MinPasswordLength {
+		p.minLength = entity.MinPasswordLength
+	}
+	if p.maxLength <= 0 {
+		p.maxLength = defaultMaxPasswordLength
+	}
+	return p
+}
+
+// Check validate a new password of a user against the policy.
+func (p *PasswordPolicy) Check(password string, user *entity.User) error {
+	if utf8.RuneCountInString(password) < p.minLength {
+		return &entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: p.minLength}
+	}
+	if len(password) > p.maxLength {
+		return &entity.PasswordLengthError{Err: entity.ErrPasswordTooLong, Limit: p.maxLength}
+	}
+
+	lower := strings.ToLower(password)
+	email := strings.ToLower(user.Email)
+	localPart, _, _ := strings.Cut(email, "@")
+	if containsPart(lower, email) || containsPart(lower, localPart) {
+		return entity.ErrPasswordContainsEmail
+	}
+	for _, part := range strings.Fields(strings.ToLower(user.Name)) {
+		if containsPart(lower, part) {
+			return entity.ErrPasswordContainsName
+		}
+	}
+
+	breached, err := p.isBreached(password)
+	if err != nil {
+		return err
+	}
+	if breached {
+		return entity.ErrPasswordBreached
+	}
+	return nil
+}
+
+func containsPart(password string, part string) bool {
+	return utf8.RuneCountInString(part) >= minPersonalInfoLength && strings.Contains(password, part)
+}
+
+// isBreached look up the SHA-1 hash of a password in its range file of the corpus.
+func (p *PasswordPolicy) isBreached(password string) (bool, error) {
+	if p.breachedCorpusDir == "" {
+		return false, nil
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:5], hash[5:]
+
+	f, err := os.Open(filepath.Join(p.breachedCorpusDir, prefix+".txt"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lineSuffix, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		// Padding lines of the range api have a count of zero.
+		if strings.EqualFold(lineSuffix, suffix) && count != "0" {
+			return true, nil
+		}
+	}
+	return false, scanner.Err()
+}
@@ -0,0 +1,71 @@
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/edwintantawi/taskit/internal/domain/entity"
+)
+
+type PasswordPolicyTestSuite struct {
+	suite.Suite
+}
+
+func TestPasswordPolicySuite(t *testing.T) {
+	suite.Run(t, new(PasswordPolicyTestSuite))
+}
+
+// writeCorpus write the range file of "P@ssw0rd", whose SHA-1 is 21BD12DC183F740EE76F27B78EB39C8AD972A757,
+// and the padding line of "padded_password".
+func (s *PasswordPolicyTestSuite) writeCorpus() string {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "21BD1.txt"), []byte("0018A45C4D1DEF81644B54AB7F969B88D65:1\r\n2DC183F740EE76F27B78EB39C8AD972A757:52579\r\n"), 0o600))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, "8E369.txt"), []byte("7A703389D6E302861F5F4B33D60931166F4:0\n"), 0o600))
+	return dir
+}
+
+func (s *PasswordPolicyTestSuite) TestCheck() {
+	policy := NewPasswordPolicy(PasswordPolicyConfig{MinLength: 8, MaxLength: 72, BreachedCorpusDir: s.writeCorpus()})
+	user := &entity.User{Name: "Edwin Tantawi", Email: "gopher@go.dev"}
+
+	tests := []struct {
+		name     string
+		password string
+		expected error
+	}{
+		{name: "it should return error PasswordLengthError when password is shorter than the minimum", password: "sh0rt", expected: &entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: 8}},
+		{name: "it should count characters instead of bytes for the minimum", password: "ééééééé", expected: &entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: 8}},
+		{name: "it should return error PasswordLengthError when password is longer than the maximum", password: strings.Repeat("a", 73), expected: &entity.PasswordLengthError{Err: entity.ErrPasswordTooLong, Limit: 72}},
+		{name: "it should return error ErrPasswordContainsEmail when password contains the email", password: "my-gopher@go.dev", expected: entity.ErrPasswordContainsEmail},
+		{name: "it should return error ErrPasswordContainsEmail when password contains the local part of the email", password: "GOPHER-2023", expected: entity.ErrPasswordContainsEmail},
+		{name: "it should return error ErrPasswordContainsName when password contains a part of the name", password: "tantawi!2023", expected: entity.ErrPasswordContainsName},
+		{name: "it should return error ErrPasswordBreached when password is in the corpus", password: "P@ssw0rd", expected: entity.ErrPasswordBreached},
+		{name: "it should return error nil when password is a padding line of the corpus", password: "padded_password", expected: nil},
+		{name: "it should return error nil when the range file of the password does not exist", password: "correct horse battery staple", expected: nil},
+	}
+
+	for _, t := range tests {
+		s.Run(t.name, func() {
+			s.Equal(t.expected, policy.Check(t.password, user))
+		})
+	}
+
+	s.Run("it should not check a corpus when it is not configured", func() {
+		policy := NewPasswordPolicy(PasswordPolicyConfig{})
+		s.NoError(policy.Check("P@ssw0rd", user))
+	})
+
+	s.Run("it should require 8 characters when no minimum is configured", func() {
+		policy := NewPasswordPolicy(PasswordPolicyConfig{})
+		s.Equal(&entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: 8}, policy.Check("x7#kq2!", user))
+	})
+
+	s.Run("it should not accept a minimum lower than the entity minimum", func() {
+		policy := NewPasswordPolicy(PasswordPolicyConfig{MinLength: 1})
+		s.Equal(&entity.PasswordLengthError{Err: entity.ErrPasswordTooShort, Limit: entity.MinPasswordLength}, policy.Check("abc", user))
+	})
+}
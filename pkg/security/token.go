@@ -0,0 +1,33 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// tokenBytes is the amount of random bytes in a generated token.
+const tokenBytes = 32
+
+type Token struct{}
+
+func NewToken() Token {
+	return Token{}
+}
+
+// Generate creates a new random url safe token.
+func (t *Token) Generate() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Hash hashes a token so that only the digest has to be stored.
+// Tokens are high entropy, so a fast hash is enough to protect them at rest.
+func (t *Token) Hash(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,122 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	// totpDigits is the length of a generated code.
+	totpDigits = 6
+	// totpPeriod is how long a code stays current.
+	totpPeriod = 30 * time.Second
+	// totpSkew is how many periods before and after the current one are still accepted,
+	// to tolerate clocks of the server and the authenticator app drifting apart.
+	totpSkew = 1
+	// totpSecretBytes is the size of a generated secret, as recommended by RFC 4226.
+	totpSecretBytes = 20
+	// totpQRCodeSize is the width and height of a generated QR code in pixels.
+	totpQRCodeSize = 256
+	// recoveryCodeChars is the amount of base32 characters in a recovery code, 50 bits of entropy.
+	recoveryCodeChars = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTP generate and validate RFC 6238 time based one-time passwords,
+// using the defaults every authenticator app supports (SHA-1, 6 digits, 30 seconds).
+type TOTP struct {
+	issuer string
+}
+
+func NewTOTP(issuer string) TOTP {
+	return TOTP{issuer: issuer}
+}
+
+// GenerateSecret creates a new random base32 encoded secret.
+func (t *TOTP) GenerateSecret() (string, error) {
+	b := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// GenerateRecoveryCode creates a new random one-time recovery code, formatted as xxxxx-xxxxx.
+func (t *TOTP) GenerateRecoveryCode() (string, error) {
+	b := make([]byte, totpEncoding.DecodedLen(recoveryCodeChars)+1)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(totpEncoding.EncodeToString(b))[:recoveryCodeChars]
+	return code[:recoveryCodeChars/2] + "-" + code[recoveryCodeChars/2:], nil
+}
+
+// URI creates the otpauth:// key uri understood by authenticator apps.
+func (t *TOTP) URI(secret string, account string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", t.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod.Seconds())))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + t.issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+// QRCode renders a key uri as a PNG image.
+func (t *TOTP) QRCode(uri string) ([]byte, error) {
+	return qrcode.Encode(uri, qrcode.Medium, totpQRCodeSize)
+}
+
+// Validate checks a code against the secret at the given time.
+// It returns the time step the code belongs to, so callers can reject a step used before.
+func (t *TOTP) Validate(secret string, code string, at time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := at.Unix() / int64(totpPeriod.Seconds())
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(generateTOTPCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// generateTOTPCode computes the code of a time step as described in RFC 4226 section 5.3.
+func generateTOTPCode(key []byte, step int64) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", totpDigits, value%mod)
+}
@@ -0,0 +1,113 @@
+package security
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TOTPTestSuite struct {
+	suite.Suite
+}
+
+func TestTOTPSuite(t *testing.T) {
+	suite.Run(t, new(TOTPTestSuite))
+}
+
+// rfcSecret is the SHA-1 seed "12345678901234567890" of the RFC 6238 test vectors, base32 encoded.
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func (s *TOTPTestSuite) TestValidate() {
+	totp := NewTOTP("Taskit")
+
+	s.Run("it should accept the RFC 6238 test vectors", func() {
+		vectors := map[int64]string{
+			59:         "287082",
+			1111111109: "081804",
+			1111111111: "050471",
+			1234567890: "005924",
+			2000000000: "279037",
+		}
+		for unix, code := range vectors {
+			step, ok := totp.Validate(rfcSecret, code, time.Unix(unix, 0))
+
+			s.True(ok)
+			s.Equal(unix/30, step)
+		}
+	})
+
+	s.Run("it should accept a code from the previous and next period", func() {
+		step, ok := totp.Validate(rfcSecret, "287082", time.Unix(59+30, 0))
+		s.True(ok)
+		s.Equal(int64(1), step)
+
+		step, ok = totp.Validate(rfcSecret, "287082", time.Unix(59-30, 0))
+		s.True(ok)
+		s.Equal(int64(1), step)
+	})
+
+	s.Run("it should reject a code outside the skew window", func() {
+		_, ok := totp.Validate(rfcSecret, "287082", time.Unix(59+60, 0))
+		s.False(ok)
+	})
+
+	s.Run("it should reject a wrong, malformed or undecodable code", func() {
+		_, ok := totp.Validate(rfcSecret, "000000", time.Unix(59, 0))
+		s.False(ok)
+
+		_, ok = totp.Validate(rfcSecret, "28708", time.Unix(59, 0))
+		s.False(ok)
+
+		_, ok = totp.Validate("not base32!", "287082", time.Unix(59, 0))
+		s.False(ok)
+	})
+}
+
+func (s *TOTPTestSuite) TestGenerateSecret() {
+	totp := NewTOTP("Taskit")
+
+	secret, err := totp.GenerateSecret()
+	s.NoError(err)
+	s.Len(secret, 32)
+
+	other, err := totp.GenerateSecret()
+	s.NoError(err)
+	s.NotEqual(secret, other)
+}
+
+func (s *TOTPTestSuite) TestGenerateRecoveryCode() {
+	totp := NewTOTP("Taskit")
+
+	code, err := totp.GenerateRecoveryCode()
+	s.NoError(err)
+	s.Regexp(`^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+
+	other, err := totp.GenerateRecoveryCode()
+	s.NoError(err)
+	s.NotEqual(code, other)
+}
+
+func (s *TOTPTestSuite) TestURI() {
+	totp := NewTOTP("Taskit")
+
+	uri, err := url.Parse(totp.URI(rfcSecret, "gopher@go.dev"))
+	s.NoError(err)
+	s.Equal("otpauth", uri.Scheme)
+	s.Equal("totp", uri.Host)
+	s.Equal("/Taskit:gopher@go.dev", uri.Path)
+	s.Equal(rfcSecret, uri.Query().Get("secret"))
+	s.Equal("Taskit", uri.Query().Get("issuer"))
+	s.Equal("6", uri.Query().Get("digits"))
+	s.Equal("30", uri.Query().Get("period"))
+}
+
+func (s *TOTPTestSuite) TestQRCode() {
+	totp := NewTOTP("Taskit")
+
+	png, err := totp.QRCode(totp.URI(rfcSecret, "gopher@go.dev"))
+	s.NoError(err)
+	s.True(bytes.HasPrefix(png, []byte("\x89PNG")))
+}
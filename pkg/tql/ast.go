@@ -0,0 +1,121 @@
+package tql
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Node represents a node of a query syntax tree.
+type Node interface {
+	String() string
+	node()
+}
+
+// Logical represents two conditions joined with And or Or.
+type Logical struct {
+	Op    Kind
+	Left  Node
+	Right Node
+}
+
+// Negation represents a negated condition.
+type Negation struct {
+	Expr Node
+}
+
+// Comparison represents a comparison between a field and a value.
+type Comparison struct {
+	Field string
+	Op    Kind
+	Value Value
+}
+
+// Search represents a bare string that matches the content or the description of a task.
+type Search struct {
+	Text string
+}
+
+func (*Logical) node()    {}
+func (*Negation) node()   {}
+func (*Comparison) node() {}
+func (*Search) node()     {}
+
+// String print the node with as few parentheses as needed to parse back into an equivalent tree.
+func (n *Logical) String() string {
+	left := n.Left.String()
+	if l, ok := n.Left.(*Logical); ok && l.Op == Or && n.Op == And {
+		left = "(" + left + ")"
+	}
+	right := n.Right.String()
+	if _, ok := n.Right.(*Logical); ok {
+		right = "(" + right + ")"
+	}
+	return left + " " + n.Op.String() + " " + right
+}
+
+func (n *Negation) String() string {
+	if _, ok := n.Expr.(*Logical); ok {
+		return "not (" + n.Expr.String() + ")"
+	}
+	return "not " + n.Expr.String()
+}
+
+func (n *Comparison) String() string {
+	return n.Field + " " + n.Op.String() + " " + n.Value.String()
+}
+
+func (n *Search) String() string {
+	return quote(n.Text)
+}
+
+// ValueKind represents the kind of a value.
+type ValueKind int
+
+const (
+	StringValue ValueKind = iota
+	BoolValue
+	NullValue
+	DateValue
+)
+
+// Value represents the right hand side of a comparison.
+// A date value is an absolute date (YYYY-MM-DD) or a relative anchor shifted by an optional offset.
+type Value struct {
+	Kind   ValueKind
+	Text   string
+	Bool   bool
+	Anchor string
+	Offset int
+	Unit   byte
+}
+
+func (v Value) String() string {
+	switch v.Kind {
+	case StringValue:
+		return quote(v.Text)
+	case BoolValue:
+		return strconv.FormatBool(v.Bool)
+	case NullValue:
+		return "null"
+	}
+	if v.Unit == 0 {
+		return v.Anchor
+	}
+	sign := "+"
+	if v.Offset < 0 {
+		sign = "-"
+	}
+	return v.Anchor + sign + strconv.Itoa(abs(v.Offset)) + string(v.Unit)
+}
+
+func quote(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return `"` + replacer.Replace(text) + `"`
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
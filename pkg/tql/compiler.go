@@ -0,0 +1,246 @@
+package tql
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// columns map queryable fields to their columns, it is the only source of identifiers in the compiled SQL.
+var columns = map[string][]string{
+	"text":         {"content", "description"},
+	"content":      {"content"},
+	"description":  {"description"},
+	"due_date":     {"due_date"},
+	"start_date":   {"start_date"},
+	"created_at":   {"created_at"},
+	"completed_at": {"completed_at"},
+	"is_completed": {"is_completed"},
+}
+
+// Options configure the compilation of a query.
+type Options struct {
+	// Now is the current time, its location is used to resolve dates.
+	Now time.Time
+	// Offset is the number of parameters already used by the surrounding statement.
+	Offset int
+}
+
+// Compile parse and compile a query into a parameterized SQL condition.
+func Compile(input string, opts Options) (string, []any, error) {
+	node, err := Parse(input)
+	if err != nil {
+		return "", nil, err
+	}
+	return CompileNode(node, opts)
+}
+
+// CompileNode compile a syntax tree into a parameterized SQL condition.
+// Values are always passed as parameters, a nil node match everything.
+func CompileNode(node Node, opts Options) (string, []any, error) {
+	if node == nil {
+		return "TRUE", nil, nil
+	}
+	c := compiler{opts: opts}
+	condition, err := c.compile(node)
+	if err != nil {
+		return "", nil, err
+	}
+	return condition, c.args, nil
+}
+
+type compiler struct {
+	opts Options
+	args []any
+}
+
+// param add a parameter and return its placeholder.
+func (c *compiler) param(value any) string {
+	c.args = append(c.args, value)
+	return "$" + strconv.Itoa(c.opts.Offset+len(c.args))
+}
+
+func (c *compiler) compile(node Node) (string, error) {
+	switch n := node.(type) {
+	case *Logical:
+		left, err := c.compile(n.Left)
+		if err != nil {
+			return "", err
+		}
+		right, err := c.compile(n.Right)
+		if err != nil {
+			return "", err
+		}
+		switch n.Op {
+		case And:
+			return "(" + left + " AND " + right + ")", nil
+		case Or:
+			return "(" + left + " OR " + right + ")", nil
+		}
+		return "", errorf(0, "invalid logical operator %s", n.Op)
+	case *Negation:
+		expr, err := c.compile(n.Expr)
+		if err != nil {
+			return "", err
+		}
+		return "(NOT " + expr + ")", nil
+	case *Search:
+		return c.text(columns["text"], Contains, n.Text), nil
+	case *Comparison:
+		return c.comparison(n)
+	}
+	return "", errorf(0, "invalid node %T", node)
+}
+
+func (c *compiler) comparison(n *Comparison) (string, error) {
+	fieldType, ok := Fields[n.Field]
+	if !ok {
+		return "", errorf(0, "unknown field %q", n.Field)
+	}
+	if !contains(operators[fieldType], n.Op) {
+		return "", errorf(0, "operator %s is not supported by %s", n.Op, n.Field)
+	}
+
+	switch {
+	case fieldType == TextField && n.Value.Kind == StringValue:
+		return c.text(columns[n.Field], n.Op, n.Value.Text), nil
+	case fieldType == BoolField && n.Value.Kind == BoolValue:
+		return c.bool(columns[n.Field][0], n.Op, n.Value.Bool), nil
+	case fieldType == DateField && n.Value.Kind == NullValue:
+		return c.null(columns[n.Field][0], n.Op)
+	case fieldType == DateField && n.Value.Kind == DateValue:
+		return c.date(columns[n.Field][0], n.Op, n.Value)
+	}
+	return "", errorf(0, "invalid value %s for %s", n.Value, n.Field)
+}
+
+// text compile a text comparison, contains matches are case-insensitive.
+func (c *compiler) text(cols []string, op Kind, text string) string {
+	var placeholder, operator string
+	switch op {
+	case Eq, NotEq:
+		placeholder, operator = c.param(text), " = "
+	default:
+		placeholder, operator = c.param("%"+escapeLike(text)+"%"), " ILIKE "
+	}
+
+	conditions := make([]string, len(cols))
+	for i, col := range cols {
+		conditions[i] = col + operator + placeholder
+	}
+	condition := "(" + strings.Join(conditions, " OR ") + ")"
+	if op == NotEq || op == NotContains {
+		return "(NOT " + condition + ")"
+	}
+	return condition
+}
+
+func (c *compiler) bool(col string, op Kind, value bool) string {
+	if op == NotEq {
+		return "(" + col + " <> " + c.param(value) + ")"
+	}
+	return "(" + col + " = " + c.param(value) + ")"
+}
+
+func (c *compiler) null(col string, op Kind) (string, error) {
+	switch op {
+	case Eq:
+		return "(" + col + " IS NULL)", nil
+	case NotEq:
+		return "(" + col + " IS NOT NULL)", nil
+	}
+	return "", errorf(0, "null can only be compared with = or !=")
+}
+
+// date compile a date comparison. Values relative to now are compared as instants,
+// every other value covers a whole day so due_date = today matches any time of today.
+func (c *compiler) date(col string, op Kind, value Value) (string, error) {
+	start, ok := c.resolve(value)
+	if !ok {
+		return "", errorf(0, "invalid date %s", value)
+	}
+
+	if value.Anchor == "now" {
+		switch op {
+		case Eq:
+			return "(" + col + " = " + c.param(start) + ")", nil
+		case NotEq:
+			return "(" + col + " <> " + c.param(start) + ")", nil
+		case Lt:
+			return "(" + col + " < " + c.param(start) + ")", nil
+		case LtEq:
+			return "(" + col + " <= " + c.param(start) + ")", nil
+		case Gt:
+			return "(" + col + " > " + c.param(start) + ")", nil
+		case GtEq:
+			return "(" + col + " >= " + c.param(start) + ")", nil
+		}
+		return "", errorf(0, "invalid date operator %s", op)
+	}
+
+	end := start.AddDate(0, 0, 1)
+	switch op {
+	case Eq:
+		return "(" + col + " >= " + c.param(start) + " AND " + col + " < " + c.param(end) + ")", nil
+	case NotEq:
+		return "(" + col + " < " + c.param(start) + " OR " + col + " >= " + c.param(end) + ")", nil
+	case Lt:
+		return "(" + col + " < " + c.param(start) + ")", nil
+	case LtEq:
+		return "(" + col + " < " + c.param(end) + ")", nil
+	case Gt:
+		return "(" + col + " >= " + c.param(end) + ")", nil
+	case GtEq:
+		return "(" + col + " >= " + c.param(start) + ")", nil
+	}
+	return "", errorf(0, "invalid date operator %s", op)
+}
+
+// resolve turn a date value into a time in UTC.
+func (c *compiler) resolve(value Value) (time.Time, bool) {
+	now := c.opts.Now
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	var t time.Time
+	switch value.Anchor {
+	case "now":
+		t = now
+	case "today":
+		t = today
+	case "tomorrow":
+		t = today.AddDate(0, 0, 1)
+	case "yesterday":
+		t = today.AddDate(0, 0, -1)
+	case "week_start":
+		t = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	case "month_start":
+		t = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	default:
+		date, err := time.ParseInLocation(DateLayout, value.Anchor, now.Location())
+		if err != nil {
+			return time.Time{}, false
+		}
+		t = date
+	}
+
+	switch value.Unit {
+	case 0:
+	case 'd':
+		t = t.AddDate(0, 0, value.Offset)
+	case 'w':
+		t = t.AddDate(0, 0, 7*value.Offset)
+	case 'm':
+		t = t.AddDate(0, value.Offset, 0)
+	case 'y':
+		t = t.AddDate(value.Offset, 0, 0)
+	default:
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
+
+// escapeLike escape the wildcard characters of a LIKE pattern using the default backslash escape.
+func escapeLike(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(text)
+}
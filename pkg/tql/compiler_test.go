@@ -0,0 +1,164 @@
+package tql
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CompilerTestSuite struct {
+	suite.Suite
+}
+
+func TestCompilerSuite(t *testing.T) {
+	suite.Run(t, new(CompilerTestSuite))
+}
+
+func (s *CompilerTestSuite) TestCompile() {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+	// Wednesday, 2026-01-07 10:30 in Jakarta.
+	now := time.Date(2026, 1, 7, 10, 30, 0, 0, jakarta)
+	day := func(d int) time.Time {
+		return time.Date(2026, 1, d, 0, 0, 0, 0, jakarta).UTC()
+	}
+
+	tests := []struct {
+		name         string
+		input        string
+		expectedSQL  string
+		expectedArgs []any
+		err          error
+	}{
+		{
+			name:         "it should match everything when query is empty",
+			input:        "",
+			expectedSQL:  "TRUE",
+			expectedArgs: nil,
+		},
+		{
+			name:         "it should compile overdue or due this week, not done, containing invoice",
+			input:        `(due_date < today or due_date < week_start+1w) and is_completed = false and "invoice"`,
+			expectedSQL:  "((((due_date < $2) OR (due_date < $3)) AND (is_completed = $4)) AND (content ILIKE $5 OR description ILIKE $5))",
+			expectedArgs: []any{day(7), day(12), false, "%invoice%"},
+		},
+		{
+			name:         "it should compile day comparisons to whole day ranges",
+			input:        "due_date = tomorrow or due_date != yesterday or due_date <= 2026-01-31 or due_date > month_start",
+			expectedSQL:  "((((due_date >= $2 AND due_date < $3) OR (due_date < $4 OR due_date >= $5)) OR (due_date < $6)) OR (due_date >= $7))",
+			expectedArgs: []any{day(8), day(9), day(6), day(7), time.Date(2026, 2, 1, 0, 0, 0, 0, jakarta).UTC(), day(2)},
+		},
+		{
+			name:         "it should compile now comparisons to instants",
+			input:        "completed_at >= now-1d",
+			expectedSQL:  "(completed_at >= $2)",
+			expectedArgs: []any{now.AddDate(0, 0, -1).UTC()},
+		},
+		{
+			name:         "it should compile snoozed tasks query",
+			input:        "start_date > now",
+			expectedSQL:  "(start_date > $2)",
+			expectedArgs: []any{now.UTC()},
+		},
+		{
+			name:         "it should compile null and negated text comparisons",
+			input:        `due_date = null and not content = "a" and description !~ "50%_off\\"`,
+			expectedSQL:  `(((due_date IS NULL) AND (NOT (content = $2))) AND (NOT (description ILIKE $3)))`,
+			expectedArgs: []any{"a", `%50\%\_off\\%`},
+		},
+		{
+			name:         "it should keep injection attempts inside parameters",
+			input:        `text = "'; DROP TABLE tasks; --" or is_completed != true`,
+			expectedSQL:  "((content = $2 OR description = $2) OR (is_completed <> $3))",
+			expectedArgs: []any{"'; DROP TABLE tasks; --", true},
+		},
+		{
+			name:  "it should return error when query is invalid",
+			input: "due_date <",
+			err:   &Error{Pos: 10, Msg: "expected a date but found end of query"},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			sql, args, err := Compile(test.input, Options{Now: now, Offset: 1})
+
+			s.Equal(test.err, err)
+			s.Equal(test.expectedSQL, sql)
+			s.Equal(test.expectedArgs, args)
+		})
+	}
+}
+
+func (s *CompilerTestSuite) TestCompileNode() {
+	tests := []struct {
+		name  string
+		input Node
+		err   error
+	}{
+		{name: "it should return error when field is unknown", input: &Comparison{Field: "user_id", Op: Eq, Value: Value{Kind: StringValue}}, err: &Error{Msg: `unknown field "user_id"`}},
+		{name: "it should return error when operator is not supported", input: &Comparison{Field: "content", Op: Lt, Value: Value{Kind: StringValue}}, err: &Error{Msg: "operator < is not supported by content"}},
+		{name: "it should return error when value does not match field", input: &Comparison{Field: "is_completed", Op: Eq, Value: Value{Kind: StringValue, Text: "x"}}, err: &Error{Msg: `invalid value "x" for is_completed`}},
+		{name: "it should return error when date is invalid", input: &Comparison{Field: "due_date", Op: Eq, Value: Value{Kind: DateValue, Anchor: "due_date"}}, err: &Error{Msg: "invalid date due_date"}},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			_, _, err := CompileNode(test.input, Options{Now: time.Now()})
+
+			s.Equal(test.err, err)
+		})
+	}
+}
+
+// safeSQL matches compiled conditions made only of whitelisted columns, keywords, operators and placeholders.
+var safeSQL = regexp.MustCompile(`^(?:content|description|due_date|start_date|created_at|completed_at|is_completed|TRUE|AND|OR|NOT|ILIKE|IS|NULL|\$[0-9]+|<>|<=|>=|[<>=()]| )*$`)
+
+var placeholder = regexp.MustCompile(`\$([0-9]+)`)
+
+func FuzzCompile(f *testing.F) {
+	seeds := []string{
+		"",
+		`"invoice"`,
+		`(due_date < today or due_date < week_start+1w) and is_completed = false and text ~ "invoice"`,
+		`not (content = 'say "hi"' or created_at >= 2026-01-31-1m) description !~ "50%_off"`,
+		`due_date = null or completed_at > now-12d`,
+		`text = "'; DROP TABLE tasks; --"`,
+		`content ~ "\\" or content = '\''`,
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	now := time.Date(2026, 1, 7, 10, 30, 0, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, input string) {
+		node, err := Parse(input)
+		if err != nil || node == nil {
+			return
+		}
+
+		sql, args, err := CompileNode(node, Options{Now: now, Offset: 1})
+		if err != nil {
+			t.Fatalf("parsed query %q failed to compile: %v", input, err)
+		}
+		if !safeSQL.MatchString(sql) {
+			t.Fatalf("query %q compiled to unsafe sql %q", input, sql)
+		}
+		for _, match := range placeholder.FindAllStringSubmatch(sql, -1) {
+			if n, _ := strconv.Atoi(match[1]); n < 2 || n > len(args)+1 {
+				t.Fatalf("query %q compiled to out of range placeholder %s", input, match[0])
+			}
+		}
+
+		printed := node.String()
+		reparsed, err := Parse(printed)
+		if err != nil {
+			t.Fatalf("printed query %q of %q failed to parse: %v", printed, input, err)
+		}
+		if reparsed.String() != printed {
+			t.Fatalf("printed query %q of %q is not stable: %q", printed, input, reparsed.String())
+		}
+	})
+}
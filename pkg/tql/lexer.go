@@ -0,0 +1,163 @@
+package tql
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Lex split a query into tokens, the last token is always EOF.
+func Lex(input string) ([]Token, error) {
+	l := lexer{input: input}
+	var tokens []Token
+	for {
+		token, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+		if token.Kind == EOF {
+			return tokens, nil
+		}
+	}
+}
+
+type lexer struct {
+	input string
+	pos   int
+}
+
+func (l *lexer) next() (Token, error) {
+	for l.pos < len(l.input) && isSpace(l.input[l.pos]) {
+		l.pos++
+	}
+	if l.pos >= len(l.input) {
+		return Token{Kind: EOF, Pos: l.pos}, nil
+	}
+
+	start := l.pos
+	c := l.input[l.pos]
+	switch {
+	case c == '(':
+		l.pos++
+		return Token{Kind: LParen, Value: "(", Pos: start}, nil
+	case c == ')':
+		l.pos++
+		return Token{Kind: RParen, Value: ")", Pos: start}, nil
+	case c == '+':
+		l.pos++
+		return Token{Kind: Plus, Value: "+", Pos: start}, nil
+	case c == '-':
+		l.pos++
+		return Token{Kind: Minus, Value: "-", Pos: start}, nil
+	case c == '~':
+		l.pos++
+		return Token{Kind: Contains, Value: "~", Pos: start}, nil
+	case c == '=':
+		l.pos++
+		return Token{Kind: Eq, Value: "=", Pos: start}, nil
+	case c == '!':
+		return l.operator(start, map[byte]Kind{'=': NotEq, '~': NotContains}, 0)
+	case c == '<':
+		return l.operator(start, map[byte]Kind{'=': LtEq}, Lt)
+	case c == '>':
+		return l.operator(start, map[byte]Kind{'=': GtEq}, Gt)
+	case c == '"' || c == '\'':
+		return l.string(start, c)
+	case isDigit(c):
+		return l.number(start)
+	case isLetter(c):
+		for l.pos < len(l.input) && (isLetter(l.input[l.pos]) || isDigit(l.input[l.pos])) {
+			l.pos++
+		}
+		value := l.input[start:l.pos]
+		if kind, ok := keywords[strings.ToLower(value)]; ok {
+			return Token{Kind: kind, Value: strings.ToLower(value), Pos: start}, nil
+		}
+		return Token{Kind: Ident, Value: value, Pos: start}, nil
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.input[l.pos:])
+	return Token{}, errorf(start, "unexpected character %q", r)
+}
+
+// operator lex a one or two characters operator, single is EOF when the first character alone is invalid.
+func (l *lexer) operator(start int, double map[byte]Kind, single Kind) (Token, error) {
+	l.pos++
+	if l.pos < len(l.input) {
+		if kind, ok := double[l.input[l.pos]]; ok {
+			l.pos++
+			return Token{Kind: kind, Value: l.input[start:l.pos], Pos: start}, nil
+		}
+	}
+	if single == EOF {
+		return Token{}, errorf(start, "unexpected character %q", l.input[start])
+	}
+	return Token{Kind: single, Value: l.input[start:l.pos], Pos: start}, nil
+}
+
+// string lex a quoted string, the quote character can be escaped with a backslash.
+func (l *lexer) string(start int, quote byte) (Token, error) {
+	l.pos++
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case c == quote:
+			l.pos++
+			return Token{Kind: String, Value: b.String(), Pos: start}, nil
+		case c == '\\' && l.pos+1 < len(l.input):
+			b.WriteByte(l.input[l.pos+1])
+			l.pos += 2
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return Token{}, errorf(start, "unterminated string")
+}
+
+// number lex a date (YYYY-MM-DD) or a duration (7d, 2w, 1m, 1y).
+func (l *lexer) number(start int) (Token, error) {
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.pos++
+	}
+
+	if l.pos-start == 4 && l.pos < len(l.input) && l.input[l.pos] == '-' {
+		for part := 0; part < 2; part++ {
+			if l.pos >= len(l.input) || l.input[l.pos] != '-' {
+				return Token{}, errorf(start, "invalid date, expected YYYY-MM-DD")
+			}
+			l.pos++
+			digits := l.pos
+			for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+				l.pos++
+			}
+			if l.pos == digits {
+				return Token{}, errorf(start, "invalid date, expected YYYY-MM-DD")
+			}
+		}
+		return Token{Kind: Date, Value: l.input[start:l.pos], Pos: start}, nil
+	}
+
+	if l.pos < len(l.input) && strings.IndexByte(durationUnits, l.input[l.pos]) >= 0 {
+		l.pos++
+		if l.pos >= len(l.input) || !(isLetter(l.input[l.pos]) || isDigit(l.input[l.pos])) {
+			return Token{Kind: Duration, Value: l.input[start:l.pos], Pos: start}, nil
+		}
+	}
+	return Token{}, errorf(start, "invalid number, expected a date like 2026-01-31 or a duration like 7d")
+}
+
+const durationUnits = "dwmy"
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
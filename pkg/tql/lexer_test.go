@@ -0,0 +1,100 @@
+package tql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LexerTestSuite struct {
+	suite.Suite
+}
+
+func TestLexerSuite(t *testing.T) {
+	suite.Run(t, new(LexerTestSuite))
+}
+
+func (s *LexerTestSuite) TestLex() {
+	tests := []struct {
+		name     string
+		input    string
+		expected []Token
+		err      error
+	}{
+		{
+			name:     "it should return only EOF when input is empty",
+			input:    "  ",
+			expected: []Token{{Kind: EOF, Pos: 2}},
+		},
+		{
+			name:  "it should return tokens of a comparison with relative date",
+			input: "due_date <= today+7d",
+			expected: []Token{
+				{Kind: Ident, Value: "due_date", Pos: 0},
+				{Kind: LtEq, Value: "<=", Pos: 9},
+				{Kind: Ident, Value: "today", Pos: 12},
+				{Kind: Plus, Value: "+", Pos: 17},
+				{Kind: Duration, Value: "7d", Pos: 18},
+				{Kind: EOF, Pos: 20},
+			},
+		},
+		{
+			name:  "it should return tokens of keywords case-insensitively",
+			input: `NOT (is_completed != true) Or text !~ 'a\'b'`,
+			expected: []Token{
+				{Kind: Not, Value: "not", Pos: 0},
+				{Kind: LParen, Value: "(", Pos: 4},
+				{Kind: Ident, Value: "is_completed", Pos: 5},
+				{Kind: NotEq, Value: "!=", Pos: 18},
+				{Kind: Ident, Value: "true", Pos: 21},
+				{Kind: RParen, Value: ")", Pos: 25},
+				{Kind: Or, Value: "or", Pos: 27},
+				{Kind: Ident, Value: "text", Pos: 30},
+				{Kind: NotContains, Value: "!~", Pos: 35},
+				{Kind: String, Value: "a'b", Pos: 38},
+				{Kind: EOF, Pos: 44},
+			},
+		},
+		{
+			name:  "it should return date token",
+			input: "created_at>2026-01-31",
+			expected: []Token{
+				{Kind: Ident, Value: "created_at", Pos: 0},
+				{Kind: Gt, Value: ">", Pos: 10},
+				{Kind: Date, Value: "2026-01-31", Pos: 11},
+				{Kind: EOF, Pos: 21},
+			},
+		},
+		{
+			name:  "it should return error when string is not terminated",
+			input: `text ~ "invoice`,
+			err:   &Error{Pos: 7, Msg: "unterminated string"},
+		},
+		{
+			name:  "it should return error when character is unexpected",
+			input: "text ; drop",
+			err:   &Error{Pos: 5, Msg: `unexpected character ';'`},
+		},
+		{
+			name:  "it should return error when bang is not followed by = or ~",
+			input: "!text",
+			err:   &Error{Pos: 0, Msg: `unexpected character '!'`},
+		},
+		{
+			name:  "it should return error when number is not a date or a duration",
+			input: "today+7x",
+			err:   &Error{Pos: 6, Msg: "invalid number, expected a date like 2026-01-31 or a duration like 7d"},
+		},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			tokens, err := Lex(test.input)
+
+			s.Equal(test.err, err)
+			if test.err == nil {
+				s.Equal(test.expected, tokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+package tql
+
+import (
+	"strconv"
+	"time"
+)
+
+// FieldType represents the type of a queryable field.
+type FieldType int
+
+const (
+	TextField FieldType = iota
+	DateField
+	BoolField
+)
+
+// Fields list the queryable fields, "text" matches the content or the description.
+var Fields = map[string]FieldType{
+	"text":         TextField,
+	"content":      TextField,
+	"description":  TextField,
+	"due_date":     DateField,
+	"start_date":   DateField,
+	"created_at":   DateField,
+	"completed_at": DateField,
+	"is_completed": BoolField,
+}
+
+// Anchors list the relative dates, they are resolved at compile time.
+var Anchors = map[string]bool{
+	"now":         true,
+	"today":       true,
+	"tomorrow":    true,
+	"yesterday":   true,
+	"week_start":  true,
+	"month_start": true,
+}
+
+const (
+	// DateLayout is the layout of absolute dates.
+	DateLayout = "2006-01-02"
+
+	maxDepth  = 32
+	maxOffset = 9999
+)
+
+var operators = map[FieldType][]Kind{
+	TextField: {Eq, NotEq, Contains, NotContains},
+	DateField: {Eq, NotEq, Lt, LtEq, Gt, GtEq},
+	BoolField: {Eq, NotEq},
+}
+
+// Parse parse a query into a syntax tree, an empty query return a nil node.
+//
+//	query      = [ or ] EOF
+//	or         = and { "or" and }
+//	and        = unary { [ "and" ] unary }
+//	unary      = "not" unary | "(" or ")" | string | comparison
+//	comparison = field operator value
+//	value      = string | "true" | "false" | "null" | date [ offset ] | anchor [ offset ]
+//	offset     = ( "+" | "-" ) duration
+func Parse(input string) (Node, error) {
+	tokens, err := Lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens}
+	if p.peek().Kind == EOF {
+		return nil, nil
+	}
+	node, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+	if token := p.peek(); token.Kind != EOF {
+		return nil, errorf(token.Pos, "unexpected %s", describe(token))
+	}
+	return node, nil
+}
+
+type parser struct {
+	tokens []Token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() Token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() Token {
+	token := p.tokens[p.pos]
+	if token.Kind != EOF {
+		p.pos++
+	}
+	return token
+}
+
+func (p *parser) or() (Node, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().Kind == Or {
+		p.advance()
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: Or, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) and() (Node, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek().Kind {
+		case And:
+			p.advance()
+		case Not, LParen, String, Ident:
+			// conditions next to each other are implicitly joined with and.
+		default:
+			return left, nil
+		}
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Logical{Op: And, Left: left, Right: right}
+	}
+}
+
+func (p *parser) unary() (Node, error) {
+	p.depth++
+	defer func() { p.depth-- }()
+	if p.depth > maxDepth {
+		return nil, errorf(p.peek().Pos, "query is nested too deeply")
+	}
+
+	token := p.advance()
+	switch token.Kind {
+	case Not:
+		expr, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return &Negation{Expr: expr}, nil
+	case LParen:
+		expr, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.advance(); closing.Kind != RParen {
+			return nil, errorf(closing.Pos, "expected ) but found %s", describe(closing))
+		}
+		return expr, nil
+	case String:
+		return &Search{Text: token.Value}, nil
+	case Ident:
+		return p.comparison(token)
+	}
+	return nil, errorf(token.Pos, "expected a condition but found %s", describe(token))
+}
+
+func (p *parser) comparison(field Token) (Node, error) {
+	fieldType, ok := Fields[field.Value]
+	if !ok {
+		return nil, errorf(field.Pos, "unknown field %q", field.Value)
+	}
+
+	op := p.advance()
+	if !contains(operators[fieldType], op.Kind) {
+		return nil, errorf(op.Pos, "operator %s is not supported by %s", describe(op), field.Value)
+	}
+
+	value, err := p.value(fieldType, op.Kind)
+	if err != nil {
+		return nil, err
+	}
+	return &Comparison{Field: field.Value, Op: op.Kind, Value: value}, nil
+}
+
+func (p *parser) value(fieldType FieldType, op Kind) (Value, error) {
+	token := p.advance()
+	switch fieldType {
+	case TextField:
+		if token.Kind == String {
+			return Value{Kind: StringValue, Text: token.Value}, nil
+		}
+		return Value{}, errorf(token.Pos, "expected a string but found %s", describe(token))
+	case BoolField:
+		if token.Kind == Ident && (token.Value == "true" || token.Value == "false") {
+			return Value{Kind: BoolValue, Bool: token.Value == "true"}, nil
+		}
+		return Value{}, errorf(token.Pos, "expected true or false but found %s", describe(token))
+	}
+
+	switch {
+	case token.Kind == Ident && token.Value == "null":
+		if op != Eq && op != NotEq {
+			return Value{}, errorf(token.Pos, "null can only be compared with = or !=")
+		}
+		return Value{Kind: NullValue}, nil
+	case token.Kind == Ident && Anchors[token.Value]:
+	case token.Kind == Date:
+		if _, err := time.Parse(DateLayout, token.Value); err != nil {
+			return Value{}, errorf(token.Pos, "invalid date %q, expected YYYY-MM-DD", token.Value)
+		}
+	default:
+		return Value{}, errorf(token.Pos, "expected a date but found %s", describe(token))
+	}
+
+	value := Value{Kind: DateValue, Anchor: token.Value}
+	if sign := p.peek().Kind; sign == Plus || sign == Minus {
+		p.advance()
+		duration := p.advance()
+		if duration.Kind != Duration {
+			return Value{}, errorf(duration.Pos, "expected a duration like 7d but found %s", describe(duration))
+		}
+		amount, err := strconv.Atoi(duration.Value[:len(duration.Value)-1])
+		if err != nil || amount > maxOffset {
+			return Value{}, errorf(duration.Pos, "duration %q is too large", duration.Value)
+		}
+		if sign == Minus {
+			amount = -amount
+		}
+		value.Offset = amount
+		value.Unit = duration.Value[len(duration.Value)-1]
+	}
+	return value, nil
+}
+
+func contains(kinds []Kind, kind Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(token Token) string {
+	switch token.Kind {
+	case EOF:
+		return "end of query"
+	case String:
+		return quote(token.Value)
+	}
+	return strconv.Quote(token.Value)
+}
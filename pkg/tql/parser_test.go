@@ -0,0 +1,118 @@
+package tql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ParserTestSuite struct {
+	suite.Suite
+}
+
+func TestParserSuite(t *testing.T) {
+	suite.Run(t, new(ParserTestSuite))
+}
+
+func (s *ParserTestSuite) TestParse() {
+	tests := []struct {
+		name     string
+		input    string
+		expected Node
+		err      error
+	}{
+		{
+			name:     "it should return nil node when query is empty",
+			input:    "",
+			expected: nil,
+		},
+		{
+			name:     "it should return search node when query is a bare string",
+			input:    `"invoice"`,
+			expected: &Search{Text: "invoice"},
+		},
+		{
+			name:  "it should bind and tighter than or",
+			input: `is_completed = false or due_date < today and text ~ "invoice"`,
+			expected: &Logical{
+				Op:   Or,
+				Left: &Comparison{Field: "is_completed", Op: Eq, Value: Value{Kind: BoolValue, Bool: false}},
+				Right: &Logical{
+					Op:    And,
+					Left:  &Comparison{Field: "due_date", Op: Lt, Value: Value{Kind: DateValue, Anchor: "today"}},
+					Right: &Comparison{Field: "text", Op: Contains, Value: Value{Kind: StringValue, Text: "invoice"}},
+				},
+			},
+		},
+		{
+			name:  "it should join adjacent conditions with and and respect parentheses",
+			input: `(due_date < today or due_date <= today+1w) not is_completed = true "invoice"`,
+			expected: &Logical{
+				Op: And,
+				Left: &Logical{
+					Op: And,
+					Left: &Logical{
+						Op:    Or,
+						Left:  &Comparison{Field: "due_date", Op: Lt, Value: Value{Kind: DateValue, Anchor: "today"}},
+						Right: &Comparison{Field: "due_date", Op: LtEq, Value: Value{Kind: DateValue, Anchor: "today", Offset: 1, Unit: 'w'}},
+					},
+					Right: &Negation{Expr: &Comparison{Field: "is_completed", Op: Eq, Value: Value{Kind: BoolValue, Bool: true}}},
+				},
+				Right: &Search{Text: "invoice"},
+			},
+		},
+		{
+			name:     "it should parse absolute date with negative offset",
+			input:    "created_at >= 2026-01-31-1m",
+			expected: &Comparison{Field: "created_at", Op: GtEq, Value: Value{Kind: DateValue, Anchor: "2026-01-31", Offset: -1, Unit: 'm'}},
+		},
+		{
+			name:     "it should parse null comparison",
+			input:    "due_date != null",
+			expected: &Comparison{Field: "due_date", Op: NotEq, Value: Value{Kind: NullValue}},
+		},
+		{name: "it should return error when field is unknown", input: "user_id = 'x'", err: &Error{Pos: 0, Msg: `unknown field "user_id"`}},
+		{name: "it should return error when operator is not supported by field", input: "is_completed < true", err: &Error{Pos: 13, Msg: `operator "<" is not supported by is_completed`}},
+		{name: "it should return error when text value is not a string", input: "content = today", err: &Error{Pos: 10, Msg: `expected a string but found "today"`}},
+		{name: "it should return error when bool value is invalid", input: "is_completed = yes", err: &Error{Pos: 15, Msg: `expected true or false but found "yes"`}},
+		{name: "it should return error when date value is invalid", input: "due_date < someday", err: &Error{Pos: 11, Msg: `expected a date but found "someday"`}},
+		{name: "it should return error when date does not exist", input: "due_date < 2026-02-30", err: &Error{Pos: 11, Msg: `invalid date "2026-02-30", expected YYYY-MM-DD`}},
+		{name: "it should return error when null is compared with order", input: "due_date < null", err: &Error{Pos: 11, Msg: "null can only be compared with = or !="}},
+		{name: "it should return error when offset is not a duration", input: "due_date < today + 'x'", err: &Error{Pos: 19, Msg: `expected a duration like 7d but found "x"`}},
+		{name: "it should return error when offset is too large", input: "due_date < today+99999y", err: &Error{Pos: 17, Msg: `duration "99999y" is too large`}},
+		{name: "it should return error when parenthesis is not closed", input: "(is_completed = true", err: &Error{Pos: 20, Msg: "expected ) but found end of query"}},
+		{name: "it should return error when token is unexpected", input: "is_completed = true )", err: &Error{Pos: 20, Msg: `unexpected ")"`}},
+		{name: "it should return error when condition is missing", input: "is_completed = true and", err: &Error{Pos: 23, Msg: "expected a condition but found end of query"}},
+		{name: "it should return error when query is nested too deeply", input: "((((((((((((((((((((((((((((((((((is_completed = true))))))))))))))))))))))))))))))))))", err: &Error{Pos: 32, Msg: "query is nested too deeply"}},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			node, err := Parse(test.input)
+
+			s.Equal(test.err, err)
+			s.Equal(test.expected, node)
+		})
+	}
+}
+
+func (s *ParserTestSuite) TestString() {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "it should keep parentheses of or inside and", input: `(text ~ "a" or text ~ "b") and is_completed = false`, expected: `(text ~ "a" or text ~ "b") and is_completed = false`},
+		{name: "it should drop redundant parentheses", input: `((is_completed = true)) and (due_date = today)`, expected: `is_completed = true and due_date = today`},
+		{name: "it should print negation and escaped strings", input: `not (content = 'say "hi"' or due_date < today-2d)`, expected: `not (content = "say \"hi\"" or due_date < today-2d)`},
+	}
+
+	for _, test := range tests {
+		s.Run(test.name, func() {
+			node, err := Parse(test.input)
+
+			s.NoError(err)
+			s.Equal(test.expected, node.String())
+		})
+	}
+}
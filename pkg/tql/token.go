@@ -0,0 +1,73 @@
+package tql
+
+import "fmt"
+
+// Kind represents the kind of a token.
+type Kind int
+
+const (
+	EOF Kind = iota
+	Ident
+	String
+	Date
+	Duration
+	LParen
+	RParen
+	Plus
+	Minus
+	Eq
+	NotEq
+	Lt
+	LtEq
+	Gt
+	GtEq
+	Contains
+	NotContains
+	And
+	Or
+	Not
+)
+
+var kindNames = map[Kind]string{
+	EOF:         "end of query",
+	Ident:       "identifier",
+	String:      "string",
+	Date:        "date",
+	Duration:    "duration",
+	LParen:      "(",
+	RParen:      ")",
+	Plus:        "+",
+	Minus:       "-",
+	Eq:          "=",
+	NotEq:       "!=",
+	Lt:          "<",
+	LtEq:        "<=",
+	Gt:          ">",
+	GtEq:        ">=",
+	Contains:    "~",
+	NotContains: "!~",
+	And:         "and",
+	Or:          "or",
+	Not:         "not",
+}
+
+func (k Kind) String() string {
+	if name, ok := kindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("kind(%d)", int(k))
+}
+
+// Token represents a lexical token of a query.
+type Token struct {
+	Kind  Kind
+	Value string
+	Pos   int
+}
+
+// keywords are matched case-insensitively.
+var keywords = map[string]Kind{
+	"and": And,
+	"or":  Or,
+	"not": Not,
+}
@@ -28,6 +28,11 @@ func InjectAuthContext(r *http.Request, userID entity.UserID) *http.Request {
 	return r.WithContext(context.WithValue(r.Context(), entity.AuthUserIDKey, userID))
 }
 
+// InjectAuthSessionContext injects the session ID into the request context.
+func InjectAuthSessionContext(r *http.Request, sessionID entity.AuthID) *http.Request {
+	return r.WithContext(context.WithValue(r.Context(), entity.AuthSessionIDKey, sessionID))
+}
+
 // InjectChiRouterParams injects the chi router params into the request context.
 func InjectChiRouterParams(r *http.Request, params map[string]string) *http.Request {
 	rctx := chi.NewRouteContext()